@@ -1,8 +1,8 @@
 package theoddsapi
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,118 +12,422 @@ import (
 	"sync"
 	"time"
 
+	"github.com/XavierBriggs/Mercury/internal/bookskip"
+	"github.com/XavierBriggs/Mercury/internal/conditional"
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
 	"github.com/XavierBriggs/Mercury/pkg/contracts"
 	"github.com/XavierBriggs/Mercury/pkg/models"
 )
 
 const (
-	baseURL     = "https://api.the-odds-api.com"
+	defaultBaseURL = "https://api.the-odds-api.com"
 	apiVersion  = "v4"
 	userAgent   = "Mercury/1.0 (Fortuna Odds Aggregator)"
 	timeout     = 10 * time.Second
 	maxRetries  = 3
 	retryDelay  = 2 * time.Second
+	vendorName  = "theoddsapi" // Vendor recorded on archived payloads
 )
 
+// VendorName identifies The Odds API on archived payloads and quarantined records
+const VendorName = vendorName
+
 // Client implements the VendorAdapter interface for The Odds API
 type Client struct {
-	apiKey     string
-	httpClient *http.Client
-	rateLimits *models.RateLimits
-	mu         sync.RWMutex
+	keys         *keyPool // API keys requests rotate across
+	baseURL      string // Vendor origin (default https://api.the-odds-api.com)
+	httpClient   *http.Client
+	rateLimits   *models.RateLimits
+	oddsFormat   models.OddsFormat // Format requested from the API (american or decimal)
+	includeLinks bool              // Request bet deep links (includeLinks=true)
+	includeLimit bool              // Request max bet limits (includeBetLimits=true, exchanges/sharps only)
+	archiver     contracts.PayloadArchiver // Optional raw payload archive (nil = disabled)
+	quarantineSink contracts.QuarantineSink // Optional sink for records rejected by the parser (nil = discard)
+	usageSink    contracts.UsageSink // Optional sink for per-request credit usage (nil = discard)
+	teamNames    *normalize.Registry // Team name aliases applied while parsing (nil = names as sent)
+	bookCache    *bookskip.Cache // Committed bookmaker last_update values; unchanged books are skipped (nil = parse all)
+	validators   *conditional.Cache // Committed response validators; odds requests are sent conditionally (nil = never)
+	marketChecks MarketChecks // Two-sided consistency checks on each bookmaker's markets
+	mu           sync.RWMutex
 }
 
-// Ensure Client implements VendorAdapter
-var _ contracts.VendorAdapter = (*Client)(nil)
+// Ensure Client implements VendorAdapter, FuturesAdapter and ParticipantsAdapter
+var (
+	_ contracts.VendorAdapter       = (*Client)(nil)
+	_ contracts.FuturesAdapter      = (*Client)(nil)
+	_ contracts.ParticipantsAdapter = (*Client)(nil)
+	_ contracts.PayloadParser       = (*Client)(nil)
+)
+
+// Option customizes a Client at construction
+type Option func(*Client)
 
-// NewClient creates a new The Odds API client
-func NewClient(apiKey string) *Client {
-	return &Client{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
+// WithBaseURL points the client at another origin (an httptest server, or a proxy
+// that forwards to The Odds API). Paths such as /v4/sports/... are appended to it.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithHTTPClient replaces the HTTP client (custom transport, proxy or timeout)
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// NewClient creates a new The Odds API client. apiKey may list several keys,
+// comma-separated: requests rotate across them and fail over when one is refused
+func NewClient(apiKey string, opts ...Option) *Client {
+	c := &Client{
+		keys:    newKeyPool(apiKey),
+		baseURL: defaultBaseURL,
+		httpClient: NewHTTPClient(DefaultHTTPConfig()),
 		rateLimits: &models.RateLimits{
 			RequestsRemaining: 500, // Default quota
 			RequestsUsed:      0,
 		},
+		oddsFormat: models.OddsFormatAmerican,
+		marketChecks: DefaultMarketChecks(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetAPIKey replaces the API key (or comma-separated keys) used by subsequent
+// requests, e.g. after rotation. Keys that remain keep their usage
+func (c *Client) SetAPIKey(apiKey string) {
+	c.keys.set(apiKey)
+}
+
+// KeyUsage returns each configured API key's requests, failures and last reported
+// quota, in configuration order
+func (c *Client) KeyUsage() []models.KeyUsage {
+	return c.keys.usage()
+}
+
+// SetIncludeLinks enables requesting bookmaker deep links with odds
+func (c *Client) SetIncludeLinks(include bool) {
+	c.includeLinks = include
+}
+
+// SetIncludeBetLimits enables requesting max bet limits for books that expose them
+func (c *Client) SetIncludeBetLimits(include bool) {
+	c.includeLimit = include
+}
+
+// SetPayloadArchiver archives every raw response body before it is parsed
+func (c *Client) SetPayloadArchiver(archiver contracts.PayloadArchiver) {
+	c.archiver = archiver
+}
+
+// SetQuarantineSink routes records the parser rejects (missing fields, malformed
+// timestamps, absurd prices, duplicate outcomes) to sink instead of discarding them
+func (c *Client) SetQuarantineSink(sink contracts.QuarantineSink) {
+	c.quarantineSink = sink
+}
+
+// SetUsageSink reports the credit cost of every request (including failed ones) to sink
+func (c *Client) SetUsageSink(sink contracts.UsageSink) {
+	c.usageSink = sink
+}
+
+// SetTeamNames maps vendor team spellings to canonical names on parsed events and
+// on outcomes that name a team
+func (c *Client) SetTeamNames(registry *normalize.Registry) {
+	c.teamNames = registry
+}
+
+// SetBookmakerCache skips bookmakers whose last_update has not advanced past the
+// value committed to cache. Archived payloads are always parsed in full
+func (c *Client) SetBookmakerCache(cache *bookskip.Cache) {
+	c.bookCache = cache
+}
+
+// SetValidatorCache sends odds requests conditionally (If-None-Match,
+// If-Modified-Since) with the validators committed for the same request
+func (c *Client) SetValidatorCache(cache *conditional.Cache) {
+	c.validators = cache
+}
+
+// SetMarketChecks configures the pass that quarantines lines missing a side or priced
+// with an implausible margin
+func (c *Client) SetMarketChecks(checks MarketChecks) {
+	c.marketChecks = checks
+}
+
+// SetOddsFormat sets the price format requested from the API
+// The Odds API quotes american or decimal natively; fractional is derived by consumers
+func (c *Client) SetOddsFormat(format models.OddsFormat) error {
+	if format != models.OddsFormatAmerican && format != models.OddsFormatDecimal {
+		return fmt.Errorf("odds format %q not supported by The Odds API", format)
+	}
+	c.oddsFormat = format
+	return nil
+}
+
+// setBookFilter requests specific bookmakers when configured, otherwise whole regions
+// The vendor lets bookmakers override regions and bills every 10 books as one region
+func setBookFilter(params url.Values, regions, bookmakers []string) {
+	if len(bookmakers) > 0 {
+		params.Set("bookmakers", strings.Join(bookmakers, ","))
+		return
+	}
+	params.Set("regions", strings.Join(regions, ","))
+}
+
+// setCommenceWindow limits a request to events commencing in [from, to] (zero = unbounded)
+func setCommenceWindow(params url.Values, from, to time.Time) {
+	if !from.IsZero() {
+		params.Set("commenceTimeFrom", timeutil.FormatVendorTime(from))
+	}
+	if !to.IsZero() {
+		params.Set("commenceTimeTo", timeutil.FormatVendorTime(to))
 	}
 }
 
 // FetchOdds retrieves featured market odds (h2h, spreads, totals)
 func (c *Client) FetchOdds(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
-	endpoint := fmt.Sprintf("%s/%s/sports/%s/odds", baseURL, apiVersion, opts.Sport)
+	endpoint := fmt.Sprintf("%s/%s/sports/%s/odds", c.baseURL, apiVersion, opts.Sport)
 
 	params := url.Values{}
-	params.Set("apiKey", c.apiKey)
-	params.Set("regions", strings.Join(opts.Regions, ","))
+	params.Set("apiKey", RedactedAPIKey)
+	setBookFilter(params, opts.Regions, opts.Bookmakers)
+	setCommenceWindow(params, opts.CommenceTimeFrom, opts.CommenceTimeTo)
+	if len(opts.EventIDs) > 0 {
+		params.Set("eventIds", strings.Join(opts.EventIDs, ","))
+	}
 	params.Set("markets", strings.Join(opts.Markets, ","))
-	params.Set("oddsFormat", "american")
+	params.Set("oddsFormat", string(c.oddsFormat))
 	params.Set("dateFormat", "iso")
+	c.setLinkParam(params)
+	if c.includeLimit {
+		params.Set("includeBetLimits", "true")
+	}
 
 	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
-	body, err := c.doRequestWithRetry(ctx, fullURL)
+	ref := payloadRef{kind: models.PayloadKindOdds, sport: opts.Sport, conditional: true}
+	result, err := c.fetchOdds(ctx, fullURL, ref, func(r io.Reader, receivedAt time.Time) (*models.FetchResult, error) {
+		result, err := c.decodeOdds(r, c.oddsFormat, receivedAt, c.bookCache)
+		if err != nil {
+			return nil, fmt.Errorf("parse odds response: %w", err)
+		}
+		return result, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch odds failed: %w", err)
 	}
 
-	var apiResp []oddsResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("parse odds response: %w", err)
-	}
-
-	return c.parseOddsResponse(apiResp, time.Now()), nil
+	return result, nil
 }
 
 // FetchEventOdds retrieves event-specific odds (for props markets)
 func (c *Client) FetchEventOdds(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error) {
-	endpoint := fmt.Sprintf("%s/%s/sports/%s/events/%s/odds", baseURL, apiVersion, opts.Sport, opts.EventID)
+	endpoint := fmt.Sprintf("%s/%s/sports/%s/events/%s/odds", c.baseURL, apiVersion, opts.Sport, opts.EventID)
 
 	params := url.Values{}
-	params.Set("apiKey", c.apiKey)
-	params.Set("regions", strings.Join(opts.Regions, ","))
+	params.Set("apiKey", RedactedAPIKey)
+	setBookFilter(params, opts.Regions, opts.Bookmakers)
 	params.Set("markets", strings.Join(opts.Markets, ","))
-	params.Set("oddsFormat", "american")
+	params.Set("oddsFormat", string(c.oddsFormat))
 	params.Set("dateFormat", "iso")
+	c.setLinkParam(params)
+	if c.includeLimit {
+		params.Set("includeBetLimits", "true")
+	}
 
 	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
-	body, err := c.doRequestWithRetry(ctx, fullURL)
+	// Single event response
+	ref := payloadRef{kind: models.PayloadKindEventOdds, sport: opts.Sport, eventID: opts.EventID, conditional: true}
+	result, err := c.fetchOdds(ctx, fullURL, ref, func(r io.Reader, receivedAt time.Time) (*models.FetchResult, error) {
+		result, err := c.decodeEventOdds(r, c.oddsFormat, receivedAt, c.bookCache)
+		if err != nil {
+			return nil, fmt.Errorf("parse event odds response: %w", err)
+		}
+		return result, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch event odds failed: %w", err)
 	}
 
-	// Single event response
-	var apiResp oddsResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("parse event odds response: %w", err)
-	}
-
-	return c.parseOddsResponse([]oddsResponse{apiResp}, time.Now()), nil
+	return result, nil
 }
 
 // FetchEvents retrieves upcoming events without odds (for discovery)
-func (c *Client) FetchEvents(ctx context.Context, sport string) ([]models.Event, error) {
-	endpoint := fmt.Sprintf("%s/%s/sports/%s/events", baseURL, apiVersion, sport)
+func (c *Client) FetchEvents(ctx context.Context, opts *models.FetchEventsOptions) ([]models.Event, error) {
+	sport := opts.Sport
+	endpoint := fmt.Sprintf("%s/%s/sports/%s/events", c.baseURL, apiVersion, sport)
 
 	params := url.Values{}
-	params.Set("apiKey", c.apiKey)
+	params.Set("apiKey", RedactedAPIKey)
 	params.Set("dateFormat", "iso")
+	setCommenceWindow(params, opts.CommenceTimeFrom, opts.CommenceTimeTo)
 
 	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
 
-	body, err := c.doRequestWithRetry(ctx, fullURL)
+	var events []models.Event
+	err := c.fetchStream(ctx, fullURL, payloadRef{kind: models.PayloadKindEvents, sport: sport}, func(r io.Reader, receivedAt time.Time) error {
+		var err error
+		if events, err = c.decodeEvents(r, receivedAt); err != nil {
+			return fmt.Errorf("parse events response: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("fetch events failed: %w", err)
 	}
 
-	var apiResp []eventResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return nil, fmt.Errorf("parse events response: %w", err)
+	return events, nil
+}
+
+// FetchFutures retrieves futures/outright odds (championship winner, etc.)
+// Outrights are listed under their own vendor sport key rather than the parent sport
+func (c *Client) FetchFutures(ctx context.Context, opts *models.FetchFuturesOptions) ([]models.FuturesOdds, error) {
+	endpoint := fmt.Sprintf("%s/%s/sports/%s/odds", c.baseURL, apiVersion, opts.FuturesKey)
+
+	params := url.Values{}
+	params.Set("apiKey", RedactedAPIKey)
+	setBookFilter(params, opts.Regions, opts.Bookmakers)
+	params.Set("markets", "outrights")
+	params.Set("oddsFormat", string(c.oddsFormat))
+	params.Set("dateFormat", "iso")
+
+	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
+
+	var odds []models.FuturesOdds
+	err := c.fetchStream(ctx, fullURL, payloadRef{kind: models.PayloadKindFutures, sport: opts.Sport}, func(r io.Reader, receivedAt time.Time) error {
+		var err error
+		if odds, err = c.decodeFutures(r, opts.Sport, receivedAt); err != nil {
+			return fmt.Errorf("parse futures response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch futures failed: %w", err)
+	}
+
+	return odds, nil
+}
+
+// FetchParticipants retrieves the teams (or players) the vendor lists for a sport
+// Names are canonicalized like event teams so they match stored events
+func (c *Client) FetchParticipants(ctx context.Context, sport string) ([]models.Participant, error) {
+	endpoint := fmt.Sprintf("%s/%s/sports/%s/participants", c.baseURL, apiVersion, sport)
+
+	params := url.Values{}
+	params.Set("apiKey", RedactedAPIKey)
+
+	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
+
+	var participants []models.Participant
+	err := c.fetchStream(ctx, fullURL, payloadRef{kind: models.PayloadKindParticipants, sport: sport}, func(r io.Reader, receivedAt time.Time) error {
+		var err error
+		if participants, err = c.decodeParticipants(r, sport); err != nil {
+			return fmt.Errorf("parse participants response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch participants failed: %w", err)
+	}
+
+	return participants, nil
+}
+
+// FetchScores retrieves game state for a sport's live and upcoming games, plus games
+// completed in the last daysFrom days (1-3; 0 leaves completed games out). The vendor
+// bills 2 credits with daysFrom and 1 without
+func (c *Client) FetchScores(ctx context.Context, sport string, daysFrom int) ([]models.EventScore, error) {
+	endpoint := fmt.Sprintf("%s/%s/sports/%s/scores", c.baseURL, apiVersion, sport)
+
+	params := url.Values{}
+	params.Set("apiKey", RedactedAPIKey)
+	params.Set("dateFormat", "iso")
+	if daysFrom > 0 {
+		params.Set("daysFrom", strconv.Itoa(daysFrom))
+	}
+
+	fullURL := fmt.Sprintf("%s?%s", endpoint, params.Encode())
+
+	var scores []models.EventScore
+	err := c.fetchStream(ctx, fullURL, payloadRef{kind: models.PayloadKindScores, sport: sport}, func(r io.Reader, receivedAt time.Time) error {
+		var err error
+		if scores, err = c.decodeScores(r, receivedAt); err != nil {
+			return fmt.Errorf("parse scores response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetch scores failed: %w", err)
+	}
+
+	return scores, nil
+}
+
+// ParsePayload re-parses an archived odds, event-odds or events payload
+// Futures payloads are not supported (they feed the futures table, not the odds pipeline)
+func (c *Client) ParsePayload(payload models.RawPayload) (*models.FetchResult, error) {
+	switch payload.Kind {
+	case models.PayloadKindOdds:
+		result, err := c.decodeOdds(bytes.NewReader(payload.Body), payloadFormat(payload), payload.ReceivedAt, nil)
+		if err != nil {
+			return nil, fmt.Errorf("parse odds response: %w", err)
+		}
+		return result, nil
+
+	case models.PayloadKindEventOdds:
+		result, err := c.decodeEventOdds(bytes.NewReader(payload.Body), payloadFormat(payload), payload.ReceivedAt, nil)
+		if err != nil {
+			return nil, fmt.Errorf("parse event odds response: %w", err)
+		}
+		return result, nil
+
+	case models.PayloadKindEvents:
+		events, err := c.decodeEvents(bytes.NewReader(payload.Body), payload.ReceivedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse events response: %w", err)
+		}
+		return &models.FetchResult{Events: events}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported payload kind: %q", payload.Kind)
+	}
+}
+
+// payloadFormat returns the odds format an archived payload was requested in
+func payloadFormat(payload models.RawPayload) models.OddsFormat {
+	if payload.OddsFormat == "" {
+		return models.OddsFormatAmerican
 	}
+	return payload.OddsFormat
+}
 
-	return c.parseEventsResponse(apiResp), nil
+// archive hands a raw response body to the payload archiver, if one is set
+func (c *Client) archive(kind models.PayloadKind, sport, eventID, request string, body []byte, receivedAt time.Time) {
+	if c.archiver == nil {
+		return
+	}
+
+	c.archiver.Archive(models.RawPayload{
+		Vendor:     vendorName,
+		Kind:       kind,
+		Sport:      sport,
+		EventID:    eventID,
+		OddsFormat: c.oddsFormat,
+		Request:    request,
+		ReceivedAt: receivedAt,
+		Body:       body,
+	})
 }
 
 // SupportsMarket checks if this adapter supports a given market
@@ -147,6 +451,19 @@ func (c *Client) SupportsMarket(market string) bool {
 		"player_turnovers":               true,
 		"player_double_double":           true,
 		"player_triple_double":           true,
+		// Game props
+		"team_totals":           true,
+		"alternate_team_totals": true,
+		"alternate_spreads":     true,
+		"alternate_totals":      true,
+		"h2h_q1":                true,
+		"spreads_q1":            true,
+		"totals_q1":             true,
+		"h2h_h1":                true,
+		"spreads_h1":            true,
+		"totals_h1":             true,
+		// Futures
+		"outrights": true,
 	}
 	return supportedMarkets[market]
 }
@@ -154,74 +471,30 @@ func (c *Client) SupportsMarket(market string) bool {
 // GetRateLimits returns current rate limit information
 func (c *Client) GetRateLimits() *models.RateLimits {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return c.rateLimits
-}
-
-// doRequestWithRetry performs HTTP request with retry logic
-func (c *Client) doRequestWithRetry(ctx context.Context, fullURL string) ([]byte, error) {
-	var lastErr error
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff
-			backoff := retryDelay * time.Duration(1<<uint(attempt-1))
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(backoff):
-			}
-		}
-
-		body, err := c.doRequest(ctx, fullURL)
-		if err == nil {
-			return body, nil
-		}
-
-		lastErr = err
+	limits := *c.rateLimits
+	c.mu.RUnlock()
 
-		// Don't retry on client errors (4xx except 429)
-		if httpErr, ok := err.(*httpError); ok {
-			if httpErr.StatusCode >= 400 && httpErr.StatusCode < 500 && httpErr.StatusCode != 429 {
-				return nil, err
-			}
-		}
+	keys := c.keys.usage()
+	if len(keys) < 2 {
+		return &limits
 	}
 
-	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
-}
-
-// doRequest performs a single HTTP request
-func (c *Client) doRequest(ctx context.Context, fullURL string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", userAgent)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Update rate limits from headers
-	c.updateRateLimits(resp.Header)
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, &httpError{
-			StatusCode: resp.StatusCode,
-			Message:    string(body),
+	// Several keys: the quota is what they have between them
+	remaining, used, seen := 0, 0, false
+	for _, key := range keys {
+		if key.Remaining < 0 {
+			continue
 		}
+		remaining += key.Remaining
+		used += max(key.Used, 0)
+		seen = true
 	}
-
-	return body, nil
+	if seen {
+		limits.RequestsRemaining = remaining
+		limits.RequestsUsed = used
+	}
+	limits.Keys = keys
+	return &limits
 }
 
 // updateRateLimits extracts rate limit info from response headers
@@ -240,61 +513,97 @@ func (c *Client) updateRateLimits(headers http.Header) {
 			c.rateLimits.RequestsUsed = val
 		}
 	}
+
+	if last := headers.Get("x-requests-last"); last != "" {
+		if val, err := strconv.Atoi(last); err == nil {
+			c.rateLimits.RequestsLast = val
+		}
+	}
 }
 
-// parseOddsResponse converts API response to internal FetchResult with events and odds
-func (c *Client) parseOddsResponse(apiResp []oddsResponse, receivedAt time.Time) *models.FetchResult {
-	var allOdds []models.RawOdds
-	var allEvents []models.Event
-	seenEvents := make(map[string]bool)
+// recordUsage reports one response's credit headers to the usage sink, if one is set
+func (c *Client) recordUsage(ref payloadRef, fullURL string, resp *http.Response) {
+	if c.usageSink == nil {
+		return
+	}
 
-	for _, event := range apiResp {
-		// Parse event commence time once per event
-		commenceTime, err := time.Parse(time.RFC3339, event.CommenceTime)
-		if err != nil {
-			commenceTime = receivedAt // Fallback
+	var markets []string
+	if u, err := url.Parse(fullURL); err == nil {
+		if value := u.Query().Get("markets"); value != "" {
+			markets = strings.Split(value, ",")
 		}
+	}
 
-		// Extract event (deduplicate by ID)
-		if !seenEvents[event.ID] {
-			// Determine if game is live based on commence_time
-			eventStatus := "upcoming"
-			if time.Now().After(commenceTime) {
-				eventStatus = "live"
-			}
-			
-			allEvents = append(allEvents, models.Event{
-				EventID:      event.ID,
-				SportKey:     event.SportKey,
-				HomeTeam:     event.HomeTeam,
-				AwayTeam:     event.AwayTeam,
-				CommenceTime: commenceTime,
-				EventStatus:  eventStatus,
-			})
-			seenEvents[event.ID] = true
+	c.usageSink.RecordUsage(models.VendorUsage{
+		Vendor:      vendorName,
+		Kind:        ref.kind,
+		SportKey:    ref.sport,
+		EventID:     ref.eventID,
+		Markets:     markets,
+		StatusCode:  resp.StatusCode,
+		Cost:        headerInt(resp.Header, "x-requests-last"),
+		Used:        headerInt(resp.Header, "x-requests-used"),
+		Remaining:   headerInt(resp.Header, "x-requests-remaining"),
+		RequestedAt: timeutil.Now(),
+	})
+}
+
+// headerInt parses an integer header, returning -1 when it is missing or malformed
+func headerInt(headers http.Header, key string) int {
+	val, err := strconv.Atoi(headers.Get(key))
+	if err != nil {
+		return -1
+	}
+	return val
+}
+
+// API response structures matching The Odds API JSON format
+
+type oddsResponse struct {
+	ID           string       `json:"id"`
+	SportKey     string       `json:"sport_key"`
+	SportTitle   string       `json:"sport_title"`
+	CommenceTime string       `json:"commence_time"`
+	HomeTeam     string       `json:"home_team"`
+	AwayTeam     string       `json:"away_team"`
+	Bookmakers   []bookmaker  `json:"bookmakers"`
+}
+
+// parseFuturesResponse converts an outrights response to FuturesOdds
+func (c *Client) parseFuturesResponse(apiResp []oddsResponse, sportKey string, receivedAt time.Time) []models.FuturesOdds {
+	var allOdds []models.FuturesOdds
+
+	for _, event := range apiResp {
+		var expiresAt *time.Time
+		if t, err := timeutil.ParseVendorTime(event.CommenceTime); err == nil {
+			expiresAt = &t
 		}
 
-		// Extract odds
 		for _, bookmaker := range event.Bookmakers {
-			vendorUpdate, err := time.Parse(time.RFC3339, bookmaker.LastUpdate)
-			if err != nil {
-				vendorUpdate = receivedAt
-			}
+			vendorUpdate := timeutil.ParseVendorTimeOr(bookmaker.LastUpdate, receivedAt)
 
 			for _, market := range bookmaker.Markets {
+				marketUpdate := timeutil.ParseVendorTimeOr(market.LastUpdate, vendorUpdate)
+
 				for _, outcome := range market.Outcomes {
-					odd := models.RawOdds{
-						EventID:          event.ID,
-						SportKey:         event.SportKey,
+					american, decimal, err := models.NormalizePrice(c.oddsFormat, outcome.Price)
+					if err != nil {
+						continue // Skip malformed prices rather than storing a bogus line
+					}
+
+					odd := models.FuturesOdds{
+						SportKey:         sportKey,
+						FuturesKey:       event.SportKey,
 						MarketKey:        market.Key,
 						BookKey:          bookmaker.Key,
-						OutcomeName:      outcome.Name,
-						Price:            outcome.Price,
-						VendorLastUpdate: vendorUpdate,
+						OutcomeName:      c.teamNames.TeamName(sportKey, outcome.Name),
+						Price:            american,
+						DecimalPrice:     decimal,
+						ExpiresAt:        expiresAt,
+						VendorLastUpdate: marketUpdate,
 						ReceivedAt:       receivedAt,
 					}
 
-					// Add point for spreads/totals
 					if outcome.Point != nil {
 						point := *outcome.Point
 						odd.Point = &point
@@ -306,80 +615,50 @@ func (c *Client) parseOddsResponse(apiResp []oddsResponse, receivedAt time.Time)
 		}
 	}
 
-	return &models.FetchResult{
-		Events: allEvents,
-		Odds:   allOdds,
-	}
+	return allOdds
 }
 
-// parseEventsResponse converts API response to internal Event format
-func (c *Client) parseEventsResponse(apiResp []eventResponse) []models.Event {
-	events := make([]models.Event, 0, len(apiResp))
-
-	for _, evt := range apiResp {
-		commenceTime, err := time.Parse(time.RFC3339, evt.CommenceTime)
-		if err != nil {
-			continue // Skip invalid events
-		}
-
-		// Determine if game is live based on commence_time
-		eventStatus := "upcoming"
-		if time.Now().After(commenceTime) {
-			eventStatus = "live"
-		}
-
-		events = append(events, models.Event{
-			EventID:      evt.ID,
-			SportKey:     evt.SportKey,
-			HomeTeam:     evt.HomeTeam,
-			AwayTeam:     evt.AwayTeam,
-			CommenceTime: commenceTime,
-			EventStatus:  eventStatus,
-		})
+// setLinkParam asks for bet deep links when enabled, on featured and event (props)
+// odds requests alike
+func (c *Client) setLinkParam(params url.Values) {
+	if c.includeLinks {
+		params.Set("includeLinks", "true")
 	}
-
-	return events
-}
-
-// httpError represents an HTTP error with status code
-type httpError struct {
-	StatusCode int
-	Message    string
-}
-
-func (e *httpError) Error() string {
-	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
 }
 
-// API response structures matching The Odds API JSON format
-
-type oddsResponse struct {
-	ID           string       `json:"id"`
-	SportKey     string       `json:"sport_key"`
-	SportTitle   string       `json:"sport_title"`
-	CommenceTime string       `json:"commence_time"`
-	HomeTeam     string       `json:"home_team"`
-	AwayTeam     string       `json:"away_team"`
-	Bookmakers   []bookmaker  `json:"bookmakers"`
+// firstLink returns the most specific non-empty deep link: outcome, then market,
+// then bookmaker
+func firstLink(links ...string) string {
+	for _, link := range links {
+		if link != "" {
+			return link
+		}
+	}
+	return ""
 }
 
 type bookmaker struct {
 	Key        string   `json:"key"`
 	Title      string   `json:"title"`
 	LastUpdate string   `json:"last_update"`
+	Link       string   `json:"link,omitempty"`
 	Markets    []market `json:"markets"`
 }
 
 type market struct {
 	Key        string    `json:"key"`
 	LastUpdate string    `json:"last_update"`
+	Link       string    `json:"link,omitempty"`
 	Outcomes   []outcome `json:"outcomes"`
 }
 
 type outcome struct {
-	Name  string   `json:"name"`
-	Price int      `json:"price"`
-	Point *float64 `json:"point,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"` // Player name on props outcomes
+	Price       float64  `json:"price"`                 // American or decimal depending on oddsFormat
+	Point       *float64 `json:"point,omitempty"`
+	Link        string   `json:"link,omitempty"`      // Bet slip deep link (includeLinks=true)
+	BetLimit    *float64 `json:"bet_limit,omitempty"` // Max bet (includeBetLimits=true)
 }
 
 type eventResponse struct {
@@ -391,3 +670,24 @@ type eventResponse struct {
 	AwayTeam     string `json:"away_team"`
 }
 
+type scoresResponse struct {
+	ID           string      `json:"id"`
+	SportKey     string      `json:"sport_key"`
+	CommenceTime string      `json:"commence_time"`
+	Completed    bool        `json:"completed"`
+	HomeTeam     string      `json:"home_team"`
+	AwayTeam     string      `json:"away_team"`
+	Scores       []teamScore `json:"scores"` // null until the game starts
+	LastUpdate   *string     `json:"last_update"`
+}
+
+type teamScore struct {
+	Name  string `json:"name"`
+	Score string `json:"score"`
+}
+
+type participantResponse struct {
+	ID       string `json:"id"`
+	FullName string `json:"full_name"`
+}
+
@@ -0,0 +1,168 @@
+package theoddsapi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Default bounds on a line's summed implied probability (1 + the book's margin)
+const (
+	DefaultMinImplied = 0.97 // Below: the book is an arbitrage against itself
+	DefaultMaxImplied = 1.35 // Above: a stale or mistyped side
+)
+
+// MarketChecks configures the two-sided consistency pass over each bookmaker's market
+type MarketChecks struct {
+	Enabled    bool
+	MinImplied float64 // Lowest accepted sum of implied probabilities of a line's sides
+	MaxImplied float64 // Highest accepted sum
+}
+
+// DefaultMarketChecks returns the checks applied by a new client
+func DefaultMarketChecks() MarketChecks {
+	return MarketChecks{Enabled: true, MinImplied: DefaultMinImplied, MaxImplied: DefaultMaxImplied}
+}
+
+// marketLine is the set of outcomes that together make up one two-way (or three-way)
+// line of a market, e.g. Over and Under 220.5, or home -3.5 and away +3.5
+type marketLine struct {
+	label string
+	odds  []int // Indexes into the market's odds
+}
+
+// lineKey groups an outcome with the other sides of its line. Outcomes that are not
+// part of a two-sided line (Yes/No, player names, unresolved teams) are not grouped
+func lineKey(odd models.RawOdds) (string, bool) {
+	point := "-"
+	switch {
+	case odd.OutcomeName == normalize.TotalOver || odd.OutcomeName == normalize.TotalUnder:
+		if odd.Point != nil {
+			point = fmt.Sprint(*odd.Point)
+		}
+		return "total\x1f" + odd.Description + "\x1f" + point, true
+	case odd.Side != "":
+		if odd.Point != nil {
+			point = fmt.Sprint(math.Abs(*odd.Point))
+		}
+		return "side\x1f" + odd.Description + "\x1f" + point, true
+	case odd.OutcomeName == "Draw" && odd.Point == nil && teamSided(odd.MarketKey):
+		return "side\x1f" + odd.Description + "\x1f-", true
+	}
+	return "", false
+}
+
+// checkMarket validates the lines of one bookmaker's market: every line must carry
+// both of its sides, spread sides must mirror each other, and the implied
+// probabilities must sum within bounds. It returns the odds of the lines that pass,
+// in their original order, and a quarantine reason and detail per failed line
+func (m MarketChecks) checkMarket(odds []models.RawOdds) ([]models.RawOdds, []models.QuarantinedRecord) {
+	if !m.Enabled || len(odds) == 0 {
+		return odds, nil
+	}
+
+	lines := make(map[string]*marketLine)
+	var keys []string
+	for i, odd := range odds {
+		key, ok := lineKey(odd)
+		if !ok {
+			continue
+		}
+		line, seen := lines[key]
+		if !seen {
+			line = &marketLine{label: lineLabel(odd)}
+			lines[key] = line
+			keys = append(keys, key)
+		}
+		line.odds = append(line.odds, i)
+	}
+	sort.Strings(keys)
+
+	var failed []models.QuarantinedRecord
+	drop := make(map[int]bool)
+	for _, key := range keys {
+		line := lines[key]
+		reason, detail := m.checkLine(odds, line)
+		if reason == "" {
+			continue
+		}
+		for _, i := range line.odds {
+			drop[i] = true
+		}
+		failed = append(failed, models.QuarantinedRecord{Reason: reason, Detail: line.label + ": " + detail})
+	}
+	if len(drop) == 0 {
+		return odds, nil
+	}
+
+	kept := make([]models.RawOdds, 0, len(odds)-len(drop))
+	for i, odd := range odds {
+		if !drop[i] {
+			kept = append(kept, odd)
+		}
+	}
+	return kept, failed
+}
+
+// checkLine returns why a line is inconsistent, or "" when it is sound
+func (m MarketChecks) checkLine(odds []models.RawOdds, line *marketLine) (models.QuarantineReason, string) {
+	have := make(map[string]*models.RawOdds)
+	implied := 0.0
+	var prices []string
+	for _, i := range line.odds {
+		odd := &odds[i]
+		side := odd.Side
+		if side == "" {
+			side = odd.OutcomeName
+		}
+		have[side] = odd
+		implied += 1 / odd.DecimalPrice
+		prices = append(prices, fmt.Sprintf("%s %d", odd.OutcomeName, odd.Price))
+	}
+
+	paired := false
+	pairs := [][2]string{{normalize.TotalOver, normalize.TotalUnder}, {normalize.SideHome, normalize.SideAway}}
+	for _, pair := range pairs {
+		first, second := have[pair[0]], have[pair[1]]
+		if first == nil && second == nil {
+			continue
+		}
+		paired = true
+		if first == nil || second == nil {
+			return models.QuarantineOneSided, fmt.Sprintf("only %s quoted", strings.Join(prices, ", "))
+		}
+		if first.Side != "" && first.Point != nil && second.Point != nil && *first.Point != -*second.Point {
+			return models.QuarantineBadPoint, fmt.Sprintf("%s %v does not mirror %s %v",
+				first.OutcomeName, *first.Point, second.OutcomeName, *second.Point)
+		}
+	}
+
+	if !paired {
+		return "", "" // A lone Draw whose teams did not resolve to sides
+	}
+	if implied < m.MinImplied || implied > m.MaxImplied {
+		return models.QuarantineBadVig, fmt.Sprintf("implied total %.3f outside [%.2f, %.2f] (%s)",
+			implied, m.MinImplied, m.MaxImplied, strings.Join(prices, ", "))
+	}
+	return "", ""
+}
+
+// lineLabel names a line in quarantine detail ("LeBron James 25.5", "3.5")
+func lineLabel(odd models.RawOdds) string {
+	label := "line"
+	if odd.Point != nil {
+		point := *odd.Point
+		if odd.Side != "" {
+			point = math.Abs(point)
+		}
+		label = fmt.Sprint(point)
+	}
+	if odd.Description != "" {
+		label = odd.Description + " " + label
+	}
+	return label
+}
@@ -0,0 +1,212 @@
+package theoddsapi
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// keyCooldown is how long a key the vendor refused (used-up quota or 401) is skipped
+// before it is tried again. Quotas reset monthly, so an exhausted key is probed
+// once per cooldown until the vendor reports credits on it again
+const keyCooldown = time.Hour
+
+// keyPool rotates requests round-robin across one or more API keys, tracks each
+// key's quota from the response headers, and takes keys the vendor refuses out of
+// rotation for keyCooldown
+type keyPool struct {
+	mu   sync.Mutex
+	keys []*poolKey
+	next int
+}
+
+// poolKey is one key and its usage
+type poolKey struct {
+	value string
+	usage models.KeyUsage
+}
+
+// newKeyPool creates a pool from a comma-separated list of keys
+func newKeyPool(list string) *keyPool {
+	p := &keyPool{}
+	p.set(list)
+	return p
+}
+
+// parseKeys splits a comma-separated key list, dropping blanks and duplicates
+func parseKeys(list string) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range strings.Split(list, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// maskKey shortens a key to its last four characters for logs and metrics
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "…" + key
+	}
+	return "…" + key[len(key)-4:]
+}
+
+// set replaces the pool's keys, keeping the usage of keys that remain
+func (p *keyPool) set(list string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	existing := make(map[string]*poolKey, len(p.keys))
+	for _, key := range p.keys {
+		existing[key.value] = key
+	}
+
+	p.keys = nil
+	for _, value := range parseKeys(list) {
+		key := existing[value]
+		if key == nil {
+			key = &poolKey{value: value, usage: models.KeyUsage{Key: maskKey(value), Remaining: -1, Used: -1}}
+		}
+		p.keys = append(p.keys, key)
+	}
+	p.next = 0
+}
+
+// len returns the number of keys
+func (p *keyPool) len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.keys)
+}
+
+// pick returns the next key in rotation that is not cooling down. When every key
+// is, it returns the one that comes back soonest so the vendor's error surfaces
+func (p *keyPool) pick(now time.Time) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return ""
+	}
+
+	var soonest *poolKey
+	for i := 0; i < len(p.keys); i++ {
+		key := p.keys[(p.next+i)%len(p.keys)]
+		if !now.Before(key.usage.DisabledUntil) {
+			p.next = (p.next + i + 1) % len(p.keys)
+			return key.value
+		}
+		if soonest == nil || key.usage.DisabledUntil.Before(soonest.usage.DisabledUntil) {
+			soonest = key
+		}
+	}
+	return soonest.value
+}
+
+// lookup returns value's usage, or nil if the key has left the pool (after
+// rotation). The caller holds mu
+func (p *keyPool) lookup(value string) *models.KeyUsage {
+	for _, key := range p.keys {
+		if key.value == value {
+			return &key.usage
+		}
+	}
+	return nil
+}
+
+// observe records one response to a request made with key. A key the vendor
+// reports no credits left on is taken out of rotation before it is refused
+func (p *keyPool) observe(key string, headers http.Header, now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := p.lookup(key)
+	if usage == nil {
+		return
+	}
+
+	usage.Requests++
+	if val, err := strconv.Atoi(headers.Get("x-requests-used")); err == nil {
+		usage.Used = val
+	}
+	if val, err := strconv.Atoi(headers.Get("x-requests-remaining")); err == nil {
+		usage.Remaining = val
+		if val <= 0 {
+			p.disable(usage, merrors.Label(merrors.ErrQuotaExhausted), now)
+		} else if usage.DisabledKind == merrors.Label(merrors.ErrQuotaExhausted) {
+			// Credits are back (the monthly reset, or an upgraded plan)
+			usage.DisabledUntil = time.Time{}
+			usage.DisabledKind = ""
+		}
+	}
+}
+
+// fail takes key out of rotation if err means the vendor refused the key itself (an
+// exhausted quota or a 401). It reports whether another key is available to retry on
+func (p *keyPool) fail(key string, err error, now time.Time) bool {
+	var classified *merrors.Error
+	refused := merrors.KindOf(err) == merrors.ErrQuotaExhausted ||
+		(errors.As(err, &classified) && classified.StatusCode == http.StatusUnauthorized)
+	if !refused {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := p.lookup(key)
+	if usage == nil {
+		return false
+	}
+	usage.Failures++
+	p.disable(usage, merrors.Label(err), now)
+
+	for _, other := range p.keys {
+		if !now.Before(other.usage.DisabledUntil) {
+			return true
+		}
+	}
+	return false
+}
+
+// disable takes a key out of rotation for keyCooldown (caller holds mu)
+func (p *keyPool) disable(usage *models.KeyUsage, kind string, now time.Time) {
+	usage.DisabledUntil = now.Add(keyCooldown)
+	usage.DisabledKind = kind
+}
+
+// usage returns a copy of every key's usage, in configuration order
+func (p *keyPool) usage() []models.KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := make([]models.KeyUsage, len(p.keys))
+	for i, key := range p.keys {
+		usage[i] = key.usage
+	}
+	return usage
+}
+
+// withAPIKey returns fullURL with its apiKey parameter set to key
+func withAPIKey(fullURL, key string) string {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return fullURL
+	}
+	query := u.Query()
+	query.Set("apiKey", key)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
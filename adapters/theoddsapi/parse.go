@@ -0,0 +1,425 @@
+package theoddsapi
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/XavierBriggs/Mercury/internal/bookskip"
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Sanity bounds for parsed lines; anything outside is quarantined rather than stored
+const (
+	minDecimalPrice = 1.001  // -100000 American
+	maxDecimalPrice = 1001.0 // +100000 American
+	maxAbsPoint     = 1000.0 // Larger than any real spread, total or prop line
+	maxDetailLen    = 512    // Quarantine detail is a hint, not a copy of the payload
+)
+
+// parseOddsResponse converts API response to internal FetchResult with events and odds
+// Records that fail validation are handed to the quarantine sink instead of being
+// stored with substitute values. With skip set, bookmakers whose last_update has not
+// advanced are left out, and the stamps of the ones parsed are returned for commit
+func (c *Client) parseOddsResponse(apiResp []oddsResponse, kind models.PayloadKind, format models.OddsFormat, receivedAt time.Time, skip *bookskip.Cache) *models.FetchResult {
+	var allOdds []models.RawOdds
+	var allEvents []models.Event
+	var quarantined []models.QuarantinedRecord
+	var stamps map[string]time.Time
+	seenEvents := make(map[string]bool)
+	if skip != nil {
+		stamps = make(map[string]time.Time)
+	}
+
+	reject := func(rec models.QuarantinedRecord) {
+		rec.Vendor = vendorName
+		rec.Kind = kind
+		rec.Detail = clipDetail(rec.Detail)
+		rec.ReceivedAt = receivedAt
+		quarantined = append(quarantined, rec)
+	}
+
+	for _, event := range apiResp {
+		commenceTime, reason, detail := validateEvent(event.ID, event.SportKey, event.HomeTeam, event.AwayTeam, event.CommenceTime)
+		if reason != "" {
+			reject(models.QuarantinedRecord{
+				SportKey: event.SportKey,
+				EventID:  event.ID,
+				Reason:   reason,
+				Detail:   fmt.Sprintf("%s (%d bookmakers dropped)", detail, len(event.Bookmakers)),
+			})
+			continue
+		}
+
+		teams := c.eventTeams(event.SportKey, event.HomeTeam, event.AwayTeam)
+
+		// Extract event (deduplicate by ID)
+		if !seenEvents[event.ID] {
+			allEvents = append(allEvents, models.Event{
+				EventID:      event.ID,
+				SportKey:     event.SportKey,
+				HomeTeam:     teams[event.HomeTeam],
+				AwayTeam:     teams[event.AwayTeam],
+				CommenceTime: commenceTime,
+				EventStatus:  eventStatus(commenceTime),
+			})
+			seenEvents[event.ID] = true
+		}
+
+		// Duplicate outcomes within one event: keep the first quote, quarantine the rest
+		seenOutcomes := make(map[string]float64)
+
+		for _, bookmaker := range event.Bookmakers {
+			base := models.QuarantinedRecord{SportKey: event.SportKey, EventID: event.ID, BookKey: bookmaker.Key}
+
+			if bookmaker.Key == "" {
+				base.Reason, base.Detail = models.QuarantineMissingField, fmt.Sprintf("bookmaker key empty (title %q)", bookmaker.Title)
+				reject(base)
+				continue
+			}
+			vendorUpdate, err := timeutil.ParseVendorTime(bookmaker.LastUpdate)
+			if err != nil {
+				base.Reason, base.Detail = models.QuarantineBadTimestamp, fmt.Sprintf("bookmaker last_update=%q", bookmaker.LastUpdate)
+				reject(base)
+				continue
+			}
+
+			if skip != nil {
+				key := bookskip.Key(event.ID, bookmaker.Key, bookmaker.marketKeys())
+				if skip.Unchanged(key, vendorUpdate, receivedAt) {
+					continue
+				}
+				stamps[key] = vendorUpdate
+			}
+
+			for _, market := range bookmaker.Markets {
+				base.MarketKey = market.Key
+
+				if market.Key == "" {
+					base.Reason, base.Detail = models.QuarantineMissingField, "market key empty"
+					reject(base)
+					continue
+				}
+
+				// Market-level last_update is more precise than the bookmaker's; prefer it when present
+				marketUpdate := vendorUpdate
+				if market.LastUpdate != "" {
+					if marketUpdate, err = timeutil.ParseVendorTime(market.LastUpdate); err != nil {
+						base.Reason, base.Detail = models.QuarantineBadTimestamp, fmt.Sprintf("market last_update=%q", market.LastUpdate)
+						reject(base)
+						continue
+					}
+				}
+
+				var marketOdds []models.RawOdds
+				for _, outcome := range market.Outcomes {
+					reason, detail := validateOutcome(outcome, format)
+					if reason == "" {
+						key := outcomeKey(bookmaker.Key, market.Key, outcome)
+						if first, dup := seenOutcomes[key]; dup {
+							reason = models.QuarantineDuplicateOutcome
+							detail = fmt.Sprintf("outcome %q repeated (kept price %v, dropped %v)", outcome.Name, first, outcome.Price)
+						} else {
+							seenOutcomes[key] = outcome.Price
+						}
+					}
+					if reason != "" {
+						base.Reason, base.Detail = reason, detail
+						reject(base)
+						continue
+					}
+
+					american, decimal, _ := models.NormalizePrice(format, outcome.Price) // Checked by validateOutcome
+					outcomeName, side, linePoint := c.outcomeSide(teams, event.SportKey, market.Key, outcome.Name,
+						teams[event.HomeTeam], teams[event.AwayTeam])
+
+					odd := models.RawOdds{
+						EventID:          event.ID,
+						SportKey:         event.SportKey,
+						MarketKey:        market.Key,
+						BookKey:          bookmaker.Key,
+						OutcomeName:      outcomeName,
+						Side:             side,
+						Description:      teams.name(outcome.Description), // Team totals name the team here
+						Price:            american,
+						DecimalPrice:     decimal,
+						OddsFormat:       format,
+						DeepLink:         firstLink(outcome.Link, market.Link, bookmaker.Link),
+						VendorLastUpdate: marketUpdate,
+						ReceivedAt:       receivedAt,
+					}
+
+					// Add point for spreads/totals (from the name when a book only puts it there)
+					if outcome.Point != nil {
+						point := *outcome.Point
+						odd.Point = &point
+					} else if linePoint != nil && math.Abs(*linePoint) <= maxAbsPoint {
+						odd.Point = linePoint
+					}
+
+					// Add max bet limit for books that expose it (a negative limit is meaningless; drop it)
+					if outcome.BetLimit != nil && *outcome.BetLimit >= 0 {
+						limit := *outcome.BetLimit
+						odd.Limit = &limit
+					}
+
+					marketOdds = append(marketOdds, odd)
+				}
+
+				// Both sides of each line must be present and priced consistently
+				kept, failed := c.marketChecks.checkMarket(marketOdds)
+				for _, rec := range failed {
+					base.Reason, base.Detail = rec.Reason, rec.Detail
+					reject(base)
+				}
+				allOdds = append(allOdds, kept...)
+			}
+		}
+	}
+
+	c.quarantine(quarantined)
+
+	return &models.FetchResult{
+		Events:     allEvents,
+		Odds:       allOdds,
+		BookStamps: stamps,
+	}
+}
+
+// marketKeys returns the keys of the markets a bookmaker quoted
+func (b bookmaker) marketKeys() []string {
+	keys := make([]string, len(b.Markets))
+	for i, m := range b.Markets {
+		keys[i] = m.Key
+	}
+	return keys
+}
+
+// parseEventsResponse converts API response to internal Event format
+// Events missing required fields or with an unparseable commence_time are quarantined
+func (c *Client) parseEventsResponse(apiResp []eventResponse, receivedAt time.Time) []models.Event {
+	events := make([]models.Event, 0, len(apiResp))
+	var quarantined []models.QuarantinedRecord
+
+	for _, evt := range apiResp {
+		commenceTime, reason, detail := validateEvent(evt.ID, evt.SportKey, evt.HomeTeam, evt.AwayTeam, evt.CommenceTime)
+		if reason != "" {
+			quarantined = append(quarantined, models.QuarantinedRecord{
+				Vendor:     vendorName,
+				Kind:       models.PayloadKindEvents,
+				SportKey:   evt.SportKey,
+				EventID:    evt.ID,
+				Reason:     reason,
+				Detail:     clipDetail(detail),
+				ReceivedAt: receivedAt,
+			})
+			continue
+		}
+
+		teams := c.eventTeams(evt.SportKey, evt.HomeTeam, evt.AwayTeam)
+
+		events = append(events, models.Event{
+			EventID:      evt.ID,
+			SportKey:     evt.SportKey,
+			HomeTeam:     teams[evt.HomeTeam],
+			AwayTeam:     teams[evt.AwayTeam],
+			CommenceTime: commenceTime,
+			EventStatus:  eventStatus(commenceTime),
+		})
+	}
+
+	c.quarantine(quarantined)
+
+	return events
+}
+
+// parseScoresResponse converts a scores response to EventScores
+// Games missing required fields or with an unparseable commence_time are quarantined
+func (c *Client) parseScoresResponse(apiResp []scoresResponse, receivedAt time.Time) []models.EventScore {
+	scores := make([]models.EventScore, 0, len(apiResp))
+	var quarantined []models.QuarantinedRecord
+
+	for _, game := range apiResp {
+		commenceTime, reason, detail := validateEvent(game.ID, game.SportKey, game.HomeTeam, game.AwayTeam, game.CommenceTime)
+		if reason != "" {
+			quarantined = append(quarantined, models.QuarantinedRecord{
+				Vendor:     vendorName,
+				Kind:       models.PayloadKindScores,
+				SportKey:   game.SportKey,
+				EventID:    game.ID,
+				Reason:     reason,
+				Detail:     clipDetail(detail),
+				ReceivedAt: receivedAt,
+			})
+			continue
+		}
+
+		teams := c.eventTeams(game.SportKey, game.HomeTeam, game.AwayTeam)
+		score := models.EventScore{
+			EventID:      game.ID,
+			SportKey:     game.SportKey,
+			HomeTeam:     teams[game.HomeTeam],
+			AwayTeam:     teams[game.AwayTeam],
+			CommenceTime: commenceTime,
+			Completed:    game.Completed,
+			HasScores:    len(game.Scores) > 0,
+		}
+		for _, s := range game.Scores {
+			switch s.Name {
+			case game.HomeTeam:
+				score.HomeScore = s.Score
+			case game.AwayTeam:
+				score.AwayScore = s.Score
+			}
+		}
+		if game.LastUpdate != nil {
+			// A bad last_update only loses the timestamp, not the game state
+			if lastUpdate, err := timeutil.ParseVendorTime(*game.LastUpdate); err == nil {
+				score.LastUpdate = lastUpdate
+			}
+		}
+		scores = append(scores, score)
+	}
+
+	c.quarantine(quarantined)
+
+	return scores
+}
+
+// eventTeams maps an event's team names as sent to their canonical names
+type eventTeams map[string]string
+
+func (c *Client) eventTeams(sport, homeTeam, awayTeam string) eventTeams {
+	return eventTeams{
+		homeTeam: c.teamNames.TeamName(sport, homeTeam),
+		awayTeam: c.teamNames.TeamName(sport, awayTeam),
+	}
+}
+
+// name returns the canonical name when value is one of the event's teams as sent;
+// anything else (Over/Under, Draw, player names) is returned unchanged
+func (t eventTeams) name(value string) string {
+	if canonical, ok := t[value]; ok {
+		return canonical
+	}
+	return value
+}
+
+// teamSided reports whether a market's outcomes are named after teams: the moneyline
+// and spread families, including period and alternate variants
+func teamSided(marketKey string) bool {
+	for _, family := range []string{"h2h", "spreads", "alternate_spreads"} {
+		if marketKey == family || strings.HasPrefix(marketKey, family+"_") {
+			return true
+		}
+	}
+	return false
+}
+
+// outcomeSide names a team-sided outcome after its team's canonical name and side,
+// however the book formatted it ("LA Lakers", "Lakers -3.5"), so delta keys agree
+// across books. It also returns a line found in the name. Over/Under outcomes of
+// totals and props get their canonical spelling ("o", "Über 220.5" -> "Over"); other
+// outcomes keep their alias-mapped name. Only team-sided outcomes have a side
+func (c *Client) outcomeSide(teams eventTeams, sport, marketKey, name, homeTeam, awayTeam string) (string, string, *float64) {
+	if !teamSided(marketKey) {
+		if total, point, ok := normalize.TotalsOutcome(name); ok {
+			return total, "", point
+		}
+		return teams.name(name), "", nil
+	}
+
+	team, point := normalize.SplitLine(name)
+	if canonical, ok := teams[team]; ok {
+		team = canonical
+	} else {
+		team = c.teamNames.TeamName(sport, team)
+	}
+
+	switch normalize.MatchSide(team, homeTeam, awayTeam) {
+	case normalize.SideHome:
+		return homeTeam, normalize.SideHome, point
+	case normalize.SideAway:
+		return awayTeam, normalize.SideAway, point
+	}
+	return teams.name(name), "", nil
+}
+
+// quarantine hands rejected records to the quarantine sink, if one is set
+func (c *Client) quarantine(records []models.QuarantinedRecord) {
+	if c.quarantineSink == nil || len(records) == 0 {
+		return
+	}
+	c.quarantineSink.Quarantine(records)
+}
+
+// validateEvent checks the fields every event needs and parses its commence time
+func validateEvent(id, sportKey, homeTeam, awayTeam, commence string) (time.Time, models.QuarantineReason, string) {
+	switch {
+	case id == "":
+		return time.Time{}, models.QuarantineMissingField, "event id empty"
+	case sportKey == "":
+		return time.Time{}, models.QuarantineMissingField, "sport_key empty"
+	case homeTeam == "" || awayTeam == "":
+		return time.Time{}, models.QuarantineMissingField, fmt.Sprintf("home_team=%q away_team=%q", homeTeam, awayTeam)
+	}
+
+	commenceTime, err := timeutil.ParseVendorTime(commence)
+	if err != nil {
+		return time.Time{}, models.QuarantineBadTimestamp, fmt.Sprintf("commence_time=%q", commence)
+	}
+	return commenceTime, "", ""
+}
+
+// validateOutcome checks an outcome's name, price and line
+func validateOutcome(o outcome, format models.OddsFormat) (models.QuarantineReason, string) {
+	if o.Name == "" {
+		return models.QuarantineMissingField, "outcome name empty"
+	}
+
+	// Bound the raw price before NormalizePrice rounds it into an int
+	if math.IsNaN(o.Price) || math.IsInf(o.Price, 0) || math.Abs(o.Price) > 1e6 {
+		return models.QuarantineBadPrice, fmt.Sprintf("outcome %q price=%v", o.Name, o.Price)
+	}
+	_, decimal, err := models.NormalizePrice(format, o.Price)
+	if err != nil || decimal < minDecimalPrice || decimal > maxDecimalPrice {
+		return models.QuarantineBadPrice, fmt.Sprintf("outcome %q %s price=%v", o.Name, format, o.Price)
+	}
+
+	if o.Point != nil && (math.IsNaN(*o.Point) || math.Abs(*o.Point) > maxAbsPoint) {
+		return models.QuarantineBadPoint, fmt.Sprintf("outcome %q point=%v", o.Name, *o.Point)
+	}
+	return "", ""
+}
+
+// outcomeKey identifies an outcome within one event's payload
+func outcomeKey(bookKey, marketKey string, o outcome) string {
+	point := "-"
+	if o.Point != nil {
+		point = fmt.Sprint(*o.Point)
+	}
+	return bookKey + "\x1f" + marketKey + "\x1f" + o.Name + "\x1f" + o.Description + "\x1f" + point
+}
+
+// eventStatus is the status of an event listed by the odds and events endpoints,
+// which carry no game state: the vendor drops games once they complete, so only the
+// commence time is left to tell upcoming from live
+func eventStatus(commenceTime time.Time) string {
+	return models.ResolveEventStatus(commenceTime, false, false, time.Now())
+}
+
+// clipDetail bounds quarantine detail so a hostile payload cannot bloat the table
+func clipDetail(detail string) string {
+	if len(detail) <= maxDetailLen {
+		return detail
+	}
+	cut := maxDetailLen
+	for cut > 0 && !utf8.RuneStart(detail[cut]) {
+		cut-- // Don't split a multi-byte rune
+	}
+	return detail[:cut] + "…"
+}
@@ -0,0 +1,455 @@
+package theoddsapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bookskip"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Large slates are multi-megabyte JSON arrays. Responses are requested gzipped and
+// decoded as they arrive, one event at a time, so a poll never holds the whole body
+// plus its fully decoded tree in memory. The raw body is only buffered when a
+// payload archiver needs it.
+
+// RedactedAPIKey replaces the apiKey query parameter in recorded requests
+const RedactedAPIKey = "REDACTED"
+
+// unixResetThreshold separates rate limit reset headers given as a Unix time from
+// those given in seconds (no vendor asks for a wait of over ten years)
+const unixResetThreshold = 315360000
+
+// payloadRef describes a response for the payload archive
+type payloadRef struct {
+	kind        models.PayloadKind
+	sport       string
+	eventID     string
+	conditional bool // Sent with committed validators when there are any; 304 is accepted
+}
+
+// fetchStream performs a GET with retries and streams the (decompressed) body to
+// decode. receivedAt is taken when the response headers arrive. Decode errors are
+// not retried: the vendor returned a payload we cannot parse.
+func (c *Client) fetchStream(ctx context.Context, fullURL string, ref payloadRef, decode func(r io.Reader, receivedAt time.Time) error) error {
+	resp, err := c.openWithRetry(ctx, fullURL, ref)
+	if err != nil {
+		return err
+	}
+	return c.readStream(ctx, fullURL, ref, resp, decode)
+}
+
+// fetchOdds is fetchStream for odds requests, sent conditionally with a validator
+// cache. A 304 returns a NotModified result without reading a body. A 200's
+// validators ride on the result, for the scheduler to commit once it is processed
+func (c *Client) fetchOdds(ctx context.Context, fullURL string, ref payloadRef, decode func(r io.Reader, receivedAt time.Time) (*models.FetchResult, error)) (*models.FetchResult, error) {
+	resp, err := c.openWithRetry(ctx, fullURL, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		if c.validators != nil {
+			c.validators.NotModified()
+		}
+		return &models.FetchResult{NotModified: true}, nil
+	}
+
+	var result *models.FetchResult
+	err = c.readStream(ctx, fullURL, ref, resp, func(r io.Reader, receivedAt time.Time) error {
+		var err error
+		result, err = decode(r, receivedAt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c.validators != nil {
+		// Empty validators are kept too: committing them forgets stale ones
+		result.Validators = map[string]models.Validators{
+			redactRequest(fullURL): {ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")},
+		}
+	}
+	return result, nil
+}
+
+// readStream streams a 200 response's (decompressed) body to decode and closes it
+func (c *Client) readStream(ctx context.Context, fullURL string, ref payloadRef, resp *http.Response, decode func(r io.Reader, receivedAt time.Time) error) error {
+	defer resp.Body.Close()
+
+	receivedAt := timeutil.Now()
+
+	// Bytes as received, before decompression, for the poll's audit row
+	counted := &countingReader{r: resp.Body}
+	defer func() { contracts.RequestStatsFrom(ctx).AddBytes(counted.n) }()
+
+	var body io.Reader = counted
+	if resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(counted)
+		if err != nil {
+			return merrors.Wrap(merrors.ErrMalformedResponse, "open gzip body", err)
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	var raw *bytes.Buffer
+	if c.archiver != nil {
+		raw = &bytes.Buffer{}
+		body = io.TeeReader(body, raw)
+	}
+
+	decodeErr := decode(body, receivedAt)
+
+	// Read to EOF so the connection returns to the pool (and the archive gets the
+	// whole body, including whatever made decoding fail)
+	io.Copy(io.Discard, body)
+
+	if raw != nil {
+		c.archive(ref.kind, ref.sport, ref.eventID, redactRequest(fullURL), raw.Bytes(), receivedAt)
+	}
+	return classifyDecode(ctx, decodeErr)
+}
+
+// classifyStatus classifies a non-200 response, with the vendor's requested wait on
+// 429 and 503. The Odds API answers 401 both for a bad key and for a used-up quota;
+// the latter names OUT_OF_USAGE_CREDITS in the body or leaves no requests remaining
+func classifyStatus(resp *http.Response, message string) error {
+	var wait time.Duration
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		wait = retryAfter(resp.Header, timeutil.Now())
+	}
+
+	err := merrors.FromStatus(resp.StatusCode, message, wait)
+	if resp.StatusCode == http.StatusUnauthorized &&
+		(strings.Contains(message, "OUT_OF_USAGE_CREDITS") || resp.Header.Get("x-requests-remaining") == "0") {
+		err.Kind = merrors.ErrQuotaExhausted
+	}
+	return err
+}
+
+// retryAfter returns how long the vendor asked clients to wait: Retry-After in
+// seconds or as an HTTP date, else a RateLimit-Reset or X-RateLimit-Reset header in
+// seconds (or a Unix time, as some gateways send). 0 when none is usable
+func retryAfter(headers http.Header, now time.Time) time.Duration {
+	if value := strings.TrimSpace(headers.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return max(time.Duration(seconds)*time.Second, 0)
+		}
+		if at, err := timeutil.ParseHTTPTime(value); err == nil {
+			return max(at.Sub(now), 0)
+		}
+	}
+
+	for _, key := range []string{"RateLimit-Reset", "X-RateLimit-Reset"} {
+		seconds, err := strconv.ParseInt(strings.TrimSpace(headers.Get(key)), 10, 64)
+		if err != nil || seconds < 0 {
+			continue
+		}
+		if seconds > unixResetThreshold {
+			return max(time.Unix(seconds, 0).Sub(now), 0)
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+// classifyDecode classifies a failure reading or decoding a 200 body: a body cut off
+// mid-read means the vendor (or the network) failed, anything else is a payload we
+// cannot parse. Decode errors are never retried here either way
+func classifyDecode(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() != nil {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &netErr) {
+		return merrors.Wrap(merrors.ErrVendorUnavailable, "", err)
+	}
+	return merrors.Wrap(merrors.ErrMalformedResponse, "", err)
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// redactRequest returns a request's path and query with the API key replaced, so
+// archived payloads and recorded fixtures never carry the secret
+func redactRequest(fullURL string) string {
+	u, err := url.Parse(fullURL)
+	if err != nil {
+		return ""
+	}
+
+	query := u.Query()
+	if query.Has("apiKey") {
+		query.Set("apiKey", RedactedAPIKey)
+	}
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+// openWithRetry performs a GET and returns the response once the vendor answers
+// 200, retrying retryable failures (network errors, 429 and 5xx) with exponential
+// backoff, or after the vendor's Retry-After when that is longer. Waits are capped
+// by the context's retry budget (contracts.WithRetryBudget): a retry that could not
+// start within it is not attempted and the last error, still carrying the vendor's
+// Retry-After, is returned for the caller to back off on
+func (c *Client) openWithRetry(ctx context.Context, fullURL string, ref payloadRef) (*http.Response, error) {
+	budget, limited := contracts.RetryBudget(ctx)
+
+	var lastErr error
+	var waited time.Duration
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			// Exponential backoff
+			backoff := retryDelay * time.Duration(1<<uint(attempt-1))
+			if wait := merrors.RetryAfter(lastErr); wait > backoff {
+				backoff = wait
+			}
+			if limited && waited+backoff > budget {
+				return nil, fmt.Errorf("retry budget %v exhausted: %w", budget, lastErr)
+			}
+			waited += backoff
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+
+		resp, err := c.openWithFailover(ctx, fullURL, ref)
+		if err == nil {
+			return resp, nil
+		}
+
+		lastErr = err
+
+		// Terminal failures (4xx other than 429, an exhausted quota) fail at once
+		if !merrors.Retryable(err) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+}
+
+// openWithFailover performs one attempt with the next API key in rotation. When the
+// vendor refuses that key (401 or a used-up quota) it is taken out of rotation and
+// the request is sent again at once with another, until none are left
+func (c *Client) openWithFailover(ctx context.Context, fullURL string, ref payloadRef) (*http.Response, error) {
+	for tried := 1; ; tried++ {
+		key := c.keys.pick(timeutil.Now())
+		resp, err := c.open(ctx, fullURL, key, ref)
+		if err == nil || !c.keys.fail(key, err, timeutil.Now()) || tried >= c.keys.len() {
+			return resp, err
+		}
+		fmt.Printf("[TheOddsAPI] ⚠ key %s refused (%s), failing over to the next key\n", maskKey(key), merrors.Label(err))
+	}
+}
+
+// open performs a single GET with key and reports its credit usage; non-200
+// responses are read, closed and returned classified
+func (c *Client) open(ctx context.Context, fullURL, key string, ref payloadRef) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, withAPIKey(fullURL, key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+	// Set explicitly so the body is decompressed as a stream in fetchStream
+	req.Header.Set("Accept-Encoding", "gzip")
+	if ref.conditional && c.validators != nil {
+		if v, ok := c.validators.Get(redactRequest(fullURL), timeutil.Now()); ok {
+			if v.ETag != "" {
+				req.Header.Set("If-None-Match", v.ETag)
+			}
+			if v.LastModified != "" {
+				req.Header.Set("If-Modified-Since", v.LastModified)
+			}
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("execute request: %w", err)
+		}
+		return nil, merrors.Wrap(merrors.ErrVendorUnavailable, "execute request", err)
+	}
+
+	// Update rate limits from headers
+	c.updateRateLimits(resp.Header)
+	c.keys.observe(key, resp.Header, timeutil.Now())
+	contracts.RequestStatsFrom(ctx).Response(resp.StatusCode, headerInt(resp.Header, "x-requests-last"))
+	c.recordUsage(ref, fullURL, resp)
+
+	// Only an answer to validators we sent means the last processed response stands
+	if resp.StatusCode == http.StatusNotModified && (req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "") {
+		return resp, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		counted := &countingReader{r: resp.Body}
+		var body io.Reader = counted
+		if resp.Header.Get("Content-Encoding") == "gzip" {
+			if gz, err := gzip.NewReader(counted); err == nil {
+				defer gz.Close()
+				body = gz
+			}
+		}
+		message, _ := io.ReadAll(io.LimitReader(body, 64<<10))
+		contracts.RequestStatsFrom(ctx).AddBytes(counted.n)
+		return nil, classifyStatus(resp, string(message))
+	}
+
+	return resp, nil
+}
+
+// decodeOdds streams an odds array, converting each event to RawOdds as it is read
+// (skipping unchanged bookmakers when skip is set)
+func (c *Client) decodeOdds(r io.Reader, format models.OddsFormat, receivedAt time.Time, skip *bookskip.Cache) (*models.FetchResult, error) {
+	result := &models.FetchResult{}
+	seenEvents := make(map[string]bool)
+
+	err := decodeArray(r, func(dec *json.Decoder) error {
+		var event oddsResponse
+		if err := dec.Decode(&event); err != nil {
+			return err
+		}
+
+		parsed := c.parseOddsResponse([]oddsResponse{event}, models.PayloadKindOdds, format, receivedAt, skip)
+		for _, evt := range parsed.Events {
+			if !seenEvents[evt.EventID] {
+				seenEvents[evt.EventID] = true
+				result.Events = append(result.Events, evt)
+			}
+		}
+		result.Odds = append(result.Odds, parsed.Odds...)
+		if parsed.BookStamps != nil {
+			if result.BookStamps == nil {
+				result.BookStamps = make(map[string]time.Time)
+			}
+			for key, stamp := range parsed.BookStamps {
+				result.BookStamps[key] = stamp
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// decodeEventOdds decodes a single-event odds object
+func (c *Client) decodeEventOdds(r io.Reader, format models.OddsFormat, receivedAt time.Time, skip *bookskip.Cache) (*models.FetchResult, error) {
+	var event oddsResponse
+	if err := json.NewDecoder(r).Decode(&event); err != nil {
+		return nil, err
+	}
+	return c.parseOddsResponse([]oddsResponse{event}, models.PayloadKindEventOdds, format, receivedAt, skip), nil
+}
+
+// decodeEvents decodes an events array
+func (c *Client) decodeEvents(r io.Reader, receivedAt time.Time) ([]models.Event, error) {
+	var apiResp []eventResponse
+	if err := json.NewDecoder(r).Decode(&apiResp); err != nil {
+		return nil, err
+	}
+	return c.parseEventsResponse(apiResp, receivedAt), nil
+}
+
+// decodeScores decodes a scores array
+func (c *Client) decodeScores(r io.Reader, receivedAt time.Time) ([]models.EventScore, error) {
+	var apiResp []scoresResponse
+	if err := json.NewDecoder(r).Decode(&apiResp); err != nil {
+		return nil, err
+	}
+	return c.parseScoresResponse(apiResp, receivedAt), nil
+}
+
+// decodeParticipants decodes a participants array, dropping entries without an ID or name
+func (c *Client) decodeParticipants(r io.Reader, sport string) ([]models.Participant, error) {
+	var apiResp []participantResponse
+	if err := json.NewDecoder(r).Decode(&apiResp); err != nil {
+		return nil, err
+	}
+
+	participants := make([]models.Participant, 0, len(apiResp))
+	for _, p := range apiResp {
+		if p.ID == "" || strings.TrimSpace(p.FullName) == "" {
+			continue
+		}
+		participants = append(participants, models.Participant{
+			ID:       p.ID,
+			SportKey: sport,
+			FullName: c.teamNames.TeamName(sport, p.FullName),
+		})
+	}
+	return participants, nil
+}
+
+// decodeFutures streams an outrights array, converting each event as it is read
+func (c *Client) decodeFutures(r io.Reader, sportKey string, receivedAt time.Time) ([]models.FuturesOdds, error) {
+	var odds []models.FuturesOdds
+	err := decodeArray(r, func(dec *json.Decoder) error {
+		var event oddsResponse
+		if err := dec.Decode(&event); err != nil {
+			return err
+		}
+		odds = append(odds, c.parseFuturesResponse([]oddsResponse{event}, sportKey, receivedAt)...)
+		return nil
+	})
+	return odds, err
+}
+
+// decodeArray walks a top-level JSON array, calling element once per item
+func decodeArray(r io.Reader, element func(dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected a JSON array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := element(dec); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package theoddsapi
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HTTPConfig tunes the vendor HTTP client. Every sport's featured poller and every
+// props poller hit the same host, so the pool keeps enough idle connections per
+// host that concurrent polls reuse TLS sessions instead of re-dialing each minute.
+type HTTPConfig struct {
+	Timeout               time.Duration // Whole request, including reading the body
+	DialTimeout           time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration // Time to first response byte after sending
+	IdleConnTimeout       time.Duration // How long an unused connection stays pooled
+	MaxIdleConnsPerHost   int
+
+	// Proxy routes every request through a fixed outbound proxy, e.g. one whose IPs
+	// the vendor whitelists. Credentials in its userinfo are sent as
+	// Proxy-Authorization. nil uses HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	Proxy *url.URL
+}
+
+// DefaultHTTPConfig returns the settings NewClient uses without WithHTTPClient
+func DefaultHTTPConfig() HTTPConfig {
+	return HTTPConfig{
+		Timeout:               timeout,
+		DialTimeout:           5 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 8 * time.Second,
+		IdleConnTimeout:       5 * time.Minute, // Outlives the longest regular poll interval
+		MaxIdleConnsPerHost:   32,
+	}
+}
+
+// NewHTTPClient builds an HTTP client with a tuned, keep-alive transport that
+// negotiates HTTP/2 when the vendor supports it. Zero fields use the defaults.
+func NewHTTPClient(cfg HTTPConfig) *http.Client {
+	def := DefaultHTTPConfig()
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = def.Timeout
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = def.DialTimeout
+	}
+	if cfg.TLSHandshakeTimeout <= 0 {
+		cfg.TLSHandshakeTimeout = def.TLSHandshakeTimeout
+	}
+	if cfg.ResponseHeaderTimeout <= 0 {
+		cfg.ResponseHeaderTimeout = def.ResponseHeaderTimeout
+	}
+	if cfg.IdleConnTimeout <= 0 {
+		cfg.IdleConnTimeout = def.IdleConnTimeout
+	}
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = def.MaxIdleConnsPerHost
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if cfg.Proxy != nil {
+		proxy = http.ProxyURL(cfg.Proxy)
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   cfg.DialTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          cfg.MaxIdleConnsPerHost * 2,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+	}
+}
+
+// ParseProxyURL parses an outbound proxy URL (http, https or socks5). A non-empty
+// username replaces the URL's credentials, so a password can be kept out of the URL.
+// An empty raw URL returns nil
+func ParseProxyURL(raw, username, password string) (*url.URL, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse proxy URL: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy URL %s: scheme must be http, https or socks5", proxy.Redacted())
+	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("proxy URL %s: missing host", proxy.Redacted())
+	}
+
+	if username != "" {
+		proxy.User = url.UserPassword(username, password)
+	}
+	return proxy, nil
+}
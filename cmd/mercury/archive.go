@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/archive"
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// archiveS3Config reads object storage credentials for s3:// and gs:// archive URLs
+func archiveS3Config() archive.S3Config {
+	return archive.S3Config{
+		Endpoint:        os.Getenv("ARCHIVE_ENDPOINT"),
+		Region:          os.Getenv("ARCHIVE_REGION"),
+		AccessKeyID:     getEnv("ARCHIVE_ACCESS_KEY_ID", os.Getenv("AWS_ACCESS_KEY_ID")),
+		SecretAccessKey: getEnv("ARCHIVE_SECRET_ACCESS_KEY", os.Getenv("AWS_SECRET_ACCESS_KEY")),
+	}
+}
+
+// replayTotals summarizes an archive replay
+type replayTotals struct {
+	Payloads int
+	Skipped  int
+	Failed   int
+	Events   int
+	Odds     int
+	Invalid  int
+
+	Quarantined int
+}
+
+// replayQuarantine counts records the parser rejects during a replay
+type replayQuarantine struct {
+	totals  *replayTotals
+	verbose bool
+}
+
+// Quarantine implements contracts.QuarantineSink
+func (q replayQuarantine) Quarantine(records []models.QuarantinedRecord) {
+	q.totals.Quarantined += len(records)
+	if !q.verbose {
+		return
+	}
+	for _, rec := range records {
+		fmt.Printf("    quarantined %s/%s/%s %s: %s\n", rec.EventID, rec.MarketKey, rec.BookKey, rec.Reason, rec.Detail)
+	}
+}
+
+// runArchiveReplay implements `mercury archive-replay`, feeding archived raw payloads
+// back through the parser and, with --apply, the delta → write pipeline
+func runArchiveReplay(args []string) int {
+	fs := flag.NewFlagSet("archive-replay", flag.ExitOnError)
+	archiveURL := fs.String("archive", os.Getenv("ARCHIVE_URL"), "archive URL (file://, s3:// or gs://)")
+	sportKey := fs.String("sport", "", "sport key (e.g. basketball_nba)")
+	vendor := fs.String("vendor", "theoddsapi", "vendor partition to read")
+	fromFlag := fs.String("from", "", "start of the receive-time range (RFC3339 or YYYY-MM-DD)")
+	toFlag := fs.String("to", "", "end of the range, exclusive (default: from + 24h)")
+	kindsFlag := fs.String("kinds", "odds,event-odds", "comma-separated payload kinds to replay (odds, event-odds, events)")
+	apply := fs.Bool("apply", false, "write parsed odds through delta detection into Alexandria and Redis")
+	verbose := fs.Bool("v", false, "print every payload and validation error")
+	validation := fs.String("validation", "drop", "with --apply, what happens to records failing sport validation (drop or log)")
+	fs.Parse(args)
+
+	if *archiveURL == "" || *sportKey == "" || *fromFlag == "" {
+		fmt.Println("usage: mercury archive-replay --archive <url> --sport <sport_key> --from <time> [--to <time>] [--apply]")
+		return 2
+	}
+
+	from, err := parseReplayTime(*fromFlag)
+	if err != nil {
+		fmt.Printf("✗ invalid --from: %v\n", err)
+		return 2
+	}
+	to := from.Add(24 * time.Hour)
+	if *toFlag != "" {
+		if to, err = parseReplayTime(*toFlag); err != nil {
+			fmt.Printf("✗ invalid --to: %v\n", err)
+			return 2
+		}
+	}
+
+	validationMode, err := scheduler.ParseValidationMode(*validation)
+	if err != nil || validationMode == scheduler.ValidationQuarantine {
+		fmt.Printf("✗ invalid --validation %q (want drop or log)\n", *validation)
+		return 2
+	}
+
+	kinds := make(map[models.PayloadKind]bool)
+	for _, kind := range splitList(*kindsFlag) {
+		kinds[models.PayloadKind(kind)] = true
+	}
+
+	store, err := archive.Open(*archiveURL, archiveS3Config())
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+
+	// Parsing needs no API key; the sport module (if registered) validates parsed odds
+	parser := theoddsapi.NewClient("")
+	sportRegistry := registry.NewSportRegistry()
+	if err := registerSports(sportRegistry); err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+	sport, _ := sportRegistry.Get(*sportKey)
+
+	// Parse with the same team aliases as the live poller
+	teamNames := normalize.NewRegistry()
+	teamNames.LoadSports(sportRegistry.GetAll())
+	parser.SetTeamNames(teamNames)
+
+	ctx := context.Background()
+
+	var sched *scheduler.Scheduler
+	if *apply {
+		fmt.Println("⚠ --apply writes replayed odds as current prices; point it at a scratch Alexandria/Redis")
+		db, redisClient, err := connectReplayTargets(ctx)
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			return 1
+		}
+		defer db.Close()
+		defer redisClient.Close()
+
+		if _, err := teamNames.LoadDB(ctx, db); err != nil {
+			fmt.Printf("⚠ Team aliases not loaded from Alexandria: %v\n", err)
+		}
+
+		cacheTTL := 5 * time.Minute
+		if ttlStr := os.Getenv("MERCURY_CACHE_TTL"); ttlStr != "" {
+			if parsed, err := time.ParseDuration(ttlStr); err == nil {
+				cacheTTL = parsed
+			}
+		}
+		sched = scheduler.NewScheduler(db, redisClient, parser, cacheTTL, sportRegistry)
+		sched.SetValidationMode(validationMode)
+	}
+
+	keys, err := archive.ListRange(ctx, store, *vendor, *sportKey, from, to)
+	if err != nil {
+		fmt.Printf("✗ list archive: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Replaying %d payload(s) for %s from %s to %s\n", len(keys), *sportKey,
+		from.Format(time.RFC3339), to.Format(time.RFC3339))
+
+	var totals replayTotals
+	parser.SetQuarantineSink(replayQuarantine{totals: &totals, verbose: *verbose})
+	for _, key := range keys {
+		payload, err := archive.Read(ctx, store, key)
+		if err != nil {
+			totals.Failed++
+			fmt.Printf("✗ %s: %v\n", key, err)
+			continue
+		}
+		if !kinds[payload.Kind] {
+			totals.Skipped++
+			continue
+		}
+		totals.Payloads++
+
+		result, err := parser.ParsePayload(payload)
+		if err != nil {
+			totals.Failed++
+			fmt.Printf("✗ %s: %v\n", key, err)
+			continue
+		}
+
+		invalid := 0
+		if sport != nil {
+			for _, odd := range result.Odds {
+				if err := sport.ValidateOdds(odd); err != nil {
+					invalid++
+					if *verbose {
+						fmt.Printf("    invalid %s/%s/%s %s: %v\n", odd.EventID, odd.MarketKey, odd.BookKey, odd.OutcomeName, err)
+					}
+				}
+			}
+		}
+
+		totals.Events += len(result.Events)
+		totals.Odds += len(result.Odds)
+		totals.Invalid += invalid
+		if *verbose {
+			fmt.Printf("  %s: %d events, %d odds, %d invalid\n", key, len(result.Events), len(result.Odds), invalid)
+		}
+
+		if sched != nil {
+			if err := sched.ProcessResult(ctx, *sportKey, payload.Kind, result); err != nil {
+				totals.Failed++
+				fmt.Printf("✗ %s: %v\n", key, err)
+			}
+		}
+	}
+
+	fmt.Printf("✓ %d payload(s) parsed: %d events, %d odds, %d invalid odds, %d quarantined, %d skipped, %d failed\n",
+		totals.Payloads, totals.Events, totals.Odds, totals.Invalid, totals.Quarantined, totals.Skipped, totals.Failed)
+	if totals.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// parseReplayTime accepts RFC3339 or a date (midnight UTC)
+func parseReplayTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == len("2006-01-02") {
+		value += "T00:00:00Z"
+	}
+	return timeutil.ParseVendorTime(value)
+}
+
+// connectReplayTargets opens the Alexandria and Redis connections used by --apply
+func connectReplayTargets(ctx context.Context) (*sql.DB, *redis.Client, error) {
+	db, err := openAlexandria(getEnv("ALEXANDRIA_DSN", defaultAlexandriaDSN))
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect to Alexandria DB: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("ping Alexandria DB: %w", err)
+	}
+
+	redisClient, err := newRedisClient(getEnv("REDIS_URL", "localhost:6379"))
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("configure Redis: %w", err)
+	}
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		db.Close()
+		redisClient.Close()
+		return nil, nil, fmt.Errorf("connect to Redis: %w", err)
+	}
+
+	return db, redisClient, nil
+}
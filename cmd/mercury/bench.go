@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bench"
+	"github.com/XavierBriggs/Mercury/internal/delta"
+	"github.com/XavierBriggs/Mercury/internal/writer"
+)
+
+// runBench implements `mercury bench`, pushing synthetic odds churn through the delta
+// engine and (optionally) the writer and reporting throughput and latency vs the SLO
+func runBench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	events := fs.Int("events", 15, "events in the synthetic slate")
+	books := fs.Int("books", 10, "books quoting every event")
+	markets := fs.Int("markets", 3, "markets per book (two outcomes each)")
+	churn := fs.Float64("churn", 0.05, "fraction of outcomes that move per snapshot (0-1)")
+	rate := fs.Float64("rate", 1, "snapshots per second (0 = back to back)")
+	duration := fs.Duration("duration", 30*time.Second, "how long to run")
+	batches := fs.Int("batches", 0, "stop after this many snapshots (0 = run for --duration)")
+	cacheMode := fs.String("cache", "memory", "delta cache: memory or redis (REDIS_URL)")
+	write := fs.Bool("write", false, "also write deltas to Alexandria and publish to Redis streams (scratch DB only)")
+	sport := fs.String("sport", "basketball_nba", "sport key stamped on synthetic odds (must exist in sports when --write)")
+	slo := fs.Duration("slo", bench.SLO, "per-snapshot latency budget checked against p99")
+	seed := fs.Int64("seed", 1, "random seed for the slate and price moves")
+	fs.Parse(args)
+
+	if *events <= 0 || *books <= 0 || *markets <= 0 || *churn < 0 || *churn > 1 {
+		fmt.Println("✗ --events, --books and --markets must be positive and --churn within 0-1")
+		return 2
+	}
+	if *cacheMode != "memory" && *cacheMode != "redis" {
+		fmt.Printf("✗ unknown --cache %q (memory or redis)\n", *cacheMode)
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var engine *delta.Engine
+	var oddsWriter bench.Writer
+
+	if *write {
+		fmt.Println("⚠ --write inserts synthetic odds as current prices; point it at a scratch Alexandria/Redis")
+		db, redisClient, err := connectReplayTargets(ctx)
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			return 1
+		}
+		defer db.Close()
+		defer redisClient.Close()
+
+		oddsWriter = writer.NewWriter(db, redisClient)
+		if *cacheMode == "redis" {
+			engine = delta.NewEngine(redisClient, 5*time.Minute)
+		}
+	} else if *cacheMode == "redis" {
+		redisClient, err := newRedisClient(getEnv("REDIS_URL", "localhost:6379"))
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			return 2
+		}
+		defer redisClient.Close()
+		if err := redisClient.Ping(ctx).Err(); err != nil {
+			fmt.Printf("✗ connect to Redis: %v\n", err)
+			return 1
+		}
+		engine = delta.NewEngine(redisClient, 5*time.Minute)
+	}
+	if engine == nil {
+		engine = delta.NewEngineWithCache(delta.NewMemoryCache(), 5*time.Minute)
+	}
+
+	gen := bench.NewGenerator(*sport, *events, *books, *markets, *churn, *seed)
+	fmt.Printf("Benchmarking %d events × %d books × %d markets = %d outcomes/snapshot, %.0f%% churn, cache=%s, write=%v\n",
+		*events, *books, *markets, gen.Size(), *churn*100, *cacheMode, *write)
+
+	report := bench.NewRunner(gen, engine, oddsWriter).Run(ctx, bench.Config{
+		Rate:     *rate,
+		Duration: *duration,
+		Batches:  *batches,
+		SLO:      *slo,
+	})
+
+	printBenchReport(report)
+	if report.Errors > 0 || !report.MeetsSLO() {
+		return 1
+	}
+	return 0
+}
+
+func printBenchReport(r bench.Report) {
+	fmt.Printf("\n%d snapshots in %v (%d missed ticks, %d errors)\n", r.Batches, r.Elapsed.Round(time.Millisecond), r.Missed, r.Errors)
+	fmt.Printf("Throughput: %.0f odds/s compared, %.0f deltas/s\n\n", r.OddsPerSecond(), r.DeltasPerSecond())
+
+	fmt.Printf("%-8s %10s %10s %10s %10s\n", "stage", "p50", "p90", "p99", "max")
+	for _, stage := range []string{"detect", "write", "cache", "total"} {
+		l, ok := r.Stages[stage]
+		if !ok {
+			continue
+		}
+		fmt.Printf("%-8s %10v %10v %10v %10v\n", stage, roundLatency(l.P50), roundLatency(l.P90), roundLatency(l.P99), roundLatency(l.Max))
+	}
+
+	p99 := r.Stages["total"].P99
+	if r.MeetsSLO() {
+		fmt.Printf("\n✓ p99 %v within the %v SLO (%d snapshot(s) over)\n", roundLatency(p99), r.SLO, r.SLOMisses)
+	} else {
+		fmt.Printf("\n✗ p99 %v exceeds the %v SLO (%d snapshot(s) over)\n", roundLatency(p99), r.SLO, r.SLOMisses)
+	}
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// tierReport summarizes what one market tier fetch returned
+type tierReport struct {
+	Name    string
+	Region  string
+	Cost    int
+	Events  int
+	Odds    int
+	Markets map[string]int // market_key -> odds count
+	Books   map[string]int // book_key -> odds count
+	Err     error
+}
+
+// runCheckSport implements `mercury check-sport`, a pre-flight check that performs
+// real fetches for a sport config and estimates its steady-state quota usage
+func runCheckSport(args []string) int {
+	fs := flag.NewFlagSet("check-sport", flag.ExitOnError)
+	sportKey := fs.String("sport", "", "vendor sport key to check (e.g. basketball_wnba)")
+	regionsFlag := fs.String("regions", "", "comma-separated regions (default: module config or \"us\")")
+	bookmakersFlag := fs.String("bookmakers", "", "comma-separated bookmakers requested instead of regions (default: module config; \"none\" = use regions)")
+	marketsFlag := fs.String("markets", "", "comma-separated featured markets (default: module config or h2h,spreads,totals)")
+	propsFlag := fs.String("props-markets", "", "comma-separated props markets to probe on one event (default: none)")
+	featuredInterval := fs.Duration("featured-interval", 0, "proposed featured poll interval (default: module config or 60s)")
+	propsInterval := fs.Duration("props-interval", 0, "proposed props poll interval (default: module config or 30m)")
+	windowHours := fs.Int("window-hours", 0, "props discovery window in hours (default: module config or 48)")
+	featuredWindow := fs.Int("featured-window-hours", -1, "only fetch featured odds for events starting within N hours (default: module config; 0 = all)")
+	fs.Parse(args)
+
+	if *sportKey == "" {
+		fmt.Println("✗ --sport is required")
+		fs.Usage()
+		return 2
+	}
+
+	apiKey := os.Getenv("ODDS_API_KEY")
+	if apiKey == "" {
+		fmt.Println("✗ ODDS_API_KEY environment variable is required")
+		return 1
+	}
+
+	// Start from the registered module config (if any), then apply flag overrides
+	regions := []string{"us"}
+	var bookmakers []string
+	markets := []string{"h2h", "spreads", "totals"}
+	var propsMarkets []string
+	fInterval := 60 * time.Second
+	pInterval := 30 * time.Minute
+	window := 48
+	fWindow := 0
+
+	sportRegistry := registry.NewSportRegistry()
+	if err := registerSports(sportRegistry); err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+
+	if sport, ok := sportRegistry.Get(*sportKey); ok {
+		fmt.Printf("Using registered module config for %s\n", sport.GetDisplayName())
+		regions = sport.GetRegions()
+		bookmakers = sport.GetBookmakers()
+		markets = sport.GetFeaturedMarkets()
+		fInterval = sport.GetFeaturedPollInterval()
+		pInterval = sport.GetPropsPollInterval()
+		window = sport.GetPropsDiscoveryWindowHours()
+		fWindow = sport.GetFeaturedWindowHours()
+	} else {
+		fmt.Printf("No registered module for %s, using proposed config from flags\n", *sportKey)
+	}
+
+	if *regionsFlag != "" {
+		regions = splitList(*regionsFlag)
+		bookmakers = nil // Explicit regions are checked as regions
+	}
+	if *bookmakersFlag == "none" {
+		bookmakers = nil
+	} else if *bookmakersFlag != "" {
+		bookmakers = splitList(*bookmakersFlag)
+	}
+	if *marketsFlag != "" {
+		markets = splitList(*marketsFlag)
+	}
+	if *propsFlag != "" {
+		propsMarkets = splitList(*propsFlag)
+	}
+	if *featuredInterval > 0 {
+		fInterval = *featuredInterval
+	}
+	if *propsInterval > 0 {
+		pInterval = *propsInterval
+	}
+	if *windowHours > 0 {
+		window = *windowHours
+	}
+	if *featuredWindow >= 0 {
+		fWindow = *featuredWindow
+	}
+	var featuredTo time.Time
+	if fWindow > 0 {
+		featuredTo = time.Now().Add(time.Duration(fWindow) * time.Hour)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	adapter := theoddsapi.NewClient(apiKey,
+		theoddsapi.WithBaseURL(os.Getenv("ODDS_API_BASE_URL")),
+		theoddsapi.WithHTTPClient(theoddsapi.NewHTTPClient(loadVendorHTTPConfig())),
+	)
+
+	if len(bookmakers) > 0 {
+		fmt.Printf("\nChecking %s (bookmakers=%v markets=%v)\n\n", *sportKey, bookmakers, markets)
+	} else {
+		fmt.Printf("\nChecking %s (regions=%v markets=%v)\n\n", *sportKey, regions, markets)
+	}
+
+	// Tier 1: featured markets, one fetch per region so coverage can be attributed
+	// (a bookmaker list is one fetch; per-book counts already attribute coverage)
+	var featuredReports []tierReport
+	if len(bookmakers) > 0 {
+		report := runTier(ctx, adapter, "featured", "bookmakers", func(ctx context.Context) (*models.FetchResult, error) {
+			return adapter.FetchOdds(ctx, &models.FetchOddsOptions{
+				Sport:          *sportKey,
+				Markets:        markets,
+				Bookmakers:     bookmakers,
+				CommenceTimeTo: featuredTo,
+			})
+		})
+		featuredReports = append(featuredReports, report)
+		printTierReport(report)
+		regions = nil
+	}
+	for _, region := range regions {
+		report := runTier(ctx, adapter, "featured", region, func(ctx context.Context) (*models.FetchResult, error) {
+			return adapter.FetchOdds(ctx, &models.FetchOddsOptions{
+				Sport:          *sportKey,
+				Regions:        []string{region},
+				Markets:        markets,
+				CommenceTimeTo: featuredTo,
+			})
+		})
+		featuredReports = append(featuredReports, report)
+		printTierReport(report)
+	}
+
+	// Discovery (events endpoint, limited to the props window like the scheduler)
+	now := time.Now()
+	windowEnd := now.Add(time.Duration(window) * time.Hour)
+	events, err := adapter.FetchEvents(ctx, &models.FetchEventsOptions{
+		Sport:            *sportKey,
+		CommenceTimeFrom: now,
+		CommenceTimeTo:   windowEnd,
+	})
+	if err != nil {
+		fmt.Printf("✗ events discovery failed: %v\n", err)
+	}
+	eventsInWindow := 0
+	var probeEvent *models.Event
+	for i, evt := range events {
+		if evt.CommenceTime.After(now) && evt.CommenceTime.Before(windowEnd) {
+			eventsInWindow++
+			if probeEvent == nil {
+				probeEvent = &events[i]
+			}
+		}
+	}
+	fmt.Printf("[discovery] %d events returned, %d within %dhr window\n\n", len(events), eventsInWindow, window)
+
+	// Tier 2: props markets on a single event
+	propsCostPerEvent := 0
+	if len(propsMarkets) > 0 {
+		if probeEvent == nil {
+			fmt.Println("[props] skipped: no upcoming event in window to probe")
+		} else {
+			label := strings.Join(regions, ",")
+			if len(bookmakers) > 0 {
+				label = "bookmakers"
+			}
+			report := runTier(ctx, adapter, "props", label, func(ctx context.Context) (*models.FetchResult, error) {
+				return adapter.FetchEventOdds(ctx, &models.FetchEventOddsOptions{
+					Sport:      *sportKey,
+					EventID:    probeEvent.EventID,
+					Regions:    regions,
+					Markets:    propsMarkets,
+					Bookmakers: bookmakers,
+				})
+			})
+			printTierReport(report)
+			propsCostPerEvent = report.Cost
+		}
+	}
+
+	// Steady-state estimate
+	featuredCostPerPoll := 0
+	for _, r := range featuredReports {
+		featuredCostPerPoll += r.Cost
+	}
+
+	featuredPollsPerDay := float64(24*time.Hour) / float64(fInterval)
+	featuredDaily := featuredPollsPerDay * float64(featuredCostPerPoll)
+
+	propsDaily := 0.0
+	if propsCostPerEvent > 0 && pInterval > 0 {
+		propsPollsPerDay := float64(24*time.Hour) / float64(pInterval)
+		propsDaily = propsPollsPerDay * float64(propsCostPerEvent*eventsInWindow)
+	}
+
+	fmt.Println("Estimated steady-state quota usage:")
+	fmt.Printf("  featured: %d credits/poll every %v = %.0f credits/day\n", featuredCostPerPoll, fInterval, featuredDaily)
+	if len(propsMarkets) > 0 {
+		fmt.Printf("  props:    %d credits/event x %d events every %v = %.0f credits/day\n",
+			propsCostPerEvent, eventsInWindow, pInterval, propsDaily)
+	}
+	fmt.Printf("  total:    %.0f credits/day, ~%.0f credits/month\n", featuredDaily+propsDaily, (featuredDaily+propsDaily)*30)
+
+	limits := adapter.GetRateLimits()
+	fmt.Printf("\nQuota remaining: %d (used %d)\n", limits.RequestsRemaining, limits.RequestsUsed)
+
+	return 0
+}
+
+// runTier executes one fetch and measures its quota cost from the x-requests-last
+// header of its response. The used count can't be diffed: it is 0 until the first
+// response and, with several keys, follows whichever key served the request
+func runTier(ctx context.Context, adapter *theoddsapi.Client, name, region string, fetch func(ctx context.Context) (*models.FetchResult, error)) tierReport {
+	report := tierReport{
+		Name:    name,
+		Region:  region,
+		Markets: make(map[string]int),
+		Books:   make(map[string]int),
+	}
+
+	// Only a response to this fetch that reported its cost makes RequestsLast this tier's
+	stats := &contracts.RequestStats{}
+	result, err := fetch(contracts.WithRequestStats(ctx, stats))
+	if totals := stats.Totals(); totals.Requests > 0 && totals.Credits >= 0 {
+		report.Cost = adapter.GetRateLimits().RequestsLast
+	}
+	if err != nil {
+		report.Err = err
+		return report
+	}
+
+	report.Events = len(result.Events)
+	report.Odds = len(result.Odds)
+	for _, odd := range result.Odds {
+		report.Markets[odd.MarketKey]++
+		report.Books[odd.BookKey]++
+	}
+
+	return report
+}
+
+// printTierReport prints a tier report in a compact, readable format
+func printTierReport(r tierReport) {
+	if r.Err != nil {
+		fmt.Printf("[%s:%s] ✗ %v (cost %d)\n\n", r.Name, r.Region, r.Err, r.Cost)
+		return
+	}
+
+	fmt.Printf("[%s:%s] %d events, %d odds, cost %d\n", r.Name, r.Region, r.Events, r.Odds, r.Cost)
+	fmt.Printf("  markets: %s\n", formatCounts(r.Markets))
+	fmt.Printf("  books:   %s\n\n", formatCounts(r.Books))
+}
+
+// formatCounts renders a count map sorted by key
+func formatCounts(counts map[string]int) string {
+	if len(counts) == 0 {
+		return "(none)"
+	}
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s(%d)", k, counts[k])
+	}
+	return strings.Join(parts, " ")
+}
+
+// splitList splits a comma-separated flag value, trimming blanks
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
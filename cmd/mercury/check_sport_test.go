@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const checkSportFixture = `[{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z",
+	"home_team":"Lakers","away_team":"Celtics","bookmakers":[{"key":"fanduel",
+	"last_update":"2025-01-15T11:59:00Z","markets":[{"key":"h2h","outcomes":[
+	{"name":"Lakers","price":-120},{"name":"Celtics","price":100}]}]}]}]`
+
+func TestRunTier_CostIsEachTiersOwn(t *testing.T) {
+	// The account has used 5000 credits before check-sport runs; us costs 2 and eu 3
+	used := 5000
+	costs := map[string]int{"us": 2, "eu": 3}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cost := costs[r.URL.Query().Get("regions")]
+		used += cost
+		w.Header().Set("x-requests-used", strconv.Itoa(used))
+		w.Header().Set("x-requests-remaining", strconv.Itoa(20000-used))
+		w.Header().Set("x-requests-last", strconv.Itoa(cost))
+		w.Write([]byte(checkSportFixture))
+	}))
+	defer server.Close()
+	adapter := theoddsapi.NewClient("test_key", theoddsapi.WithBaseURL(server.URL), theoddsapi.WithHTTPClient(server.Client()))
+
+	ctx := context.Background()
+	for _, region := range []string{"us", "eu"} {
+		report := runTier(ctx, adapter, "featured", region, func(ctx context.Context) (*models.FetchResult, error) {
+			return adapter.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{region}, Markets: []string{"h2h"}})
+		})
+		if report.Err != nil {
+			t.Fatalf("%s: %v", region, report.Err)
+		}
+		if report.Cost != costs[region] {
+			t.Errorf("%s: expected cost %d, got %d", region, costs[region], report.Cost)
+		}
+		if report.Odds != 2 || report.Books["fanduel"] != 2 {
+			t.Errorf("%s: unexpected report %+v", region, report)
+		}
+	}
+
+	// A tier that never reached the vendor costs nothing, not the previous tier's cost
+	report := runTier(ctx, adapter, "props", "us", func(ctx context.Context) (*models.FetchResult, error) {
+		return nil, errors.New("no event to probe")
+	})
+	if report.Err == nil || report.Cost != 0 {
+		t.Errorf("expected an error and no cost, got %+v", report)
+	}
+}
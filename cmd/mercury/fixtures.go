@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/fixtures"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// runRecordFixtures implements `mercury record-fixtures`, capturing real vendor responses
+// for a sport into golden files used by the parser tests and the fixtures adapter
+func runRecordFixtures(args []string) int {
+	fs := flag.NewFlagSet("record-fixtures", flag.ExitOnError)
+	sportKey := fs.String("sport", "", "vendor sport key to record (e.g. basketball_nba)")
+	outDir := fs.String("out", "tests/fixtures", "golden file root")
+	regionsFlag := fs.String("regions", "", "comma-separated regions (default: module config or \"us\")")
+	marketsFlag := fs.String("markets", "", "comma-separated featured markets (default: module config or h2h,spreads,totals)")
+	propsFlag := fs.String("props-markets", "", "comma-separated props markets (default: module config; \"none\" skips event odds)")
+	eventCount := fs.Int("events", 1, "upcoming events to record event odds for")
+	fs.Parse(args)
+
+	if *sportKey == "" {
+		fmt.Println("✗ --sport is required")
+		fs.Usage()
+		return 2
+	}
+
+	apiKey := os.Getenv("ODDS_API_KEY")
+	if apiKey == "" {
+		fmt.Println("✗ ODDS_API_KEY environment variable is required")
+		return 1
+	}
+
+	regions := []string{"us"}
+	var bookmakers []string
+	markets := []string{"h2h", "spreads", "totals"}
+	var propsMarkets []string
+
+	sportRegistry := registry.NewSportRegistry()
+	if err := registerSports(sportRegistry); err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+	if sport, ok := sportRegistry.Get(*sportKey); ok {
+		regions = sport.GetRegions()
+		bookmakers = sport.GetBookmakers()
+		markets = sport.GetFeaturedMarkets()
+		propsMarkets = sport.GetPropsMarkets()
+	}
+
+	if *regionsFlag != "" {
+		regions = splitList(*regionsFlag)
+		bookmakers = nil // Explicit regions are recorded as regions
+	}
+	if *marketsFlag != "" {
+		markets = splitList(*marketsFlag)
+	}
+	if *propsFlag == "none" {
+		propsMarkets = nil
+	} else if *propsFlag != "" {
+		propsMarkets = splitList(*propsFlag)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	// Record mode: every response body is captured before parsing, with the API key redacted
+	recorder := fixtures.NewRecorder(*outDir, apiKey)
+	adapter := theoddsapi.NewClient(apiKey,
+		theoddsapi.WithBaseURL(os.Getenv("ODDS_API_BASE_URL")),
+		theoddsapi.WithHTTPClient(theoddsapi.NewHTTPClient(loadVendorHTTPConfig())),
+	)
+	adapter.SetPayloadArchiver(recorder)
+
+	fmt.Printf("Recording %s (regions=%v bookmakers=%v markets=%v) into %s\n", *sportKey, regions, bookmakers, markets, *outDir)
+
+	failed := false
+
+	events, err := adapter.FetchEvents(ctx, &models.FetchEventsOptions{Sport: *sportKey})
+	if err != nil {
+		failed = true
+		fmt.Printf("✗ events: %v\n", err)
+	}
+
+	if _, err := adapter.FetchOdds(ctx, &models.FetchOddsOptions{Sport: *sportKey, Regions: regions, Markets: markets, Bookmakers: bookmakers}); err != nil {
+		failed = true
+		fmt.Printf("✗ odds: %v\n", err)
+	}
+
+	if len(propsMarkets) > 0 {
+		recorded := 0
+		now := time.Now()
+		for _, evt := range events {
+			if recorded >= *eventCount {
+				break
+			}
+			if !evt.CommenceTime.After(now) {
+				continue // Live events often have props pulled; record upcoming ones
+			}
+			_, err := adapter.FetchEventOdds(ctx, &models.FetchEventOddsOptions{
+				Sport:      *sportKey,
+				EventID:    evt.EventID,
+				Regions:    regions,
+				Markets:    propsMarkets,
+				Bookmakers: bookmakers,
+			})
+			if err != nil {
+				failed = true
+				fmt.Printf("✗ event odds %s: %v\n", evt.EventID, err)
+			}
+			recorded++
+		}
+	}
+
+	for _, path := range recorder.Recorded() {
+		fmt.Printf("✓ %s\n", path)
+	}
+	if limits := adapter.GetRateLimits(); limits != nil {
+		fmt.Printf("Quota: %d used, %d remaining\n", limits.RequestsUsed, limits.RequestsRemaining)
+	}
+
+	if failed || len(recorder.Errors()) > 0 || len(recorder.Recorded()) == 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/freshness"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// runFreshness implements `mercury freshness`, a report of how late each book's
+// price changes reach Mercury (received_at − vendor_last_update), slowest first
+func runFreshness(args []string) int {
+	fs := flag.NewFlagSet("freshness", flag.ExitOnError)
+	redisURL := fs.String("redis", getEnv("REDIS_URL", "localhost:6379"), "Redis address")
+	days := fs.Int("days", 1, "number of UTC days to report, ending today (at most 7 are kept)")
+	fs.Parse(args)
+
+	if *days < 1 {
+		fmt.Println("✗ --days must be at least 1")
+		return 2
+	}
+
+	redisClient, err := newRedisClient(*redisURL)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 2
+	}
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		fmt.Printf("✗ failed to connect to Redis at %s: %v\n", *redisURL, err)
+		return 1
+	}
+
+	today := timeutil.Now()
+	dayList := make([]time.Time, *days)
+	for i := range dayList {
+		dayList[i] = today.Add(-time.Duration(i) * 24 * time.Hour)
+	}
+
+	lags, err := freshness.Read(ctx, redisClient, dayList...)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+	if len(lags) == 0 {
+		fmt.Println("No freshness recorded in range")
+		return 0
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BOOK\tDELTAS\tMEAN\tP50\tP90\tP99")
+	for _, lag := range lags {
+		h := lag.Histogram
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", lag.BookKey, h.Count(),
+			h.Mean().Round(100*time.Millisecond), formatQuantile(h, 0.5), formatQuantile(h, 0.9), formatQuantile(h, 0.99))
+	}
+	tw.Flush()
+
+	fmt.Println("\nQuantiles are bucket upper bounds (≤); \">\" means beyond the last bucket")
+	return 0
+}
+
+// formatQuantile renders a histogram quantile as "≤5s" or ">30m0s"
+func formatQuantile(h *freshness.Histogram, q float64) string {
+	bound, over := h.Quantile(q)
+	if over {
+		return ">" + bound.String()
+	}
+	return "≤" + bound.String()
+}
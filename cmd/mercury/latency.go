@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/latency"
+	"github.com/XavierBriggs/Mercury/internal/slo"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// runLatency implements `mercury latency`, per-stage pipeline latency percentiles over
+// whole days and the share of polls within the pipeline SLO
+func runLatency(args []string) int {
+	fs := flag.NewFlagSet("latency", flag.ExitOnError)
+	redisURL := fs.String("redis", getEnv("REDIS_URL", "localhost:6379"), "Redis address")
+	days := fs.Int("days", 1, "number of UTC days to report, ending today (at most 7 are kept)")
+	target := fs.Duration("slo", getEnvDuration("PIPELINE_SLO", slo.DefaultTarget), "pipeline latency objective")
+	fs.Parse(args)
+
+	if *days < 1 {
+		fmt.Println("✗ --days must be at least 1")
+		return 2
+	}
+
+	redisClient, err := newRedisClient(*redisURL)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 2
+	}
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		fmt.Printf("✗ failed to connect to Redis at %s: %v\n", *redisURL, err)
+		return 1
+	}
+
+	today := timeutil.Now()
+	dayList := make([]time.Time, *days)
+	for i := range dayList {
+		dayList[i] = today.Add(-time.Duration(i) * 24 * time.Hour)
+	}
+
+	stages, err := latency.Read(ctx, redisClient, dayList...)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+	if len(stages) == 0 {
+		fmt.Println("No latency recorded in range")
+		return 0
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STAGE\tCOUNT\tMEAN\tP50\tP95\tP99")
+	for _, stage := range stages {
+		h := stage.Histogram
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", stage.Stage, h.Count(),
+			h.Mean().Round(10*time.Microsecond), h.FormatQuantile(0.5), h.FormatQuantile(0.95), h.FormatQuantile(0.99))
+	}
+	tw.Flush()
+
+	for _, stage := range stages {
+		if stage.Stage == latency.StagePipeline {
+			fmt.Printf("\nPipeline (delta + write + cache) within %v: %.2f%% of %d polls\n",
+				*target, 100*stage.Histogram.Within(*target), stage.Histogram.Count())
+		}
+	}
+	fmt.Println("Quantiles are bucket upper bounds (≤); \">\" means beyond the last bucket")
+	return 0
+}
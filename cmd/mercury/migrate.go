@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/XavierBriggs/Mercury/infra/alexandria"
+	"github.com/XavierBriggs/Mercury/internal/migrate"
+)
+
+// runMigrate implements `mercury migrate [up|status|baseline <version>]` against the
+// migrations embedded in the binary
+func runMigrate(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dsn := fs.String("dsn", getEnv("ALEXANDRIA_DSN", defaultAlexandriaDSN), "Alexandria DSN")
+	fs.Parse(args)
+
+	action := "up"
+	if fs.NArg() > 0 {
+		action = fs.Arg(0)
+	}
+
+	migrations, err := migrate.Load(alexandria.Migrations, "migrations")
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+
+	db, err := openAlexandria(*dsn)
+	if err != nil {
+		fmt.Printf("✗ failed to connect to Alexandria DB: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		fmt.Printf("✗ failed to ping Alexandria DB: %v\n", err)
+		return 1
+	}
+
+	migrator := migrate.New(db, migrations)
+
+	switch action {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, mig := range applied {
+			fmt.Printf("✓ applied %03d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			return 1
+		}
+		fmt.Printf("✓ schema at version %d (%d applied)\n", migrate.Latest(migrations), len(applied))
+
+	case "status":
+		applied, err := migrator.Applied(ctx)
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			return 1
+		}
+		done := make(map[int]migrate.Applied, len(applied))
+		for _, a := range applied {
+			done[a.Version] = a
+		}
+		for _, mig := range migrations {
+			if a, ok := done[mig.Version]; ok {
+				fmt.Printf("  %03d_%-30s applied %s\n", mig.Version, mig.Name, a.AppliedAt.UTC().Format("2006-01-02 15:04:05Z"))
+			} else {
+				fmt.Printf("  %03d_%-30s pending\n", mig.Version, mig.Name)
+			}
+		}
+		if err := migrator.Check(ctx); err != nil {
+			fmt.Printf("⚠ %v\n", err)
+			return 1
+		}
+
+	case "baseline":
+		if fs.NArg() < 2 {
+			fmt.Println("usage: mercury migrate baseline <version>")
+			return 2
+		}
+		version, err := strconv.Atoi(fs.Arg(1))
+		if err != nil {
+			fmt.Printf("✗ invalid version %q\n", fs.Arg(1))
+			return 2
+		}
+		recorded, err := migrator.Baseline(ctx, version)
+		for _, mig := range recorded {
+			fmt.Printf("✓ marked %03d_%s as applied\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			fmt.Printf("✗ %v\n", err)
+			return 1
+		}
+
+	default:
+		fmt.Println("usage: mercury migrate [--dsn ...] [up|status|baseline <version>]")
+		return 2
+	}
+
+	return 0
+}
+
+// checkSchema applies pending migrations when migrateOnStart is set, otherwise it
+// refuses to run against a schema older than this binary expects
+func checkSchema(ctx context.Context, db *sql.DB, migrateOnStart bool) error {
+	migrations, err := migrate.Load(alexandria.Migrations, "migrations")
+	if err != nil {
+		return err
+	}
+	migrator := migrate.New(db, migrations)
+
+	if migrateOnStart {
+		applied, err := migrator.Up(ctx)
+		for _, mig := range applied {
+			fmt.Printf("[Migrate] Applied %03d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := migrator.Check(ctx); err != nil {
+		if errors.Is(err, migrate.ErrSchemaBehind) {
+			return fmt.Errorf("%v (run `mercury migrate`, or `mercury migrate baseline <version>` for a database created with psql)", err)
+		}
+		return err
+	}
+
+	fmt.Printf("✓ Alexandria schema at version %d\n", migrate.Latest(migrations))
+	return nil
+}
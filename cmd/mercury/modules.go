@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Optional subsystems that can be switched off via MERCURY_DISABLED_MODULES.
+// The core fetch → delta → write pipeline is always on.
+const (
+	moduleCloser        = "closer"         // Closing line capturer
+	moduleStatusUpdater = "status_updater" // upcoming → live → completed transitions
+	moduleTalos         = "talos"          // Talos page warming/closing lifecycle
+	moduleQuota         = "quota"          // Quota-aware polling degradation
+	moduleReliability   = "reliability"    // Per-book reliability scoring
+	moduleFutures       = "futures"        // Futures/outrights polling track
+	moduleWSPush        = "wspush"         // WebSocket delta push server (needs WS_PUSH_ADDR)
+	moduleGRPC          = "grpc"           // gRPC OddsService queries and delta streams (needs GRPC_ADDR)
+	moduleStreamGroups  = "streamgroups"   // Consumer group bootstrap and lag monitoring
+	moduleEdge          = "edge"           // +EV detection versus sharp no-vig lines
+	moduleArb           = "arb"            // Cross-book arbitrage detection
+	moduleSteam         = "steam"          // Steam move / line velocity detection
+	moduleBestLine      = "bestline"       // Best available price per outcome cached in Redis
+	moduleArchive       = "archive"        // Raw vendor payload archiving to object storage
+	moduleExport        = "export"         // Scheduled daily CSV/Parquet export
+	moduleParticipants  = "participants"   // Daily vendor roster refresh (team IDs on stream messages)
+	moduleScores        = "scores"         // Live scores into the scores table and scores.live streams
+	moduleWebhooks      = "webhooks"       // Signed HTTP webhooks for line moves (needs WEBHOOK_URLS)
+	moduleAlerting      = "alerting"       // Slack/Discord operational alerts (needs ALERT_*_WEBHOOK_URL)
+	moduleFreshness     = "freshness"      // Per-book vendor lag (received_at − vendor_last_update) histograms
+	moduleSLO           = "slo"            // Pipeline latency SLO tracking and violation records
+	moduleLatency       = "latency"        // Per-stage latency histograms and periodic summary
+	moduleOHLC          = "ohlc"           // 1m/5m/1h OHLC candles of line movement in odds_ohlc
+	moduleStaleBook     = "stalebook"      // Stale-book detection near tipoff (warnings, best line exclusion)
+	moduleUsage         = "usage"          // Per-request vendor credit usage in vendor_usage and its daily rollup
+)
+
+// knownModules lists every toggleable module (all enabled by default)
+var knownModules = []string{
+	moduleCloser,
+	moduleStatusUpdater,
+	moduleTalos,
+	moduleQuota,
+	moduleReliability,
+	moduleFutures,
+	moduleWSPush,
+	moduleGRPC,
+	moduleStreamGroups,
+	moduleEdge,
+	moduleArb,
+	moduleSteam,
+	moduleBestLine,
+	moduleArchive,
+	moduleExport,
+	moduleParticipants,
+	moduleScores,
+	moduleWebhooks,
+	moduleAlerting,
+	moduleFreshness,
+	moduleSLO,
+	moduleLatency,
+	moduleOHLC,
+	moduleStaleBook,
+	moduleUsage,
+}
+
+// ModuleToggles records which optional subsystems are enabled
+type ModuleToggles map[string]bool
+
+// Enabled reports whether a module is enabled (unknown modules are disabled)
+func (m ModuleToggles) Enabled(name string) bool {
+	return m[name]
+}
+
+// Disabled returns the sorted names of disabled modules
+func (m ModuleToggles) Disabled() []string {
+	var disabled []string
+	for name, enabled := range m {
+		if !enabled {
+			disabled = append(disabled, name)
+		}
+	}
+	sort.Strings(disabled)
+	return disabled
+}
+
+// loadModuleToggles parses MERCURY_DISABLED_MODULES (comma-separated module names)
+// Setting it to "all" leaves only the fetch → write pipeline running
+func loadModuleToggles() ModuleToggles {
+	toggles := make(ModuleToggles, len(knownModules))
+	for _, name := range knownModules {
+		toggles[name] = true
+	}
+
+	disabledStr := os.Getenv("MERCURY_DISABLED_MODULES")
+	if disabledStr == "" {
+		return toggles
+	}
+
+	for _, name := range splitList(strings.ToLower(disabledStr)) {
+		if name == "all" {
+			for _, known := range knownModules {
+				toggles[known] = false
+			}
+			continue
+		}
+
+		if _, ok := toggles[name]; !ok {
+			fmt.Printf("⚠ Unknown module '%s' in MERCURY_DISABLED_MODULES (known: %s)\n",
+				name, strings.Join(knownModules, ", "))
+			continue
+		}
+		toggles[name] = false
+	}
+
+	return toggles
+}
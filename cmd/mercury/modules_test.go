@@ -0,0 +1,79 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadModuleToggles_DefaultsToAllEnabled(t *testing.T) {
+	t.Setenv("MERCURY_DISABLED_MODULES", "")
+	toggles := loadModuleToggles()
+
+	for _, name := range knownModules {
+		if !toggles.Enabled(name) {
+			t.Errorf("expected %s enabled by default", name)
+		}
+	}
+	if disabled := toggles.Disabled(); len(disabled) != 0 {
+		t.Errorf("expected nothing disabled, got %v", disabled)
+	}
+}
+
+func TestLoadModuleToggles_ExplicitOff(t *testing.T) {
+	t.Setenv("MERCURY_DISABLED_MODULES", " edge, ARB ,talos")
+	toggles := loadModuleToggles()
+
+	if want := []string{moduleArb, moduleEdge, moduleTalos}; !reflect.DeepEqual(toggles.Disabled(), want) {
+		t.Errorf("expected %v disabled, got %v", want, toggles.Disabled())
+	}
+	for _, name := range []string{moduleCloser, moduleStatusUpdater, moduleGRPC} {
+		if !toggles.Enabled(name) {
+			t.Errorf("expected %s still enabled", name)
+		}
+	}
+}
+
+func TestLoadModuleToggles_AllDisablesEveryModule(t *testing.T) {
+	t.Setenv("MERCURY_DISABLED_MODULES", "all")
+	toggles := loadModuleToggles()
+
+	for _, name := range knownModules {
+		if toggles.Enabled(name) {
+			t.Errorf("expected %s disabled by all", name)
+		}
+	}
+	if got := len(toggles.Disabled()); got != len(knownModules) {
+		t.Errorf("expected %d disabled, got %d", len(knownModules), got)
+	}
+}
+
+func TestLoadModuleToggles_UnknownNamesAreIgnored(t *testing.T) {
+	t.Setenv("MERCURY_DISABLED_MODULES", "edge,rest_api,archiver")
+	toggles := loadModuleToggles()
+
+	if want := []string{moduleEdge}; !reflect.DeepEqual(toggles.Disabled(), want) {
+		t.Errorf("expected only %v disabled, got %v", want, toggles.Disabled())
+	}
+	if toggles.Enabled("rest_api") {
+		t.Error("expected an unknown module to report disabled")
+	}
+	if _, ok := toggles["archiver"]; ok {
+		t.Error("expected an unknown name not to be added to the toggles")
+	}
+}
+
+func TestLoadModuleToggles_InvalidValuesDisableNothing(t *testing.T) {
+	for _, value := range []string{" , ,", "edge=false", "edge;arb", "-edge"} {
+		t.Run(value, func(t *testing.T) {
+			t.Setenv("MERCURY_DISABLED_MODULES", value)
+			toggles := loadModuleToggles()
+
+			if disabled := toggles.Disabled(); len(disabled) != 0 {
+				t.Errorf("expected nothing disabled, got %v", disabled)
+			}
+			if len(toggles) != len(knownModules) {
+				t.Errorf("expected only the %d known modules, got %d", len(knownModules), len(toggles))
+			}
+		})
+	}
+}
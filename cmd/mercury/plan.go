@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/plan"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+)
+
+// runPlan implements `mercury plan`, an offline estimate of the daily vendor credits
+// the registered sport configs would spend on an expected slate
+func runPlan(args []string) int {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	sportFlag := fs.String("sport", "", "only estimate this sport (default: every registered sport)")
+	games := fs.Int("games", 10, "expected games per day")
+	tipoffsFlag := fs.String("tipoffs", "23:00,23:30,00:00,00:30,01:00,02:00,02:30,03:00",
+		"comma-separated UTC start times (HH:MM), cycled to fill --games")
+	quota := fs.Float64("quota", 0, "monthly credit quota to compare the 30-day estimate against (0 = none)")
+	byMarket := fs.Bool("markets", false, "break every tier down per market")
+	fs.Parse(args)
+
+	tipoffs, err := parseTipoffs(*tipoffsFlag, *games)
+	if err != nil {
+		fmt.Printf("✗ invalid --tipoffs: %v\n", err)
+		return 2
+	}
+
+	sportRegistry := registry.NewSportRegistry()
+	if err := registerSports(sportRegistry); err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+
+	var sports []contracts.SportModule
+	for _, sport := range sportRegistry.GetAll() {
+		if *sportFlag == "" || sport.GetSportKey() == *sportFlag {
+			sports = append(sports, sport)
+		}
+	}
+	if len(sports) == 0 {
+		fmt.Printf("✗ sport %q is not registered\n", *sportFlag)
+		return 1
+	}
+	sort.Slice(sports, func(i, j int) bool { return sports[i].GetSportKey() < sports[j].GetSportKey() })
+
+	estimate := plan.Estimate(sports, plan.Slate{Tipoffs: tipoffs})
+
+	fmt.Printf("Slate: %d games/day per sport\n\n", len(tipoffs))
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SPORT\tTRACK\tTIER\tMARKETS\tREQUESTS/DAY\tCREDITS/DAY")
+	for _, row := range planRows(estimate.Lines, *byMarket) {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.0f\t%.0f\n",
+			row.SportKey, row.Track, row.Tier, row.Market, row.Polls, row.Credits)
+	}
+	tw.Flush()
+
+	markets := estimate.ByMarket()
+	keys := make([]string, 0, len(markets))
+	for key := range markets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return markets[keys[i]] > markets[keys[j]] })
+
+	fmt.Println("\nBy market:")
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(tw, "  %s\t%.0f/day\n", key, markets[key])
+	}
+	tw.Flush()
+
+	total := estimate.Total()
+	fmt.Printf("\nTotal: %.0f credits/day, %.0f credits/30 days\n", total, total*30)
+	if *quota > 0 {
+		fmt.Printf("Quota: %.0f%% of %.0f monthly credits\n", 100*total*30/(*quota), *quota)
+		if total*30 > *quota {
+			fmt.Println("⚠ the plan exceeds the quota; slow the largest tiers above")
+			return 1
+		}
+	}
+	return 0
+}
+
+// planRows merges market lines into one row per sport/track/tier unless per-market
+// rows are requested; merged rows list their market count
+func planRows(lines []plan.Line, byMarket bool) []plan.Line {
+	if byMarket {
+		return lines
+	}
+
+	var rows []plan.Line
+	index := make(map[string]int)
+	counts := make(map[string]int)
+	for _, l := range lines {
+		key := l.SportKey + "|" + string(l.Track) + "|" + l.Tier
+		i, ok := index[key]
+		if !ok {
+			i = len(rows)
+			index[key] = i
+			rows = append(rows, plan.Line{SportKey: l.SportKey, Track: l.Track, Tier: l.Tier})
+		}
+		rows[i].Polls += l.Polls
+		rows[i].Credits += l.Credits
+		if l.Market != "" {
+			counts[key]++
+		}
+	}
+
+	for key, i := range index {
+		rows[i].Market = "-"
+		if counts[key] > 0 {
+			rows[i].Market = strconv.Itoa(counts[key])
+		}
+	}
+	return rows
+}
+
+// parseTipoffs parses "HH:MM" UTC start times and cycles them to fill games
+func parseTipoffs(spec string, games int) ([]time.Duration, error) {
+	var times []time.Duration
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		hh, mm, ok := strings.Cut(item, ":")
+		hours, errH := strconv.Atoi(hh)
+		minutes, errM := strconv.Atoi(mm)
+		if !ok || errH != nil || errM != nil || hours < 0 || hours > 23 || minutes < 0 || minutes > 59 {
+			return nil, fmt.Errorf("%q is not HH:MM", item)
+		}
+		times = append(times, time.Duration(hours)*time.Hour+time.Duration(minutes)*time.Minute)
+	}
+	if len(times) == 0 && games > 0 {
+		return nil, fmt.Errorf("no start times given")
+	}
+
+	tipoffs := make([]time.Duration, 0, games)
+	for i := 0; i < games; i++ {
+		tipoffs = append(tipoffs, times[i%len(times)])
+	}
+	return tipoffs, nil
+}
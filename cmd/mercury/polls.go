@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/pollaudit"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// runPolls implements `mercury polls`, the recent per-poll audit rows: what each poll
+// requested, what the vendor returned and the events, odds and deltas it produced
+func runPolls(args []string) int {
+	fs := flag.NewFlagSet("polls", flag.ExitOnError)
+	dsn := fs.String("dsn", getEnv("ALEXANDRIA_DSN", defaultAlexandriaDSN), "Alexandria DSN")
+	sport := fs.String("sport", "", "only this sport key")
+	track := fs.String("track", "", "only this track (featured, tipoff, props, props discovery)")
+	event := fs.String("event", "", "only props polls for this event ID")
+	poll := fs.String("poll", "", "only the poll with this ID (poll_id on odds rows and stream messages); ignores --since")
+	since := fs.Duration("since", time.Hour, "how far back to look")
+	failed := fs.Bool("failed", false, "only polls that returned an error")
+	limit := fs.Int("limit", 50, "maximum rows, newest first")
+	wide := fs.Bool("wide", false, "also show the poll IDs and the regions and markets requested")
+	fs.Parse(args)
+
+	from := timeutil.Now().Add(-*since)
+	if *poll != "" {
+		from = time.Time{}
+	}
+
+	db, err := openAlexandria(*dsn)
+	if err != nil {
+		fmt.Printf("✗ failed to connect to Alexandria DB: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		fmt.Printf("✗ failed to ping Alexandria DB: %v\n", err)
+		return 1
+	}
+
+	rows, err := pollaudit.Recent(ctx, db, pollaudit.Filter{
+		SportKey:   *sport,
+		Track:      *track,
+		EventID:    *event,
+		PollID:     *poll,
+		Since:      from,
+		FailedOnly: *failed,
+		Limit:      *limit,
+	})
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+	if len(rows) == 0 {
+		fmt.Println("No polls recorded in range")
+		return 0
+	}
+
+	printPolls(os.Stdout, rows, *wide)
+	return 0
+}
+
+// printPolls writes the audit rows as a table, then the full message of each failed poll
+func printPolls(w io.Writer, rows []models.PollAudit, wide bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := "STARTED\tSPORT\tTRACK\tEVENT\tSTATUS\tREQ\tBYTES\tEVENTS\tODDS\tDELTAS\tCREDITS\tDURATION\tERROR"
+	if wide {
+		header += "\tPOLL\tREGIONS\tMARKETS"
+	}
+	fmt.Fprintln(tw, header)
+	for _, row := range rows {
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\t%v\t%s",
+			row.StartedAt.Format("2006-01-02 15:04:05"), row.SportKey, row.Track, dash(row.EventID),
+			orDash(row.StatusCode, 0), row.Requests, row.Bytes, row.Events, row.Odds, row.Deltas,
+			orDash(row.Credits, -1), row.Duration.Round(time.Millisecond), dash(row.ErrorKind))
+		if wide {
+			line += fmt.Sprintf("\t%s\t%s\t%s", dash(row.PollID), dash(strings.Join(row.Regions, ",")), dash(strings.Join(row.Markets, ",")))
+		}
+		fmt.Fprintln(tw, line)
+	}
+	tw.Flush()
+
+	// Full messages for failed polls, which the ERROR column only labels
+	for _, row := range rows {
+		if row.Error != "" {
+			fmt.Fprintf(w, "\n%s %s %s: %s", row.StartedAt.Format("15:04:05"), row.SportKey, row.Track, row.Error)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// dash shows an empty value as "-"
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// orDash shows n as "-" when it equals missing (no status, unreported credits)
+func orDash(n, missing int) string {
+	if n == missing {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func pollsFixture() []models.PollAudit {
+	started := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	return []models.PollAudit{
+		{
+			PollID:     "a1b2c3d4e5f60718",
+			SportKey:   "basketball_nba",
+			Track:      "featured",
+			Regions:    []string{"us", "us2"},
+			Markets:    []string{"h2h", "spreads"},
+			Requests:   1,
+			StatusCode: 200,
+			Bytes:      2048,
+			Events:     9,
+			Odds:       120,
+			Deltas:     14,
+			Credits:    4,
+			Duration:   1234567 * time.Microsecond,
+			StartedAt:  started,
+		},
+		{
+			SportKey:  "basketball_nba",
+			Track:     "props",
+			EventID:   "evt-1",
+			Credits:   -1,
+			ErrorKind: "timeout",
+			Error:     "context deadline exceeded",
+			Duration:  30 * time.Second,
+			StartedAt: started.Add(-time.Minute),
+		},
+	}
+}
+
+// fields splits each output line on runs of spaces
+func fields(out string) [][]string {
+	var lines [][]string
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		lines = append(lines, strings.Fields(line))
+	}
+	return lines
+}
+
+func TestPrintPolls_Table(t *testing.T) {
+	var buf bytes.Buffer
+	printPolls(&buf, pollsFixture(), false)
+	lines := fields(buf.String())
+
+	wantHeader := "STARTED SPORT TRACK EVENT STATUS REQ BYTES EVENTS ODDS DELTAS CREDITS DURATION ERROR"
+	if got := strings.Join(lines[0], " "); got != wantHeader {
+		t.Errorf("header:\n got %s\nwant %s", got, wantHeader)
+	}
+
+	// STARTED is a date and a time, so it spans two fields
+	want := [][]string{
+		{"2025-01-15", "12:00:00", "basketball_nba", "featured", "-", "200", "1", "2048", "9", "120", "14", "4", "1.235s", "-"},
+		{"2025-01-15", "11:59:00", "basketball_nba", "props", "evt-1", "-", "0", "0", "0", "0", "0", "-", "30s", "timeout"},
+	}
+	for i, row := range want {
+		if got := strings.Join(lines[i+1], " "); got != strings.Join(row, " ") {
+			t.Errorf("row %d:\n got %s\nwant %s", i, got, strings.Join(row, " "))
+		}
+	}
+
+	if !strings.Contains(buf.String(), "\n11:59:00 basketball_nba props: context deadline exceeded") {
+		t.Errorf("expected the failed poll's full message, got:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "POLL") {
+		t.Error("expected no wide columns without --wide")
+	}
+}
+
+func TestPrintPolls_Wide(t *testing.T) {
+	var buf bytes.Buffer
+	printPolls(&buf, pollsFixture(), true)
+	lines := fields(buf.String())
+
+	header := lines[0]
+	if got := strings.Join(header[len(header)-3:], " "); got != "POLL REGIONS MARKETS" {
+		t.Errorf("expected wide header columns, got %s", got)
+	}
+
+	first := lines[1]
+	if got := strings.Join(first[len(first)-3:], " "); got != "a1b2c3d4e5f60718 us,us2 h2h,spreads" {
+		t.Errorf("expected poll ID, regions and markets, got %s", got)
+	}
+	second := lines[2]
+	if got := strings.Join(second[len(second)-3:], " "); got != "- - -" {
+		t.Errorf("expected dashes for a row without them, got %s", got)
+	}
+}
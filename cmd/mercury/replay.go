@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/XavierBriggs/Mercury/internal/streamgroups"
+)
+
+// runReplay implements `mercury replay`, rewinding a consumer group on a sport's streams
+// so the group's consumers re-read from a given entry ID or timestamp
+func runReplay(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	redisURL := fs.String("redis", getEnv("REDIS_URL", "localhost:6379"), "Redis address")
+	sport := fs.String("sport", "", "sport key (e.g. basketball_nba)")
+	group := fs.String("group", "", "consumer group to rewind (created if missing)")
+	from := fs.String("from", "", "stream ID (1700000000000-0), RFC3339 timestamp, or \"beginning\"")
+	fs.Parse(args)
+
+	if *sport == "" || *group == "" || *from == "" {
+		fmt.Println("usage: mercury replay --sport <sport_key> --group <group> --from <stream ID|RFC3339|beginning>")
+		return 2
+	}
+
+	startID, err := streamgroups.ParseStart(*from)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 2
+	}
+
+	redisClient, err := newRedisClient(*redisURL)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 2
+	}
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		fmt.Printf("✗ failed to connect to Redis at %s: %v\n", *redisURL, err)
+		return 1
+	}
+
+	if err := streamgroups.Replay(ctx, redisClient, *sport, *group, startID); err != nil {
+		fmt.Printf("✗ replay failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("✓ Group %s on odds.raw.%s and odds.live.%s will re-read from %s\n", *group, *sport, *sport, startID)
+
+	lags, err := streamgroups.Lag(ctx, redisClient, []string{*sport})
+	if err == nil {
+		for _, lag := range lags {
+			if lag.Group == *group && lag.Lag >= 0 {
+				fmt.Printf("  %s: %d entries to replay\n", lag.Stream, lag.Lag)
+			}
+		}
+	}
+
+	return 0
+}
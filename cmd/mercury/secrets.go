@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/secrets"
+)
+
+// Secrets that running clients pick up when they rotate; others need a restart
+const (
+	secretOddsAPIKey         = "ODDS_API_KEY"
+	secretAlexandriaPassword = "ALEXANDRIA_PASSWORD"
+	secretRedisPassword      = "REDIS_PASSWORD"
+)
+
+// loadSecretsManager builds the secrets manager for SECRETS_PROVIDER (nil when unset)
+func loadSecretsManager() (*secrets.Manager, error) {
+	var provider secrets.Provider
+	var err error
+
+	switch name := strings.ToLower(os.Getenv("SECRETS_PROVIDER")); name {
+	case "":
+		return nil, nil
+	case "vault":
+		provider, err = secrets.NewVaultProvider(secrets.VaultConfig{
+			Addr:      os.Getenv("VAULT_ADDR"),
+			Token:     os.Getenv("VAULT_TOKEN"),
+			TokenFile: os.Getenv("VAULT_TOKEN_FILE"),
+			Namespace: os.Getenv("VAULT_NAMESPACE"),
+			Mount:     getEnv("VAULT_KV_MOUNT", "secret"),
+			Path:      os.Getenv("VAULT_SECRET_PATH"),
+			KVVersion: getEnvInt("VAULT_KV_VERSION", 2),
+		})
+	case "aws":
+		provider, err = secrets.NewAWSProvider(secrets.AWSConfig{
+			Region:          getEnv("AWS_REGION", os.Getenv("AWS_DEFAULT_REGION")),
+			SecretID:        os.Getenv("AWS_SECRET_ID"),
+			VersionStage:    os.Getenv("AWS_SECRET_VERSION_STAGE"),
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			Endpoint:        os.Getenv("AWS_SECRETS_MANAGER_ENDPOINT"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q (vault or aws)", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets.NewManager(provider, getEnvDurationOrZero("SECRETS_REFRESH_INTERVAL", 0)), nil
+}
+
+// applySecretRotation makes new database and Redis connections read rotating passwords
+// from the secrets manager
+func applySecretRotation(manager *secrets.Manager, config *Config) {
+	if _, ok := manager.Get(secretAlexandriaPassword); ok {
+		config.Alexandria.PasswordFunc = manager.Getter(secretAlexandriaPassword)
+	}
+	if _, ok := manager.Get(secretRedisPassword); ok {
+		config.Redis.PasswordFunc = manager.Getter(secretRedisPassword)
+	}
+}
+
+// rotateAPIKey points the vendor client at a rotated API key
+func rotateAPIKey(manager *secrets.Manager, adapter *theoddsapi.Client) {
+	if _, ok := manager.Get(secretOddsAPIKey); ok {
+		manager.OnRotate(secretOddsAPIKey, adapter.SetAPIKey)
+	}
+}
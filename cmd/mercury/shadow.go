@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/shadow"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// runShadow implements `mercury shadow`, a report of shadow vendor comparisons per
+// vendor, sport and endpoint from the shadow_comparisons table
+func runShadow(args []string) int {
+	fs := flag.NewFlagSet("shadow", flag.ExitOnError)
+	dsn := fs.String("dsn", getEnv("ALEXANDRIA_DSN", defaultAlexandriaDSN), "Alexandria DSN")
+	since := fs.Duration("since", 24*time.Hour, "report comparisons made in this window, ending now")
+	fromFlag := fs.String("from", "", "start of the range (RFC3339 or YYYY-MM-DD; overrides --since)")
+	toFlag := fs.String("to", "", "end of the range, exclusive (default: now)")
+	fs.Parse(args)
+
+	to := timeutil.Now()
+	from := to.Add(-*since)
+	var err error
+	if *toFlag != "" {
+		if to, err = parseReplayTime(*toFlag); err != nil {
+			fmt.Printf("✗ invalid --to: %v\n", err)
+			return 2
+		}
+	}
+	if *fromFlag != "" {
+		if from, err = parseReplayTime(*fromFlag); err != nil {
+			fmt.Printf("✗ invalid --from: %v\n", err)
+			return 2
+		}
+	}
+
+	db, err := openAlexandria(*dsn)
+	if err != nil {
+		fmt.Printf("✗ failed to connect to Alexandria DB: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		fmt.Printf("✗ failed to ping Alexandria DB: %v\n", err)
+		return 1
+	}
+
+	summaries, err := shadow.Report(ctx, db, from, to)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+	if len(summaries) == 0 {
+		fmt.Println("No shadow comparisons in range")
+		return 0
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VENDOR\tSPORT\tENDPOINT\tCOMPARED\tERRORS\tCOVERAGE\tEXTRA ODDS\tPRICE DIFFS\tMEAN GAP\tMAX GAP\tLATENCY (PRIMARY/SHADOW)")
+	for _, s := range summaries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%.1f%%\t%d\t%.1f%%\t%.4f\t%.4f\t%v / %v\n",
+			s.Vendor, s.SportKey, s.Kind, s.Comparisons, s.Errors, 100*s.Coverage, s.ExtraOdds,
+			100*s.PriceDiffRate, s.MeanProbDiff, s.MaxProbDiff,
+			s.PrimaryLatency.Round(time.Millisecond), s.ShadowLatency.Round(time.Millisecond))
+	}
+	tw.Flush()
+	return 0
+}
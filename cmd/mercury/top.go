@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/freshness"
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/internal/leader"
+	"github.com/XavierBriggs/Mercury/internal/outlier"
+	"github.com/XavierBriggs/Mercury/internal/sportlock"
+	"github.com/XavierBriggs/Mercury/internal/writer"
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	ansiClear = "\033[H\033[2J"
+	ansiBold  = "\033[1m"
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiAmber = "\033[33m"
+	ansiReset = "\033[0m"
+)
+
+// movedLine is a recently published odds change read back from a sport stream
+type movedLine struct {
+	msg writer.StreamMessage
+	at  time.Time
+}
+
+// topState carries counters between refreshes so rates can be computed
+type topState struct {
+	lastDeltas map[string]int64
+	lastAt     time.Time
+}
+
+// runTop implements `mercury top`, a live terminal board of pipeline health read from Redis
+func runTop(args []string) int {
+	fs := flag.NewFlagSet("top", flag.ExitOnError)
+	redisURL := fs.String("redis", getEnv("REDIS_URL", "localhost:6379"), "Redis address")
+	refresh := fs.Duration("interval", 2*time.Second, "refresh interval")
+	lines := fs.Int("lines", 10, "number of recently moved lines to show")
+	staleAfter := fs.Duration("stale-after", 3*time.Minute, "mark a sport stale when it has not polled for this long")
+	once := fs.Bool("once", false, "render one frame and exit")
+	fs.Parse(args)
+
+	redisClient, err := newRedisClient(*redisURL)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 2
+	}
+	defer redisClient.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		fmt.Printf("✗ failed to connect to Redis at %s: %v\n", *redisURL, err)
+		return 1
+	}
+
+	reporter := health.NewReporter(redisClient)
+	state := &topState{lastDeltas: make(map[string]int64)}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	ticker := time.NewTicker(*refresh)
+	defer ticker.Stop()
+
+	for {
+		frame, err := renderTop(ctx, redisClient, reporter, state, *lines, *staleAfter)
+		if err != nil {
+			frame = fmt.Sprintf("%s✗ %v%s\n", ansiRed, err, ansiReset)
+		}
+
+		if *once {
+			fmt.Print(frame)
+			return 0
+		}
+		fmt.Print(ansiClear + frame)
+
+		select {
+		case <-ticker.C:
+		case <-sigChan:
+			return 0
+		}
+	}
+}
+
+// renderTop builds one frame of the board
+func renderTop(ctx context.Context, redisClient *redis.Client, reporter *health.Reporter, state *topState, lines int, staleAfter time.Duration) (string, error) {
+	snapshot, err := reporter.Snapshot(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(state.lastAt).Seconds()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%sMercury top%s  %s\n\n", ansiBold, ansiReset, now.UTC().Format("2006-01-02 15:04:05 UTC"))
+
+	// Leader (only set when replicas run with LEADER_ELECTION=true)
+	if id, remaining, err := leader.Current(ctx, redisClient); err == nil && id != "" {
+		fmt.Fprintf(&b, "Leader: %s (lease %s)\n", id, remaining.Round(time.Second))
+	}
+
+	// Sport lock holders (only set when replicas run with SPORT_SHARDING=true)
+	sportKeys := make([]string, len(snapshot.Sports))
+	for i, sport := range snapshot.Sports {
+		sportKeys[i] = sport.SportKey
+	}
+	if owners, err := sportlock.Owners(ctx, redisClient, sportKeys); err == nil {
+		for _, sportKey := range sportKeys {
+			if owners[sportKey] != "" {
+				fmt.Fprintf(&b, "Polled by: %s → %s\n", sportKey, owners[sportKey])
+			}
+		}
+	}
+
+	// Quota
+	if snapshot.Quota != nil {
+		fmt.Fprintf(&b, "Quota: %d remaining, %d used (updated %s ago)\n",
+			snapshot.Quota.Remaining, snapshot.Quota.Used, formatAge(now.Sub(snapshot.Quota.UpdatedAt)))
+		if until := snapshot.Quota.PausedUntil; until.After(now) {
+			fmt.Fprintf(&b, "%sVendor requests paused (%s) for %s%s\n",
+				ansiAmber, snapshot.Quota.PausedKind, formatAge(until.Sub(now)), ansiReset)
+		}
+		for _, key := range snapshot.Quota.Keys {
+			remaining := "?"
+			if key.Remaining >= 0 {
+				remaining = fmt.Sprint(key.Remaining)
+			}
+			line := fmt.Sprintf("  Key %s: %d requests, %s remaining, %d refused",
+				key.Key, key.Requests, remaining, key.Failures)
+			if key.DisabledUntil.After(now) {
+				line = fmt.Sprintf("%s%s, out of rotation (%s) for %s%s",
+					ansiAmber, line, key.DisabledKind, formatAge(key.DisabledUntil.Sub(now)), ansiReset)
+			}
+			fmt.Fprintln(&b, line)
+		}
+	} else {
+		fmt.Fprintf(&b, "Quota: unknown\n")
+	}
+	if len(snapshot.Usage) > 0 {
+		parts := make([]string, len(snapshot.Usage))
+		for i, u := range snapshot.Usage {
+			parts[i] = fmt.Sprintf("%s %d (%d req)", u.SportKey, u.Credits, u.Requests)
+		}
+		fmt.Fprintf(&b, "Credits today: %s\n", strings.Join(parts, ", "))
+	}
+	b.WriteString("\n")
+
+	// Per-sport poll health and delta rates
+	fmt.Fprintf(&b, "%s%-24s %-7s %-10s %8s %8s %10s %10s %8s %8s %9s%s\n", ansiBold,
+		"SPORT", "STATUS", "LAST POLL", "POLLS", "ERRORS", "DELTAS", "DELTAS/S", "INVALID", "SKIPPED", "SLO P95", ansiReset)
+
+	if len(snapshot.Sports) == 0 {
+		fmt.Fprintf(&b, "(no sports reporting yet)\n")
+	}
+
+	for _, sport := range snapshot.Sports {
+		rate := "-"
+		if prev, ok := state.lastDeltas[sport.SportKey]; ok && elapsed > 0 {
+			rate = fmt.Sprintf("%.2f", float64(sport.Deltas-prev)/elapsed)
+		}
+		state.lastDeltas[sport.SportKey] = sport.Deltas
+
+		fmt.Fprintf(&b, "%-24s %s %-10s %8d %8d %10d %10s %8d %8d %9s\n",
+			sport.SportKey, pollStatus(sport, now, staleAfter), formatAge(now.Sub(sport.LastPollAt)),
+			sport.Polls, sport.Errors, sport.Deltas, rate, sport.Invalid, sport.Skipped, sloP95(sport.SLO))
+
+		if sport.LastError != "" && sport.LastErrorAt.After(sport.LastPollAt) {
+			lastError := sport.LastError
+			if sport.LastErrKind != "" {
+				lastError = "[" + sport.LastErrKind + "] " + lastError
+			}
+			fmt.Fprintf(&b, "  %s└ %s%s\n", ansiRed, truncate(lastError, 90), ansiReset)
+		}
+	}
+	state.lastAt = now
+
+	// Consumer group lag on the odds streams
+	if len(snapshot.Lags) > 0 {
+		fmt.Fprintf(&b, "\n%s%-28s %-20s %8s %8s %9s%s\n", ansiBold,
+			"STREAM", "GROUP", "LAG", "PENDING", "CONSUMERS", ansiReset)
+		for _, lag := range snapshot.Lags {
+			lagStr := "?"
+			if lag.Lag >= 0 {
+				lagStr = fmt.Sprintf("%d", lag.Lag)
+			}
+			fmt.Fprintf(&b, "%-28s %-20s %8s %8d %9d\n",
+				lag.Stream, truncate(lag.Group, 20), lagStr, lag.Pending, lag.Consumers)
+		}
+	}
+
+	// Slowest pipeline stages from each sport's last poll
+	type stageTiming struct {
+		sport string
+		stage string
+		took  time.Duration
+	}
+	var timings []stageTiming
+	for _, sport := range snapshot.Sports {
+		for stage, took := range sport.Stages {
+			timings = append(timings, stageTiming{sport.SportKey, stage, took})
+		}
+	}
+	sort.Slice(timings, func(i, j int) bool { return timings[i].took > timings[j].took })
+	if len(timings) > 5 {
+		timings = timings[:5]
+	}
+
+	fmt.Fprintf(&b, "\n%sSlowest stages (last poll)%s\n", ansiBold, ansiReset)
+	for _, t := range timings {
+		fmt.Fprintf(&b, "  %-24s %-6s %10v\n", t.sport, t.stage, t.took.Round(time.Microsecond))
+	}
+
+	// Books whose price changes reach us latest today (received_at − vendor_last_update)
+	if lags, err := freshness.Read(ctx, redisClient, now); err == nil && len(lags) > 0 {
+		if len(lags) > 5 {
+			lags = lags[:5]
+		}
+		fmt.Fprintf(&b, "\n%sSlowest books today (vendor lag)%s\n", ansiBold, ansiReset)
+		for _, lag := range lags {
+			fmt.Fprintf(&b, "  %-16s p50 %-7s p90 %-7s %8d deltas\n", lag.BookKey,
+				formatQuantile(lag.Histogram, 0.5), formatQuantile(lag.Histogram, 0.9), lag.Histogram.Count())
+		}
+	}
+
+	// Books whose quotes strayed from the consensus most often today
+	if books, err := outlier.Read(ctx, redisClient, now); err == nil && len(books) > 0 && books[0].Outliers > 0 {
+		fmt.Fprintf(&b, "\n%sOutlier books today%s\n", ansiBold, ansiReset)
+		for i, book := range books {
+			if i == 5 || book.Outliers == 0 {
+				break
+			}
+			fmt.Fprintf(&b, "  %-24s %-16s %6d of %d quotes\n", book.SportKey, book.BookKey, book.Outliers, book.Checked)
+		}
+	}
+
+	// Most recently moved lines across all sport streams
+	moved, err := recentMoves(ctx, redisClient, snapshot.Sports, lines)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(&b, "\n%sRecently moved lines%s\n", ansiBold, ansiReset)
+	for _, m := range moved {
+		point := ""
+		if m.msg.Point != nil {
+			point = fmt.Sprintf(" %+.1f", *m.msg.Point)
+		}
+		outcome := m.msg.OutcomeName
+		if m.msg.Description != "" {
+			outcome = m.msg.Description + " " + outcome
+		}
+		fmt.Fprintf(&b, "  %-8s %-16s %-10s %-12s %-28s %+5d%s\n",
+			formatAge(now.Sub(m.at)), m.msg.SportKey, m.msg.MarketKey, m.msg.BookKey,
+			truncate(outcome, 28), m.msg.Price, point)
+	}
+
+	return b.String(), nil
+}
+
+// recentMoves reads the newest messages from each sport's odds streams (pre-match and
+// in-play), newest first
+func recentMoves(ctx context.Context, redisClient *redis.Client, sports []health.SportHealth, limit int) ([]movedLine, error) {
+	var moved []movedLine
+
+	for _, sport := range sports {
+		for _, stream := range []string{consumer.StreamKey(sport.SportKey), consumer.LiveStreamKey(sport.SportKey)} {
+			entries, err := redisClient.XRevRangeN(ctx, stream, "+", "-", int64(limit)).Result()
+			if err != nil && err != redis.Nil {
+				return nil, fmt.Errorf("read stream %s: %w", stream, err)
+			}
+
+			for _, entry := range entries {
+				outcomes, err := consumer.DecodeEntry(entry.Values)
+				if err != nil {
+					continue
+				}
+
+				for _, msg := range outcomes {
+					moved = append(moved, movedLine{msg: msg, at: msg.ReceivedAt})
+				}
+			}
+		}
+	}
+
+	sort.Slice(moved, func(i, j int) bool { return moved[i].at.After(moved[j].at) })
+	if len(moved) > limit {
+		moved = moved[:limit]
+	}
+	return moved, nil
+}
+
+// pollStatus classifies a sport's polling as OK, STALE or ERROR (colored, fixed width)
+func pollStatus(sport health.SportHealth, now time.Time, staleAfter time.Duration) string {
+	switch {
+	case sport.LastErrorAt.After(sport.LastPollAt):
+		return ansiRed + "ERROR  " + ansiReset
+	case sport.LastPollAt.IsZero() || now.Sub(sport.LastPollAt) > staleAfter:
+		return ansiAmber + "STALE  " + ansiReset
+	default:
+		return ansiGreen + "OK     " + ansiReset
+	}
+}
+
+// sloP95 renders a sport's rolling p95 pipeline latency, marked when over target
+func sloP95(stats health.SLOStats) string {
+	if stats.Samples == 0 {
+		return "-"
+	}
+	p95 := stats.P95.Round(100 * time.Microsecond).String()
+	if stats.P95 > stats.Target {
+		return "!" + p95
+	}
+	return p95
+}
+
+// formatAge renders a duration compactly (e.g. 4s, 3m, 2h)
+func formatAge(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d > 24*time.Hour*365:
+		return "never"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
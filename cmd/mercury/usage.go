@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/internal/usage"
+)
+
+// runUsage implements `mercury usage`, a daily report of vendor credits by sport,
+// endpoint and market from the vendor_usage_daily rollup
+func runUsage(args []string) int {
+	fs := flag.NewFlagSet("usage", flag.ExitOnError)
+	dsn := fs.String("dsn", getEnv("ALEXANDRIA_DSN", defaultAlexandriaDSN), "Alexandria DSN")
+	days := fs.Int("days", 7, "number of UTC days to report, ending today")
+	fromFlag := fs.String("from", "", "first day (RFC3339 or YYYY-MM-DD; overrides --days)")
+	toFlag := fs.String("to", "", "end of the range, exclusive (default: from + 24h)")
+	refresh := fs.Bool("refresh", true, "roll up raw usage for the range before reporting")
+	fs.Parse(args)
+
+	to := timeutil.Now().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	from := to.Add(-time.Duration(*days) * 24 * time.Hour)
+	if *fromFlag != "" {
+		var err error
+		if from, err = parseReplayTime(*fromFlag); err != nil {
+			fmt.Printf("✗ invalid --from: %v\n", err)
+			return 2
+		}
+		to = from.Add(24 * time.Hour)
+		if *toFlag != "" {
+			if to, err = parseReplayTime(*toFlag); err != nil {
+				fmt.Printf("✗ invalid --to: %v\n", err)
+				return 2
+			}
+		}
+	}
+
+	db, err := openAlexandria(*dsn)
+	if err != nil {
+		fmt.Printf("✗ failed to connect to Alexandria DB: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		fmt.Printf("✗ failed to ping Alexandria DB: %v\n", err)
+		return 1
+	}
+
+	if *refresh {
+		if err := usage.Rollup(ctx, db, from, to); err != nil {
+			fmt.Printf("✗ %v\n", err)
+			return 1
+		}
+	}
+
+	rows, err := usage.Report(ctx, db, from, to)
+	if err != nil {
+		fmt.Printf("✗ %v\n", err)
+		return 1
+	}
+	if len(rows) == 0 {
+		fmt.Println("No vendor usage recorded in range")
+		return 0
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tSPORT\tENDPOINT\tMARKET\tREQUESTS\tCREDITS")
+	var total float64
+	for _, row := range rows {
+		market := row.MarketKey
+		if market == "" {
+			market = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%.1f\n",
+			row.Day.Format("2006-01-02"), row.SportKey, row.Kind, market, row.Requests, row.Credits)
+		total += row.Credits
+	}
+	tw.Flush()
+
+	fmt.Printf("\nTotal: %.0f credits (%s)\n", total, usage.Summarize(rows))
+	return 0
+}
@@ -0,0 +1,10 @@
+// Package alexandria embeds the Alexandria schema migrations so the mercury binary
+// can apply and verify them without the repository on disk
+package alexandria
+
+import "embed"
+
+// Migrations holds migrations/NNN_name.sql, applied in version order by internal/migrate
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
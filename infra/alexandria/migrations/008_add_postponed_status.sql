@@ -0,0 +1,11 @@
+-- Alexandria DB Migration 008: Add postponed event status
+-- Events the vendor stops listing before tipoff are marked postponed so their
+-- Talos game pages can be closed
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_event_status;
+
+ALTER TABLE events
+ADD CONSTRAINT chk_event_status
+CHECK (event_status IN ('upcoming', 'live', 'completed', 'cancelled', 'postponed'));
+
+COMMENT ON COLUMN events.event_status IS 'Current status: upcoming, live, completed, cancelled, postponed';
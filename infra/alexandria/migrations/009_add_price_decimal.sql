@@ -0,0 +1,15 @@
+-- Alexandria DB Migration 009: Decimal odds
+-- Stores decimal prices alongside American so non-US vendors/consumers avoid lossy conversions
+
+ALTER TABLE odds_raw ADD COLUMN IF NOT EXISTS price_decimal DECIMAL(10,4);
+
+-- Backfill from American odds for existing rows
+UPDATE odds_raw
+SET price_decimal = CASE
+    WHEN price >= 100 THEN 1 + price / 100.0
+    WHEN price <= -100 THEN 1 + 100.0 / -price
+END
+WHERE price_decimal IS NULL;
+
+COMMENT ON COLUMN odds_raw.price IS 'American odds (e.g., -110, +150); converted when the vendor quotes decimal';
+COMMENT ON COLUMN odds_raw.price_decimal IS 'Decimal odds (e.g., 1.91, 2.50); native when the vendor quotes decimal';
@@ -0,0 +1,19 @@
+-- Alexandria DB Migration 010: Outcome description for player props
+-- Props outcomes are "Over"/"Under" with the player in the vendor's description field;
+-- without it, rows for different players in the same market are indistinguishable
+
+ALTER TABLE odds_raw ADD COLUMN IF NOT EXISTS description VARCHAR(200) NOT NULL DEFAULT '';
+ALTER TABLE closing_lines ADD COLUMN IF NOT EXISTS description VARCHAR(200) NOT NULL DEFAULT '';
+
+-- Latest-odds lookups now identify an outcome by (event, market, book, outcome_name, description)
+DROP INDEX IF EXISTS idx_odds_raw_current_odds;
+CREATE INDEX idx_odds_raw_current_odds ON odds_raw(event_id, market_key, book_key, outcome_name, description)
+WHERE is_latest = true;
+
+-- Closing lines for different players in the same props market must not collide
+ALTER TABLE closing_lines DROP CONSTRAINT IF EXISTS closing_lines_pkey;
+ALTER TABLE closing_lines
+ADD PRIMARY KEY (event_id, market_key, book_key, outcome_name, description, point);
+
+COMMENT ON COLUMN odds_raw.description IS 'Player name for props outcomes (e.g., LeBron James); empty for featured markets';
+COMMENT ON COLUMN closing_lines.description IS 'Player name for props outcomes; empty for featured markets';
@@ -0,0 +1,6 @@
+-- Alexandria DB Migration 011: Bet deep links
+-- Vendor bet-slip links per outcome so bet-placement tooling can jump straight to the book
+
+ALTER TABLE odds_raw ADD COLUMN IF NOT EXISTS deep_link TEXT;
+
+COMMENT ON COLUMN odds_raw.deep_link IS 'Vendor bet link (outcome, else market, else bookmaker level); NULL if the vendor did not provide one';
@@ -0,0 +1,31 @@
+-- Alexandria DB Migration 012: Book reliability scoring
+-- Per-book data-quality score (staleness, coverage, anomalies, closing line agreement)
+-- used by consensus/edge engines as a dynamic weight instead of static sharp/soft labels
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS reliability_score DECIMAL(5,4);
+ALTER TABLE books ADD COLUMN IF NOT EXISTS reliability_updated_at TIMESTAMPTZ;
+
+ALTER TABLE books DROP CONSTRAINT IF EXISTS chk_reliability_score;
+ALTER TABLE books
+ADD CONSTRAINT chk_reliability_score
+CHECK (reliability_score IS NULL OR (reliability_score >= 0 AND reliability_score <= 1));
+
+-- History of every scoring run (for trend analysis and auditing weight changes)
+CREATE TABLE IF NOT EXISTS book_reliability (
+    id BIGSERIAL PRIMARY KEY,
+    book_key VARCHAR(50) NOT NULL REFERENCES books(book_key) ON DELETE CASCADE,
+    computed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    rows_observed INT NOT NULL,
+    staleness_seconds DOUBLE PRECISION NOT NULL,
+    coverage DOUBLE PRECISION NOT NULL,
+    anomaly_count INT NOT NULL,
+    closing_deviation DOUBLE PRECISION NOT NULL,
+    closing_samples INT NOT NULL,
+    score DECIMAL(5,4) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_reliability_book ON book_reliability(book_key, computed_at DESC);
+
+COMMENT ON COLUMN books.reliability_score IS 'Data-quality score 0-1 (NULL until first scoring run); consumers fall back to book_type when NULL';
+COMMENT ON TABLE book_reliability IS 'Per-run book reliability signals and scores';
+COMMENT ON COLUMN book_reliability.closing_deviation IS 'Mean |implied prob - closing consensus implied prob| across closing lines';
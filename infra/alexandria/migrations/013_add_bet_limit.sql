@@ -0,0 +1,6 @@
+-- Alexandria DB Migration 013: Max bet limits
+-- Sharp books/exchanges expose max bet sizes; limit moves often precede line moves
+
+ALTER TABLE odds_raw ADD COLUMN IF NOT EXISTS bet_limit DECIMAL(14,2);
+
+COMMENT ON COLUMN odds_raw.bet_limit IS 'Max bet size quoted by the book; NULL when the vendor does not expose limits';
@@ -0,0 +1,30 @@
+-- Alexandria DB Migration 014: Futures odds table
+-- Futures/outrights (championship winner, win totals) have no single event commence
+-- time, so they are stored outside the event-centric odds_raw table
+
+CREATE TABLE IF NOT EXISTS futures_odds (
+    id BIGSERIAL PRIMARY KEY,
+    sport_key VARCHAR(50) NOT NULL REFERENCES sports(sport_key),
+    futures_key VARCHAR(100) NOT NULL,
+    market_key VARCHAR(50) NOT NULL,
+    book_key VARCHAR(50) NOT NULL REFERENCES books(book_key),
+    outcome_name VARCHAR(200) NOT NULL,
+    price INT NOT NULL,
+    price_decimal DECIMAL(10,4),
+    point DECIMAL(10,2),
+    expires_at TIMESTAMPTZ,
+    vendor_last_update TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    is_latest BOOLEAN NOT NULL DEFAULT true
+);
+
+-- Fast "current futures board" queries
+CREATE INDEX idx_futures_odds_current ON futures_odds(futures_key, market_key, book_key, outcome_name)
+WHERE is_latest = true;
+
+-- History per outcome
+CREATE INDEX idx_futures_odds_outcome ON futures_odds(futures_key, outcome_name, received_at DESC);
+
+COMMENT ON TABLE futures_odds IS 'Futures/outright odds (low-frequency track, published to futures.raw.<sport>)';
+COMMENT ON COLUMN futures_odds.futures_key IS 'Vendor outright key (e.g., basketball_nba_championship_winner)';
+COMMENT ON COLUMN futures_odds.expires_at IS 'When the market settles, if the vendor provides it';
@@ -0,0 +1,31 @@
+-- Alexandria DB Migration 015: Detected edges
+-- Soft-book prices with positive expected value against a sharp book's no-vig line
+-- (also published to the edges.detected Redis stream)
+
+CREATE TABLE IF NOT EXISTS edges_detected (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(100) NOT NULL REFERENCES events(event_id) ON DELETE CASCADE,
+    sport_key VARCHAR(50) NOT NULL REFERENCES sports(sport_key),
+    market_key VARCHAR(50) NOT NULL,
+    book_key VARCHAR(50) NOT NULL REFERENCES books(book_key),
+    outcome_name VARCHAR(200) NOT NULL,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    point DECIMAL(10,2),
+    price INT NOT NULL,
+    price_decimal DECIMAL(10,4) NOT NULL,
+    sharp_book_key VARCHAR(50) NOT NULL REFERENCES books(book_key),
+    fair_prob DECIMAL(6,5) NOT NULL,
+    edge_pct DECIMAL(7,2) NOT NULL,
+    sharp_updated_at TIMESTAMPTZ NOT NULL,
+    soft_updated_at TIMESTAMPTZ NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Recent edges, and edges per event
+CREATE INDEX idx_edges_detected_at ON edges_detected(detected_at DESC);
+CREATE INDEX idx_edges_detected_event ON edges_detected(event_id, market_key, detected_at DESC);
+
+COMMENT ON TABLE edges_detected IS 'Positive-EV soft-book prices versus a sharp no-vig reference line';
+COMMENT ON COLUMN edges_detected.fair_prob IS 'Sharp no-vig win probability for the outcome';
+COMMENT ON COLUMN edges_detected.edge_pct IS 'Expected value in percent at the soft price (3.20 = +3.2%)';
+COMMENT ON COLUMN edges_detected.sharp_updated_at IS 'When the oldest sharp quote on the line was received';
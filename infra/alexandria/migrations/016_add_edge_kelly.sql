@@ -0,0 +1,7 @@
+-- Alexandria DB Migration 016: Kelly stake on detected edges
+-- Full-Kelly stake sizing for each edge (pkg/oddsmath), so consumers can size bets
+-- without recomputing from fair_prob and price_decimal
+
+ALTER TABLE edges_detected ADD COLUMN IF NOT EXISTS kelly_pct DECIMAL(7,2) NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN edges_detected.kelly_pct IS 'Full-Kelly stake in percent of bankroll at the soft price (scale down for fractional Kelly)';
@@ -0,0 +1,11 @@
+-- Alexandria DB Migration 017: Idempotent odds writes
+-- Hash of event/market/book/outcome/description/price/point/vendor_last_update
+-- (models.RawOdds.DedupeKey) so replays, retries and overlapping pollers cannot
+-- insert the same quote twice. Rows written before this migration keep NULL.
+
+ALTER TABLE odds_raw ADD COLUMN IF NOT EXISTS dedupe_key CHAR(32);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_odds_raw_dedupe_key ON odds_raw(dedupe_key)
+WHERE dedupe_key IS NOT NULL;
+
+COMMENT ON COLUMN odds_raw.dedupe_key IS 'Quote identity hash (writer skips rows whose key already exists)';
@@ -0,0 +1,24 @@
+-- Alexandria DB Migration 018: Parser quarantine
+-- Vendor records the adapter rejected (missing fields, malformed timestamps,
+-- absurd prices, duplicate outcomes) instead of storing them with fallbacks.
+-- No foreign keys and unbounded key columns: quarantined rows carry whatever the
+-- vendor sent, often for events we never stored.
+
+CREATE TABLE IF NOT EXISTS odds_quarantine (
+    id BIGSERIAL PRIMARY KEY,
+    vendor VARCHAR(50) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    sport_key TEXT NOT NULL DEFAULT '',
+    event_id TEXT NOT NULL DEFAULT '',
+    book_key TEXT NOT NULL DEFAULT '',
+    market_key TEXT NOT NULL DEFAULT '',
+    reason VARCHAR(50) NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+    received_at TIMESTAMPTZ NOT NULL,
+    quarantined_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_odds_quarantine_received ON odds_quarantine(received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_odds_quarantine_reason ON odds_quarantine(reason, received_at DESC);
+
+COMMENT ON TABLE odds_quarantine IS 'Vendor records rejected by the adapter parser, kept for inspection';
@@ -0,0 +1,15 @@
+-- Alexandria DB Migration 019: Team name aliases
+-- Per-sport spellings mapped to one canonical team name, loaded at startup by
+-- internal/normalize on top of the aliases configured in each sport module
+-- (rows here win for the same alias). Aliases match case-insensitively.
+
+CREATE TABLE IF NOT EXISTS team_aliases (
+    sport_key VARCHAR(50) NOT NULL REFERENCES sports(sport_key) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    canonical VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sport_key, alias)
+);
+
+COMMENT ON TABLE team_aliases IS 'Vendor/book team name spellings mapped to canonical names';
+COMMENT ON COLUMN team_aliases.canonical IS 'Name stored on events and used in Talos game keys';
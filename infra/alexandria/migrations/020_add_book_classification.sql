@@ -0,0 +1,24 @@
+-- Alexandria DB Migration 020: Book classification and default weights
+-- Adds the 'exchange' book type and a per-book default consensus weight used
+-- until a reliability score exists. Rows are seeded from config by internal/books
+-- and edited through the admin API.
+
+ALTER TABLE books DROP CONSTRAINT IF EXISTS chk_book_type;
+ALTER TABLE books
+ADD CONSTRAINT chk_book_type
+CHECK (book_type IN ('sharp', 'soft', 'exchange'));
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS default_weight DECIMAL(5,4);
+
+ALTER TABLE books DROP CONSTRAINT IF EXISTS chk_default_weight;
+ALTER TABLE books
+ADD CONSTRAINT chk_default_weight
+CHECK (default_weight IS NULL OR (default_weight >= 0 AND default_weight <= 1));
+
+-- Exchanges were auto-inserted as 'soft' by the writer before this migration
+UPDATE books SET book_type = 'exchange', updated_at = NOW()
+WHERE book_type = 'soft'
+  AND (book_key LIKE 'betfair_ex_%' OR book_key IN ('matchbook', 'smarkets', 'novig', 'prophetx'));
+
+COMMENT ON COLUMN books.book_type IS 'sharp = fast, efficient, low limits; soft = slower, higher vig; exchange = peer-to-peer, commission instead of vig';
+COMMENT ON COLUMN books.default_weight IS 'Consensus weight 0-1 used while reliability_score is NULL (NULL = book_type default)';
@@ -0,0 +1,20 @@
+-- Alexandria DB Migration 021: Participants
+-- Teams (or players) as the vendor's participants endpoint lists them, refreshed
+-- by internal/participants. Full names are canonical (team aliases applied), so
+-- they match events.home_team/away_team; IDs are stable across renames and are
+-- attached to stream messages.
+
+CREATE TABLE IF NOT EXISTS participants (
+    sport_key VARCHAR(50) NOT NULL REFERENCES sports(sport_key) ON DELETE CASCADE,
+    participant_id VARCHAR(100) NOT NULL,
+    full_name VARCHAR(100) NOT NULL,
+    first_seen TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sport_key, participant_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_participants_name ON participants(sport_key, LOWER(full_name));
+
+COMMENT ON TABLE participants IS 'Vendor participant rosters with stable IDs per sport';
+COMMENT ON COLUMN participants.full_name IS 'Canonical name (matches events.home_team/away_team)';
+COMMENT ON COLUMN participants.last_seen IS 'Last refresh that listed the participant';
@@ -0,0 +1,38 @@
+-- Alexandria DB Migration 022: Vendor credit usage
+-- One row per vendor request with the credit headers it returned
+-- (x-requests-last/used/remaining), written by internal/usage, plus a daily
+-- rollup per sport, endpoint and market so operators can see what spends the
+-- API budget. Raw rows are pruned after USAGE_RETENTION; the rollup is kept.
+
+CREATE TABLE IF NOT EXISTS vendor_usage (
+    id BIGSERIAL PRIMARY KEY,
+    vendor VARCHAR(50) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    sport_key TEXT NOT NULL DEFAULT '',
+    event_id TEXT NOT NULL DEFAULT '',
+    markets TEXT[] NOT NULL DEFAULT '{}',
+    status_code INTEGER NOT NULL,
+    cost INTEGER,      -- x-requests-last (NULL when not reported)
+    used INTEGER,      -- x-requests-used after the request
+    remaining INTEGER, -- x-requests-remaining after the request
+    requested_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_vendor_usage_requested ON vendor_usage(requested_at);
+
+CREATE TABLE IF NOT EXISTS vendor_usage_daily (
+    day DATE NOT NULL,
+    vendor VARCHAR(50) NOT NULL,
+    sport_key TEXT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    market_key TEXT NOT NULL, -- '' for requests without markets (events, participants)
+    requests INTEGER NOT NULL,
+    credits DECIMAL(12,2) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (day, vendor, sport_key, kind, market_key)
+);
+
+COMMENT ON TABLE vendor_usage IS 'Credit cost of each vendor request, from response headers';
+COMMENT ON TABLE vendor_usage_daily IS 'Daily (UTC) vendor usage per sport, endpoint and market';
+COMMENT ON COLUMN vendor_usage_daily.requests IS 'Requests that included the market';
+COMMENT ON COLUMN vendor_usage_daily.credits IS 'Credits attributed to the market (request cost split evenly across its markets)';
@@ -0,0 +1,31 @@
+-- Alexandria DB Migration 023: Shadow vendor comparisons
+-- One row per primary fetch repeated against a shadow (candidate) vendor, with the
+-- coverage, price and latency diff. Shadow odds themselves are never stored.
+
+CREATE TABLE IF NOT EXISTS shadow_comparisons (
+    id BIGSERIAL PRIMARY KEY,
+    vendor VARCHAR(50) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    sport_key TEXT NOT NULL,
+    event_id TEXT NOT NULL DEFAULT '',
+    primary_events INTEGER NOT NULL DEFAULT 0,
+    shadow_events INTEGER NOT NULL DEFAULT 0,
+    matched_events INTEGER NOT NULL DEFAULT 0,
+    primary_odds INTEGER NOT NULL DEFAULT 0,
+    shadow_odds INTEGER NOT NULL DEFAULT 0,
+    matched_odds INTEGER NOT NULL DEFAULT 0,
+    price_diffs INTEGER NOT NULL DEFAULT 0,
+    mean_prob_diff DOUBLE PRECISION NOT NULL DEFAULT 0,
+    max_prob_diff DOUBLE PRECISION NOT NULL DEFAULT 0,
+    missing_books TEXT[] NOT NULL DEFAULT '{}',
+    extra_books TEXT[] NOT NULL DEFAULT '{}',
+    primary_latency_ms INTEGER NOT NULL DEFAULT 0,
+    shadow_latency_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    compared_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_shadow_comparisons_compared ON shadow_comparisons(compared_at DESC);
+CREATE INDEX IF NOT EXISTS idx_shadow_comparisons_vendor ON shadow_comparisons(vendor, sport_key, compared_at DESC);
+
+COMMENT ON TABLE shadow_comparisons IS 'Diffs of primary vendor fetches against a shadow vendor evaluated before cutover';
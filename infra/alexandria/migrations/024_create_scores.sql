@@ -0,0 +1,25 @@
+-- Alexandria DB Migration 024: Scores
+-- Append-only log of live score changes written by internal/scores: one row per
+-- period whose score (or completed flag) changed since the last poll. The running
+-- game total uses period 'game'; vendors that report period breakdowns add one row
+-- per period. Joined with odds on event_id and received_at to price on game state.
+
+CREATE TABLE IF NOT EXISTS scores (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(100) NOT NULL REFERENCES events(event_id) ON DELETE CASCADE,
+    sport_key VARCHAR(50) NOT NULL,
+    period VARCHAR(20) NOT NULL,
+    home_score TEXT NOT NULL,
+    away_score TEXT NOT NULL,
+    completed BOOLEAN NOT NULL DEFAULT false,
+    vendor_last_update TIMESTAMPTZ,
+    received_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_scores_event ON scores(event_id, period, received_at DESC);
+CREATE INDEX IF NOT EXISTS idx_scores_sport_time ON scores(sport_key, received_at);
+
+COMMENT ON TABLE scores IS 'Live score changes per event and period';
+COMMENT ON COLUMN scores.period IS 'game = running total; otherwise the vendor period label';
+COMMENT ON COLUMN scores.home_score IS 'As sent by the vendor (not always numeric)';
+COMMENT ON COLUMN scores.completed IS 'The vendor marked the game final';
@@ -0,0 +1,16 @@
+-- Alexandria DB Migration 025: Live odds table
+-- In-play odds, written here instead of odds_raw when LIVE_ODDS_TABLE is enabled.
+-- In-play volume and retention differ from pre-match, and keeping it apart leaves
+-- odds_raw's latest rows as they stood at tip-off. Same columns, defaults and indexes
+-- as odds_raw (ids share its sequence, so they stay unique across both tables).
+
+CREATE TABLE IF NOT EXISTS odds_live (LIKE odds_raw INCLUDING ALL);
+
+ALTER TABLE odds_live DROP CONSTRAINT IF EXISTS odds_live_event_id_fkey;
+ALTER TABLE odds_live ADD CONSTRAINT odds_live_event_id_fkey
+    FOREIGN KEY (event_id) REFERENCES events(event_id) ON DELETE CASCADE;
+ALTER TABLE odds_live DROP CONSTRAINT IF EXISTS odds_live_book_key_fkey;
+ALTER TABLE odds_live ADD CONSTRAINT odds_live_book_key_fkey
+    FOREIGN KEY (book_key) REFERENCES books(book_key);
+
+COMMENT ON TABLE odds_live IS 'In-play vendor odds (same shape as odds_raw)';
@@ -0,0 +1,10 @@
+-- Alexandria DB Migration 026: Outcome side
+-- Moneyline and spread outcomes are resolved to the event's home or away team
+-- however the book formats the name ("LA Lakers", "Lakers -3.5"), so outcomes can be
+-- matched across books by side. NULL for outcomes that do not name a team.
+
+ALTER TABLE odds_raw ADD COLUMN IF NOT EXISTS side VARCHAR(4);
+ALTER TABLE odds_live ADD COLUMN IF NOT EXISTS side VARCHAR(4);
+
+COMMENT ON COLUMN odds_raw.side IS 'home or away for team-sided outcomes (moneyline, spreads); NULL otherwise';
+COMMENT ON COLUMN odds_live.side IS 'home or away for team-sided outcomes (moneyline, spreads); NULL otherwise';
@@ -0,0 +1,12 @@
+-- Alexandria DB Migration 027: Quarantine review
+-- Quarantined records can be marked reviewed (with a note) through the admin API,
+-- so the queue of records nobody has looked at stays visible.
+
+ALTER TABLE odds_quarantine ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ;
+ALTER TABLE odds_quarantine ADD COLUMN IF NOT EXISTS review_note TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_odds_quarantine_unreviewed
+    ON odds_quarantine(received_at DESC) WHERE reviewed_at IS NULL;
+
+COMMENT ON COLUMN odds_quarantine.reviewed_at IS 'When the record was marked reviewed (NULL = awaiting review)';
+COMMENT ON COLUMN odds_quarantine.review_note IS 'Reviewer note (e.g. vendor ticket, rule to relax)';
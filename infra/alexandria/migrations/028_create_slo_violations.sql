@@ -0,0 +1,25 @@
+-- Alexandria DB Migration 028: Pipeline SLO violations
+-- One row per poll whose delta → write → cache time exceeded the pipeline SLO, with
+-- each stage's share and the stage that took longest, so regressions can be traced
+-- to a stage.
+
+CREATE TABLE IF NOT EXISTS slo_violations (
+    id BIGSERIAL PRIMARY KEY,
+    instance_id TEXT NOT NULL DEFAULT '',
+    sport_key TEXT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    latency_ms DOUBLE PRECISION NOT NULL,
+    target_ms DOUBLE PRECISION NOT NULL,
+    stage VARCHAR(20) NOT NULL,
+    delta_ms DOUBLE PRECISION NOT NULL DEFAULT 0,
+    write_ms DOUBLE PRECISION NOT NULL DEFAULT 0,
+    cache_ms DOUBLE PRECISION NOT NULL DEFAULT 0,
+    deltas INTEGER NOT NULL DEFAULT 0,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_slo_violations_occurred ON slo_violations(occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_slo_violations_sport ON slo_violations(sport_key, occurred_at DESC);
+
+COMMENT ON TABLE slo_violations IS 'Polls whose Mercury pipeline time (delta → write → cache) exceeded the SLO';
+COMMENT ON COLUMN slo_violations.stage IS 'Stage that took longest in the violating poll (delta, write or cache)';
@@ -0,0 +1,8 @@
+-- Alexandria DB Migration 029: Muted books
+-- A muted book is left out of vendor requests where a sport requests bookmakers by
+-- key, and its odds are dropped before the delta engine otherwise. Toggled through
+-- the admin API and picked up by running instances on the next books refresh.
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS muted BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN books.muted IS 'Excluded from polling (requests and ingested odds) until unmuted';
@@ -0,0 +1,34 @@
+-- Alexandria DB Migration 030: Per-poll audit
+-- One compact row per poll: the sport, track and event polled, the regions and
+-- markets requested, what the vendor returned (status, bytes, credits) and what the
+-- pipeline made of it (events, odds, deltas), so operators can reconstruct each
+-- poll without its logs. Written by internal/pollaudit; pruned after
+-- POLL_AUDIT_RETENTION.
+
+CREATE TABLE IF NOT EXISTS poll_audit (
+    id BIGSERIAL PRIMARY KEY,
+    sport_key TEXT NOT NULL,
+    track VARCHAR(20) NOT NULL,
+    event_id TEXT NOT NULL DEFAULT '',
+    regions TEXT[] NOT NULL DEFAULT '{}',
+    markets TEXT[] NOT NULL DEFAULT '{}',
+    requests INTEGER NOT NULL DEFAULT 0,
+    status_code INTEGER,  -- NULL when no response arrived
+    bytes BIGINT NOT NULL DEFAULT 0,
+    events INTEGER NOT NULL DEFAULT 0,
+    odds INTEGER NOT NULL DEFAULT 0,
+    deltas INTEGER NOT NULL DEFAULT 0,
+    credits INTEGER,      -- NULL when the vendor did not report a cost
+    error_kind VARCHAR(30) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms DOUBLE PRECISION NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_poll_audit_started ON poll_audit(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_poll_audit_sport ON poll_audit(sport_key, started_at DESC);
+
+COMMENT ON TABLE poll_audit IS 'One row per poll: request, vendor response and pipeline outcome';
+COMMENT ON COLUMN poll_audit.status_code IS 'Last failed HTTP status of the poll''s requests, else 200';
+COMMENT ON COLUMN poll_audit.bytes IS 'Response bytes as received (compressed when gzipped)';
+COMMENT ON COLUMN poll_audit.error_kind IS 'pkg/errors label of the poll''s error; empty when it succeeded';
@@ -0,0 +1,15 @@
+-- Alexandria DB Migration 031: Poll correlation ID
+-- Each poll gets an ID when it starts. It is stored on the poll's audit row and on
+-- every odds row the poll wrote, and carried in the stream messages, so an odds
+-- update seen downstream can be traced back to the poll (request, vendor response,
+-- timings) that produced it. NULL for rows written before this migration.
+
+ALTER TABLE poll_audit ADD COLUMN IF NOT EXISTS poll_id TEXT;
+ALTER TABLE odds_raw ADD COLUMN IF NOT EXISTS poll_id TEXT;
+ALTER TABLE odds_live ADD COLUMN IF NOT EXISTS poll_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_poll_audit_poll_id ON poll_audit(poll_id);
+
+COMMENT ON COLUMN poll_audit.poll_id IS 'Correlation ID shared with the poll''s odds rows and stream messages';
+COMMENT ON COLUMN odds_raw.poll_id IS 'Poll that fetched the quote (poll_audit.poll_id)';
+COMMENT ON COLUMN odds_live.poll_id IS 'Poll that fetched the quote (poll_audit.poll_id)';
@@ -0,0 +1,22 @@
+-- Alexandria DB Migration 032: Admin audit log
+-- One row per runtime change made through the admin API (book edits and mutes,
+-- quarantine reviews): the operator whose token made it, where from, the action and
+-- target, and the target's state before and after. Written by internal/admin, which
+-- also emits each row on the mercury:admin:audit Redis stream.
+
+CREATE TABLE IF NOT EXISTS admin_audit (
+    id BIGSERIAL PRIMARY KEY,
+    operator TEXT NOT NULL,
+    remote_addr TEXT NOT NULL DEFAULT '',
+    action VARCHAR(40) NOT NULL,
+    target TEXT NOT NULL DEFAULT '',
+    before JSONB,  -- NULL when the target did not exist or has no prior state
+    after JSONB,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_audit_occurred ON admin_audit(occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_admin_audit_target ON admin_audit(action, target, occurred_at DESC);
+
+COMMENT ON TABLE admin_audit IS 'Runtime changes made through the admin API: who, what and when';
+COMMENT ON COLUMN admin_audit.operator IS 'Operator named by ADMIN_OPERATOR_TOKENS, "admin" for ADMIN_TOKEN, "anonymous" without auth';
@@ -0,0 +1,24 @@
+-- Alexandria DB Migration 033: Props polling schedules
+-- One row per event with an active props poller: the event, where the poller is on
+-- the sport's ramp and when it polls next. Written by the scheduler after every props
+-- poll and deleted when the event is over, so a restart resumes each poller at its
+-- saved next poll instead of polling every discovered event at once.
+
+CREATE TABLE IF NOT EXISTS props_schedule (
+    event_id VARCHAR(100) PRIMARY KEY,
+    sport_key VARCHAR(50) NOT NULL,
+    home_team TEXT NOT NULL DEFAULT '',
+    away_team TEXT NOT NULL DEFAULT '',
+    commence_time TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    polls INTEGER NOT NULL DEFAULT 0,
+    last_poll_at TIMESTAMPTZ,  -- NULL until the first poll
+    next_poll_at TIMESTAMPTZ,  -- NULL while a poll runs
+    ramp_interval_ms DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_props_schedule_sport ON props_schedule(sport_key, commence_time);
+
+COMMENT ON TABLE props_schedule IS 'Per-event props poller schedules, resumed on restart';
+COMMENT ON COLUMN props_schedule.ramp_interval_ms IS 'Interval of the ramp tier the event is in, before jitter and quota slowdown';
@@ -0,0 +1,36 @@
+-- Alexandria DB Migration 034: OHLC candles of line movement
+-- 1m, 5m and 1h open/high/low/close of price and point per outcome and book, rolled
+-- up from odds_raw and odds_live by internal/ohlc. Candles keep the shape of line
+-- movement at a fraction of the rows, so superseded raw quotes can be pruned
+-- (OHLC_RAW_RETENTION). No foreign keys, so candles outlive their events.
+
+CREATE TABLE IF NOT EXISTS odds_ohlc (
+    event_id VARCHAR(100) NOT NULL,
+    sport_key VARCHAR(50) NOT NULL,
+    market_key VARCHAR(50) NOT NULL,
+    book_key VARCHAR(50) NOT NULL,
+    outcome_name VARCHAR(200) NOT NULL,
+    description VARCHAR(200) NOT NULL DEFAULT '',
+    interval_seconds INTEGER NOT NULL,
+    bucket_start TIMESTAMPTZ NOT NULL,
+    price_open INT NOT NULL,
+    price_high INT NOT NULL,
+    price_low INT NOT NULL,
+    price_close INT NOT NULL,
+    point_open DECIMAL(10,2),
+    point_high DECIMAL(10,2),
+    point_low DECIMAL(10,2),
+    point_close DECIMAL(10,2),
+    changes INTEGER NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, market_key, book_key, outcome_name, description, interval_seconds, bucket_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_odds_ohlc_bucket ON odds_ohlc(interval_seconds, bucket_start DESC);
+CREATE INDEX IF NOT EXISTS idx_odds_ohlc_sport_bucket ON odds_ohlc(sport_key, interval_seconds, bucket_start);
+
+COMMENT ON TABLE odds_ohlc IS 'Open/high/low/close of price and point per outcome, book and 1m/5m/1h candle';
+COMMENT ON COLUMN odds_ohlc.interval_seconds IS 'Candle size: 60, 300 or 3600';
+COMMENT ON COLUMN odds_ohlc.bucket_start IS 'Start of the candle (received_at truncated to the interval, UTC)';
+COMMENT ON COLUMN odds_ohlc.price_high IS 'Best American price in the candle (highest payout)';
+COMMENT ON COLUMN odds_ohlc.changes IS 'Stored quotes (price or point changes) in the candle';
@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/redis/go-redis/v9"
+)
+
+// AuditStream is the Redis stream every admin change is emitted to
+const AuditStream = "mercury:admin:audit"
+
+// Audited actions
+const (
+	ActionBookUpdate       = "book.update"
+	ActionQuarantineReview = "quarantine.review"
+)
+
+// Bounds on listing changes
+const (
+	DefaultChangeLimit = 100
+	MaxChangeLimit     = 1000
+
+	auditStreamMaxLen = 10000
+)
+
+// Change is one runtime change made through the admin API: who made it, what it
+// changed and when
+type Change struct {
+	ID         int64           `json:"id,omitempty"`
+	Operator   string          `json:"operator"` // Named token's operator, "admin" for ADMIN_TOKEN, "anonymous" without auth
+	RemoteAddr string          `json:"remote_addr"`
+	Action     string          `json:"action"` // e.g. book.update
+	Target     string          `json:"target"` // Book key, quarantined record ID
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	At         time.Time       `json:"at"`
+}
+
+// ChangeFilter selects audited changes; empty fields match everything
+type ChangeFilter struct {
+	Operator string
+	Action   string
+	Target   string
+	Since    time.Time // Zero = no bound
+	Limit    int       // Default DefaultChangeLimit, capped at MaxChangeLimit
+}
+
+// AuditLog stores admin changes in admin_audit and emits them on AuditStream
+type AuditLog struct {
+	db    *sql.DB
+	redis *redis.Client // Optional
+}
+
+// NewAuditLog creates an audit log; a nil Redis client skips the stream
+func NewAuditLog(db *sql.DB, redisClient *redis.Client) *AuditLog {
+	return &AuditLog{db: db, redis: redisClient}
+}
+
+// Record stores a change, then emits it with its ID
+func (a *AuditLog) Record(ctx context.Context, change Change) (Change, error) {
+	err := a.db.QueryRowContext(ctx, `
+		INSERT INTO admin_audit (operator, remote_addr, action, target, before, after, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, change.Operator, change.RemoteAddr, change.Action, change.Target,
+		nullJSON(change.Before), nullJSON(change.After), change.At).Scan(&change.ID)
+	if err != nil {
+		return change, fmt.Errorf("insert admin audit: %w", err)
+	}
+
+	if a.redis == nil {
+		return change, nil
+	}
+	data, err := json.Marshal(change)
+	if err != nil {
+		return change, fmt.Errorf("marshal admin change: %w", err)
+	}
+	err = a.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: AuditStream,
+		MaxLen: auditStreamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"data": data},
+	}).Err()
+	if err != nil {
+		return change, fmt.Errorf("emit admin change: %w", err)
+	}
+	return change, nil
+}
+
+// ListChanges returns audited changes matching filter, newest first
+func ListChanges(ctx context.Context, db *sql.DB, filter ChangeFilter) ([]Change, error) {
+	var conds []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.Operator != "" {
+		add("operator = $%d", filter.Operator)
+	}
+	if filter.Action != "" {
+		add("action = $%d", filter.Action)
+	}
+	if filter.Target != "" {
+		add("target = $%d", filter.Target)
+	}
+	if !filter.Since.IsZero() {
+		add("occurred_at >= $%d", filter.Since)
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultChangeLimit
+	}
+	if limit > MaxChangeLimit {
+		limit = MaxChangeLimit
+	}
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, operator, remote_addr, action, target, before, after, occurred_at
+		FROM admin_audit `+where+`
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT $`+fmt.Sprint(len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query admin audit: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []Change{}
+	for rows.Next() {
+		var change Change
+		var before, after []byte
+		if err := rows.Scan(&change.ID, &change.Operator, &change.RemoteAddr, &change.Action,
+			&change.Target, &before, &after, &change.At); err != nil {
+			return nil, fmt.Errorf("scan admin audit: %w", err)
+		}
+		change.Before = before
+		change.After = after
+		change.At = timeutil.UTC(change.At)
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read admin audit: %w", err)
+	}
+	return changes, nil
+}
+
+// nullJSON stores an absent snapshot as NULL
+func nullJSON(data json.RawMessage) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return []byte(data)
+}
@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Role scopes what a token may do
+type Role string
+
+const (
+	// RoleObserver may only read (GET and HEAD requests)
+	RoleObserver Role = "observer"
+	// RoleOperator may also change books and review quarantined odds
+	RoleOperator Role = "operator"
+)
+
+// ParseRole validates a role name
+func ParseRole(value string) (Role, error) {
+	switch role := Role(strings.ToLower(strings.TrimSpace(value))); role {
+	case RoleObserver, RoleOperator:
+		return role, nil
+	default:
+		return "", fmt.Errorf("unknown role %q (want observer or operator)", value)
+	}
+}
+
+// CanWrite reports whether the role may make changes
+func (r Role) CanWrite() bool {
+	return r == RoleOperator
+}
+
+// Credential is who a bearer token belongs to and what they may do
+type Credential struct {
+	Operator string `json:"operator"`
+	Role     Role   `json:"role"`
+}
+
+// credentialKey carries the authenticated credential in a request context
+type credentialKey struct{}
+
+// credentialFrom returns the credential authorize attached to a request context
+func credentialFrom(ctx context.Context) Credential {
+	cred, _ := ctx.Value(credentialKey{}).(Credential)
+	return cred
+}
+
+// authorize rejects requests without a configured bearer token, and changes made with
+// an observer's token, and attaches the token's credential
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cred, ok := s.credential(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !cred.Role.CanWrite() {
+			fmt.Printf("[Admin] ⚠ %s %s denied to %s (%s)\n", r.Method, r.URL.Path, cred.Operator, cred.Role)
+			writeError(w, http.StatusForbidden, fmt.Sprintf("role %s is read-only", cred.Role))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), credentialKey{}, cred)))
+	})
+}
+
+// credential returns who a bearer token belongs to. Without any token configured
+// everyone is an anonymous observer: the API fails closed to read-only
+func (s *Server) credential(token string) (Credential, bool) {
+	if !s.hasTokens() {
+		return Credential{Operator: "anonymous", Role: RoleObserver}, true
+	}
+
+	// Compare against every token so timing does not reveal which one matched
+	var cred Credential
+	var ok bool
+	if s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+		cred, ok = Credential{Operator: "admin", Role: RoleOperator}, true
+	}
+	for candidate, named := range s.operators {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(candidate)) == 1 {
+			cred, ok = named, true
+		}
+	}
+	return cred, ok
+}
+
+// hasTokens reports whether any bearer token is configured
+func (s *Server) hasTokens() bool {
+	return s.token != "" || len(s.operators) > 0
+}
+
+// handleWhoAmI returns the operator and role of the request's token
+func (s *Server) handleWhoAmI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, credentialFrom(r.Context()))
+}
+
+// ParseOperatorTokens parses ADMIN_OPERATOR_TOKENS ("name:token[:role],...", role
+// observer or operator, default operator) into token -> credential
+func ParseOperatorTokens(value string) (map[string]Credential, error) {
+	tokens := make(map[string]Credential)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid operator token %q (want name:token[:role])", item)
+		}
+		name, token := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if name == "" || token == "" {
+			return nil, fmt.Errorf("invalid operator token %q (want name:token[:role])", item)
+		}
+		role := RoleOperator
+		if len(parts) == 3 {
+			var err error
+			if role, err = ParseRole(parts[2]); err != nil {
+				return nil, fmt.Errorf("operator %s: %w", name, err)
+			}
+		}
+		if _, dup := tokens[token]; dup {
+			return nil, fmt.Errorf("operator %s reuses another operator's token", name)
+		}
+		tokens[token] = Credential{Operator: name, Role: role}
+	}
+	return tokens, nil
+}
@@ -0,0 +1,530 @@
+// Package admin serves a small HTTP API for editing reference data (book
+// classification, weights and regions) and reviewing quarantined odds on a running
+// Mercury. Every change is audited with the operator who made it.
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/quarantine"
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/history"
+)
+
+// maxBodyBytes bounds request bodies
+const maxBodyBytes = 64 << 10
+
+// Server is the admin HTTP API
+type Server struct {
+	addr       string
+	token      string                // Bearer token required on every request (empty = no auth)
+	operators  map[string]Credential // Named tokens: token -> operator and role
+	db         *sql.DB
+	books      *books.Registry
+	audit      *AuditLog                // Optional; changes are only logged without it
+	props      *scheduler.PropsRegistry // Optional; /props/pollers is 404 without it
+	httpServer *http.Server
+
+	wg sync.WaitGroup
+}
+
+// BookUpdate is the body of PUT /books/{key}; omitted fields keep their current value
+type BookUpdate struct {
+	DisplayName *string      `json:"display_name"`
+	Class       *books.Class `json:"book_type"`
+	Weight      *float64     `json:"default_weight"`
+	ClearWeight bool         `json:"clear_default_weight"` // Revert to the class default
+	Regions     []string     `json:"regions"`
+	Active      *bool        `json:"active"`
+	Muted       *bool        `json:"muted"` // Stop requesting and ingesting the book
+}
+
+// NewServer creates an admin API listening on addr (e.g. ":8091")
+func NewServer(addr string, db *sql.DB, registry *books.Registry) *Server {
+	s := &Server{
+		addr:  addr,
+		db:    db,
+		books: registry,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /books", s.handleListBooks)
+	mux.HandleFunc("GET /books/{key}", s.handleGetBook)
+	mux.HandleFunc("PUT /books/{key}", s.handlePutBook)
+	mux.HandleFunc("GET /quarantine", s.handleListQuarantine)
+	mux.HandleFunc("GET /quarantine/summary", s.handleQuarantineSummary)
+	mux.HandleFunc("POST /quarantine/{id}/review", s.handleReviewQuarantine)
+	mux.HandleFunc("GET /audit", s.handleListAudit)
+	mux.HandleFunc("GET /whoami", s.handleWhoAmI)
+	mux.HandleFunc("GET /props/pollers", s.handleListPropsPollers)
+	mux.HandleFunc("GET /props/pollers/{event_id}", s.handleGetPropsPoller)
+	mux.HandleFunc("GET /history", s.handleHistory)
+	s.httpServer = &http.Server{Addr: addr, Handler: s.authorize(mux)}
+
+	return s
+}
+
+// SetToken requires "Authorization: Bearer <token>" on every request
+func (s *Server) SetToken(token string) {
+	s.token = token
+}
+
+// SetOperatorTokens accepts one bearer token per operator, each with a role, so
+// audited changes say who made them and observers cannot make any. They work
+// alongside SetToken's shared token, an operator attributed to "admin"
+func (s *Server) SetOperatorTokens(tokens map[string]Credential) {
+	s.operators = tokens
+}
+
+// SetAuditLog stores and emits every change made through the API
+func (s *Server) SetAuditLog(log *AuditLog) {
+	s.audit = log
+}
+
+// SetPropsPollers exposes the scheduler's active per-event props pollers
+func (s *Server) SetPropsPollers(registry *scheduler.PropsRegistry) {
+	s.props = registry
+}
+
+// Handler returns the HTTP handler (for tests and embedding)
+func (s *Server) Handler() http.Handler {
+	return s.httpServer.Handler
+}
+
+// Start begins accepting requests
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", s.addr, err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("[Admin] server error: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("✓ Admin API listening on %s\n", listener.Addr())
+	if !s.hasTokens() {
+		fmt.Println("⚠ Admin API has no ADMIN_TOKEN or ADMIN_OPERATOR_TOKENS: it is read-only")
+	}
+	return nil
+}
+
+// Stop shuts the server down
+func (s *Server) Stop() {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.httpServer.Shutdown(shutdownCtx)
+	s.wg.Wait()
+}
+
+// recordChange audits a change made by the request's operator. before and after
+// are the target's state (nil when absent); a failure to store it is logged, since
+// the change itself has been made
+func (s *Server) recordChange(r *http.Request, action, target string, before, after interface{}) {
+	operator := credentialFrom(r.Context()).Operator
+	change := Change{
+		Operator:   operator,
+		RemoteAddr: remoteHost(r.RemoteAddr),
+		Action:     action,
+		Target:     target,
+		Before:     snapshot(before),
+		After:      snapshot(after),
+		At:         timeutil.Now(),
+	}
+
+	fmt.Printf("[Admin] %s %s by %s from %s\n", action, target, operator, change.RemoteAddr)
+	if s.audit == nil {
+		return
+	}
+	if _, err := s.audit.Record(r.Context(), change); err != nil {
+		fmt.Printf("[Admin] ⚠ audit %s %s: %v\n", action, target, err)
+	}
+}
+
+// snapshot encodes a target's state for the audit (nil stays absent)
+func snapshot(state interface{}) json.RawMessage {
+	if state == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// remoteHost strips the port from a request's remote address
+func remoteHost(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+func (s *Server) handleListBooks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.books.All())
+}
+
+func (s *Server) handleGetBook(w http.ResponseWriter, r *http.Request) {
+	book, ok := s.books.Get(r.PathValue("key"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown book")
+		return
+	}
+	writeJSON(w, http.StatusOK, book)
+}
+
+// handleListPropsPollers lists active props pollers, optionally for one sport
+func (s *Server) handleListPropsPollers(w http.ResponseWriter, r *http.Request) {
+	if s.props == nil {
+		writeError(w, http.StatusNotFound, "props pollers not available")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.props.List(strings.TrimSpace(r.URL.Query().Get("sport"))))
+}
+
+// handleGetPropsPoller returns one event's props poller
+func (s *Server) handleGetPropsPoller(w http.ResponseWriter, r *http.Request) {
+	if s.props == nil {
+		writeError(w, http.StatusNotFound, "props pollers not available")
+		return
+	}
+	poller, ok := s.props.Get(r.PathValue("event_id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "no props poller for event")
+		return
+	}
+	writeJSON(w, http.StatusOK, poller)
+}
+
+// handlePutBook creates or edits a book; unknown keys start from the soft/us default
+func (s *Server) handlePutBook(w http.ResponseWriter, r *http.Request) {
+	var update BookUpdate
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %v", err))
+		return
+	}
+
+	key := strings.ToLower(r.PathValue("key"))
+	var before interface{}
+	if existing, ok := s.books.Get(key); ok {
+		before = existing
+	}
+
+	book := update.Apply(s.books.Lookup(key))
+	if err := book.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := s.books.Save(r.Context(), s.db, book); err != nil {
+		fmt.Printf("[Admin] save book %s: %v\n", book.Key, err)
+		writeError(w, http.StatusInternalServerError, "failed to save book")
+		return
+	}
+
+	fmt.Printf("[Admin] book %s updated (%s, weight %.2f, regions %v, active %t, muted %t)\n",
+		book.Key, book.Class, book.ConsensusWeight(), book.Regions, book.Active, book.Muted)
+	s.recordChange(r, ActionBookUpdate, book.Key, before, book)
+	writeJSON(w, http.StatusOK, book)
+}
+
+// QuarantineReview is the body of POST /quarantine/{id}/review
+type QuarantineReview struct {
+	Note string `json:"note"`
+}
+
+// ParseQuarantineFilter reads the sport, book, market, reason, reviewed, since
+// (duration back from now) and limit query parameters
+func ParseQuarantineFilter(query map[string][]string, now time.Time) (quarantine.Filter, error) {
+	get := func(name string) string {
+		if values := query[name]; len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+
+	f := quarantine.Filter{
+		SportKey:  get("sport"),
+		BookKey:   get("book"),
+		MarketKey: get("market"),
+		Reason:    get("reason"),
+	}
+	if v := get("reviewed"); v != "" {
+		reviewed, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, fmt.Errorf("invalid reviewed %q", v)
+		}
+		f.Reviewed = &reviewed
+	}
+	if v := get("since"); v != "" {
+		since, err := time.ParseDuration(v)
+		if err != nil || since <= 0 {
+			return f, fmt.Errorf("invalid since %q (want a duration such as 24h)", v)
+		}
+		f.Since = now.Add(-since)
+	}
+	if v := get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > quarantine.MaxListLimit {
+			return f, fmt.Errorf("invalid limit %q (1-%d)", v, quarantine.MaxListLimit)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
+
+// handleListQuarantine lists quarantined records, newest first
+func (s *Server) handleListQuarantine(w http.ResponseWriter, r *http.Request) {
+	filter, err := ParseQuarantineFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	records, err := quarantine.List(r.Context(), s.db, filter)
+	if err != nil {
+		fmt.Printf("[Admin] list quarantine: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list quarantine")
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// handleQuarantineSummary counts quarantined records by sport and reason (default
+// the last 24h)
+func (s *Server) handleQuarantineSummary(w http.ResponseWriter, r *http.Request) {
+	filter, err := ParseQuarantineFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.Since.IsZero() {
+		filter.Since = time.Now().Add(-24 * time.Hour)
+	}
+
+	counts, err := quarantine.SummarizeStored(r.Context(), s.db, filter)
+	if err != nil {
+		fmt.Printf("[Admin] summarize quarantine: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to summarize quarantine")
+		return
+	}
+	writeJSON(w, http.StatusOK, counts)
+}
+
+// handleReviewQuarantine marks a quarantined record reviewed
+func (s *Server) handleReviewQuarantine(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var review QuarantineReview
+	if r.ContentLength != 0 {
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&review); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid body: %v", err))
+			return
+		}
+	}
+
+	if err := quarantine.MarkReviewed(r.Context(), s.db, id, review.Note); err != nil {
+		if errors.Is(err, quarantine.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "unknown quarantined record")
+			return
+		}
+		fmt.Printf("[Admin] review quarantine %d: %v\n", id, err)
+		writeError(w, http.StatusInternalServerError, "failed to mark reviewed")
+		return
+	}
+
+	fmt.Printf("[Admin] quarantined record %d reviewed\n", id)
+	s.recordChange(r, ActionQuarantineReview, strconv.FormatInt(id, 10), nil, review)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"id": id, "reviewed": true})
+}
+
+// ParseChangeFilter reads the operator, action, target, since (duration back from
+// now) and limit query parameters
+func ParseChangeFilter(query map[string][]string, now time.Time) (ChangeFilter, error) {
+	get := func(name string) string {
+		if values := query[name]; len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+
+	f := ChangeFilter{
+		Operator: get("operator"),
+		Action:   get("action"),
+		Target:   get("target"),
+	}
+	if v := get("since"); v != "" {
+		since, err := time.ParseDuration(v)
+		if err != nil || since <= 0 {
+			return f, fmt.Errorf("invalid since %q (want a duration such as 24h)", v)
+		}
+		f.Since = now.Add(-since)
+	}
+	if v := get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > MaxChangeLimit {
+			return f, fmt.Errorf("invalid limit %q (1-%d)", v, MaxChangeLimit)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
+
+// ParseHistoryQuery reads the event, market, book, outcome, description, from and to
+// (timestamps) or since (duration back from now), interval, max_points and limit
+// query parameters
+func ParseHistoryQuery(query map[string][]string, now time.Time) (history.Query, error) {
+	get := func(name string) string {
+		if values := query[name]; len(values) > 0 {
+			return strings.TrimSpace(values[0])
+		}
+		return ""
+	}
+
+	q := history.Query{
+		EventID:     get("event"),
+		MarketKey:   get("market"),
+		BookKey:     get("book"),
+		OutcomeName: get("outcome"),
+		Description: get("description"),
+	}
+	if q.EventID == "" || q.MarketKey == "" {
+		return q, errors.New("event and market are required")
+	}
+	for name, bound := range map[string]*time.Time{"from": &q.From, "to": &q.To} {
+		if v := get(name); v != "" {
+			t, err := timeutil.ParseVendorTime(v)
+			if err != nil {
+				return q, fmt.Errorf("invalid %s %q (want a timestamp such as 2025-01-15T00:00:00Z)", name, v)
+			}
+			*bound = t
+		}
+	}
+	if v := get("since"); v != "" {
+		since, err := time.ParseDuration(v)
+		if err != nil || since <= 0 {
+			return q, fmt.Errorf("invalid since %q (want a duration such as 24h)", v)
+		}
+		if q.From.IsZero() {
+			q.From = now.Add(-since)
+		}
+	}
+	if v := get("interval"); v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil || interval <= 0 {
+			return q, fmt.Errorf("invalid interval %q (want a duration such as 5m)", v)
+		}
+		q.Interval = interval
+	}
+	if v := get("max_points"); v != "" {
+		maxPoints, err := strconv.Atoi(v)
+		if err != nil || maxPoints <= 0 {
+			return q, fmt.Errorf("invalid max_points %q", v)
+		}
+		q.MaxPoints = maxPoints
+	}
+	if v := get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 || limit > history.MaxLimit {
+			return q, fmt.Errorf("invalid limit %q (1-%d)", v, history.MaxLimit)
+		}
+		q.Limit = limit
+	}
+	return q, nil
+}
+
+// handleHistory returns the price and point history of a market's outcomes, one
+// series per book and outcome
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	query, err := ParseHistoryQuery(r.URL.Query(), timeutil.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	series, err := history.Fetch(r.Context(), s.db, query)
+	if err != nil {
+		fmt.Printf("[Admin] history: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to read history")
+		return
+	}
+	writeJSON(w, http.StatusOK, series)
+}
+
+// handleListAudit lists audited changes, newest first
+func (s *Server) handleListAudit(w http.ResponseWriter, r *http.Request) {
+	filter, err := ParseChangeFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, err := ListChanges(r.Context(), s.db, filter)
+	if err != nil {
+		fmt.Printf("[Admin] list audit: %v\n", err)
+		writeError(w, http.StatusInternalServerError, "failed to list audit")
+		return
+	}
+	writeJSON(w, http.StatusOK, changes)
+}
+
+// Apply returns b with the update's fields applied
+func (u BookUpdate) Apply(b books.Book) books.Book {
+	if u.DisplayName != nil {
+		b.DisplayName = *u.DisplayName
+	}
+	if u.Class != nil {
+		b.Class = *u.Class
+	}
+	if u.Weight != nil {
+		b.Weight = u.Weight
+	}
+	if u.ClearWeight {
+		b.Weight = nil
+	}
+	if u.Regions != nil {
+		b.Regions = u.Regions
+	}
+	if u.Active != nil {
+		b.Active = *u.Active
+	}
+	if u.Muted != nil {
+		b.Muted = *u.Muted
+	}
+	return b
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,296 @@
+// Package alerting posts operational alerts (quota, vendor errors, stale sports, SLO
+// misses, stream lag) and optionally betting signals (arbs, steam) to Slack and
+// Discord webhooks.
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/arb"
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/internal/steam"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	firingKeyPrefix = "mercury:alerts:firing:" // Title of each firing alert, so one replica announces its resolution
+	sentKeyPrefix   = "mercury:alerts:sent:"   // Set for the cooldown after an alert is sent, shared by replicas
+
+	// firingTTL forgets conditions nobody has re-checked (e.g. every replica stopped)
+	firingTTL = 24 * time.Hour
+
+	signalBlock = 5 * time.Second
+)
+
+// Signal sources
+const (
+	SignalArb   = "arb"
+	SignalSteam = "steam"
+)
+
+// Config configures the alerter
+type Config struct {
+	Thresholds Thresholds
+	Interval   time.Duration // Health check interval
+	Cooldown   time.Duration // Minimum time between repeats of the same alert
+	Signals    []string      // Betting signals to forward (arb, steam); empty = none
+}
+
+// Alerter checks pipeline health on an interval and forwards betting signals from
+// their Redis streams. Cooldowns live in Redis, so replicas don't repeat each other
+type Alerter struct {
+	reporter *health.Reporter
+	redis    *redis.Client
+	config   Config
+	senders  []Sender
+	cancel   context.CancelFunc
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewAlerter creates an alerter reading health from reporter
+func NewAlerter(reporter *health.Reporter, redisClient *redis.Client, config Config) *Alerter {
+	return &Alerter{
+		reporter: reporter,
+		redis:    redisClient,
+		config:   config,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// AddSender registers a chat service that receives every alert
+func (a *Alerter) AddSender(sender Sender) {
+	a.senders = append(a.senders, sender)
+}
+
+// Start begins health checks and, if configured, signal forwarding
+func (a *Alerter) Start(ctx context.Context) {
+	ctx, a.cancel = context.WithCancel(ctx)
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+
+		ticker := time.NewTicker(a.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				a.check(ctx)
+			case <-a.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if streams := signalStreams(a.config.Signals); len(streams) > 0 {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.forwardSignals(ctx, streams)
+		}()
+	}
+
+	fmt.Printf("✓ Alerting started (every %v, signals: %v)\n", a.config.Interval, a.config.Signals)
+}
+
+// Stop stops checks and signal forwarding
+func (a *Alerter) Stop() {
+	close(a.stopChan)
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.wg.Wait()
+}
+
+// check sends newly firing alerts (outside their cooldown) and resolutions
+func (a *Alerter) check(ctx context.Context) {
+	snapshot, err := a.reporter.Snapshot(ctx)
+	if err != nil {
+		fmt.Printf("[Alerting] health snapshot error: %v\n", err)
+		return
+	}
+
+	firing := make(map[string]bool)
+	for _, alert := range Check(snapshot, a.config.Thresholds, timeutil.Now()) {
+		firing[alert.Key] = true
+		if err := a.redis.Set(ctx, firingKeyPrefix+alert.Key, alert.Title, firingTTL).Err(); err != nil {
+			fmt.Printf("[Alerting] record %s: %v\n", alert.Key, err)
+		}
+		a.sendOnce(ctx, alert)
+	}
+
+	keys, err := a.scanKeys(ctx, firingKeyPrefix+"*")
+	if err != nil {
+		fmt.Printf("[Alerting] scan firing alerts: %v\n", err)
+		return
+	}
+	for _, key := range keys {
+		alertKey := strings.TrimPrefix(key, firingKeyPrefix)
+		if firing[alertKey] {
+			continue
+		}
+		// GETDEL succeeds for one replica only, which announces the resolution
+		title, err := a.redis.GetDel(ctx, key).Result()
+		if err != nil {
+			continue
+		}
+		a.redis.Del(ctx, sentKeyPrefix+alertKey)
+		a.send(ctx, Resolved(alertKey, title))
+	}
+}
+
+// sendOnce sends an alert unless the same alert was sent within the cooldown
+func (a *Alerter) sendOnce(ctx context.Context, alert Alert) {
+	ok, err := a.redis.SetNX(ctx, sentKeyPrefix+alert.Key, timeutil.Now().Unix(), a.config.Cooldown).Result()
+	if err != nil {
+		fmt.Printf("[Alerting] cooldown %s: %v\n", alert.Key, err)
+		return
+	}
+	if ok {
+		a.send(ctx, alert)
+	}
+}
+
+// send posts an alert to every sender
+func (a *Alerter) send(ctx context.Context, alert Alert) {
+	for _, sender := range a.senders {
+		if err := sender.Send(ctx, alert); err != nil {
+			fmt.Printf("[Alerting] %s: send %q: %v\n", sender.Name(), alert.Title, err)
+		}
+	}
+}
+
+// signalStreams maps signal names to the streams their detectors publish to
+func signalStreams(signals []string) []string {
+	var streams []string
+	for _, signal := range signals {
+		switch signal {
+		case SignalArb:
+			streams = append(streams, arb.StreamKey)
+		case SignalSteam:
+			streams = append(streams, steam.StreamKey)
+		default:
+			fmt.Printf("⚠ Unknown alert signal %q (known: %s, %s)\n", signal, SignalArb, SignalSteam)
+		}
+	}
+	return streams
+}
+
+// forwardSignals tails the signal streams from now on and alerts on each entry
+func (a *Alerter) forwardSignals(ctx context.Context, streams []string) {
+	ids := make([]string, len(streams))
+	for i := range ids {
+		ids[i] = "$"
+	}
+
+	for {
+		select {
+		case <-a.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		results, err := a.redis.XRead(ctx, &redis.XReadArgs{
+			Streams: append(append([]string(nil), streams...), ids...),
+			Block:   signalBlock,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Printf("[Alerting] read signals: %v\n", err)
+			time.Sleep(signalBlock)
+			continue
+		}
+
+		for _, result := range results {
+			for i, stream := range streams {
+				if stream == result.Stream && len(result.Messages) > 0 {
+					ids[i] = result.Messages[len(result.Messages)-1].ID
+				}
+			}
+			for _, message := range result.Messages {
+				data, _ := message.Values["data"].(string)
+				alert, err := SignalAlert(result.Stream, []byte(data))
+				if err != nil {
+					fmt.Printf("[Alerting] decode %s %s: %v\n", result.Stream, message.ID, err)
+					continue
+				}
+				a.sendOnce(ctx, alert)
+			}
+		}
+	}
+}
+
+// SignalAlert formats an entry of the arb or steam stream as an alert
+func SignalAlert(stream string, data []byte) (Alert, error) {
+	switch stream {
+	case arb.StreamKey:
+		var opp arb.Opportunity
+		if err := json.Unmarshal(data, &opp); err != nil {
+			return Alert{}, err
+		}
+		var legs []string
+		for _, leg := range opp.Legs {
+			legs = append(legs, fmt.Sprintf("%s %s %+d (%.1f%%)", leg.BookKey, outcomeLabel(leg.OutcomeName, leg.Point), leg.Price, leg.StakePct))
+		}
+		return Alert{
+			Key:      "arb:" + opp.EventID + "|" + opp.MarketKey + "|" + opp.Description,
+			Severity: SeveritySignal,
+			Title:    fmt.Sprintf("Arb %.2f%% on %s %s", opp.ProfitPct, opp.EventID, opp.MarketKey),
+			Text:     strings.Join(legs, "\n"),
+		}, nil
+
+	case steam.StreamKey:
+		var signal steam.Signal
+		if err := json.Unmarshal(data, &signal); err != nil {
+			return Alert{}, err
+		}
+		outcome := signal.OutcomeName
+		if signal.Description != "" {
+			outcome = signal.Description + " " + outcome
+		}
+		return Alert{
+			Key:      "steam:" + signal.EventID + "|" + signal.MarketKey + "|" + signal.Description + "|" + signal.OutcomeName + "|" + signal.Direction,
+			Severity: SeveritySignal,
+			Title:    fmt.Sprintf("Steam %s %s on %s %s", signal.Direction, outcome, signal.EventID, signal.MarketKey),
+			Text:     fmt.Sprintf("%d books in %s: %s", len(signal.Books), signal.Window, strings.Join(signal.Books, ", ")),
+		}, nil
+
+	default:
+		return Alert{}, fmt.Errorf("unknown signal stream %s", stream)
+	}
+}
+
+// outcomeLabel appends a leg's point to its outcome (e.g. "Over 221.5")
+func outcomeLabel(outcome string, point *float64) string {
+	if point == nil {
+		return outcome
+	}
+	return fmt.Sprintf("%s %g", outcome, *point)
+}
+
+// scanKeys returns all keys matching a pattern using SCAN (never KEYS)
+func (a *Alerter) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	iter := a.redis.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
+}
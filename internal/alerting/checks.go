@@ -0,0 +1,173 @@
+package alerting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/health"
+)
+
+// Severity classifies an alert
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityWarning  Severity = "warning"
+	SeverityResolved Severity = "resolved"
+	SeveritySignal   Severity = "signal" // Betting signals (arbs, steam)
+)
+
+// Alert is one message for the chat channels
+type Alert struct {
+	Key      string // Identifies the condition, for cooldown and resolution
+	Severity Severity
+	Title    string
+	Text     string
+}
+
+// Thresholds configure the operational checks (zero disables a check)
+type Thresholds struct {
+	QuotaRemaining int           // Alert at or below this many remaining vendor requests
+	StaleAfter     time.Duration // Alert when a sport has not polled for this long
+	SLO            time.Duration // Alert when the rolling p95 of a poll's delta → write → cache path exceeds this
+	StreamLag      int64         // Alert when a consumer group falls this many entries behind
+}
+
+// Check evaluates a health snapshot and returns the alerts that are currently firing
+func Check(snapshot *health.Snapshot, thresholds Thresholds, now time.Time) []Alert {
+	var alerts []Alert
+
+	if q := snapshot.Quota; q != nil && thresholds.QuotaRemaining > 0 && q.Remaining <= thresholds.QuotaRemaining {
+		severity := SeverityWarning
+		if q.Remaining <= thresholds.QuotaRemaining/4 {
+			severity = SeverityCritical
+		}
+		alerts = append(alerts, Alert{
+			Key:      "quota",
+			Severity: severity,
+			Title:    "Vendor quota nearly exhausted",
+			Text:     fmt.Sprintf("%d requests remaining (%d used)", q.Remaining, q.Used),
+		})
+	}
+
+	for _, sport := range snapshot.Sports {
+		switch {
+		case sport.LastErrorAt.After(sport.LastPollAt):
+			alerts = append(alerts, errorAlert(sport))
+		case thresholds.StaleAfter > 0 && now.Sub(sport.LastPollAt) > thresholds.StaleAfter:
+			alerts = append(alerts, Alert{
+				Key:      "stale:" + sport.SportKey,
+				Severity: SeverityWarning,
+				Title:    "Stale sport " + sport.SportKey,
+				Text:     "No successful poll for " + now.Sub(sport.LastPollAt).Round(time.Second).String(),
+			})
+		}
+
+		if thresholds.SLO > 0 {
+			if alert, ok := sloAlert(sport, thresholds.SLO); ok {
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	for _, book := range snapshot.StaleBooks {
+		text := "No accepted delta for " + now.Sub(book.LastDelta).Round(time.Second).String()
+		if !book.NextTipoff.IsZero() {
+			text += "; next tipoff in " + book.NextTipoff.Sub(now).Round(time.Minute).String()
+		}
+		alerts = append(alerts, Alert{
+			Key:      "stale_book:" + book.SportKey + ":" + book.BookKey,
+			Severity: SeverityWarning,
+			Title:    "Stale book " + book.BookKey + " on " + book.SportKey,
+			Text:     text,
+		})
+	}
+
+	if thresholds.StreamLag > 0 {
+		for _, lag := range snapshot.Lags {
+			if lag.Lag >= thresholds.StreamLag {
+				alerts = append(alerts, Alert{
+					Key:      "lag:" + lag.Stream + "|" + lag.Group,
+					Severity: SeverityWarning,
+					Title:    "Consumer group " + lag.Group + " lagging on " + lag.Stream,
+					Text:     fmt.Sprintf("%d entries behind, %d pending", lag.Lag, lag.Pending),
+				})
+			}
+		}
+	}
+
+	return alerts
+}
+
+// sloAlert fires when a sport's rolling p95 pipeline latency exceeds the SLO, naming
+// the slowest stage. Without rolling stats (SLO tracking off) the last poll is checked
+func sloAlert(sport health.SportHealth, target time.Duration) (Alert, bool) {
+	alert := Alert{
+		Key:      "slo:" + sport.SportKey,
+		Severity: SeverityWarning,
+		Title:    "Latency SLO missed on " + sport.SportKey,
+	}
+
+	if stats := sport.SLO; stats.Samples > 0 {
+		if stats.P95 <= target {
+			return Alert{}, false
+		}
+		if stats.P99 > 2*target {
+			alert.Severity = SeverityCritical
+		}
+		alert.Text = fmt.Sprintf("p95 %v, p99 %v from delta to cache over the last %d polls (SLO %v, %d over); slowest stage %s (p95 %v)",
+			stats.P95, stats.P99, stats.Samples, target, stats.Violations, stats.Stage, stats.StageP95)
+		return alert, true
+	}
+
+	latency := pipelineLatency(sport)
+	if latency <= target {
+		return Alert{}, false
+	}
+	alert.Text = fmt.Sprintf("Last poll took %v from delta to cache (SLO %v)", latency, target)
+	return alert, true
+}
+
+// pipelineLatency is Mercury's own share of a poll: every stage after the vendor fetch
+func pipelineLatency(sport health.SportHealth) time.Duration {
+	var total time.Duration
+	for stage, d := range sport.Stages {
+		if stage != "fetch" {
+			total += d
+		}
+	}
+	return total
+}
+
+// Resolved returns the alert announcing that a firing alert's condition cleared
+func Resolved(key, title string) Alert {
+	return Alert{
+		Key:      key,
+		Severity: SeverityResolved,
+		Title:    "Resolved: " + title,
+	}
+}
+
+// errorAlert reports a sport whose last poll failed. Rate limits are only a warning:
+// the scheduler pauses requests and resumes on its own
+func errorAlert(sport health.SportHealth) Alert {
+	alert := Alert{
+		Key:      "errors:" + sport.SportKey,
+		Severity: SeverityCritical,
+		Title:    "Vendor errors on " + sport.SportKey,
+		Text:     fmt.Sprintf("%d errors so far; last: %s", sport.Errors, sport.LastError),
+	}
+
+	switch sport.LastErrKind {
+	case "rate_limited":
+		alert.Severity = SeverityWarning
+	case "quota_exhausted":
+		alert.Title = "Vendor quota exhausted on " + sport.SportKey
+	case "storage":
+		alert.Title = "Pipeline errors on " + sport.SportKey
+	}
+	if sport.LastErrKind != "" {
+		alert.Text = fmt.Sprintf("%d errors so far; last (%s): %s", sport.Errors, sport.LastErrKind, sport.LastError)
+	}
+	return alert
+}
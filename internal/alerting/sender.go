@@ -0,0 +1,124 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	sendTimeout = 10 * time.Second
+
+	// discordMaxContent is Discord's message length limit
+	discordMaxContent = 2000
+)
+
+// Sender posts alerts to one chat service
+type Sender interface {
+	Name() string
+	Send(ctx context.Context, alert Alert) error
+}
+
+// icon marks an alert's severity in chat
+func icon(severity Severity) string {
+	switch severity {
+	case SeverityCritical:
+		return "✗"
+	case SeverityResolved:
+		return "✓"
+	case SeveritySignal:
+		return "📈"
+	default:
+		return "⚠"
+	}
+}
+
+// SlackSender posts to a Slack incoming webhook
+type SlackSender struct {
+	url        string
+	prefix     string
+	httpClient *http.Client
+}
+
+// NewSlackSender creates a sender for a Slack incoming webhook URL; prefix (e.g. the
+// instance ID) is prepended to every message
+func NewSlackSender(url, prefix string) *SlackSender {
+	return &SlackSender{url: url, prefix: prefix, httpClient: &http.Client{Timeout: sendTimeout}}
+}
+
+// Name identifies the sender in logs
+func (s *SlackSender) Name() string { return "slack" }
+
+// Send posts an alert as a mrkdwn message
+func (s *SlackSender) Send(ctx context.Context, alert Alert) error {
+	text := fmt.Sprintf("%s *%s*", icon(alert.Severity), alert.Title)
+	if s.prefix != "" {
+		text = "[" + s.prefix + "] " + text
+	}
+	if alert.Text != "" {
+		text += "\n" + alert.Text
+	}
+	return postJSON(ctx, s.httpClient, s.url, map[string]string{"text": text})
+}
+
+// DiscordSender posts to a Discord channel webhook
+type DiscordSender struct {
+	url        string
+	prefix     string
+	httpClient *http.Client
+}
+
+// NewDiscordSender creates a sender for a Discord webhook URL; prefix (e.g. the
+// instance ID) is prepended to every message
+func NewDiscordSender(url, prefix string) *DiscordSender {
+	return &DiscordSender{url: url, prefix: prefix, httpClient: &http.Client{Timeout: sendTimeout}}
+}
+
+// Name identifies the sender in logs
+func (d *DiscordSender) Name() string { return "discord" }
+
+// Send posts an alert as a markdown message, truncated to Discord's limit
+func (d *DiscordSender) Send(ctx context.Context, alert Alert) error {
+	content := fmt.Sprintf("%s **%s**", icon(alert.Severity), alert.Title)
+	if d.prefix != "" {
+		content = "[" + d.prefix + "] " + content
+	}
+	if alert.Text != "" {
+		content += "\n" + alert.Text
+	}
+	if runes := []rune(content); len(runes) > discordMaxContent {
+		content = string(runes[:discordMaxContent-1]) + "…"
+	}
+	return postJSON(ctx, d.httpClient, d.url, map[string]string{"content": content})
+}
+
+// postJSON posts a JSON body and fails on non-2xx responses
+func postJSON(ctx context.Context, client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return nil
+}
@@ -0,0 +1,271 @@
+// Package arb detects cross-book arbitrage: a complete set of outcomes on one line
+// whose best prices across books imply a total probability below 1.
+package arb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/pricebook"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamKey is the Redis stream receiving detected arbitrage opportunities
+const StreamKey = "arbs.detected"
+
+// Leg is one bet of an arbitrage
+type Leg struct {
+	BookKey      string   `json:"book_key"`
+	OutcomeName  string   `json:"outcome_name"`
+	Point        *float64 `json:"point,omitempty"`
+	Price        int      `json:"price"`
+	PriceDecimal float64  `json:"price_decimal"`
+	StakePct     float64  `json:"stake_pct"` // Share of the total stake (sums to 100)
+	DeepLink     string   `json:"deep_link,omitempty"`
+}
+
+// Opportunity is a two-way or three-way arbitrage across books
+type Opportunity struct {
+	EventID     string    `json:"event_id"`
+	SportKey    string    `json:"sport_key"`
+	MarketKey   string    `json:"market_key"`
+	Description string    `json:"description,omitempty"`
+	Legs        []Leg     `json:"legs"`
+	ImpliedSum  float64   `json:"implied_sum"` // Sum of 1/decimal over legs (< 1 for an arb)
+	ProfitPct   float64   `json:"profit_pct"`  // Guaranteed return on total stake in percent
+	DetectedAt  time.Time `json:"detected_at"`
+}
+
+// Engine maintains best prices per outcome and detects arbitrage on each delta
+type Engine struct {
+	db           *sql.DB
+	redis        *redis.Client
+	minProfitPct float64
+
+	mu          sync.Mutex
+	prices      *pricebook.Book
+	lastEmitted map[string]string // line key + outcome set -> signature of the last emitted arb
+}
+
+// NewEngine creates an arbitrage engine reporting opportunities of at least minProfitPct
+func NewEngine(db *sql.DB, redisClient *redis.Client, minProfitPct float64) *Engine {
+	return &Engine{
+		db:           db,
+		redis:        redisClient,
+		minProfitPct: minProfitPct,
+		prices:       pricebook.New(),
+		lastEmitted:  make(map[string]string),
+	}
+}
+
+// Load seeds current prices for upcoming and live events from Alexandria
+func (e *Engine) Load(ctx context.Context) error {
+	odds, err := pricebook.LoadCurrent(ctx, e.db)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	for _, odd := range odds {
+		e.prices.Apply(odd)
+	}
+	e.mu.Unlock()
+
+	fmt.Printf("[Arb] loaded %d current prices\n", len(odds))
+	return nil
+}
+
+// HandleDeltaBatchCommitted detects arbitrage on lines touched by a committed batch
+func (e *Engine) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	opportunities := e.Detect(msg.Odds, timeutil.Now())
+	if len(opportunities) == 0 {
+		return
+	}
+
+	if err := e.publish(ctx, opportunities); err != nil {
+		fmt.Printf("[Arb] publish error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("[Arb] %d arbitrage opportunities detected\n", len(opportunities))
+}
+
+// HandleEventStatusChanged drops state for events that can no longer be bet
+func (e *Engine) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	switch msg.NewStatus {
+	case "completed", "cancelled", "postponed":
+		e.Evict(msg.EventID)
+	}
+}
+
+// Evict removes all prices held for an event
+func (e *Engine) Evict(eventID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.prices.Evict(eventID)
+
+	prefix := eventID + "|"
+	for key := range e.lastEmitted {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.lastEmitted, key)
+		}
+	}
+}
+
+// Detect applies odds to the price state and returns new or changed opportunities on affected lines
+func (e *Engine) Detect(odds []models.RawOdds, now time.Time) []Opportunity {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	affected := make(map[string]bool)
+	for _, odd := range odds {
+		affected[e.prices.Apply(odd)] = true
+	}
+
+	keys := make([]string, 0, len(affected))
+	for key := range affected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var opportunities []Opportunity
+	for _, key := range keys {
+		opportunities = append(opportunities, e.evaluate(key, e.prices.Line(key), now)...)
+	}
+	return opportunities
+}
+
+// evaluate checks every complete outcome set on a line for arbitrage
+// A set is complete when a single book quotes it in full (so the outcomes are
+// complementary, e.g. Lakers -3.5 / Celtics +3.5 rather than Lakers -3.5 / Celtics -3.5)
+func (e *Engine) evaluate(lineKey string, l *pricebook.Line, now time.Time) []Opportunity {
+	outcomes := l.Outcomes()
+	if outcomes < 2 {
+		return nil
+	}
+
+	// Best quote per outcome across books
+	best := make(map[string]models.RawOdds)
+	for _, quotes := range l.Quotes {
+		for key, odd := range quotes {
+			if current, ok := best[key]; !ok || odd.Decimal() > current.Decimal() {
+				best[key] = odd
+			}
+		}
+	}
+
+	// Distinct complete outcome sets quoted by some book
+	sets := make(map[string][]string)
+	for _, quotes := range l.Quotes {
+		if len(quotes) != outcomes {
+			continue
+		}
+		keys := make([]string, 0, len(quotes))
+		for key := range quotes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		sets[strings.Join(keys, "\x00")] = keys
+	}
+
+	var opportunities []Opportunity
+	for setID, keys := range sets {
+		emitKey := lineKey + "|" + setID
+
+		legs := make([]models.RawOdds, len(keys))
+		prices := make([]float64, len(keys))
+		books := make(map[string]bool)
+		for i, key := range keys {
+			legs[i] = best[key]
+			prices[i] = legs[i].Decimal()
+			books[legs[i].BookKey] = true
+		}
+
+		// A single-book "arb" is a pricing error at that book, not a cross-book opportunity
+		impliedSum := oddsmath.Overround(prices)
+		profitPct := oddsmath.ArbitrageProfit(prices) * 100
+		if len(books) < 2 || impliedSum == 0 || impliedSum >= 1 || profitPct < e.minProfitPct {
+			delete(e.lastEmitted, emitKey)
+			continue
+		}
+
+		var signature strings.Builder
+		for _, leg := range legs {
+			signature.WriteString(leg.BookKey + ":" + strconv.Itoa(leg.Price) + ";")
+		}
+		if e.lastEmitted[emitKey] == signature.String() {
+			continue
+		}
+		e.lastEmitted[emitKey] = signature.String()
+
+		split := oddsmath.StakeSplit(prices)
+		opportunity := Opportunity{
+			EventID:     legs[0].EventID,
+			SportKey:    legs[0].SportKey,
+			MarketKey:   legs[0].MarketKey,
+			Description: legs[0].Description,
+			ImpliedSum:  round(impliedSum, 4),
+			ProfitPct:   round(profitPct, 2),
+			DetectedAt:  timeutil.UTC(now),
+		}
+		for i, leg := range legs {
+			opportunity.Legs = append(opportunity.Legs, Leg{
+				BookKey:      leg.BookKey,
+				OutcomeName:  leg.OutcomeName,
+				Point:        leg.Point,
+				Price:        leg.Price,
+				PriceDecimal: prices[i],
+				StakePct:     round(split[i]*100, 2),
+				DeepLink:     leg.DeepLink,
+			})
+		}
+		opportunities = append(opportunities, opportunity)
+	}
+
+	sort.Slice(opportunities, func(i, j int) bool { return opportunities[i].ProfitPct > opportunities[j].ProfitPct })
+	return opportunities
+}
+
+// publish appends opportunities to the arbitrage stream
+func (e *Engine) publish(ctx context.Context, opportunities []Opportunity) error {
+	pipe := e.redis.Pipeline()
+
+	for _, opportunity := range opportunities {
+		msgJSON, err := json.Marshal(opportunity)
+		if err != nil {
+			return fmt.Errorf("marshal opportunity: %w", err)
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: StreamKey,
+			Values: map[string]interface{}{
+				"data": msgJSON,
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+
+	return nil
+}
+
+// round rounds to the given number of decimal places
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,271 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const (
+	defaultQueueSize = 256
+	putTimeout       = 30 * time.Second
+	keyTimeLayout    = "20060102T150405.000000000Z"
+	keySuffix        = ".json.gz"
+)
+
+// Archiver writes raw payloads to a store in the background so polling never waits
+// on object storage. Payloads are dropped (and counted) when the queue is full.
+type Archiver struct {
+	store    Store
+	queue    chan models.RawPayload
+	written  atomic.Int64
+	dropped  atomic.Int64
+	failed   atomic.Int64
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewArchiver creates an archiver writing to store
+func NewArchiver(store Store) *Archiver {
+	return &Archiver{
+		store:    store,
+		queue:    make(chan models.RawPayload, defaultQueueSize),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetQueueSize sets how many payloads may wait for upload (call before Start)
+func (a *Archiver) SetQueueSize(size int) {
+	if size > 0 {
+		a.queue = make(chan models.RawPayload, size)
+	}
+}
+
+// Archive queues a payload for upload without blocking
+func (a *Archiver) Archive(payload models.RawPayload) {
+	select {
+	case a.queue <- payload:
+	default:
+		if a.dropped.Add(1)%100 == 1 {
+			fmt.Printf("[Archive] queue full, dropped %d payload(s) so far\n", a.dropped.Load())
+		}
+	}
+}
+
+// Start begins uploading queued payloads
+func (a *Archiver) Start(ctx context.Context) {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		for {
+			select {
+			case payload := <-a.queue:
+				a.write(ctx, payload)
+			case <-a.stopChan:
+				a.drain(ctx)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Println("[Archive] started")
+}
+
+// Stop uploads anything still queued and stops the archiver
+func (a *Archiver) Stop() {
+	close(a.stopChan)
+	a.wg.Wait()
+
+	fmt.Printf("[Archive] stopped (%d written, %d failed, %d dropped)\n",
+		a.written.Load(), a.failed.Load(), a.dropped.Load())
+}
+
+// drain writes payloads queued before Stop
+func (a *Archiver) drain(ctx context.Context) {
+	for {
+		select {
+		case payload := <-a.queue:
+			a.write(ctx, payload)
+		default:
+			return
+		}
+	}
+}
+
+// write encodes and uploads one payload
+func (a *Archiver) write(ctx context.Context, payload models.RawPayload) {
+	data, err := Encode(payload)
+	if err != nil {
+		a.failed.Add(1)
+		fmt.Printf("[Archive] encode error: %v\n", err)
+		return
+	}
+
+	putCtx, cancel := context.WithTimeout(ctx, putTimeout)
+	defer cancel()
+
+	key := Key(payload)
+	if err := a.store.Put(putCtx, key, data); err != nil {
+		a.failed.Add(1)
+		fmt.Printf("[Archive] upload error for %s: %v\n", key, err)
+		return
+	}
+	a.written.Add(1)
+}
+
+// Key returns the object key for a payload:
+// <vendor>/<sport>/<YYYY-MM-DD>/<received_at>.<kind>[.<event_id>].json.gz
+// Keys sort by receive time within a partition
+func Key(payload models.RawPayload) string {
+	receivedAt := timeutil.UTC(payload.ReceivedAt)
+
+	name := receivedAt.Format(keyTimeLayout) + "." + string(payload.Kind)
+	if payload.EventID != "" {
+		name += "." + payload.EventID
+	}
+
+	return PartitionPrefix(payload.Vendor, payload.Sport, receivedAt) + name + keySuffix
+}
+
+// PartitionPrefix returns the key prefix holding a vendor/sport/day partition
+func PartitionPrefix(vendor, sport string, day time.Time) string {
+	return vendor + "/" + sport + "/" + timeutil.UTC(day).Format("2006-01-02") + "/"
+}
+
+// KeyTime returns the receive time encoded in a key
+func KeyTime(key string) (time.Time, error) {
+	name := key[strings.LastIndex(key, "/")+1:]
+	if len(name) < len(keyTimeLayout) || name[8] != 'T' {
+		return time.Time{}, fmt.Errorf("not an archive key: %q", key)
+	}
+
+	// 20060102T150405.000000000Z -> RFC 3339
+	compact := name[:len(keyTimeLayout)]
+	return timeutil.ParseVendorTime(compact[0:4] + "-" + compact[4:6] + "-" + compact[6:8] + "T" +
+		compact[9:11] + ":" + compact[11:13] + ":" + compact[13:])
+}
+
+// header is the payload metadata stored in the gzip comment, so the object itself
+// decompresses to the vendor body exactly as received
+type header struct {
+	Vendor     string             `json:"vendor"`
+	Kind       models.PayloadKind `json:"kind"`
+	Sport      string             `json:"sport"`
+	EventID    string             `json:"event_id,omitempty"`
+	OddsFormat models.OddsFormat  `json:"odds_format,omitempty"`
+	Request    string             `json:"request,omitempty"`
+	ReceivedAt time.Time          `json:"received_at"`
+}
+
+// Encode gzips a payload body with its metadata in the gzip header
+func Encode(payload models.RawPayload) ([]byte, error) {
+	meta, err := json.Marshal(header{
+		Vendor:     payload.Vendor,
+		Kind:       payload.Kind,
+		Sport:      payload.Sport,
+		EventID:    payload.EventID,
+		OddsFormat: payload.OddsFormat,
+		Request:    payload.Request,
+		ReceivedAt: timeutil.UTC(payload.ReceivedAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload header: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Name = string(payload.Kind) + ".json"
+	zw.Comment = string(meta)
+	zw.ModTime = payload.ReceivedAt
+
+	if _, err := zw.Write(payload.Body); err != nil {
+		return nil, fmt.Errorf("gzip payload: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("gzip payload: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decode reverses Encode
+func Decode(data []byte) (models.RawPayload, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return models.RawPayload{}, fmt.Errorf("open gzip: %w", err)
+	}
+	defer zr.Close()
+
+	var meta header
+	if err := json.Unmarshal([]byte(zr.Comment), &meta); err != nil {
+		return models.RawPayload{}, fmt.Errorf("decode payload header: %w", err)
+	}
+
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		return models.RawPayload{}, fmt.Errorf("gunzip payload: %w", err)
+	}
+
+	return models.RawPayload{
+		Vendor:     meta.Vendor,
+		Kind:       meta.Kind,
+		Sport:      meta.Sport,
+		EventID:    meta.EventID,
+		OddsFormat: meta.OddsFormat,
+		Request:    meta.Request,
+		ReceivedAt: meta.ReceivedAt,
+		Body:       body,
+	}, nil
+}
+
+// ListRange returns keys for payloads received in [from, to), oldest first
+func ListRange(ctx context.Context, store Store, vendor, sport string, from, to time.Time) ([]string, error) {
+	from, to = timeutil.UTC(from), timeutil.UTC(to)
+
+	var keys []string
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		partition, err := store.List(ctx, PartitionPrefix(vendor, sport, day))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range partition {
+			if !strings.HasSuffix(key, keySuffix) {
+				continue
+			}
+			receivedAt, err := KeyTime(key)
+			if err != nil || receivedAt.Before(from) || !receivedAt.Before(to) {
+				continue
+			}
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, nil
+}
+
+// Read fetches and decodes one archived payload
+func Read(ctx context.Context, store Store, key string) (models.RawPayload, error) {
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		return models.RawPayload{}, err
+	}
+
+	payload, err := Decode(data)
+	if err != nil {
+		return models.RawPayload{}, fmt.Errorf("decode %s: %w", key, err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,148 @@
+// Package archive writes raw vendor payloads to object storage before they are parsed,
+// gzipped and partitioned by vendor/sport/date, and reads them back for replay.
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound is returned by Store.Get for a missing key
+var ErrNotFound = errors.New("archive object not found")
+
+// Store is a flat object store addressed by slash-separated keys
+type Store interface {
+	// Put writes an object, replacing any existing one
+	Put(ctx context.Context, key string, data []byte) error
+
+	// Get reads an object (ErrNotFound if missing)
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// List returns all keys starting with prefix, in lexical order
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Open creates a store from a URL:
+//
+//	file:///var/lib/mercury/archive   local directory
+//	s3://bucket/prefix                 Amazon S3 (or any S3-compatible endpoint)
+//	gs://bucket/prefix                 Google Cloud Storage via its S3-compatible XML API (HMAC keys)
+func Open(rawURL string, creds S3Config) (Store, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse archive URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		return NewFileStore(u.Path)
+	case "s3", "gs":
+		if u.Host == "" {
+			return nil, fmt.Errorf("archive URL %q has no bucket", rawURL)
+		}
+		creds.Bucket = u.Host
+		creds.Prefix = strings.Trim(u.Path, "/")
+		if u.Scheme == "gs" && creds.Endpoint == "" {
+			creds.Endpoint = gcsEndpoint
+		}
+		return NewS3Store(creds)
+	default:
+		return nil, fmt.Errorf("unsupported archive URL scheme %q (want file, s3 or gs)", u.Scheme)
+	}
+}
+
+// FileStore keeps objects as files under a root directory
+type FileStore struct {
+	root string
+}
+
+// NewFileStore creates a file store, creating the root directory if needed
+func NewFileStore(root string) (*FileStore, error) {
+	if root == "" {
+		return nil, errors.New("archive directory is required")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create archive directory: %w", err)
+	}
+	return &FileStore{root: root}, nil
+}
+
+// Put writes an object atomically (temp file + rename)
+func (s *FileStore) Put(ctx context.Context, key string, data []byte) error {
+	name := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("create partition: %w", err)
+	}
+
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp, name); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("rename %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get reads an object
+func (s *FileStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// List walks the directory holding prefix and returns matching keys
+func (s *FileStore) List(ctx context.Context, prefix string) ([]string, error) {
+	// Walk from the deepest directory fully covered by the prefix
+	dir := s.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dir = s.path(prefix[:i])
+	}
+
+	var keys []string
+	err := filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(name, ".tmp") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, name)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", prefix, err)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// path maps a key to a file under the root (keys cannot escape it)
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(path.Clean("/"+key)))
+}
@@ -0,0 +1,154 @@
+package bench
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// marketKeys are used in order; beyond these, markets are named market_<n>
+var marketKeys = []string{"h2h", "spreads", "totals", "h2h_q1", "spreads_q1", "totals_q1", "h2h_h1", "spreads_h1", "totals_h1"}
+
+// Generator synthesizes a slate of events × books × markets (two outcomes each) and
+// moves a fraction of the prices on every snapshot, like a live featured poll
+type Generator struct {
+	sport  string
+	churn  float64
+	rng    *rand.Rand
+	events []models.Event
+	odds   []models.RawOdds
+}
+
+// NewGenerator builds the initial slate. churn is the fraction of outcomes whose
+// price (or line) moves per snapshot, in [0, 1]. The same seed gives the same slate
+// and the same moves.
+func NewGenerator(sport string, events, books, markets int, churn float64, seed int64) *Generator {
+	g := &Generator{
+		sport: sport,
+		churn: churn,
+		rng:   rand.New(rand.NewSource(seed)),
+	}
+
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	for e := 0; e < events; e++ {
+		event := models.Event{
+			EventID:      fmt.Sprintf("bench_event_%04d", e),
+			SportKey:     sport,
+			HomeTeam:     fmt.Sprintf("Bench Home %d", e),
+			AwayTeam:     fmt.Sprintf("Bench Away %d", e),
+			CommenceTime: start.Add(time.Duration(e) * 30 * time.Minute),
+			EventStatus:  "upcoming",
+		}
+		g.events = append(g.events, event)
+
+		for b := 0; b < books; b++ {
+			for m := 0; m < markets; m++ {
+				g.odds = append(g.odds, g.outcomes(event, fmt.Sprintf("bench_book_%02d", b), marketKey(m))...)
+			}
+		}
+	}
+
+	return g
+}
+
+// Events returns the synthetic events
+func (g *Generator) Events() []models.Event {
+	return g.events
+}
+
+// Size returns the number of outcomes in every snapshot
+func (g *Generator) Size() int {
+	return len(g.odds)
+}
+
+// Next moves churned outcomes and returns a full snapshot stamped at now
+func (g *Generator) Next(now time.Time) []models.RawOdds {
+	snapshot := make([]models.RawOdds, len(g.odds))
+	for i := range g.odds {
+		odd := &g.odds[i]
+		if g.rng.Float64() < g.churn {
+			g.move(odd)
+			odd.VendorLastUpdate = now
+		}
+		odd.ReceivedAt = now
+
+		snapshot[i] = *odd
+		if odd.Point != nil {
+			point := *odd.Point
+			snapshot[i].Point = &point
+		}
+	}
+	return snapshot
+}
+
+// outcomes returns the two sides of a market at a book
+func (g *Generator) outcomes(event models.Event, book, market string) []models.RawOdds {
+	names := [2]string{event.HomeTeam, event.AwayTeam}
+	var points [2]*float64
+
+	switch {
+	case strings.HasPrefix(market, "spreads"):
+		line := float64(g.rng.Intn(20)) + 0.5
+		home, away := -line, line
+		points = [2]*float64{&home, &away}
+	case strings.HasPrefix(market, "totals"):
+		total := float64(200+g.rng.Intn(50)) + 0.5
+		over, under := total, total
+		names = [2]string{"Over", "Under"}
+		points = [2]*float64{&over, &under}
+	}
+
+	now := time.Now()
+	odds := make([]models.RawOdds, 2)
+	for side := range odds {
+		price := -110 - g.rng.Intn(10)
+		odds[side] = models.RawOdds{
+			EventID:          event.EventID,
+			SportKey:         g.sport,
+			MarketKey:        market,
+			BookKey:          book,
+			OutcomeName:      names[side],
+			Price:            price,
+			DecimalPrice:     models.AmericanToDecimal(price),
+			OddsFormat:       models.OddsFormatAmerican,
+			Point:            points[side],
+			VendorLastUpdate: now,
+			ReceivedAt:       now,
+		}
+	}
+	return odds
+}
+
+// move shifts a price by 5 cents (skipping the -100..+100 gap) and, for lines,
+// occasionally moves the point by half a point
+func (g *Generator) move(odd *models.RawOdds) {
+	step := 5
+	if g.rng.Intn(2) == 0 {
+		step = -5
+	}
+
+	price := odd.Price + step
+	switch {
+	case price > -100 && price < 100 && step > 0:
+		price = 100
+	case price > -100 && price < 100:
+		price = -100
+	}
+	odd.Price = price
+	odd.DecimalPrice = models.AmericanToDecimal(price)
+
+	if odd.Point != nil && g.rng.Intn(4) == 0 {
+		point := *odd.Point + 0.5*float64(step/5)
+		odd.Point = &point
+	}
+}
+
+func marketKey(i int) string {
+	if i < len(marketKeys) {
+		return marketKeys[i]
+	}
+	return fmt.Sprintf("market_%d", i)
+}
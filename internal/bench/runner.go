@@ -0,0 +1,228 @@
+package bench
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/delta"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// SLO is Mercury's per-poll budget for delta detection, write and cache update
+const SLO = 30 * time.Millisecond
+
+// Writer is the write stage (writer.Writer in production)
+type Writer interface {
+	WriteWithEvents(ctx context.Context, events []models.Event, odds []models.RawOdds) error
+}
+
+// Config controls a benchmark run
+type Config struct {
+	Rate     float64       // Snapshots per second (<= 0 runs back to back)
+	Duration time.Duration // Stop after this long
+	Batches  int           // Or after this many snapshots (0 = no limit)
+	SLO      time.Duration // Per-snapshot budget (default SLO)
+}
+
+// Runner drives generated snapshots through the scheduler's process stages:
+// delta detection, write of the deltas, then write-through cache update
+type Runner struct {
+	gen    *Generator
+	engine *delta.Engine
+	writer Writer // nil = skip the write stage
+}
+
+// NewRunner creates a runner; writer may be nil to benchmark delta detection alone
+func NewRunner(gen *Generator, engine *delta.Engine, writer Writer) *Runner {
+	return &Runner{
+		gen:    gen,
+		engine: engine,
+		writer: writer,
+	}
+}
+
+// Report summarizes a run
+type Report struct {
+	Batches   int
+	Odds      int
+	Deltas    int
+	Errors    int
+	Missed    int // Ticks skipped because the previous snapshot was still processing
+	Elapsed   time.Duration
+	SLO       time.Duration
+	SLOMisses int
+	Stages    map[string]Latency // detect, write, cache, total
+}
+
+// Latency is a per-snapshot stage latency distribution
+type Latency struct {
+	P50, P90, P99, Max time.Duration
+}
+
+// OddsPerSecond is the snapshot throughput in outcomes compared per second
+func (r Report) OddsPerSecond() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Odds) / r.Elapsed.Seconds()
+}
+
+// DeltasPerSecond is the write throughput in changed outcomes per second
+func (r Report) DeltasPerSecond() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Deltas) / r.Elapsed.Seconds()
+}
+
+// MeetsSLO reports whether p99 end-to-end latency is within the SLO
+func (r Report) MeetsSLO() bool {
+	return r.Stages["total"].P99 <= r.SLO
+}
+
+// Run processes snapshots until the duration or batch limit is reached or ctx is done.
+// The first snapshot seeds the cache (every outcome is new) and is not measured.
+func (r *Runner) Run(ctx context.Context, cfg Config) Report {
+	if cfg.SLO <= 0 {
+		cfg.SLO = SLO
+	}
+	report := Report{SLO: cfg.SLO}
+
+	events := r.gen.Events()
+	if _, err := r.process(ctx, events, r.gen.Next(time.Now()), nil); err != nil {
+		fmt.Printf("[Bench] seed error: %v\n", err)
+	}
+
+	var tick <-chan time.Time
+	if cfg.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.Rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	samples := map[string][]time.Duration{}
+	start := time.Now()
+	deadline := start.Add(cfg.Duration)
+
+	for {
+		if cfg.Batches > 0 && report.Batches >= cfg.Batches {
+			break
+		}
+		if cfg.Duration > 0 && !time.Now().Before(deadline) {
+			break
+		}
+
+		if tick != nil {
+			select {
+			case <-ctx.Done():
+				return finish(report, samples, start)
+			case <-tick:
+			}
+		} else if ctx.Err() != nil {
+			return finish(report, samples, start)
+		}
+
+		snapshot := r.gen.Next(time.Now())
+		stages := map[string]time.Duration{}
+		deltas, err := r.process(ctx, events, snapshot, stages)
+		if err != nil {
+			report.Errors++
+			fmt.Printf("[Bench] %v\n", err)
+		}
+
+		report.Batches++
+		report.Odds += len(snapshot)
+		report.Deltas += deltas
+		for stage, d := range stages {
+			samples[stage] = append(samples[stage], d)
+		}
+		if stages["total"] > cfg.SLO {
+			report.SLOMisses++
+		}
+
+		// The ticker drops ticks while we are busy; count them as missed
+		if tick != nil {
+		drain:
+			for {
+				select {
+				case <-tick:
+					report.Missed++
+				default:
+					break drain
+				}
+			}
+		}
+	}
+
+	return finish(report, samples, start)
+}
+
+// process runs one snapshot through detect → write → cache, recording stage timings
+func (r *Runner) process(ctx context.Context, events []models.Event, snapshot []models.RawOdds, stages map[string]time.Duration) (int, error) {
+	start := time.Now()
+	record := func(stage string, since time.Time) time.Time {
+		now := time.Now()
+		if stages != nil {
+			stages[stage] = now.Sub(since)
+		}
+		return now
+	}
+
+	deltas, err := r.engine.DetectChanges(ctx, snapshot)
+	if err != nil {
+		return 0, fmt.Errorf("detect changes: %w", err)
+	}
+	changed := make([]models.RawOdds, len(deltas))
+	for i, d := range deltas {
+		changed[i] = d.Odds()
+	}
+	mark := record("detect", start)
+
+	if r.writer != nil {
+		if len(changed) > 0 {
+			if err := r.writer.WriteWithEvents(ctx, events, changed); err != nil {
+				return len(changed), fmt.Errorf("write deltas: %w", err)
+			}
+		}
+		mark = record("write", mark)
+	}
+
+	if err := r.engine.UpdateCache(ctx, changed); err != nil {
+		return len(changed), fmt.Errorf("update cache: %w", err)
+	}
+	record("cache", mark)
+	record("total", start)
+
+	return len(changed), nil
+}
+
+func finish(report Report, samples map[string][]time.Duration, start time.Time) Report {
+	report.Elapsed = time.Since(start)
+	report.Stages = make(map[string]Latency, len(samples))
+	for stage, durations := range samples {
+		report.Stages[stage] = Distribution(durations)
+	}
+	return report
+}
+
+// Distribution computes nearest-rank percentiles (sorts durations in place)
+func Distribution(durations []time.Duration) Latency {
+	if len(durations) == 0 {
+		return Latency{}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	rank := func(p float64) time.Duration {
+		i := int(math.Ceil(p*float64(len(durations)))) - 1
+		return durations[max(i, 0)]
+	}
+	return Latency{
+		P50: rank(0.50),
+		P90: rank(0.90),
+		P99: rank(0.99),
+		Max: durations[len(durations)-1],
+	}
+}
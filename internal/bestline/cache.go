@@ -0,0 +1,323 @@
+// Package bestline maintains the best available price per event, market and outcome
+// across all books in Redis, updated from the delta path, so consumers read best lines
+// instead of computing them from every book's quotes.
+package bestline
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/pricebook"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "odds:best:"   // Hash per event: "<market>|<description>|<outcome>|<point>" -> BestPrice JSON
+	keyTTL    = 24 * time.Hour // Refreshed on every write; completed events are deleted explicitly
+)
+
+// BestPrice is the best available price for one outcome across all books
+type BestPrice struct {
+	EventID      string    `json:"event_id"`
+	SportKey     string    `json:"sport_key"`
+	MarketKey    string    `json:"market_key"`
+	OutcomeName  string    `json:"outcome_name"`
+	Description  string    `json:"description,omitempty"`
+	Point        *float64  `json:"point,omitempty"`
+	BookKey      string    `json:"book_key"` // Best book (alphabetical first on ties)
+	Price        int       `json:"price"`
+	PriceDecimal float64   `json:"price_decimal"`
+	Books        []string  `json:"books"`      // Every book offering the best price
+	BookCount    int       `json:"book_count"` // Books quoting the outcome
+	ReceivedAt   time.Time `json:"received_at"`
+	Stale        bool      `json:"stale,omitempty"`       // Only stale books quote the outcome
+	StaleBooks   []string  `json:"stale_books,omitempty"` // Stale books left out of the best price
+}
+
+// StaleChecker reports books whose quotes stopped moving (see internal/stalebook)
+type StaleChecker interface {
+	IsStale(sportKey, bookKey string) bool
+}
+
+// Key returns the Redis hash holding best prices for an event
+func Key(eventID string) string {
+	return keyPrefix + eventID
+}
+
+// Field returns the hash field for an outcome (signed point, so spread sides differ)
+func Field(marketKey, description, outcomeName string, point *float64) string {
+	p := ""
+	if point != nil {
+		p = strconv.FormatFloat(*point, 'f', -1, 64)
+	}
+	return marketKey + "|" + description + "|" + outcomeName + "|" + p
+}
+
+// Cache computes best prices from the latest quotes and writes them to Redis
+type Cache struct {
+	db    *sql.DB
+	redis *redis.Client
+
+	mu     sync.Mutex
+	prices *pricebook.Book
+	stale  StaleChecker
+}
+
+// NewCache creates a best-line cache
+func NewCache(db *sql.DB, redisClient *redis.Client) *Cache {
+	return &Cache{
+		db:     db,
+		redis:  redisClient,
+		prices: pricebook.New(),
+	}
+}
+
+// SetStaleBooks leaves stale books out of best prices while a fresh book quotes the outcome
+func (c *Cache) SetStaleBooks(checker StaleChecker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stale = checker
+}
+
+// Load seeds current prices from Alexandria and rebuilds the Redis best lines
+// Called on startup so lines that have not moved since are present
+func (c *Cache) Load(ctx context.Context) error {
+	odds, err := pricebook.LoadCurrent(ctx, c.db)
+	if err != nil {
+		return err
+	}
+
+	best := c.Apply(odds)
+	if err := c.write(ctx, best); err != nil {
+		return err
+	}
+
+	fmt.Printf("[BestLine] loaded %d current prices, %d best lines\n", len(odds), len(best))
+	return nil
+}
+
+// HandleDeltaBatchCommitted recomputes best prices for outcomes touched by a committed batch
+func (c *Cache) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	best := c.Apply(msg.Odds)
+	if err := c.write(ctx, best); err != nil {
+		fmt.Printf("[BestLine] write error: %v\n", err)
+	}
+}
+
+// HandleStaleBooksChanged recomputes best prices for a sport's lines quoted by books
+// that became stale or fresh again
+func (c *Cache) HandleStaleBooksChanged(ctx context.Context, sportKey string, books []string) {
+	best := c.Recompute(sportKey, books)
+	if err := c.write(ctx, best); err != nil {
+		fmt.Printf("[BestLine] write error: %v\n", err)
+	}
+}
+
+// Recompute returns the best price for every outcome of a sport's lines quoted by any of books
+func (c *Cache) Recompute(sportKey string, books []string) []BestPrice {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var best []BestPrice
+	c.prices.Range(func(key string, l *pricebook.Line) {
+		outcomes := make(map[string]bool)
+		for _, book := range books {
+			for outcomeKey, odd := range l.Quotes[book] {
+				if odd.SportKey == sportKey {
+					outcomes[outcomeKey] = true
+				}
+			}
+		}
+		for outcomeKey := range outcomes {
+			if bp, ok := bestOf(l, outcomeKey, c.isStale); ok {
+				best = append(best, bp)
+			}
+		}
+	})
+	return best
+}
+
+// isStale reports whether a book is stale (caller holds mu)
+func (c *Cache) isStale(sportKey, bookKey string) bool {
+	return c.stale != nil && c.stale.IsStale(sportKey, bookKey)
+}
+
+// HandleEventStatusChanged drops best lines for events that can no longer be bet
+func (c *Cache) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	switch msg.NewStatus {
+	case "completed", "cancelled", "postponed":
+		c.Evict(msg.EventID)
+		if err := c.redis.Del(ctx, Key(msg.EventID)).Err(); err != nil {
+			fmt.Printf("[BestLine] delete error for %s: %v\n", msg.EventID, err)
+		}
+	}
+}
+
+// Evict removes all prices held for an event
+func (c *Cache) Evict(eventID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.prices.Evict(eventID)
+}
+
+// Apply stores odds and returns the best price for every outcome they touch
+func (c *Cache) Apply(odds []models.RawOdds) []BestPrice {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	type touched struct {
+		lineKey    string
+		outcomeKey string
+	}
+
+	seen := make(map[touched]bool)
+	var order []touched
+	for _, odd := range odds {
+		t := touched{lineKey: c.prices.Apply(odd), outcomeKey: pricebook.OutcomeKey(odd)}
+		if !seen[t] {
+			seen[t] = true
+			order = append(order, t)
+		}
+	}
+
+	best := make([]BestPrice, 0, len(order))
+	for _, t := range order {
+		if bp, ok := bestOf(c.prices.Line(t.lineKey), t.outcomeKey, c.isStale); ok {
+			best = append(best, bp)
+		}
+	}
+	return best
+}
+
+// bestOf picks the highest decimal price quoted for an outcome on a line. Stale books
+// only count when no fresh book quotes the outcome
+func bestOf(l *pricebook.Line, outcomeKey string, isStale func(sportKey, bookKey string) bool) (BestPrice, bool) {
+	if l == nil {
+		return BestPrice{}, false
+	}
+
+	books := make([]string, 0, len(l.Quotes))
+	for book, quotes := range l.Quotes {
+		if _, ok := quotes[outcomeKey]; ok {
+			books = append(books, book)
+		}
+	}
+	if len(books) == 0 {
+		return BestPrice{}, false
+	}
+	sort.Strings(books)
+
+	var fresh, staleBooks []string
+	for _, book := range books {
+		if isStale(l.Quotes[book][outcomeKey].SportKey, book) {
+			staleBooks = append(staleBooks, book)
+		} else {
+			fresh = append(fresh, book)
+		}
+	}
+	candidates := fresh
+	if len(fresh) == 0 {
+		candidates = staleBooks
+	}
+
+	var best models.RawOdds
+	var bestBooks []string
+	for _, book := range candidates {
+		odd := l.Quotes[book][outcomeKey]
+		switch {
+		case len(bestBooks) == 0 || odd.Decimal() > best.Decimal():
+			best = odd
+			bestBooks = []string{book}
+		case odd.Decimal() == best.Decimal():
+			bestBooks = append(bestBooks, book)
+		}
+	}
+
+	return BestPrice{
+		EventID:      best.EventID,
+		SportKey:     best.SportKey,
+		MarketKey:    best.MarketKey,
+		OutcomeName:  best.OutcomeName,
+		Description:  best.Description,
+		Point:        best.Point,
+		BookKey:      best.BookKey,
+		Price:        best.Price,
+		PriceDecimal: best.Decimal(),
+		Books:        bestBooks,
+		BookCount:    len(books),
+		ReceivedAt:   best.ReceivedAt,
+		Stale:        len(fresh) == 0,
+		StaleBooks:   staleBooks,
+	}, true
+}
+
+// write stores best prices in their event hashes
+func (c *Cache) write(ctx context.Context, best []BestPrice) error {
+	if len(best) == 0 {
+		return nil
+	}
+
+	fields := make(map[string][]interface{})
+	for _, bp := range best {
+		data, err := json.Marshal(bp)
+		if err != nil {
+			return fmt.Errorf("marshal best price: %w", err)
+		}
+		key := Key(bp.EventID)
+		fields[key] = append(fields[key], Field(bp.MarketKey, bp.Description, bp.OutcomeName, bp.Point), data)
+	}
+
+	pipe := c.redis.Pipeline()
+	for key, values := range fields {
+		pipe.HSet(ctx, key, values...)
+		pipe.Expire(ctx, key, keyTTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+	return nil
+}
+
+// Get reads best prices for an event from Redis, optionally limited to markets
+// Results are ordered by market, description, outcome and point
+func Get(ctx context.Context, redisClient *redis.Client, eventID string, marketKeys []string) ([]BestPrice, error) {
+	values, err := redisClient.HGetAll(ctx, Key(eventID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read best lines: %w", err)
+	}
+
+	markets := make(map[string]bool, len(marketKeys))
+	for _, market := range marketKeys {
+		markets[market] = true
+	}
+
+	fieldNames := make([]string, 0, len(values))
+	for field := range values {
+		fieldNames = append(fieldNames, field)
+	}
+	sort.Strings(fieldNames)
+
+	best := make([]BestPrice, 0, len(fieldNames))
+	for _, field := range fieldNames {
+		var bp BestPrice
+		if err := json.Unmarshal([]byte(values[field]), &bp); err != nil {
+			return nil, fmt.Errorf("decode best line %s: %w", field, err)
+		}
+		if len(markets) > 0 && !markets[bp.MarketKey] {
+			continue
+		}
+		best = append(best, bp)
+	}
+
+	return best, nil
+}
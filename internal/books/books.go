@@ -0,0 +1,375 @@
+// Package books holds sportsbook metadata (classification, default consensus
+// weight, regions) seeded from config and kept in the Alexandria books table,
+// where the admin API edits it. Edge and consensus consumers consult it instead
+// of hardcoding sharp/soft labels.
+package books
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Class is a book's market-making classification
+type Class string
+
+const (
+	ClassSharp    Class = "sharp"    // Fast, efficient, low limits; fair price baseline
+	ClassSoft     Class = "soft"     // Slower, higher vig; where edges are found
+	ClassExchange Class = "exchange" // Peer-to-peer; commission instead of vig
+)
+
+// Classes lists every valid classification
+var Classes = []Class{ClassSharp, ClassSoft, ClassExchange}
+
+// classWeights are the consensus weights for books without an explicit default weight
+var classWeights = map[Class]float64{
+	ClassSharp:    1.0,
+	ClassSoft:     0.5,
+	ClassExchange: 0.8,
+}
+
+// Valid reports whether c is a known classification
+func (c Class) Valid() bool {
+	_, ok := classWeights[c]
+	return ok
+}
+
+// DefaultWeight returns the consensus weight for a classification
+// Unknown classifications get the soft weight
+func DefaultWeight(c Class) float64 {
+	if w, ok := classWeights[c]; ok {
+		return w
+	}
+	return classWeights[ClassSoft]
+}
+
+// Book is the metadata held for one sportsbook
+type Book struct {
+	Key         string   `json:"book_key"`
+	DisplayName string   `json:"display_name"`
+	Class       Class    `json:"book_type"`
+	Weight      *float64 `json:"default_weight,omitempty"` // nil = class default
+	Regions     []string `json:"regions"`
+	Active      bool     `json:"active"`
+	Muted       bool     `json:"muted"` // Left out of requests and ingestion entirely
+}
+
+// ConsensusWeight returns the book's default weight, or its class default when unset
+func (b Book) ConsensusWeight() float64 {
+	if b.Weight != nil {
+		return *b.Weight
+	}
+	return DefaultWeight(b.Class)
+}
+
+// Validate checks that a book can be stored
+func (b Book) Validate() error {
+	if b.Key == "" {
+		return fmt.Errorf("book_key is required")
+	}
+	if !b.Class.Valid() {
+		return fmt.Errorf("invalid book_type %q (want sharp, soft or exchange)", b.Class)
+	}
+	if b.Weight != nil && (*b.Weight < 0 || *b.Weight > 1) {
+		return fmt.Errorf("default_weight %.4f out of range [0, 1]", *b.Weight)
+	}
+	if len(b.Regions) == 0 {
+		return fmt.Errorf("at least one region is required")
+	}
+	return nil
+}
+
+// Defaults are the books Mercury knows before any config or admin edits
+var Defaults = []Book{
+	{Key: "pinnacle", DisplayName: "Pinnacle", Class: ClassSharp, Weight: weight(1.0), Regions: []string{"eu"}, Active: true},
+	{Key: "circa", DisplayName: "Circa Sports", Class: ClassSharp, Weight: weight(0.9), Regions: []string{"us"}, Active: true},
+	{Key: "bookmaker", DisplayName: "Bookmaker", Class: ClassSharp, Weight: weight(0.85), Regions: []string{"us"}, Active: true},
+	{Key: "betfair_ex_eu", DisplayName: "Betfair Exchange", Class: ClassExchange, Regions: []string{"eu"}, Active: true},
+	{Key: "betfair_ex_uk", DisplayName: "Betfair Exchange UK", Class: ClassExchange, Regions: []string{"uk"}, Active: true},
+	{Key: "matchbook", DisplayName: "Matchbook", Class: ClassExchange, Regions: []string{"eu", "uk"}, Active: true},
+	{Key: "novig", DisplayName: "Novig", Class: ClassExchange, Regions: []string{"us_ex"}, Active: true},
+	{Key: "prophetx", DisplayName: "ProphetX", Class: ClassExchange, Regions: []string{"us_ex"}, Active: true},
+	{Key: "fanduel", DisplayName: "FanDuel", Class: ClassSoft, Regions: []string{"us", "us2"}, Active: true},
+	{Key: "draftkings", DisplayName: "DraftKings", Class: ClassSoft, Regions: []string{"us", "us2"}, Active: true},
+	{Key: "betmgm", DisplayName: "BetMGM", Class: ClassSoft, Regions: []string{"us", "us2"}, Active: true},
+	{Key: "caesars", DisplayName: "Caesars Sportsbook", Class: ClassSoft, Regions: []string{"us", "us2"}, Active: true},
+	{Key: "betrivers", DisplayName: "BetRivers", Class: ClassSoft, Regions: []string{"us", "us2"}, Active: true},
+	{Key: "hardrockbet", DisplayName: "Hard Rock Bet", Class: ClassSoft, Regions: []string{"us2"}, Active: true},
+	{Key: "espnbet", DisplayName: "ESPN BET", Class: ClassSoft, Regions: []string{"us2"}, Active: true},
+	{Key: "fanatics", DisplayName: "Fanatics", Class: ClassSoft, Regions: []string{"us", "us2"}, Active: true},
+	{Key: "bovada", DisplayName: "Bovada", Class: ClassSoft, Regions: []string{"us"}, Active: true},
+	{Key: "betonlineag", DisplayName: "BetOnline.ag", Class: ClassSoft, Regions: []string{"us"}, Active: true},
+	{Key: "mybookieag", DisplayName: "MyBookie.ag", Class: ClassSoft, Regions: []string{"us"}, Active: true},
+	{Key: "lowvig", DisplayName: "LowVig.ag", Class: ClassSoft, Regions: []string{"us"}, Active: true},
+}
+
+// weight returns a pointer for literal default weights
+func weight(w float64) *float64 {
+	return &w
+}
+
+// Registry is the in-memory book metadata consulted on the hot path
+type Registry struct {
+	mu          sync.RWMutex
+	books       map[string]Book
+	byClass     map[Class][]string // Keys per class, highest weight first
+	configMuted map[string]bool    // Muted by MUTED_BOOKS whatever Alexandria says
+}
+
+// NewRegistry creates a registry holding the given books
+func NewRegistry(seed []Book) *Registry {
+	r := &Registry{books: make(map[string]Book, len(seed))}
+	for _, b := range seed {
+		r.books[b.Key] = clone(b)
+	}
+	r.index()
+	return r
+}
+
+// Get returns the metadata for a book
+func (r *Registry) Get(key string) (Book, bool) {
+	if r == nil {
+		return Book{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.books[key]
+	if !ok {
+		return Book{}, false
+	}
+	return clone(b), true
+}
+
+// Lookup returns the metadata for a book, or an active soft US book for keys the
+// registry has never seen (matching how new vendor books were always inserted)
+func (r *Registry) Lookup(key string) Book {
+	if b, ok := r.Get(key); ok {
+		return b
+	}
+	return Book{
+		Key:         key,
+		DisplayName: displayName(key),
+		Class:       ClassSoft,
+		Regions:     []string{"us"},
+		Active:      true,
+	}
+}
+
+// Class returns a book's classification (soft when unknown)
+func (r *Registry) Class(key string) Class {
+	return r.Lookup(key).Class
+}
+
+// Weight returns a book's default consensus weight
+func (r *Registry) Weight(key string) float64 {
+	return r.Lookup(key).ConsensusWeight()
+}
+
+// Keys returns the active, unmuted books of a class, highest default weight first
+// The returned slice is shared; callers must not modify it
+func (r *Registry) Keys(c Class) []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.byClass[c]
+}
+
+// All returns every book sorted by key
+func (r *Registry) All() []Book {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]Book, 0, len(r.books))
+	for _, b := range r.books {
+		all = append(all, clone(b))
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Key < all[j].Key })
+	return all
+}
+
+// Set adds or replaces a book
+func (r *Registry) Set(b Book) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	if b.DisplayName == "" {
+		b.DisplayName = displayName(b.Key)
+	}
+
+	r.mu.Lock()
+	r.books[b.Key] = clone(b)
+	r.index()
+	r.mu.Unlock()
+	return nil
+}
+
+// MuteFromConfig mutes books regardless of their stored state (e.g. MUTED_BOOKS)
+func (r *Registry) MuteFromConfig(keys []string) {
+	muted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			muted[key] = true
+		}
+	}
+
+	r.mu.Lock()
+	r.configMuted = muted
+	r.index()
+	r.mu.Unlock()
+}
+
+// Muted reports whether a book is muted from polling
+func (r *Registry) Muted(key string) bool {
+	if r == nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.configMuted[key] || r.books[key].Muted
+}
+
+// MutedKeys returns every muted book, sorted
+func (r *Registry) MutedKeys() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var keys []string
+	for key := range r.configMuted {
+		keys = append(keys, key)
+	}
+	for key, b := range r.books {
+		if b.Muted && !r.configMuted[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Unmuted returns the bookmakers to request without the muted ones. When every one
+// is muted the list is returned unchanged (an empty list would poll whole regions),
+// and the odds are filtered after the fetch instead
+func (r *Registry) Unmuted(bookmakers []string) []string {
+	if r == nil || len(bookmakers) == 0 {
+		return bookmakers
+	}
+
+	kept := make([]string, 0, len(bookmakers))
+	for _, key := range bookmakers {
+		if !r.Muted(key) {
+			kept = append(kept, key)
+		}
+	}
+	if len(kept) == 0 {
+		return bookmakers
+	}
+	return kept
+}
+
+// Count returns the number of books held
+func (r *Registry) Count() int {
+	if r == nil {
+		return 0
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.books)
+}
+
+// index rebuilds the per-class key lists of active, unmuted books; callers hold the
+// write lock
+func (r *Registry) index() {
+	byClass := make(map[Class][]string, len(Classes))
+	for key, b := range r.books {
+		if b.Active && !b.Muted && !r.configMuted[key] {
+			byClass[b.Class] = append(byClass[b.Class], key)
+		}
+	}
+
+	for _, keys := range byClass {
+		sort.Slice(keys, func(i, j int) bool {
+			wi, wj := r.books[keys[i]].ConsensusWeight(), r.books[keys[j]].ConsensusWeight()
+			if wi != wj {
+				return wi > wj
+			}
+			return keys[i] < keys[j]
+		})
+	}
+	r.byClass = byClass
+}
+
+// ApplyOverrides parses BOOK_CLASSES-style overrides ("key=class[:weight],...")
+// and applies them on top of the current metadata
+func (r *Registry) ApplyOverrides(spec string) error {
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return fmt.Errorf("invalid book override %q (want key=class[:weight])", item)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		classStr, weightStr, hasWeight := strings.Cut(value, ":")
+		b := r.Lookup(key)
+		class := Class(strings.ToLower(strings.TrimSpace(classStr)))
+		if class != b.Class {
+			b.Weight = nil // Reclassified books take the new class default unless given
+		}
+		b.Class = class
+		if hasWeight {
+			w, err := strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+			if err != nil {
+				return fmt.Errorf("invalid weight in book override %q: %w", item, err)
+			}
+			b.Weight = &w
+		}
+
+		if err := r.Set(b); err != nil {
+			return fmt.Errorf("book override %q: %w", item, err)
+		}
+	}
+	return nil
+}
+
+// clone copies a book so callers cannot mutate registry state
+func clone(b Book) Book {
+	b.Regions = append([]string(nil), b.Regions...)
+	if b.Weight != nil {
+		w := *b.Weight
+		b.Weight = &w
+	}
+	return b
+}
+
+// displayName capitalizes the first letter of a vendor book key
+func displayName(key string) string {
+	if key == "" {
+		return key
+	}
+	if key[0] >= 'a' && key[0] <= 'z' {
+		return string(key[0]-32) + key[1:]
+	}
+	return key
+}
@@ -0,0 +1,165 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Seed inserts the registry's books into Alexandria when they are not there yet
+// Existing rows are left alone so admin edits survive restarts
+func (r *Registry) Seed(ctx context.Context, db *sql.DB) (int, error) {
+	all := r.All()
+	if len(all) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	inserted := 0
+	for _, b := range all {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO books (book_key, display_name, book_type, default_weight, active, regions, muted, supported_sports)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, '{}')
+			ON CONFLICT (book_key) DO NOTHING
+		`, b.Key, b.DisplayName, string(b.Class), nullWeight(b.Weight), b.Active, pq.Array(b.Regions), b.Muted)
+		if err != nil {
+			return 0, fmt.Errorf("seed book %s: %w", b.Key, err)
+		}
+		if n, err := res.RowsAffected(); err == nil {
+			inserted += int(n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit: %w", err)
+	}
+	return inserted, nil
+}
+
+// Load replaces registry entries with the rows stored in Alexandria
+func (r *Registry) Load(ctx context.Context, db *sql.DB) (int, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT book_key, display_name, book_type, default_weight, active, regions, muted
+		FROM books
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("query books: %w", err)
+	}
+	defer rows.Close()
+
+	var loaded []Book
+	for rows.Next() {
+		var b Book
+		var class string
+		var w sql.NullFloat64
+		if err := rows.Scan(&b.Key, &b.DisplayName, &class, &w, &b.Active, pq.Array(&b.Regions), &b.Muted); err != nil {
+			return 0, fmt.Errorf("scan book: %w", err)
+		}
+		b.Class = Class(class)
+		if w.Valid {
+			b.Weight = &w.Float64
+		}
+		if len(b.Regions) == 0 {
+			b.Regions = []string{"us"}
+		}
+		loaded = append(loaded, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	for _, b := range loaded {
+		if err := r.Set(b); err != nil {
+			fmt.Printf("[Books] skipping %s: %v\n", b.Key, err)
+		}
+	}
+	return len(loaded), nil
+}
+
+// Save writes a book to Alexandria and then to the registry
+func (r *Registry) Save(ctx context.Context, db *sql.DB, b Book) error {
+	if err := b.Validate(); err != nil {
+		return err
+	}
+	if b.DisplayName == "" {
+		b.DisplayName = displayName(b.Key)
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO books (book_key, display_name, book_type, default_weight, active, regions, muted, supported_sports)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, '{}')
+		ON CONFLICT (book_key) DO UPDATE SET
+			display_name = EXCLUDED.display_name,
+			book_type = EXCLUDED.book_type,
+			default_weight = EXCLUDED.default_weight,
+			active = EXCLUDED.active,
+			regions = EXCLUDED.regions,
+			muted = EXCLUDED.muted,
+			updated_at = NOW()
+	`, b.Key, b.DisplayName, string(b.Class), nullWeight(b.Weight), b.Active, pq.Array(b.Regions), b.Muted)
+	if err != nil {
+		return fmt.Errorf("save book %s: %w", b.Key, err)
+	}
+
+	return r.Set(b)
+}
+
+// nullWeight maps an unset default weight to NULL
+func nullWeight(w *float64) sql.NullFloat64 {
+	if w == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *w, Valid: true}
+}
+
+// Refresher periodically reloads the registry so admin edits made through
+// another instance reach this one
+type Refresher struct {
+	registry     *Registry
+	db           *sql.DB
+	pollInterval time.Duration
+	stopChan     chan struct{}
+}
+
+// NewRefresher creates a new registry refresher
+func NewRefresher(registry *Registry, db *sql.DB, pollInterval time.Duration) *Refresher {
+	return &Refresher{
+		registry:     registry,
+		db:           db,
+		pollInterval: pollInterval,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins periodic reloads
+func (f *Refresher) Start(ctx context.Context) {
+	ticker := time.NewTicker(f.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := f.registry.Load(ctx, f.db); err != nil {
+				fmt.Printf("[Books] reload error: %v\n", err)
+			}
+		case <-f.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the refresher
+func (f *Refresher) Stop() {
+	close(f.stopChan)
+}
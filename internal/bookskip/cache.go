@@ -0,0 +1,108 @@
+// Package bookskip remembers the vendor last_update of every bookmaker whose odds
+// made it through the pipeline, so the adapter can skip a bookmaker whose timestamp
+// has not advanced before exploding its markets into RawOdds. On quiet slates most
+// books are unchanged between polls, and skipping them keeps their outcomes out of
+// the delta engine entirely.
+package bookskip
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultMaxAge bounds how long a bookmaker may be skipped before it is parsed again
+// anyway, in case the vendor changed a price without bumping last_update
+const DefaultMaxAge = 10 * time.Minute
+
+// entry is the last committed timestamp of one bookmaker
+type entry struct {
+	lastUpdate  time.Time
+	committedAt time.Time
+}
+
+// Cache holds committed bookmaker timestamps. Stamps are committed only after the
+// odds parsed with them were processed, so a failed write is retried on the next poll
+type Cache struct {
+	maxAge time.Duration
+
+	mu        sync.RWMutex
+	stamps    map[string]entry
+	lastPrune time.Time
+
+	skipped atomic.Int64
+	parsed  atomic.Int64
+}
+
+// NewCache creates a cache (maxAge <= 0 uses DefaultMaxAge)
+func NewCache(maxAge time.Duration) *Cache {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return &Cache{
+		maxAge: maxAge,
+		stamps: make(map[string]entry),
+	}
+}
+
+// Key identifies a bookmaker's quotes for one event and set of markets. The market
+// set is part of the key because featured, split and props requests return different
+// slices of the same book under the same last_update
+func Key(eventID, bookKey string, markets []string) string {
+	sorted := append([]string(nil), markets...)
+	sort.Strings(sorted)
+	return eventID + "|" + bookKey + "|" + strings.Join(sorted, ",")
+}
+
+// Unchanged reports whether a bookmaker can be skipped: its last_update has not
+// advanced past the committed one, which is younger than the max age
+func (c *Cache) Unchanged(key string, lastUpdate time.Time, now time.Time) bool {
+	c.mu.RLock()
+	e, ok := c.stamps[key]
+	c.mu.RUnlock()
+
+	if ok && !lastUpdate.IsZero() && !lastUpdate.After(e.lastUpdate) && now.Sub(e.committedAt) < c.maxAge {
+		c.skipped.Add(1)
+		return true
+	}
+	c.parsed.Add(1)
+	return false
+}
+
+// Commit records the timestamps of bookmakers whose odds were processed
+func (c *Cache) Commit(stamps map[string]time.Time, now time.Time) {
+	if len(stamps) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, lastUpdate := range stamps {
+		c.stamps[key] = entry{lastUpdate: lastUpdate, committedAt: now}
+	}
+
+	// Entries past the max age are parsed again anyway; drop them (finished events)
+	if now.Sub(c.lastPrune) >= c.maxAge {
+		for key, e := range c.stamps {
+			if now.Sub(e.committedAt) >= c.maxAge {
+				delete(c.stamps, key)
+			}
+		}
+		c.lastPrune = now
+	}
+}
+
+// Len returns the number of tracked bookmakers
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.stamps)
+}
+
+// Stats returns how many bookmakers were skipped and parsed since startup
+func (c *Cache) Stats() (skipped, parsed int64) {
+	return c.skipped.Load(), c.parsed.Load()
+}
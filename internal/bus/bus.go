@@ -0,0 +1,212 @@
+// Package bus provides a lightweight in-process event bus so modules can react to
+// pipeline events (new events, committed deltas, status changes) without direct calls.
+package bus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Topic identifies a class of bus messages
+type Topic string
+
+const (
+	TopicEventDiscovered     Topic = "event.discovered"
+	TopicDeltaBatchCommitted Topic = "delta.batch_committed"
+	TopicEventStatusChanged  Topic = "event.status_changed"
+	defaultSubscriberBuffer        = 256
+)
+
+// EventDiscovered is published when events are seen for the first time
+type EventDiscovered struct {
+	Events []models.Event
+}
+
+// DeltaBatchCommitted is published after a batch of odds deltas is committed to Alexandria
+type DeltaBatchCommitted struct {
+	Events      []models.Event
+	Odds        []models.RawOdds
+	CommittedAt time.Time
+}
+
+// EventStatusChanged is published when an event transitions between statuses
+type EventStatusChanged struct {
+	EventID      string
+	SportKey     string
+	HomeTeam     string
+	AwayTeam     string
+	CommenceTime time.Time
+	OldStatus    string
+	NewStatus    string
+	ChangedAt    time.Time
+}
+
+// subscription is a single subscriber with its own delivery goroutine
+type subscription struct {
+	name    string
+	topic   Topic
+	handler func(ctx context.Context, msg interface{})
+	ch      chan interface{}
+	dropped atomic.Int64
+}
+
+// Bus delivers typed messages to subscribers asynchronously
+// Each subscriber has a buffered queue; a slow subscriber drops messages
+// rather than blocking publishers on the hot path
+type Bus struct {
+	subs   map[Topic][]*subscription
+	mu     sync.RWMutex
+	buffer int
+
+	started  bool
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// New creates a new event bus
+func New() *Bus {
+	return &Bus{
+		subs:     make(map[Topic][]*subscription),
+		buffer:   defaultSubscriberBuffer,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SubscribeEventDiscovered registers a handler for EventDiscovered messages
+func (b *Bus) SubscribeEventDiscovered(name string, handler func(ctx context.Context, msg EventDiscovered)) {
+	b.subscribe(name, TopicEventDiscovered, func(ctx context.Context, msg interface{}) {
+		handler(ctx, msg.(EventDiscovered))
+	})
+}
+
+// SubscribeDeltaBatchCommitted registers a handler for DeltaBatchCommitted messages
+func (b *Bus) SubscribeDeltaBatchCommitted(name string, handler func(ctx context.Context, msg DeltaBatchCommitted)) {
+	b.subscribe(name, TopicDeltaBatchCommitted, func(ctx context.Context, msg interface{}) {
+		handler(ctx, msg.(DeltaBatchCommitted))
+	})
+}
+
+// SubscribeEventStatusChanged registers a handler for EventStatusChanged messages
+func (b *Bus) SubscribeEventStatusChanged(name string, handler func(ctx context.Context, msg EventStatusChanged)) {
+	b.subscribe(name, TopicEventStatusChanged, func(ctx context.Context, msg interface{}) {
+		handler(ctx, msg.(EventStatusChanged))
+	})
+}
+
+// PublishEventDiscovered publishes an EventDiscovered message
+func (b *Bus) PublishEventDiscovered(msg EventDiscovered) {
+	b.publish(TopicEventDiscovered, msg)
+}
+
+// PublishDeltaBatchCommitted publishes a DeltaBatchCommitted message
+func (b *Bus) PublishDeltaBatchCommitted(msg DeltaBatchCommitted) {
+	b.publish(TopicDeltaBatchCommitted, msg)
+}
+
+// PublishEventStatusChanged publishes an EventStatusChanged message
+func (b *Bus) PublishEventStatusChanged(msg EventStatusChanged) {
+	b.publish(TopicEventStatusChanged, msg)
+}
+
+// Start launches a delivery goroutine per subscriber
+// Subscriptions must be registered before Start
+func (b *Bus) Start(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.started {
+		return
+	}
+	b.started = true
+
+	for _, subs := range b.subs {
+		for _, sub := range subs {
+			b.wg.Add(1)
+			go func(sub *subscription) {
+				defer b.wg.Done()
+				b.deliver(ctx, sub)
+			}(sub)
+		}
+	}
+}
+
+// Stop drains queued messages and stops all delivery goroutines
+func (b *Bus) Stop() {
+	close(b.stopChan)
+	b.wg.Wait()
+}
+
+// subscribe registers a subscription for a topic
+func (b *Bus) subscribe(name string, topic Topic, handler func(ctx context.Context, msg interface{})) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.started {
+		panic(fmt.Sprintf("bus: subscribe %q to %s after Start", name, topic))
+	}
+
+	b.subs[topic] = append(b.subs[topic], &subscription{
+		name:    name,
+		topic:   topic,
+		handler: handler,
+		ch:      make(chan interface{}, b.buffer),
+	})
+}
+
+// publish enqueues a message for every subscriber of a topic without blocking
+func (b *Bus) publish(topic Topic, msg interface{}) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subs[topic] {
+		select {
+		case sub.ch <- msg:
+		default:
+			dropped := sub.dropped.Add(1)
+			fmt.Printf("[Bus] subscriber %s is full, dropped %s message (total dropped: %d)\n",
+				sub.name, topic, dropped)
+		}
+	}
+}
+
+// deliver runs a subscriber's handler for each queued message
+func (b *Bus) deliver(ctx context.Context, sub *subscription) {
+	for {
+		select {
+		case msg := <-sub.ch:
+			b.invoke(ctx, sub, msg)
+		case <-b.stopChan:
+			// Drain anything already queued before exiting
+			for {
+				select {
+				case msg := <-sub.ch:
+					b.invoke(ctx, sub, msg)
+				default:
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// invoke calls a handler, recovering from panics so one subscriber can't take down the bus
+func (b *Bus) invoke(ctx context.Context, sub *subscription, msg interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("[Bus] subscriber %s panicked handling %s: %v\n", sub.name, sub.topic, r)
+		}
+	}()
+
+	sub.handler(ctx, msg)
+}
@@ -6,15 +6,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/lifecycle"
 	"github.com/redis/go-redis/v9"
 )
 
 // Capturer monitors events and captures closing lines when they go live
 type Capturer struct {
-	db          *sql.DB
-	redisClient *redis.Client
+	db           *sql.DB
+	redisClient  *redis.Client
 	pollInterval time.Duration
-	stopChan    chan struct{}
+	clock        clock.Clock
 }
 
 // NewCapturer creates a new closing line capturer
@@ -23,40 +26,58 @@ func NewCapturer(db *sql.DB, redisClient *redis.Client, pollInterval time.Durati
 		db:           db,
 		redisClient:  redisClient,
 		pollInterval: pollInterval,
-		stopChan:     make(chan struct{}),
+		clock:        clock.Real,
 	}
 }
 
-// Start begins monitoring for events going live
-func (c *Capturer) Start(ctx context.Context) {
-	ticker := time.NewTicker(c.pollInterval)
+// SetClock replaces the wall clock driving the capture ticker (for tests)
+func (c *Capturer) SetClock(clk clock.Clock) {
+	c.clock = clk
+}
+
+// Run monitors for events going live until ctx is done. Failures are logged;
+// it returns an error after lifecycle.DefaultMaxFailures in a row
+func (c *Capturer) Run(ctx context.Context) error {
+	ticker := c.clock.NewTicker(c.pollInterval)
 	defer ticker.Stop()
 
 	fmt.Println("✓ Closing line capturer started")
 
-	// Initial check immediately
-	if err := c.captureClosingLines(ctx); err != nil {
-		fmt.Printf("[Closer] initial capture error: %v\n", err)
-	}
+	var failures lifecycle.Failures
 
+	// Check immediately, then on every tick
 	for {
-		select {
-		case <-ticker.C:
-			if err := c.captureClosingLines(ctx); err != nil {
-				fmt.Printf("[Closer] capture error: %v\n", err)
-			}
-		case <-c.stopChan:
+		err := c.captureClosingLines(ctx)
+		if ctx.Err() != nil {
 			fmt.Println("✓ Closing line capturer stopped")
-			return
+			return nil
+		}
+		if err != nil {
+			fmt.Printf("[Closer] capture error: %v\n", err)
+		}
+		if err := failures.Record(err); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C():
 		case <-ctx.Done():
-			return
+			fmt.Println("✓ Closing line capturer stopped")
+			return nil
 		}
 	}
 }
 
-// Stop gracefully stops the capturer
-func (c *Capturer) Stop() {
-	close(c.stopChan)
+// HandleEventStatusChanged captures closing lines as soon as an event goes live (bus subscriber)
+// The polling loop remains as a fallback for transitions that happen outside this process
+func (c *Capturer) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	if msg.NewStatus != "live" {
+		return
+	}
+
+	if err := c.captureEventClosingLines(ctx, msg.EventID); err != nil {
+		fmt.Printf("[Closer] error capturing lines for event %s: %v\n", msg.EventID, err)
+	}
 }
 
 // captureClosingLines finds events that just went live and captures their closing lines
@@ -110,14 +131,26 @@ func (c *Capturer) captureEventClosingLines(ctx context.Context, eventID string)
 	}
 	defer tx.Rollback()
 
-	// Insert closing lines from current odds
+	// Insert closing lines: each outcome's last quote received before commence time,
+	// from odds_raw or odds_live (in-play quotes written before the status change
+	// may be in either, and must not count)
 	// Convert NULL points to 0 for h2h markets (primary key compatibility)
+	quotes := func(table string) string {
+		return `SELECT o.id, o.event_id, o.sport_key, o.market_key, o.book_key, o.outcome_name, o.description,
+			       o.price, o.point, o.received_at
+			FROM ` + table + ` o
+			JOIN events ev ON ev.event_id = o.event_id
+			WHERE o.event_id = $1 AND o.received_at <= ev.commence_time`
+	}
 	insertQuery := `
-		INSERT INTO closing_lines (event_id, sport_key, market_key, book_key, outcome_name, closing_price, point, closed_at)
-		SELECT event_id, sport_key, market_key, book_key, outcome_name, price, COALESCE(point, 0), NOW()
-		FROM odds_raw
-		WHERE event_id = $1 AND is_latest = true
-		ON CONFLICT (event_id, market_key, book_key, outcome_name, point) DO NOTHING
+		INSERT INTO closing_lines (event_id, sport_key, market_key, book_key, outcome_name, description, closing_price, point, closed_at)
+		SELECT DISTINCT ON (market_key, book_key, outcome_name, description)
+		       event_id, sport_key, market_key, book_key, outcome_name, description, price, COALESCE(point, 0), NOW()
+		FROM (` + quotes("odds_raw") + `
+			UNION ALL
+			` + quotes("odds_live") + `) q
+		ORDER BY market_key, book_key, outcome_name, description, received_at DESC, id DESC
+		ON CONFLICT (event_id, market_key, book_key, outcome_name, description, point) DO NOTHING
 	`
 
 	result, err := tx.ExecContext(ctx, insertQuery, eventID)
@@ -149,10 +182,10 @@ func (c *Capturer) captureEventClosingLines(ctx context.Context, eventID string)
 // publishClosingLineEvent publishes a message to Redis stream
 func (c *Capturer) publishClosingLineEvent(ctx context.Context, eventID string) error {
 	streamName := "closing_lines.captured"
-	
+
 	values := map[string]interface{}{
 		"event_id":    eventID,
-		"captured_at": time.Now().UTC().Format(time.RFC3339),
+		"captured_at": c.clock.Now().UTC().Format(time.RFC3339),
 	}
 
 	_, err := c.redisClient.XAdd(ctx, &redis.XAddArgs{
@@ -166,4 +199,3 @@ func (c *Capturer) publishClosingLineEvent(ctx context.Context, eventID string)
 
 	return nil
 }
-
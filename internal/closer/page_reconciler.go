@@ -0,0 +1,183 @@
+package closer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/lifecycle"
+	"github.com/XavierBriggs/Mercury/internal/talos"
+	"github.com/lib/pq"
+)
+
+// stalePageAge closes pages for games that started long ago regardless of status
+const stalePageAge = 24 * time.Hour
+
+// EventStatusSource looks up stored event statuses
+type EventStatusSource interface {
+	// EventStatuses returns event_status keyed by event_id for the events that still exist
+	EventStatuses(ctx context.Context, eventIDs []string) (map[string]string, error)
+}
+
+// PageReconciler periodically closes Talos pages whose events are finished,
+// cancelled, postponed, or no longer exist in Alexandria
+type PageReconciler struct {
+	statuses     EventStatusSource
+	warmQueue    *talos.WarmQueue
+	pollInterval time.Duration
+	clock        clock.Clock
+}
+
+// NewPageReconciler creates a new Talos page reconciler reading statuses from db
+func NewPageReconciler(db *sql.DB, warmQueue *talos.WarmQueue, pollInterval time.Duration) *PageReconciler {
+	return &PageReconciler{
+		statuses:     dbStatuses{db: db},
+		warmQueue:    warmQueue,
+		pollInterval: pollInterval,
+		clock:        clock.Real,
+	}
+}
+
+// SetClock replaces the wall clock driving the sweep ticker and the stale page age
+// (for tests)
+func (r *PageReconciler) SetClock(clk clock.Clock) {
+	r.clock = clk
+}
+
+// SetStatusSource replaces the events table as the source of event statuses (for tests)
+func (r *PageReconciler) SetStatusSource(source EventStatusSource) {
+	r.statuses = source
+}
+
+// Run sweeps open pages until ctx is done. Failures are logged;
+// it returns an error after lifecycle.DefaultMaxFailures in a row
+func (r *PageReconciler) Run(ctx context.Context) error {
+	ticker := r.clock.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	fmt.Println("✓ Talos page reconciler started")
+
+	var failures lifecycle.Failures
+
+	// Sweep immediately (catches pages left open across restarts), then on every tick
+	for {
+		err := r.reconcile(ctx)
+		if ctx.Err() != nil {
+			fmt.Println("✓ Talos page reconciler stopped")
+			return nil
+		}
+		if err != nil {
+			fmt.Printf("[PageReconciler] sweep error: %v\n", err)
+		}
+		if err := failures.Record(err); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C():
+		case <-ctx.Done():
+			fmt.Println("✓ Talos page reconciler stopped")
+			return nil
+		}
+	}
+}
+
+// reconcile closes open pages that should no longer be open
+func (r *PageReconciler) reconcile(ctx context.Context) error {
+	if !r.warmQueue.IsEnabled() {
+		return nil
+	}
+
+	pages, err := r.warmQueue.OpenPages(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(pages) == 0 {
+		return nil
+	}
+
+	eventIDs := make([]string, len(pages))
+	for i, page := range pages {
+		eventIDs[i] = page.EventID
+	}
+
+	statuses, err := r.statuses.EventStatuses(ctx, eventIDs)
+	if err != nil {
+		return err
+	}
+
+	now := r.clock.Now()
+	closed := 0
+
+	for _, page := range pages {
+		status, exists := statuses[page.EventID]
+
+		reason := ""
+		switch {
+		case !exists:
+			reason = "event no longer exists"
+		case talos.IsTerminalStatus(status):
+			reason = "event " + status
+		case !page.CommenceTime.IsZero() && now.Sub(page.CommenceTime) > stalePageAge:
+			reason = "page is stale"
+		default:
+			continue
+		}
+
+		closeCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err := r.warmQueue.ClosePage(closeCtx, page)
+		cancel()
+
+		if err != nil {
+			fmt.Printf("[PageReconciler] failed to close page for %s (%s): %v\n", page.EventID, reason, err)
+			continue
+		}
+
+		closed++
+		fmt.Printf("[PageReconciler] closed page for %s @ %s (%s)\n", page.AwayTeam, page.HomeTeam, reason)
+	}
+
+	if closed > 0 {
+		fmt.Printf("[PageReconciler] closed %d of %d open page(s)\n", closed, len(pages))
+	}
+
+	return nil
+}
+
+// dbStatuses reads event statuses from the events table
+type dbStatuses struct {
+	db *sql.DB
+}
+
+// EventStatuses implements EventStatusSource
+func (s dbStatuses) EventStatuses(ctx context.Context, eventIDs []string) (map[string]string, error) {
+	query := `
+		SELECT event_id, event_status
+		FROM events
+		WHERE event_id = ANY($1)
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(eventIDs))
+	if err != nil {
+		return nil, fmt.Errorf("query page event statuses: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := make(map[string]string, len(eventIDs))
+	for rows.Next() {
+		var eventID, status string
+		if err := rows.Scan(&eventID, &status); err != nil {
+			return nil, fmt.Errorf("scan event status: %w", err)
+		}
+		statuses[eventID] = status
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return statuses, nil
+}
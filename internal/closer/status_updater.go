@@ -6,24 +6,54 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/XavierBriggs/Mercury/internal/talos"
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/lifecycle"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/internal/sporthooks"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
 )
 
-// completedEvent holds the details needed to close game pages
-type completedEvent struct {
-	EventID      string
-	SportKey     string
-	HomeTeam     string
-	AwayTeam     string
-	CommenceTime time.Time
-}
+// defaultGameDuration is used for sports without a registered module
+const defaultGameDuration = 3 * time.Hour
+
+// completedCondition matches live events whose sport-specific game duration has elapsed
+// and that the vendor does not report as still in progress
+// $1 = sport keys, $2 = durations in seconds (parallel arrays), $3 = fallback duration in
+// seconds, $4 = event IDs the vendor reports in progress
+const completedCondition = `
+		event_status = 'live'
+		  AND commence_time < NOW() - make_interval(secs => COALESCE(
+			(SELECT d.secs FROM UNNEST($1::text[], $2::float8[]) AS d(sport_key, secs)
+			 WHERE d.sport_key = events.sport_key),
+			$3::float8))
+		  AND NOT (event_id = ANY($4::text[]))
+`
+
+// cancelAfter is how long after its original start a postponed event the vendor has
+// not relisted is treated as cancelled
+const cancelAfter = 48 * time.Hour
 
-// StatusUpdater updates event status based on commence_time
+// scoresDaysFrom asks the scores endpoint for games completed since yesterday too
+const scoresDaysFrom = 1
+
+// StatusUpdater owns event_status: it is the only component that changes the status
+// of a stored event. Vendor game state (scores and completed flags) wins when a scores
+// adapter is set; commence time and game duration heuristics cover the rest.
+// Transitions are published on the event bus; Talos page closing and closing line
+// capture subscribe rather than being called directly
 type StatusUpdater struct {
-	db           *sql.DB
-	talos        *talos.Client // Optional Talos client for page closing
-	pollInterval time.Duration
-	stopChan     chan struct{}
+	db             *sql.DB
+	eventBus       *bus.Bus                // Optional bus for status change notifications
+	sports         *registry.SportRegistry // Optional registry for per-sport game durations
+	scores         contracts.ScoresAdapter // Optional vendor game state
+	scoresInterval time.Duration
+	lastScores     time.Time
+	inProgress     []string // Event IDs the vendor last reported started but not completed
+	pollInterval   time.Duration
+	clock          clock.Clock
 }
 
 // NewStatusUpdater creates a new event status updater
@@ -31,146 +61,335 @@ func NewStatusUpdater(db *sql.DB, pollInterval time.Duration) *StatusUpdater {
 	return &StatusUpdater{
 		db:           db,
 		pollInterval: pollInterval,
-		stopChan:     make(chan struct{}),
+		clock:        clock.Real,
 	}
 }
 
-// SetTalosClient sets the Talos client for page closing
-func (s *StatusUpdater) SetTalosClient(client *talos.Client) {
-	s.talos = client
+// SetClock replaces the wall clock driving the update ticker, the scores interval
+// and transition timestamps (for tests)
+func (s *StatusUpdater) SetClock(clk clock.Clock) {
+	s.clock = clk
+}
+
+// SetEventBus sets the event bus used to announce status transitions
+func (s *StatusUpdater) SetEventBus(eventBus *bus.Bus) {
+	s.eventBus = eventBus
+}
+
+// SetSportRegistry sets the sport registry used to look up per-sport game durations
+// and the OnEventLive / OnEventCompleted hooks sport modules implement
+func (s *StatusUpdater) SetSportRegistry(sportRegistry *registry.SportRegistry) {
+	s.sports = sportRegistry
+}
+
+// SetScoresAdapter applies the vendor's game state every interval (at most once per
+// status update) for sports with games in progress
+func (s *StatusUpdater) SetScoresAdapter(adapter contracts.ScoresAdapter, interval time.Duration) {
+	s.scores = adapter
+	s.scoresInterval = interval
+}
+
+// gameDurations returns parallel arrays of sport keys and game durations (seconds)
+// for all registered sports
+func (s *StatusUpdater) gameDurations() ([]string, []float64) {
+	sportKeys := []string{}
+	durations := []float64{}
+
+	if s.sports == nil {
+		return sportKeys, durations
+	}
+
+	for _, sport := range s.sports.GetAll() {
+		duration := sport.GetTypicalGameDuration()
+		if duration <= 0 {
+			continue
+		}
+		sportKeys = append(sportKeys, sport.GetSportKey())
+		durations = append(durations, duration.Seconds())
+	}
+
+	return sportKeys, durations
 }
 
-// Start begins monitoring and updating event statuses
-func (s *StatusUpdater) Start(ctx context.Context) {
-	ticker := time.NewTicker(s.pollInterval)
+// Run updates event statuses until ctx is done. Failures are logged;
+// it returns an error after lifecycle.DefaultMaxFailures in a row
+func (s *StatusUpdater) Run(ctx context.Context) error {
+	ticker := s.clock.NewTicker(s.pollInterval)
 	defer ticker.Stop()
 
 	fmt.Println("✓ Event status updater started")
 
-	// Initial update immediately
-	if err := s.updateStatuses(ctx); err != nil {
-		fmt.Printf("[StatusUpdater] initial update error: %v\n", err)
-	}
+	var failures lifecycle.Failures
 
+	// Update immediately, then on every tick
 	for {
-		select {
-		case <-ticker.C:
-			if err := s.updateStatuses(ctx); err != nil {
-				fmt.Printf("[StatusUpdater] update error: %v\n", err)
-			}
-		case <-s.stopChan:
+		err := s.updateStatuses(ctx)
+		if ctx.Err() != nil {
 			fmt.Println("✓ Event status updater stopped")
-			return
+			return nil
+		}
+		if err != nil {
+			fmt.Printf("[StatusUpdater] update error: %v\n", err)
+		}
+		if err := failures.Record(err); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C():
 		case <-ctx.Done():
-			return
+			fmt.Println("✓ Event status updater stopped")
+			return nil
 		}
 	}
 }
 
-// Stop gracefully stops the updater
-func (s *StatusUpdater) Stop() {
-	close(s.stopChan)
-}
-
-// updateStatuses updates event statuses based on current time
+// updateStatuses applies vendor game state, then the time heuristics
 func (s *StatusUpdater) updateStatuses(ctx context.Context) error {
-	// Update upcoming -> live (games that started in last 5 minutes)
+	if s.scores != nil && s.clock.Since(s.lastScores) >= s.scoresInterval {
+		if err := s.applyScores(ctx); err != nil {
+			fmt.Printf("[StatusUpdater] scores error: %v\n", err)
+		}
+	}
+
+	// Update upcoming -> live (games that started and were still listed near start;
+	// the rest are left to the postponed check below)
 	liveQuery := `
 		UPDATE events
 		SET event_status = 'live'
 		WHERE event_status = 'upcoming'
 		  AND commence_time <= NOW()
-		  AND commence_time > NOW() - INTERVAL '5 minutes'
+		  AND last_seen_at >= commence_time - INTERVAL '1 hour'
+		RETURNING event_id, sport_key, home_team, away_team, commence_time
 	`
 
-	liveResult, err := s.db.ExecContext(ctx, liveQuery)
+	wentLive, err := s.transition(ctx, "upcoming", "live", liveQuery)
 	if err != nil {
 		return fmt.Errorf("update to live: %w", err)
 	}
 
-	liveCount, _ := liveResult.RowsAffected()
-	if liveCount > 0 {
-		fmt.Printf("[StatusUpdater] marked %d event(s) as LIVE\n", liveCount)
+	if len(wentLive) > 0 {
+		fmt.Printf("[StatusUpdater] marked %d event(s) as LIVE\n", len(wentLive))
+	}
+
+	// Update upcoming -> postponed (vendor stopped listing the event before it started)
+	postponedQuery := `
+		UPDATE events
+		SET event_status = 'postponed'
+		WHERE event_status = 'upcoming'
+		  AND commence_time < NOW() - INTERVAL '5 minutes'
+		  AND last_seen_at < commence_time - INTERVAL '1 hour'
+		RETURNING event_id, sport_key, home_team, away_team, commence_time
+	`
+
+	postponed, err := s.transition(ctx, "upcoming", "postponed", postponedQuery)
+	if err != nil {
+		return fmt.Errorf("update to postponed: %w", err)
+	}
+
+	if len(postponed) > 0 {
+		fmt.Printf("[StatusUpdater] marked %d event(s) as POSTPONED\n", len(postponed))
 	}
 
-	// First, fetch events that are about to be marked as completed
-	// We need their details for closing game pages
-	eventsToComplete, err := s.fetchEventsToComplete(ctx)
+	// Update postponed -> cancelled (still unlisted long after the original start; a
+	// relisted event goes back to upcoming in the writer's upsert instead)
+	cancelledQuery := `
+		UPDATE events
+		SET event_status = 'cancelled'
+		WHERE event_status = 'postponed'
+		  AND commence_time < NOW() - make_interval(secs => $1)
+		  AND last_seen_at < commence_time
+		RETURNING event_id, sport_key, home_team, away_team, commence_time
+	`
+
+	cancelled, err := s.transition(ctx, "postponed", "cancelled", cancelledQuery, cancelAfter.Seconds())
 	if err != nil {
-		fmt.Printf("[StatusUpdater] fetch events to complete warning: %v\n", err)
-		// Continue with update even if fetch fails
+		return fmt.Errorf("update to cancelled: %w", err)
 	}
 
-	// Update live -> completed (games that started >3 hours ago)
-	// NBA games typically last 2-2.5 hours, so 3 hours is a safe buffer
+	if len(cancelled) > 0 {
+		fmt.Printf("[StatusUpdater] marked %d event(s) as CANCELLED\n", len(cancelled))
+	}
+
+	// Update live -> completed (games older than their sport's typical duration, unless
+	// the vendor still reports them in progress)
+	// RETURNING gives subscribers (e.g. Talos page closing) the exact rows that changed
 	completedQuery := `
 		UPDATE events
 		SET event_status = 'completed'
-		WHERE event_status = 'live'
-		  AND commence_time < NOW() - INTERVAL '3 hours'
+		WHERE ` + completedCondition + `
+		RETURNING event_id, sport_key, home_team, away_team, commence_time
 	`
 
-	completedResult, err := s.db.ExecContext(ctx, completedQuery)
+	sportKeys, durations := s.gameDurations()
+	completed, err := s.transition(ctx, "live", "completed", completedQuery,
+		pq.Array(sportKeys), pq.Array(durations), defaultGameDuration.Seconds(), pq.Array(s.inProgressIDs()))
 	if err != nil {
 		return fmt.Errorf("update to completed: %w", err)
 	}
 
-	completedCount, _ := completedResult.RowsAffected()
-	if completedCount > 0 {
-		fmt.Printf("[StatusUpdater] marked %d event(s) as COMPLETED\n", completedCount)
+	if len(completed) > 0 {
+		fmt.Printf("[StatusUpdater] marked %d event(s) as COMPLETED\n", len(completed))
+	}
+
+	return nil
+}
+
+// applyScores fetches vendor game state for sports with games in progress and moves
+// their events to live or completed. Completed games pass through live first so
+// live subscribers (closing line capture) see every game
+func (s *StatusUpdater) applyScores(ctx context.Context) error {
+	s.lastScores = s.clock.Now()
+
+	sportKeys, err := s.sportsInProgress(ctx)
+	if err != nil {
+		return err
+	}
+
+	var started, completed, inProgress []string
+	for _, sportKey := range sportKeys {
+		scores, err := s.scores.FetchScores(ctx, sportKey, scoresDaysFrom)
+		if err != nil {
+			fmt.Printf("[StatusUpdater] %s scores: %v\n", sportKey, err)
+			continue
+		}
+
+		for _, score := range scores {
+			switch score.Status(s.clock.Now()) {
+			case models.EventStatusCompleted:
+				started = append(started, score.EventID)
+				completed = append(completed, score.EventID)
+			case models.EventStatusLive:
+				if score.HasScores {
+					started = append(started, score.EventID)
+					inProgress = append(inProgress, score.EventID)
+				}
+			}
+		}
+	}
+	s.inProgress = inProgress
+
+	wentLive, err := s.transition(ctx, "upcoming", "live", `
+		UPDATE events
+		SET event_status = 'live'
+		WHERE event_status = 'upcoming' AND event_id = ANY($1::text[])
+		RETURNING event_id, sport_key, home_team, away_team, commence_time
+	`, pq.Array(started))
+	if err != nil {
+		return fmt.Errorf("apply vendor live: %w", err)
+	}
 
-		// Close game pages for completed events
-		s.closeGamePages(ctx, eventsToComplete)
+	finished, err := s.transition(ctx, "live", "completed", `
+		UPDATE events
+		SET event_status = 'completed'
+		WHERE event_status = 'live' AND event_id = ANY($1::text[])
+		RETURNING event_id, sport_key, home_team, away_team, commence_time
+	`, pq.Array(completed))
+	if err != nil {
+		return fmt.Errorf("apply vendor completed: %w", err)
 	}
 
+	if len(wentLive) > 0 || len(finished) > 0 {
+		fmt.Printf("[StatusUpdater] vendor scores: %d event(s) LIVE, %d COMPLETED\n", len(wentLive), len(finished))
+	}
 	return nil
 }
 
-// fetchEventsToComplete fetches event details for events about to be marked completed
-func (s *StatusUpdater) fetchEventsToComplete(ctx context.Context) ([]completedEvent, error) {
-	query := `
-		SELECT event_id, sport_key, home_team, away_team, commence_time
+// sportsInProgress returns the sports with live games or games past their start
+func (s *StatusUpdater) sportsInProgress(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT sport_key
 		FROM events
 		WHERE event_status = 'live'
-		  AND commence_time < NOW() - INTERVAL '3 hours'
-	`
+		   OR (event_status = 'upcoming' AND commence_time <= NOW())
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query sports in progress: %w", err)
+	}
+	defer rows.Close()
+
+	var sportKeys []string
+	for rows.Next() {
+		var sportKey string
+		if err := rows.Scan(&sportKey); err != nil {
+			return nil, fmt.Errorf("scan sport: %w", err)
+		}
+		sportKeys = append(sportKeys, sportKey)
+	}
+	return sportKeys, rows.Err()
+}
+
+// inProgressIDs returns the in-progress event IDs as a non-nil array parameter
+func (s *StatusUpdater) inProgressIDs() []string {
+	if s.inProgress == nil {
+		return []string{}
+	}
+	return s.inProgress
+}
 
-	rows, err := s.db.QueryContext(ctx, query)
+// transition runs a status UPDATE ... RETURNING query and publishes a status change per row
+func (s *StatusUpdater) transition(ctx context.Context, oldStatus, newStatus, query string, args ...interface{}) ([]bus.EventStatusChanged, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("query events to complete: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
-	var events []completedEvent
+	now := s.clock.Now()
+
+	var changes []bus.EventStatusChanged
 	for rows.Next() {
-		var evt completedEvent
-		if err := rows.Scan(&evt.EventID, &evt.SportKey, &evt.HomeTeam, &evt.AwayTeam, &evt.CommenceTime); err != nil {
+		change := bus.EventStatusChanged{
+			OldStatus: oldStatus,
+			NewStatus: newStatus,
+			ChangedAt: now,
+		}
+		if err := rows.Scan(&change.EventID, &change.SportKey, &change.HomeTeam, &change.AwayTeam, &change.CommenceTime); err != nil {
 			fmt.Printf("[StatusUpdater] scan warning: %v\n", err)
 			continue
 		}
-		events = append(events, evt)
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return changes, fmt.Errorf("rows error: %w", err)
+	}
+
+	if s.eventBus != nil {
+		for _, change := range changes {
+			s.eventBus.PublishEventStatusChanged(change)
+		}
 	}
+	s.runHooks(ctx, changes)
 
-	return events, nil
+	return changes, nil
 }
 
-// closeGamePages sends CloseGamePage requests to Talos for completed events
-func (s *StatusUpdater) closeGamePages(ctx context.Context, events []completedEvent) {
-	if s.talos == nil || !s.talos.IsEnabled() {
+// runHooks calls the sport module's hook for each change to live or completed
+func (s *StatusUpdater) runHooks(ctx context.Context, changes []bus.EventStatusChanged) {
+	if s.sports == nil {
 		return
 	}
 
-	for _, evt := range events {
-		// Send close request (async - don't block)
-		go func(e completedEvent) {
-			closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
+	for _, change := range changes {
+		sport, ok := s.sports.Get(change.SportKey)
+		if !ok {
+			continue
+		}
 
-			if err := s.talos.CloseGamePageForEvent(closeCtx, e.HomeTeam, e.AwayTeam, e.SportKey, e.CommenceTime); err != nil {
-				fmt.Printf("[StatusUpdater] Page close failed for %s @ %s: %v\n", e.AwayTeam, e.HomeTeam, err)
-			} else {
-				fmt.Printf("[StatusUpdater] Closed pages for %s @ %s\n", e.AwayTeam, e.HomeTeam)
-			}
-		}(evt)
+		event := models.Event{
+			EventID:      change.EventID,
+			SportKey:     change.SportKey,
+			HomeTeam:     change.HomeTeam,
+			AwayTeam:     change.AwayTeam,
+			CommenceTime: change.CommenceTime,
+			EventStatus:  change.NewStatus,
+		}
+		switch change.NewStatus {
+		case models.EventStatusLive:
+			sporthooks.EventLive(ctx, sport, event)
+		case models.EventStatusCompleted:
+			sporthooks.EventCompleted(ctx, sport, event)
+		}
 	}
 }
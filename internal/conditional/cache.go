@@ -0,0 +1,107 @@
+// Package conditional remembers the cache validators (ETag, Last-Modified) of vendor
+// responses whose odds made it through the pipeline, so the adapter can send the next
+// poll of the same request conditionally. A vendor that supports it answers 304 Not
+// Modified on quiet polls, and the adapter skips reading and parsing the body. A vendor
+// that sends no validators gets plain requests, as before.
+package conditional
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// DefaultMaxAge bounds how long validators are reused before a request is sent
+// unconditionally, in case a cache in front of the vendor answers 304 for a change
+const DefaultMaxAge = 10 * time.Minute
+
+// entry is the last committed validators of one request
+type entry struct {
+	validators  models.Validators
+	committedAt time.Time
+}
+
+// Cache holds committed validators by redacted request. Validators are committed only
+// after the response they came with was processed, so a failed write is fetched in
+// full on the next poll
+type Cache struct {
+	maxAge time.Duration
+
+	mu        sync.RWMutex
+	entries   map[string]entry
+	lastPrune time.Time
+
+	sent        atomic.Int64
+	notModified atomic.Int64
+}
+
+// NewCache creates a cache (maxAge <= 0 uses DefaultMaxAge)
+func NewCache(maxAge time.Duration) *Cache {
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	return &Cache{
+		maxAge:  maxAge,
+		entries: make(map[string]entry),
+	}
+}
+
+// Get returns the validators to send with a request, if committed within the max age
+func (c *Cache) Get(request string, now time.Time) (models.Validators, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[request]
+	c.mu.RUnlock()
+
+	if !ok || now.Sub(e.committedAt) >= c.maxAge {
+		return models.Validators{}, false
+	}
+	c.sent.Add(1)
+	return e.validators, true
+}
+
+// NotModified counts a 304 answer to a conditional request
+func (c *Cache) NotModified() {
+	c.notModified.Add(1)
+}
+
+// Commit records the validators of responses that were processed
+func (c *Cache) Commit(validators map[string]models.Validators, now time.Time) {
+	if len(validators) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for request, v := range validators {
+		if v.Empty() {
+			delete(c.entries, request)
+			continue
+		}
+		c.entries[request] = entry{validators: v, committedAt: now}
+	}
+
+	// Entries past the max age are not sent anyway; drop them (finished events)
+	if now.Sub(c.lastPrune) >= c.maxAge {
+		for request, e := range c.entries {
+			if now.Sub(e.committedAt) >= c.maxAge {
+				delete(c.entries, request)
+			}
+		}
+		c.lastPrune = now
+	}
+}
+
+// Len returns the number of tracked requests
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Stats returns how many conditional requests were sent and answered 304 since startup
+func (c *Cache) Stats() (sent, notModified int64) {
+	return c.sent.Load(), c.notModified.Load()
+}
@@ -0,0 +1,255 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const defaultPostgresPort = "5432"
+
+// PostgresConfig describes a Postgres connection. DSN (URL or key=value form) is the
+// base; every discrete field that is set overrides the matching DSN parameter, so
+// credentials and TLS can come from separate settings without escaping them into a URL
+type PostgresConfig struct {
+	DSN      string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Database string
+
+	SSLMode       string // disable, require, verify-ca or verify-full
+	SSLRootCert   string // CA bundle (PEM) the server certificate must chain to
+	SSLCert       string // Client certificate (PEM), for mutual TLS
+	SSLKey        string // Client key (PEM)
+	SSLServerName string // Name verified with verify-full when it differs from Host (e.g. via a tunnel)
+
+	ApplicationName string
+	ConnectTimeout  time.Duration
+
+	// PasswordFunc, if set, supplies the password for every new connection, so a
+	// rotated password is used without reopening the pool
+	PasswordFunc func() string
+}
+
+// options merges the DSN and the discrete fields into connection parameters
+func (c PostgresConfig) options() (map[string]string, error) {
+	opts := make(map[string]string)
+
+	dsn := strings.TrimSpace(c.DSN)
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		converted, err := pq.ParseURL(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("parse DSN: %w", err)
+		}
+		dsn = converted
+	}
+	if dsn != "" {
+		parsed, err := parseKeywordValues(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("parse DSN: %w", err)
+		}
+		opts = parsed
+	}
+
+	set := func(key, value string) {
+		if value != "" {
+			opts[key] = value
+		}
+	}
+	set("host", c.Host)
+	set("port", c.Port)
+	set("user", c.User)
+	set("password", c.Password)
+	if c.PasswordFunc != nil {
+		set("password", c.PasswordFunc())
+	}
+	set("dbname", c.Database)
+	set("sslmode", c.SSLMode)
+	set("sslrootcert", c.SSLRootCert)
+	set("sslcert", c.SSLCert)
+	set("sslkey", c.SSLKey)
+	set("application_name", c.ApplicationName)
+	if c.ConnectTimeout > 0 {
+		opts["connect_timeout"] = fmt.Sprint(int(c.ConnectTimeout.Round(time.Second) / time.Second))
+	}
+
+	return opts, nil
+}
+
+// BuildDSN returns the connection string in key=value form
+func (c PostgresConfig) BuildDSN() (string, error) {
+	opts, err := c.options()
+	if err != nil {
+		return "", err
+	}
+	return formatKeywordValues(opts), nil
+}
+
+// OpenPostgres opens a connection pool. With SSLServerName set, connections dial
+// the configured host but verify the certificate against the server name
+func OpenPostgres(c PostgresConfig) (*sql.DB, error) {
+	connector, err := c.connector()
+	if err != nil {
+		return nil, err
+	}
+	if c.PasswordFunc == nil {
+		return sql.OpenDB(connector), nil
+	}
+	return sql.OpenDB(&rotatingConnector{config: c}), nil
+}
+
+// connector builds a lib/pq connector from the current settings
+func (c PostgresConfig) connector() (*pq.Connector, error) {
+	opts, err := c.options()
+	if err != nil {
+		return nil, err
+	}
+	if c.SSLServerName == "" {
+		return pq.NewConnector(formatKeywordValues(opts))
+	}
+
+	host, port := opts["host"], opts["port"]
+	if host == "" {
+		host = "localhost"
+	}
+	if port == "" {
+		port = defaultPostgresPort
+	}
+	if strings.HasPrefix(host, "/") {
+		return nil, fmt.Errorf("SSL server name needs a TCP host, not socket %s", host)
+	}
+
+	// lib/pq verifies against (and sends SNI for) the host parameter
+	opts["host"] = c.SSLServerName
+	connector, err := pq.NewConnector(formatKeywordValues(opts))
+	if err != nil {
+		return nil, err
+	}
+	connector.Dialer(&fixedDialer{address: net.JoinHostPort(host, port)})
+	return connector, nil
+}
+
+// rotatingConnector rebuilds the connection settings for every new connection, so
+// each one authenticates with the current password
+type rotatingConnector struct {
+	config PostgresConfig
+}
+
+func (r *rotatingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	connector, err := r.config.connector()
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (r *rotatingConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// fixedDialer dials one address whatever lib/pq asks for
+type fixedDialer struct {
+	address string
+	dialer  net.Dialer
+}
+
+func (d *fixedDialer) Dial(network, _ string) (net.Conn, error) {
+	return d.dialer.Dial(network, d.address)
+}
+
+func (d *fixedDialer) DialTimeout(network, _ string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout(network, d.address, timeout)
+}
+
+func (d *fixedDialer) DialContext(ctx context.Context, network, _ string) (net.Conn, error) {
+	return d.dialer.DialContext(ctx, network, d.address)
+}
+
+// parseKeywordValues parses a libpq key=value connection string; values may be
+// single-quoted with backslash escapes
+func parseKeywordValues(s string) (map[string]string, error) {
+	opts := make(map[string]string)
+	r := []rune(s)
+	i := 0
+
+	skipSpace := func() {
+		for i < len(r) && (r[i] == ' ' || r[i] == '\t' || r[i] == '\n' || r[i] == '\r') {
+			i++
+		}
+	}
+
+	for {
+		skipSpace()
+		if i >= len(r) {
+			return opts, nil
+		}
+
+		start := i
+		for i < len(r) && r[i] != '=' && r[i] != ' ' {
+			i++
+		}
+		key := string(r[start:i])
+		skipSpace()
+		if i >= len(r) || r[i] != '=' || key == "" {
+			return nil, fmt.Errorf("missing value for %q", key)
+		}
+		i++
+		skipSpace()
+
+		var value strings.Builder
+		if i < len(r) && r[i] == '\'' {
+			i++
+			for {
+				if i >= len(r) {
+					return nil, fmt.Errorf("unterminated quoted value for %q", key)
+				}
+				if r[i] == '\'' {
+					i++
+					break
+				}
+				if r[i] == '\\' && i+1 < len(r) {
+					i++
+				}
+				value.WriteRune(r[i])
+				i++
+			}
+		} else {
+			for i < len(r) && r[i] != ' ' {
+				if r[i] == '\\' && i+1 < len(r) {
+					i++
+				}
+				value.WriteRune(r[i])
+				i++
+			}
+		}
+		opts[key] = value.String()
+	}
+}
+
+// formatKeywordValues renders parameters as a key=value string, quoting as needed
+func formatKeywordValues(opts map[string]string) string {
+	keys := make([]string, 0, len(opts))
+	for key := range opts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		value := opts[key]
+		if value == "" || strings.ContainsAny(value, " '\\\t\n") {
+			value = "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+		}
+		parts[i] = key + "=" + value
+	}
+	return strings.Join(parts, " ")
+}
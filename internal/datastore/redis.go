@@ -0,0 +1,75 @@
+package datastore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisConfig describes a Redis connection. Addr is host:port or a redis:// or
+// rediss:// URL; the discrete fields that are set override the URL's
+type RedisConfig struct {
+	Addr     string
+	Username string // ACL user (Redis 6+); empty = default user
+	Password string
+	DB       int
+	TLS      TLSConfig
+
+	// PasswordFunc, if set, supplies the password for every new connection, so a
+	// rotated password is used without recreating the client
+	PasswordFunc func() string
+}
+
+// Options returns the go-redis client options
+func (c RedisConfig) Options() (*redis.Options, error) {
+	var opts *redis.Options
+	if strings.Contains(c.Addr, "://") {
+		parsed, err := redis.ParseURL(c.Addr)
+		if err != nil {
+			return nil, fmt.Errorf("parse redis URL: %w", err)
+		}
+		opts = parsed
+	} else {
+		opts = &redis.Options{Addr: c.Addr}
+	}
+
+	if c.Username != "" {
+		opts.Username = c.Username
+	}
+	if c.Password != "" {
+		opts.Password = c.Password
+	}
+	if c.DB != 0 {
+		opts.DB = c.DB
+	}
+
+	if c.PasswordFunc != nil {
+		username := opts.Username
+		opts.CredentialsProvider = func() (string, string) {
+			return username, c.PasswordFunc()
+		}
+	}
+
+	if c.TLS.Active() {
+		tlsConfig, err := c.TLS.Build()
+		if err != nil {
+			return nil, fmt.Errorf("redis TLS: %w", err)
+		}
+		if tlsConfig.ServerName == "" && opts.TLSConfig != nil {
+			tlsConfig.ServerName = opts.TLSConfig.ServerName // From a rediss:// URL
+		}
+		opts.TLSConfig = tlsConfig
+	}
+
+	return opts, nil
+}
+
+// NewRedisClient creates a Redis client
+func NewRedisClient(c RedisConfig) (*redis.Client, error) {
+	opts, err := c.Options()
+	if err != nil {
+		return nil, err
+	}
+	return redis.NewClient(opts), nil
+}
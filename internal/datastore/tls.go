@@ -0,0 +1,65 @@
+// Package datastore builds Postgres and Redis connections from discrete settings:
+// TLS (CA, client certificate, server name), Redis ACL users and Postgres DSNs
+// assembled from host, user and password fields.
+package datastore
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSConfig describes a TLS client configuration
+type TLSConfig struct {
+	Enabled            bool
+	CAFile             string // PEM bundle trusted in addition to the system roots
+	CertFile           string // Client certificate (PEM), for mutual TLS
+	KeyFile            string // Client key (PEM)
+	ServerName         string // Name verified against the server certificate (default: the dialed host)
+	InsecureSkipVerify bool   // Encrypt without verifying the server (testing only)
+}
+
+// Active reports whether TLS should be used: explicitly enabled or any TLS file or
+// server name set
+func (c TLSConfig) Active() bool {
+	return c.Enabled || c.CAFile != "" || c.CertFile != "" || c.KeyFile != "" || c.ServerName != ""
+}
+
+// Build loads the certificates and returns the crypto/tls configuration
+func (c TLSConfig) Build() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA file %s contains no PEM certificates", c.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return nil, errors.New("client certificate and key must be set together")
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
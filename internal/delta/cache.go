@@ -0,0 +1,133 @@
+package delta
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache is the key/value store the engine compares odds against. Production uses
+// Redis (NewRedisCache); MemoryCache backs unit tests and tools without a server.
+type Cache interface {
+	// MGet returns one value per key, in order: the stored string, or nil when absent
+	MGet(ctx context.Context, keys ...string) ([]interface{}, error)
+
+	// SetMany stores every entry with the same TTL
+	SetMany(ctx context.Context, entries []CacheEntry, ttl time.Duration) error
+}
+
+// CacheEntry is one key/value pair written by SetMany
+type CacheEntry struct {
+	Key   string
+	Value []byte
+}
+
+// RedisCache implements Cache with a single MGET and a pipelined batch of SETs
+type RedisCache struct {
+	client redis.UniversalClient
+}
+
+// NewRedisCache wraps a Redis client (single node, sentinel or cluster)
+func NewRedisCache(client redis.UniversalClient) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+// MGet implements Cache
+func (c *RedisCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// SetMany implements Cache
+func (c *RedisCache) SetMany(ctx context.Context, entries []CacheEntry, ttl time.Duration) error {
+	pipe := c.client.Pipeline()
+	for _, entry := range entries {
+		pipe.Set(ctx, entry.Key, entry.Value, ttl)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// MemoryCache is an in-process Cache with Redis-like TTL semantics (a TTL <= 0
+// never expires). It is safe for concurrent use.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time // Zero = no expiry
+}
+
+// NewMemoryCache creates an empty in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// SetClock replaces the cache's time source, so tests can expire entries without sleeping
+func (c *MemoryCache) SetClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// MGet implements Cache
+func (c *MemoryCache) MGet(_ context.Context, keys ...string) ([]interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		entry, ok := c.entries[key]
+		if !ok {
+			continue
+		}
+		if !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		values[i] = entry.value
+	}
+	return values, nil
+}
+
+// SetMany implements Cache
+func (c *MemoryCache) SetMany(_ context.Context, entries []CacheEntry, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.now().Add(ttl)
+	}
+	for _, entry := range entries {
+		c.entries[entry.Key] = memoryEntry{value: string(entry.Value), expiresAt: expiresAt}
+	}
+	return nil
+}
+
+// Len returns the number of unexpired entries
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	n := 0
+	for _, entry := range c.entries {
+		if entry.expiresAt.IsZero() || now.Before(entry.expiresAt) {
+			n++
+		}
+	}
+	return n
+}
@@ -13,14 +13,20 @@ import (
 // Engine detects changes in odds by comparing against Redis cache
 // This is the Redis-first approach for <1ms delta detection
 type Engine struct {
-	redis *redis.Client
+	cache Cache
 	ttl   time.Duration
+
+	// skipUnchangedTimestamps treats an odd as unchanged when its vendor timestamp
+	// has not advanced past the cached one, skipping the value comparison
+	skipUnchangedTimestamps bool
 }
 
 // CachedOdd represents the minimal data stored in Redis for comparison
 type CachedOdd struct {
 	Price            int       `json:"price"`
+	DecimalPrice     float64   `json:"decimal_price,omitempty"`
 	Point            *float64  `json:"point,omitempty"`
+	Limit            *float64  `json:"limit,omitempty"`
 	VendorLastUpdate time.Time `json:"vendor_last_update"`
 }
 
@@ -32,6 +38,7 @@ const (
 	ChangeTypePriceOnly ChangeType = "price"
 	ChangeTypePointOnly ChangeType = "point"
 	ChangeTypeBoth      ChangeType = "price_and_point"
+	ChangeTypeLimitOnly ChangeType = "limit" // Max bet moved without a price/point move (often precedes one)
 	ChangeTypeNone      ChangeType = "none"
 )
 
@@ -41,16 +48,38 @@ type Delta struct {
 	ChangeType ChangeType
 	OldPrice   *int
 	OldPoint   *float64
+	OldLimit   *float64
+}
+
+// Odds returns the changed odd tagged with its change type and previous quote
+func (d Delta) Odds() models.RawOdds {
+	odd := d.Odd
+	odd.ChangeType = string(d.ChangeType)
+	odd.OldPrice = d.OldPrice
+	odd.OldPoint = d.OldPoint
+	return odd
 }
 
-// NewEngine creates a new delta detection engine
+// NewEngine creates a new delta detection engine backed by Redis
 func NewEngine(redisClient *redis.Client, cacheTTL time.Duration) *Engine {
+	return NewEngineWithCache(NewRedisCache(redisClient), cacheTTL)
+}
+
+// NewEngineWithCache creates a delta detection engine backed by any Cache
+// (e.g. NewMemoryCache for unit tests)
+func NewEngineWithCache(cache Cache, cacheTTL time.Duration) *Engine {
 	return &Engine{
-		redis: redisClient,
+		cache: cache,
 		ttl:   cacheTTL,
 	}
 }
 
+// SetSkipUnchangedTimestamps enables short-circuiting comparison when the vendor's
+// last_update has not advanced (trusts the vendor to bump timestamps on every change)
+func (e *Engine) SetSkipUnchangedTimestamps(enabled bool) {
+	e.skipUnchangedTimestamps = enabled
+}
+
 // DetectChanges compares new odds against Redis cache and returns only deltas
 // This is the hot path - must be <1ms per call
 func (e *Engine) DetectChanges(ctx context.Context, newOdds []models.RawOdds) ([]Delta, error) {
@@ -65,8 +94,8 @@ func (e *Engine) DetectChanges(ctx context.Context, newOdds []models.RawOdds) ([
 	}
 
 	// Batch GET from Redis (<1ms for 100s of keys)
-	cachedValues, err := e.redis.MGet(ctx, keys...).Result()
-	if err != nil && err != redis.Nil {
+	cachedValues, err := e.cache.MGet(ctx, keys...)
+	if err != nil {
 		return nil, fmt.Errorf("redis mget: %w", err)
 	}
 
@@ -76,7 +105,7 @@ func (e *Engine) DetectChanges(ctx context.Context, newOdds []models.RawOdds) ([
 	for i, odd := range newOdds {
 		cachedValue := cachedValues[i]
 
-		changeType, oldPrice, oldPoint := e.compareOdd(odd, cachedValue)
+		changeType, oldPrice, oldPoint, oldLimit := e.compareOdd(odd, cachedValue)
 
 		if changeType != ChangeTypeNone {
 			deltas = append(deltas, Delta{
@@ -84,6 +113,7 @@ func (e *Engine) DetectChanges(ctx context.Context, newOdds []models.RawOdds) ([
 				ChangeType: changeType,
 				OldPrice:   oldPrice,
 				OldPoint:   oldPoint,
+				OldLimit:   oldLimit,
 			})
 		}
 	}
@@ -99,13 +129,15 @@ func (e *Engine) UpdateCache(ctx context.Context, odds []models.RawOdds) error {
 	}
 
 	// Build SET commands for pipeline
-	pipe := e.redis.Pipeline()
+	entries := make([]CacheEntry, 0, len(odds))
 
 	for _, odd := range odds {
 		key := e.buildKey(odd)
 		cached := CachedOdd{
 			Price:            odd.Price,
+			DecimalPrice:     odd.DecimalPrice,
 			Point:            odd.Point,
+			Limit:            odd.Limit,
 			VendorLastUpdate: odd.VendorLastUpdate,
 		}
 
@@ -114,12 +146,11 @@ func (e *Engine) UpdateCache(ctx context.Context, odds []models.RawOdds) error {
 			return fmt.Errorf("marshal cached odd: %w", err)
 		}
 
-		pipe.Set(ctx, key, data, e.ttl)
+		entries = append(entries, CacheEntry{Key: key, Value: data})
 	}
 
 	// Execute pipeline
-	_, err := pipe.Exec(ctx)
-	if err != nil {
+	if err := e.cache.SetMany(ctx, entries, e.ttl); err != nil {
 		return fmt.Errorf("redis pipeline exec: %w", err)
 	}
 
@@ -133,42 +164,56 @@ func (e *Engine) RebuildCache(ctx context.Context, currentOdds []models.RawOdds)
 }
 
 // buildKey creates a Redis key for an odd
-// Format: odds:current:{event_id}:{market_key}:{book_key}:{outcome_name}
+// Format: odds:current:{event_id}:{market_key}:{book_key}:{outcome_name}[:{description}]
+// The description (player name) is only appended for props so featured keys are unchanged
 func (e *Engine) buildKey(odd models.RawOdds) string {
-	return fmt.Sprintf("odds:current:%s:%s:%s:%s",
+	key := fmt.Sprintf("odds:current:%s:%s:%s:%s",
 		odd.EventID,
 		odd.MarketKey,
 		odd.BookKey,
 		odd.OutcomeName,
 	)
+	if odd.Description != "" {
+		key += ":" + odd.Description
+	}
+	return key
 }
 
 // compareOdd compares a new odd against its cached value
-func (e *Engine) compareOdd(newOdd models.RawOdds, cachedValue interface{}) (ChangeType, *int, *float64) {
+func (e *Engine) compareOdd(newOdd models.RawOdds, cachedValue interface{}) (ChangeType, *int, *float64, *float64) {
 	// If no cache entry, this is a new outcome
 	if cachedValue == nil {
-		return ChangeTypeNew, nil, nil
+		return ChangeTypeNew, nil, nil, nil
 	}
 
 	// Parse cached value
 	cachedStr, ok := cachedValue.(string)
 	if !ok {
 		// Cache corruption, treat as new
-		return ChangeTypeNew, nil, nil
+		return ChangeTypeNew, nil, nil, nil
 	}
 
 	var cached CachedOdd
 	if err := json.Unmarshal([]byte(cachedStr), &cached); err != nil {
 		// Cache corruption, treat as new
-		return ChangeTypeNew, nil, nil
+		return ChangeTypeNew, nil, nil, nil
+	}
+
+	// Vendor hasn't published anything newer for this market; nothing can have changed
+	if e.skipUnchangedTimestamps && !cached.VendorLastUpdate.IsZero() &&
+		!newOdd.VendorLastUpdate.After(cached.VendorLastUpdate) {
+		return ChangeTypeNone, nil, nil, nil
 	}
 
 	// Compare price and point
-	priceChanged := newOdd.Price != cached.Price
+	// Decimal is compared too so native decimal moves that round to the same American price are kept
+	priceChanged := newOdd.Price != cached.Price ||
+		(newOdd.DecimalPrice > 0 && cached.DecimalPrice > 0 && newOdd.DecimalPrice != cached.DecimalPrice)
 	pointChanged := e.pointChanged(newOdd.Point, cached.Point)
+	limitChanged := e.limitChanged(newOdd.Limit, cached.Limit)
 
-	if !priceChanged && !pointChanged {
-		return ChangeTypeNone, nil, nil
+	if !priceChanged && !pointChanged && !limitChanged {
+		return ChangeTypeNone, nil, nil, nil
 	}
 
 	oldPrice := &cached.Price
@@ -177,16 +222,39 @@ func (e *Engine) compareOdd(newOdd models.RawOdds, cachedValue interface{}) (Cha
 		val := *cached.Point
 		oldPoint = &val
 	}
+	var oldLimit *float64
+	if cached.Limit != nil {
+		val := *cached.Limit
+		oldLimit = &val
+	}
 
 	if priceChanged && pointChanged {
-		return ChangeTypeBoth, oldPrice, oldPoint
+		return ChangeTypeBoth, oldPrice, oldPoint, oldLimit
 	}
 
 	if priceChanged {
-		return ChangeTypePriceOnly, oldPrice, oldPoint
+		return ChangeTypePriceOnly, oldPrice, oldPoint, oldLimit
+	}
+
+	if pointChanged {
+		return ChangeTypePointOnly, oldPrice, oldPoint, oldLimit
+	}
+
+	return ChangeTypeLimitOnly, oldPrice, oldPoint, oldLimit
+}
+
+// limitChanged checks if max bet limits are different
+// Books that do not expose limits (nil on both sides) never report a change
+func (e *Engine) limitChanged(newLimit, oldLimit *float64) bool {
+	if newLimit == nil && oldLimit == nil {
+		return false
+	}
+
+	if newLimit == nil || oldLimit == nil {
+		return true
 	}
 
-	return ChangeTypePointOnly, oldPrice, oldPoint
+	return *newLimit != *oldLimit
 }
 
 // pointChanged checks if point values are different
@@ -0,0 +1,505 @@
+// Package edge detects positive expected-value prices at soft books by comparing
+// them to the no-vig (fair) line of a sharp reference book for the same outcome.
+package edge
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/pricebook"
+	"github.com/XavierBriggs/Mercury/internal/reliability"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamKey is the Redis stream receiving detected edges
+const StreamKey = "edges.detected"
+
+// DefaultSharpBooks are the reference books used when none are configured
+var DefaultSharpBooks = []string{"pinnacle"}
+
+// Edge is a soft-book price with positive expected value against a sharp no-vig line
+type Edge struct {
+	EventID          string    `json:"event_id"`
+	SportKey         string    `json:"sport_key"`
+	MarketKey        string    `json:"market_key"`
+	BookKey          string    `json:"book_key"`
+	OutcomeName      string    `json:"outcome_name"`
+	Description      string    `json:"description,omitempty"`
+	Point            *float64  `json:"point,omitempty"`
+	Price            int       `json:"price"`
+	PriceDecimal     float64   `json:"price_decimal"`
+	SharpBookKey     string    `json:"sharp_book_key"`
+	FairProb         float64   `json:"fair_prob"`
+	FairPriceDecimal float64   `json:"fair_price_decimal"`
+	EdgePct          float64   `json:"edge_pct"`  // Expected value in percent (3.2 = +3.2%)
+	KellyPct         float64   `json:"kelly_pct"` // Full-Kelly stake in percent of bankroll
+	SharpUpdatedAt   time.Time `json:"sharp_updated_at"`
+	SoftUpdatedAt    time.Time `json:"soft_updated_at"`
+	DetectedAt       time.Time `json:"detected_at"`
+
+	// The soft quote's last move (absent when it has not moved since it was first seen)
+	OldPrice        *int     `json:"old_price,omitempty"`
+	OldPoint        *float64 `json:"old_point,omitempty"`
+	PriceDeltaCents *int     `json:"price_delta_cents,omitempty"`
+	PointDelta      *float64 `json:"point_delta,omitempty"`
+}
+
+// Engine maintains the latest prices from the delta path and detects edges
+type Engine struct {
+	db         *sql.DB
+	redis      *redis.Client
+	sharpBooks []string        // Priority order; the first with a complete line is the reference
+	books      *books.Registry // Optional; supplies sharp books when none are configured
+	minEdge    float64         // Minimum expected value (fraction) to report
+
+	// Optional; when set the fair line blends every complete reference book's no-vig
+	// line by reliability weight instead of taking the first
+	weights *reliability.Weights
+
+	mu          sync.Mutex
+	prices      *pricebook.Book
+	lastEmitted map[string]string // soft outcome key -> signature of the last emitted edge
+	sharpSet    map[string]bool
+}
+
+// NewEngine creates an edge detection engine
+// minEdgePct is the minimum expected value in percent (e.g. 1.0 = +1%)
+// With no sharpBooks, the book registry's sharp books are used (see SetBooks),
+// falling back to DefaultSharpBooks
+func NewEngine(db *sql.DB, redisClient *redis.Client, sharpBooks []string, minEdgePct float64) *Engine {
+	e := &Engine{
+		db:          db,
+		redis:       redisClient,
+		minEdge:     minEdgePct / 100,
+		prices:      pricebook.New(),
+		lastEmitted: make(map[string]string),
+	}
+	e.setSharpBooks(sharpBooks)
+	return e
+}
+
+// SetBooks makes the engine consult book classifications: only soft books are
+// evaluated for edges, and when no sharp books were configured the registry's
+// sharp books (highest default weight first) become the reference
+func (e *Engine) SetBooks(registry *books.Registry) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.books = registry
+	if e.sharpSet == nil {
+		e.setSharpBooks(nil)
+	}
+}
+
+// SetWeights blends the fair line from every reference book quoting the full line,
+// each weighted by its reliability, so a poorly scored sharp book moves it less
+func (e *Engine) SetWeights(weights *reliability.Weights) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.weights = weights
+}
+
+// SharpBooks returns the reference books in priority order
+func (e *Engine) SharpBooks() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.referenceBooks()
+}
+
+// setSharpBooks records configured reference books; callers hold e.mu (or own e)
+// An empty list leaves sharpSet nil so references follow the registry
+func (e *Engine) setSharpBooks(sharpBooks []string) {
+	if len(sharpBooks) == 0 {
+		e.sharpBooks = nil
+		e.sharpSet = nil
+		return
+	}
+
+	e.sharpBooks = sharpBooks
+	e.sharpSet = make(map[string]bool, len(sharpBooks))
+	for _, book := range sharpBooks {
+		e.sharpSet[book] = true
+	}
+}
+
+// referenceBooks returns the configured sharp books, else the registry's, else the defaults
+// Registry lookups happen per evaluation so admin reclassifications apply immediately
+func (e *Engine) referenceBooks() []string {
+	if e.sharpSet != nil {
+		return e.sharpBooks
+	}
+	if sharp := e.books.Keys(books.ClassSharp); len(sharp) > 0 {
+		return sharp
+	}
+	return DefaultSharpBooks
+}
+
+// isTarget reports whether a book's quotes are evaluated for edges
+func (e *Engine) isTarget(book string, reference []string) bool {
+	if e.sharpSet != nil {
+		if e.sharpSet[book] {
+			return false
+		}
+	} else {
+		for _, ref := range reference {
+			if ref == book {
+				return false
+			}
+		}
+	}
+	return e.books == nil || e.books.Class(book) == books.ClassSoft
+}
+
+// Load seeds current prices for upcoming and live events from Alexandria
+// Deltas only carry changes, so without this the engine is blind to lines that
+// have not moved since startup
+func (e *Engine) Load(ctx context.Context) error {
+	odds, err := pricebook.LoadCurrent(ctx, e.db)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	for _, odd := range odds {
+		e.prices.Apply(odd)
+	}
+	e.mu.Unlock()
+
+	fmt.Printf("[Edge] loaded %d current prices\n", len(odds))
+	return nil
+}
+
+// HandleDeltaBatchCommitted detects edges on lines touched by a committed batch
+func (e *Engine) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	edges := e.Detect(msg.Odds, timeutil.Now())
+	if len(edges) == 0 {
+		return
+	}
+
+	if err := e.write(ctx, edges); err != nil {
+		fmt.Printf("[Edge] write error: %v\n", err)
+		return
+	}
+
+	if err := e.publish(ctx, edges); err != nil {
+		// Log but don't fail - edges are in Alexandria
+		fmt.Printf("[Edge] publish error: %v\n", err)
+	}
+
+	fmt.Printf("[Edge] %d edge(s) detected\n", len(edges))
+}
+
+// HandleEventStatusChanged drops state for events that can no longer be bet
+func (e *Engine) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	switch msg.NewStatus {
+	case "completed", "cancelled", "postponed":
+		e.Evict(msg.EventID)
+	}
+}
+
+// Evict removes all prices held for an event
+func (e *Engine) Evict(eventID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.prices.Evict(eventID)
+
+	prefix := eventID + "|"
+	for key := range e.lastEmitted {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.lastEmitted, key)
+		}
+	}
+}
+
+// Detect applies odds to the price state and returns new or changed edges on affected lines
+// An edge is reported again only when its soft price or the fair line changes
+func (e *Engine) Detect(odds []models.RawOdds, now time.Time) []Edge {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	affected := make(map[string]bool)
+	for _, odd := range odds {
+		affected[e.prices.Apply(odd)] = true
+	}
+
+	keys := make([]string, 0, len(affected))
+	for key := range affected {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var edges []Edge
+	for _, key := range keys {
+		edges = append(edges, e.evaluate(e.prices.Line(key), now)...)
+	}
+	return edges
+}
+
+// evaluate compares every soft quote on a line to the reference sharp no-vig line
+func (e *Engine) evaluate(l *pricebook.Line, now time.Time) []Edge {
+	// A sharp line is complete when it quotes as many outcomes as any book on the line
+	outcomes := l.Outcomes()
+
+	reference := e.referenceBooks()
+	var candidates []fairLine
+	for _, book := range reference {
+		quotes := l.Quotes[book]
+		if len(quotes) < 2 || len(quotes) != outcomes {
+			continue
+		}
+
+		keys := make([]string, 0, len(quotes))
+		prices := make([]float64, 0, len(quotes))
+		var oldest time.Time
+		for key, odd := range quotes {
+			keys = append(keys, key)
+			prices = append(prices, odd.Decimal())
+			if oldest.IsZero() || odd.ReceivedAt.Before(oldest) {
+				oldest = odd.ReceivedAt
+			}
+		}
+
+		probs := oddsmath.NoVig(prices)
+		if probs == nil {
+			continue
+		}
+
+		line := fairLine{book: book, probs: make(map[string]float64, len(keys)), updatedAt: oldest}
+		for i, key := range keys {
+			line.probs[key] = probs[i]
+		}
+		candidates = append(candidates, line)
+		if e.weights == nil {
+			break // The first complete reference book is the fair line
+		}
+	}
+
+	sharpBook, fair, sharpUpdatedAt := e.blend(candidates)
+	if fair == nil {
+		return nil
+	}
+
+	var edges []Edge
+	for book, quotes := range l.Quotes {
+		if !e.isTarget(book, reference) {
+			continue
+		}
+
+		for key, odd := range quotes {
+			fairProb, ok := fair[key]
+			if !ok {
+				continue // Soft book quotes an outcome/point the sharp book doesn't
+			}
+
+			emitKey := pricebook.LineKey(odd) + "|" + book + "|" + key
+			ev := oddsmath.ExpectedValue(fairProb, odd.Decimal())
+			if ev < e.minEdge {
+				delete(e.lastEmitted, emitKey)
+				continue
+			}
+
+			signature := strconv.Itoa(odd.Price) + "|" + strconv.FormatFloat(fairProb, 'f', 4, 64)
+			if e.lastEmitted[emitKey] == signature {
+				continue
+			}
+			e.lastEmitted[emitKey] = signature
+
+			edges = append(edges, Edge{
+				EventID:          odd.EventID,
+				SportKey:         odd.SportKey,
+				MarketKey:        odd.MarketKey,
+				BookKey:          book,
+				OutcomeName:      odd.OutcomeName,
+				Description:      odd.Description,
+				Point:            odd.Point,
+				Price:            odd.Price,
+				PriceDecimal:     odd.Decimal(),
+				SharpBookKey:     sharpBook,
+				FairProb:         fairProb,
+				FairPriceDecimal: oddsmath.ImpliedToDecimal(fairProb),
+				EdgePct:          math.Round(ev*10000) / 100,
+				KellyPct:         math.Round(oddsmath.Kelly(fairProb, odd.Decimal())*10000) / 100,
+				SharpUpdatedAt:   timeutil.UTC(sharpUpdatedAt),
+				SoftUpdatedAt:    timeutil.UTC(odd.ReceivedAt),
+				DetectedAt:       timeutil.UTC(now),
+				OldPrice:         odd.OldPrice,
+				OldPoint:         odd.OldPoint,
+				PriceDeltaCents:  odd.PriceDeltaCents(),
+				PointDelta:       odd.PointDelta(),
+			})
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool { return edges[i].EdgePct > edges[j].EdgePct })
+	return edges
+}
+
+// fairLine is one reference book's no-vig probabilities by outcome key
+type fairLine struct {
+	book      string
+	probs     map[string]float64
+	updatedAt time.Time // Oldest quote on the line
+}
+
+// blend combines reference lines quoting the same outcomes as the first into one
+// fair line, averaging each outcome's probability by book weight. The reported
+// sharp book is the heaviest contributor; the update time is the oldest used
+func (e *Engine) blend(candidates []fairLine) (string, map[string]float64, time.Time) {
+	if len(candidates) == 0 {
+		return "", nil, time.Time{}
+	}
+	first := candidates[0]
+	if e.weights == nil || len(candidates) == 1 {
+		return first.book, first.probs, first.updatedAt
+	}
+
+	sums := make(map[string]float64, len(first.probs))
+	var total, heaviest float64
+	sharpBook, updatedAt := first.book, time.Time{}
+	for _, line := range candidates {
+		if !sameOutcomes(line.probs, first.probs) {
+			continue // e.g. a different alternate point
+		}
+		weight := e.weights.Of(line.book)
+		if weight <= 0 {
+			continue
+		}
+		for key, prob := range line.probs {
+			sums[key] += weight * prob
+		}
+		total += weight
+		if weight > heaviest {
+			heaviest, sharpBook = weight, line.book
+		}
+		if updatedAt.IsZero() || line.updatedAt.Before(updatedAt) {
+			updatedAt = line.updatedAt
+		}
+	}
+	if total == 0 {
+		return first.book, first.probs, first.updatedAt
+	}
+
+	fair := make(map[string]float64, len(sums))
+	for key, sum := range sums {
+		fair[key] = sum / total
+	}
+	return sharpBook, fair, updatedAt
+}
+
+// sameOutcomes reports whether two lines price the same outcome keys
+func sameOutcomes(a, b map[string]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key := range a {
+		if _, ok := b[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// write stores detected edges in Alexandria
+func (e *Engine) write(ctx context.Context, edges []Edge) error {
+	n := len(edges)
+	eventIDs := make([]string, n)
+	sportKeys := make([]string, n)
+	marketKeys := make([]string, n)
+	bookKeys := make([]string, n)
+	outcomeNames := make([]string, n)
+	descriptions := make([]string, n)
+	points := make([]*float64, n)
+	prices := make([]int, n)
+	decimalPrices := make([]float64, n)
+	sharpBooks := make([]string, n)
+	fairProbs := make([]float64, n)
+	edgePcts := make([]float64, n)
+	kellyPcts := make([]float64, n)
+	sharpUpdates := make([]time.Time, n)
+	softUpdates := make([]time.Time, n)
+	detectedAts := make([]time.Time, n)
+
+	for i, edge := range edges {
+		eventIDs[i] = edge.EventID
+		sportKeys[i] = edge.SportKey
+		marketKeys[i] = edge.MarketKey
+		bookKeys[i] = edge.BookKey
+		outcomeNames[i] = edge.OutcomeName
+		descriptions[i] = edge.Description
+		points[i] = edge.Point
+		prices[i] = edge.Price
+		decimalPrices[i] = edge.PriceDecimal
+		sharpBooks[i] = edge.SharpBookKey
+		fairProbs[i] = edge.FairProb
+		edgePcts[i] = edge.EdgePct
+		kellyPcts[i] = edge.KellyPct
+		sharpUpdates[i] = edge.SharpUpdatedAt
+		softUpdates[i] = edge.SoftUpdatedAt
+		detectedAts[i] = edge.DetectedAt
+	}
+
+	query := `
+		INSERT INTO edges_detected (
+			event_id, sport_key, market_key, book_key, outcome_name, description, point,
+			price, price_decimal, sharp_book_key, fair_prob, edge_pct, kelly_pct,
+			sharp_updated_at, soft_updated_at, detected_at
+		)
+		SELECT * FROM UNNEST(
+			$1::text[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[], $7::decimal[],
+			$8::int[], $9::decimal[], $10::text[], $11::decimal[], $12::decimal[], $13::decimal[],
+			$14::timestamptz[], $15::timestamptz[], $16::timestamptz[]
+		)
+	`
+
+	if _, err := e.db.ExecContext(ctx, query,
+		pq.Array(eventIDs), pq.Array(sportKeys), pq.Array(marketKeys), pq.Array(bookKeys),
+		pq.Array(outcomeNames), pq.Array(descriptions), pq.Array(points),
+		pq.Array(prices), pq.Array(decimalPrices), pq.Array(sharpBooks), pq.Array(fairProbs), pq.Array(edgePcts),
+		pq.Array(kellyPcts),
+		pq.Array(sharpUpdates), pq.Array(softUpdates), pq.Array(detectedAts),
+	); err != nil {
+		return fmt.Errorf("insert edges: %w", err)
+	}
+
+	return nil
+}
+
+// publish appends detected edges to the edges stream
+func (e *Engine) publish(ctx context.Context, edges []Edge) error {
+	pipe := e.redis.Pipeline()
+
+	for _, edge := range edges {
+		msgJSON, err := json.Marshal(edge)
+		if err != nil {
+			return fmt.Errorf("marshal edge: %w", err)
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: StreamKey,
+			Values: map[string]interface{}{
+				"data": msgJSON,
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+
+	return nil
+}
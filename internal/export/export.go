@@ -0,0 +1,435 @@
+// Package export dumps Alexandria tables (odds_raw, closing_lines, events) for a date
+// range into CSV or Parquet files for analysts, with sport filters and column selection.
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/lib/pq"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat validates a format name (case-insensitive)
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatParquet:
+		return FormatParquet, nil
+	default:
+		return "", fmt.Errorf("unknown export format: %q (want csv or parquet)", value)
+	}
+}
+
+// ColumnType is the logical type of an exported column
+type ColumnType int
+
+const (
+	ColumnString ColumnType = iota
+	ColumnInt
+	ColumnFloat
+	ColumnBool
+	ColumnTimestamp
+)
+
+// Column is an exportable table column
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Nullable bool
+}
+
+// Table describes an exportable table
+type Table struct {
+	Name       string
+	TimeColumn string   // Filtered by the export date range
+	Columns    []Column // In export order
+}
+
+// Tables lists the exportable tables
+var Tables = []Table{
+	{
+		Name:       "odds_raw",
+		TimeColumn: "received_at",
+		Columns: []Column{
+			{Name: "id", Type: ColumnInt},
+			{Name: "event_id", Type: ColumnString},
+			{Name: "sport_key", Type: ColumnString},
+			{Name: "market_key", Type: ColumnString},
+			{Name: "book_key", Type: ColumnString},
+			{Name: "outcome_name", Type: ColumnString},
+			{Name: "description", Type: ColumnString},
+			{Name: "price", Type: ColumnInt},
+			{Name: "price_decimal", Type: ColumnFloat, Nullable: true},
+			{Name: "point", Type: ColumnFloat, Nullable: true},
+			{Name: "bet_limit", Type: ColumnFloat, Nullable: true},
+			{Name: "deep_link", Type: ColumnString, Nullable: true},
+			{Name: "side", Type: ColumnString, Nullable: true},
+			{Name: "poll_id", Type: ColumnString, Nullable: true},
+			{Name: "vendor_last_update", Type: ColumnTimestamp},
+			{Name: "received_at", Type: ColumnTimestamp},
+			{Name: "is_latest", Type: ColumnBool},
+		},
+	},
+	{
+		Name:       "closing_lines",
+		TimeColumn: "closed_at",
+		Columns: []Column{
+			{Name: "event_id", Type: ColumnString},
+			{Name: "sport_key", Type: ColumnString},
+			{Name: "market_key", Type: ColumnString},
+			{Name: "book_key", Type: ColumnString},
+			{Name: "outcome_name", Type: ColumnString},
+			{Name: "description", Type: ColumnString},
+			{Name: "closing_price", Type: ColumnInt},
+			{Name: "point", Type: ColumnFloat, Nullable: true},
+			{Name: "closed_at", Type: ColumnTimestamp},
+		},
+	},
+	{
+		Name:       "events",
+		TimeColumn: "commence_time",
+		Columns: []Column{
+			{Name: "event_id", Type: ColumnString},
+			{Name: "sport_key", Type: ColumnString},
+			{Name: "home_team", Type: ColumnString},
+			{Name: "away_team", Type: ColumnString},
+			{Name: "commence_time", Type: ColumnTimestamp},
+			{Name: "event_status", Type: ColumnString},
+			{Name: "discovered_at", Type: ColumnTimestamp},
+			{Name: "last_seen_at", Type: ColumnTimestamp},
+		},
+	},
+}
+
+// LookupTable returns an exportable table by name
+func LookupTable(name string) (Table, bool) {
+	for _, table := range Tables {
+		if table.Name == name {
+			return table, true
+		}
+	}
+	return Table{}, false
+}
+
+// Select returns the table's columns restricted to names (all columns when empty)
+func (t Table) Select(names []string) ([]Column, error) {
+	if len(names) == 0 {
+		return t.Columns, nil
+	}
+
+	selected := make([]Column, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, col := range t.Columns {
+			if col.Name == name {
+				selected = append(selected, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("table %s has no column %q", t.Name, name)
+		}
+	}
+	return selected, nil
+}
+
+// Options selects what to export
+type Options struct {
+	Tables  []string            // Empty = all tables
+	Columns map[string][]string // table -> columns; tables not listed export every column
+	Sports  []string            // Empty = all sports
+	From    time.Time           // Inclusive
+	To      time.Time           // Exclusive
+	Format  Format
+	Dir     string // Output directory (created if missing)
+}
+
+// File is one written export file
+type File struct {
+	Table string
+	Path  string
+	Rows  int
+}
+
+// ParseColumns parses "table.column,table.column" into per-table column lists
+func ParseColumns(value string) (map[string][]string, error) {
+	columns := make(map[string][]string)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		table, column, ok := strings.Cut(item, ".")
+		if !ok || table == "" || column == "" {
+			return nil, fmt.Errorf("invalid column %q (want table.column)", item)
+		}
+		columns[table] = append(columns[table], column)
+	}
+	return columns, nil
+}
+
+// FileName returns the export file name for a table and range
+func FileName(table string, from, to time.Time, format Format) string {
+	return fmt.Sprintf("%s_%s_%s.%s", table,
+		timeutil.UTC(from).Format("20060102T150405Z"), timeutil.UTC(to).Format("20060102T150405Z"), format)
+}
+
+// Export writes one file per table and returns what was written
+func Export(ctx context.Context, db *sql.DB, opts Options) ([]File, error) {
+	if !opts.To.After(opts.From) {
+		return nil, fmt.Errorf("export range is empty: %v to %v", opts.From, opts.To)
+	}
+	if opts.Format == "" {
+		opts.Format = FormatCSV
+	}
+
+	names := opts.Tables
+	if len(names) == 0 {
+		for _, table := range Tables {
+			names = append(names, table.Name)
+		}
+	}
+
+	// Validate everything before writing anything
+	tables := make([]Table, 0, len(names))
+	columns := make([][]Column, 0, len(names))
+	for _, name := range names {
+		table, ok := LookupTable(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown table %q", name)
+		}
+		cols, err := table.Select(opts.Columns[name])
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+		columns = append(columns, cols)
+	}
+	for name := range opts.Columns {
+		if _, ok := LookupTable(name); !ok {
+			return nil, fmt.Errorf("unknown table %q in column selection", name)
+		}
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create export directory: %w", err)
+	}
+
+	files := make([]File, 0, len(tables))
+	for i, table := range tables {
+		path := filepath.Join(opts.Dir, FileName(table.Name, opts.From, opts.To, opts.Format))
+		rows, err := exportTable(ctx, db, table, columns[i], opts, path)
+		if err != nil {
+			return files, fmt.Errorf("export %s: %w", table.Name, err)
+		}
+		files = append(files, File{Table: table.Name, Path: path, Rows: rows})
+	}
+
+	return files, nil
+}
+
+// rowWriter is implemented by the CSV and Parquet writers
+type rowWriter interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// exportTable streams one table's rows into a file (written to a temp file, then renamed)
+func exportTable(ctx context.Context, db *sql.DB, table Table, columns []Column, opts Options, path string) (int, error) {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE %s >= $1 AND %s < $2
+		  AND (cardinality($3::text[]) = 0 OR sport_key = ANY($3))
+		ORDER BY %s
+	`, strings.Join(names, ", "), table.Name, table.TimeColumn, table.TimeColumn, table.TimeColumn)
+
+	rows, err := db.QueryContext(ctx, query, opts.From, opts.To, pq.Array(opts.Sports))
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+	defer rows.Close()
+
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return 0, fmt.Errorf("create file: %w", err)
+	}
+	defer os.Remove(tmp) // No-op after a successful rename
+
+	count, err := writeRows(f, rows, columns, opts.Format)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("close file: %w", closeErr)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, fmt.Errorf("rename file: %w", err)
+	}
+	return count, nil
+}
+
+// writeRows scans every row into the format writer
+func writeRows(w io.Writer, rows *sql.Rows, columns []Column, format Format) (int, error) {
+	var out rowWriter
+	var err error
+	switch format {
+	case FormatParquet:
+		out, err = NewParquetWriter(w, columns)
+	default:
+		out, err = NewCSVWriter(w, columns)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	dest := make([]interface{}, len(columns))
+	for i, col := range columns {
+		dest[i] = scanTarget(col.Type)
+	}
+
+	values := make([]interface{}, len(columns))
+	count := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return count, fmt.Errorf("scan: %w", err)
+		}
+		for i := range dest {
+			values[i] = scannedValue(dest[i])
+		}
+		if err := out.WriteRow(values); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("rows error: %w", err)
+	}
+
+	return count, out.Close()
+}
+
+// scanTarget returns a nullable scan destination for a column type
+func scanTarget(kind ColumnType) interface{} {
+	switch kind {
+	case ColumnInt:
+		return &sql.NullInt64{}
+	case ColumnFloat:
+		return &sql.NullFloat64{}
+	case ColumnBool:
+		return &sql.NullBool{}
+	case ColumnTimestamp:
+		return &sql.NullTime{}
+	default:
+		return &sql.NullString{}
+	}
+}
+
+// scannedValue unwraps a scan destination (nil for NULL)
+func scannedValue(dest interface{}) interface{} {
+	switch v := dest.(type) {
+	case *sql.NullInt64:
+		if v.Valid {
+			return v.Int64
+		}
+	case *sql.NullFloat64:
+		if v.Valid {
+			return v.Float64
+		}
+	case *sql.NullBool:
+		if v.Valid {
+			return v.Bool
+		}
+	case *sql.NullTime:
+		if v.Valid {
+			return timeutil.UTC(v.Time)
+		}
+	case *sql.NullString:
+		if v.Valid {
+			return v.String
+		}
+	}
+	return nil
+}
+
+// CSVWriter writes rows as CSV with a header line; NULL is an empty field
+type CSVWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+// NewCSVWriter writes the header for columns
+func NewCSVWriter(w io.Writer, columns []Column) (*CSVWriter, error) {
+	cw := &CSVWriter{w: csv.NewWriter(w), record: make([]string, len(columns))}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	if err := cw.w.Write(header); err != nil {
+		return nil, fmt.Errorf("write csv header: %w", err)
+	}
+	return cw, nil
+}
+
+// WriteRow writes one record (timestamps as RFC 3339 UTC)
+func (cw *CSVWriter) WriteRow(values []interface{}) error {
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			cw.record[i] = ""
+		case string:
+			cw.record[i] = v
+		case int64:
+			cw.record[i] = strconv.FormatInt(v, 10)
+		case float64:
+			cw.record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			cw.record[i] = strconv.FormatBool(v)
+		case time.Time:
+			cw.record[i] = timeutil.UTC(v).Format(time.RFC3339Nano)
+		default:
+			return fmt.Errorf("unsupported value %T", value)
+		}
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	return nil
+}
+
+// Close flushes buffered output
+func (cw *CSVWriter) Close() error {
+	cw.w.Flush()
+	if err := cw.w.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// Job exports each completed UTC day once, checking on an interval
+// Days whose files already exist in the output directory are skipped, so restarts
+// do not re-export
+type Job struct {
+	db           *sql.DB
+	opts         Options // From/To are set per run
+	pollInterval time.Duration
+	stopChan     chan struct{}
+}
+
+// NewJob creates a scheduled daily export
+func NewJob(db *sql.DB, opts Options, pollInterval time.Duration) *Job {
+	return &Job{
+		db:           db,
+		opts:         opts,
+		pollInterval: pollInterval,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins periodic exports
+func (j *Job) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.pollInterval)
+	defer ticker.Stop()
+
+	fmt.Printf("✓ Scheduled export started (%s to %s)\n", j.opts.Format, j.opts.Dir)
+
+	j.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			j.run(ctx)
+		case <-j.stopChan:
+			fmt.Println("✓ Scheduled export stopped")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the job
+func (j *Job) Stop() {
+	close(j.stopChan)
+}
+
+// run exports yesterday (UTC) unless it has already been exported
+func (j *Job) run(ctx context.Context) {
+	to := timeutil.Now().Truncate(24 * time.Hour)
+	from := to.Add(-24 * time.Hour)
+
+	if j.exported(from, to) {
+		return
+	}
+
+	opts := j.opts
+	opts.From, opts.To = from, to
+
+	files, err := Export(ctx, j.db, opts)
+	if err != nil {
+		fmt.Printf("[Export] %s error: %v\n", from.Format("2006-01-02"), err)
+		return
+	}
+
+	for _, f := range files {
+		fmt.Printf("[Export] %s: %d rows → %s\n", f.Table, f.Rows, f.Path)
+	}
+}
+
+// exported reports whether every table's file for the range already exists
+func (j *Job) exported(from, to time.Time) bool {
+	tables := j.opts.Tables
+	if len(tables) == 0 {
+		for _, table := range Tables {
+			tables = append(tables, table.Name)
+		}
+	}
+
+	for _, table := range tables {
+		if _, err := os.Stat(filepath.Join(j.opts.Dir, FileName(table, from, to, j.opts.Format))); err != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,458 @@
+package export
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Minimal Apache Parquet writer: flat schema, PLAIN encoding, uncompressed, one data
+// page per column chunk. Enough for analyst tools (pandas, DuckDB, Spark) to read
+// exports without a third-party dependency.
+
+const (
+	parquetMagic        = "PAR1"
+	defaultRowGroupRows = 100000
+	parquetCreatedBy    = "mercury export"
+)
+
+// Parquet physical types
+const (
+	parquetBoolean   int32 = 0
+	parquetInt32     int32 = 1
+	parquetInt64     int32 = 2
+	parquetDouble    int32 = 5
+	parquetByteArray int32 = 6
+)
+
+// Parquet converted types, encodings and field repetition
+const (
+	convertedUTF8            int32 = 0
+	convertedTimestampMicros int32 = 10
+
+	encodingPlain int32 = 0
+	encodingRLE   int32 = 3
+
+	repetitionRequired int32 = 0
+	repetitionOptional int32 = 1
+)
+
+// parquetColumn buffers one column of the current row group
+type parquetColumn struct {
+	name     string
+	kind     ColumnType
+	nullable bool
+
+	defLevels []bool // Per row: value present
+	values    []byte // PLAIN-encoded non-null values (booleans are packed at flush)
+	bools     []bool
+	count     int // Non-null values
+}
+
+// parquetChunk records where a flushed column chunk lives
+type parquetChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// parquetRowGroup records a flushed row group
+type parquetRowGroup struct {
+	chunks   []parquetChunk
+	numRows  int64
+	byteSize int64
+}
+
+// ParquetWriter writes rows to a Parquet file
+type ParquetWriter struct {
+	w            io.Writer
+	offset       int64
+	columns      []*parquetColumn
+	rows         int
+	rowGroupRows int
+	rowGroups    []parquetRowGroup
+	totalRows    int64
+	closed       bool
+}
+
+// NewParquetWriter starts a Parquet file with the given columns
+func NewParquetWriter(w io.Writer, columns []Column) (*ParquetWriter, error) {
+	pw := &ParquetWriter{w: w, rowGroupRows: defaultRowGroupRows}
+	for _, col := range columns {
+		pw.columns = append(pw.columns, &parquetColumn{name: col.Name, kind: col.Type, nullable: col.Nullable})
+	}
+
+	if err := pw.write([]byte(parquetMagic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// WriteRow buffers one row; values are nil or match the column type
+// (string, int64, float64, bool, time.Time)
+func (pw *ParquetWriter) WriteRow(values []interface{}) error {
+	if pw.closed {
+		return errParquetClosed
+	}
+	if len(values) != len(pw.columns) {
+		return fmt.Errorf("row has %d values, want %d", len(values), len(pw.columns))
+	}
+
+	for i, value := range values {
+		if err := pw.columns[i].append(value); err != nil {
+			return err
+		}
+	}
+
+	pw.rows++
+	if pw.rows >= pw.rowGroupRows {
+		return pw.flushRowGroup()
+	}
+	return nil
+}
+
+// Close flushes buffered rows and writes the footer
+func (pw *ParquetWriter) Close() error {
+	if pw.closed {
+		return nil
+	}
+	pw.closed = true
+
+	if pw.rows > 0 {
+		if err := pw.flushRowGroup(); err != nil {
+			return err
+		}
+	}
+
+	footer := pw.fileMetaData()
+	if err := pw.write(footer); err != nil {
+		return err
+	}
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	if err := pw.write(length[:]); err != nil {
+		return err
+	}
+	return pw.write([]byte(parquetMagic))
+}
+
+// append buffers one value
+func (c *parquetColumn) append(value interface{}) error {
+	if value == nil {
+		if !c.nullable {
+			return fmt.Errorf("column %s: null in a required column", c.name)
+		}
+		c.defLevels = append(c.defLevels, false)
+		return nil
+	}
+	c.defLevels = append(c.defLevels, true)
+	c.count++
+
+	switch c.kind {
+	case ColumnString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("column %s: want string, got %T", c.name, value)
+		}
+		c.values = binary.LittleEndian.AppendUint32(c.values, uint32(len(s)))
+		c.values = append(c.values, s...)
+	case ColumnInt:
+		n, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("column %s: want int64, got %T", c.name, value)
+		}
+		c.values = binary.LittleEndian.AppendUint64(c.values, uint64(n))
+	case ColumnFloat:
+		f, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("column %s: want float64, got %T", c.name, value)
+		}
+		c.values = binary.LittleEndian.AppendUint64(c.values, math.Float64bits(f))
+	case ColumnBool:
+		b, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("column %s: want bool, got %T", c.name, value)
+		}
+		c.bools = append(c.bools, b)
+	case ColumnTimestamp:
+		t, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("column %s: want time.Time, got %T", c.name, value)
+		}
+		c.values = binary.LittleEndian.AppendUint64(c.values, uint64(t.UnixMicro()))
+	default:
+		return fmt.Errorf("column %s: unknown type %d", c.name, c.kind)
+	}
+	return nil
+}
+
+// physicalType maps a column type to its Parquet physical type
+func (c *parquetColumn) physicalType() int32 {
+	switch c.kind {
+	case ColumnString:
+		return parquetByteArray
+	case ColumnFloat:
+		return parquetDouble
+	case ColumnBool:
+		return parquetBoolean
+	default:
+		return parquetInt64 // ints and timestamps
+	}
+}
+
+// pageData returns the data page body: definition levels (optional columns) then values
+func (c *parquetColumn) pageData() []byte {
+	var data []byte
+
+	if c.nullable {
+		levels := rleBooleans(c.defLevels)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(levels)))
+		data = append(data, levels...)
+	}
+
+	if c.kind == ColumnBool {
+		packed := make([]byte, (len(c.bools)+7)/8)
+		for i, b := range c.bools {
+			if b {
+				packed[i/8] |= 1 << uint(i%8)
+			}
+		}
+		return append(data, packed...)
+	}
+	return append(data, c.values...)
+}
+
+// reset clears buffered values after a flush
+func (c *parquetColumn) reset() {
+	c.defLevels = c.defLevels[:0]
+	c.values = c.values[:0]
+	c.bools = c.bools[:0]
+	c.count = 0
+}
+
+// rleBooleans encodes 0/1 levels with the RLE/bit-packed hybrid (runs only, bit width 1)
+func rleBooleans(levels []bool) []byte {
+	var out []byte
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		out = binary.AppendUvarint(out, uint64(j-i)<<1)
+		if levels[i] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		i = j
+	}
+	return out
+}
+
+// flushRowGroup writes one data page per column and records the row group
+func (pw *ParquetWriter) flushRowGroup() error {
+	group := parquetRowGroup{numRows: int64(pw.rows)}
+
+	for _, col := range pw.columns {
+		data := col.pageData()
+		header := pageHeader(len(data), pw.rows)
+
+		chunk := parquetChunk{
+			offset:    pw.offset,
+			size:      int64(len(header) + len(data)),
+			numValues: int64(pw.rows),
+		}
+		if err := pw.write(header); err != nil {
+			return err
+		}
+		if err := pw.write(data); err != nil {
+			return err
+		}
+
+		group.chunks = append(group.chunks, chunk)
+		group.byteSize += chunk.size
+		col.reset()
+	}
+
+	pw.rowGroups = append(pw.rowGroups, group)
+	pw.totalRows += int64(pw.rows)
+	pw.rows = 0
+	return nil
+}
+
+// write appends bytes to the file, tracking the offset
+func (pw *ParquetWriter) write(data []byte) error {
+	n, err := pw.w.Write(data)
+	pw.offset += int64(n)
+	if err != nil {
+		return fmt.Errorf("write parquet: %w", err)
+	}
+	return nil
+}
+
+// pageHeader encodes a DATA_PAGE header
+func pageHeader(size, numValues int) []byte {
+	var t thriftWriter
+	t.fieldI32(1, 0) // type = DATA_PAGE
+	t.fieldI32(2, int32(size))
+	t.fieldI32(3, int32(size))
+	t.fieldStructBegin(5) // data_page_header
+	t.fieldI32(1, int32(numValues))
+	t.fieldI32(2, encodingPlain)
+	t.fieldI32(3, encodingRLE)
+	t.fieldI32(4, encodingRLE)
+	t.structEnd()
+	t.structEnd()
+	return t.buf
+}
+
+// fileMetaData encodes the footer
+func (pw *ParquetWriter) fileMetaData() []byte {
+	var t thriftWriter
+	t.fieldI32(1, 1) // version
+
+	t.fieldListBegin(2, thriftStruct, len(pw.columns)+1) // schema
+	t.structBegin()
+	t.fieldString(4, "schema")
+	t.fieldI32(5, int32(len(pw.columns)))
+	t.structEnd()
+	for _, col := range pw.columns {
+		t.structBegin()
+		t.fieldI32(1, col.physicalType())
+		if col.nullable {
+			t.fieldI32(3, repetitionOptional)
+		} else {
+			t.fieldI32(3, repetitionRequired)
+		}
+		t.fieldString(4, col.name)
+		switch col.kind {
+		case ColumnString:
+			t.fieldI32(6, convertedUTF8)
+		case ColumnTimestamp:
+			t.fieldI32(6, convertedTimestampMicros)
+		}
+		t.structEnd()
+	}
+
+	t.fieldI64(3, pw.totalRows)
+
+	t.fieldListBegin(4, thriftStruct, len(pw.rowGroups))
+	for _, group := range pw.rowGroups {
+		t.structBegin()
+		t.fieldListBegin(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			col := pw.columns[i]
+			t.structBegin()
+			t.fieldI64(2, chunk.offset) // file_offset
+			t.fieldStructBegin(3)       // meta_data
+			t.fieldI32(1, col.physicalType())
+			t.fieldListBegin(2, thriftI32, 2)
+			t.i32(encodingPlain)
+			t.i32(encodingRLE)
+			t.fieldListBegin(3, thriftBinary, 1)
+			t.string(col.name)
+			t.fieldI32(4, 0) // codec = UNCOMPRESSED
+			t.fieldI64(5, chunk.numValues)
+			t.fieldI64(6, chunk.size)
+			t.fieldI64(7, chunk.size)
+			t.fieldI64(9, chunk.offset) // data_page_offset
+			t.structEnd()
+			t.structEnd()
+		}
+		t.fieldI64(2, group.byteSize)
+		t.fieldI64(3, group.numRows)
+		t.structEnd()
+	}
+
+	t.fieldString(6, parquetCreatedBy)
+	t.structEnd()
+	return t.buf
+}
+
+// Thrift compact protocol types
+const (
+	thriftI32    byte = 5
+	thriftI64    byte = 6
+	thriftBinary byte = 8
+	thriftList   byte = 9
+	thriftStruct byte = 12
+)
+
+// thriftWriter encodes the subset of the Thrift compact protocol Parquet metadata needs
+type thriftWriter struct {
+	buf     []byte
+	lastID  int16
+	idStack []int16
+}
+
+func (t *thriftWriter) fieldHeader(id int16, kind byte) {
+	if delta := id - t.lastID; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|kind)
+	} else {
+		t.buf = append(t.buf, kind)
+		t.buf = binary.AppendVarint(t.buf, int64(id))
+	}
+	t.lastID = id
+}
+
+func (t *thriftWriter) i32(v int32) {
+	t.buf = binary.AppendVarint(t.buf, int64(v))
+}
+
+func (t *thriftWriter) string(s string) {
+	t.buf = binary.AppendUvarint(t.buf, uint64(len(s)))
+	t.buf = append(t.buf, s...)
+}
+
+func (t *thriftWriter) fieldI32(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.i32(v)
+}
+
+func (t *thriftWriter) fieldI64(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.buf = binary.AppendVarint(t.buf, v)
+}
+
+func (t *thriftWriter) fieldString(id int16, s string) {
+	t.fieldHeader(id, thriftBinary)
+	t.string(s)
+}
+
+func (t *thriftWriter) fieldListBegin(id int16, elem byte, size int) {
+	t.fieldHeader(id, thriftList)
+	if size < 15 {
+		t.buf = append(t.buf, byte(size)<<4|elem)
+	} else {
+		t.buf = append(t.buf, 0xF0|elem)
+		t.buf = binary.AppendUvarint(t.buf, uint64(size))
+	}
+}
+
+// fieldStructBegin starts a struct-typed field
+func (t *thriftWriter) fieldStructBegin(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.structBegin()
+}
+
+// structBegin starts a struct (a list element, or after fieldStructBegin's header)
+func (t *thriftWriter) structBegin() {
+	t.idStack = append(t.idStack, t.lastID)
+	t.lastID = 0
+}
+
+// structEnd writes the stop byte and restores the enclosing struct's field ID
+func (t *thriftWriter) structEnd() {
+	t.buf = append(t.buf, 0)
+	if n := len(t.idStack); n > 0 {
+		t.lastID = t.idStack[n-1]
+		t.idStack = t.idStack[:n-1]
+	}
+}
+
+// errParquetClosed is returned when writing after Close
+var errParquetClosed = errors.New("parquet writer closed")
@@ -0,0 +1,161 @@
+package fixtures
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Ensure Adapter implements VendorAdapter
+var _ contracts.VendorAdapter = (*Adapter)(nil)
+
+// Adapter is a VendorAdapter that answers from recorded fixtures instead of the vendor,
+// parsing them with the real adapter's parser so tests see real payload shapes
+type Adapter struct {
+	parser   contracts.PayloadParser
+	fixtures []Fixture
+}
+
+// NewAdapter serves fixtures through parser (e.g. theoddsapi.NewClient(""))
+func NewAdapter(parser contracts.PayloadParser, fixtures []Fixture) *Adapter {
+	return &Adapter{
+		parser:   parser,
+		fixtures: fixtures,
+	}
+}
+
+// FetchOdds returns the sport's recorded featured odds, limited to the requested
+// markets, commence window and event IDs
+func (a *Adapter) FetchOdds(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+	result, err := a.parse(models.PayloadKindOdds, opts.Sport, "")
+	if err != nil {
+		return nil, err
+	}
+	result = filterWindow(result, opts.CommenceTimeFrom, opts.CommenceTimeTo)
+	result = filterEvents(result, opts.EventIDs)
+	return filterMarkets(result, opts.Markets), nil
+}
+
+// FetchEventOdds returns the event's recorded odds, limited to the requested markets
+func (a *Adapter) FetchEventOdds(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error) {
+	result, err := a.parse(models.PayloadKindEventOdds, opts.Sport, opts.EventID)
+	if err != nil {
+		return nil, err
+	}
+	return filterMarkets(result, opts.Markets), nil
+}
+
+// FetchEvents returns the sport's recorded events in the commence window
+func (a *Adapter) FetchEvents(ctx context.Context, opts *models.FetchEventsOptions) ([]models.Event, error) {
+	result, err := a.parse(models.PayloadKindEvents, opts.Sport, "")
+	if err != nil {
+		return nil, err
+	}
+	return filterWindow(result, opts.CommenceTimeFrom, opts.CommenceTimeTo).Events, nil
+}
+
+// SupportsMarket reports whether any fixture quotes the market
+func (a *Adapter) SupportsMarket(market string) bool {
+	for _, f := range a.fixtures {
+		result, err := a.parser.ParsePayload(f.Payload())
+		if err != nil {
+			continue
+		}
+		for _, odd := range result.Odds {
+			if odd.MarketKey == market {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetRateLimits reports no quota usage (fixtures cost nothing)
+func (a *Adapter) GetRateLimits() *models.RateLimits {
+	return &models.RateLimits{}
+}
+
+// parse finds and parses the fixture for a kind/sport/event
+func (a *Adapter) parse(kind models.PayloadKind, sport, eventID string) (*models.FetchResult, error) {
+	for _, f := range a.fixtures {
+		if f.Kind == kind && f.Sport == sport && f.EventID == eventID {
+			return a.parser.ParsePayload(f.Payload())
+		}
+	}
+	if eventID != "" {
+		return nil, fmt.Errorf("no %s fixture for %s event %s", kind, sport, eventID)
+	}
+	return nil, fmt.Errorf("no %s fixture for %s", kind, sport)
+}
+
+// filterWindow drops events (and their odds) commencing outside [from, to], as the vendor would
+func filterWindow(result *models.FetchResult, from, to time.Time) *models.FetchResult {
+	if from.IsZero() && to.IsZero() {
+		return result
+	}
+
+	return keepEvents(result, func(evt models.Event) bool {
+		return models.InCommenceWindow(evt.CommenceTime, from, to)
+	})
+}
+
+// filterEvents drops events (and their odds) not in eventIDs (empty = keep all)
+func filterEvents(result *models.FetchResult, eventIDs []string) *models.FetchResult {
+	if len(eventIDs) == 0 {
+		return result
+	}
+
+	wanted := make(map[string]bool, len(eventIDs))
+	for _, eventID := range eventIDs {
+		wanted[eventID] = true
+	}
+	return keepEvents(result, func(evt models.Event) bool {
+		return wanted[evt.EventID]
+	})
+}
+
+// keepEvents drops events failing keep, along with their odds
+func keepEvents(result *models.FetchResult, keep func(models.Event) bool) *models.FetchResult {
+	kept := make(map[string]bool, len(result.Events))
+	events := result.Events[:0]
+	for _, evt := range result.Events {
+		if keep(evt) {
+			kept[evt.EventID] = true
+			events = append(events, evt)
+		}
+	}
+	result.Events = events
+
+	odds := result.Odds[:0]
+	for _, odd := range result.Odds {
+		if kept[odd.EventID] {
+			odds = append(odds, odd)
+		}
+	}
+	result.Odds = odds
+	return result
+}
+
+// filterMarkets drops odds outside markets (empty = keep all)
+func filterMarkets(result *models.FetchResult, markets []string) *models.FetchResult {
+	if len(markets) == 0 {
+		return result
+	}
+
+	wanted := make(map[string]bool, len(markets))
+	for _, market := range markets {
+		wanted[market] = true
+	}
+
+	odds := result.Odds[:0]
+	for _, odd := range result.Odds {
+		if wanted[odd.MarketKey] {
+			odds = append(odds, odd)
+		}
+	}
+	result.Odds = odds
+	return result
+}
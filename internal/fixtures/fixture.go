@@ -0,0 +1,150 @@
+package fixtures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// FormatVersion is the golden file schema version. Files live under a v<N> directory,
+// so a schema change can land next to the old files instead of rewriting them.
+const FormatVersion = 1
+
+const fileSuffix = ".json"
+
+// Fixture is a recorded vendor response: the body exactly as the vendor sent it
+// (decompressed, secrets redacted) plus what is needed to re-parse it
+type Fixture struct {
+	FormatVersion int                `json:"format_version"`
+	Vendor        string             `json:"vendor"`
+	Kind          models.PayloadKind `json:"kind"`
+	Sport         string             `json:"sport"`
+	EventID       string             `json:"event_id,omitempty"`
+	OddsFormat    models.OddsFormat  `json:"odds_format,omitempty"`
+	Request       string             `json:"request,omitempty"` // Path and query, API key redacted
+	RecordedAt    time.Time          `json:"recorded_at"`
+	Body          json.RawMessage    `json:"body"`
+}
+
+// FromPayload converts a captured payload to a fixture
+// The body must be valid JSON (golden files embed it rather than escaping it)
+func FromPayload(payload models.RawPayload) (Fixture, error) {
+	if !json.Valid(payload.Body) {
+		return Fixture{}, fmt.Errorf("%s body for %s is not valid JSON", payload.Kind, payload.Sport)
+	}
+
+	return Fixture{
+		FormatVersion: FormatVersion,
+		Vendor:        payload.Vendor,
+		Kind:          payload.Kind,
+		Sport:         payload.Sport,
+		EventID:       payload.EventID,
+		OddsFormat:    payload.OddsFormat,
+		Request:       payload.Request,
+		RecordedAt:    timeutil.UTC(payload.ReceivedAt),
+		Body:          append(json.RawMessage(nil), payload.Body...),
+	}, nil
+}
+
+// Payload returns the fixture as a raw payload for contracts.PayloadParser
+// ReceivedAt is the recording time, so parsed odds carry the original timestamps
+func (f Fixture) Payload() models.RawPayload {
+	return models.RawPayload{
+		Vendor:     f.Vendor,
+		Kind:       f.Kind,
+		Sport:      f.Sport,
+		EventID:    f.EventID,
+		OddsFormat: f.OddsFormat,
+		Request:    f.Request,
+		ReceivedAt: f.RecordedAt,
+		Body:       f.Body,
+	}
+}
+
+// Path returns where a fixture is stored under root:
+// <vendor>/v<FormatVersion>/<sport>/<kind>[.<event_id>].json
+func Path(root string, f Fixture) string {
+	name := string(f.Kind)
+	if f.EventID != "" {
+		name += "." + f.EventID
+	}
+	return filepath.Join(root, f.Vendor, fmt.Sprintf("v%d", FormatVersion), f.Sport, name+fileSuffix)
+}
+
+// Save writes a fixture as indented JSON, replacing any previous recording
+func Save(root string, f Fixture) (string, error) {
+	path := Path(root, f)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("create fixture dir: %w", err)
+	}
+
+	// Keep request query strings readable (no \u0026 escapes)
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return "", fmt.Errorf("marshal fixture: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", fmt.Errorf("write fixture: %w", err)
+	}
+	return path, nil
+}
+
+// Load reads one golden file
+func Load(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	var f Fixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Fixture{}, fmt.Errorf("decode %s: %w", path, err)
+	}
+	if f.FormatVersion != FormatVersion {
+		return Fixture{}, fmt.Errorf("%s: format version %d, expected %d", path, f.FormatVersion, FormatVersion)
+	}
+	return f, nil
+}
+
+// LoadAll reads every current-version golden file for a vendor under root, sorted by path
+func LoadAll(root, vendor string) ([]Fixture, error) {
+	dir := filepath.Join(root, vendor, fmt.Sprintf("v%d", FormatVersion))
+
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, fileSuffix) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list fixtures: %w", err)
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]Fixture, 0, len(paths))
+	for _, path := range paths {
+		f, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
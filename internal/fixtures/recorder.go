@@ -0,0 +1,81 @@
+package fixtures
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// redacted replaces secrets found in recorded bodies
+const redacted = "REDACTED"
+
+// Ensure Recorder implements PayloadArchiver
+var _ contracts.PayloadArchiver = (*Recorder)(nil)
+
+// Recorder is a PayloadArchiver that writes every captured response to a golden file.
+// It writes synchronously: record mode is for one-off captures, not live polling.
+type Recorder struct {
+	root    string
+	secrets [][]byte
+	mu      sync.Mutex
+	paths   []string
+	errs    []error
+}
+
+// NewRecorder records fixtures under root. Any secret (e.g. the API key) found in a
+// body is replaced before the file is written; request URLs arrive already redacted.
+func NewRecorder(root string, secrets ...string) *Recorder {
+	r := &Recorder{root: root}
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, []byte(secret))
+		}
+	}
+	return r
+}
+
+// Archive implements contracts.PayloadArchiver
+func (r *Recorder) Archive(payload models.RawPayload) {
+	payload.Body = r.sanitize(payload.Body)
+	payload.Request = string(r.sanitize([]byte(payload.Request)))
+
+	f, err := FromPayload(payload)
+	if err == nil {
+		var path string
+		if path, err = Save(r.root, f); err == nil {
+			r.mu.Lock()
+			r.paths = append(r.paths, path)
+			r.mu.Unlock()
+			return
+		}
+	}
+
+	r.mu.Lock()
+	r.errs = append(r.errs, err)
+	r.mu.Unlock()
+	fmt.Printf("[Fixtures] not recorded: %v\n", err)
+}
+
+// Recorded returns the paths written so far
+func (r *Recorder) Recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.paths...)
+}
+
+// Errors returns the recording failures so far
+func (r *Recorder) Errors() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]error(nil), r.errs...)
+}
+
+func (r *Recorder) sanitize(data []byte) []byte {
+	for _, secret := range r.secrets {
+		data = bytes.ReplaceAll(data, secret, []byte(redacted))
+	}
+	return data
+}
@@ -0,0 +1,107 @@
+// Package freshness measures how late Mercury receives each book's prices: the
+// distribution of received_at − vendor_last_update over committed deltas, per book.
+// Unchanged quotes keep their old vendor_last_update, so only deltas say anything
+// about delivery lag.
+package freshness
+
+import (
+	"math"
+	"time"
+)
+
+// Bounds are the histogram's bucket upper bounds; a last bucket counts anything later
+var Bounds = []time.Duration{
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+}
+
+// Histogram is a bucketed lag distribution
+type Histogram struct {
+	Counts []int64 // len(Bounds)+1; Counts[i] holds lags ≤ Bounds[i], the last one the rest
+	Sum    time.Duration
+}
+
+// NewHistogram creates an empty histogram
+func NewHistogram() *Histogram {
+	return &Histogram{Counts: make([]int64, len(Bounds)+1)}
+}
+
+// Observe adds one lag; negative lags (vendor clock ahead of ours) count as zero
+func (h *Histogram) Observe(lag time.Duration) {
+	if lag < 0 {
+		lag = 0
+	}
+	h.Counts[bucket(lag)]++
+	h.Sum += lag
+}
+
+// Merge adds another histogram's observations
+func (h *Histogram) Merge(other *Histogram) {
+	for i, n := range other.Counts {
+		h.Counts[i] += n
+	}
+	h.Sum += other.Sum
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() int64 {
+	var n int64
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
+
+// Mean returns the average lag (0 when empty)
+func (h *Histogram) Mean() time.Duration {
+	n := h.Count()
+	if n == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(n)
+}
+
+// Quantile returns the upper bound of the bucket holding the q-th quantile (0 < q ≤ 1).
+// over is true when it falls past the last bound, in which case bound is that last bound
+func (h *Histogram) Quantile(q float64) (bound time.Duration, over bool) {
+	n := h.Count()
+	if n == 0 {
+		return 0, false
+	}
+
+	target := int64(math.Ceil(q * float64(n)))
+	if target < 1 {
+		target = 1
+	}
+
+	var seen int64
+	for i, c := range h.Counts {
+		seen += c
+		if seen >= target {
+			if i == len(Bounds) {
+				return Bounds[len(Bounds)-1], true
+			}
+			return Bounds[i], false
+		}
+	}
+	return Bounds[len(Bounds)-1], true
+}
+
+// bucket returns the index of the bucket a lag falls in
+func bucket(lag time.Duration) int {
+	for i, bound := range Bounds {
+		if lag <= bound {
+			return i
+		}
+	}
+	return len(Bounds)
+}
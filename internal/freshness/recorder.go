@@ -0,0 +1,201 @@
+package freshness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "mercury:freshness:" // Hash per UTC day of "<book>|<bucket>" counts and "<book>|sum_ms"
+	keyTTL    = 8 * 24 * time.Hour   // A week of history
+
+	// DefaultFlushInterval is how often observed lags are added to Redis
+	DefaultFlushInterval = 30 * time.Second
+)
+
+// Key returns the Redis hash holding one UTC day's lag histograms
+func Key(day time.Time) string {
+	return keyPrefix + timeutil.UTC(day).Format("2006-01-02")
+}
+
+// BookLag is one book's lag distribution
+type BookLag struct {
+	BookKey   string
+	Histogram *Histogram
+}
+
+// Recorder observes the lag of committed deltas and adds it to the day's
+// histograms in Redis every flush interval
+type Recorder struct {
+	redis    *redis.Client
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*Histogram // book_key -> lags since the last flush
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewRecorder creates a recorder flushing every interval (DefaultFlushInterval when ≤ 0)
+func NewRecorder(redisClient *redis.Client, interval time.Duration) *Recorder {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	return &Recorder{
+		redis:    redisClient,
+		interval: interval,
+		pending:  make(map[string]*Histogram),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// HandleDeltaBatchCommitted observes received_at − vendor_last_update for each delta
+func (r *Recorder) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, odd := range msg.Odds {
+		if odd.VendorLastUpdate.IsZero() || odd.ReceivedAt.IsZero() {
+			continue
+		}
+		h := r.pending[odd.BookKey]
+		if h == nil {
+			h = NewHistogram()
+			r.pending[odd.BookKey] = h
+		}
+		h.Observe(odd.ReceivedAt.Sub(odd.VendorLastUpdate))
+	}
+}
+
+// Flush adds pending observations to today's histograms
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]*Histogram)
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	key := Key(timeutil.Now())
+	pipe := r.redis.TxPipeline()
+	for book, h := range pending {
+		for i, n := range h.Counts {
+			if n > 0 {
+				pipe.HIncrBy(ctx, key, book+"|"+strconv.Itoa(i), n)
+			}
+		}
+		pipe.HIncrBy(ctx, key, book+"|sum_ms", h.Sum.Milliseconds())
+	}
+	pipe.Expire(ctx, key, keyTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record freshness: %w", err)
+	}
+	return nil
+}
+
+// Start begins periodic flushes
+func (r *Recorder) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := r.Flush(ctx); err != nil {
+					fmt.Printf("[Freshness] %v\n", err)
+				}
+			case <-r.stopChan:
+				if err := r.Flush(context.Background()); err != nil {
+					fmt.Printf("[Freshness] %v\n", err)
+				}
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop flushes pending observations and stops the recorder
+func (r *Recorder) Stop() {
+	close(r.stopChan)
+	r.wg.Wait()
+}
+
+// Read merges the lag histograms of the given UTC days, slowest books (by p90) first
+func Read(ctx context.Context, redisClient *redis.Client, days ...time.Time) ([]BookLag, error) {
+	byBook := make(map[string]*Histogram)
+	for _, day := range days {
+		values, err := redisClient.HGetAll(ctx, Key(day)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read freshness: %w", err)
+		}
+		for book, h := range parse(values) {
+			if merged, ok := byBook[book]; ok {
+				merged.Merge(h)
+			} else {
+				byBook[book] = h
+			}
+		}
+	}
+
+	lags := make([]BookLag, 0, len(byBook))
+	for book, h := range byBook {
+		lags = append(lags, BookLag{BookKey: book, Histogram: h})
+	}
+	sort.Slice(lags, func(i, j int) bool {
+		pi, _ := lags[i].Histogram.Quantile(0.9)
+		pj, _ := lags[j].Histogram.Quantile(0.9)
+		if pi != pj {
+			return pi > pj
+		}
+		return lags[i].BookKey < lags[j].BookKey
+	})
+	return lags, nil
+}
+
+// parse converts one day's hash to histograms per book
+func parse(values map[string]string) map[string]*Histogram {
+	byBook := make(map[string]*Histogram)
+	for field, value := range values {
+		book, suffix, ok := strings.Cut(field, "|")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		h := byBook[book]
+		if h == nil {
+			h = NewHistogram()
+			byBook[book] = h
+		}
+		if suffix == "sum_ms" {
+			h.Sum += time.Duration(n) * time.Millisecond
+			continue
+		}
+		if i, err := strconv.Atoi(suffix); err == nil && i >= 0 && i < len(h.Counts) {
+			h.Counts[i] += n
+		}
+	}
+	return byBook
+}
@@ -0,0 +1,357 @@
+// Package futures runs a low-frequency polling track for futures/outright markets.
+// Futures have no single event commence time, so they bypass the event-centric
+// pipeline and are stored in futures_odds and published to futures.raw.<sport>.
+package futures
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/internal/sportlock"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	streamKeyFormat = "futures.raw.%s"              // Redis stream per parent sport
+	cacheKeyFormat  = "futures:current:%s:%s:%s:%s" // futures_key, market_key, book_key, outcome_name
+
+	// cacheTTL outlives several poll intervals so restarts don't rewrite every price
+	cacheTTL = 48 * time.Hour
+)
+
+// StreamMessage represents a futures change published to Redis Stream
+type StreamMessage struct {
+	SportKey         string     `json:"sport_key"`
+	FuturesKey       string     `json:"futures_key"`
+	MarketKey        string     `json:"market_key"`
+	BookKey          string     `json:"book_key"`
+	OutcomeName      string     `json:"outcome_name"`
+	Price            int        `json:"price"`
+	PriceDecimal     float64    `json:"price_decimal"`
+	Point            *float64   `json:"point,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	VendorLastUpdate time.Time  `json:"vendor_last_update"`
+	ReceivedAt       time.Time  `json:"received_at"`
+}
+
+// Poller polls futures/outright markets for every sport that configures futures keys
+type Poller struct {
+	db            *sql.DB
+	redis         *redis.Client
+	adapter       contracts.FuturesAdapter
+	sportRegistry *registry.SportRegistry
+	sportLocks    *sportlock.Manager // Optional: only poll sports this instance holds
+	books         *books.Registry    // Optional: muted books are neither requested nor stored
+	stopChan      chan struct{}
+	wg            sync.WaitGroup
+}
+
+// NewPoller creates a new futures poller
+func NewPoller(db *sql.DB, redisClient *redis.Client, adapter contracts.FuturesAdapter, sportRegistry *registry.SportRegistry) *Poller {
+	return &Poller{
+		db:            db,
+		redis:         redisClient,
+		adapter:       adapter,
+		sportRegistry: sportRegistry,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// SetSportLocks shards futures polling the same way as the main scheduler
+func (p *Poller) SetSportLocks(locks *sportlock.Manager) {
+	p.sportLocks = locks
+}
+
+// SetBooks leaves muted books out of futures requests and results
+func (p *Poller) SetBooks(registry *books.Registry) {
+	p.books = registry
+}
+
+// Start begins futures polling for each sport with futures keys configured
+func (p *Poller) Start(ctx context.Context) {
+	for _, sport := range p.sportRegistry.GetAll() {
+		if len(sport.GetFuturesKeys()) == 0 || sport.GetFuturesPollInterval() <= 0 {
+			continue
+		}
+
+		p.wg.Add(1)
+		go func(sport contracts.SportModule) {
+			defer p.wg.Done()
+			p.pollSport(ctx, sport)
+		}(sport)
+
+		fmt.Printf("✓ Started futures polling for %s (%v every %v)\n",
+			sport.GetDisplayName(), sport.GetFuturesKeys(), sport.GetFuturesPollInterval())
+	}
+}
+
+// Stop gracefully stops all futures polling
+func (p *Poller) Stop() {
+	close(p.stopChan)
+	p.wg.Wait()
+}
+
+// pollSport polls all futures keys for one sport on its interval
+func (p *Poller) pollSport(ctx context.Context, sport contracts.SportModule) {
+	p.pollAll(ctx, sport)
+
+	ticker := time.NewTicker(sport.GetFuturesPollInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.pollAll(ctx, sport)
+		case <-p.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// pollAll polls each futures key for a sport, logging (not failing) per-key errors
+func (p *Poller) pollAll(ctx context.Context, sport contracts.SportModule) {
+	if p.sportLocks != nil && !p.sportLocks.Owns(sport.GetSportKey()) {
+		return
+	}
+
+	for _, futuresKey := range sport.GetFuturesKeys() {
+		if err := p.fetchAndProcess(ctx, &models.FetchFuturesOptions{
+			Sport:      sport.GetSportKey(),
+			FuturesKey: futuresKey,
+			Regions:    sport.GetRegions(),
+			Bookmakers: p.books.Unmuted(sport.GetBookmakers()),
+		}); err != nil {
+			fmt.Printf("[%s] futures poll error (%s): %v\n", sport.GetDisplayName(), futuresKey, err)
+		}
+	}
+}
+
+// fetchAndProcess runs fetch → delta → write → publish → cache for one futures key
+func (p *Poller) fetchAndProcess(ctx context.Context, opts *models.FetchFuturesOptions) error {
+	odds, err := p.adapter.FetchFutures(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("fetch futures: %w", err)
+	}
+
+	if p.books != nil {
+		kept := odds[:0]
+		for _, odd := range odds {
+			if !p.books.Muted(odd.BookKey) {
+				kept = append(kept, odd)
+			}
+		}
+		odds = kept
+	}
+
+	if len(odds) == 0 {
+		return nil
+	}
+
+	changed, err := p.detectChanges(ctx, odds)
+	if err != nil {
+		return fmt.Errorf("detect changes: %w", err)
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if err := p.write(ctx, changed); err != nil {
+		return fmt.Errorf("write futures: %w", err)
+	}
+
+	if err := p.publish(ctx, opts.Sport, changed); err != nil {
+		// Log but don't fail - data is in Alexandria
+		fmt.Printf("[Futures] publish error: %v\n", err)
+	}
+
+	if err := p.updateCache(ctx, changed); err != nil {
+		fmt.Printf("[Futures] update cache error: %v\n", err)
+	}
+
+	fmt.Printf("[Futures] %s: %d prices, %d changed\n", opts.FuturesKey, len(odds), len(changed))
+	return nil
+}
+
+// cacheKey builds the Redis key holding the last written price for a futures outcome
+func cacheKey(odd models.FuturesOdds) string {
+	return fmt.Sprintf(cacheKeyFormat, odd.FuturesKey, odd.MarketKey, odd.BookKey, odd.OutcomeName)
+}
+
+// CacheValue encodes the fields whose change is worth writing: an outcome is written
+// again only when its value differs from the cached one. The decimal price is part of
+// it because decimal quotes finer than a cent round to the same American price
+func CacheValue(odd models.FuturesOdds) string {
+	value := strconv.Itoa(odd.Price) + "|" + strconv.FormatFloat(odd.DecimalPrice, 'f', -1, 64)
+	if odd.Point != nil {
+		value += "|" + strconv.FormatFloat(*odd.Point, 'f', -1, 64)
+	}
+	return value
+}
+
+// detectChanges returns the odds whose price/decimal price/point differ from the cache
+func (p *Poller) detectChanges(ctx context.Context, odds []models.FuturesOdds) ([]models.FuturesOdds, error) {
+	keys := make([]string, len(odds))
+	for i, odd := range odds {
+		keys[i] = cacheKey(odd)
+	}
+
+	cached, err := p.redis.MGet(ctx, keys...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("redis mget: %w", err)
+	}
+
+	changed := make([]models.FuturesOdds, 0, len(odds))
+	for i, odd := range odds {
+		if prev, ok := cached[i].(string); ok && prev == CacheValue(odd) {
+			continue
+		}
+		changed = append(changed, odd)
+	}
+
+	return changed, nil
+}
+
+// write stores changed futures odds, flipping previous rows' is_latest in the same transaction
+func (p *Poller) write(ctx context.Context, odds []models.FuturesOdds) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	n := len(odds)
+	sportKeys := make([]string, n)
+	futuresKeys := make([]string, n)
+	marketKeys := make([]string, n)
+	bookKeys := make([]string, n)
+	outcomeNames := make([]string, n)
+	prices := make([]int, n)
+	decimalPrices := make([]float64, n)
+	points := make([]*float64, n)
+	expiresAts := make([]*time.Time, n)
+	vendorUpdates := make([]time.Time, n)
+	receivedAts := make([]time.Time, n)
+
+	for i, odd := range odds {
+		sportKeys[i] = odd.SportKey
+		futuresKeys[i] = odd.FuturesKey
+		marketKeys[i] = odd.MarketKey
+		bookKeys[i] = odd.BookKey
+		outcomeNames[i] = odd.OutcomeName
+		prices[i] = odd.Price
+		decimalPrices[i] = odd.DecimalPrice
+		points[i] = odd.Point
+		if odd.ExpiresAt != nil {
+			expiresAt := timeutil.UTC(*odd.ExpiresAt)
+			expiresAts[i] = &expiresAt
+		}
+		vendorUpdates[i] = timeutil.UTC(odd.VendorLastUpdate)
+		receivedAts[i] = timeutil.UTC(odd.ReceivedAt)
+	}
+
+	updateQuery := `
+		UPDATE futures_odds
+		SET is_latest = false
+		WHERE is_latest = true
+		  AND (futures_key, market_key, book_key, outcome_name) IN (
+			SELECT UNNEST($1::text[]), UNNEST($2::text[]), UNNEST($3::text[]), UNNEST($4::text[])
+		  )
+	`
+
+	if _, err := tx.ExecContext(ctx, updateQuery,
+		pq.Array(futuresKeys), pq.Array(marketKeys), pq.Array(bookKeys), pq.Array(outcomeNames),
+	); err != nil {
+		return fmt.Errorf("update previous futures: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO futures_odds (
+			sport_key, futures_key, market_key, book_key, outcome_name,
+			price, price_decimal, point, expires_at, vendor_last_update, received_at
+		)
+		SELECT * FROM UNNEST(
+			$1::text[], $2::text[], $3::text[], $4::text[], $5::text[],
+			$6::int[], $7::decimal[], $8::decimal[], $9::timestamptz[], $10::timestamptz[], $11::timestamptz[]
+		)
+	`
+
+	if _, err := tx.ExecContext(ctx, insertQuery,
+		pq.Array(sportKeys), pq.Array(futuresKeys), pq.Array(marketKeys), pq.Array(bookKeys), pq.Array(outcomeNames),
+		pq.Array(prices), pq.Array(decimalPrices), pq.Array(points), pq.Array(expiresAts), pq.Array(vendorUpdates), pq.Array(receivedAts),
+	); err != nil {
+		return fmt.Errorf("insert futures: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// publish appends changed futures to the sport's futures stream
+func (p *Poller) publish(ctx context.Context, sportKey string, odds []models.FuturesOdds) error {
+	streamKey := fmt.Sprintf(streamKeyFormat, sportKey)
+	pipe := p.redis.Pipeline()
+
+	for _, odd := range odds {
+		msg := StreamMessage{
+			SportKey:         odd.SportKey,
+			FuturesKey:       odd.FuturesKey,
+			MarketKey:        odd.MarketKey,
+			BookKey:          odd.BookKey,
+			OutcomeName:      odd.OutcomeName,
+			Price:            odd.Price,
+			PriceDecimal:     odd.DecimalPrice,
+			Point:            odd.Point,
+			ExpiresAt:        odd.ExpiresAt,
+			VendorLastUpdate: timeutil.UTC(odd.VendorLastUpdate),
+			ReceivedAt:       timeutil.UTC(odd.ReceivedAt),
+		}
+
+		msgJSON, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("marshal stream message: %w", err)
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: streamKey,
+			Values: map[string]interface{}{
+				"data": msgJSON,
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+
+	return nil
+}
+
+// updateCache records written prices so unchanged futures are skipped next poll
+func (p *Poller) updateCache(ctx context.Context, odds []models.FuturesOdds) error {
+	pipe := p.redis.Pipeline()
+	for _, odd := range odds {
+		pipe.Set(ctx, cacheKey(odd), CacheValue(odd), cacheTTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bestline"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Message types mirror api/proto/mercury/v1/odds.proto. Timestamps are time.Time here;
+// MarshalProto encodes them as google.protobuf.Timestamp.
+
+// Odds is a single outcome price
+type Odds struct {
+	EventID          string
+	SportKey         string
+	MarketKey        string
+	BookKey          string
+	OutcomeName      string
+	Description      string
+	Price            int
+	PriceDecimal     float64
+	Point            *float64
+	Limit            *float64
+	DeepLink         string
+	VendorLastUpdate time.Time
+	ReceivedAt       time.Time
+}
+
+// Event is a sporting event
+type Event struct {
+	EventID      string
+	SportKey     string
+	HomeTeam     string
+	AwayTeam     string
+	CommenceTime time.Time
+	EventStatus  string
+}
+
+// GetLatestOddsRequest selects current odds for an event
+type GetLatestOddsRequest struct {
+	EventID    string
+	MarketKeys []string // Empty = all markets
+	BookKeys   []string // Empty = all books
+}
+
+// GetLatestOddsResponse carries current odds
+type GetLatestOddsResponse struct {
+	Odds []*Odds
+}
+
+// ListEventsRequest selects events for a sport
+type ListEventsRequest struct {
+	SportKey     string
+	Statuses     []string   // Empty = all statuses
+	CommenceFrom *time.Time // Optional lower bound
+	CommenceTo   *time.Time // Optional upper bound
+	Limit        int        // 0 = server default
+}
+
+// ListEventsResponse carries matching events
+type ListEventsResponse struct {
+	Events []*Event
+}
+
+// StreamDeltasRequest filters a delta subscription; empty filters match everything
+type StreamDeltasRequest struct {
+	SportKeys  []string
+	EventIDs   []string
+	MarketKeys []string
+}
+
+// OddsDelta is a committed odds change pushed to subscribers
+type OddsDelta struct {
+	Odds        *Odds
+	CommittedAt time.Time
+}
+
+// BestLine is the best available price for one outcome across all books
+type BestLine struct {
+	EventID      string
+	SportKey     string
+	MarketKey    string
+	OutcomeName  string
+	Description  string
+	Point        *float64
+	BookKey      string
+	Price        int
+	PriceDecimal float64
+	Books        []string // Every book offering the best price
+	BookCount    int      // Books quoting the outcome
+	ReceivedAt   time.Time
+}
+
+// GetBestLinesRequest selects best prices for an event
+type GetBestLinesRequest struct {
+	EventID    string
+	MarketKeys []string // Empty = all markets
+}
+
+// GetBestLinesResponse carries best prices
+type GetBestLinesResponse struct {
+	Lines []*BestLine
+}
+
+// fromBestPrice converts a cached best price to the API message
+func fromBestPrice(bp bestline.BestPrice) *BestLine {
+	return &BestLine{
+		EventID:      bp.EventID,
+		SportKey:     bp.SportKey,
+		MarketKey:    bp.MarketKey,
+		OutcomeName:  bp.OutcomeName,
+		Description:  bp.Description,
+		Point:        bp.Point,
+		BookKey:      bp.BookKey,
+		Price:        bp.Price,
+		PriceDecimal: bp.PriceDecimal,
+		Books:        bp.Books,
+		BookCount:    bp.BookCount,
+		ReceivedAt:   timeutil.UTC(bp.ReceivedAt),
+	}
+}
+
+// fromRawOdds converts pipeline odds to the API message
+func fromRawOdds(odd models.RawOdds) *Odds {
+	return &Odds{
+		EventID:          odd.EventID,
+		SportKey:         odd.SportKey,
+		MarketKey:        odd.MarketKey,
+		BookKey:          odd.BookKey,
+		OutcomeName:      odd.OutcomeName,
+		Description:      odd.Description,
+		Price:            odd.Price,
+		PriceDecimal:     odd.Decimal(),
+		Point:            odd.Point,
+		Limit:            odd.Limit,
+		DeepLink:         odd.DeepLink,
+		VendorLastUpdate: timeutil.UTC(odd.VendorLastUpdate),
+		ReceivedAt:       timeutil.UTC(odd.ReceivedAt),
+	}
+}
@@ -0,0 +1,522 @@
+package grpcapi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Protobuf wire encoding of the messages in api/proto/mercury/v1/odds.proto. Each
+// message has MarshalProto and UnmarshalProto; unknown fields are skipped, so
+// clients built against a newer schema keep working
+
+// Protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errProtoTruncated = errors.New("protobuf: truncated message")
+
+// protoField is one decoded field: varint and fixed64 values in num, length-delimited
+// ones in data
+type protoField struct {
+	field    int
+	wireType int
+	num      uint64
+	data     []byte
+}
+
+// str returns a length-delimited field as a string
+func (f protoField) str() string {
+	return string(f.data)
+}
+
+// double returns a fixed64 field as a float64
+func (f protoField) double() float64 {
+	return math.Float64frombits(f.num)
+}
+
+// rangeProto calls fn for each field of an encoded message
+func rangeProto(data []byte, fn func(protoField) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errProtoTruncated
+		}
+		data = data[n:]
+		f := protoField{field: int(tag >> 3), wireType: int(tag & 7)}
+
+		switch f.wireType {
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return errProtoTruncated
+			}
+			f.num = v
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return errProtoTruncated
+			}
+			f.num = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errProtoTruncated
+			}
+			f.data = data[n : n+int(length)]
+			data = data[n+int(length):]
+		case wireFixed32:
+			if len(data) < 4 {
+				return errProtoTruncated
+			}
+			data = data[4:]
+			continue
+		default:
+			return fmt.Errorf("protobuf: unsupported wire type %d (field %d)", f.wireType, f.field)
+		}
+
+		if err := fn(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendTag(b []byte, field, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field<<3|wireType))
+}
+
+// appendInt32 encodes an int32 field; negative values take ten bytes, as in proto3
+func appendInt32(b []byte, field, value int) []byte {
+	if value == 0 {
+		return b
+	}
+	b = appendTag(b, field, wireVarint)
+	return binary.AppendUvarint(b, uint64(int64(int32(value))))
+}
+
+func appendString(b []byte, field int, value string) []byte {
+	if value == "" {
+		return b
+	}
+	return appendBytes(b, field, []byte(value))
+}
+
+func appendStrings(b []byte, field int, values []string) []byte {
+	for _, value := range values {
+		b = appendBytes(b, field, []byte(value))
+	}
+	return b
+}
+
+// appendBytes encodes a length-delimited field (strings and embedded messages)
+func appendBytes(b []byte, field int, value []byte) []byte {
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendDouble(b []byte, field int, value float64) []byte {
+	if value == 0 {
+		return b
+	}
+	return appendOptionalDouble(b, field, &value)
+}
+
+// appendOptionalDouble encodes an optional double, keeping zero when it is set
+func appendOptionalDouble(b []byte, field int, value *float64) []byte {
+	if value == nil {
+		return b
+	}
+	b = appendTag(b, field, wireFixed64)
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(*value))
+}
+
+// appendTimestamp encodes a google.protobuf.Timestamp (seconds = 1, nanos = 2)
+func appendTimestamp(b []byte, field int, t time.Time) []byte {
+	if t.IsZero() {
+		return b
+	}
+	var ts []byte
+	if seconds := t.Unix(); seconds != 0 {
+		ts = binary.AppendUvarint(appendTag(ts, 1, wireVarint), uint64(seconds))
+	}
+	if nanos := t.Nanosecond(); nanos != 0 {
+		ts = binary.AppendUvarint(appendTag(ts, 2, wireVarint), uint64(nanos))
+	}
+	return appendBytes(b, field, ts)
+}
+
+// parseTimestamp decodes a google.protobuf.Timestamp as a UTC time
+func parseTimestamp(data []byte) (time.Time, error) {
+	var seconds, nanos int64
+	err := rangeProto(data, func(f protoField) error {
+		switch f.field {
+		case 1:
+			seconds = int64(f.num)
+		case 2:
+			nanos = int64(f.num)
+		}
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, nanos).UTC(), nil
+}
+
+// MarshalProto encodes mercury.v1.Odds
+func (o *Odds) MarshalProto() []byte {
+	b := make([]byte, 0, 192)
+	b = appendString(b, 1, o.EventID)
+	b = appendString(b, 2, o.SportKey)
+	b = appendString(b, 3, o.MarketKey)
+	b = appendString(b, 4, o.BookKey)
+	b = appendString(b, 5, o.OutcomeName)
+	b = appendString(b, 6, o.Description)
+	b = appendInt32(b, 7, o.Price)
+	b = appendDouble(b, 8, o.PriceDecimal)
+	b = appendOptionalDouble(b, 9, o.Point)
+	b = appendOptionalDouble(b, 10, o.Limit)
+	b = appendString(b, 11, o.DeepLink)
+	b = appendTimestamp(b, 12, o.VendorLastUpdate)
+	return appendTimestamp(b, 13, o.ReceivedAt)
+}
+
+// UnmarshalProto decodes mercury.v1.Odds
+func (o *Odds) UnmarshalProto(data []byte) error {
+	*o = Odds{}
+	return rangeProto(data, func(f protoField) error {
+		var err error
+		switch f.field {
+		case 1:
+			o.EventID = f.str()
+		case 2:
+			o.SportKey = f.str()
+		case 3:
+			o.MarketKey = f.str()
+		case 4:
+			o.BookKey = f.str()
+		case 5:
+			o.OutcomeName = f.str()
+		case 6:
+			o.Description = f.str()
+		case 7:
+			o.Price = int(int32(f.num))
+		case 8:
+			o.PriceDecimal = f.double()
+		case 9:
+			point := f.double()
+			o.Point = &point
+		case 10:
+			limit := f.double()
+			o.Limit = &limit
+		case 11:
+			o.DeepLink = f.str()
+		case 12:
+			o.VendorLastUpdate, err = parseTimestamp(f.data)
+		case 13:
+			o.ReceivedAt, err = parseTimestamp(f.data)
+		}
+		return err
+	})
+}
+
+// MarshalProto encodes mercury.v1.Event
+func (e *Event) MarshalProto() []byte {
+	b := make([]byte, 0, 96)
+	b = appendString(b, 1, e.EventID)
+	b = appendString(b, 2, e.SportKey)
+	b = appendString(b, 3, e.HomeTeam)
+	b = appendString(b, 4, e.AwayTeam)
+	b = appendTimestamp(b, 5, e.CommenceTime)
+	return appendString(b, 6, e.EventStatus)
+}
+
+// UnmarshalProto decodes mercury.v1.Event
+func (e *Event) UnmarshalProto(data []byte) error {
+	*e = Event{}
+	return rangeProto(data, func(f protoField) error {
+		var err error
+		switch f.field {
+		case 1:
+			e.EventID = f.str()
+		case 2:
+			e.SportKey = f.str()
+		case 3:
+			e.HomeTeam = f.str()
+		case 4:
+			e.AwayTeam = f.str()
+		case 5:
+			e.CommenceTime, err = parseTimestamp(f.data)
+		case 6:
+			e.EventStatus = f.str()
+		}
+		return err
+	})
+}
+
+// MarshalProto encodes mercury.v1.BestLine
+func (l *BestLine) MarshalProto() []byte {
+	b := make([]byte, 0, 160)
+	b = appendString(b, 1, l.EventID)
+	b = appendString(b, 2, l.SportKey)
+	b = appendString(b, 3, l.MarketKey)
+	b = appendString(b, 4, l.OutcomeName)
+	b = appendString(b, 5, l.Description)
+	b = appendOptionalDouble(b, 6, l.Point)
+	b = appendString(b, 7, l.BookKey)
+	b = appendInt32(b, 8, l.Price)
+	b = appendDouble(b, 9, l.PriceDecimal)
+	b = appendStrings(b, 10, l.Books)
+	b = appendInt32(b, 11, l.BookCount)
+	return appendTimestamp(b, 12, l.ReceivedAt)
+}
+
+// UnmarshalProto decodes mercury.v1.BestLine
+func (l *BestLine) UnmarshalProto(data []byte) error {
+	*l = BestLine{}
+	return rangeProto(data, func(f protoField) error {
+		var err error
+		switch f.field {
+		case 1:
+			l.EventID = f.str()
+		case 2:
+			l.SportKey = f.str()
+		case 3:
+			l.MarketKey = f.str()
+		case 4:
+			l.OutcomeName = f.str()
+		case 5:
+			l.Description = f.str()
+		case 6:
+			point := f.double()
+			l.Point = &point
+		case 7:
+			l.BookKey = f.str()
+		case 8:
+			l.Price = int(int32(f.num))
+		case 9:
+			l.PriceDecimal = f.double()
+		case 10:
+			l.Books = append(l.Books, f.str())
+		case 11:
+			l.BookCount = int(int32(f.num))
+		case 12:
+			l.ReceivedAt, err = parseTimestamp(f.data)
+		}
+		return err
+	})
+}
+
+// MarshalProto encodes mercury.v1.GetLatestOddsRequest
+func (r *GetLatestOddsRequest) MarshalProto() []byte {
+	b := appendString(nil, 1, r.EventID)
+	b = appendStrings(b, 2, r.MarketKeys)
+	return appendStrings(b, 3, r.BookKeys)
+}
+
+// UnmarshalProto decodes mercury.v1.GetLatestOddsRequest
+func (r *GetLatestOddsRequest) UnmarshalProto(data []byte) error {
+	*r = GetLatestOddsRequest{}
+	return rangeProto(data, func(f protoField) error {
+		switch f.field {
+		case 1:
+			r.EventID = f.str()
+		case 2:
+			r.MarketKeys = append(r.MarketKeys, f.str())
+		case 3:
+			r.BookKeys = append(r.BookKeys, f.str())
+		}
+		return nil
+	})
+}
+
+// MarshalProto encodes mercury.v1.GetLatestOddsResponse
+func (r *GetLatestOddsResponse) MarshalProto() []byte {
+	var b []byte
+	for _, odd := range r.Odds {
+		b = appendBytes(b, 1, odd.MarshalProto())
+	}
+	return b
+}
+
+// UnmarshalProto decodes mercury.v1.GetLatestOddsResponse
+func (r *GetLatestOddsResponse) UnmarshalProto(data []byte) error {
+	*r = GetLatestOddsResponse{}
+	return rangeProto(data, func(f protoField) error {
+		if f.field != 1 {
+			return nil
+		}
+		odd := &Odds{}
+		if err := odd.UnmarshalProto(f.data); err != nil {
+			return err
+		}
+		r.Odds = append(r.Odds, odd)
+		return nil
+	})
+}
+
+// MarshalProto encodes mercury.v1.GetBestLinesRequest
+func (r *GetBestLinesRequest) MarshalProto() []byte {
+	b := appendString(nil, 1, r.EventID)
+	return appendStrings(b, 2, r.MarketKeys)
+}
+
+// UnmarshalProto decodes mercury.v1.GetBestLinesRequest
+func (r *GetBestLinesRequest) UnmarshalProto(data []byte) error {
+	*r = GetBestLinesRequest{}
+	return rangeProto(data, func(f protoField) error {
+		switch f.field {
+		case 1:
+			r.EventID = f.str()
+		case 2:
+			r.MarketKeys = append(r.MarketKeys, f.str())
+		}
+		return nil
+	})
+}
+
+// MarshalProto encodes mercury.v1.GetBestLinesResponse
+func (r *GetBestLinesResponse) MarshalProto() []byte {
+	var b []byte
+	for _, line := range r.Lines {
+		b = appendBytes(b, 1, line.MarshalProto())
+	}
+	return b
+}
+
+// UnmarshalProto decodes mercury.v1.GetBestLinesResponse
+func (r *GetBestLinesResponse) UnmarshalProto(data []byte) error {
+	*r = GetBestLinesResponse{}
+	return rangeProto(data, func(f protoField) error {
+		if f.field != 1 {
+			return nil
+		}
+		line := &BestLine{}
+		if err := line.UnmarshalProto(f.data); err != nil {
+			return err
+		}
+		r.Lines = append(r.Lines, line)
+		return nil
+	})
+}
+
+// MarshalProto encodes mercury.v1.ListEventsRequest
+func (r *ListEventsRequest) MarshalProto() []byte {
+	b := appendString(nil, 1, r.SportKey)
+	b = appendStrings(b, 2, r.Statuses)
+	if r.CommenceFrom != nil {
+		b = appendTimestamp(b, 3, *r.CommenceFrom)
+	}
+	if r.CommenceTo != nil {
+		b = appendTimestamp(b, 4, *r.CommenceTo)
+	}
+	return appendInt32(b, 5, r.Limit)
+}
+
+// UnmarshalProto decodes mercury.v1.ListEventsRequest
+func (r *ListEventsRequest) UnmarshalProto(data []byte) error {
+	*r = ListEventsRequest{}
+	return rangeProto(data, func(f protoField) error {
+		switch f.field {
+		case 1:
+			r.SportKey = f.str()
+		case 2:
+			r.Statuses = append(r.Statuses, f.str())
+		case 3, 4:
+			t, err := parseTimestamp(f.data)
+			if err != nil {
+				return err
+			}
+			if f.field == 3 {
+				r.CommenceFrom = &t
+			} else {
+				r.CommenceTo = &t
+			}
+		case 5:
+			r.Limit = int(int32(f.num))
+		}
+		return nil
+	})
+}
+
+// MarshalProto encodes mercury.v1.ListEventsResponse
+func (r *ListEventsResponse) MarshalProto() []byte {
+	var b []byte
+	for _, evt := range r.Events {
+		b = appendBytes(b, 1, evt.MarshalProto())
+	}
+	return b
+}
+
+// UnmarshalProto decodes mercury.v1.ListEventsResponse
+func (r *ListEventsResponse) UnmarshalProto(data []byte) error {
+	*r = ListEventsResponse{}
+	return rangeProto(data, func(f protoField) error {
+		if f.field != 1 {
+			return nil
+		}
+		evt := &Event{}
+		if err := evt.UnmarshalProto(f.data); err != nil {
+			return err
+		}
+		r.Events = append(r.Events, evt)
+		return nil
+	})
+}
+
+// MarshalProto encodes mercury.v1.StreamDeltasRequest
+func (r *StreamDeltasRequest) MarshalProto() []byte {
+	b := appendStrings(nil, 1, r.SportKeys)
+	b = appendStrings(b, 2, r.EventIDs)
+	return appendStrings(b, 3, r.MarketKeys)
+}
+
+// UnmarshalProto decodes mercury.v1.StreamDeltasRequest
+func (r *StreamDeltasRequest) UnmarshalProto(data []byte) error {
+	*r = StreamDeltasRequest{}
+	return rangeProto(data, func(f protoField) error {
+		switch f.field {
+		case 1:
+			r.SportKeys = append(r.SportKeys, f.str())
+		case 2:
+			r.EventIDs = append(r.EventIDs, f.str())
+		case 3:
+			r.MarketKeys = append(r.MarketKeys, f.str())
+		}
+		return nil
+	})
+}
+
+// MarshalProto encodes mercury.v1.OddsDelta
+func (d *OddsDelta) MarshalProto() []byte {
+	var b []byte
+	if d.Odds != nil {
+		b = appendBytes(b, 1, d.Odds.MarshalProto())
+	}
+	return appendTimestamp(b, 2, d.CommittedAt)
+}
+
+// UnmarshalProto decodes mercury.v1.OddsDelta
+func (d *OddsDelta) UnmarshalProto(data []byte) error {
+	*d = OddsDelta{}
+	return rangeProto(data, func(f protoField) error {
+		var err error
+		switch f.field {
+		case 1:
+			d.Odds = &Odds{}
+			err = d.Odds.UnmarshalProto(f.data)
+		case 2:
+			d.CommittedAt, err = parseTimestamp(f.data)
+		}
+		return err
+	})
+}
@@ -0,0 +1,347 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServicePath is the HTTP/2 path prefix of OddsService methods
+const ServicePath = "/mercury.v1.OddsService/"
+
+// maxMessageSize bounds a request message, as gRPC's default receive limit does
+const maxMessageSize = 4 << 20
+
+// gRPC status codes (google.golang.org/grpc/codes)
+const (
+	CodeOK                = 0
+	CodeCanceled          = 1
+	CodeInvalidArgument   = 3
+	CodeDeadlineExceeded  = 4
+	CodeResourceExhausted = 8
+	CodeUnimplemented     = 12
+	CodeInternal          = 13
+	CodeUnavailable       = 14
+)
+
+// protoMessage is a request or response message
+type protoMessage interface {
+	MarshalProto() []byte
+	UnmarshalProto(data []byte) error
+}
+
+// statusError is an error with its gRPC status code
+type statusError struct {
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return e.message
+}
+
+// Server serves a Service as the gRPC mercury.v1.OddsService over HTTP/2 without
+// TLS (prior knowledge), which is what gRPC clients use for plaintext targets.
+// Messages are uncompressed
+type Server struct {
+	addr       string
+	service    *Service
+	httpServer *http.Server
+	listener   net.Listener
+
+	// stopping ends open StreamDeltas calls, which would otherwise hold Shutdown
+	stopping context.Context
+	stop     context.CancelFunc
+
+	wg sync.WaitGroup
+}
+
+// NewServer creates a gRPC server for service listening on addr (e.g. ":9090")
+func NewServer(addr string, service *Service) *Server {
+	s := &Server{addr: addr, service: service}
+	s.stopping, s.stop = context.WithCancel(context.Background())
+
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	s.httpServer = &http.Server{Addr: addr, Handler: s, Protocols: &protocols}
+
+	return s
+}
+
+// Start begins accepting connections
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", s.addr, err)
+	}
+	s.listener = listener
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("[GRPC] server error: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("✓ gRPC OddsService listening on %s\n", listener.Addr())
+	return nil
+}
+
+// Addr returns the listening address once started (useful with port 0)
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return s.addr
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop ends open delta streams and shuts the server down
+func (s *Server) Stop() {
+	s.stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.httpServer.Shutdown(shutdownCtx)
+	s.wg.Wait()
+}
+
+// ServeHTTP dispatches one gRPC call
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "gRPC requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requires content-type application/grpc", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	c := &call{w: w}
+	ctx, cancel, err := callContext(r)
+	if err != nil {
+		c.finish(err)
+		return
+	}
+	defer cancel()
+
+	switch strings.TrimPrefix(r.URL.Path, ServicePath) {
+	case "GetLatestOdds":
+		req := &GetLatestOddsRequest{}
+		c.unary(r.Body, req, func() (protoMessage, error) { return s.service.GetLatestOdds(ctx, req) })
+	case "GetBestLines":
+		req := &GetBestLinesRequest{}
+		c.unary(r.Body, req, func() (protoMessage, error) { return s.service.GetBestLines(ctx, req) })
+	case "ListEvents":
+		req := &ListEventsRequest{}
+		c.unary(r.Body, req, func() (protoMessage, error) { return s.service.ListEvents(ctx, req) })
+	case "StreamDeltas":
+		s.streamDeltas(ctx, c, r.Body)
+	default:
+		c.finish(&statusError{CodeUnimplemented, "unknown method " + r.URL.Path})
+	}
+}
+
+// streamDeltas serves StreamDeltas until the client leaves or the server stops
+func (s *Server) streamDeltas(ctx context.Context, c *call, body io.Reader) {
+	req := &StreamDeltasRequest{}
+	if err := readMessage(body, req); err != nil {
+		c.finish(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	defer context.AfterFunc(s.stopping, cancel)()
+
+	// Send headers now, so the client sees the stream open before the first delta
+	if err := c.start(); err != nil {
+		return
+	}
+	err := s.service.StreamDeltas(req, &deltaStream{ctx: ctx, call: c})
+	if s.stopping.Err() != nil {
+		err = &statusError{CodeUnavailable, "server is shutting down"}
+	}
+	c.finish(err)
+}
+
+// deltaStream sends deltas as response messages of a StreamDeltas call
+type deltaStream struct {
+	ctx  context.Context
+	call *call
+}
+
+func (d *deltaStream) Send(delta *OddsDelta) error {
+	return d.call.send(delta)
+}
+
+func (d *deltaStream) Context() context.Context {
+	return d.ctx
+}
+
+// call writes one gRPC response: headers, length-prefixed messages, then the status
+// in trailers (or in the headers alone when nothing was sent)
+type call struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+// unary reads the request message, runs the method and writes its response
+func (c *call) unary(body io.Reader, req protoMessage, method func() (protoMessage, error)) {
+	if err := readMessage(body, req); err != nil {
+		c.finish(err)
+		return
+	}
+	resp, err := method()
+	if err == nil {
+		err = c.send(resp)
+	}
+	c.finish(err)
+}
+
+// start writes the response headers, declaring the status trailers
+func (c *call) start() error {
+	if c.started {
+		return nil
+	}
+	c.started = true
+	header := c.w.Header()
+	header.Set("Content-Type", "application/grpc")
+	header.Set("Trailer", "Grpc-Status, Grpc-Message")
+	c.w.WriteHeader(http.StatusOK)
+	return http.NewResponseController(c.w).Flush()
+}
+
+// send writes one length-prefixed response message and flushes it
+func (c *call) send(msg protoMessage) error {
+	if err := c.start(); err != nil {
+		return err
+	}
+	data := msg.MarshalProto()
+	frame := make([]byte, 5, 5+len(data))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(data)))
+	if _, err := c.w.Write(append(frame, data...)); err != nil {
+		return err
+	}
+	return http.NewResponseController(c.w).Flush()
+}
+
+// finish writes the call's status
+func (c *call) finish(err error) {
+	code, message := status(err)
+	header := c.w.Header()
+	if !c.started {
+		header.Set("Content-Type", "application/grpc")
+	}
+	header.Set("Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		header.Set("Grpc-Message", encodeGrpcMessage(message))
+	}
+	if !c.started {
+		c.started = true
+		c.w.WriteHeader(http.StatusOK)
+	}
+}
+
+// status maps an error to its gRPC code and message
+func status(err error) (int, string) {
+	var statusErr *statusError
+	switch {
+	case err == nil:
+		return CodeOK, ""
+	case errors.As(err, &statusErr):
+		return statusErr.code, statusErr.message
+	case errors.Is(err, ErrInvalidArgument):
+		return CodeInvalidArgument, err.Error()
+	case errors.Is(err, ErrUnavailable):
+		return CodeUnavailable, err.Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		return CodeDeadlineExceeded, err.Error()
+	case errors.Is(err, context.Canceled):
+		return CodeCanceled, err.Error()
+	default:
+		fmt.Printf("[GRPC] %v\n", err)
+		return CodeInternal, "internal error"
+	}
+}
+
+// readMessage reads the single length-prefixed request message of a call
+func readMessage(body io.Reader, msg protoMessage) error {
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return &statusError{CodeInvalidArgument, "missing request message"}
+	}
+	if prefix[0] != 0 {
+		return &statusError{CodeUnimplemented, "compressed messages are not supported"}
+	}
+	length := binary.BigEndian.Uint32(prefix[1:])
+	if length > maxMessageSize {
+		return &statusError{CodeResourceExhausted, fmt.Sprintf("request message of %d bytes exceeds %d", length, maxMessageSize)}
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(body, data); err != nil {
+		return &statusError{CodeInvalidArgument, "truncated request message"}
+	}
+	if err := msg.UnmarshalProto(data); err != nil {
+		return &statusError{CodeInvalidArgument, "malformed request message: " + err.Error()}
+	}
+	return nil
+}
+
+// callContext applies the client's grpc-timeout header to the request context
+func callContext(r *http.Request) (context.Context, context.CancelFunc, error) {
+	value := r.Header.Get("Grpc-Timeout")
+	if value == "" {
+		ctx, cancel := context.WithCancel(r.Context())
+		return ctx, cancel, nil
+	}
+	timeout, err := parseGrpcTimeout(value)
+	if err != nil {
+		return nil, nil, &statusError{CodeInvalidArgument, err.Error()}
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	return ctx, cancel, nil
+}
+
+// parseGrpcTimeout parses a grpc-timeout value: up to 8 digits and a unit
+// (H, M, S, m, u or n)
+func parseGrpcTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+	n, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+		'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid grpc-timeout unit in %q", value)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// encodeGrpcMessage percent-encodes a status message as the gRPC spec requires
+func encodeGrpcMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
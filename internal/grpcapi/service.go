@@ -0,0 +1,300 @@
+// Package grpcapi serves the Mercury OddsService (api/proto/mercury/v1/odds.proto):
+// point queries against Alexandria and a delta subscription fed from the in-process
+// event bus, so low-latency consumers get pushes without reading Redis streams.
+//
+// Service implements the methods; Server carries them over gRPC's HTTP/2 wire
+// protocol with the standard library, encoding messages with their MarshalProto and
+// UnmarshalProto methods, so any gRPC client generated from odds.proto can call it.
+// Subscribe HandleDeltaBatchCommitted to the bus before it starts, and call
+// SetBestLines when the bestline module is enabled.
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/XavierBriggs/Mercury/internal/bestline"
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultListLimit    = 500
+	maxListLimit        = 5000
+	defaultStreamBuffer = 1024
+)
+
+// ErrInvalidArgument is returned for malformed requests (maps to codes.InvalidArgument)
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// ErrUnavailable is returned when a backing store is not configured (maps to codes.Unavailable)
+var ErrUnavailable = errors.New("unavailable")
+
+// DeltaStream is the server side of a StreamDeltas call
+type DeltaStream interface {
+	Send(*OddsDelta) error
+	Context() context.Context
+}
+
+// subscriber is one open StreamDeltas call
+type subscriber struct {
+	filter  StreamDeltasRequest
+	ch      chan *OddsDelta
+	dropped atomic.Int64
+}
+
+// Service serves odds queries and delta subscriptions
+type Service struct {
+	db           *sql.DB
+	redis        *redis.Client // Best-line cache; nil = GetBestLines unavailable
+	streamBuffer int
+
+	mu   sync.RWMutex
+	subs map[*subscriber]struct{}
+}
+
+// NewService creates a new odds service
+func NewService(db *sql.DB) *Service {
+	return &Service{
+		db:           db,
+		streamBuffer: defaultStreamBuffer,
+		subs:         make(map[*subscriber]struct{}),
+	}
+}
+
+// SetStreamBuffer sets the per-subscriber delta queue size (slow subscribers drop beyond it)
+func (s *Service) SetStreamBuffer(size int) {
+	if size > 0 {
+		s.streamBuffer = size
+	}
+}
+
+// SetBestLines enables GetBestLines, reading the best-line cache from Redis
+func (s *Service) SetBestLines(redisClient *redis.Client) {
+	s.redis = redisClient
+}
+
+// GetBestLines returns the best available price per outcome for an event
+func (s *Service) GetBestLines(ctx context.Context, req *GetBestLinesRequest) (*GetBestLinesResponse, error) {
+	if req == nil || req.EventID == "" {
+		return nil, fmt.Errorf("%w: event_id is required", ErrInvalidArgument)
+	}
+	if s.redis == nil {
+		return nil, fmt.Errorf("%w: best-line cache is not enabled", ErrUnavailable)
+	}
+
+	best, err := bestline.Get(ctx, s.redis, req.EventID, req.MarketKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &GetBestLinesResponse{Lines: make([]*BestLine, 0, len(best))}
+	for _, bp := range best {
+		resp.Lines = append(resp.Lines, fromBestPrice(bp))
+	}
+	return resp, nil
+}
+
+// GetLatestOdds returns the current odds for an event
+func (s *Service) GetLatestOdds(ctx context.Context, req *GetLatestOddsRequest) (*GetLatestOddsResponse, error) {
+	if req == nil || req.EventID == "" {
+		return nil, fmt.Errorf("%w: event_id is required", ErrInvalidArgument)
+	}
+
+	// In-play odds may be in odds_live; an outcome's newest latest row wins
+	latest := func(table string) string {
+		return `SELECT event_id, sport_key, market_key, book_key, outcome_name, description,
+			       price, price_decimal, point, bet_limit, deep_link, vendor_last_update, received_at
+			FROM ` + table + `
+			WHERE event_id = $1
+			  AND is_latest = true
+			  AND (cardinality($2::text[]) = 0 OR market_key = ANY($2))
+			  AND (cardinality($3::text[]) = 0 OR book_key = ANY($3))`
+	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT ON (market_key, book_key, description, outcome_name)
+		       event_id, sport_key, market_key, book_key, outcome_name, description,
+		       price, price_decimal, point, bet_limit, deep_link, vendor_last_update, received_at
+		FROM (`+latest("odds_raw")+`
+			UNION ALL
+			`+latest("odds_live")+`) o
+		ORDER BY market_key, book_key, description, outcome_name, received_at DESC
+	`, req.EventID, pq.Array(req.MarketKeys), pq.Array(req.BookKeys))
+	if err != nil {
+		return nil, fmt.Errorf("query latest odds: %w", err)
+	}
+	defer rows.Close()
+
+	resp := &GetLatestOddsResponse{}
+	for rows.Next() {
+		var odd Odds
+		var priceDecimal, point, limit sql.NullFloat64
+		var deepLink sql.NullString
+		if err := rows.Scan(&odd.EventID, &odd.SportKey, &odd.MarketKey, &odd.BookKey, &odd.OutcomeName,
+			&odd.Description, &odd.Price, &priceDecimal, &point, &limit, &deepLink,
+			&odd.VendorLastUpdate, &odd.ReceivedAt); err != nil {
+			return nil, fmt.Errorf("scan odds: %w", err)
+		}
+
+		odd.PriceDecimal = priceDecimal.Float64
+		if point.Valid {
+			odd.Point = &point.Float64
+		}
+		if limit.Valid {
+			odd.Limit = &limit.Float64
+		}
+		odd.DeepLink = deepLink.String
+		odd.VendorLastUpdate = timeutil.UTC(odd.VendorLastUpdate)
+		odd.ReceivedAt = timeutil.UTC(odd.ReceivedAt)
+
+		resp.Odds = append(resp.Odds, &odd)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListEvents returns events for a sport ordered by commence time
+func (s *Service) ListEvents(ctx context.Context, req *ListEventsRequest) (*ListEventsResponse, error) {
+	if req == nil || req.SportKey == "" {
+		return nil, fmt.Errorf("%w: sport_key is required", ErrInvalidArgument)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT event_id, sport_key, home_team, away_team, commence_time, event_status
+		FROM events
+		WHERE sport_key = $1
+		  AND (cardinality($2::text[]) = 0 OR event_status = ANY($2))
+		  AND ($3::timestamptz IS NULL OR commence_time >= $3)
+		  AND ($4::timestamptz IS NULL OR commence_time < $4)
+		ORDER BY commence_time, event_id
+		LIMIT $5
+	`, req.SportKey, pq.Array(req.Statuses), req.CommenceFrom, req.CommenceTo, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query events: %w", err)
+	}
+	defer rows.Close()
+
+	resp := &ListEventsResponse{}
+	for rows.Next() {
+		var evt Event
+		if err := rows.Scan(&evt.EventID, &evt.SportKey, &evt.HomeTeam, &evt.AwayTeam,
+			&evt.CommenceTime, &evt.EventStatus); err != nil {
+			return nil, fmt.Errorf("scan event: %w", err)
+		}
+		evt.CommenceTime = timeutil.UTC(evt.CommenceTime)
+		resp.Events = append(resp.Events, &evt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return resp, nil
+}
+
+// StreamDeltas pushes committed deltas matching the request until the stream's context ends
+func (s *Service) StreamDeltas(req *StreamDeltasRequest, stream DeltaStream) error {
+	sub := &subscriber{ch: make(chan *OddsDelta, s.streamBuffer)}
+	if req != nil {
+		sub.filter = *req
+	}
+
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.subs, sub)
+		s.mu.Unlock()
+
+		if dropped := sub.dropped.Load(); dropped > 0 {
+			fmt.Printf("[GRPC] delta subscriber closed after dropping %d deltas\n", dropped)
+		}
+	}()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case delta := <-sub.ch:
+			if err := stream.Send(delta); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Subscribers returns the number of open StreamDeltas calls
+func (s *Service) Subscribers() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.subs)
+}
+
+// HandleDeltaBatchCommitted fans committed deltas out to matching subscribers
+// A slow subscriber drops deltas rather than blocking the bus
+func (s *Service) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.subs) == 0 {
+		return
+	}
+
+	committedAt := timeutil.UTC(msg.CommittedAt)
+	for _, odd := range msg.Odds {
+		var delta *OddsDelta
+		for sub := range s.subs {
+			if !sub.filter.matches(odd.SportKey, odd.EventID, odd.MarketKey) {
+				continue
+			}
+			if delta == nil {
+				delta = &OddsDelta{Odds: fromRawOdds(odd), CommittedAt: committedAt}
+			}
+
+			select {
+			case sub.ch <- delta:
+			default:
+				sub.dropped.Add(1)
+			}
+		}
+	}
+}
+
+// matches reports whether a delta passes the subscription filter
+func (r StreamDeltasRequest) matches(sportKey, eventID, marketKey string) bool {
+	return matchAny(r.SportKeys, sportKey) && matchAny(r.EventIDs, eventID) && matchAny(r.MarketKeys, marketKey)
+}
+
+// matchAny reports whether value is in values (an empty filter matches everything)
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
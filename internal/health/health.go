@@ -0,0 +1,585 @@
+// Package health records pipeline health in Redis so out-of-process tools
+// (e.g. `mercury top`) can observe a running Mercury without extra setup.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sportKeyPrefix = "mercury:health:sport:" // Hash per sport with last poll stats and counters
+	quotaKey       = "mercury:health:quota"  // Hash with the latest vendor rate limits
+	lagKey         = "mercury:health:lag"    // Hash of "<stream>|<group>" -> "lag|pending|consumers"
+	apiKeysKey     = "mercury:health:keys"   // Hash of masked API key -> "requests|failures|remaining|used|disabled_until|disabled_kind"
+	usageKeyPrefix = "mercury:health:usage:" // Hash per UTC day of "<sport>|credits" and "<sport>|requests"
+	staleKeyPrefix = "mercury:health:stale:" // Hash per sport of stale "<book>" -> "<last delta>|<next tipoff>"
+
+	// errorKindPrefix prefixes per-kind error counters in a sport's hash
+	errorKindPrefix = "errors:"
+
+	// healthTTL expires health for sports that stopped polling
+	healthTTL = 24 * time.Hour
+)
+
+// Stages lists pipeline stages in the order the scheduler runs them
+var Stages = []string{"fetch", "delta", "write", "cache"}
+
+// PollStats describes one completed poll
+type PollStats struct {
+	Events  int
+	Odds    int
+	Deltas  int
+	Invalid int                      // Events and odds failing the sport module's validation
+	Stages  map[string]time.Duration // stage name -> duration
+	Total   time.Duration
+}
+
+// SportHealth is the recorded health of one sport
+type SportHealth struct {
+	SportKey    string
+	LastPollAt  time.Time
+	LastErrorAt time.Time
+	LastError   string
+	LastErrKind string // pkg/errors label of the last error
+	Polls       int64
+	Errors      int64
+	ErrorKinds  map[string]int64 // Cumulative errors by pkg/errors label
+	Odds        int64            // Cumulative odds fetched
+	Deltas      int64            // Cumulative deltas written
+	Invalid     int64            // Cumulative events and odds failing sport validation
+	Skipped     int64            // Cumulative polls skipped because the previous one was still running
+	LastDeltas  int
+	Stages      map[string]time.Duration // Last poll's stage durations
+	Total       time.Duration
+	SLO         SLOStats // Rolling pipeline latency (zero Samples when not tracked)
+}
+
+// SLOStats are rolling percentiles of a sport's delta → write → cache time
+type SLOStats struct {
+	Target     time.Duration
+	Samples    int // Polls covered
+	P50        time.Duration
+	P95        time.Duration
+	P99        time.Duration
+	Violations int           // Polls over Target among Samples
+	Stage      string        // Stage with the highest p95
+	StageP95   time.Duration // That stage's p95
+}
+
+// Quota is the most recently observed vendor quota
+type Quota struct {
+	Remaining   int
+	Used        int
+	UpdatedAt   time.Time
+	PausedUntil time.Time         // Vendor requests held after throttling (zero if never)
+	PausedKind  string            // pkg/errors label of the failure that paused them
+	Keys        []models.KeyUsage // Per-key usage when several API keys are configured
+}
+
+// SportUsage is one sport's vendor credit usage for the current UTC day
+type SportUsage struct {
+	SportKey string
+	Requests int64
+	Credits  int64
+}
+
+// StreamLag is the most recently observed lag of one consumer group
+type StreamLag struct {
+	Stream    string
+	Group     string
+	Consumers int64
+	Pending   int64
+	Lag       int64 // -1 when Redis cannot report it
+}
+
+// StaleBook is a book that stopped producing deltas for a sport close to tipoff
+type StaleBook struct {
+	SportKey   string
+	BookKey    string
+	LastDelta  time.Time
+	NextTipoff time.Time
+}
+
+// Snapshot is a point-in-time view of all recorded health
+type Snapshot struct {
+	Sports     []SportHealth
+	Quota      *Quota
+	Usage      []SportUsage // Today's (UTC) credit usage, highest first
+	Lags       []StreamLag
+	StaleBooks []StaleBook // Ordered by sport, then book
+}
+
+// Reporter writes and reads pipeline health in Redis
+type Reporter struct {
+	redis *redis.Client
+}
+
+// NewReporter creates a new health reporter
+func NewReporter(redisClient *redis.Client) *Reporter {
+	return &Reporter{redis: redisClient}
+}
+
+// RecordPoll records a successful poll for a sport
+func (r *Reporter) RecordPoll(ctx context.Context, sportKey string, stats PollStats) error {
+	key := sportKeyPrefix + sportKey
+
+	fields := map[string]interface{}{
+		"last_poll_at": timeutil.Now().Format(time.RFC3339Nano),
+		"last_deltas":  stats.Deltas,
+		"total_ms":     durationMillis(stats.Total),
+	}
+	for stage, d := range stats.Stages {
+		fields["stage_"+stage+"_ms"] = durationMillis(d)
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, key, fields)
+	pipe.HIncrBy(ctx, key, "polls", 1)
+	pipe.HIncrBy(ctx, key, "odds", int64(stats.Odds))
+	pipe.HIncrBy(ctx, key, "deltas", int64(stats.Deltas))
+	pipe.HIncrBy(ctx, key, "invalid", int64(stats.Invalid))
+	pipe.Expire(ctx, key, healthTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record poll: %w", err)
+	}
+	return nil
+}
+
+// RecordSkipped counts a poll skipped because the previous one for the sport was still
+// running (or every poll worker was busy)
+func (r *Reporter) RecordSkipped(ctx context.Context, sportKey string) error {
+	key := sportKeyPrefix + sportKey
+
+	pipe := r.redis.TxPipeline()
+	pipe.HIncrBy(ctx, key, "skipped", 1)
+	pipe.Expire(ctx, key, healthTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record skipped poll: %w", err)
+	}
+	return nil
+}
+
+// RecordSLO records a sport's rolling pipeline latency percentiles
+func (r *Reporter) RecordSLO(ctx context.Context, sportKey string, stats SLOStats) error {
+	key := sportKeyPrefix + sportKey
+
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, key, map[string]interface{}{
+		"slo_target_ms":    durationMillis(stats.Target),
+		"slo_samples":      stats.Samples,
+		"slo_p50_ms":       durationMillis(stats.P50),
+		"slo_p95_ms":       durationMillis(stats.P95),
+		"slo_p99_ms":       durationMillis(stats.P99),
+		"slo_violations":   stats.Violations,
+		"slo_stage":        stats.Stage,
+		"slo_stage_p95_ms": durationMillis(stats.StageP95),
+	})
+	pipe.Expire(ctx, key, healthTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record slo: %w", err)
+	}
+	return nil
+}
+
+// RecordError records a failed poll for a sport
+func (r *Reporter) RecordError(ctx context.Context, sportKey string, pollErr error) error {
+	key := sportKeyPrefix + sportKey
+
+	pipe := r.redis.TxPipeline()
+	label := merrors.Label(pollErr)
+	pipe.HSet(ctx, key,
+		"last_error", pollErr.Error(),
+		"last_error_kind", label,
+		"last_error_at", timeutil.Now().Format(time.RFC3339Nano),
+	)
+	pipe.HIncrBy(ctx, key, "errors", 1)
+	pipe.HIncrBy(ctx, key, errorKindPrefix+label, 1)
+	pipe.Expire(ctx, key, healthTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record error: %w", err)
+	}
+	return nil
+}
+
+// RecordQuota records the vendor's latest rate limits
+func (r *Reporter) RecordQuota(ctx context.Context, limits *models.RateLimits) error {
+	if limits == nil {
+		return nil
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, quotaKey,
+		"remaining", limits.RequestsRemaining,
+		"used", limits.RequestsUsed,
+		"updated_at", timeutil.Now().Format(time.RFC3339Nano),
+	)
+	pipe.Del(ctx, apiKeysKey)
+	for _, key := range limits.Keys {
+		var disabledUntil string
+		if !key.DisabledUntil.IsZero() {
+			disabledUntil = timeutil.UTC(key.DisabledUntil).Format(time.RFC3339Nano)
+		}
+		pipe.HSet(ctx, apiKeysKey, key.Key, fmt.Sprintf("%d|%d|%d|%d|%s|%s",
+			key.Requests, key.Failures, key.Remaining, key.Used, disabledUntil, key.DisabledKind))
+	}
+	pipe.Expire(ctx, apiKeysKey, healthTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record quota: %w", err)
+	}
+	return nil
+}
+
+// RecordVendorPause records that vendor requests are held until a time after the
+// vendor throttled them (kind is the pkg/errors label, e.g. rate_limited)
+func (r *Reporter) RecordVendorPause(ctx context.Context, kind string, until time.Time) error {
+	err := r.redis.HSet(ctx, quotaKey,
+		"paused_until", timeutil.UTC(until).Format(time.RFC3339Nano),
+		"paused_kind", kind,
+	).Err()
+	if err != nil {
+		return fmt.Errorf("record vendor pause: %w", err)
+	}
+	return nil
+}
+
+// RecordUsage adds one request's credit cost to today's (UTC) per-sport usage
+func (r *Reporter) RecordUsage(ctx context.Context, usage models.VendorUsage) error {
+	key := usageKey(usage.RequestedAt)
+	sport := usage.SportKey
+	if sport == "" {
+		sport = "unknown"
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.HIncrBy(ctx, key, sport+"|requests", 1)
+	if usage.Cost > 0 {
+		pipe.HIncrBy(ctx, key, sport+"|credits", int64(usage.Cost))
+	}
+	pipe.Expire(ctx, key, 2*healthTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record usage: %w", err)
+	}
+	return nil
+}
+
+// usageKey is the usage hash for the UTC day containing t
+func usageKey(t time.Time) string {
+	return usageKeyPrefix + timeutil.UTC(t).Format("2006-01-02")
+}
+
+// RecordStreamLag replaces the recorded consumer group lag
+func (r *Reporter) RecordStreamLag(ctx context.Context, lags []StreamLag) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, lagKey)
+	for _, lag := range lags {
+		pipe.HSet(ctx, lagKey, lag.Stream+"|"+lag.Group,
+			fmt.Sprintf("%d|%d|%d", lag.Lag, lag.Pending, lag.Consumers))
+	}
+	pipe.Expire(ctx, lagKey, healthTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record stream lag: %w", err)
+	}
+	return nil
+}
+
+// RecordStaleBooks replaces a sport's stale books. The hash expires after ttl so a
+// stopped detector cannot leave books flagged forever
+func (r *Reporter) RecordStaleBooks(ctx context.Context, sportKey string, books []StaleBook, ttl time.Duration) error {
+	key := staleKeyPrefix + sportKey
+
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, key)
+	for _, book := range books {
+		pipe.HSet(ctx, key, book.BookKey,
+			book.LastDelta.Format(time.RFC3339Nano)+"|"+book.NextTipoff.Format(time.RFC3339Nano))
+	}
+	if len(books) > 0 {
+		pipe.Expire(ctx, key, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record stale books: %w", err)
+	}
+	return nil
+}
+
+// Snapshot reads the health of all sports and the latest quota
+func (r *Reporter) Snapshot(ctx context.Context) (*Snapshot, error) {
+	keys, err := r.scanKeys(ctx, sportKeyPrefix+"*")
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	for _, key := range keys {
+		values, err := r.redis.HGetAll(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", key, err)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		snapshot.Sports = append(snapshot.Sports, parseSportHealth(strings.TrimPrefix(key, sportKeyPrefix), values))
+	}
+
+	sort.Slice(snapshot.Sports, func(i, j int) bool {
+		return snapshot.Sports[i].SportKey < snapshot.Sports[j].SportKey
+	})
+
+	quota, err := r.redis.HGetAll(ctx, quotaKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read quota: %w", err)
+	}
+	if len(quota) > 0 {
+		snapshot.Quota = &Quota{
+			Remaining:   int(parseInt(quota["remaining"])),
+			Used:        int(parseInt(quota["used"])),
+			UpdatedAt:   parseTime(quota["updated_at"]),
+			PausedUntil: parseTime(quota["paused_until"]),
+			PausedKind:  quota["paused_kind"],
+		}
+
+		apiKeys, err := r.redis.HGetAll(ctx, apiKeysKey).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read api keys: %w", err)
+		}
+		for field, value := range apiKeys {
+			if key, ok := parseKeyUsage(field, value); ok {
+				snapshot.Quota.Keys = append(snapshot.Quota.Keys, key)
+			}
+		}
+		sort.Slice(snapshot.Quota.Keys, func(i, j int) bool {
+			return snapshot.Quota.Keys[i].Key < snapshot.Quota.Keys[j].Key
+		})
+	}
+
+	usage, err := r.redis.HGetAll(ctx, usageKey(timeutil.Now())).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read usage: %w", err)
+	}
+	snapshot.Usage = parseUsage(usage)
+
+	lags, err := r.redis.HGetAll(ctx, lagKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read stream lag: %w", err)
+	}
+	for field, value := range lags {
+		if lag, ok := parseStreamLag(field, value); ok {
+			snapshot.Lags = append(snapshot.Lags, lag)
+		}
+	}
+	sort.Slice(snapshot.Lags, func(i, j int) bool {
+		if snapshot.Lags[i].Stream != snapshot.Lags[j].Stream {
+			return snapshot.Lags[i].Stream < snapshot.Lags[j].Stream
+		}
+		return snapshot.Lags[i].Group < snapshot.Lags[j].Group
+	})
+
+	staleKeys, err := r.scanKeys(ctx, staleKeyPrefix+"*")
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range staleKeys {
+		values, err := r.redis.HGetAll(ctx, key).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", key, err)
+		}
+		sportKey := strings.TrimPrefix(key, staleKeyPrefix)
+		for bookKey, value := range values {
+			lastDelta, nextTipoff, _ := strings.Cut(value, "|")
+			snapshot.StaleBooks = append(snapshot.StaleBooks, StaleBook{
+				SportKey:   sportKey,
+				BookKey:    bookKey,
+				LastDelta:  parseTime(lastDelta),
+				NextTipoff: parseTime(nextTipoff),
+			})
+		}
+	}
+	sort.Slice(snapshot.StaleBooks, func(i, j int) bool {
+		if snapshot.StaleBooks[i].SportKey != snapshot.StaleBooks[j].SportKey {
+			return snapshot.StaleBooks[i].SportKey < snapshot.StaleBooks[j].SportKey
+		}
+		return snapshot.StaleBooks[i].BookKey < snapshot.StaleBooks[j].BookKey
+	})
+
+	return snapshot, nil
+}
+
+// scanKeys returns all keys matching a pattern using SCAN (never KEYS)
+func (r *Reporter) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	var keys []string
+	var cursor uint64
+	for {
+		batch, next, err := r.redis.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return nil, fmt.Errorf("scan %s: %w", pattern, err)
+		}
+		keys = append(keys, batch...)
+		if next == 0 {
+			return keys, nil
+		}
+		cursor = next
+	}
+}
+
+// parseSportHealth converts a health hash to SportHealth
+func parseSportHealth(sportKey string, values map[string]string) SportHealth {
+	h := SportHealth{
+		SportKey:    sportKey,
+		LastPollAt:  parseTime(values["last_poll_at"]),
+		LastErrorAt: parseTime(values["last_error_at"]),
+		LastError:   values["last_error"],
+		LastErrKind: values["last_error_kind"],
+		Polls:       parseInt(values["polls"]),
+		Errors:      parseInt(values["errors"]),
+		ErrorKinds:  make(map[string]int64),
+		Odds:        parseInt(values["odds"]),
+		Deltas:      parseInt(values["deltas"]),
+		Invalid:     parseInt(values["invalid"]),
+		Skipped:     parseInt(values["skipped"]),
+		LastDeltas:  int(parseInt(values["last_deltas"])),
+		Stages:      make(map[string]time.Duration),
+		Total:       millisDuration(values["total_ms"]),
+		SLO: SLOStats{
+			Target:     millisDuration(values["slo_target_ms"]),
+			Samples:    int(parseInt(values["slo_samples"])),
+			P50:        millisDuration(values["slo_p50_ms"]),
+			P95:        millisDuration(values["slo_p95_ms"]),
+			P99:        millisDuration(values["slo_p99_ms"]),
+			Violations: int(parseInt(values["slo_violations"])),
+			Stage:      values["slo_stage"],
+			StageP95:   millisDuration(values["slo_stage_p95_ms"]),
+		},
+	}
+
+	for _, stage := range Stages {
+		if v, ok := values["stage_"+stage+"_ms"]; ok {
+			h.Stages[stage] = millisDuration(v)
+		}
+	}
+
+	for field, v := range values {
+		if label, ok := strings.CutPrefix(field, errorKindPrefix); ok {
+			h.ErrorKinds[label] = parseInt(v)
+		}
+	}
+
+	return h
+}
+
+// parseUsage converts a usage hash to per-sport totals, highest credits first
+func parseUsage(values map[string]string) []SportUsage {
+	bySport := make(map[string]*SportUsage)
+	for field, value := range values {
+		sport, metric, ok := strings.Cut(field, "|")
+		if !ok {
+			continue
+		}
+		u := bySport[sport]
+		if u == nil {
+			u = &SportUsage{SportKey: sport}
+			bySport[sport] = u
+		}
+		switch metric {
+		case "requests":
+			u.Requests = parseInt(value)
+		case "credits":
+			u.Credits = parseInt(value)
+		}
+	}
+
+	usage := make([]SportUsage, 0, len(bySport))
+	for _, u := range bySport {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Credits != usage[j].Credits {
+			return usage[i].Credits > usage[j].Credits
+		}
+		return usage[i].SportKey < usage[j].SportKey
+	})
+	return usage
+}
+
+// parseStreamLag converts one lag hash entry to StreamLag
+func parseStreamLag(field, value string) (StreamLag, bool) {
+	stream, group, ok := strings.Cut(field, "|")
+	if !ok {
+		return StreamLag{}, false
+	}
+
+	parts := strings.Split(value, "|")
+	if len(parts) != 3 {
+		return StreamLag{}, false
+	}
+
+	return StreamLag{
+		Stream:    stream,
+		Group:     group,
+		Lag:       parseInt(parts[0]),
+		Pending:   parseInt(parts[1]),
+		Consumers: parseInt(parts[2]),
+	}, true
+}
+
+// parseKeyUsage parses one field of the API keys hash
+func parseKeyUsage(field, value string) (models.KeyUsage, bool) {
+	parts := strings.Split(value, "|")
+	if len(parts) != 6 {
+		return models.KeyUsage{}, false
+	}
+
+	return models.KeyUsage{
+		Key:           field,
+		Requests:      parseInt(parts[0]),
+		Failures:      parseInt(parts[1]),
+		Remaining:     int(parseInt(parts[2])),
+		Used:          int(parseInt(parts[3])),
+		DisabledUntil: parseTime(parts[4]),
+		DisabledKind:  parts[5],
+	}, true
+}
+
+// durationMillis formats a duration as fractional milliseconds
+func durationMillis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
+
+// millisDuration parses fractional milliseconds into a duration
+func millisDuration(value string) time.Duration {
+	ms, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(ms * float64(time.Millisecond))
+}
+
+func parseInt(value string) int64 {
+	n, _ := strconv.ParseInt(value, 10, 64)
+	return n
+}
+
+func parseTime(value string) time.Time {
+	t, err := timeutil.ParseVendorTime(value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,418 @@
+// Package jetstream forwards committed odds deltas to a NATS JetStream stream, a
+// lighter-weight alternative to Kafka for consumers outside the Redis deployment.
+// Subjects are templated per sport/market (mercury.odds.basketball_nba.spreads), the
+// durable stream is created or updated at connect, and each message carries its quote
+// identity as Nats-Msg-Id, so redelivered batches are dropped by the stream.
+package jetstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const (
+	DefaultStream          = "MERCURY_ODDS"
+	DefaultSubjectTemplate = "mercury.odds.{sport}.{market}"
+
+	// HeaderSignature carries "sha256=<hex HMAC of the payload>" when a Secret is set
+	// (verify with consumer.VerifyPayload)
+	HeaderSignature = "Mercury-Signature"
+
+	defaultTimeout         = 5 * time.Second
+	defaultDuplicateWindow = 2 * time.Minute
+)
+
+// placeholders maps subject template placeholders to message fields
+var placeholders = map[string]func(models.StreamMessage) string{
+	"{sport}":  func(m models.StreamMessage) string { return m.SportKey },
+	"{market}": func(m models.StreamMessage) string { return m.MarketKey },
+	"{book}":   func(m models.StreamMessage) string { return m.BookKey },
+	"{event}":  func(m models.StreamMessage) string { return m.EventID },
+	"{status}": func(m models.StreamMessage) string { return m.EventStatus },
+}
+
+// Config configures the JetStream sink
+type Config struct {
+	URL             string // nats://[user:pass@|token@]host:4222
+	Stream          string // Durable stream name (default MERCURY_ODDS)
+	SubjectTemplate string // Placeholders: {sport} {market} {book} {event} {status}
+
+	// Stream limits, applied when the stream is created or updated
+	MaxAge          time.Duration // 0 = keep until MaxBytes
+	MaxBytes        int64         // 0 = unlimited
+	Replicas        int           // Default 1
+	MemoryStorage   bool          // Default file storage
+	DuplicateWindow time.Duration // Nats-Msg-Id dedupe window (default 2m)
+
+	Encoding models.StreamEncoding // Payload encoding (default JSON)
+	Timeout  time.Duration         // Connect, stream setup and per-batch ack wait (default 5s)
+	Secret   string                // HMAC key for the Mercury-Signature header (empty = unsigned)
+}
+
+// Sink publishes odds deltas to JetStream and implements contracts.StreamSink
+// A failed connection is dropped and redialed on the next batch
+type Sink struct {
+	config Config
+
+	mu   sync.Mutex // Serializes batches so per-outcome order is kept
+	conn *conn
+
+	published atomic.Int64
+	failed    atomic.Int64
+}
+
+// pubAck is JetStream's reply to a publish
+type pubAck struct {
+	Stream    string    `json:"stream"`
+	Seq       uint64    `json:"seq"`
+	Duplicate bool      `json:"duplicate"`
+	Error     *apiError `json:"error"`
+}
+
+// apiError is the error object of JetStream API and publish replies
+type apiError struct {
+	Code        int    `json:"code"`
+	Description string `json:"description"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (%d)", e.Description, e.Code)
+}
+
+// NewSink validates config and creates a sink; call Connect to dial
+func NewSink(config Config) (*Sink, error) {
+	if config.URL == "" {
+		return nil, errors.New("jetstream: URL is required")
+	}
+	if config.Stream == "" {
+		config.Stream = DefaultStream
+	}
+	if strings.ContainsAny(config.Stream, ". *>") {
+		return nil, fmt.Errorf("jetstream: invalid stream name %q", config.Stream)
+	}
+	if config.SubjectTemplate == "" {
+		config.SubjectTemplate = DefaultSubjectTemplate
+	}
+	if err := ValidateSubjectTemplate(config.SubjectTemplate); err != nil {
+		return nil, err
+	}
+	if config.Replicas <= 0 {
+		config.Replicas = 1
+	}
+	if config.DuplicateWindow <= 0 {
+		config.DuplicateWindow = defaultDuplicateWindow
+	}
+	if config.Encoding == "" {
+		config.Encoding = models.StreamEncodingJSON
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return &Sink{config: config}, nil
+}
+
+// Name identifies the sink in logs
+func (s *Sink) Name() string {
+	return "jetstream"
+}
+
+// Connect dials the server and creates or updates the durable stream
+func (s *Sink) Connect(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.connection(ctx)
+	return err
+}
+
+// Publish sends a batch and waits for the stream to acknowledge every message
+func (s *Sink) Publish(ctx context.Context, messages []models.StreamMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+
+	c, err := s.connection(ctx)
+	if err != nil {
+		s.failed.Add(int64(len(messages)))
+		return err
+	}
+
+	headers := map[string]string{"Mercury-Schema-Version": strconv.Itoa(models.StreamSchemaVersion)}
+	if s.config.Encoding == models.StreamEncodingProtobuf {
+		headers["Mercury-Encoding"] = string(models.StreamEncodingProtobuf)
+	}
+
+	acks := make([]chan reply, 0, len(messages))
+	subjects := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		payload, err := s.encode(msg)
+		if err != nil {
+			s.failed.Add(1)
+			continue
+		}
+
+		msgHeaders := headers
+		if s.config.Secret != "" {
+			msgHeaders = make(map[string]string, len(headers)+1)
+			for key, value := range headers {
+				msgHeaders[key] = value
+			}
+			msgHeaders[HeaderSignature] = consumer.SignPayload(s.config.Secret, payload)
+		}
+
+		replyTo, ch := c.expect()
+		if err := c.write(Subject(s.config.SubjectTemplate, msg), replyTo, msg.DedupeKey, msgHeaders, payload); err != nil {
+			c.forget(replyTo)
+			s.failed.Add(1)
+			continue
+		}
+		acks = append(acks, ch)
+		subjects = append(subjects, replyTo)
+	}
+	if err := c.flush(); err != nil {
+		s.drop()
+		s.failed.Add(int64(len(acks)))
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	var rejected int
+	var lastErr error
+	for i, ch := range acks {
+		select {
+		case msg := <-ch:
+			var ack pubAck
+			switch {
+			case msg.status == "503":
+				lastErr = fmt.Errorf("no stream captures the subject (stream %s)", s.config.Stream)
+			case json.Unmarshal(msg.data, &ack) != nil:
+				lastErr = fmt.Errorf("malformed ack %q", msg.data)
+			case ack.Error != nil:
+				lastErr = ack.Error
+			default:
+				s.published.Add(1)
+				continue
+			}
+			rejected++
+
+		case <-c.closed:
+			s.drop()
+			s.failed.Add(int64(len(acks) - i + rejected))
+			return fmt.Errorf("connection lost awaiting acks: %w", c.Err())
+
+		case <-ctx.Done():
+			for _, subject := range subjects[i:] {
+				c.forget(subject)
+			}
+			s.drop() // Acks may still arrive out of step; start clean
+			s.failed.Add(int64(len(acks) - i + rejected))
+			return fmt.Errorf("%d of %d acks not received: %w", len(acks)-i, len(messages), ctx.Err())
+		}
+	}
+
+	if rejected > 0 {
+		s.failed.Add(int64(rejected))
+		return fmt.Errorf("%d of %d messages rejected: %w", rejected, len(messages), lastErr)
+	}
+	return nil
+}
+
+// Stats returns how many messages were acknowledged and how many failed
+func (s *Sink) Stats() (published, failed int64) {
+	return s.published.Load(), s.failed.Load()
+}
+
+// Close closes the connection
+func (s *Sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.drop()
+	published, failed := s.Stats()
+	fmt.Printf("✓ JetStream sink closed (%d published, %d failed)\n", published, failed)
+	return nil
+}
+
+// connection returns the live connection, dialing and setting up the stream if needed
+// Callers hold s.mu
+func (s *Sink) connection(ctx context.Context) (*conn, error) {
+	if s.conn != nil && s.conn.Err() == nil {
+		return s.conn, nil
+	}
+	s.drop()
+
+	c, err := dial(ctx, s.config.URL, s.config.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	if err := s.ensureStream(ctx, c); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("stream %s: %w", s.config.Stream, err)
+	}
+
+	s.conn = c
+	fmt.Printf("✓ JetStream connected (stream %s, subjects %s)\n", s.config.Stream, SubjectFilter(s.config.SubjectTemplate))
+	return c, nil
+}
+
+// drop closes and forgets the connection. Callers hold s.mu
+func (s *Sink) drop() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// StreamConfig returns the JetStream stream configuration the sink applies
+func (s *Sink) StreamConfig() map[string]interface{} {
+	storage := "file"
+	if s.config.MemoryStorage {
+		storage = "memory"
+	}
+	maxBytes := s.config.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = -1
+	}
+
+	return map[string]interface{}{
+		"name":             s.config.Stream,
+		"subjects":         []string{SubjectFilter(s.config.SubjectTemplate)},
+		"retention":        "limits",
+		"storage":          storage,
+		"discard":          "old",
+		"num_replicas":     s.config.Replicas,
+		"max_age":          s.config.MaxAge.Nanoseconds(),
+		"max_bytes":        maxBytes,
+		"max_msgs":         -1,
+		"duplicate_window": s.config.DuplicateWindow.Nanoseconds(),
+	}
+}
+
+// ensureStream creates the stream, or updates an existing one to the configured
+// subjects and limits
+func (s *Sink) ensureStream(ctx context.Context, c *conn) error {
+	info, err := apiRequest(ctx, c, "$JS.API.STREAM.INFO."+s.config.Stream, nil)
+	if err != nil {
+		var apiErr *apiError
+		if !errors.As(err, &apiErr) || apiErr.Code != 404 {
+			return err
+		}
+	}
+
+	action := "CREATE"
+	if info != nil {
+		action = "UPDATE"
+	}
+
+	config, _ := json.Marshal(s.StreamConfig())
+	_, err = apiRequest(ctx, c, "$JS.API.STREAM."+action+"."+s.config.Stream, config)
+	return err
+}
+
+// apiRequest calls the JetStream API, turning error replies into *apiError
+func apiRequest(ctx context.Context, c *conn, subject string, payload []byte) (json.RawMessage, error) {
+	data, err := c.request(ctx, subject, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Error *apiError `json:"error"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("malformed API reply %q", data)
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return data, nil
+}
+
+// encode serializes a message in the configured encoding
+func (s *Sink) encode(msg models.StreamMessage) ([]byte, error) {
+	if s.config.Encoding == models.StreamEncodingProtobuf {
+		return msg.MarshalProto(), nil
+	}
+	return json.Marshal(msg)
+}
+
+// ValidateSubjectTemplate checks that a template has non-empty, wildcard-free tokens
+// and only known placeholders
+func ValidateSubjectTemplate(template string) error {
+	for _, token := range strings.Split(template, ".") {
+		if token == "" || strings.ContainsAny(token, " *>") {
+			return fmt.Errorf("jetstream: invalid subject template %q", template)
+		}
+
+		rest := token
+		for {
+			start := strings.IndexByte(rest, '{')
+			if start < 0 {
+				break
+			}
+			end := strings.IndexByte(rest[start:], '}')
+			if end < 0 {
+				return fmt.Errorf("jetstream: unclosed placeholder in %q", template)
+			}
+			if _, ok := placeholders[rest[start:start+end+1]]; !ok {
+				return fmt.Errorf("jetstream: unknown placeholder %s in %q", rest[start:start+end+1], template)
+			}
+			rest = rest[start+end+1:]
+		}
+	}
+	return nil
+}
+
+// Subject fills a template from a message. Values are sanitized into single subject
+// tokens (dots, spaces and wildcards become underscores)
+func Subject(template string, msg models.StreamMessage) string {
+	subject := template
+	for placeholder, field := range placeholders {
+		if strings.Contains(subject, placeholder) {
+			subject = strings.ReplaceAll(subject, placeholder, subjectToken(field(msg)))
+		}
+	}
+	return subject
+}
+
+// SubjectFilter returns the stream subject filter matching every subject a template
+// produces: each token holding a placeholder becomes a wildcard
+func SubjectFilter(template string) string {
+	tokens := strings.Split(template, ".")
+	for i, token := range tokens {
+		if strings.Contains(token, "{") {
+			tokens[i] = "*"
+		}
+	}
+	return strings.Join(tokens, ".")
+}
+
+// subjectToken makes a value safe to use as one subject token
+func subjectToken(value string) string {
+	if value == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', ' ', '\t', '\r', '\n', '*', '>':
+			return '_'
+		}
+		return r
+	}, value)
+}
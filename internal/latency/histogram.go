@@ -0,0 +1,140 @@
+// Package latency keeps per-stage latency histograms of the poll pipeline (fetch,
+// delta, write, publish, cache, and the delta → write → cache total the SLO covers),
+// so the pipeline SLO can be measured over days rather than per poll. Histograms are
+// added to a Redis hash per UTC day and summarised in the log every report interval.
+package latency
+
+import (
+	"math"
+	"time"
+)
+
+// Bounds are the histogram's bucket upper bounds; a last bucket counts anything slower.
+// The SLO target (30ms by default) is a bound, so the share of polls within it is exact
+var Bounds = []time.Duration{
+	500 * time.Microsecond,
+	time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	30 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// Histogram is a bucketed latency distribution
+type Histogram struct {
+	Counts []int64 // len(Bounds)+1; Counts[i] holds latencies ≤ Bounds[i], the last one the rest
+	Sum    time.Duration
+}
+
+// NewHistogram creates an empty histogram
+func NewHistogram() *Histogram {
+	return &Histogram{Counts: make([]int64, len(Bounds)+1)}
+}
+
+// Observe adds one latency; negative ones count as zero
+func (h *Histogram) Observe(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	h.Counts[bucket(d)]++
+	h.Sum += d
+}
+
+// Merge adds another histogram's observations
+func (h *Histogram) Merge(other *Histogram) {
+	for i, n := range other.Counts {
+		h.Counts[i] += n
+	}
+	h.Sum += other.Sum
+}
+
+// Count returns the number of observations
+func (h *Histogram) Count() int64 {
+	var n int64
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
+
+// Mean returns the average latency (0 when empty)
+func (h *Histogram) Mean() time.Duration {
+	n := h.Count()
+	if n == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(n)
+}
+
+// Within returns the share (0-1) of observations in buckets bounded at or below
+// target. It is exact when target is one of Bounds and conservative otherwise
+func (h *Histogram) Within(target time.Duration) float64 {
+	n := h.Count()
+	if n == 0 {
+		return 0
+	}
+
+	var within int64
+	for i, bound := range Bounds {
+		if bound > target {
+			break
+		}
+		within += h.Counts[i]
+	}
+	return float64(within) / float64(n)
+}
+
+// Quantile returns the upper bound of the bucket holding the q-th quantile (0 < q ≤ 1).
+// over is true when it falls past the last bound, in which case bound is that last bound
+func (h *Histogram) Quantile(q float64) (bound time.Duration, over bool) {
+	n := h.Count()
+	if n == 0 {
+		return 0, false
+	}
+
+	target := int64(math.Ceil(q * float64(n)))
+	if target < 1 {
+		target = 1
+	}
+
+	var seen int64
+	for i, c := range h.Counts {
+		seen += c
+		if seen >= target {
+			if i == len(Bounds) {
+				return Bounds[len(Bounds)-1], true
+			}
+			return Bounds[i], false
+		}
+	}
+	return Bounds[len(Bounds)-1], true
+}
+
+// FormatQuantile renders a quantile as "≤30ms" or ">30s"
+func (h *Histogram) FormatQuantile(q float64) string {
+	bound, over := h.Quantile(q)
+	if over {
+		return ">" + bound.String()
+	}
+	return "≤" + bound.String()
+}
+
+// bucket returns the index of the bucket a latency falls in
+func bucket(d time.Duration) int {
+	for i, bound := range Bounds {
+		if d <= bound {
+			return i
+		}
+	}
+	return len(Bounds)
+}
@@ -0,0 +1,275 @@
+package latency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "mercury:latency:" // Hash per UTC day of "<stage>|<bucket>" counts and "<stage>|sum_us"
+	keyTTL    = 8 * 24 * time.Hour // A week of history
+
+	// DefaultInterval is how often observations are added to Redis and summarised in the log
+	DefaultInterval = time.Minute
+	// DefaultTarget is the pipeline SLO the summary measures against
+	DefaultTarget = 30 * time.Millisecond
+)
+
+// Pipeline stages. Publish runs inside write (the writer publishes to the stream
+// before returning); Pipeline is delta + write + cache, the share of a poll the SLO covers
+const (
+	StageFetch    = "fetch"
+	StageDelta    = "delta"
+	StageWrite    = "write"
+	StagePublish  = "publish"
+	StageCache    = "cache"
+	StagePipeline = "pipeline"
+)
+
+// Stages lists every stage in pipeline order
+var Stages = []string{StageFetch, StageDelta, StageWrite, StagePublish, StageCache, StagePipeline}
+
+// Key returns the Redis hash holding one UTC day's stage histograms
+func Key(day time.Time) string {
+	return keyPrefix + timeutil.UTC(day).Format("2006-01-02")
+}
+
+// StageLatency is one stage's latency distribution
+type StageLatency struct {
+	Stage     string
+	Histogram *Histogram
+}
+
+// Recorder observes stage latencies and, every interval, adds them to the day's
+// histograms in Redis and logs a summary of the interval
+type Recorder struct {
+	redis    *redis.Client
+	interval time.Duration
+	target   time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*Histogram // stage -> latencies since the last flush
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewRecorder creates a recorder flushing every interval (DefaultInterval when ≤ 0) and
+// reporting the pipeline's share within target (DefaultTarget when ≤ 0). A nil Redis
+// client keeps the summary log only
+func NewRecorder(redisClient *redis.Client, interval, target time.Duration) *Recorder {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if target <= 0 {
+		target = DefaultTarget
+	}
+	return &Recorder{
+		redis:    redisClient,
+		interval: interval,
+		target:   target,
+		pending:  make(map[string]*Histogram),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Observe adds one latency to a stage
+func (r *Recorder) Observe(stage string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.observe(stage, d)
+}
+
+// ObservePoll adds a poll's stage durations (stage name -> duration, as the scheduler
+// reports them). Polls that reached the delta engine also count towards Pipeline
+func (r *Recorder) ObservePoll(stages map[string]time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for stage, d := range stages {
+		r.observe(stage, d)
+	}
+	if _, ok := stages[StageDelta]; ok {
+		r.observe(StagePipeline, stages[StageDelta]+stages[StageWrite]+stages[StageCache])
+	}
+}
+
+// observe adds one latency (caller holds mu)
+func (r *Recorder) observe(stage string, d time.Duration) {
+	h := r.pending[stage]
+	if h == nil {
+		h = NewHistogram()
+		r.pending[stage] = h
+	}
+	h.Observe(d)
+}
+
+// Flush adds pending observations to today's histograms and returns them, in
+// pipeline order, for the summary
+func (r *Recorder) Flush(ctx context.Context) ([]StageLatency, error) {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]*Histogram)
+	r.mu.Unlock()
+
+	flushed := ordered(pending)
+	if len(flushed) == 0 || r.redis == nil {
+		return flushed, nil
+	}
+
+	key := Key(timeutil.Now())
+	pipe := r.redis.TxPipeline()
+	for _, stage := range flushed {
+		for i, n := range stage.Histogram.Counts {
+			if n > 0 {
+				pipe.HIncrBy(ctx, key, stage.Stage+"|"+strconv.Itoa(i), n)
+			}
+		}
+		pipe.HIncrBy(ctx, key, stage.Stage+"|sum_us", stage.Histogram.Sum.Microseconds())
+	}
+	pipe.Expire(ctx, key, keyTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return flushed, fmt.Errorf("record latency: %w", err)
+	}
+	return flushed, nil
+}
+
+// Summary renders one interval's stages as a log line, e.g.
+// "fetch n=12 p50≤250ms p95≤500ms p99≤1s | … | pipeline 98.0% ≤30ms"
+func Summary(stages []StageLatency, target time.Duration) string {
+	parts := make([]string, 0, len(stages)+1)
+	var pipeline *Histogram
+	for _, stage := range stages {
+		h := stage.Histogram
+		parts = append(parts, fmt.Sprintf("%s n=%d p50%s p95%s p99%s", stage.Stage, h.Count(),
+			h.FormatQuantile(0.5), h.FormatQuantile(0.95), h.FormatQuantile(0.99)))
+		if stage.Stage == StagePipeline {
+			pipeline = h
+		}
+	}
+	if pipeline != nil {
+		parts = append(parts, fmt.Sprintf("pipeline %.1f%% ≤%v", 100*pipeline.Within(target), target))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Start begins periodic flushes and summaries
+func (r *Recorder) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.report(ctx)
+			case <-r.stopChan:
+				r.report(context.Background())
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// report flushes and logs the interval's summary
+func (r *Recorder) report(ctx context.Context) {
+	stages, err := r.Flush(ctx)
+	if err != nil {
+		fmt.Printf("[Latency] %v\n", err)
+	}
+	if len(stages) > 0 {
+		fmt.Printf("[Latency] last %v: %s\n", r.interval, Summary(stages, r.target))
+	}
+}
+
+// Stop flushes pending observations and stops the recorder
+func (r *Recorder) Stop() {
+	close(r.stopChan)
+	r.wg.Wait()
+}
+
+// Read merges the stage histograms of the given UTC days, in pipeline order
+func Read(ctx context.Context, redisClient *redis.Client, days ...time.Time) ([]StageLatency, error) {
+	byStage := make(map[string]*Histogram)
+	for _, day := range days {
+		values, err := redisClient.HGetAll(ctx, Key(day)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read latency: %w", err)
+		}
+		for stage, h := range parse(values) {
+			if merged, ok := byStage[stage]; ok {
+				merged.Merge(h)
+			} else {
+				byStage[stage] = h
+			}
+		}
+	}
+	return ordered(byStage), nil
+}
+
+// ordered lists histograms in pipeline order, then any unknown stages by name
+func ordered(byStage map[string]*Histogram) []StageLatency {
+	stages := make([]StageLatency, 0, len(byStage))
+	known := make(map[string]bool, len(Stages))
+	for _, stage := range Stages {
+		known[stage] = true
+		if h, ok := byStage[stage]; ok {
+			stages = append(stages, StageLatency{Stage: stage, Histogram: h})
+		}
+	}
+
+	var other []string
+	for stage := range byStage {
+		if !known[stage] {
+			other = append(other, stage)
+		}
+	}
+	sort.Strings(other)
+	for _, stage := range other {
+		stages = append(stages, StageLatency{Stage: stage, Histogram: byStage[stage]})
+	}
+	return stages
+}
+
+// parse converts one day's hash to histograms per stage
+func parse(values map[string]string) map[string]*Histogram {
+	byStage := make(map[string]*Histogram)
+	for field, value := range values {
+		stage, suffix, ok := strings.Cut(field, "|")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		h := byStage[stage]
+		if h == nil {
+			h = NewHistogram()
+			byStage[stage] = h
+		}
+		if suffix == "sum_us" {
+			h.Sum += time.Duration(n) * time.Microsecond
+			continue
+		}
+		if i, err := strconv.Atoi(suffix); err == nil && i >= 0 && i < len(h.Counts) {
+			h.Counts[i] += n
+		}
+	}
+	return byStage
+}
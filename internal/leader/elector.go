@@ -0,0 +1,263 @@
+// Package leader elects a single active Mercury replica with a Redis lease, so
+// several replicas can run for availability while only one polls and writes.
+//
+// The leader holds "mercury:leader" (value: its instance ID) with a TTL and renews it
+// at a third of the TTL. Standbys retry at the same cadence, so a crashed leader is
+// replaced within one TTL and a gracefully stopped one (which releases the lease)
+// within one retry interval. A leader that fails to renew closes Lost() and must
+// stop working: another replica may already hold the lease. When Redis is
+// unreachable, Lost() closes a safety margin before the last renewed lease expires,
+// so a standby never takes over while this replica still acts as leader.
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/redis/go-redis/v9"
+)
+
+// Key holds the current leader's instance ID
+const Key = "mercury:leader"
+
+// DefaultTTL is the lease length: the worst-case failover time after a crash
+const DefaultTTL = 15 * time.Second
+
+// renewScript extends the lease only if this instance still holds it
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseScript deletes the lease only if this instance still holds it
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Elector campaigns for and holds the leader lease
+type Elector struct {
+	redis      *redis.Client
+	instanceID string
+	ttl        time.Duration
+	clock      clock.Clock
+
+	mu          sync.Mutex
+	isLeader    bool
+	lastRenewed time.Time // When the last successful acquire or renewal was sent
+	lost        chan struct{}
+	lostOnce    sync.Once
+	stopChan    chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewElector creates an elector for one replica
+func NewElector(redisClient *redis.Client, instanceID string) *Elector {
+	return &Elector{
+		redis:      redisClient,
+		instanceID: instanceID,
+		ttl:        DefaultTTL,
+		clock:      clock.Real,
+		lost:       make(chan struct{}),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// SetTTL sets the lease length (renewals and standby retries run at a third of it)
+func (e *Elector) SetTTL(ttl time.Duration) {
+	if ttl > 0 {
+		e.ttl = ttl
+	}
+}
+
+// SetClock replaces the wall clock (tests drive renewals with a clock.Fake)
+func (e *Elector) SetClock(c clock.Clock) {
+	e.clock = c
+}
+
+// InstanceID returns this replica's identity in the lease
+func (e *Elector) InstanceID() string {
+	return e.instanceID
+}
+
+// IsLeader reports whether this replica currently holds the lease
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.isLeader
+}
+
+// Lost is closed when a held lease could not be renewed
+func (e *Elector) Lost() <-chan struct{} {
+	return e.lost
+}
+
+func (e *Elector) interval() time.Duration {
+	return e.ttl / 3
+}
+
+// safetyMargin is how long before the lease expires a leader that cannot renew
+// gives it up, covering Redis latency and clock drift between replicas
+func (e *Elector) safetyMargin() time.Duration {
+	return e.ttl / 10
+}
+
+// Acquire blocks until this replica becomes leader, or returns ctx.Err() / an
+// error from Stop being called while standing by
+func (e *Elector) Acquire(ctx context.Context) error {
+	ticker := e.clock.NewTicker(e.interval())
+	defer ticker.Stop()
+
+	lastSeen := ""
+	for {
+		sentAt := e.clock.Now()
+		acquired, err := e.redis.SetNX(ctx, Key, e.instanceID, e.ttl).Result()
+		if err != nil {
+			fmt.Printf("[Leader] Campaign error: %v\n", err)
+		} else if acquired {
+			e.mu.Lock()
+			e.isLeader = true
+			e.lastRenewed = sentAt
+			e.mu.Unlock()
+			fmt.Printf("[Leader] %s is now the leader (lease %v)\n", e.instanceID, e.ttl)
+			return nil
+		} else if current, err := e.redis.Get(ctx, Key).Result(); err == nil && current != lastSeen {
+			// Log only leader changes, not every retry
+			lastSeen = current
+			fmt.Printf("[Leader] Standing by: current leader is %s\n", current)
+		}
+
+		select {
+		case <-ticker.C():
+		case <-e.stopChan:
+			return errors.New("elector stopped before acquiring leadership")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Start renews the lease until Stop, ctx cancellation, or the lease is lost
+func (e *Elector) Start(ctx context.Context) {
+	ticker := e.clock.NewTicker(e.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			if err := e.renew(ctx); err != nil {
+				e.markLost(err)
+				return
+			}
+		case <-e.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// renew extends the lease. A Redis error is retried while the lease is still valid;
+// it is treated as lost once the next retry would land within the safety margin of
+// the last renewed lease's expiry (not a full TTL from this attempt, which would
+// outlive the lease)
+func (e *Elector) renew(ctx context.Context) error {
+	retry := e.interval() / 2
+	for {
+		sentAt := e.clock.Now()
+		renewed, err := renewScript.Run(ctx, e.redis, []string{Key}, e.instanceID, e.ttl.Milliseconds()).Int()
+		if err == nil {
+			if renewed == 0 {
+				return errors.New("lease is held by another instance")
+			}
+			e.mu.Lock()
+			e.lastRenewed = sentAt
+			e.mu.Unlock()
+			return nil
+		}
+
+		e.mu.Lock()
+		validUntil := e.lastRenewed.Add(e.ttl - e.safetyMargin())
+		e.mu.Unlock()
+		if !e.clock.Now().Add(retry).Before(validUntil) {
+			return fmt.Errorf("renew failed until the lease was about to expire: %w", err)
+		}
+		fmt.Printf("[Leader] Renew error (retrying): %v\n", err)
+
+		timer := e.clock.NewTimer(retry)
+		select {
+		case <-timer.C():
+		case <-e.stopChan:
+			timer.Stop()
+			return nil
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+	}
+}
+
+func (e *Elector) markLost(err error) {
+	e.mu.Lock()
+	e.isLeader = false
+	e.mu.Unlock()
+
+	e.lostOnce.Do(func() {
+		fmt.Printf("[Leader] %s lost leadership: %v\n", e.instanceID, err)
+		close(e.lost)
+	})
+}
+
+// Stop stops renewing and releases the lease if held, so a standby takes over
+// without waiting for it to expire
+func (e *Elector) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stopChan)
+	})
+
+	e.mu.Lock()
+	wasLeader := e.isLeader
+	e.isLeader = false
+	e.mu.Unlock()
+
+	if !wasLeader {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := releaseScript.Run(ctx, e.redis, []string{Key}, e.instanceID).Err(); err != nil {
+		fmt.Printf("[Leader] Failed to release lease: %v\n", err)
+		return
+	}
+	fmt.Printf("[Leader] %s released leadership\n", e.instanceID)
+}
+
+// Current returns the leader's instance ID and remaining lease ("" if none)
+func Current(ctx context.Context, redisClient *redis.Client) (string, time.Duration, error) {
+	pipe := redisClient.Pipeline()
+	get := pipe.Get(ctx, Key)
+	ttl := pipe.PTTL(ctx, Key)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return "", 0, err
+	}
+	id, _ := get.Result()
+	remaining, _ := ttl.Result()
+	return id, remaining, nil
+}
+
+// DefaultInstanceID identifies a replica as hostname-pid
+func DefaultInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "mercury"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
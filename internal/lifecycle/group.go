@@ -0,0 +1,109 @@
+// Package lifecycle runs long-lived components under a shared context so that one
+// component's failure stops its siblings and is reported to the caller instead of
+// being printed and lost. Group follows golang.org/x/sync/errgroup, with named
+// goroutines and panics turned into errors.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// DefaultMaxFailures is how many consecutive failed runs a polling loop tolerates
+// before it gives up and fails its group
+const DefaultMaxFailures = 10
+
+// Group runs named goroutines. The first one to return an error (or panic) cancels
+// the group's context; Wait returns every failure once all of them have returned
+type Group struct {
+	cancel context.CancelCauseFunc
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// WithContext returns a group and a context derived from ctx that is cancelled when
+// a goroutine fails or the parent is done
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go runs fn in a new goroutine. A returned error or panic is recorded as
+// "<name>: <err>" and cancels the group's context. Go may be called from a goroutine
+// of the same group while Wait is in progress
+func (g *Group) Go(name string, fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+
+		if err := run(fn); err != nil {
+			g.fail(fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+}
+
+// Wait blocks until every goroutine has returned, then cancels the group's context
+// and returns the joined failures (nil if none failed)
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(context.Canceled)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
+
+// fail records err and cancels the group with it as the cause
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	g.errs = append(g.errs, err)
+	g.mu.Unlock()
+
+	if g.cancel != nil {
+		g.cancel(err)
+	}
+}
+
+// run calls fn, converting a panic into an error carrying the stack
+func run(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return fn()
+}
+
+// Failures counts consecutive failed runs of a polling loop
+type Failures struct {
+	Max int // Consecutive failures tolerated (0 = DefaultMaxFailures)
+
+	count int
+}
+
+// Record notes the outcome of one run. A nil err resets the count; it returns an
+// error once Max consecutive runs have failed, which the loop should return
+func (f *Failures) Record(err error) error {
+	if err == nil {
+		f.count = 0
+		return nil
+	}
+
+	max := f.Max
+	if max <= 0 {
+		max = DefaultMaxFailures
+	}
+
+	f.count++
+	if f.count >= max {
+		return fmt.Errorf("%d consecutive failures, last: %w", f.count, err)
+	}
+	return nil
+}
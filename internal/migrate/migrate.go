@@ -0,0 +1,270 @@
+// Package migrate applies the embedded Alexandria schema migrations and records the
+// applied versions in schema_migrations, so Mercury can refuse to start against an
+// out-of-date schema
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// advisoryLockID serializes migration runs across replicas (pg_advisory_lock key)
+const advisoryLockID = 7_421_086
+
+// ErrSchemaBehind is returned by Check when migrations are pending
+var ErrSchemaBehind = errors.New("schema is behind the embedded migrations")
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// Migration is one numbered schema change
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Applied is a row of schema_migrations
+type Applied struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+// Load reads NNN_name.sql files from dir in fsys, sorted by version
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: expected NNN_name.sql", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, other, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		body, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: match[2], SQL: string(body)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest returns the highest version in migrations (0 if empty)
+func Latest(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// Migrator applies migrations to one database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New creates a migrator for a sorted migration set (see Load)
+func New(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// ensureTable creates schema_migrations if it does not exist
+func (m *Migrator) ensureTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name VARCHAR(200) NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`)
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// Applied lists the recorded migrations in version order (empty if none were ever run)
+func (m *Migrator) Applied(ctx context.Context) ([]Applied, error) {
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check schema_migrations: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, fmt.Errorf("query schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var applied []Applied
+	for rows.Next() {
+		var a Applied
+		if err := rows.Scan(&a.Version, &a.Name, &a.AppliedAt); err != nil {
+			return nil, fmt.Errorf("scan schema_migrations: %w", err)
+		}
+		applied = append(applied, a)
+	}
+	return applied, rows.Err()
+}
+
+// Pending returns the migrations not yet recorded in schema_migrations
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pending(m.migrations, applied), nil
+}
+
+func pending(migrations []Migration, applied []Applied) []Migration {
+	done := make(map[int]bool, len(applied))
+	for _, a := range applied {
+		done[a.Version] = true
+	}
+
+	var out []Migration
+	for _, mig := range migrations {
+		if !done[mig.Version] {
+			out = append(out, mig)
+		}
+	}
+	return out
+}
+
+// Up applies every pending migration in order, each in its own transaction, and
+// returns the ones applied. Concurrent callers are serialized by an advisory lock.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	conn, unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	// Re-read under the lock: another replica may have just migrated
+	todo, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, mig := range todo {
+		if err := m.apply(ctx, conn, mig); err != nil {
+			return applied, err
+		}
+		applied = append(applied, mig)
+	}
+	return applied, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migration %03d: begin: %w", mig.Version, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, mig.SQL); err != nil {
+		return fmt.Errorf("migration %03d_%s: %w", mig.Version, mig.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name); err != nil {
+		return fmt.Errorf("migration %03d: record version: %w", mig.Version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migration %03d: commit: %w", mig.Version, err)
+	}
+	return nil
+}
+
+// Baseline records every migration up to and including version as applied without
+// running it, for databases created before schema_migrations existed (via psql)
+func (m *Migrator) Baseline(ctx context.Context, version int) ([]Migration, error) {
+	conn, unlock, err := m.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	todo, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded []Migration
+	for _, mig := range todo {
+		if mig.Version > version {
+			break
+		}
+		if _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, mig.Version, mig.Name); err != nil {
+			return recorded, fmt.Errorf("baseline %03d: %w", mig.Version, err)
+		}
+		recorded = append(recorded, mig)
+	}
+	return recorded, nil
+}
+
+// Check returns ErrSchemaBehind (wrapped with the pending versions) when the database
+// has not applied every embedded migration. A database ahead of the binary is fine:
+// during a rolling deploy older replicas keep running against the newer schema.
+func (m *Migrator) Check(ctx context.Context) error {
+	todo, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(todo) == 0 {
+		return nil
+	}
+
+	versions := make([]int, len(todo))
+	for i, mig := range todo {
+		versions[i] = mig.Version
+	}
+	return fmt.Errorf("%w: %d pending %v", ErrSchemaBehind, len(todo), versions)
+}
+
+// lock takes the migration advisory lock on a dedicated connection (session locks
+// are per connection) and ensures schema_migrations exists
+func (m *Migrator) lock(ctx context.Context) (*sql.Conn, func(), error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockID); err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("acquire migration lock: %w", err)
+	}
+
+	unlock := func() {
+		conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockID)
+		conn.Close()
+	}
+	if err := m.ensureTable(ctx, conn); err != nil {
+		unlock()
+		return nil, nil, err
+	}
+	return conn, unlock, nil
+}
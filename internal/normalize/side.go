@@ -0,0 +1,90 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Sides of a team-sided outcome (moneyline, spread)
+const (
+	SideHome = "home"
+	SideAway = "away"
+)
+
+// linePattern matches a line some books append to a spread outcome's name:
+// "Lakers -3.5", "Lakers (+3.5)", "Lakers 3.5", "Lakers PK". A bare integer is not
+// taken as a line, so names such as "Philadelphia 76ers" are left alone
+var linePattern = regexp.MustCompile(`(?i)\s+\(?([+-]\d+(?:\.\d+)?|\d+\.\d+|pk|pick|even)\)?$`)
+
+// SplitLine separates a line appended to an outcome name from the team, returning
+// the team and the signed point (nil when the name carries no line)
+func SplitLine(name string) (string, *float64) {
+	match := linePattern.FindStringSubmatchIndex(name)
+	if match == nil {
+		return name, nil
+	}
+
+	token := name[match[2]:match[3]]
+	point := 0.0 // pk, pick and even are a zero line
+	switch strings.ToLower(token) {
+	case "pk", "pick", "even":
+	default:
+		parsed, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return name, nil
+		}
+		point = parsed
+	}
+	return clean(name[:match[0]]), &point
+}
+
+// MatchSide reports which of an event's teams an outcome name refers to: the same
+// name, a trailing part of it ("Lakers", "Trail Blazers"), or the same nickname under
+// another city form ("LA Lakers"). It returns "" for non-team outcomes (Over, Draw)
+// and for names that fit both teams
+func MatchSide(name, homeTeam, awayTeam string) string {
+	outcome, home, away := words(name), words(homeTeam), words(awayTeam)
+	if len(outcome) == 0 {
+		return ""
+	}
+
+	rules := []func(team []string) bool{
+		func(team []string) bool { return equalWords(outcome, team) },
+		func(team []string) bool {
+			return len(team) > len(outcome) && equalWords(outcome, team[len(team)-len(outcome):])
+		},
+		func(team []string) bool {
+			return len(team) > 0 && len(outcome) > 1 && outcome[len(outcome)-1] == team[len(team)-1]
+		},
+	}
+	for _, matches := range rules {
+		isHome, isAway := matches(home), matches(away)
+		switch {
+		case isHome && isAway:
+			return ""
+		case isHome:
+			return SideHome
+		case isAway:
+			return SideAway
+		}
+	}
+	return ""
+}
+
+// words splits a name into lowercase words, dropping punctuation ("St. Louis" -> st louis)
+func words(name string) []string {
+	return strings.Fields(strings.ReplaceAll(Slug(strings.ReplaceAll(name, "-", " ")), "_", " "))
+}
+
+func equalWords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
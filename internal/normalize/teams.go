@@ -0,0 +1,150 @@
+// Package normalize maps vendor and book spellings of team names to one canonical
+// name per sport, so events, outcomes and Talos game keys agree across sources.
+package normalize
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+)
+
+// Registry holds per-sport alias tables (alias -> canonical team name)
+// Lookups ignore case and repeated whitespace. Safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	aliases map[string]map[string]string // sport -> folded alias -> canonical
+}
+
+// NewRegistry creates an empty registry (every name maps to itself)
+func NewRegistry() *Registry {
+	return &Registry{
+		aliases: make(map[string]map[string]string),
+	}
+}
+
+// SetAliases adds a sport's aliases, replacing any existing entry for the same alias
+func (r *Registry) SetAliases(sport string, aliases map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	table, ok := r.aliases[sport]
+	if !ok {
+		table = make(map[string]string, len(aliases))
+		r.aliases[sport] = table
+	}
+	for alias, canonical := range aliases {
+		table[fold(alias)] = clean(canonical)
+	}
+}
+
+// AddAliases adds a sport's aliases that are not already mapped, so derived aliases
+// (e.g. from participant rosters) never override configured ones. Returns how many were added.
+func (r *Registry) AddAliases(sport string, aliases map[string]string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	table, ok := r.aliases[sport]
+	if !ok {
+		table = make(map[string]string, len(aliases))
+		r.aliases[sport] = table
+	}
+
+	added := 0
+	for alias, canonical := range aliases {
+		key := fold(alias)
+		if _, exists := table[key]; exists {
+			continue
+		}
+		table[key] = clean(canonical)
+		added++
+	}
+	return added
+}
+
+// LoadSports adds the alias tables configured on each sport module
+func (r *Registry) LoadSports(sports []contracts.SportModule) {
+	for _, sport := range sports {
+		r.SetAliases(sport.GetSportKey(), sport.GetTeamAliases())
+	}
+}
+
+// LoadDB adds aliases from the team_aliases table, overriding module config for the
+// same alias. Returns how many aliases were loaded.
+func (r *Registry) LoadDB(ctx context.Context, db *sql.DB) (int, error) {
+	rows, err := db.QueryContext(ctx, `SELECT sport_key, alias, canonical FROM team_aliases`)
+	if err != nil {
+		return 0, fmt.Errorf("query team aliases: %w", err)
+	}
+	defer rows.Close()
+
+	bySport := make(map[string]map[string]string)
+	count := 0
+	for rows.Next() {
+		var sport, alias, canonical string
+		if err := rows.Scan(&sport, &alias, &canonical); err != nil {
+			return 0, fmt.Errorf("scan team alias: %w", err)
+		}
+		if bySport[sport] == nil {
+			bySport[sport] = make(map[string]string)
+		}
+		bySport[sport][alias] = canonical
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("read team aliases: %w", err)
+	}
+
+	for sport, aliases := range bySport {
+		r.SetAliases(sport, aliases)
+	}
+	return count, nil
+}
+
+// TeamName returns the canonical name for a sport's team, or the trimmed name when
+// it has no alias. A nil registry only trims.
+func (r *Registry) TeamName(sport, name string) string {
+	if r == nil {
+		return clean(name)
+	}
+
+	r.mu.RLock()
+	canonical, ok := r.aliases[sport][fold(name)]
+	r.mu.RUnlock()
+
+	if ok {
+		return canonical
+	}
+	return clean(name)
+}
+
+// Slug converts a team name to the lowercase underscore form used in Talos game keys
+// ("Los Angeles Lakers" -> "los_angeles_lakers"); other punctuation is dropped
+func Slug(name string) string {
+	var b strings.Builder
+	b.Grow(len(name))
+	for _, c := range name {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			b.WriteRune(c + 'a' - 'A')
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+			b.WriteRune(c)
+		case c == ' ':
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// clean trims and collapses internal whitespace
+func clean(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// fold is the lookup form of a name
+func fold(name string) string {
+	return strings.ToLower(clean(name))
+}
@@ -0,0 +1,73 @@
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Canonical names of totals outcomes (game, team and player totals)
+const (
+	TotalOver  = "Over"
+	TotalUnder = "Under"
+)
+
+// totalsNames maps folded spellings of Over/Under, including abbreviations and the
+// localized labels of European books, to the canonical name
+var totalsNames = map[string]string{
+	"over": TotalOver, "o": TotalOver, "ov": TotalOver,
+	"plus": TotalOver, "plus de": TotalOver, // French
+	"mas": TotalOver, "mas de": TotalOver, // Spanish
+	"piu": TotalOver, "piu di": TotalOver, // Italian
+	"mais": TotalOver, "mais de": TotalOver, // Portuguese
+	"uber": TotalOver, "ueber": TotalOver, // German
+	"boven":   TotalOver, // Dutch
+	"yli":     TotalOver, // Finnish
+	"powyzej": TotalOver, // Polish
+	"ust":     TotalOver, // Turkish
+	"vice":    TotalOver, // Czech
+
+	"under": TotalUnder, "u": TotalUnder, "un": TotalUnder,
+	"moins": TotalUnder, "moins de": TotalUnder, // French
+	"menos": TotalUnder, "menos de": TotalUnder, // Spanish, Portuguese
+	"meno": TotalUnder, "meno di": TotalUnder, // Italian
+	"unter":   TotalUnder, // German
+	"onder":   TotalUnder, // Dutch
+	"alle":    TotalUnder, // Finnish
+	"ponizej": TotalUnder, // Polish
+	"alt":     TotalUnder, // Turkish
+	"mene":    TotalUnder, // Czech
+}
+
+// foldAccents strips the diacritics that appear in localized Over/Under labels
+var foldAccents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ě", "e",
+	"í", "i", "ì", "i", "î", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ż", "z", "ź", "z", "ñ", "n", "ç", "c",
+)
+
+// totalLinePattern matches a total appended to the label ("Over 220.5", "O (220)")
+var totalLinePattern = regexp.MustCompile(`\s*\(?(\d+(?:\.\d+)?)\)?$`)
+
+// TotalsOutcome canonicalizes an Over/Under outcome name however the book spells it
+// ("over", "O", "U 220.5", "Über", "Moins de"). It also returns a total appended to
+// the label (nil when there is none); ok is false for names that are not Over/Under
+func TotalsOutcome(name string) (canonical string, point *float64, ok bool) {
+	label := strings.TrimSpace(name)
+	if match := totalLinePattern.FindStringSubmatchIndex(label); match != nil && match[0] > 0 {
+		if parsed, err := strconv.ParseFloat(label[match[2]:match[3]], 64); err == nil {
+			point = &parsed
+			label = label[:match[0]]
+		}
+	}
+
+	folded := foldAccents.Replace(fold(strings.TrimSuffix(label, ".")))
+	canonical, ok = totalsNames[folded]
+	if !ok {
+		return "", nil, false
+	}
+	return canonical, point, true
+}
@@ -0,0 +1,203 @@
+// Package ohlc rolls odds_raw (and odds_live) up into 1m/5m/1h OHLC candles of price
+// and point per outcome and book in odds_ohlc. Candles keep the shape of line
+// movement for analytics once raw history is pruned.
+package ohlc
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// Intervals are the candle sizes rolled up; each divides an hour
+var Intervals = []time.Duration{time.Minute, 5 * time.Minute, time.Hour}
+
+// chunk is the span rolled up per statement; candles never cross it
+const chunk = time.Hour
+
+// Bucket returns the start of the candle of the given interval that t falls in
+func Bucket(t time.Time, interval time.Duration) time.Time {
+	return timeutil.UTC(t).Truncate(interval)
+}
+
+// Windows splits [from, to) into hour-aligned chunks, the first starting at the
+// hour from falls in
+func Windows(from, to time.Time) [][2]time.Time {
+	var windows [][2]time.Time
+	for start := Bucket(from, chunk); start.Before(to); start = start.Add(chunk) {
+		windows = append(windows, [2]time.Time{start, start.Add(chunk)})
+	}
+	return windows
+}
+
+// Rollup recomputes the candles of one interval starting in [from, to); from and to
+// should be aligned to the interval. Re-running a candle replaces it, so the
+// current one can be rolled up repeatedly. Returns the candles written
+func Rollup(ctx context.Context, db *sql.DB, interval time.Duration, from, to time.Time) (int64, error) {
+	seconds := int64(interval / time.Second)
+
+	selectFrom := func(table string) string {
+		return `SELECT id, event_id, sport_key, market_key, book_key, outcome_name, description, price, point, received_at,
+				to_timestamp(floor(extract(epoch FROM received_at) / $1::int) * $1::int) AS bucket
+			FROM ` + table + ` WHERE received_at >= $2 AND received_at < $3`
+	}
+	res, err := db.ExecContext(ctx, `
+		INSERT INTO odds_ohlc (event_id, sport_key, market_key, book_key, outcome_name, description,
+			interval_seconds, bucket_start,
+			price_open, price_high, price_low, price_close,
+			point_open, point_high, point_low, point_close,
+			changes, updated_at)
+		SELECT event_id, sport_key, market_key, book_key, outcome_name, description,
+		       $1::int, bucket,
+		       (array_agg(price ORDER BY received_at, id))[1], MAX(price), MIN(price),
+		       (array_agg(price ORDER BY received_at DESC, id DESC))[1],
+		       (array_agg(point ORDER BY received_at, id))[1], MAX(point), MIN(point),
+		       (array_agg(point ORDER BY received_at DESC, id DESC))[1],
+		       COUNT(*), NOW()
+		FROM (`+selectFrom("odds_raw")+`
+			UNION ALL
+			`+selectFrom("odds_live")+`) q
+		GROUP BY event_id, sport_key, market_key, book_key, outcome_name, description, bucket
+		ON CONFLICT (event_id, market_key, book_key, outcome_name, description, interval_seconds, bucket_start) DO UPDATE SET
+			price_open = EXCLUDED.price_open,
+			price_high = EXCLUDED.price_high,
+			price_low = EXCLUDED.price_low,
+			price_close = EXCLUDED.price_close,
+			point_open = EXCLUDED.point_open,
+			point_high = EXCLUDED.point_high,
+			point_low = EXCLUDED.point_low,
+			point_close = EXCLUDED.point_close,
+			changes = EXCLUDED.changes,
+			updated_at = NOW()
+	`, seconds, from, to)
+	if err != nil {
+		return 0, fmt.Errorf("roll up %v candles: %w", interval, err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// Latest returns the start of the newest 1m candle (zero when none are stored)
+func Latest(ctx context.Context, db *sql.DB) (time.Time, error) {
+	var latest sql.NullTime
+	err := db.QueryRowContext(ctx, `
+		SELECT MAX(bucket_start) FROM odds_ohlc WHERE interval_seconds = $1
+	`, int64(Intervals[0]/time.Second)).Scan(&latest)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("query latest candle: %w", err)
+	}
+	if !latest.Valid {
+		return time.Time{}, nil
+	}
+	return timeutil.UTC(latest.Time), nil
+}
+
+// Prune deletes superseded raw quotes received before before; the latest quote per
+// outcome and the candles are kept
+func Prune(ctx context.Context, db *sql.DB, before time.Time) (int64, error) {
+	var total int64
+	for _, table := range []string{"odds_raw", "odds_live"} {
+		res, err := db.ExecContext(ctx, `DELETE FROM `+table+` WHERE is_latest = false AND received_at < $1`, before)
+		if err != nil {
+			return total, fmt.Errorf("prune %s: %w", table, err)
+		}
+		n, _ := res.RowsAffected()
+		total += n
+	}
+	return total, nil
+}
+
+// Job rolls up every interval from the last candle to now, then prunes raw history
+type Job struct {
+	db           *sql.DB
+	backfill     time.Duration // How far back to start when odds_ohlc is empty
+	retention    time.Duration // Superseded raw quotes older than this are deleted (0 = keep)
+	pollInterval time.Duration
+	from         time.Time // Start of the hour the next run rolls up from (zero = ask Latest)
+	stopChan     chan struct{}
+}
+
+// NewJob creates an OHLC rollup job
+func NewJob(db *sql.DB, pollInterval, backfill, retention time.Duration) *Job {
+	return &Job{
+		db:           db,
+		backfill:     backfill,
+		retention:    retention,
+		pollInterval: pollInterval,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins periodic rollups
+func (j *Job) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.pollInterval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			j.run(ctx)
+		case <-j.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the job
+func (j *Job) Stop() {
+	close(j.stopChan)
+}
+
+// run rolls up each hour from the last run's (or candle's) hour through now. The
+// current hour is recomputed every run until it is over
+func (j *Job) run(ctx context.Context) {
+	now := timeutil.Now()
+	if j.from.IsZero() {
+		latest, err := Latest(ctx, j.db)
+		if err != nil {
+			fmt.Printf("[OHLC] %v\n", err)
+			return
+		}
+		if latest.IsZero() || latest.Before(now.Add(-j.backfill)) {
+			latest = now.Add(-j.backfill)
+		}
+		j.from = Bucket(latest, chunk)
+	}
+
+	var written int64
+	windows := Windows(j.from, now)
+	for _, window := range windows {
+		for _, interval := range Intervals {
+			n, err := Rollup(ctx, j.db, interval, window[0], window[1])
+			if err != nil {
+				fmt.Printf("[OHLC] %v\n", err)
+				return
+			}
+			written += n
+		}
+		j.from = window[0]
+	}
+	if len(windows) > 1 {
+		fmt.Printf("[OHLC] rolled up %d hour(s) from %s: %d candle(s)\n",
+			len(windows), windows[0][0].Format(time.RFC3339), written)
+	}
+
+	if j.retention > 0 {
+		before := now.Add(-j.retention)
+		if before.After(j.from) {
+			before = j.from // Never prune hours not yet rolled up
+		}
+		if n, err := Prune(ctx, j.db, before); err != nil {
+			fmt.Printf("[OHLC] %v\n", err)
+		} else if n > 0 {
+			fmt.Printf("[OHLC] pruned %d superseded raw quote(s)\n", n)
+		}
+	}
+}
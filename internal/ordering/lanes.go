@@ -0,0 +1,77 @@
+// Package ordering provides keyed ordering lanes so that work for the same key
+// (e.g. one odds outcome) is committed and published in the same order.
+package ordering
+
+import (
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// DefaultLanes is the default number of ordering lanes
+const DefaultLanes = 64
+
+// Lanes partitions keys onto a fixed set of mutex-protected lanes
+//
+// Holding the lanes for a batch from DB commit through stream publish
+// guarantees that, for any single key, messages reach the stream in commit
+// order. Batches touching disjoint lanes still proceed in parallel.
+type Lanes struct {
+	lanes []sync.Mutex
+}
+
+// NewLanes creates n ordering lanes (n <= 0 uses DefaultLanes)
+func NewLanes(n int) *Lanes {
+	if n <= 0 {
+		n = DefaultLanes
+	}
+	return &Lanes{lanes: make([]sync.Mutex, n)}
+}
+
+// Lane returns the lane index for a key
+func (l *Lanes) Lane(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(l.lanes)))
+}
+
+// Acquire locks every lane touched by keys and returns a function that releases them
+// Lanes are locked in ascending index order so concurrent callers cannot deadlock
+func (l *Lanes) Acquire(keys []string) (release func()) {
+	seen := make(map[int]bool, len(keys))
+	indexes := make([]int, 0, len(keys))
+	for _, key := range keys {
+		idx := l.Lane(key)
+		if !seen[idx] {
+			seen[idx] = true
+			indexes = append(indexes, idx)
+		}
+	}
+	sort.Ints(indexes)
+
+	for _, idx := range indexes {
+		l.lanes[idx].Lock()
+	}
+
+	return func() {
+		for i := len(indexes) - 1; i >= 0; i-- {
+			l.lanes[indexes[i]].Unlock()
+		}
+	}
+}
+
+// AcquireOdds locks the lanes for every outcome in a batch of odds
+func (l *Lanes) AcquireOdds(odds []models.RawOdds) (release func()) {
+	keys := make([]string, len(odds))
+	for i, odd := range odds {
+		keys[i] = OutcomeKey(odd)
+	}
+	return l.Acquire(keys)
+}
+
+// OutcomeKey identifies a single outcome: (event, market, book, outcome, description)
+func OutcomeKey(odd models.RawOdds) string {
+	return odd.EventID + "|" + odd.MarketKey + "|" + odd.BookKey + "|" + odd.OutcomeName + "|" + odd.Description
+}
@@ -0,0 +1,406 @@
+// Package outlier compares each book's price to the consensus of the other books
+// quoting the same outcome and flags quotes that stray too far from it: often a
+// stale line the book has not moved yet, or a boosted price. The writer tags
+// flagged deltas on the odds streams, and per-book counts go to Redis.
+package outlier
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/pricebook"
+	"github.com/XavierBriggs/Mercury/internal/reliability"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix = "mercury:outliers:" // Hash per UTC day of "<sport>|<book>|checked" and "<sport>|<book>|outliers"
+	keyTTL    = 8 * 24 * time.Hour  // A week of history
+
+	// DefaultMinBooks is how many other books must quote an outcome for a consensus
+	DefaultMinBooks = 3
+	// DefaultFlushInterval is how often counts are added to Redis
+	DefaultFlushInterval = time.Minute
+
+	// minStdDev floors the spread of the other books' implied probabilities, so a
+	// z-score stays meaningful when they all agree exactly
+	minStdDev = 0.005
+)
+
+// Key returns the Redis hash holding one UTC day's counts
+func Key(day time.Time) string {
+	return keyPrefix + timeutil.UTC(day).Format("2006-01-02")
+}
+
+// Config configures outlier detection. A quote is an outlier when either enabled
+// threshold is reached
+type Config struct {
+	ZScore   float64 // |z| of the quote's implied probability among the other books (0 disables)
+	Cents    int     // Distance from the consensus price in cents (0 disables)
+	MinBooks int     // Other books needed for a consensus (default DefaultMinBooks)
+
+	FlushInterval time.Duration // How often counts are added to Redis (default DefaultFlushInterval)
+}
+
+// Enabled reports whether any threshold is set
+func (c Config) Enabled() bool {
+	return c.ZScore > 0 || c.Cents > 0
+}
+
+// Verdict is one quote compared to its consensus
+type Verdict struct {
+	Consensus int     // Consensus American price: the other books' weighted median (0 = no consensus)
+	Cents     int     // Quote minus consensus in cents, across even money
+	ZScore    float64 // Quote's implied probability in standard deviations from the other books' weighted mean
+	Outlier   bool
+}
+
+// BookOutliers is how often one book's quotes were outliers
+type BookOutliers struct {
+	SportKey string
+	BookKey  string
+	Checked  int64 // Quotes compared to a consensus
+	Outliers int64
+}
+
+// Detector keeps the latest quote per book and checks deltas against the others
+type Detector struct {
+	config  Config
+	redis   *redis.Client
+	weights *reliability.Weights // Optional; nil weighs every book equally
+
+	mu      sync.Mutex
+	prices  *pricebook.Book
+	pending map[string]*BookOutliers // sport|book -> counts since the last flush
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewDetector creates a detector; a nil Redis client keeps counts in memory only
+func NewDetector(config Config, redisClient *redis.Client) *Detector {
+	if config.MinBooks <= 0 {
+		config.MinBooks = DefaultMinBooks
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultFlushInterval
+	}
+	return &Detector{
+		config:   config,
+		redis:    redisClient,
+		prices:   pricebook.New(),
+		pending:  make(map[string]*BookOutliers),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetWeights weighs each book's quote in the consensus by its reliability, so a
+// poorly scored book moves it less. Books weighted 0 are left out
+func (d *Detector) SetWeights(weights *reliability.Weights) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.weights = weights
+}
+
+// Load seeds current prices for upcoming and live events from Alexandria, so the
+// first deltas after startup have a consensus
+func (d *Detector) Load(ctx context.Context, db *sql.DB) error {
+	odds, err := pricebook.LoadCurrent(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	for _, odd := range odds {
+		d.prices.Apply(odd)
+	}
+	d.mu.Unlock()
+
+	fmt.Printf("[Outlier] loaded %d current prices\n", len(odds))
+	return nil
+}
+
+// Check applies a batch of deltas to the price state, then compares each to the
+// other books' latest quotes for the same outcome. Verdicts are in odds order; a
+// nil detector returns nil
+func (d *Detector) Check(odds []models.RawOdds) []Verdict {
+	if d == nil || len(odds) == 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, odd := range odds {
+		d.prices.Apply(odd)
+	}
+
+	verdicts := make([]Verdict, len(odds))
+	for i, odd := range odds {
+		verdict, ok := d.compare(odd)
+		if !ok {
+			continue
+		}
+		verdicts[i] = verdict
+
+		key := odd.SportKey + "|" + odd.BookKey
+		counts := d.pending[key]
+		if counts == nil {
+			counts = &BookOutliers{SportKey: odd.SportKey, BookKey: odd.BookKey}
+			d.pending[key] = counts
+		}
+		counts.Checked++
+		if verdict.Outlier {
+			counts.Outliers++
+		}
+	}
+	return verdicts
+}
+
+// compare builds a quote's verdict; false when too few other books quote it
+func (d *Detector) compare(odd models.RawOdds) (Verdict, bool) {
+	line := d.prices.Line(pricebook.LineKey(odd))
+	if line == nil {
+		return Verdict{}, false
+	}
+	prob := oddsmath.DecimalToImplied(odd.Decimal())
+	if prob == 0 {
+		return Verdict{}, false
+	}
+
+	outcome := pricebook.OutcomeKey(odd)
+	var others []weighted
+	for book, quotes := range line.Quotes {
+		if book == odd.BookKey {
+			continue
+		}
+		weight := d.weights.Of(book)
+		if weight <= 0 {
+			continue
+		}
+		if quote, ok := quotes[outcome]; ok {
+			if p := oddsmath.DecimalToImplied(quote.Decimal()); p > 0 {
+				others = append(others, weighted{p, weight})
+			}
+		}
+	}
+	if len(others) < d.config.MinBooks {
+		return Verdict{}, false
+	}
+
+	consensus := oddsmath.ImpliedToAmerican(median(others))
+	if consensus == 0 {
+		return Verdict{}, false
+	}
+	mean, stdDev := meanStdDev(others)
+	verdict := Verdict{
+		Consensus: consensus,
+		Cents:     oddsmath.PriceDeltaCents(consensus, odd.Price),
+		ZScore:    math.Round((prob-mean)/math.Max(stdDev, minStdDev)*100) / 100,
+	}
+	verdict.Outlier = (d.config.Cents > 0 && abs(verdict.Cents) >= d.config.Cents) ||
+		(d.config.ZScore > 0 && math.Abs(verdict.ZScore) >= d.config.ZScore)
+	return verdict, true
+}
+
+// HandleEventStatusChanged drops prices for events that can no longer be bet
+func (d *Detector) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	switch msg.NewStatus {
+	case "completed", "cancelled", "postponed":
+		d.Evict(msg.EventID)
+	}
+}
+
+// Evict removes all prices held for an event
+func (d *Detector) Evict(eventID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.prices.Evict(eventID)
+}
+
+// Flush adds pending counts to today's hash and logs books with outliers
+func (d *Detector) Flush(ctx context.Context) error {
+	d.mu.Lock()
+	pending := d.pending
+	d.pending = make(map[string]*BookOutliers)
+	d.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	var flagged []string
+	for _, counts := range pending {
+		if counts.Outliers > 0 {
+			flagged = append(flagged, fmt.Sprintf("%s/%s %d/%d", counts.SportKey, counts.BookKey, counts.Outliers, counts.Checked))
+		}
+	}
+	if len(flagged) > 0 {
+		sort.Strings(flagged)
+		fmt.Printf("[Outlier] last %v: %s\n", d.config.FlushInterval, strings.Join(flagged, ", "))
+	}
+
+	if d.redis == nil {
+		return nil
+	}
+	key := Key(timeutil.Now())
+	pipe := d.redis.TxPipeline()
+	for field, counts := range pending {
+		pipe.HIncrBy(ctx, key, field+"|checked", counts.Checked)
+		if counts.Outliers > 0 {
+			pipe.HIncrBy(ctx, key, field+"|outliers", counts.Outliers)
+		}
+	}
+	pipe.Expire(ctx, key, keyTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("record outliers: %w", err)
+	}
+	return nil
+}
+
+// Start begins periodic flushes
+func (d *Detector) Start(ctx context.Context) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		ticker := time.NewTicker(d.config.FlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := d.Flush(ctx); err != nil {
+					fmt.Printf("[Outlier] %v\n", err)
+				}
+			case <-d.stopChan:
+				if err := d.Flush(context.Background()); err != nil {
+					fmt.Printf("[Outlier] %v\n", err)
+				}
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop flushes pending counts and stops the detector
+func (d *Detector) Stop() {
+	close(d.stopChan)
+	d.wg.Wait()
+}
+
+// Read sums the counts of the given UTC days, books with the most outliers first
+func Read(ctx context.Context, redisClient *redis.Client, days ...time.Time) ([]BookOutliers, error) {
+	byBook := make(map[string]*BookOutliers)
+	for _, day := range days {
+		values, err := redisClient.HGetAll(ctx, Key(day)).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read outliers: %w", err)
+		}
+		for field, value := range values {
+			parts := strings.Split(field, "|")
+			if len(parts) != 3 {
+				continue
+			}
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			key := parts[0] + "|" + parts[1]
+			counts := byBook[key]
+			if counts == nil {
+				counts = &BookOutliers{SportKey: parts[0], BookKey: parts[1]}
+				byBook[key] = counts
+			}
+			switch parts[2] {
+			case "checked":
+				counts.Checked += n
+			case "outliers":
+				counts.Outliers += n
+			}
+		}
+	}
+
+	books := make([]BookOutliers, 0, len(byBook))
+	for _, counts := range byBook {
+		books = append(books, *counts)
+	}
+	sort.Slice(books, func(i, j int) bool {
+		if books[i].Outliers != books[j].Outliers {
+			return books[i].Outliers > books[j].Outliers
+		}
+		if books[i].SportKey != books[j].SportKey {
+			return books[i].SportKey < books[j].SportKey
+		}
+		return books[i].BookKey < books[j].BookKey
+	})
+	return books, nil
+}
+
+// weighted is one book's implied probability and consensus weight
+type weighted struct {
+	value  float64
+	weight float64
+}
+
+// median returns the weighted median: the value at which half the total weight is
+// reached (the mean of the two around it when exactly half falls between them)
+func median(values []weighted) float64 {
+	sorted := append([]weighted(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].value < sorted[j].value })
+
+	var total float64
+	for _, v := range sorted {
+		total += v.weight
+	}
+	half := total / 2
+
+	var cumulative float64
+	for i, v := range sorted {
+		cumulative += v.weight
+		if math.Abs(cumulative-half) < 1e-9*total && i+1 < len(sorted) {
+			return (v.value + sorted[i+1].value) / 2
+		}
+		if cumulative > half {
+			return v.value
+		}
+	}
+	return sorted[len(sorted)-1].value
+}
+
+// meanStdDev returns the weighted mean and population standard deviation
+func meanStdDev(values []weighted) (float64, float64) {
+	var sum, total float64
+	for _, v := range values {
+		sum += v.value * v.weight
+		total += v.weight
+	}
+	mean := sum / total
+
+	var squares float64
+	for _, v := range values {
+		squares += v.weight * (v.value - mean) * (v.value - mean)
+	}
+	return mean, math.Sqrt(squares / total)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
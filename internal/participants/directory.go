@@ -0,0 +1,126 @@
+// Package participants keeps the vendor's participant rosters (stable IDs and
+// canonical full names per sport) in Alexandria and in memory. Rosters add nickname
+// aliases to team name normalization and attach team IDs to stream messages.
+package participants
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Directory maps canonical team names to participant IDs, per sport
+// Safe for concurrent use; a nil Directory resolves nothing.
+type Directory struct {
+	mu        sync.RWMutex
+	ids       map[string]map[string]string // sport -> folded full name -> participant ID
+	teamNames *normalize.Registry          // Optional: canonicalizes lookups and receives nicknames
+}
+
+// NewDirectory creates an empty directory
+func NewDirectory() *Directory {
+	return &Directory{
+		ids: make(map[string]map[string]string),
+	}
+}
+
+// SetTeamNames canonicalizes lookups through registry and registers each roster's
+// unambiguous nicknames ("Lakers" -> "Los Angeles Lakers") as aliases there
+// Configured aliases always win over nicknames.
+func (d *Directory) SetTeamNames(registry *normalize.Registry) {
+	d.teamNames = registry
+}
+
+// Set adds a sport's participants, replacing the IDs of any names already held
+func (d *Directory) Set(sport string, participants []models.Participant) {
+	if len(participants) == 0 {
+		return
+	}
+
+	d.mu.Lock()
+	table, ok := d.ids[sport]
+	if !ok {
+		table = make(map[string]string, len(participants))
+		d.ids[sport] = table
+	}
+	for _, p := range participants {
+		table[fold(p.FullName)] = p.ID
+	}
+	d.mu.Unlock()
+
+	if d.teamNames != nil {
+		d.teamNames.AddAliases(sport, Nicknames(participants))
+	}
+}
+
+// ID returns the participant ID for a sport's team name, or "" when unknown
+func (d *Directory) ID(sport, name string) string {
+	if d == nil || name == "" {
+		return ""
+	}
+
+	name = d.teamNames.TeamName(sport, name)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.ids[sport][fold(name)]
+}
+
+// Count returns the number of participants held across sports
+func (d *Directory) Count() int {
+	if d == nil {
+		return 0
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	count := 0
+	for _, table := range d.ids {
+		count += len(table)
+	}
+	return count
+}
+
+// Enrich sets the team IDs on a stream message: the outcome's team (h2h and spreads
+// outcomes name a team) and, when the event is known, its home and away teams
+func (d *Directory) Enrich(msg *models.StreamMessage, event *models.Event) {
+	if d == nil {
+		return
+	}
+
+	msg.TeamID = d.ID(msg.SportKey, msg.OutcomeName)
+	if event != nil {
+		msg.HomeTeamID = d.ID(msg.SportKey, event.HomeTeam)
+		msg.AwayTeamID = d.ID(msg.SportKey, event.AwayTeam)
+	}
+}
+
+// Nicknames maps each participant's last name word to its full name ("Celtics" ->
+// "Boston Celtics"), skipping words shared by more than one participant
+func Nicknames(participants []models.Participant) map[string]string {
+	byWord := make(map[string][]string, len(participants))
+	for _, p := range participants {
+		words := strings.Fields(p.FullName)
+		if len(words) < 2 {
+			continue
+		}
+		word := fold(words[len(words)-1])
+		byWord[word] = append(byWord[word], p.FullName)
+	}
+
+	nicknames := make(map[string]string, len(byWord))
+	for word, names := range byWord {
+		if len(names) == 1 {
+			nicknames[word] = names[0]
+		}
+	}
+	return nicknames
+}
+
+// fold is the lookup form of a name (case and repeated whitespace ignored)
+func fold(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
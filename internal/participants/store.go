@@ -0,0 +1,74 @@
+package participants
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Load adds every participant stored in Alexandria to the directory
+// Returns how many were loaded.
+func (d *Directory) Load(ctx context.Context, db *sql.DB) (int, error) {
+	rows, err := db.QueryContext(ctx, `SELECT sport_key, participant_id, full_name FROM participants`)
+	if err != nil {
+		return 0, fmt.Errorf("query participants: %w", err)
+	}
+	defer rows.Close()
+
+	bySport := make(map[string][]models.Participant)
+	count := 0
+	for rows.Next() {
+		var p models.Participant
+		if err := rows.Scan(&p.SportKey, &p.ID, &p.FullName); err != nil {
+			return 0, fmt.Errorf("scan participant: %w", err)
+		}
+		bySport[p.SportKey] = append(bySport[p.SportKey], p)
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("read participants: %w", err)
+	}
+
+	for sport, participants := range bySport {
+		d.Set(sport, participants)
+	}
+	return count, nil
+}
+
+// Save upserts a roster into Alexandria, refreshing names and last_seen
+func Save(ctx context.Context, db *sql.DB, participants []models.Participant) error {
+	if len(participants) == 0 {
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO participants (sport_key, participant_id, full_name)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (sport_key, participant_id) DO UPDATE SET
+			full_name = EXCLUDED.full_name,
+			last_seen = NOW()
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare participant upsert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, p := range participants {
+		if _, err := stmt.ExecContext(ctx, p.SportKey, p.ID, p.FullName); err != nil {
+			return fmt.Errorf("save participant %s: %w", p.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package participants
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+)
+
+// Syncer periodically fetches each sport's roster, stores it and updates the directory
+// Rosters change a few times a season, so a daily refresh is plenty (1 credit per sport).
+type Syncer struct {
+	adapter      contracts.ParticipantsAdapter
+	db           *sql.DB
+	directory    *Directory
+	sports       *registry.SportRegistry
+	pollInterval time.Duration
+	stopChan     chan struct{}
+}
+
+// NewSyncer creates a new roster syncer
+func NewSyncer(adapter contracts.ParticipantsAdapter, db *sql.DB, directory *Directory, sports *registry.SportRegistry, pollInterval time.Duration) *Syncer {
+	return &Syncer{
+		adapter:      adapter,
+		db:           db,
+		directory:    directory,
+		sports:       sports,
+		pollInterval: pollInterval,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Sync refreshes every registered sport's roster
+// A failed sport is logged and skipped; the last error is returned.
+func (s *Syncer) Sync(ctx context.Context) error {
+	var lastErr error
+	for _, sport := range s.sports.GetAll() {
+		sportKey := sport.GetSportKey()
+
+		participants, err := s.adapter.FetchParticipants(ctx, sportKey)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", sportKey, err)
+			fmt.Printf("[Participants] fetch %s: %v\n", sportKey, err)
+			continue
+		}
+
+		// The directory is updated even when storing fails; the next sync retries the write
+		s.directory.Set(sportKey, participants)
+		if s.db != nil {
+			if err := Save(ctx, s.db, participants); err != nil {
+				lastErr = fmt.Errorf("%s: %w", sportKey, err)
+				fmt.Printf("[Participants] save %s: %v\n", sportKey, err)
+				continue
+			}
+		}
+
+		fmt.Printf("[Participants] %s: %d participant(s)\n", sportKey, len(participants))
+	}
+	return lastErr
+}
+
+// Start syncs immediately, then every poll interval
+func (s *Syncer) Start(ctx context.Context) {
+	s.Sync(ctx)
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.Sync(ctx)
+		case <-s.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the syncer
+func (s *Syncer) Stop() {
+	close(s.stopChan)
+}
@@ -0,0 +1,105 @@
+// Package pgnotify announces odds changes with Postgres NOTIFY, for small deployments
+// whose consumers only need to react to changes and have no Redis:
+//
+//	LISTEN mercury_odds;
+//	-- {"event_id":"...","sport_key":"basketball_nba","market":"spreads","book":"fanduel","outcome":"Boston Celtics","change_type":"price"}
+//
+// Payloads identify the change only; listeners read the price from odds_raw.
+package pgnotify
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
+)
+
+// DefaultChannel is the NOTIFY channel when none is configured
+const DefaultChannel = "mercury_odds"
+
+// validChannel matches channels usable unquoted in LISTEN
+var validChannel = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// Payload is the JSON body of each notification
+type Payload struct {
+	EventID     string `json:"event_id"`
+	SportKey    string `json:"sport_key"`
+	MarketKey   string `json:"market"`
+	BookKey     string `json:"book"`
+	OutcomeName string `json:"outcome"`
+	Description string `json:"description,omitempty"` // Player name for props
+	ChangeType  string `json:"change_type"`
+}
+
+// Notifier publishes odds changes on a NOTIFY channel and implements
+// contracts.StreamSink
+type Notifier struct {
+	db      *sql.DB
+	channel string
+}
+
+// NewNotifier creates a notifier for channel (lowercase identifier)
+func NewNotifier(db *sql.DB, channel string) (*Notifier, error) {
+	if !validChannel.MatchString(channel) {
+		return nil, fmt.Errorf("pgnotify: invalid channel %q (lowercase letters, digits and _)", channel)
+	}
+	return &Notifier{db: db, channel: channel}, nil
+}
+
+// Name identifies the sink in logs
+func (n *Notifier) Name() string {
+	return "pgnotify"
+}
+
+// Publish sends one notification per message in a single statement
+// Postgres delivers them to listeners in order once the statement commits
+func (n *Notifier) Publish(ctx context.Context, messages []models.StreamMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	payloads := make([]string, len(messages))
+	for i, msg := range messages {
+		payload, err := NewPayload(msg)
+		if err != nil {
+			return err
+		}
+		payloads[i] = payload
+	}
+
+	_, err := n.db.ExecContext(ctx, `
+		SELECT pg_notify($1, payload)
+		FROM UNNEST($2::text[]) WITH ORDINALITY AS p(payload, ord)
+		ORDER BY ord
+	`, n.channel, pq.Array(payloads))
+	if err != nil {
+		return fmt.Errorf("notify %s: %w", n.channel, err)
+	}
+	return nil
+}
+
+// Close is a no-op: the notifier shares Mercury's database pool
+func (n *Notifier) Close() error {
+	return nil
+}
+
+// NewPayload encodes the notification for one odds change
+func NewPayload(msg models.StreamMessage) (string, error) {
+	data, err := json.Marshal(Payload{
+		EventID:     msg.EventID,
+		SportKey:    msg.SportKey,
+		MarketKey:   msg.MarketKey,
+		BookKey:     msg.BookKey,
+		OutcomeName: msg.OutcomeName,
+		Description: msg.Description,
+		ChangeType:  msg.ChangeType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal notification: %w", err)
+	}
+	return string(data), nil
+}
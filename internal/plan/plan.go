@@ -0,0 +1,323 @@
+// Package plan estimates the vendor credits a set of sport configs will spend on
+// an expected slate, so polling intervals can be tuned before they burn quota.
+// It mirrors the scheduler's tracks (featured, tipoff, props ramp, discovery and
+// futures) and The Odds API's billing of one credit per region × market.
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+)
+
+// Track identifies the polling loop that spends the credits
+type Track string
+
+const (
+	TrackFeatured  Track = "featured"
+	TrackTipoff    Track = "tipoff"
+	TrackProps     Track = "props"
+	TrackDiscovery Track = "discovery"
+	TrackFutures   Track = "futures"
+)
+
+// day is the period every estimate covers
+const day = 24 * time.Hour
+
+// Slate is the expected daily schedule of a sport
+type Slate struct {
+	Tipoffs []time.Duration // Game start times as offsets from 00:00 UTC, one per game
+}
+
+// Line is the estimated daily spend of one sport/track/tier/market
+type Line struct {
+	SportKey string
+	Track    Track
+	Tier     string  // Cadence or ramp tier, e.g. "T-6h→T-1h30m @10m"
+	Market   string  // Market or futures key (empty for requests without one)
+	Polls    float64 // Requests per day
+	Credits  float64 // Credits per day
+}
+
+// Plan is the estimate for every sport
+type Plan struct {
+	Lines []Line
+}
+
+// Total returns the estimated credits per day
+func (p *Plan) Total() float64 {
+	total := 0.0
+	for _, l := range p.Lines {
+		total += l.Credits
+	}
+	return total
+}
+
+// BySport returns the estimated credits per day of each sport
+func (p *Plan) BySport() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, l := range p.Lines {
+		totals[l.SportKey] += l.Credits
+	}
+	return totals
+}
+
+// ByMarket returns the estimated credits per day of each sport's markets
+// keyed by "sport/market"
+func (p *Plan) ByMarket() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, l := range p.Lines {
+		if l.Market != "" {
+			totals[l.SportKey+"/"+l.Market] += l.Credits
+		}
+	}
+	return totals
+}
+
+// Estimate returns the daily credit estimate of the given sports on a slate
+func Estimate(sports []contracts.SportModule, slate Slate) *Plan {
+	p := &Plan{}
+	for _, sport := range sports {
+		p.Lines = append(p.Lines, featuredLines(sport, slate)...)
+		p.Lines = append(p.Lines, tipoffLines(sport, slate)...)
+		if sport.ShouldPollProps() {
+			p.Lines = append(p.Lines, propsLines(sport, slate)...)
+			p.Lines = append(p.Lines, discoveryLine(sport))
+		}
+		p.Lines = append(p.Lines, futuresLines(sport)...)
+	}
+	return p
+}
+
+// RequestUnits returns how many credits one market costs per request: one per
+// region, or one per BookmakersPerRegion books when bookmakers are requested
+func RequestUnits(sport contracts.SportModule) float64 {
+	if books := len(sport.GetBookmakers()); books > 0 {
+		return float64((books + scheduler.BookmakersPerRegion - 1) / scheduler.BookmakersPerRegion)
+	}
+	return float64(len(sport.GetRegions()))
+}
+
+// featuredLines estimates the slate poll, which runs all day unless the featured
+// window limits it to hours with games inside the window or in play
+func featuredLines(sport contracts.SportModule, slate Slate) []Line {
+	interval := sport.GetFeaturedPollInterval()
+	if interval <= 0 {
+		return nil
+	}
+
+	active := day
+	tier := "@" + formatDuration(interval)
+	if hours := sport.GetFeaturedWindowHours(); hours > 0 {
+		before := time.Duration(hours) * time.Hour
+		active = Coverage(slate.Tipoffs, before, sport.GetTypicalGameDuration())
+		tier = fmt.Sprintf("T-%s→end @%s", formatDuration(before), formatDuration(interval))
+	}
+
+	polls := float64(active) / float64(interval)
+	return marketLines(sport, TrackFeatured, tier, sport.GetFeaturedMarkets(), polls)
+}
+
+// tipoffLines estimates the targeted refreshes of events about to start
+func tipoffLines(sport contracts.SportModule, slate Slate) []Line {
+	window, interval := sport.GetTipoffWindow(), sport.GetTipoffInterval()
+	if window <= 0 || interval <= 0 || len(slate.Tipoffs) == 0 {
+		return nil
+	}
+
+	polls := float64(Coverage(slate.Tipoffs, window, 0)) / float64(interval)
+	tier := fmt.Sprintf("T-%s @%s", formatDuration(window), formatDuration(interval))
+	return marketLines(sport, TrackTipoff, tier, sport.GetFeaturedMarkets(), polls)
+}
+
+// propsLines estimates every game's props poller along the ramp schedule. The
+// vendor bills only markets it returns, so this is an upper bound
+func propsLines(sport contracts.SportModule, slate Slate) []Line {
+	markets := sport.GetPropsMarkets()
+	if len(markets) == 0 || len(slate.Tipoffs) == 0 {
+		return nil
+	}
+
+	var lines []Line
+	for _, tier := range PropsTiers(sport) {
+		polls := tier.Polls * float64(len(slate.Tipoffs))
+		lines = append(lines, marketLines(sport, TrackProps, tier.Label, markets, polls)...)
+	}
+	return lines
+}
+
+// discoveryLine counts the events sweeps, which the vendor does not bill
+func discoveryLine(sport contracts.SportModule) Line {
+	line := Line{SportKey: sport.GetSportKey(), Track: TrackDiscovery}
+	if interval := sport.GetPropsDiscoveryInterval(); interval > 0 {
+		line.Tier = "@" + formatDuration(interval)
+		line.Polls = float64(day) / float64(interval)
+	}
+	return line
+}
+
+// futuresLines estimates the outright polls, one request per futures key
+func futuresLines(sport contracts.SportModule) []Line {
+	interval := sport.GetFuturesPollInterval()
+	if interval <= 0 {
+		return nil
+	}
+
+	polls := float64(day) / float64(interval)
+	return marketLines(sport, TrackFutures, "@"+formatDuration(interval), sport.GetFuturesKeys(), polls)
+}
+
+// marketLines splits a track's polls into one line per market
+func marketLines(sport contracts.SportModule, track Track, tier string, markets []string, polls float64) []Line {
+	units := RequestUnits(sport)
+	lines := make([]Line, 0, len(markets))
+	for _, market := range markets {
+		lines = append(lines, Line{
+			SportKey: sport.GetSportKey(),
+			Track:    track,
+			Tier:     tier,
+			Market:   market,
+			Polls:    polls,
+			Credits:  polls * units,
+		})
+	}
+	return lines
+}
+
+// PropsTier is a stretch of one game's props ramp polled at the same interval
+type PropsTier struct {
+	Label    string
+	Interval time.Duration
+	Polls    float64 // Polls per game
+}
+
+// PropsTiers walks one game's props poller from discovery to the end of the game
+// the way the scheduler does: an initial poll when the event is found (half a
+// discovery sweep inside the window on average), then one poll per ramp interval
+// plus the average jitter until the game is over
+func PropsTiers(sport contracts.SportModule) []PropsTier {
+	window := time.Duration(sport.GetPropsDiscoveryWindowHours()) * time.Hour
+	start := window - sport.GetPropsDiscoveryInterval()/2
+	if start < 0 {
+		start = window
+	}
+	end := -sport.GetTypicalGameDuration()
+	jitter := time.Duration(sport.GetPropsJitterSeconds()) * time.Second / 2
+
+	var tiers []PropsTier
+	var from, previous time.Duration // Start of the current tier and time of the last poll
+	until := start
+	for {
+		hours := until.Hours()
+		interval := sport.GetPropsInterval(hours, hours <= 0)
+		if interval <= 0 {
+			break
+		}
+
+		if len(tiers) == 0 || tiers[len(tiers)-1].Interval != interval {
+			if len(tiers) > 0 {
+				boundary := rampBoundary(sport, until, previous)
+				tiers[len(tiers)-1].Label = tierLabel(from, boundary, tiers[len(tiers)-1].Interval)
+				from = boundary
+			} else {
+				from = until
+			}
+			tiers = append(tiers, PropsTier{Interval: interval})
+		}
+		tiers[len(tiers)-1].Polls++
+
+		previous = until
+		until -= interval + jitter
+		if until < end {
+			break
+		}
+	}
+	if len(tiers) > 0 {
+		tiers[len(tiers)-1].Label = tierLabel(from, end, tiers[len(tiers)-1].Interval)
+	}
+	return tiers
+}
+
+// rampBoundary finds where the ramp interval changes between two polls, to the
+// second, so tiers are labelled with the configured boundary instead of a poll time
+func rampBoundary(sport contracts.SportModule, after, before time.Duration) time.Duration {
+	interval := func(d time.Duration) time.Duration {
+		return sport.GetPropsInterval(d.Hours(), d <= 0)
+	}
+
+	want := interval(after)
+	for before-after > time.Second {
+		mid := after + (before-after)/2
+		if interval(mid) == want {
+			after = mid
+		} else {
+			before = mid
+		}
+	}
+	return after.Round(time.Minute)
+}
+
+// tierLabel describes a stretch of the ramp relative to start (T)
+func tierLabel(from, to time.Duration, interval time.Duration) string {
+	return fmt.Sprintf("%s→%s @%s", relative(from), relative(to), formatDuration(interval))
+}
+
+// relative formats a time to start as T-6h, T or T+3h
+func relative(untilStart time.Duration) string {
+	switch {
+	case untilStart > 0:
+		return "T-" + formatDuration(untilStart)
+	case untilStart < 0:
+		return "T+" + formatDuration(-untilStart)
+	}
+	return "T"
+}
+
+// Coverage returns how much of the day lies within [tipoff-before, tipoff+after]
+// of at least one game; windows wrap around midnight since the slate repeats daily
+func Coverage(tipoffs []time.Duration, before, after time.Duration) time.Duration {
+	if before+after >= day && len(tipoffs) > 0 {
+		return day
+	}
+
+	type span struct{ from, to time.Duration }
+	var spans []span
+	for _, t := range tipoffs {
+		from := ((t-before)%day + day) % day
+		to := from + before + after
+		if to > day {
+			spans = append(spans, span{from, day}, span{0, to - day})
+			continue
+		}
+		spans = append(spans, span{from, to})
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].from < spans[j].from })
+
+	var covered, reach time.Duration
+	for _, s := range spans {
+		if s.from > reach {
+			reach = s.from
+		}
+		if s.to > reach {
+			covered += s.to - reach
+			reach = s.to
+		}
+	}
+	return covered
+}
+
+// formatDuration prints a duration without zero units (90m -> 1h30m, 1h -> 1h)
+func formatDuration(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
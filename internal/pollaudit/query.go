@@ -0,0 +1,85 @@
+package pollaudit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
+)
+
+// Filter selects audit rows (zero fields match everything)
+type Filter struct {
+	SportKey   string
+	Track      string
+	EventID    string
+	PollID     string
+	Since      time.Time
+	FailedOnly bool // Only polls that returned an error
+	Limit      int  // Newest rows first (0 = 100)
+}
+
+// Recent returns the audit rows matching filter, newest first
+func Recent(ctx context.Context, db *sql.DB, filter Filter) ([]models.PollAudit, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT sport_key, track, event_id, regions, markets, requests, status_code, bytes,
+		       events, odds, deltas, credits, error_kind, error, duration_ms, started_at, COALESCE(poll_id, '')
+		FROM poll_audit
+		WHERE ($1 = '' OR sport_key = $1)
+		  AND ($2 = '' OR track = $2)
+		  AND ($3 = '' OR event_id = $3)
+		  AND started_at >= $4
+		  AND (NOT $5 OR error_kind <> '')
+		  AND ($7 = '' OR poll_id = $7)
+		ORDER BY started_at DESC
+		LIMIT $6
+	`, filter.SportKey, filter.Track, filter.EventID, filter.Since, filter.FailedOnly, limit, filter.PollID)
+	if err != nil {
+		return nil, fmt.Errorf("query poll audit: %w", err)
+	}
+	defer rows.Close()
+
+	var audits []models.PollAudit
+	for rows.Next() {
+		var audit models.PollAudit
+		var statusCode, credits sql.NullInt64
+		var durationMillis float64
+		err := rows.Scan(&audit.SportKey, &audit.Track, &audit.EventID,
+			pq.Array(&audit.Regions), pq.Array(&audit.Markets), &audit.Requests, &statusCode, &audit.Bytes,
+			&audit.Events, &audit.Odds, &audit.Deltas, &credits, &audit.ErrorKind, &audit.Error,
+			&durationMillis, &audit.StartedAt, &audit.PollID)
+		if err != nil {
+			return nil, fmt.Errorf("scan poll audit: %w", err)
+		}
+		audit.StatusCode = int(statusCode.Int64)
+		audit.Credits = -1
+		if credits.Valid {
+			audit.Credits = int(credits.Int64)
+		}
+		audit.Duration = time.Duration(durationMillis * float64(time.Millisecond))
+		audit.StartedAt = timeutil.UTC(audit.StartedAt)
+		audits = append(audits, audit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read poll audit: %w", err)
+	}
+	return audits, nil
+}
+
+// Prune deletes audit rows older than before
+func Prune(ctx context.Context, db *sql.DB, before time.Time) (int64, error) {
+	res, err := db.ExecContext(ctx, `DELETE FROM poll_audit WHERE started_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune poll audit: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
@@ -0,0 +1,211 @@
+// Package pollaudit persists one compact row per poll (what was requested, what the
+// vendor returned and what the pipeline made of it) so operators can reconstruct
+// what each poll did without scraping logs.
+package pollaudit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultQueueSize = 1024
+	maxBatch         = 100
+	insertTimeout    = 10 * time.Second
+	pruneInterval    = time.Hour
+)
+
+// Ensure Recorder implements PollAuditSink
+var _ contracts.PollAuditSink = (*Recorder)(nil)
+
+// Recorder writes audit rows to the poll_audit table in the background, so polls
+// never wait on Postgres; rows are dropped (and counted) when the queue is full
+type Recorder struct {
+	db        *sql.DB
+	retention time.Duration // Rows older than this are deleted hourly (0 = keep)
+	queue     chan models.PollAudit
+	written   atomic.Int64
+	dropped   atomic.Int64
+	failed    atomic.Int64
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewRecorder creates a poll audit recorder writing to db
+func NewRecorder(db *sql.DB) *Recorder {
+	return &Recorder{
+		db:       db,
+		queue:    make(chan models.PollAudit, defaultQueueSize),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetRetention deletes rows older than retention every hour (0 keeps them)
+func (r *Recorder) SetRetention(retention time.Duration) {
+	r.retention = retention
+}
+
+// RecordPoll queues one poll's row without blocking
+func (r *Recorder) RecordPoll(audit models.PollAudit) {
+	select {
+	case r.queue <- audit:
+	default:
+		if r.dropped.Add(1) == 1 {
+			fmt.Println("[PollAudit] queue full, dropping rows")
+		}
+	}
+}
+
+// Start begins writing queued rows. Rows still queued when ctx is cancelled or
+// Stop is called are written before the recorder exits
+func (r *Recorder) Start(ctx context.Context) {
+	// Inserts outlive ctx (each bounded by insertTimeout) so shutdown keeps the last polls
+	writeCtx := context.WithoutCancel(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(pruneInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case audit := <-r.queue:
+				r.write(writeCtx, r.batch(audit))
+			case <-ticker.C:
+				r.prune(ctx)
+			case <-r.stopChan:
+				r.drain(writeCtx)
+				return
+			case <-ctx.Done():
+				r.drain(writeCtx)
+				return
+			}
+		}
+	}()
+}
+
+// Stop writes anything still queued and stops the recorder
+func (r *Recorder) Stop() {
+	close(r.stopChan)
+	r.wg.Wait()
+
+	fmt.Printf("[PollAudit] stopped (%d written, %d failed, %d dropped)\n",
+		r.written.Load(), r.failed.Load(), r.dropped.Load())
+}
+
+// batch collects first plus whatever else is already queued, up to maxBatch
+func (r *Recorder) batch(first models.PollAudit) []models.PollAudit {
+	rows := []models.PollAudit{first}
+	for len(rows) < maxBatch {
+		select {
+		case audit := <-r.queue:
+			rows = append(rows, audit)
+		default:
+			return rows
+		}
+	}
+	return rows
+}
+
+// drain writes rows queued before shutdown
+func (r *Recorder) drain(ctx context.Context) {
+	for {
+		select {
+		case audit := <-r.queue:
+			r.write(ctx, r.batch(audit))
+		default:
+			return
+		}
+	}
+}
+
+// write inserts one batch
+func (r *Recorder) write(ctx context.Context, rows []models.PollAudit) {
+	insertCtx, cancel := context.WithTimeout(ctx, insertTimeout)
+	defer cancel()
+
+	if err := r.insert(insertCtx, rows); err != nil {
+		r.failed.Add(int64(len(rows)))
+		fmt.Printf("[PollAudit] insert error: %v\n", err)
+		return
+	}
+	r.written.Add(int64(len(rows)))
+}
+
+func (r *Recorder) insert(ctx context.Context, rows []models.PollAudit) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO poll_audit (
+			sport_key, track, event_id, regions, markets, requests, status_code, bytes,
+			events, odds, deltas, credits, error_kind, error, duration_ms, started_at, poll_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, audit := range rows {
+		_, err := stmt.ExecContext(ctx,
+			audit.SportKey,
+			audit.Track,
+			audit.EventID,
+			pq.Array(nonNil(audit.Regions)),
+			pq.Array(nonNil(audit.Markets)),
+			audit.Requests,
+			sql.NullInt64{Int64: int64(audit.StatusCode), Valid: audit.StatusCode > 0},
+			audit.Bytes,
+			audit.Events,
+			audit.Odds,
+			audit.Deltas,
+			sql.NullInt64{Int64: int64(audit.Credits), Valid: audit.Credits >= 0},
+			audit.ErrorKind,
+			audit.Error,
+			float64(audit.Duration)/float64(time.Millisecond),
+			audit.StartedAt,
+			sql.NullString{String: audit.PollID, Valid: audit.PollID != ""},
+		)
+		if err != nil {
+			return fmt.Errorf("insert poll audit: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// prune deletes rows past the retention
+func (r *Recorder) prune(ctx context.Context) {
+	if r.retention <= 0 {
+		return
+	}
+	n, err := Prune(ctx, r.db, timeutil.Now().Add(-r.retention))
+	if err != nil {
+		fmt.Printf("[PollAudit] %v\n", err)
+	} else if n > 0 {
+		fmt.Printf("[PollAudit] pruned %d row(s)\n", n)
+	}
+}
+
+// nonNil maps a nil slice to an empty one (the array columns are NOT NULL)
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
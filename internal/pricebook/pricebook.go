@@ -0,0 +1,164 @@
+// Package pricebook holds the latest quote per book and outcome for every line,
+// built from the delta path, for in-process engines (edge, arbitrage) that compare
+// prices across books.
+package pricebook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Line holds the latest quotes for one proposition (event, market, player/team, line)
+type Line struct {
+	Quotes map[string]map[string]models.RawOdds // book_key -> outcome key -> odds
+}
+
+// Outcomes returns the number of outcomes a complete quote of the line has
+// (the most quoted by any single book)
+func (l *Line) Outcomes() int {
+	outcomes := 0
+	for _, quotes := range l.Quotes {
+		if len(quotes) > outcomes {
+			outcomes = len(quotes)
+		}
+	}
+	return outcomes
+}
+
+// Book is the latest-price state. It is not safe for concurrent use; callers lock.
+type Book struct {
+	lines      map[string]*Line
+	eventLines map[string][]string // event_id -> line keys (for eviction)
+}
+
+// New creates an empty price book
+func New() *Book {
+	return &Book{
+		lines:      make(map[string]*Line),
+		eventLines: make(map[string][]string),
+	}
+}
+
+// Apply stores an odd and returns its line key
+func (b *Book) Apply(odd models.RawOdds) string {
+	key := LineKey(odd)
+
+	l, ok := b.lines[key]
+	if !ok {
+		l = &Line{Quotes: make(map[string]map[string]models.RawOdds)}
+		b.lines[key] = l
+		b.eventLines[odd.EventID] = append(b.eventLines[odd.EventID], key)
+	}
+
+	quotes, ok := l.Quotes[odd.BookKey]
+	if !ok {
+		quotes = make(map[string]models.RawOdds)
+		l.Quotes[odd.BookKey] = quotes
+	}
+	quotes[OutcomeKey(odd)] = odd
+
+	return key
+}
+
+// Line returns a line by key (nil if unknown)
+func (b *Book) Line(key string) *Line {
+	return b.lines[key]
+}
+
+// Range calls fn for every line
+func (b *Book) Range(fn func(key string, l *Line)) {
+	for key, l := range b.lines {
+		fn(key, l)
+	}
+}
+
+// Evict removes all lines for an event
+func (b *Book) Evict(eventID string) {
+	for _, key := range b.eventLines[eventID] {
+		delete(b.lines, key)
+	}
+	delete(b.eventLines, eventID)
+}
+
+// LineKey groups quotes that price the same proposition; it is prefixed by "<event_id>|"
+// Spreads are keyed by the absolute line so both sides (-3.5 / +3.5) share a line
+func LineKey(odd models.RawOdds) string {
+	point := ""
+	if odd.Point != nil {
+		p := *odd.Point
+		if IsSpreadMarket(odd.MarketKey) {
+			p = math.Abs(p)
+		}
+		point = strconv.FormatFloat(p, 'f', -1, 64)
+	}
+	return odd.EventID + "|" + odd.MarketKey + "|" + odd.Description + "|" + point
+}
+
+// OutcomeKey identifies an outcome within a line (signed point for spreads)
+func OutcomeKey(odd models.RawOdds) string {
+	if odd.Point != nil {
+		return odd.OutcomeName + "|" + strconv.FormatFloat(*odd.Point, 'f', -1, 64)
+	}
+	return odd.OutcomeName
+}
+
+// IsSpreadMarket reports whether a market's outcomes carry opposite-signed points
+func IsSpreadMarket(marketKey string) bool {
+	return strings.HasPrefix(marketKey, "spreads") || strings.HasPrefix(marketKey, "alternate_spreads")
+}
+
+// LoadCurrent reads the latest odds for upcoming and live events from Alexandria
+// Deltas only carry changes, so engines seed from this to see lines that have not
+// moved since startup. In-play odds may be in odds_live, so an outcome's newest
+// latest row across both tables wins
+func LoadCurrent(ctx context.Context, db *sql.DB) ([]models.RawOdds, error) {
+	latest := func(table string) string {
+		return `SELECT event_id, sport_key, market_key, book_key, outcome_name, description,
+		       price, price_decimal, point, deep_link, vendor_last_update, received_at
+			FROM ` + table + ` WHERE is_latest = true`
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT DISTINCT ON (o.event_id, o.market_key, o.book_key, o.outcome_name, o.description)
+		       o.event_id, o.sport_key, o.market_key, o.book_key, o.outcome_name, o.description,
+		       o.price, o.price_decimal, o.point, o.deep_link, o.vendor_last_update, o.received_at
+		FROM (`+latest("odds_raw")+`
+			UNION ALL
+			`+latest("odds_live")+`) o
+		JOIN events ev ON ev.event_id = o.event_id
+		WHERE ev.event_status IN ('upcoming', 'live')
+		ORDER BY o.event_id, o.market_key, o.book_key, o.outcome_name, o.description, o.received_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query current odds: %w", err)
+	}
+	defer rows.Close()
+
+	var odds []models.RawOdds
+	for rows.Next() {
+		var odd models.RawOdds
+		var priceDecimal, point sql.NullFloat64
+		var deepLink sql.NullString
+		if err := rows.Scan(&odd.EventID, &odd.SportKey, &odd.MarketKey, &odd.BookKey, &odd.OutcomeName,
+			&odd.Description, &odd.Price, &priceDecimal, &point, &deepLink, &odd.VendorLastUpdate, &odd.ReceivedAt); err != nil {
+			return nil, fmt.Errorf("scan odds: %w", err)
+		}
+		odd.DecimalPrice = priceDecimal.Float64
+		if point.Valid {
+			odd.Point = &point.Float64
+		}
+		odd.DeepLink = deepLink.String
+		odds = append(odds, odd)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return odds, nil
+}
@@ -0,0 +1,103 @@
+// Package propsschedule persists each event's props polling schedule in the
+// props_schedule table, so a restarted scheduler resumes every poller where it was on
+// the ramp instead of polling every discovered event at once.
+package propsschedule
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Ensure Store implements PropsScheduleStore
+var _ contracts.PropsScheduleStore = (*Store)(nil)
+
+// Store reads and writes props_schedule
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore creates a store on db
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// Load returns a sport's saved schedules, soonest event first
+func (s *Store) Load(ctx context.Context, sportKey string) ([]models.PropsPoller, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT event_id, sport_key, home_team, away_team, commence_time, started_at,
+			polls, last_poll_at, next_poll_at, ramp_interval_ms
+		FROM props_schedule
+		WHERE sport_key = $1
+		ORDER BY commence_time, event_id
+	`, sportKey)
+	if err != nil {
+		return nil, fmt.Errorf("query props schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var pollers []models.PropsPoller
+	for rows.Next() {
+		var p models.PropsPoller
+		var lastPoll, nextPoll sql.NullTime
+		var rampMs float64
+		if err := rows.Scan(&p.EventID, &p.SportKey, &p.HomeTeam, &p.AwayTeam, &p.CommenceTime,
+			&p.StartedAt, &p.Polls, &lastPoll, &nextPoll, &rampMs); err != nil {
+			return nil, fmt.Errorf("scan props schedule: %w", err)
+		}
+		p.CommenceTime = timeutil.UTC(p.CommenceTime)
+		p.StartedAt = timeutil.UTC(p.StartedAt)
+		if lastPoll.Valid {
+			p.LastPollAt = timeutil.UTC(lastPoll.Time)
+		}
+		if nextPoll.Valid {
+			p.NextPollAt = timeutil.UTC(nextPoll.Time)
+		}
+		p.RampInterval = time.Duration(rampMs * float64(time.Millisecond))
+		pollers = append(pollers, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read props schedules: %w", err)
+	}
+	return pollers, nil
+}
+
+// Save inserts or replaces an event's schedule
+func (s *Store) Save(ctx context.Context, p models.PropsPoller) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO props_schedule (
+			event_id, sport_key, home_team, away_team, commence_time, started_at,
+			polls, last_poll_at, next_poll_at, ramp_interval_ms, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			commence_time = EXCLUDED.commence_time,
+			polls = EXCLUDED.polls,
+			last_poll_at = EXCLUDED.last_poll_at,
+			next_poll_at = EXCLUDED.next_poll_at,
+			ramp_interval_ms = EXCLUDED.ramp_interval_ms,
+			updated_at = NOW()
+	`, p.EventID, p.SportKey, p.HomeTeam, p.AwayTeam, p.CommenceTime, p.StartedAt, p.Polls,
+		nullTime(p.LastPollAt), nullTime(p.NextPollAt), float64(p.RampInterval)/float64(time.Millisecond))
+	if err != nil {
+		return fmt.Errorf("save props schedule: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes an event's schedule
+func (s *Store) Remove(ctx context.Context, eventID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM props_schedule WHERE event_id = $1`, eventID); err != nil {
+		return fmt.Errorf("remove props schedule: %w", err)
+	}
+	return nil
+}
+
+// nullTime stores a zero time as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
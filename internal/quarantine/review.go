@@ -0,0 +1,173 @@
+package quarantine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bounds on listing quarantined records
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+)
+
+// ErrNotFound is returned when reviewing a record that does not exist
+var ErrNotFound = errors.New("quarantined record not found")
+
+// Record is a stored quarantined record with its review state
+type Record struct {
+	ID            int64      `json:"id"`
+	Vendor        string     `json:"vendor"`
+	Kind          string     `json:"kind"`
+	SportKey      string     `json:"sport_key"`
+	EventID       string     `json:"event_id"`
+	BookKey       string     `json:"book_key"`
+	MarketKey     string     `json:"market_key"`
+	Reason        string     `json:"reason"`
+	Detail        string     `json:"detail"`
+	ReceivedAt    time.Time  `json:"received_at"`
+	QuarantinedAt time.Time  `json:"quarantined_at"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote    string     `json:"review_note,omitempty"`
+}
+
+// Filter selects quarantined records; empty fields match everything
+type Filter struct {
+	SportKey  string
+	BookKey   string
+	MarketKey string
+	Reason    string
+	Reviewed  *bool     // nil = both, false = awaiting review
+	Since     time.Time // Received at or after (zero = no bound)
+	Limit     int       // Default DefaultListLimit, capped at MaxListLimit
+}
+
+// where renders the filter as a WHERE clause and its arguments
+func (f Filter) where() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.SportKey != "" {
+		add("sport_key = $%d", f.SportKey)
+	}
+	if f.BookKey != "" {
+		add("book_key = $%d", f.BookKey)
+	}
+	if f.MarketKey != "" {
+		add("market_key = $%d", f.MarketKey)
+	}
+	if f.Reason != "" {
+		add("reason = $%d", f.Reason)
+	}
+	if !f.Since.IsZero() {
+		add("received_at >= $%d", f.Since)
+	}
+	if f.Reviewed != nil {
+		if *f.Reviewed {
+			conds = append(conds, "reviewed_at IS NOT NULL")
+		} else {
+			conds = append(conds, "reviewed_at IS NULL")
+		}
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// List returns matching records, newest first
+func List(ctx context.Context, db *sql.DB, f Filter) ([]Record, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	if limit > MaxListLimit {
+		limit = MaxListLimit
+	}
+
+	where, args := f.where()
+	args = append(args, limit)
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, vendor, kind, sport_key, event_id, book_key, market_key, reason, detail,
+		       received_at, quarantined_at, reviewed_at, COALESCE(review_note, '')
+		FROM odds_quarantine`+where+fmt.Sprintf(`
+		ORDER BY received_at DESC, id DESC
+		LIMIT $%d`, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query quarantine: %w", err)
+	}
+	defer rows.Close()
+
+	records := []Record{}
+	for rows.Next() {
+		var r Record
+		var reviewedAt sql.NullTime
+		if err := rows.Scan(&r.ID, &r.Vendor, &r.Kind, &r.SportKey, &r.EventID, &r.BookKey, &r.MarketKey,
+			&r.Reason, &r.Detail, &r.ReceivedAt, &r.QuarantinedAt, &reviewedAt, &r.ReviewNote); err != nil {
+			return nil, fmt.Errorf("scan quarantine: %w", err)
+		}
+		if reviewedAt.Valid {
+			t := reviewedAt.Time.UTC()
+			r.ReviewedAt = &t
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// ReasonCount is the number of matching records per sport and reason
+type ReasonCount struct {
+	SportKey   string    `json:"sport_key"`
+	Reason     string    `json:"reason"`
+	Count      int64     `json:"count"`
+	Unreviewed int64     `json:"unreviewed"`
+	LastSeen   time.Time `json:"last_seen"`
+}
+
+// SummarizeStored counts matching records by sport and reason, largest first (Limit is ignored)
+func SummarizeStored(ctx context.Context, db *sql.DB, f Filter) ([]ReasonCount, error) {
+	where, args := f.where()
+	rows, err := db.QueryContext(ctx, `
+		SELECT sport_key, reason, COUNT(*), COUNT(*) FILTER (WHERE reviewed_at IS NULL), MAX(received_at)
+		FROM odds_quarantine`+where+`
+		GROUP BY sport_key, reason
+		ORDER BY COUNT(*) DESC, sport_key, reason`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("summarize quarantine: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []ReasonCount{}
+	for rows.Next() {
+		var c ReasonCount
+		if err := rows.Scan(&c.SportKey, &c.Reason, &c.Count, &c.Unreviewed, &c.LastSeen); err != nil {
+			return nil, fmt.Errorf("scan quarantine summary: %w", err)
+		}
+		c.LastSeen = c.LastSeen.UTC()
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// MarkReviewed records that a person looked at a record, with an optional note
+func MarkReviewed(ctx context.Context, db *sql.DB, id int64, note string) error {
+	result, err := db.ExecContext(ctx, `
+		UPDATE odds_quarantine SET reviewed_at = NOW(), review_note = NULLIF($2, '')
+		WHERE id = $1`, id, clean(note))
+	if err != nil {
+		return fmt.Errorf("mark reviewed: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
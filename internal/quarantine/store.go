@@ -0,0 +1,175 @@
+package quarantine
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const (
+	defaultQueueSize = 256
+	insertTimeout    = 10 * time.Second
+)
+
+// Ensure Store implements QuarantineSink
+var _ contracts.QuarantineSink = (*Store)(nil)
+
+// Store persists records rejected by adapter parsers to the odds_quarantine table.
+// Inserts run in the background so parsing never waits on Postgres; batches are
+// dropped (and counted) when the queue is full.
+type Store struct {
+	db       *sql.DB
+	queue    chan []models.QuarantinedRecord
+	written  atomic.Int64
+	dropped  atomic.Int64
+	failed   atomic.Int64
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewStore creates a quarantine store writing to db
+func NewStore(db *sql.DB) *Store {
+	return &Store{
+		db:       db,
+		queue:    make(chan []models.QuarantinedRecord, defaultQueueSize),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Quarantine logs a batch of rejected records and queues it for insert without blocking
+func (s *Store) Quarantine(records []models.QuarantinedRecord) {
+	if len(records) == 0 {
+		return
+	}
+
+	fmt.Printf("[Quarantine] %s %s: %d record(s) rejected (%s)\n",
+		records[0].Vendor, records[0].SportKey, len(records), Summarize(records))
+
+	select {
+	case s.queue <- records:
+	default:
+		if s.dropped.Add(int64(len(records))) == int64(len(records)) {
+			fmt.Println("[Quarantine] queue full, dropping records")
+		}
+	}
+}
+
+// Start begins inserting queued records
+func (s *Store) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			select {
+			case records := <-s.queue:
+				s.write(ctx, records)
+			case <-s.stopChan:
+				s.drain(ctx)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Println("[Quarantine] started")
+}
+
+// Stop inserts anything still queued and stops the store
+func (s *Store) Stop() {
+	close(s.stopChan)
+	s.wg.Wait()
+
+	fmt.Printf("[Quarantine] stopped (%d written, %d failed, %d dropped)\n",
+		s.written.Load(), s.failed.Load(), s.dropped.Load())
+}
+
+// drain writes batches queued before Stop
+func (s *Store) drain(ctx context.Context) {
+	for {
+		select {
+		case records := <-s.queue:
+			s.write(ctx, records)
+		default:
+			return
+		}
+	}
+}
+
+// write inserts one batch in a single transaction
+func (s *Store) write(ctx context.Context, records []models.QuarantinedRecord) {
+	insertCtx, cancel := context.WithTimeout(ctx, insertTimeout)
+	defer cancel()
+
+	if err := s.insert(insertCtx, records); err != nil {
+		s.failed.Add(int64(len(records)))
+		fmt.Printf("[Quarantine] insert error: %v\n", err)
+		return
+	}
+	s.written.Add(int64(len(records)))
+}
+
+func (s *Store) insert(ctx context.Context, records []models.QuarantinedRecord) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO odds_quarantine (
+			vendor, kind, sport_key, event_id, book_key, market_key, reason, detail, received_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, rec := range records {
+		_, err := stmt.ExecContext(ctx,
+			rec.Vendor,
+			string(rec.Kind),
+			clean(rec.SportKey),
+			clean(rec.EventID),
+			clean(rec.BookKey),
+			clean(rec.MarketKey),
+			string(rec.Reason),
+			clean(rec.Detail),
+			rec.ReceivedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insert record: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// clean strips NUL bytes, which Postgres rejects in text columns
+func clean(value string) string {
+	return strings.ReplaceAll(value, "\x00", "")
+}
+
+// Summarize counts records by reason, e.g. "bad_price=2, missing_field=1"
+func Summarize(records []models.QuarantinedRecord) string {
+	counts := make(map[models.QuarantineReason]int)
+	for _, rec := range records {
+		counts[rec.Reason]++
+	}
+
+	parts := make([]string, 0, len(counts))
+	for reason, n := range counts {
+		parts = append(parts, fmt.Sprintf("%s=%d", reason, n))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
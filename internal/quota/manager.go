@@ -0,0 +1,217 @@
+// Package quota decides how polling cadence should degrade when the vendor
+// request quota runs low, cutting the least important sports first.
+package quota
+
+import (
+	"sort"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const (
+	defaultSoftReserve = 200 // Start degrading below this many remaining requests
+	defaultHardReserve = 25  // Fully degraded at or below this many remaining requests
+)
+
+// RateLimitSource provides current vendor quota information (satisfied by VendorAdapter)
+type RateLimitSource interface {
+	GetRateLimits() *models.RateLimits
+}
+
+// Config holds quota degradation thresholds
+type Config struct {
+	SoftReserve int // Remaining requests at which degradation begins
+	HardReserve int // Remaining requests at which every sport is fully degraded
+}
+
+// Policy describes how far a sport may be degraded and in what order
+type Policy struct {
+	SportKey string
+	Priority int // Lower value = more important (degraded last)
+
+	// Normal cadence
+	FeaturedInterval time.Duration
+	PropsInterval    time.Duration
+	PollsProps       bool
+
+	// Slowest cadence allowed under pressure (0 props cadence = props may be paused)
+	MinFeaturedCadence time.Duration
+	MinPropsCadence    time.Duration
+}
+
+// Degradation is the effective cadence for a sport under the current quota pressure
+type Degradation struct {
+	FeaturedInterval time.Duration
+	PropsInterval    time.Duration
+	PropsPaused      bool
+	FeaturedDegraded bool
+	PropsDegraded    bool
+}
+
+// Manager evaluates quota pressure and produces per-sport degradations
+type Manager struct {
+	limits        RateLimitSource
+	sportRegistry *registry.SportRegistry
+	config        Config
+}
+
+// NewManager creates a new quota manager
+func NewManager(limits RateLimitSource, sportRegistry *registry.SportRegistry, cfg Config) *Manager {
+	if cfg.SoftReserve <= 0 {
+		cfg.SoftReserve = defaultSoftReserve
+	}
+	if cfg.HardReserve < 0 || cfg.HardReserve >= cfg.SoftReserve {
+		cfg.HardReserve = defaultHardReserve
+		if cfg.HardReserve >= cfg.SoftReserve {
+			cfg.HardReserve = 0
+		}
+	}
+
+	return &Manager{
+		limits:        limits,
+		sportRegistry: sportRegistry,
+		config:        cfg,
+	}
+}
+
+// Pressure returns quota pressure from 0 (healthy) to 1 (fully degraded)
+func (m *Manager) Pressure() float64 {
+	limits := m.limits.GetRateLimits()
+	if limits == nil {
+		return 0
+	}
+
+	return CalculatePressure(limits.RequestsRemaining, m.config.SoftReserve, m.config.HardReserve)
+}
+
+// Policies builds degradation policies from the registered sport modules
+func (m *Manager) Policies() []Policy {
+	sports := m.sportRegistry.GetAll()
+	policies := make([]Policy, 0, len(sports))
+
+	for _, sport := range sports {
+		policies = append(policies, Policy{
+			SportKey:           sport.GetSportKey(),
+			Priority:           sport.GetQuotaPriority(),
+			FeaturedInterval:   sport.GetFeaturedPollInterval(),
+			PropsInterval:      sport.GetPropsPollInterval(),
+			PollsProps:         sport.ShouldPollProps(),
+			MinFeaturedCadence: sport.GetMinFeaturedCadence(),
+			MinPropsCadence:    sport.GetMinPropsCadence(),
+		})
+	}
+
+	return policies
+}
+
+// Plan returns the degradation for every registered sport at the current pressure
+func (m *Manager) Plan() map[string]Degradation {
+	return Degrade(m.Policies(), m.Pressure())
+}
+
+// ForSport returns the degradation for a single sport at the current pressure
+func (m *Manager) ForSport(sportKey string) (Degradation, bool) {
+	deg, ok := m.Plan()[sportKey]
+	return deg, ok
+}
+
+// CalculatePressure maps remaining requests onto a 0-1 pressure scale
+func CalculatePressure(remaining, softReserve, hardReserve int) float64 {
+	if remaining >= softReserve {
+		return 0
+	}
+	if remaining <= hardReserve {
+		return 1
+	}
+	return float64(softReserve-remaining) / float64(softReserve-hardReserve)
+}
+
+// Degrade applies cuts in priority order for the given pressure
+//
+// Cuts are ordered so that props of the least important sport go first,
+// then props of progressively more important sports, and only then featured
+// markets, again least important sport first. Each cut moves that market
+// family to its minimum cadence (or pauses props if no minimum is set).
+func Degrade(policies []Policy, pressure float64) map[string]Degradation {
+	result := make(map[string]Degradation, len(policies))
+	for _, p := range policies {
+		result[p.SportKey] = Degradation{
+			FeaturedInterval: p.FeaturedInterval,
+			PropsInterval:    p.PropsInterval,
+		}
+	}
+
+	if pressure <= 0 || len(policies) == 0 {
+		return result
+	}
+	if pressure > 1 {
+		pressure = 1
+	}
+
+	// Least important first; tie-break on sport key for stable ordering
+	ordered := make([]Policy, len(policies))
+	copy(ordered, policies)
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority > ordered[j].Priority
+		}
+		return ordered[i].SportKey < ordered[j].SportKey
+	})
+
+	type cut struct {
+		sportKey string
+		props    bool
+	}
+
+	var cuts []cut
+	for _, p := range ordered {
+		if p.PollsProps {
+			cuts = append(cuts, cut{sportKey: p.SportKey, props: true})
+		}
+	}
+	for _, p := range ordered {
+		if p.MinFeaturedCadence > p.FeaturedInterval {
+			cuts = append(cuts, cut{sportKey: p.SportKey, props: false})
+		}
+	}
+
+	if len(cuts) == 0 {
+		return result
+	}
+
+	// Number of cuts scales with pressure (any pressure makes at least one cut)
+	numCuts := int(pressure*float64(len(cuts)) + 0.999999)
+	if numCuts > len(cuts) {
+		numCuts = len(cuts)
+	}
+
+	policyByKey := make(map[string]Policy, len(policies))
+	for _, p := range policies {
+		policyByKey[p.SportKey] = p
+	}
+
+	for _, c := range cuts[:numCuts] {
+		p := policyByKey[c.sportKey]
+		deg := result[c.sportKey]
+
+		if c.props {
+			deg.PropsDegraded = true
+			if p.MinPropsCadence > 0 {
+				if p.MinPropsCadence > deg.PropsInterval {
+					deg.PropsInterval = p.MinPropsCadence
+				}
+			} else {
+				deg.PropsPaused = true
+			}
+		} else {
+			deg.FeaturedDegraded = true
+			deg.FeaturedInterval = p.MinFeaturedCadence
+		}
+
+		result[c.sportKey] = deg
+	}
+
+	return result
+}
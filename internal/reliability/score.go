@@ -0,0 +1,85 @@
+// Package reliability scores sportsbooks on observed data quality so downstream
+// consensus/edge engines can weight books dynamically instead of by static
+// sharp/soft labels.
+package reliability
+
+import (
+	"math"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+)
+
+// Component weights for the overall score (sum to 1)
+const (
+	stalenessWeight = 0.25
+	coverageWeight  = 0.25
+	anomalyWeight   = 0.20
+	agreementWeight = 0.30
+)
+
+const (
+	// staleReferenceSeconds is the vendor-update lag at which the staleness component falls to ~37%
+	staleReferenceSeconds = 120.0
+
+	// maxAnomalyRate is the anomalous-move rate at which the anomaly component reaches 0
+	maxAnomalyRate = 0.05
+
+	// maxClosingDeviation is the mean implied-probability deviation from the closing
+	// consensus at which the agreement component reaches 0
+	maxClosingDeviation = 0.05
+)
+
+// Signals are the raw data-quality observations for one book over the lookback window
+type Signals struct {
+	BookKey          string
+	Rows             int     // Odds rows observed
+	StalenessSeconds float64 // Mean lag between vendor_last_update and received_at
+	Coverage         float64 // Fraction of events in the window the book quoted (0-1)
+	AnomalyCount     int     // Implausibly large single-update price jumps
+	ClosingDeviation float64 // Mean |implied prob - closing consensus implied prob|
+	ClosingSamples   int     // Closing lines compared (0 = no agreement signal)
+}
+
+// Score combines signals into a reliability score in [0, 1]
+// Components without data are left out and the remaining weights renormalized
+func Score(s Signals) float64 {
+	if s.Rows == 0 {
+		return 0
+	}
+
+	total := 0.0
+	weight := 0.0
+
+	total += stalenessWeight * math.Exp(-math.Max(s.StalenessSeconds, 0)/staleReferenceSeconds)
+	weight += stalenessWeight
+
+	total += coverageWeight * clamp(s.Coverage)
+	weight += coverageWeight
+
+	anomalyRate := float64(s.AnomalyCount) / float64(s.Rows)
+	total += anomalyWeight * clamp(1-anomalyRate/maxAnomalyRate)
+	weight += anomalyWeight
+
+	if s.ClosingSamples > 0 {
+		total += agreementWeight * clamp(1-s.ClosingDeviation/maxClosingDeviation)
+		weight += agreementWeight
+	}
+
+	return total / weight
+}
+
+// Weight returns the consensus weight for a book
+// Scored books use their reliability score; unscored books fall back to the
+// classification's default weight so new books are not ignored before enough
+// data accumulates
+func Weight(bookType string, score *float64) float64 {
+	if score != nil {
+		return clamp(*score)
+	}
+	return books.DefaultWeight(books.Class(bookType))
+}
+
+// clamp limits v to [0, 1]
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -0,0 +1,248 @@
+package reliability
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// anomalyJump is the implied-probability change in a single update treated as anomalous
+const anomalyJump = 0.15
+
+// impliedProb converts an American price column to implied probability in SQL
+const impliedProb = `CASE WHEN %[1]s > 0 THEN 100.0 / (%[1]s + 100) ELSE -%[1]s / (-%[1]s + 100.0) END`
+
+// Scorer periodically recomputes per-book reliability from Alexandria and stores
+// the score on the books table (with history in book_reliability)
+type Scorer struct {
+	db           *sql.DB
+	pollInterval time.Duration
+	lookback     time.Duration
+	weights      *Weights // Optional; reloaded after every run
+	stopChan     chan struct{}
+}
+
+// NewScorer creates a new book reliability scorer
+func NewScorer(db *sql.DB, pollInterval, lookback time.Duration) *Scorer {
+	return &Scorer{
+		db:           db,
+		pollInterval: pollInterval,
+		lookback:     lookback,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// SetWeights makes every run reload weights, so the engines reading them follow
+// the new scores
+func (s *Scorer) SetWeights(weights *Weights) {
+	s.weights = weights
+}
+
+// Start begins periodic scoring
+func (s *Scorer) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	fmt.Println("✓ Book reliability scorer started")
+
+	if err := s.Refresh(ctx); err != nil {
+		fmt.Printf("[Reliability] initial refresh error: %v\n", err)
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				fmt.Printf("[Reliability] refresh error: %v\n", err)
+			}
+		case <-s.stopChan:
+			fmt.Println("✓ Book reliability scorer stopped")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the scorer
+func (s *Scorer) Stop() {
+	close(s.stopChan)
+}
+
+// Refresh recomputes signals and scores for every book seen in the lookback window
+func (s *Scorer) Refresh(ctx context.Context) error {
+	signals, err := s.collectSignals(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(signals) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, sig := range signals {
+		score := Score(sig)
+
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE books
+			SET reliability_score = $2, reliability_updated_at = NOW(), updated_at = NOW()
+			WHERE book_key = $1
+		`, sig.BookKey, score); err != nil {
+			return fmt.Errorf("update book %s: %w", sig.BookKey, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO book_reliability (
+				book_key, computed_at, rows_observed, staleness_seconds, coverage,
+				anomaly_count, closing_deviation, closing_samples, score
+			) VALUES ($1, NOW(), $2, $3, $4, $5, $6, $7, $8)
+		`, sig.BookKey, sig.Rows, sig.StalenessSeconds, sig.Coverage,
+			sig.AnomalyCount, sig.ClosingDeviation, sig.ClosingSamples, score); err != nil {
+			return fmt.Errorf("record reliability for %s: %w", sig.BookKey, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	fmt.Printf("[Reliability] scored %d book(s)\n", len(signals))
+
+	if s.weights != nil {
+		if err := s.weights.Load(ctx, s.db); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectSignals gathers per-book data-quality signals over the lookback window
+func (s *Scorer) collectSignals(ctx context.Context) ([]Signals, error) {
+	query := fmt.Sprintf(`
+		WITH recent AS (
+			SELECT event_id, market_key, book_key, outcome_name, description, price,
+			       received_at, vendor_last_update,
+			       LAG(price) OVER (
+			           PARTITION BY event_id, market_key, book_key, outcome_name, description
+			           ORDER BY received_at
+			       ) AS prev_price
+			FROM (
+				SELECT event_id, market_key, book_key, outcome_name, description, price,
+				       received_at, vendor_last_update
+				FROM odds_raw
+				WHERE received_at > NOW() - make_interval(secs => $1)
+				UNION ALL
+				SELECT event_id, market_key, book_key, outcome_name, description, price,
+				       received_at, vendor_last_update
+				FROM odds_live
+				WHERE received_at > NOW() - make_interval(secs => $1)
+			) quotes
+		),
+		totals AS (
+			SELECT COUNT(DISTINCT event_id)::float8 AS events FROM recent
+		),
+		per_book AS (
+			SELECT book_key,
+			       COUNT(*) AS rows_observed,
+			       AVG(GREATEST(EXTRACT(EPOCH FROM received_at - vendor_last_update), 0)) AS staleness,
+			       COUNT(DISTINCT event_id) AS events,
+			       COUNT(*) FILTER (
+			           WHERE prev_price IS NOT NULL
+			             AND ABS((%[1]s) - (%[2]s)) > $2
+			       ) AS anomalies
+			FROM recent
+			GROUP BY book_key
+		),
+		closing AS (
+			SELECT book_key,
+			       ABS((%[3]s) - AVG(%[3]s) OVER (
+			           PARTITION BY event_id, market_key, outcome_name, description, point
+			       )) AS deviation
+			FROM closing_lines
+			WHERE closed_at > NOW() - make_interval(secs => $1)
+		),
+		per_book_closing AS (
+			SELECT book_key, AVG(deviation) AS deviation, COUNT(*) AS samples
+			FROM closing
+			GROUP BY book_key
+		)
+		SELECT p.book_key, p.rows_observed, COALESCE(p.staleness, 0),
+		       CASE WHEN t.events > 0 THEN p.events / t.events ELSE 0 END,
+		       p.anomalies, COALESCE(c.deviation, 0), COALESCE(c.samples, 0)
+		FROM per_book p
+		CROSS JOIN totals t
+		LEFT JOIN per_book_closing c ON c.book_key = p.book_key
+	`,
+		fmt.Sprintf(impliedProb, "price"),
+		fmt.Sprintf(impliedProb, "prev_price"),
+		fmt.Sprintf(impliedProb, "closing_price"),
+	)
+
+	rows, err := s.db.QueryContext(ctx, query, s.lookback.Seconds(), anomalyJump)
+	if err != nil {
+		return nil, fmt.Errorf("query reliability signals: %w", err)
+	}
+	defer rows.Close()
+
+	var signals []Signals
+	for rows.Next() {
+		var sig Signals
+		if err := rows.Scan(&sig.BookKey, &sig.Rows, &sig.StalenessSeconds, &sig.Coverage,
+			&sig.AnomalyCount, &sig.ClosingDeviation, &sig.ClosingSamples); err != nil {
+			return nil, fmt.Errorf("scan reliability signals: %w", err)
+		}
+		signals = append(signals, sig)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return signals, nil
+}
+
+// LoadWeights returns the consensus weight for every active book, keyed by book_key
+// Consumers use this in place of static sharp/soft weights. Unscored books use
+// their default_weight (set through the admin API) before the class default
+func LoadWeights(ctx context.Context, db *sql.DB) (map[string]float64, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT book_key, book_type, reliability_score, default_weight
+		FROM books
+		WHERE active = true
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("query book weights: %w", err)
+	}
+	defer rows.Close()
+
+	weights := make(map[string]float64)
+	for rows.Next() {
+		var bookKey, bookType string
+		var score, defaultWeight sql.NullFloat64
+		if err := rows.Scan(&bookKey, &bookType, &score, &defaultWeight); err != nil {
+			return nil, fmt.Errorf("scan book weight: %w", err)
+		}
+
+		switch {
+		case score.Valid:
+			weights[bookKey] = Weight(bookType, &score.Float64)
+		case defaultWeight.Valid:
+			weights[bookKey] = clamp(defaultWeight.Float64)
+		default:
+			weights[bookKey] = Weight(bookType, nil)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return weights, nil
+}
@@ -0,0 +1,61 @@
+package reliability
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+)
+
+// Weights holds every book's consensus weight (see LoadWeights) for the outlier and
+// edge engines. The scorer reloads it after each run, so engines follow scores as
+// they change. Methods are safe on a nil *Weights, which weighs every book equally
+type Weights struct {
+	mu     sync.RWMutex
+	byBook map[string]float64
+}
+
+// NewWeights creates weights from a book_key -> weight map
+func NewWeights(byBook map[string]float64) *Weights {
+	w := &Weights{}
+	w.Set(byBook)
+	return w
+}
+
+// Set replaces every book's weight
+func (w *Weights) Set(byBook map[string]float64) {
+	weights := make(map[string]float64, len(byBook))
+	for book, weight := range byBook {
+		weights[book] = clamp(weight)
+	}
+
+	w.mu.Lock()
+	w.byBook = weights
+	w.mu.Unlock()
+}
+
+// Load replaces the weights with LoadWeights
+func (w *Weights) Load(ctx context.Context, db *sql.DB) error {
+	byBook, err := LoadWeights(ctx, db)
+	if err != nil {
+		return err
+	}
+	w.Set(byBook)
+	return nil
+}
+
+// Of returns a book's weight in [0, 1]. Books not loaded yet get the soft class
+// default; a nil *Weights returns 1 for every book
+func (w *Weights) Of(bookKey string) float64 {
+	if w == nil {
+		return 1
+	}
+
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if weight, ok := w.byBook[bookKey]; ok {
+		return weight
+	}
+	return books.DefaultWeight(books.ClassSoft)
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// auditKey carries a poll's audit in its context
+type auditKey struct{}
+
+// pollIDKey carries a poll's correlation ID in its context
+type pollIDKey struct{}
+
+// newPollID returns a random poll correlation ID (16 hex characters)
+func newPollID() string {
+	var b [8]byte
+	rand.Read(b[:]) // Never fails on supported platforms
+	return hex.EncodeToString(b[:])
+}
+
+// pollIDFrom returns the correlation ID of the poll running on ctx, or ""
+func pollIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(pollIDKey{}).(string)
+	return id
+}
+
+// pollAudit builds one poll's audit row. The adapter adds its responses to stats;
+// the pipeline adds its counts through recordPoll
+type pollAudit struct {
+	row   models.PollAudit
+	stats contracts.RequestStats
+}
+
+// SetPollAuditSink records one audit row per poll (what was requested, the vendor's
+// status, bytes and credits, and the events, odds and deltas that came of it)
+func (s *Scheduler) SetPollAuditSink(sink contracts.PollAuditSink) {
+	s.auditSink = sink
+}
+
+// startAudit gives one poll its correlation ID and attaches an audit for it to ctx.
+// Without a sink only the ID is attached and the audit is nil (every pollAudit
+// method accepts nil)
+func (s *Scheduler) startAudit(ctx context.Context, sportKey, track string) (context.Context, *pollAudit) {
+	pollID := newPollID()
+	ctx = context.WithValue(ctx, pollIDKey{}, pollID)
+	if s.auditSink == nil {
+		return ctx, nil
+	}
+
+	audit := &pollAudit{row: models.PollAudit{
+		PollID:    pollID,
+		SportKey:  sportKey,
+		Track:     track,
+		StartedAt: s.clock.Now().UTC(),
+	}}
+	ctx = contracts.WithRequestStats(ctx, &audit.stats)
+	return context.WithValue(ctx, auditKey{}, audit), audit
+}
+
+// auditFrom returns the audit attached to ctx, or nil
+func auditFrom(ctx context.Context) *pollAudit {
+	audit, _ := ctx.Value(auditKey{}).(*pollAudit)
+	return audit
+}
+
+// request notes what the poll asked the vendor for
+func (a *pollAudit) request(eventID string, regions, markets []string) {
+	if a == nil {
+		return
+	}
+	a.row.EventID = eventID
+	a.row.Regions = regions
+	a.row.Markets = markets
+}
+
+// observe notes what the pipeline made of the response
+func (a *pollAudit) observe(stats health.PollStats) {
+	if a == nil {
+		return
+	}
+	a.row.Events = stats.Events
+	a.row.Odds = stats.Odds
+	a.row.Deltas = stats.Deltas
+}
+
+// finishAudit completes a poll's row and hands it to the sink. A poll that neither
+// sent a request nor failed (another instance owns the sport, no markets were due)
+// leaves no row
+func (s *Scheduler) finishAudit(audit *pollAudit, err error) {
+	if audit == nil {
+		return
+	}
+
+	totals := audit.stats.Totals()
+	if totals.Requests == 0 && err == nil {
+		return
+	}
+
+	row := audit.row
+	row.Requests = totals.Requests
+	row.StatusCode = totals.StatusCode
+	row.Bytes = totals.Bytes
+	row.Credits = totals.Credits
+	if totals.Requests == 0 {
+		row.Credits = -1
+	}
+	if err != nil {
+		row.ErrorKind = merrors.Label(err)
+		row.Error = err.Error()
+	}
+	row.Duration = s.clock.Since(row.StartedAt)
+	s.auditSink.RecordPoll(row)
+}
@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MarketBatching polls each featured market at its own cadence and packs the markets
+// due on a poll into as few vendor requests as the per-request market limit allows
+type MarketBatching struct {
+	MaxMarketsPerRequest int                      // Vendor limit on markets per request (0 = no limit)
+	Cadence              map[string]time.Duration // Per-market interval; unlisted markets are fetched on every featured poll
+}
+
+// SetMarketBatching configures per-market cadences and the per-request market limit
+func (s *Scheduler) SetMarketBatching(batching MarketBatching) {
+	s.batching = batching
+	s.marketPlanner = NewMarketPlanner(batching)
+}
+
+// ParseMarketCadence parses "totals=2m,h2h=1m" into per-market intervals
+func ParseMarketCadence(s string) (map[string]time.Duration, error) {
+	cadence := make(map[string]time.Duration)
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		market, value, ok := strings.Cut(entry, "=")
+		market = strings.TrimSpace(market)
+		if !ok || market == "" {
+			return nil, fmt.Errorf("invalid entry %q (want market=interval)", entry)
+		}
+		interval, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("invalid interval for %s: %q", market, value)
+		}
+		cadence[market] = interval
+	}
+	return cadence, nil
+}
+
+// RequestCount returns how many requests a number of markets needs under a
+// per-request limit (0 = no limit)
+func RequestCount(markets, limit int) int {
+	if markets == 0 {
+		return 0
+	}
+	if limit <= 0 {
+		return 1
+	}
+	return (markets + limit - 1) / limit
+}
+
+// MarketPlanner tracks when each sport's featured markets were last fetched and
+// picks the markets each featured poll requests
+type MarketPlanner struct {
+	batching MarketBatching
+
+	mu      sync.Mutex
+	fetched map[string]time.Time // Last successful fetch by sport|market
+}
+
+// NewMarketPlanner creates a planner; every market is due until it is first fetched
+func NewMarketPlanner(batching MarketBatching) *MarketPlanner {
+	return &MarketPlanner{
+		batching: batching,
+		fetched:  make(map[string]time.Time),
+	}
+}
+
+// upcomingMarket is a market that falls due before the next poll
+type upcomingMarket struct {
+	market string
+	dueIn  time.Duration
+}
+
+// Plan returns the markets a featured poll at now should request, in configured
+// order (nil = nothing is due, skip the poll). interval is the featured interval in
+// effect and stretch (>= 1) how far quota pressure has slowed it; market cadences
+// stretch by the same factor. When the due markets leave room in their last
+// request, markets falling due by the next poll are pulled forward into it so that
+// poll needs fewer requests; pullForward is false under quota pressure, because
+// the vendor bills every market requested.
+func (p *MarketPlanner) Plan(sportKey string, markets []string, now time.Time, interval time.Duration, stretch float64, pullForward bool) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if stretch < 1 {
+		stretch = 1
+	}
+	// Tickers drift; a market due within half a poll is fetched now rather than a poll late
+	tolerance := interval / 2
+
+	selected := make(map[string]bool, len(markets))
+	var upcoming []upcomingMarket
+	for _, market := range markets {
+		cadence := time.Duration(float64(p.batching.Cadence[market]) * stretch)
+		last, ok := p.fetched[sportKey+"|"+market]
+		if !ok || cadence <= interval {
+			selected[market] = true
+			continue
+		}
+
+		dueIn := cadence - now.Sub(last)
+		switch {
+		case dueIn <= tolerance:
+			selected[market] = true
+		case dueIn <= interval+tolerance:
+			upcoming = append(upcoming, upcomingMarket{market: market, dueIn: dueIn})
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	if limit := p.batching.MaxMarketsPerRequest; pullForward && limit > 0 {
+		spare := RequestCount(len(selected), limit)*limit - len(selected)
+		sort.SliceStable(upcoming, func(i, j int) bool { return upcoming[i].dueIn < upcoming[j].dueIn })
+		for i := 0; i < spare && i < len(upcoming); i++ {
+			selected[upcoming[i].market] = true
+		}
+	}
+
+	planned := make([]string, 0, len(selected))
+	for _, market := range markets {
+		if selected[market] {
+			planned = append(planned, market)
+			delete(selected, market) // Listed twice: request once
+		}
+	}
+	return planned
+}
+
+// Fetched records a successful fetch of a sport's markets
+func (p *MarketPlanner) Fetched(sportKey string, markets []string, at time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, market := range markets {
+		p.fetched[sportKey+"|"+market] = at
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+)
+
+const (
+	// rateLimitPause holds vendor requests after a 429 without a Retry-After
+	rateLimitPause = 30 * time.Second
+
+	// quotaPause holds vendor requests after the vendor reports the quota used up.
+	// Rejected requests cost no credits, so this only bounds log and error noise
+	quotaPause = 10 * time.Minute
+)
+
+// vendorCircuit stops every poller from sending requests the vendor has said it
+// will refuse: after a rate limit until its Retry-After, after an exhausted quota
+// for quotaPause. Other failures leave it closed; the adapter retries those
+type vendorCircuit struct {
+	mu    sync.Mutex
+	until time.Time
+	cause error // Kind that opened the circuit
+}
+
+// Allow returns nil when requests may be sent, otherwise a classified error with the
+// kind that opened the circuit
+func (c *vendorCircuit) Allow(now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Before(c.until) {
+		return merrors.Wrap(c.cause, "vendor paused", fmt.Errorf("%w until %s", c.cause, c.until.UTC().Format(time.RFC3339)))
+	}
+	return nil
+}
+
+// Record opens the circuit if err is a rate limit or exhausted quota. It returns the
+// new end of the pause when it was opened or extended (zero otherwise)
+func (c *vendorCircuit) Record(err error, now time.Time) time.Time {
+	var pause time.Duration
+	switch merrors.KindOf(err) {
+	case merrors.ErrRateLimited:
+		if pause = merrors.RetryAfter(err); pause <= 0 {
+			pause = rateLimitPause
+		}
+	case merrors.ErrQuotaExhausted:
+		pause = quotaPause
+	default:
+		return time.Time{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	until := now.Add(pause)
+	if !until.After(c.until) {
+		return time.Time{}
+	}
+	c.until = until
+	c.cause = merrors.KindOf(err)
+	return until
+}
+
+// vendorAllowed returns the circuit's error while vendor requests are paused
+func (s *Scheduler) vendorAllowed() error {
+	return s.circuit.Allow(s.clock.Now())
+}
+
+// observeVendor opens the circuit when a vendor request was rate limited or refused
+// for quota, and reports the pause to health so it shows in mercury top
+func (s *Scheduler) observeVendor(ctx context.Context, err error) {
+	now := s.clock.Now()
+	until := s.circuit.Record(err, now)
+	if until.IsZero() {
+		return
+	}
+
+	label := merrors.Label(err)
+	fmt.Printf("[Scheduler] vendor %s, pausing requests for %v\n", label, until.Sub(now).Round(time.Second))
+	if s.health != nil {
+		if err := s.health.RecordVendorPause(ctx, label, until); err != nil {
+			fmt.Printf("health report error: %v\n", err)
+		}
+	}
+}
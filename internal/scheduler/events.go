@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// DefaultEventsCacheTTL is how long a sport's fetched events answer later lookups
+const DefaultEventsCacheTTL = time.Minute
+
+// cachedEvents is one sport's last events fetch
+type cachedEvents struct {
+	events    []models.Event
+	from, to  time.Time // Commence window requested (zero = unbounded)
+	fetchedAt time.Time
+}
+
+// EventsCache keeps each sport's last FetchEvents response for a short TTL, so repeated
+// event lookups (discovery, props scheduling) within it do not spend vendor requests
+type EventsCache struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	sports map[string]cachedEvents // sport_key -> last fetch
+}
+
+// NewEventsCache creates a cache (ttl <= 0 uses DefaultEventsCacheTTL)
+func NewEventsCache(ttl time.Duration) *EventsCache {
+	if ttl <= 0 {
+		ttl = DefaultEventsCacheTTL
+	}
+	return &EventsCache{ttl: ttl, sports: make(map[string]cachedEvents)}
+}
+
+// Get returns the cached events of opts' sport within opts' commence window, if they
+// were fetched less than the TTL ago for a window covering it. A window ending up to
+// the TTL after the cached one still counts: its later events start too far ahead to
+// matter before the next fetch
+func (c *EventsCache) Get(opts *models.FetchEventsOptions, now time.Time) ([]models.Event, bool) {
+	c.mu.Lock()
+	cached, ok := c.sports[opts.Sport]
+	c.mu.Unlock()
+
+	if !ok || now.Sub(cached.fetchedAt) >= c.ttl {
+		return nil, false
+	}
+	if !cached.from.IsZero() && (opts.CommenceTimeFrom.IsZero() || opts.CommenceTimeFrom.Before(cached.from)) {
+		return nil, false
+	}
+	if !cached.to.IsZero() && (opts.CommenceTimeTo.IsZero() || opts.CommenceTimeTo.After(cached.to.Add(c.ttl))) {
+		return nil, false
+	}
+
+	events := make([]models.Event, 0, len(cached.events))
+	for _, evt := range cached.events {
+		if models.InCommenceWindow(evt.CommenceTime, opts.CommenceTimeFrom, opts.CommenceTimeTo) {
+			events = append(events, evt)
+		}
+	}
+	return events, true
+}
+
+// Put records a sport's fetched events
+func (c *EventsCache) Put(opts *models.FetchEventsOptions, events []models.Event, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sports[opts.Sport] = cachedEvents{
+		events:    events,
+		from:      opts.CommenceTimeFrom,
+		to:        opts.CommenceTimeTo,
+		fetchedAt: now,
+	}
+}
+
+// SetEventsCache answers repeated event lookups for a sport from cache within its TTL
+func (s *Scheduler) SetEventsCache(cache *EventsCache) {
+	s.eventsCache = cache
+}
+
+// fetchEvents returns a sport's events from the cache when it holds them, else from
+// the vendor (caching successful responses)
+func (s *Scheduler) fetchEvents(ctx context.Context, opts *models.FetchEventsOptions) ([]models.Event, error) {
+	if s.eventsCache != nil {
+		if events, ok := s.eventsCache.Get(opts, s.clock.Now()); ok {
+			return events, nil
+		}
+	}
+
+	if err := s.vendorAllowed(); err != nil {
+		return nil, err
+	}
+	events, err := s.adapter.FetchEvents(ctx, opts)
+	s.observeVendor(ctx, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.eventsCache != nil {
+		s.eventsCache.Put(opts, events, s.clock.Now())
+	}
+	return events, nil
+}
@@ -0,0 +1,261 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// FetchSplit fans a featured poll out into several concurrent vendor requests.
+// The Odds API bills per region (or group of BookmakersPerRegion books) × market
+// either way, so splitting costs no extra quota; it trades one slow wide response
+// for several smaller ones in parallel.
+type FetchSplit struct {
+	Parallelism       int // Max concurrent requests per poll (<= 1 keeps one combined request)
+	MarketsPerRequest int // Markets per request when splitting (0 = every market in each request)
+}
+
+// SetFetchSplit configures concurrent per-region/per-market-group featured fetches
+func (s *Scheduler) SetFetchSplit(split FetchSplit) {
+	s.fetchSplit = split
+}
+
+// BookmakersPerRegion is how many requested bookmakers the vendor bills as one region
+const BookmakersPerRegion = 10
+
+// SplitFetch breaks a fetch into one request per region (or bookmaker group) and market group
+func SplitFetch(opts *models.FetchOddsOptions, marketsPerRequest int) []*models.FetchOddsOptions {
+	if len(opts.Bookmakers) > 0 {
+		return splitByBookmakers(opts, marketGroups(opts.Markets, marketsPerRequest))
+	}
+
+	regions := opts.Regions
+	if len(regions) == 0 {
+		regions = []string{""}
+	}
+	groups := marketGroups(opts.Markets, marketsPerRequest)
+
+	parts := make([]*models.FetchOddsOptions, 0, len(regions)*len(groups))
+	for _, region := range regions {
+		for _, markets := range groups {
+			part := *opts // Keeps the commence window and event IDs
+			part.Markets = markets
+			part.Regions = nil
+			if region != "" {
+				part.Regions = []string{region}
+			}
+			parts = append(parts, &part)
+		}
+	}
+	return parts
+}
+
+// splitByBookmakers issues one request per billing group of bookmakers and market group
+func splitByBookmakers(opts *models.FetchOddsOptions, groups [][]string) []*models.FetchOddsOptions {
+	var parts []*models.FetchOddsOptions
+	for i := 0; i < len(opts.Bookmakers); i += BookmakersPerRegion {
+		end := i + BookmakersPerRegion
+		if end > len(opts.Bookmakers) {
+			end = len(opts.Bookmakers)
+		}
+		for _, markets := range groups {
+			part := *opts
+			part.Markets = markets
+			part.Regions = nil
+			part.Bookmakers = opts.Bookmakers[i:end]
+			parts = append(parts, &part)
+		}
+	}
+	return parts
+}
+
+// marketGroups chunks markets into groups of marketsPerRequest (<= 0 keeps one group)
+func marketGroups(markets []string, marketsPerRequest int) [][]string {
+	if marketsPerRequest <= 0 || marketsPerRequest >= len(markets) {
+		return [][]string{markets}
+	}
+
+	var groups [][]string
+	for i := 0; i < len(markets); i += marketsPerRequest {
+		end := i + marketsPerRequest
+		if end > len(markets) {
+			end = len(markets)
+		}
+		groups = append(groups, markets[i:end])
+	}
+	return groups
+}
+
+// MergeResults combines split fetch results: events are deduplicated by ID, an
+// outcome returned by more than one request is kept once, and bookmaker stamps and
+// validators are unioned. The merge is NotModified only when every request was
+func MergeResults(results []*models.FetchResult) *models.FetchResult {
+	merged := &models.FetchResult{}
+	seenEvents := make(map[string]bool)
+	seenOdds := make(map[string]bool)
+	answered, modified := 0, false
+
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		if !result.NotModified {
+			modified = true
+		}
+		answered++
+		for _, evt := range result.Events {
+			if !seenEvents[evt.EventID] {
+				seenEvents[evt.EventID] = true
+				merged.Events = append(merged.Events, evt)
+			}
+		}
+		for _, odd := range result.Odds {
+			key := odd.DedupeKey()
+			if !seenOdds[key] {
+				seenOdds[key] = true
+				merged.Odds = append(merged.Odds, odd)
+			}
+		}
+		for key, stamp := range result.BookStamps {
+			if merged.BookStamps == nil {
+				merged.BookStamps = make(map[string]time.Time)
+			}
+			merged.BookStamps[key] = stamp
+		}
+		for request, v := range result.Validators {
+			if merged.Validators == nil {
+				merged.Validators = make(map[string]models.Validators)
+			}
+			merged.Validators[request] = v
+		}
+	}
+	merged.NotModified = answered > 0 && !modified
+	return merged
+}
+
+// splitMarkets breaks a fetch into one request per group of at most limit markets
+func splitMarkets(opts *models.FetchOddsOptions, limit int) []*models.FetchOddsOptions {
+	groups := marketGroups(opts.Markets, limit)
+	parts := make([]*models.FetchOddsOptions, len(groups))
+	for i, markets := range groups {
+		part := *opts
+		part.Markets = markets
+		parts[i] = &part
+	}
+	return parts
+}
+
+// marketsPerRequest returns the market group size for split fetches, capped by the
+// vendor's per-request market limit
+func (s *Scheduler) marketsPerRequest() int {
+	perRequest := s.fetchSplit.MarketsPerRequest
+	if limit := s.batching.MaxMarketsPerRequest; limit > 0 && (perRequest <= 0 || perRequest > limit) {
+		perRequest = limit
+	}
+	return perRequest
+}
+
+// FetchOdds fetches featured odds, split across concurrent requests when configured
+// and across sequential ones when the markets exceed the per-request limit.
+// If some split requests fail, the rest are still returned with the joined error;
+// the result is nil only when nothing was fetched. Nothing is sent while the vendor
+// circuit is open after a rate limit or exhausted quota.
+func (s *Scheduler) FetchOdds(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+	if err := s.vendorAllowed(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fetchOddsParts(ctx, opts)
+	s.observeVendor(ctx, err)
+	return result, err
+}
+
+// fetchOddsParts makes FetchOdds' requests
+func (s *Scheduler) fetchOddsParts(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+	parallelism := s.fetchSplit.Parallelism
+	var parts []*models.FetchOddsOptions
+	if parallelism > 1 {
+		parts = SplitFetch(opts, s.marketsPerRequest())
+	} else {
+		parallelism = 1
+		parts = splitMarkets(opts, s.batching.MaxMarketsPerRequest)
+	}
+	if len(parts) == 1 {
+		return s.adapter.FetchOdds(ctx, opts)
+	}
+
+	results := make([]*models.FetchResult, len(parts))
+	errs := make([]error, len(parts))
+	sem := make(chan struct{}, parallelism)
+
+	var wg sync.WaitGroup
+	for i, part := range parts {
+		wg.Add(1)
+		go func(i int, part *models.FetchOddsOptions) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result, err := s.adapter.FetchOdds(ctx, part)
+			if err != nil {
+				errs[i] = fmt.Errorf("regions=%v bookmakers=%v markets=%v: %w", part.Regions, part.Bookmakers, part.Markets, err)
+				return
+			}
+			results[i] = result
+		}(i, part)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	if failed == len(parts) {
+		return nil, errors.Join(errs...)
+	}
+	return MergeResults(results), errors.Join(errs...)
+}
+
+// FetchEventOdds fetches one event's odds, in sequential requests when its markets
+// exceed the per-request limit; partial failures are handled as in FetchOdds
+func (s *Scheduler) FetchEventOdds(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error) {
+	if err := s.vendorAllowed(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.fetchEventOddsParts(ctx, opts)
+	s.observeVendor(ctx, err)
+	return result, err
+}
+
+// fetchEventOddsParts makes FetchEventOdds' requests
+func (s *Scheduler) fetchEventOddsParts(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error) {
+	groups := marketGroups(opts.Markets, s.batching.MaxMarketsPerRequest)
+	if len(groups) == 1 {
+		return s.adapter.FetchEventOdds(ctx, opts)
+	}
+
+	var results []*models.FetchResult
+	var errs []error
+	for _, markets := range groups {
+		part := *opts
+		part.Markets = markets
+		result, err := s.adapter.FetchEventOdds(ctx, &part)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("markets=%v: %w", markets, err))
+			continue
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return nil, errors.Join(errs...)
+	}
+	return MergeResults(results), errors.Join(errs...)
+}
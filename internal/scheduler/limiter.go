@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// errLimiterStopped is returned by Acquire when the scheduler stops while waiting
+var errLimiterStopped = errors.New("scheduler stopped")
+
+// RequestLimiter bounds how many vendor requests run at once and spaces their starts
+// by a minimum pace, so a slate of props pollers firing together is worked off
+// steadily instead of bursting the vendor's rate limit
+type RequestLimiter struct {
+	slots chan struct{}
+	pace  time.Duration
+
+	mu   sync.Mutex
+	next time.Time // Earliest start time for the next request
+}
+
+// NewRequestLimiter creates a limiter; concurrency < 1 is treated as 1 and a zero
+// pace disables spacing
+func NewRequestLimiter(concurrency int, pace time.Duration) *RequestLimiter {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &RequestLimiter{
+		slots: make(chan struct{}, concurrency),
+		pace:  pace,
+	}
+}
+
+// Acquire waits for a free slot and the next paced start time. The returned release
+// must be called when the request finishes.
+func (l *RequestLimiter) Acquire(ctx context.Context, stop <-chan struct{}) (func(), error) {
+	select {
+	case l.slots <- struct{}{}:
+	case <-stop:
+		return nil, errLimiterStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	release := func() { <-l.slots }
+
+	if wait := l.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-stop:
+			release()
+			return nil, errLimiterStopped
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// reserve claims the next start time and returns how long to wait for it
+func (l *RequestLimiter) reserve() time.Duration {
+	if l.pace <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.pace)
+	return start.Sub(now)
+}
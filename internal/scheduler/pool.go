@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrPollBusy is returned by Submit while a job with the same key is queued or running
+	ErrPollBusy = errors.New("previous poll still running")
+
+	// ErrPoolFull is returned by Submit when every worker is busy and the queue is full
+	ErrPoolFull = errors.New("worker pool saturated")
+
+	// ErrPoolStopped is returned by Submit after Stop
+	ErrPoolStopped = errors.New("worker pool stopped")
+)
+
+// poolJob is one submitted poll
+type poolJob struct {
+	key string
+	run func()
+}
+
+// WorkerPool runs polls on a bounded number of workers. A job is rejected rather
+// than queued while another job with the same key (a sport's slate) is queued or
+// running, so a slow poll is never overlapped by the next tick
+type WorkerPool struct {
+	jobs chan poolJob
+
+	mu      sync.Mutex
+	busy    map[string]bool
+	stopped bool
+
+	skipped atomic.Int64
+	wg      sync.WaitGroup
+}
+
+// NewWorkerPool starts a pool with the given number of workers (at least 1). The
+// queue holds as many jobs as there are workers
+func NewWorkerPool(workers int) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &WorkerPool{
+		jobs: make(chan poolJob, workers),
+		busy: make(map[string]bool),
+	}
+	for i := 0; i < workers; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+	return p
+}
+
+// Submit queues run under key without blocking. It returns ErrPollBusy while a job
+// with the same key is pending, and ErrPoolFull when the queue is full
+func (p *WorkerPool) Submit(key string, run func()) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case p.stopped:
+		return ErrPoolStopped
+	case p.busy[key]:
+		p.skipped.Add(1)
+		return ErrPollBusy
+	}
+
+	select {
+	case p.jobs <- poolJob{key: key, run: run}:
+		p.busy[key] = true
+		return nil
+	default:
+		p.skipped.Add(1)
+		return ErrPoolFull
+	}
+}
+
+// Busy reports whether a job with key is queued or running
+func (p *WorkerPool) Busy(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.busy[key]
+}
+
+// Skipped returns how many submissions were rejected as busy or over capacity
+func (p *WorkerPool) Skipped() int64 {
+	return p.skipped.Load()
+}
+
+// Stop rejects new jobs, drops queued ones and waits for running jobs to finish
+func (p *WorkerPool) Stop() {
+	p.mu.Lock()
+	if p.stopped {
+		p.mu.Unlock()
+		return
+	}
+	p.stopped = true
+	close(p.jobs)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// work runs queued jobs until the pool stops
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+
+	for job := range p.jobs {
+		p.mu.Lock()
+		stopped := p.stopped
+		p.mu.Unlock()
+
+		if !stopped {
+			job.run()
+		}
+
+		p.mu.Lock()
+		delete(p.busy, job.key)
+		p.mu.Unlock()
+	}
+}
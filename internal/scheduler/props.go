@@ -0,0 +1,218 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// propsStoreTimeout bounds saving or removing one event's schedule
+const propsStoreTimeout = 5 * time.Second
+
+// SetPropsScheduleStore persists each event's props schedule, and resumes the saved
+// schedules of every sport when Run starts
+func (s *Scheduler) SetPropsScheduleStore(store contracts.PropsScheduleStore) {
+	s.propsStore = store
+}
+
+// rampInterval returns an event's props interval on the sport's ramp schedule
+func (s *Scheduler) rampInterval(sport contracts.SportModule, evt models.Event) time.Duration {
+	hoursUntilStart := evt.CommenceTime.Sub(s.clock.Now()).Hours()
+	return sport.GetPropsInterval(hoursUntilStart, hoursUntilStart <= 0)
+}
+
+// propsInterval returns the next props poll interval for an event on the ramp schedule,
+// slowed down if quota pressure requires it
+func (s *Scheduler) propsInterval(sport contracts.SportModule, evt models.Event) time.Duration {
+	interval := s.rampInterval(sport, evt)
+
+	if s.quota != nil {
+		if deg, ok := s.quota.ForSport(sport.GetSportKey()); ok && deg.PropsInterval > interval {
+			interval = deg.PropsInterval
+		}
+	}
+
+	return addJitter(interval, sport.GetPropsJitterSeconds())
+}
+
+// startPropsPoller runs an event's props poller until the event is over or runCtx is
+// done, releasing the event when it stops. The first poll runs after wait
+func (s *Scheduler) startPropsPoller(runCtx context.Context, sport contracts.SportModule, evt models.Event, wait time.Duration) {
+	s.pollers.Go("props "+evt.EventID, func() error {
+		defer s.props.Remove(evt.EventID)
+		s.pollEventProps(runCtx, sport, evt, wait)
+		return nil
+	})
+}
+
+// pollEventProps polls player and game props for one event until it is over. A newly
+// discovered event (wait 0) is polled at once; a resumed one waits for its saved next poll
+func (s *Scheduler) pollEventProps(ctx context.Context, sport contracts.SportModule, evt models.Event, wait time.Duration) {
+	endTime := evt.CommenceTime.Add(sport.GetTypicalGameDuration())
+
+	if wait <= 0 {
+		// Initial poll immediately
+		s.pollEventPropsOnce(ctx, sport, evt)
+		s.props.Polled(evt.EventID, s.clock.Now())
+		wait = s.propsInterval(sport, evt)
+	}
+
+	for {
+		s.scheduleProps(sport, evt, wait)
+		timer := s.clock.NewTimer(wait)
+
+		select {
+		case <-timer.C():
+			if s.clock.Now().After(endTime) {
+				s.finishProps(evt.EventID)
+				return
+			}
+
+			if !s.propsPaused(sport) {
+				s.pollEventPropsOnce(ctx, sport, evt)
+				s.props.Polled(evt.EventID, s.clock.Now())
+			}
+			wait = s.propsInterval(sport, evt)
+
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// scheduleProps records an event's next poll and saves its schedule
+func (s *Scheduler) scheduleProps(sport contracts.SportModule, evt models.Event, wait time.Duration) {
+	poller, ok := s.props.Scheduled(evt.EventID, s.clock.Now().Add(wait), s.rampInterval(sport, evt))
+	if !ok || s.propsStore == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), propsStoreTimeout)
+	defer cancel()
+	if err := s.propsStore.Save(ctx, poller); err != nil {
+		fmt.Printf("[%s] ⚠ save props schedule (%s): %v\n", sport.GetSportKey(), evt.EventID, err)
+	}
+}
+
+// finishProps deletes the saved schedule of an event that is over
+func (s *Scheduler) finishProps(eventID string) {
+	if s.propsStore == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), propsStoreTimeout)
+	defer cancel()
+	if err := s.propsStore.Remove(ctx, eventID); err != nil {
+		fmt.Printf("[Scheduler] ⚠ remove props schedule (%s): %v\n", eventID, err)
+	}
+}
+
+// resumeProps restarts the props pollers of a sport's saved schedules, each waiting
+// for its saved next poll, and deletes the schedules of events that are over
+func (s *Scheduler) resumeProps(ctx, runCtx context.Context, sport contracts.SportModule) {
+	if s.propsStore == nil {
+		return
+	}
+
+	saved, err := s.propsStore.Load(ctx, sport.GetSportKey())
+	if err != nil {
+		fmt.Printf("[%s] ⚠ load props schedules: %v\n", sport.GetDisplayName(), err)
+		return
+	}
+
+	now := s.clock.Now()
+	resumed := 0
+	for _, poller := range saved {
+		if now.After(poller.CommenceTime.Add(sport.GetTypicalGameDuration())) {
+			s.finishProps(poller.EventID)
+			continue
+		}
+		if !s.props.Restore(poller) {
+			continue
+		}
+
+		evt := models.Event{
+			EventID:      poller.EventID,
+			SportKey:     poller.SportKey,
+			HomeTeam:     poller.HomeTeam,
+			AwayTeam:     poller.AwayTeam,
+			CommenceTime: poller.CommenceTime,
+		}
+		// A poll that fell due while Mercury was down runs at once
+		wait := time.Duration(0)
+		if poller.NextPollAt.After(now) {
+			wait = poller.NextPollAt.Sub(now)
+		}
+		s.startPropsPoller(runCtx, sport, evt, wait)
+		resumed++
+	}
+	if resumed > 0 {
+		fmt.Printf("[%s] resumed %d props pollers from saved schedules\n", sport.GetDisplayName(), resumed)
+	}
+}
+
+// pollEventPropsOnce fetches props for one event and runs them through the pipeline
+func (s *Scheduler) pollEventPropsOnce(ctx context.Context, sport contracts.SportModule, evt models.Event) {
+	if !s.ownsSport(sport.GetSportKey()) {
+		return
+	}
+
+	// Wait for a props request slot (shared by every event's poller); it is held for
+	// the vendor request only, not the rest of the pipeline
+	release := func() {}
+	if s.propsLimiter != nil {
+		var err error
+		if release, err = s.propsLimiter.Acquire(ctx, nil); err != nil {
+			return
+		}
+	}
+
+	// Once the request starts it finishes through the pipeline even during shutdown;
+	// vendor retries stay within one props interval
+	if ctx.Err() != nil {
+		release()
+		return
+	}
+	ctx = contracts.WithRetryBudget(context.WithoutCancel(ctx), s.propsInterval(sport, evt))
+
+	var pollErr error
+	ctx, audit := s.startAudit(ctx, sport.GetSportKey(), "props")
+	defer func() { s.finishAudit(audit, pollErr) }()
+
+	start := s.clock.Now()
+
+	opts := &models.FetchEventOddsOptions{
+		Sport:      sport.GetSportKey(),
+		EventID:    evt.EventID,
+		Regions:    sport.GetRegions(),
+		Markets:    sport.GetPropsMarkets(),
+		Bookmakers: s.books.Unmuted(sport.GetBookmakers()),
+	}
+	audit.request(evt.EventID, opts.Regions, opts.Markets)
+	result, err := s.FetchEventOdds(ctx, opts)
+	release()
+	s.recordQuota(ctx)
+	if err != nil {
+		err = s.recordError(ctx, sport.GetSportKey(), fmt.Errorf("fetch event odds: %w", err))
+		pollErr = err
+		fmt.Printf("[%s] props poll %s error (%s): %v\n", sport.GetDisplayName(), pollIDFrom(ctx), evt.EventID, err)
+		if result == nil {
+			return
+		}
+	}
+
+	if s.shadow != nil && err == nil {
+		s.shadow.CompareEventOdds(opts, result, s.clock.Since(start))
+	}
+
+	if err := s.process(ctx, sport.GetSportKey(), models.PayloadKindEventOdds, result, start); err != nil {
+		pollErr = err
+		fmt.Printf("[%s] props poll error (%s): %v\n", sport.GetDisplayName(), evt.EventID, err)
+		return
+	}
+	s.commitResult(result)
+}
@@ -0,0 +1,133 @@
+package scheduler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// PropsPoller describes one event's active props poller
+type PropsPoller = models.PropsPoller
+
+// PropsRegistry tracks the active per-event props pollers by event ID. Discovery
+// registers an event before starting its poller, so overlapping sweeps never start a
+// second one; the poller removes itself when the event is over or the run stops, so a
+// later discovery can schedule the event again
+type PropsRegistry struct {
+	mu      sync.Mutex
+	pollers map[string]*PropsPoller // event_id -> poller
+}
+
+// NewPropsRegistry creates an empty registry
+func NewPropsRegistry() *PropsRegistry {
+	return &PropsRegistry{pollers: make(map[string]*PropsPoller)}
+}
+
+// Register claims an event for a new poller. Returns false if it already has one
+func (r *PropsRegistry) Register(sportKey string, evt models.Event, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pollers[evt.EventID]; ok {
+		return false
+	}
+	r.pollers[evt.EventID] = &PropsPoller{
+		EventID:      evt.EventID,
+		SportKey:     sportKey,
+		HomeTeam:     evt.HomeTeam,
+		AwayTeam:     evt.AwayTeam,
+		CommenceTime: evt.CommenceTime,
+		StartedAt:    now,
+	}
+	return true
+}
+
+// Remove releases an event whose poller stopped
+func (r *PropsRegistry) Remove(eventID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pollers, eventID)
+}
+
+// Restore claims an event for a poller resumed from a saved schedule. Returns false
+// if it already has one
+func (r *PropsRegistry) Restore(poller PropsPoller) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.pollers[poller.EventID]; ok {
+		return false
+	}
+	r.pollers[poller.EventID] = &poller
+	return true
+}
+
+// Polled records a finished poll of an event
+func (r *PropsRegistry) Polled(eventID string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, ok := r.pollers[eventID]; ok {
+		p.Polls++
+		p.LastPollAt = at
+		p.NextPollAt = time.Time{}
+	}
+}
+
+// Scheduled records when an event's next poll is due and the ramp interval it is on,
+// returning the updated poller
+func (r *PropsRegistry) Scheduled(eventID string, next time.Time, ramp time.Duration) (PropsPoller, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.pollers[eventID]
+	if !ok {
+		return PropsPoller{}, false
+	}
+	p.NextPollAt = next
+	p.RampInterval = ramp
+	return *p, true
+}
+
+// Get returns one event's poller
+func (r *PropsRegistry) Get(eventID string) (PropsPoller, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if p, ok := r.pollers[eventID]; ok {
+		return *p, true
+	}
+	return PropsPoller{}, false
+}
+
+// List returns the active pollers of a sport (every sport when empty), by commence
+// time then event ID
+func (r *PropsRegistry) List(sportKey string) []PropsPoller {
+	r.mu.Lock()
+	pollers := make([]PropsPoller, 0, len(r.pollers))
+	for _, p := range r.pollers {
+		if sportKey == "" || p.SportKey == sportKey {
+			pollers = append(pollers, *p)
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(pollers, func(i, j int) bool {
+		if !pollers[i].CommenceTime.Equal(pollers[j].CommenceTime) {
+			return pollers[i].CommenceTime.Before(pollers[j].CommenceTime)
+		}
+		return pollers[i].EventID < pollers[j].EventID
+	})
+	return pollers
+}
+
+// Len returns the number of active pollers
+func (r *PropsRegistry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pollers)
+}
+
+// PropsPollers returns the registry of active per-event props pollers
+func (s *Scheduler) PropsPollers() *PropsRegistry {
+	return s.props
+}
@@ -3,27 +3,65 @@ package scheduler
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"math/rand"
-	"sync"
 	"time"
 
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/bookskip"
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/conditional"
 	"github.com/XavierBriggs/Mercury/internal/delta"
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/internal/latency"
+	"github.com/XavierBriggs/Mercury/internal/lifecycle"
+	"github.com/XavierBriggs/Mercury/internal/quota"
 	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/internal/shadow"
+	"github.com/XavierBriggs/Mercury/internal/slo"
+	"github.com/XavierBriggs/Mercury/internal/sporthooks"
+	"github.com/XavierBriggs/Mercury/internal/sportlock"
 	"github.com/XavierBriggs/Mercury/internal/writer"
 	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
 	"github.com/XavierBriggs/Mercury/pkg/models"
 	"github.com/redis/go-redis/v9"
 )
 
 // Scheduler orchestrates polling for all registered sports
 type Scheduler struct {
-	adapter       contracts.VendorAdapter
-	deltaEngine   *delta.Engine
-	Writer        *writer.Writer // Exported to allow Talos client injection
-	sportRegistry *registry.SportRegistry
-	stopChan      chan struct{}
-	wg            sync.WaitGroup
+	adapter          contracts.VendorAdapter
+	deltaEngine      *delta.Engine
+	Writer           *writer.Writer // Exported to allow Talos client injection
+	sportRegistry    *registry.SportRegistry
+	quota            *quota.Manager               // Optional quota manager for graceful degradation
+	health           *health.Reporter             // Optional reporter for out-of-process monitoring
+	slo              *slo.Tracker                 // Optional pipeline latency SLO tracker
+	latency          *latency.Recorder            // Optional per-stage latency histograms
+	sportLocks       *sportlock.Manager           // Optional per-sport locks when sharding sports across instances
+	fetchSplit       FetchSplit                   // Concurrent split of featured fetches (zero = one request)
+	batching         MarketBatching               // Per-request market limit and per-market cadences
+	marketPlanner    *MarketPlanner               // Optional picker of the featured markets due on each poll
+	propsLimiter     *RequestLimiter              // Optional bound on concurrent props requests
+	props            *PropsRegistry               // Events with an active props poller, by event_id
+	propsStore       contracts.PropsScheduleStore // Optional store props schedules are saved to and resumed from
+	tipoff           *TipoffTracker               // Commence times for targeted near-tipoff refreshes
+	eventsCache      *EventsCache                 // Optional short-lived cache of each sport's FetchEvents response
+	shadow           *shadow.Comparator           // Optional candidate vendor diffed against every fetch
+	bookCache        *bookskip.Cache              // Optional bookmaker stamps the adapter skips unchanged books against
+	validators       *conditional.Cache           // Optional response validators the adapter sends odds requests conditionally with
+	books            *books.Registry              // Optional book metadata; muted books are neither requested nor ingested
+	validation       ValidationMode               // What happens to records failing sport validation
+	quarantineSink   contracts.QuarantineSink     // Optional sink for records failing sport validation
+	quarantineVendor string                       // Vendor recorded on those records
+	pool             *WorkerPool                  // Runs featured, tipoff and discovery polls (created by Run)
+	pollWorkers      int                          // Pool size (0 = two per sport)
+	pollers          *lifecycle.Group             // Poll loops of the current Run, including per-event props pollers
+	circuit          vendorCircuit                // Pauses vendor requests after a rate limit or exhausted quota
+	auditSink        contracts.PollAuditSink      // Optional sink for one audit row per poll
+	discovery        *sporthooks.Discovery        // Calls OnEventDiscovered once per event
+	clock            clock.Clock                  // Drives poll tickers, props timers and pipeline timestamps
 }
 
 // NewScheduler creates a new polling scheduler
@@ -39,180 +77,490 @@ func NewScheduler(
 		deltaEngine:   delta.NewEngine(redisClient, cacheTTL),
 		Writer:        writer.NewWriter(db, redisClient),
 		sportRegistry: sportRegistry,
-		stopChan:      make(chan struct{}),
+		props:         NewPropsRegistry(),
+		tipoff:        NewTipoffTracker(),
+		validation:    ValidationQuarantine,
+		discovery:     sporthooks.NewDiscovery(),
+		clock:         clock.Real,
 	}
 }
 
-// Start begins polling for all registered sports
-func (s *Scheduler) Start(ctx context.Context) error {
-	// Start writer's background flush
-	s.Writer.Start(ctx)
+// SetClock replaces the wall clock, so tests can drive poll intervals, props ramping
+// and jitter with a clock.Fake. Set it before Run
+func (s *Scheduler) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// SetQuotaManager sets the quota manager used to degrade polling under quota pressure
+func (s *Scheduler) SetQuotaManager(manager *quota.Manager) {
+	s.quota = manager
+}
+
+// SetHealthReporter sets the reporter used to publish poll health to Redis
+func (s *Scheduler) SetHealthReporter(reporter *health.Reporter) {
+	s.health = reporter
+}
+
+// SetLatencyRecorder adds every poll's stage durations to per-stage latency histograms
+func (s *Scheduler) SetLatencyRecorder(recorder *latency.Recorder) {
+	s.latency = recorder
+}
+
+// SetSLOTracker measures every poll's delta → write → cache time against the pipeline SLO
+func (s *Scheduler) SetSLOTracker(tracker *slo.Tracker) {
+	s.slo = tracker
+}
+
+// SetBooks leaves muted books out of requests that list bookmakers and drops their
+// odds from every result
+func (s *Scheduler) SetBooks(registry *books.Registry) {
+	s.books = registry
+}
+
+// SetShadow repeats every featured and props fetch against a shadow adapter and
+// reports the diffs; shadow results never enter the pipeline
+func (s *Scheduler) SetShadow(comparator *shadow.Comparator) {
+	s.shadow = comparator
+}
+
+// SetBookmakerCache commits the last_update of every bookmaker in a processed result
+// to cache, which the adapter consults to skip unchanged bookmakers
+func (s *Scheduler) SetBookmakerCache(cache *bookskip.Cache) {
+	s.bookCache = cache
+}
+
+// SetValidatorCache commits the validators of every processed response to cache,
+// which the adapter sends back to make the next request for the same odds conditional
+func (s *Scheduler) SetValidatorCache(cache *conditional.Cache) {
+	s.validators = cache
+}
+
+// commitResult records a processed result's bookmakers and response validators as seen
+func (s *Scheduler) commitResult(result *models.FetchResult) {
+	if s.bookCache != nil {
+		s.bookCache.Commit(result.BookStamps, s.clock.Now())
+	}
+	if s.validators != nil {
+		s.validators.Commit(result.Validators, s.clock.Now())
+	}
+}
+
+// SetSkipUnchangedTimestamps enables the delta engine's vendor-timestamp short circuit
+func (s *Scheduler) SetSkipUnchangedTimestamps(enabled bool) {
+	s.deltaEngine.SetSkipUnchangedTimestamps(enabled)
+}
+
+// SetSportLocks shards polling: only sports whose lock this instance holds are polled
+func (s *Scheduler) SetSportLocks(locks *sportlock.Manager) {
+	s.sportLocks = locks
+}
+
+// ownsSport reports whether this instance should poll a sport (always, without locks)
+func (s *Scheduler) ownsSport(sportKey string) bool {
+	return s.sportLocks == nil || s.sportLocks.Owns(sportKey)
+}
 
-	// Start polling for each registered sport
+// SetPollWorkers bounds how many featured, tipoff and props discovery polls run at
+// once (0 = two per registered sport)
+func (s *Scheduler) SetPollWorkers(workers int) {
+	s.pollWorkers = workers
+}
+
+// SetPropsConcurrency bounds concurrent props requests across all events and spaces
+// their starts by pace (0 = no spacing)
+func (s *Scheduler) SetPropsConcurrency(concurrency int, pace time.Duration) {
+	s.propsLimiter = NewRequestLimiter(concurrency, pace)
+}
+
+// featuredInterval returns the featured poll interval for a sport, degraded if quota is tight
+func (s *Scheduler) featuredInterval(sport contracts.SportModule) time.Duration {
+	if s.quota != nil {
+		if deg, ok := s.quota.ForSport(sport.GetSportKey()); ok && deg.FeaturedInterval > 0 {
+			return deg.FeaturedInterval
+		}
+	}
+	return sport.GetFeaturedPollInterval()
+}
+
+// featuredDegraded reports whether quota pressure has slowed a sport's featured polling
+func (s *Scheduler) featuredDegraded(sport contracts.SportModule) bool {
+	if s.quota == nil {
+		return false
+	}
+	deg, ok := s.quota.ForSport(sport.GetSportKey())
+	return ok && deg.FeaturedDegraded
+}
+
+// propsPaused reports whether props work for a sport is paused due to quota pressure
+func (s *Scheduler) propsPaused(sport contracts.SportModule) bool {
+	if s.quota == nil {
+		return false
+	}
+	deg, ok := s.quota.ForSport(sport.GetSportKey())
+	return ok && deg.PropsPaused
+}
+
+// Run polls every registered sport until ctx is done, then waits for in-flight polls
+// and flushes the writer. It returns the writer's failure, or a poll loop's panic,
+// after stopping everything else
+func (s *Scheduler) Run(ctx context.Context) error {
 	sports := s.sportRegistry.GetAll()
 	if len(sports) == 0 {
 		return fmt.Errorf("no sports registered")
 	}
 
+	workers := s.pollWorkers
+	if workers <= 0 {
+		workers = 2 * len(sports)
+	}
+	s.pool = NewWorkerPool(workers)
+
+	group, groupCtx := lifecycle.WithContext(ctx)
+
+	// The writer outlives the pollers so their last batches are flushed; it stops
+	// once they have all returned
+	writerCtx, stopWriter := context.WithCancel(context.WithoutCancel(groupCtx))
+	group.Go("writer", func() error {
+		return s.Writer.Run(writerCtx)
+	})
+
+	pollers, pollCtx := lifecycle.WithContext(groupCtx)
+	s.pollers = pollers
+
 	for _, sport := range sports {
 		// Start featured markets polling for this sport
-		s.wg.Add(1)
-		go func(sport contracts.SportModule) {
-			defer s.wg.Done()
-			s.pollSportFeatured(ctx, sport)
-		}(sport)
+		pollers.Go(sport.GetSportKey()+" featured", func() error {
+			s.pollSportFeatured(pollCtx, sport)
+			return nil
+		})
+
+		// Refresh events about to start by ID between slate polls
+		if sport.GetTipoffWindow() > 0 && sport.GetTipoffInterval() > 0 {
+			pollers.Go(sport.GetSportKey()+" tipoff", func() error {
+				s.pollSportTipoff(pollCtx, sport)
+				return nil
+			})
+		}
 
-		// Start props discovery if enabled for this sport
+		// Resume saved props schedules, then start props discovery if enabled for this sport
 		if sport.ShouldPollProps() {
-			s.wg.Add(1)
-			go func(sport contracts.SportModule) {
-				defer s.wg.Done()
-				s.discoverSportProps(ctx, sport)
-			}(sport)
+			s.resumeProps(ctx, pollCtx, sport)
+			pollers.Go(sport.GetSportKey()+" props discovery", func() error {
+				s.discoverSportProps(pollCtx, sport)
+				return nil
+			})
 		}
 
 		fmt.Printf("✓ Started polling for %s\n", sport.GetDisplayName())
 	}
-
-	return nil
+	fmt.Printf("✓ Poll worker pool started (%d workers)\n", workers)
+
+	group.Go("pollers", func() error {
+		defer stopWriter()
+		err := pollers.Wait()
+		s.pool.Stop()
+		return err
+	})
+
+	err := group.Wait()
+	fmt.Println("✓ Scheduler stopped")
+	return err
 }
 
-// Stop gracefully shuts down the scheduler
-func (s *Scheduler) Stop() {
-	close(s.stopChan)
-	s.wg.Wait()
-	s.Writer.Stop()
+// submitPoll runs a poll on the worker pool under key. A tick that finds the previous
+// poll with the same key still queued or running (or every worker busy) is skipped
+// and counted in the sport's health rather than overlapping it
+func (s *Scheduler) submitPoll(ctx context.Context, sport contracts.SportModule, key, track string, poll func(context.Context) error) {
+	err := s.pool.Submit(key, func() {
+		// Shutdown drops polls that have not started; one already running finishes on
+		// a context detached from it, so its fetched odds are still written
+		if ctx.Err() != nil {
+			return
+		}
+		pollCtx, audit := s.startAudit(context.WithoutCancel(ctx), sport.GetSportKey(), track)
+		err := poll(pollCtx)
+		s.finishAudit(audit, err)
+		if err != nil {
+			fmt.Printf("[%s] %s poll %s error: %v\n", sport.GetDisplayName(), track, pollIDFrom(pollCtx), err)
+		}
+	})
+	if err == nil || errors.Is(err, ErrPoolStopped) {
+		return
+	}
+
+	fmt.Printf("[%s] %s poll skipped: %v\n", sport.GetDisplayName(), track, err)
+	s.recordSkipped(ctx, sport.GetSportKey())
 }
 
 // pollSportFeatured polls featured markets for a specific sport
+// Featured and tipoff polls share the sport's key, so they never overlap each other
 func (s *Scheduler) pollSportFeatured(ctx context.Context, sport contracts.SportModule) {
-	// Initial poll immediately
-	if err := s.fetchAndProcess(ctx, &models.FetchOddsOptions{
-		Sport:   sport.GetSportKey(),
-		Regions: sport.GetRegions(),
-		Markets: sport.GetFeaturedMarkets(),
-	}); err != nil {
-		fmt.Printf("[%s] initial featured poll error: %v\n", sport.GetDisplayName(), err)
+	// Vendor retries never wait past the next tick
+	poll := func(ctx context.Context) error {
+		return s.pollFeaturedOnce(contracts.WithRetryBudget(ctx, s.featuredInterval(sport)), sport)
 	}
 
-	// Dynamic ticker based on sport configuration
-	ticker := time.NewTicker(sport.GetFeaturedPollInterval())
+	// Initial poll immediately
+	s.submitPoll(ctx, sport, sport.GetSportKey(), "featured", poll)
+
+	// Dynamic ticker based on sport configuration (degraded under quota pressure)
+	interval := s.featuredInterval(sport)
+	ticker := s.clock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
-			if err := s.fetchAndProcess(ctx, &models.FetchOddsOptions{
-				Sport:   sport.GetSportKey(),
-				Regions: sport.GetRegions(),
-				Markets: sport.GetFeaturedMarkets(),
-			}); err != nil {
-				fmt.Printf("[%s] featured poll error: %v\n", sport.GetDisplayName(), err)
+		case <-ticker.C():
+			s.submitPoll(ctx, sport, sport.GetSportKey(), "featured", poll)
+
+			// Re-evaluate cadence on every tick so quota cuts take effect promptly
+			if next := s.featuredInterval(sport); next != interval {
+				fmt.Printf("[%s] featured interval changed: %v -> %v (quota)\n", sport.GetDisplayName(), interval, next)
+				interval = next
+				ticker.Reset(interval)
 			}
 
 			// TODO: Adjust ticker interval based on nearest event time
 			// For v0, using fixed intervals (will enhance in I3)
 
-		case <-s.stopChan:
-			return
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// pollFeaturedOnce runs one slate poll. With market batching, only the markets due
+// under their cadences are requested, and they are recorded as fetched on success
+func (s *Scheduler) pollFeaturedOnce(ctx context.Context, sport contracts.SportModule) error {
+	now := s.clock.Now()
+	opts := s.featuredOptions(sport, now)
+	if s.marketPlanner == nil {
+		return s.fetchAndProcess(ctx, opts)
+	}
+	if !s.ownsSport(opts.Sport) {
+		return nil
+	}
+
+	interval := s.featuredInterval(sport)
+	stretch := 1.0
+	if base := sport.GetFeaturedPollInterval(); base > 0 && interval > base {
+		stretch = float64(interval) / float64(base)
+	}
+	opts.Markets = s.marketPlanner.Plan(opts.Sport, opts.Markets, now, interval, stretch, !s.featuredDegraded(sport))
+	if len(opts.Markets) == 0 {
+		return nil
+	}
+
+	if err := s.fetchAndProcess(ctx, opts); err != nil {
+		return err
+	}
+	s.marketPlanner.Fetched(opts.Sport, opts.Markets, now)
+	return nil
+}
+
 // discoverSportProps performs discovery sweep for props
 func (s *Scheduler) discoverSportProps(ctx context.Context, sport contracts.SportModule) {
-	ticker := time.NewTicker(sport.GetPropsDiscoveryInterval())
+	ticker := s.clock.NewTicker(sport.GetPropsDiscoveryInterval())
 	defer ticker.Stop()
 
-	// Initial discovery immediately
-	if err := s.discoverProps(ctx, sport); err != nil {
-		fmt.Printf("[%s] initial props discovery error: %v\n", sport.GetDisplayName(), err)
+	key := sport.GetSportKey() + "|props-discovery"
+	poll := func(pollCtx context.Context) error {
+		return s.discoverProps(contracts.WithRetryBudget(pollCtx, sport.GetPropsDiscoveryInterval()), ctx, sport)
 	}
 
+	// Initial discovery immediately
+	s.submitPoll(ctx, sport, key, "props discovery", poll)
+
 	for {
 		select {
-		case <-ticker.C:
-			if err := s.discoverProps(ctx, sport); err != nil {
-				fmt.Printf("[%s] props discovery error: %v\n", sport.GetDisplayName(), err)
+		case <-ticker.C():
+			if s.propsPaused(sport) {
+				fmt.Printf("[%s] props discovery paused (quota)\n", sport.GetDisplayName())
+				continue
 			}
 
-		case <-s.stopChan:
-			return
+			s.submitPoll(ctx, sport, key, "props discovery", poll)
+
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
-// discoverProps fetches upcoming events and schedules props polling for a sport
-func (s *Scheduler) discoverProps(ctx context.Context, sport contracts.SportModule) error {
-	events, err := s.adapter.FetchEvents(ctx, sport.GetSportKey())
-	if err != nil {
-		return fmt.Errorf("fetch events: %w", err)
+// featuredOptions builds a featured poll request for a sport
+// Only the end of the commence window is bounded so in-play events keep updating
+func (s *Scheduler) featuredOptions(sport contracts.SportModule, now time.Time) *models.FetchOddsOptions {
+	opts := &models.FetchOddsOptions{
+		Sport:      sport.GetSportKey(),
+		Regions:    sport.GetRegions(),
+		Markets:    sport.GetFeaturedMarkets(),
+		Bookmakers: s.books.Unmuted(sport.GetBookmakers()),
+	}
+	if hours := sport.GetFeaturedWindowHours(); hours > 0 {
+		opts.CommenceTimeTo = now.Add(time.Duration(hours) * time.Hour)
+	}
+	return opts
+}
+
+// discoverProps fetches upcoming events and schedules props polling for a sport. The
+// props pollers it starts run until runCtx is done, not the discovery poll's ctx,
+// which is detached from shutdown
+func (s *Scheduler) discoverProps(ctx, runCtx context.Context, sport contracts.SportModule) error {
+	if !s.ownsSport(sport.GetSportKey()) {
+		return nil
 	}
 
-	// Filter events within discovery window
-	now := time.Now()
+	// Ask the vendor for the discovery window only; the filter below still applies
+	// for adapters that ignore it
+	now := s.clock.Now()
 	windowEnd := now.Add(time.Duration(sport.GetPropsDiscoveryWindowHours()) * time.Hour)
 
+	events, err := s.fetchEvents(ctx, &models.FetchEventsOptions{
+		Sport:            sport.GetSportKey(),
+		CommenceTimeFrom: now,
+		CommenceTimeTo:   windowEnd,
+	})
+	if err != nil {
+		return fmt.Errorf("fetch events: %w", err)
+	}
+
 	eventsInWindow := make([]models.Event, 0)
 	for _, evt := range events {
 		if evt.CommenceTime.After(now) && evt.CommenceTime.Before(windowEnd) {
 			eventsInWindow = append(eventsInWindow, evt)
 		}
 	}
+	auditFrom(ctx).observe(health.PollStats{Events: len(eventsInWindow)})
+	s.discovery.Observe(ctx, sport, eventsInWindow)
+
+	scheduled := 0
+	for _, evt := range eventsInWindow {
+		if s.props.Register(sport.GetSportKey(), evt, s.clock.Now()) {
+			scheduled++
+			s.startPropsPoller(runCtx, sport, evt, 0)
+		}
+	}
 
-	fmt.Printf("[%s] discovered %d events in next %dhr window\n", 
-		sport.GetDisplayName(), len(eventsInWindow), sport.GetPropsDiscoveryWindowHours())
-
-	// TODO: Store discovered events and schedule ramped polling
-	// For v0, will implement full ramping in I3
+	fmt.Printf("[%s] discovered %d events in next %dhr window (%d newly scheduled for props)\n",
+		sport.GetDisplayName(), len(eventsInWindow), sport.GetPropsDiscoveryWindowHours(), scheduled)
 
 	return nil
 }
 
 // fetchAndProcess executes the full pipeline: fetch → delta → write → cache update
 func (s *Scheduler) fetchAndProcess(ctx context.Context, opts *models.FetchOddsOptions) error {
-	start := time.Now()
+	// Another instance holds this sport's lock
+	if !s.ownsSport(opts.Sport) {
+		return nil
+	}
+
+	start := s.clock.Now()
+	auditFrom(ctx).request("", opts.Regions, opts.Markets)
 
 	// Step 1: Fetch odds from vendor (includes events)
-	result, err := s.adapter.FetchOdds(ctx, opts)
+	result, err := s.FetchOdds(ctx, opts)
+	s.recordQuota(ctx)
 	if err != nil {
-		return fmt.Errorf("fetch odds: %w", err)
+		err = s.recordError(ctx, opts.Sport, fmt.Errorf("fetch odds: %w", err))
+		if result == nil {
+			return err
+		}
+		// Part of a split fetch failed: process what arrived
+		fmt.Printf("[%s] partial featured fetch: %v\n", opts.Sport, err)
+	}
+
+	s.tipoff.Observe(opts.Sport, result.Events)
+	if s.shadow != nil && err == nil {
+		s.shadow.CompareOdds(opts, result, s.clock.Since(start))
+	}
+
+	if err := s.process(ctx, opts.Sport, models.PayloadKindOdds, result, start); err != nil {
+		return err
+	}
+	s.commitResult(result)
+	return nil
+}
+
+// ProcessResult runs a fetch result obtained outside the scheduler (e.g. an archived
+// payload being replayed) through the same validate → delta → write → cache pipeline
+// as a poll
+func (s *Scheduler) ProcessResult(ctx context.Context, sportKey string, kind models.PayloadKind, result *models.FetchResult) error {
+	return s.process(ctx, sportKey, kind, result, s.clock.Now())
+}
+
+// process runs the rest of the pipeline (validate → delta → write → cache update) on
+// a fetch result
+func (s *Scheduler) process(ctx context.Context, sportKey string, kind models.PayloadKind, result *models.FetchResult, start time.Time) error {
+	fetchDuration := s.clock.Since(start)
+
+	// Step 1b: Check events and odds against the sport module before the delta engine
+	invalid := s.validate(sportKey, kind, result)
+
+	// Step 1c: Drop odds from muted books (left out of the request when it lists bookmakers)
+	s.dropMuted(result)
+
+	// Step 1d: Tag odds with the poll's correlation ID, carried through deltas to the
+	// stored rows and stream messages
+	pollID := pollIDFrom(ctx)
+	if pollID == "" {
+		pollID = newPollID() // A result processed outside a poll (e.g. a replay)
+	}
+	for i := range result.Odds {
+		result.Odds[i].PollID = pollID
+	}
+
+	// Sport modules may act on events they have not seen before
+	sport, hasModule := s.sportRegistry.Get(sportKey)
+	if hasModule {
+		s.discovery.Observe(ctx, sport, result.Events)
 	}
 
 	if len(result.Odds) == 0 {
+		stages := map[string]time.Duration{"fetch": fetchDuration}
+		s.observeLatency(stages)
+		s.recordPoll(ctx, sportKey, health.PollStats{
+			Events:  len(result.Events),
+			Invalid: invalid,
+			Stages:  stages,
+			Total:   fetchDuration,
+		})
 		return nil // No odds available
 	}
 
-	fetchDuration := time.Since(start)
-
 	// Step 2: Detect deltas (Redis-first, <1ms)
 	deltas, err := s.deltaEngine.DetectChanges(ctx, result.Odds)
 	if err != nil {
-		return fmt.Errorf("detect changes: %w", err)
+		return s.recordError(ctx, sportKey, merrors.Wrap(merrors.ErrStorage, "detect changes", err))
 	}
 
-	deltaDuration := time.Since(start) - fetchDuration
+	deltaDuration := s.clock.Since(start) - fetchDuration
 
 	if len(deltas) == 0 {
 		// No changes, skip write
+		stages := map[string]time.Duration{"fetch": fetchDuration, "delta": deltaDuration}
+		s.observeSLO(sportKey, kind, stages, 0)
+		s.observeLatency(stages)
+		s.recordPoll(ctx, sportKey, health.PollStats{
+			Events:  len(result.Events),
+			Odds:    len(result.Odds),
+			Invalid: invalid,
+			Stages:  stages,
+			Total:   s.clock.Since(start),
+		})
 		return nil
 	}
 
 	// Step 3: Write deltas to Alexandria (batched, includes event upsert)
 	deltaOdds := make([]models.RawOdds, len(deltas))
 	for i, d := range deltas {
-		deltaOdds[i] = d.Odd
+		deltaOdds[i] = d.Odds()
 	}
 
 	if err := s.Writer.WriteWithEvents(ctx, result.Events, deltaOdds); err != nil {
-		return fmt.Errorf("write deltas: %w", err)
+		return s.recordError(ctx, sportKey, merrors.Wrap(merrors.ErrStorage, "write deltas", err))
 	}
 
-	writeDuration := time.Since(start) - fetchDuration - deltaDuration
+	writeDuration := s.clock.Since(start) - fetchDuration - deltaDuration
 
 	// Step 4: Update Redis cache (write-through)
 	if err := s.deltaEngine.UpdateCache(ctx, deltaOdds); err != nil {
@@ -220,21 +568,114 @@ func (s *Scheduler) fetchAndProcess(ctx context.Context, opts *models.FetchOddsO
 		fmt.Printf("update cache error: %v\n", err)
 	}
 
-	cacheDuration := time.Since(start) - fetchDuration - deltaDuration - writeDuration
+	cacheDuration := s.clock.Since(start) - fetchDuration - deltaDuration - writeDuration
 
 	// Metrics logging (would use proper metrics in production)
-	totalDuration := time.Since(start)
-	fmt.Printf("poll complete: %d events, %d odds, %d deltas, fetch=%v delta=%v write=%v cache=%v total=%v\n",
-		len(result.Events), len(result.Odds), len(deltas), fetchDuration, deltaDuration, writeDuration, cacheDuration, totalDuration)
-
-	// Check if we're meeting SLO (<30ms for Mercury component)
-	if totalDuration > 30*time.Millisecond {
-		fmt.Printf("WARNING: poll exceeded 30ms SLO: %v\n", totalDuration)
+	totalDuration := s.clock.Since(start)
+	fmt.Printf("poll %s complete: %d events, %d odds, %d deltas, fetch=%v delta=%v write=%v cache=%v total=%v\n",
+		pollID, len(result.Events), len(result.Odds), len(deltas), fetchDuration, deltaDuration, writeDuration, cacheDuration, totalDuration)
+
+	stages := map[string]time.Duration{
+		"fetch": fetchDuration,
+		"delta": deltaDuration,
+		"write": writeDuration,
+		"cache": cacheDuration,
+	}
+	s.observeSLO(sportKey, kind, stages, len(deltas))
+	s.observeLatency(stages)
+
+	s.recordPoll(ctx, sportKey, health.PollStats{
+		Events:  len(result.Events),
+		Odds:    len(result.Odds),
+		Deltas:  len(deltas),
+		Invalid: invalid,
+		Stages:  stages,
+		Total:   totalDuration,
+	})
+
+	// After the timings, so a slow hook does not show as pipeline latency
+	if hasModule {
+		sporthooks.OddsAccepted(ctx, sport, deltaOdds)
 	}
 
 	return nil
 }
 
+// dropMuted removes odds quoted by muted books from a result
+func (s *Scheduler) dropMuted(result *models.FetchResult) {
+	if s.books == nil {
+		return
+	}
+
+	kept := result.Odds[:0]
+	for _, odd := range result.Odds {
+		if !s.books.Muted(odd.BookKey) {
+			kept = append(kept, odd)
+		}
+	}
+	result.Odds = kept
+}
+
+// observeSLO checks Mercury's share of a poll (every stage after the fetch) against
+// the pipeline SLO if a tracker is configured
+func (s *Scheduler) observeSLO(sportKey string, kind models.PayloadKind, stages map[string]time.Duration, deltas int) {
+	if s.slo == nil {
+		return
+	}
+	s.slo.Observe(sportKey, kind, stages, deltas)
+}
+
+// observeLatency adds a poll's stage durations to the latency histograms if a
+// recorder is configured
+func (s *Scheduler) observeLatency(stages map[string]time.Duration) {
+	if s.latency == nil {
+		return
+	}
+	s.latency.ObservePoll(stages)
+}
+
+// recordPoll publishes poll health if a reporter is configured, and notes the
+// counts on the poll's audit
+func (s *Scheduler) recordPoll(ctx context.Context, sportKey string, stats health.PollStats) {
+	auditFrom(ctx).observe(stats)
+	if s.health == nil {
+		return
+	}
+	if err := s.health.RecordPoll(ctx, sportKey, stats); err != nil {
+		fmt.Printf("health report error: %v\n", err)
+	}
+}
+
+// recordError publishes a poll failure if a reporter is configured and returns the error
+func (s *Scheduler) recordError(ctx context.Context, sportKey string, pollErr error) error {
+	if s.health != nil {
+		if err := s.health.RecordError(ctx, sportKey, pollErr); err != nil {
+			fmt.Printf("health report error: %v\n", err)
+		}
+	}
+	return pollErr
+}
+
+// recordSkipped counts a poll skipped for overlap if a reporter is configured
+func (s *Scheduler) recordSkipped(ctx context.Context, sportKey string) {
+	if s.health == nil {
+		return
+	}
+	if err := s.health.RecordSkipped(ctx, sportKey); err != nil {
+		fmt.Printf("health report error: %v\n", err)
+	}
+}
+
+// recordQuota publishes the adapter's latest rate limits if a reporter is configured
+func (s *Scheduler) recordQuota(ctx context.Context) {
+	if s.health == nil {
+		return
+	}
+	if err := s.health.RecordQuota(ctx, s.adapter.GetRateLimits()); err != nil {
+		fmt.Printf("health report error: %v\n", err)
+	}
+}
+
 // addJitter adds random jitter to prevent synchronization
 func addJitter(duration time.Duration, jitterSeconds int) time.Duration {
 	if jitterSeconds == 0 {
@@ -244,4 +685,3 @@ func addJitter(duration time.Duration, jitterSeconds int) time.Duration {
 	jitter := time.Duration(rand.Intn(jitterSeconds)) * time.Second
 	return duration + jitter
 }
-
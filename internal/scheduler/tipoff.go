@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// tipoffRetention is how long after start an event is remembered (then the slate poll owns it)
+const tipoffRetention = time.Hour
+
+// TipoffTracker remembers commence times seen in polls so events about to start can be
+// refreshed by ID instead of re-fetching the whole slate
+type TipoffTracker struct {
+	mu     sync.Mutex
+	events map[string]map[string]time.Time // sport_key -> event_id -> commence time
+}
+
+// NewTipoffTracker creates an empty tracker
+func NewTipoffTracker() *TipoffTracker {
+	return &TipoffTracker{events: make(map[string]map[string]time.Time)}
+}
+
+// Observe records the commence times of fetched events
+func (t *TipoffTracker) Observe(sportKey string, events []models.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	known := t.events[sportKey]
+	if known == nil {
+		known = make(map[string]time.Time)
+		t.events[sportKey] = known
+	}
+	for _, evt := range events {
+		if !evt.CommenceTime.IsZero() {
+			known[evt.EventID] = evt.CommenceTime
+		}
+	}
+}
+
+// Due returns the sport's events starting within window of now, sorted by ID
+// Events that started more than tipoffRetention ago are forgotten
+func (t *TipoffTracker) Due(sportKey string, now time.Time, window time.Duration) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var due []string
+	for eventID, commence := range t.events[sportKey] {
+		switch {
+		case commence.Before(now.Add(-tipoffRetention)):
+			delete(t.events[sportKey], eventID)
+		case commence.After(now) && !commence.After(now.Add(window)):
+			due = append(due, eventID)
+		}
+	}
+	sort.Strings(due)
+	return due
+}
+
+// tipoffPaused reports whether targeted refreshes should yield to quota pressure
+func (s *Scheduler) tipoffPaused(sport contracts.SportModule) bool {
+	return s.featuredDegraded(sport)
+}
+
+// pollSportTipoff refreshes featured markets for events about to start, by event ID
+// It shares the sport's pool key with featured polls, so a refresh never overlaps one
+func (s *Scheduler) pollSportTipoff(ctx context.Context, sport contracts.SportModule) {
+	ticker := s.clock.NewTicker(sport.GetTipoffInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			if s.tipoffPaused(sport) {
+				continue
+			}
+
+			now := s.clock.Now()
+			eventIDs := s.tipoff.Due(sport.GetSportKey(), now, sport.GetTipoffWindow())
+			if len(eventIDs) == 0 {
+				continue
+			}
+
+			opts := s.featuredOptions(sport, now)
+			opts.EventIDs = eventIDs
+			s.submitPoll(ctx, sport, sport.GetSportKey(), "tipoff", func(ctx context.Context) error {
+				ctx = contracts.WithRetryBudget(ctx, sport.GetTipoffInterval())
+				if err := s.fetchAndProcess(ctx, opts); err != nil {
+					return fmt.Errorf("%d events: %w", len(eventIDs), err)
+				}
+				return nil
+			})
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// ValidationMode sets what happens to fetched records failing their sport module's
+// ValidateEvent or ValidateOdds
+type ValidationMode string
+
+const (
+	ValidationQuarantine ValidationMode = "quarantine" // Drop them and hand them to the quarantine sink (default)
+	ValidationDrop       ValidationMode = "drop"       // Drop them with a log line
+	ValidationLog        ValidationMode = "log"        // Log them and process them anyway
+)
+
+// ParseValidationMode parses a validation mode name (empty = quarantine)
+func ParseValidationMode(value string) (ValidationMode, error) {
+	switch mode := ValidationMode(value); mode {
+	case "":
+		return ValidationQuarantine, nil
+	case ValidationQuarantine, ValidationDrop, ValidationLog:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown validation mode %q (want quarantine, drop or log)", value)
+}
+
+// SetValidationMode sets what happens to records failing sport validation
+func (s *Scheduler) SetValidationMode(mode ValidationMode) {
+	s.validation = mode
+}
+
+// SetQuarantineSink routes records failing sport validation to sink, recorded under
+// vendor. Without a sink they are still dropped, with a log line
+func (s *Scheduler) SetQuarantineSink(vendor string, sink contracts.QuarantineSink) {
+	s.quarantineVendor = vendor
+	s.quarantineSink = sink
+}
+
+// ValidateEvents splits events into those passing sport.ValidateEvent and quarantine
+// records for the rest. Odds of a rejected event are dropped with it
+func ValidateEvents(sport contracts.SportModule, vendor string, kind models.PayloadKind, events []models.Event, odds []models.RawOdds, receivedAt time.Time) ([]models.Event, []models.RawOdds, []models.QuarantinedRecord) {
+	invalid := make(map[string]error)
+	for _, event := range events {
+		if err := sport.ValidateEvent(event); err != nil {
+			invalid[event.EventID] = err
+		}
+	}
+	if len(invalid) == 0 {
+		return events, odds, nil
+	}
+
+	dropped := make(map[string]int)
+	keptOdds := odds[:0:0]
+	for _, odd := range odds {
+		if _, bad := invalid[odd.EventID]; bad {
+			dropped[odd.EventID]++
+			continue
+		}
+		keptOdds = append(keptOdds, odd)
+	}
+
+	var rejected []models.QuarantinedRecord
+	keptEvents := events[:0:0]
+	for _, event := range events {
+		err, bad := invalid[event.EventID]
+		if !bad {
+			keptEvents = append(keptEvents, event)
+			continue
+		}
+		rejected = append(rejected, models.QuarantinedRecord{
+			Vendor:     vendor,
+			Kind:       kind,
+			SportKey:   event.SportKey,
+			EventID:    event.EventID,
+			Reason:     models.QuarantineSportRule,
+			Detail:     fmt.Sprintf("event %s @ %s: %v (%d odds dropped)", event.AwayTeam, event.HomeTeam, err, dropped[event.EventID]),
+			ReceivedAt: receivedAt,
+		})
+	}
+	return keptEvents, keptOdds, rejected
+}
+
+// ValidateOdds splits odds into those passing sport.ValidateOdds and quarantine
+// records for the rest, which keep the rejection error as their detail
+func ValidateOdds(sport contracts.SportModule, vendor string, kind models.PayloadKind, odds []models.RawOdds, receivedAt time.Time) ([]models.RawOdds, []models.QuarantinedRecord) {
+	var rejected []models.QuarantinedRecord
+	valid := odds[:0:0]
+	for _, odd := range odds {
+		err := sport.ValidateOdds(odd)
+		if err == nil {
+			valid = append(valid, odd)
+			continue
+		}
+		rejected = append(rejected, models.QuarantinedRecord{
+			Vendor:     vendor,
+			Kind:       kind,
+			SportKey:   odd.SportKey,
+			EventID:    odd.EventID,
+			BookKey:    odd.BookKey,
+			MarketKey:  odd.MarketKey,
+			Reason:     models.QuarantineSportRule,
+			Detail:     fmt.Sprintf("outcome %q: %v", outcomeLabel(odd), err),
+			ReceivedAt: receivedAt,
+		})
+	}
+	if len(rejected) == 0 {
+		return odds, nil
+	}
+	return valid, rejected
+}
+
+// outcomeLabel names an outcome in quarantine detail ("Over 220.5", "LeBron James Over 25.5")
+func outcomeLabel(odd models.RawOdds) string {
+	label := odd.OutcomeName
+	if odd.Description != "" {
+		label = odd.Description + " " + label
+	}
+	if odd.Point != nil {
+		label += fmt.Sprintf(" %v", *odd.Point)
+	}
+	return label
+}
+
+// validate runs the sport module's event and odds validation over result and applies
+// the validation mode, returning how many records failed. Sports without a registered
+// module (e.g. replayed payloads) are not validated
+func (s *Scheduler) validate(sportKey string, kind models.PayloadKind, result *models.FetchResult) int {
+	sport, ok := s.sportRegistry.Get(sportKey)
+	if !ok || (len(result.Events) == 0 && len(result.Odds) == 0) {
+		return 0
+	}
+
+	receivedAt := s.clock.Now().UTC()
+	events, odds, rejected := ValidateEvents(sport, s.quarantineVendor, kind, result.Events, result.Odds, receivedAt)
+	odds, rejectedOdds := ValidateOdds(sport, s.quarantineVendor, kind, odds, receivedAt)
+	rejected = append(rejected, rejectedOdds...)
+	if len(rejected) == 0 {
+		return 0
+	}
+
+	switch {
+	case s.validation == ValidationLog:
+		fmt.Printf("[%s] %d record(s) failed sport validation, processing anyway (first: %s)\n",
+			sportKey, len(rejected), rejected[0].Detail)
+		return len(rejected)
+	case s.validation == ValidationQuarantine && s.quarantineSink != nil:
+		s.quarantineSink.Quarantine(rejected)
+	default:
+		fmt.Printf("[%s] dropped %d record(s) failing sport validation (first: %s)\n",
+			sportKey, len(rejected), rejected[0].Detail)
+	}
+
+	result.Events, result.Odds = events, odds
+	return len(rejected)
+}
@@ -0,0 +1,324 @@
+package scores
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/sportlock"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	streamKeyFormat = "scores.live.%s"       // Redis stream per sport
+	cacheKeyFormat  = "scores:current:%s:%s" // event_id, period
+
+	// cacheTTL outlives a game so restarts don't rewrite every score
+	cacheTTL = 24 * time.Hour
+
+	// daysFrom asks for games completed since yesterday too, so final scores land
+	daysFrom = 1
+)
+
+// fetched is a sport's last scores response, shared until the next poll
+type fetched struct {
+	scores   []models.EventScore
+	daysFrom int
+	at       time.Time
+}
+
+// Poller polls vendor scores for sports with events in progress and records score
+// changes. It also implements contracts.ScoresAdapter over the same responses, so
+// the status updater can share its vendor calls
+type Poller struct {
+	db         *sql.DB
+	redis      *redis.Client
+	adapter    contracts.ScoresAdapter
+	interval   time.Duration
+	sportLocks *sportlock.Manager // Optional: only poll sports this instance holds
+	mu         sync.Mutex
+	fetched    map[string]fetched
+	stopChan   chan struct{}
+	wg         sync.WaitGroup
+}
+
+// NewPoller creates a live scores poller that polls every interval
+func NewPoller(db *sql.DB, redisClient *redis.Client, adapter contracts.ScoresAdapter, interval time.Duration) *Poller {
+	return &Poller{
+		db:       db,
+		redis:    redisClient,
+		adapter:  adapter,
+		interval: interval,
+		fetched:  make(map[string]fetched),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetSportLocks shards scores polling the same way as the main scheduler
+func (p *Poller) SetSportLocks(locks *sportlock.Manager) {
+	p.sportLocks = locks
+}
+
+// FetchScores returns the sport's scores, calling the vendor at most once per poll
+// interval. The returned slice is shared and must not be modified
+func (p *Poller) FetchScores(ctx context.Context, sport string, days int) ([]models.EventScore, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if last, ok := p.fetched[sport]; ok && last.daysFrom >= days && time.Since(last.at) < p.interval {
+		return last.scores, nil
+	}
+
+	scores, err := p.adapter.FetchScores(ctx, sport, days)
+	if err != nil {
+		return nil, err
+	}
+	p.fetched[sport] = fetched{scores: scores, daysFrom: days, at: time.Now()}
+	return scores, nil
+}
+
+// Start begins polling live scores
+func (p *Poller) Start(ctx context.Context) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.poll(ctx)
+		for {
+			select {
+			case <-ticker.C:
+				p.poll(ctx)
+			case <-p.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("✓ Live scores polling started (every %v)\n", p.interval)
+}
+
+// Stop gracefully stops scores polling
+func (p *Poller) Stop() {
+	close(p.stopChan)
+	p.wg.Wait()
+}
+
+// poll records score changes for every owned sport with events in progress
+func (p *Poller) poll(ctx context.Context) {
+	events, err := p.eventsInProgress(ctx)
+	if err != nil {
+		fmt.Printf("[Scores] events query error: %v\n", err)
+		return
+	}
+
+	for sportKey, eventIDs := range events {
+		if p.sportLocks != nil && !p.sportLocks.Owns(sportKey) {
+			continue
+		}
+		if err := p.pollSport(ctx, sportKey, eventIDs); err != nil {
+			fmt.Printf("[Scores] %s: %v\n", sportKey, err)
+		}
+	}
+}
+
+// eventsInProgress returns the IDs of live events per sport, plus events that
+// completed recently without a final score recorded
+func (p *Poller) eventsInProgress(ctx context.Context) (map[string]map[string]bool, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT e.event_id, e.sport_key
+		FROM events e
+		WHERE e.event_status = 'live'
+		   OR (e.event_status = 'upcoming' AND e.commence_time <= NOW())
+		   OR (e.event_status = 'completed'
+		       AND e.commence_time >= NOW() - INTERVAL '1 day'
+		       AND NOT EXISTS (SELECT 1 FROM scores s WHERE s.event_id = e.event_id AND s.completed))
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make(map[string]map[string]bool)
+	for rows.Next() {
+		var eventID, sportKey string
+		if err := rows.Scan(&eventID, &sportKey); err != nil {
+			return nil, err
+		}
+		if events[sportKey] == nil {
+			events[sportKey] = make(map[string]bool)
+		}
+		events[sportKey][eventID] = true
+	}
+	return events, rows.Err()
+}
+
+// pollSport runs fetch → delta → write → publish → cache for one sport's events
+func (p *Poller) pollSport(ctx context.Context, sportKey string, eventIDs map[string]bool) error {
+	scores, err := p.FetchScores(ctx, sportKey, daysFrom)
+	if err != nil {
+		return fmt.Errorf("fetch scores: %w", err)
+	}
+
+	receivedAt := timeutil.Now()
+	var updates []Update
+	for _, score := range scores {
+		if eventIDs[score.EventID] {
+			updates = append(updates, Updates(score, receivedAt)...)
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	changed, err := p.detectChanges(ctx, updates)
+	if err != nil {
+		return fmt.Errorf("detect changes: %w", err)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if err := p.write(ctx, changed); err != nil {
+		return fmt.Errorf("write scores: %w", err)
+	}
+
+	if err := p.publish(ctx, sportKey, changed); err != nil {
+		// Log but don't fail - data is in Alexandria
+		fmt.Printf("[Scores] publish error: %v\n", err)
+	}
+
+	if err := p.updateCache(ctx, changed); err != nil {
+		fmt.Printf("[Scores] update cache error: %v\n", err)
+	}
+
+	fmt.Printf("[Scores] %s: %d event(s), %d score change(s)\n", sportKey, len(eventIDs), len(changed))
+	return nil
+}
+
+// cacheKey builds the Redis key holding the last written score for an event period
+func cacheKey(u Update) string {
+	return fmt.Sprintf(cacheKeyFormat, u.EventID, u.Period)
+}
+
+// cacheValue encodes the fields whose change is worth writing
+func cacheValue(u Update) string {
+	return u.HomeScore + "|" + u.AwayScore + "|" + strconv.FormatBool(u.Completed)
+}
+
+// detectChanges returns the updates whose score or completed flag differ from the cache
+func (p *Poller) detectChanges(ctx context.Context, updates []Update) ([]Update, error) {
+	keys := make([]string, len(updates))
+	for i, u := range updates {
+		keys[i] = cacheKey(u)
+	}
+
+	cached, err := p.redis.MGet(ctx, keys...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("redis mget: %w", err)
+	}
+
+	changed := make([]Update, 0, len(updates))
+	for i, u := range updates {
+		if prev, ok := cached[i].(string); ok && prev == cacheValue(u) {
+			continue
+		}
+		changed = append(changed, u)
+	}
+
+	return changed, nil
+}
+
+// write appends changed scores to the scores table
+func (p *Poller) write(ctx context.Context, updates []Update) error {
+	n := len(updates)
+	eventIDs := make([]string, n)
+	sportKeys := make([]string, n)
+	periods := make([]string, n)
+	homeScores := make([]string, n)
+	awayScores := make([]string, n)
+	completed := make([]bool, n)
+	vendorUpdates := make([]*time.Time, n)
+	receivedAts := make([]time.Time, n)
+
+	for i, u := range updates {
+		eventIDs[i] = u.EventID
+		sportKeys[i] = u.SportKey
+		periods[i] = u.Period
+		homeScores[i] = u.HomeScore
+		awayScores[i] = u.AwayScore
+		completed[i] = u.Completed
+		if !u.VendorLastUpdate.IsZero() {
+			vendorUpdate := timeutil.UTC(u.VendorLastUpdate)
+			vendorUpdates[i] = &vendorUpdate
+		}
+		receivedAts[i] = timeutil.UTC(u.ReceivedAt)
+	}
+
+	_, err := p.db.ExecContext(ctx, `
+		INSERT INTO scores (
+			event_id, sport_key, period, home_score, away_score,
+			completed, vendor_last_update, received_at
+		)
+		SELECT * FROM UNNEST(
+			$1::text[], $2::text[], $3::text[], $4::text[], $5::text[],
+			$6::boolean[], $7::timestamptz[], $8::timestamptz[]
+		)
+	`, pq.Array(eventIDs), pq.Array(sportKeys), pq.Array(periods), pq.Array(homeScores), pq.Array(awayScores),
+		pq.Array(completed), pq.Array(vendorUpdates), pq.Array(receivedAts))
+	if err != nil {
+		return fmt.Errorf("insert scores: %w", err)
+	}
+	return nil
+}
+
+// publish appends changed scores to the sport's scores stream
+func (p *Poller) publish(ctx context.Context, sportKey string, updates []Update) error {
+	streamKey := fmt.Sprintf(streamKeyFormat, sportKey)
+	pipe := p.redis.Pipeline()
+
+	for _, u := range updates {
+		msgJSON, err := json.Marshal(u)
+		if err != nil {
+			return fmt.Errorf("marshal stream message: %w", err)
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: streamKey,
+			Values: map[string]interface{}{
+				"data": msgJSON,
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+
+	return nil
+}
+
+// updateCache records written scores so unchanged periods are skipped next poll
+func (p *Poller) updateCache(ctx context.Context, updates []Update) error {
+	pipe := p.redis.Pipeline()
+	for _, u := range updates {
+		pipe.Set(ctx, cacheKey(u), cacheValue(u), cacheTTL)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+	return nil
+}
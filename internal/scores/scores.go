@@ -0,0 +1,58 @@
+// Package scores polls vendor game state for events in progress. Every score change
+// is stored in the scores table and published to scores.live.<sport>, so downstream
+// pricing models can join odds with game state.
+package scores
+
+import (
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// PeriodGame labels the running game total. Vendors without period breakdowns
+// (The Odds API) only produce this row
+const PeriodGame = "game"
+
+// Update is the score of one period of an event at the time it was received. It is
+// also the message published to scores.live.<sport>
+type Update struct {
+	EventID          string    `json:"event_id"`
+	SportKey         string    `json:"sport_key"`
+	HomeTeam         string    `json:"home_team"`
+	AwayTeam         string    `json:"away_team"`
+	Period           string    `json:"period"`
+	HomeScore        string    `json:"home_score"`
+	AwayScore        string    `json:"away_score"`
+	Completed        bool      `json:"completed"`
+	VendorLastUpdate time.Time `json:"vendor_last_update"`
+	ReceivedAt       time.Time `json:"received_at"`
+}
+
+// Updates splits an event's game state into one update per reported period plus the
+// game total. Games without a score yet produce none
+func Updates(score models.EventScore, receivedAt time.Time) []Update {
+	if !score.HasScores {
+		return nil
+	}
+
+	updates := make([]Update, 0, len(score.Periods)+1)
+	add := func(period, home, away string) {
+		updates = append(updates, Update{
+			EventID:          score.EventID,
+			SportKey:         score.SportKey,
+			HomeTeam:         score.HomeTeam,
+			AwayTeam:         score.AwayTeam,
+			Period:           period,
+			HomeScore:        home,
+			AwayScore:        away,
+			Completed:        score.Completed,
+			VendorLastUpdate: score.LastUpdate,
+			ReceivedAt:       receivedAt,
+		})
+	}
+	for _, period := range score.Periods {
+		add(period.Period, period.HomeScore, period.AwayScore)
+	}
+	add(PeriodGame, score.HomeScore, score.AwayScore)
+	return updates
+}
@@ -0,0 +1,190 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	awsService        = "secretsmanager"
+	awsTarget         = "secretsmanager.GetSecretValue"
+	awsContentType    = "application/x-amz-json-1.1"
+	awsRequestTimeout = 10 * time.Second
+)
+
+// AWSConfig configures reading a secret from AWS Secrets Manager. Credentials are
+// static (typically AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN)
+type AWSConfig struct {
+	Region          string
+	SecretID        string // Name or ARN
+	VersionStage    string // Default AWSCURRENT
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Endpoint        string // Override (VPC endpoint, LocalStack); default regional endpoint
+}
+
+// AWSProvider reads one secret whose SecretString is a JSON object of secret names
+type AWSProvider struct {
+	config     AWSConfig
+	endpoint   *url.URL
+	httpClient *http.Client
+}
+
+// NewAWSProvider creates an AWS Secrets Manager provider
+func NewAWSProvider(config AWSConfig) (*AWSProvider, error) {
+	if config.Region == "" {
+		return nil, errors.New("region is required")
+	}
+	if config.SecretID == "" {
+		return nil, errors.New("secret ID is required")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("access key ID and secret access key are required")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://secretsmanager." + config.Region + ".amazonaws.com"
+	}
+
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q", config.Endpoint)
+	}
+
+	return &AWSProvider{
+		config:     config,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: awsRequestTimeout},
+	}, nil
+}
+
+// Name identifies the provider in logs
+func (a *AWSProvider) Name() string {
+	return "aws-secretsmanager:" + a.config.SecretID
+}
+
+// Fetch reads the secret's key/value pairs
+func (a *AWSProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	request := map[string]string{"SecretId": a.config.SecretID}
+	if a.config.VersionStage != "" {
+		request["VersionStage"] = a.config.VersionStage
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	u := *a.endpoint
+	u.Path = "/"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", awsContentType)
+	req.Header.Set("X-Amz-Target", awsTarget)
+	a.sign(req, body, time.Now().UTC())
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		json.Unmarshal(respBody, &apiErr)
+		if apiErr.Type != "" {
+			return nil, fmt.Errorf("status %d: %s: %s", resp.StatusCode, apiErr.Type, apiErr.Message)
+		}
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(respBody, &secret); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if secret.SecretString == nil {
+		return nil, errors.New("secret has no SecretString (binary secrets are not supported)")
+	}
+	return decodeValues(json.RawMessage(*secret.SecretString))
+}
+
+// sign adds AWS Signature Version 4 headers to a request
+func (a *AWSProvider) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if a.config.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", a.config.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	scope := date + "/" + a.config.Region + "/" + awsService + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+a.config.SecretAccessKey), date)
+	key = hmacSHA256(key, a.config.Region)
+	key = hmacSHA256(key, awsService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		a.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,202 @@
+// Package secrets loads credentials (vendor API keys, database and Redis passwords)
+// from an external secrets store at startup and refreshes them periodically, so
+// rotated values reach the running process without a restart.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Provider fetches a set of named secrets. Names are the environment variables the
+// values replace (e.g. ODDS_API_KEY, ALEXANDRIA_PASSWORD, REDIS_PASSWORD)
+type Provider interface {
+	Name() string
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+const fetchTimeout = 30 * time.Second
+
+// Manager holds the latest secrets and notifies subscribers when one rotates
+type Manager struct {
+	provider Provider
+	interval time.Duration
+
+	mu          sync.RWMutex
+	values      map[string]string
+	subscribers map[string][]func(string)
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewManager creates a manager refreshing from provider every interval (0 = load once)
+func NewManager(provider Provider, interval time.Duration) *Manager {
+	return &Manager{
+		provider:    provider,
+		interval:    interval,
+		values:      make(map[string]string),
+		subscribers: make(map[string][]func(string)),
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Load fetches the secrets for the first time
+func (m *Manager) Load(ctx context.Context) error {
+	values, err := m.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return errors.New("no secrets found")
+	}
+
+	m.mu.Lock()
+	m.values = values
+	m.mu.Unlock()
+	return nil
+}
+
+// Export sets each secret as an environment variable, so configuration read from the
+// environment picks them up
+func (m *Manager) Export() error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for name, value := range m.values {
+		if err := os.Setenv(name, value); err != nil {
+			return fmt.Errorf("export %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Names returns the sorted names of the loaded secrets (never their values)
+func (m *Manager) Names() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.values))
+	for name := range m.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the current value of a secret
+func (m *Manager) Get(name string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	value, ok := m.values[name]
+	return value, ok
+}
+
+// OnRotate registers fn to receive a secret's new value whenever it changes
+func (m *Manager) OnRotate(name string, fn func(value string)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.subscribers[name] = append(m.subscribers[name], fn)
+}
+
+// Getter returns a function reading a secret's current value, for clients that look
+// credentials up on every connection; the secret then counts as rotated live
+func (m *Manager) Getter(name string) func() string {
+	m.OnRotate(name, func(string) {})
+	return func() string {
+		value, _ := m.Get(name)
+		return value
+	}
+}
+
+// Refresh fetches the secrets and notifies subscribers of changed values. Secrets
+// missing from the response keep their last value
+func (m *Manager) Refresh(ctx context.Context) error {
+	values, err := m.fetch(ctx)
+	if err != nil {
+		return err
+	}
+
+	type rotation struct {
+		name  string
+		value string
+		subs  []func(string)
+	}
+	var rotated []rotation
+
+	m.mu.Lock()
+	for name, value := range values {
+		if old, ok := m.values[name]; ok && old == value {
+			continue
+		}
+		m.values[name] = value
+		rotated = append(rotated, rotation{name: name, value: value, subs: m.subscribers[name]})
+	}
+	m.mu.Unlock()
+
+	sort.Slice(rotated, func(i, j int) bool { return rotated[i].name < rotated[j].name })
+	for _, r := range rotated {
+		if len(r.subs) == 0 {
+			fmt.Printf("[Secrets] %s changed; restart to apply it\n", r.name)
+			continue
+		}
+		for _, fn := range r.subs {
+			fn(r.value)
+		}
+		fmt.Printf("[Secrets] %s rotated\n", r.name)
+	}
+	return nil
+}
+
+// fetch calls the provider with a timeout
+func (m *Manager) fetch(ctx context.Context) (map[string]string, error) {
+	fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	values, err := m.provider.Fetch(fetchCtx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", m.provider.Name(), err)
+	}
+	return values, nil
+}
+
+// Start begins refreshing secrets (no-op when the interval is 0)
+func (m *Manager) Start(ctx context.Context) {
+	if m.interval <= 0 {
+		return
+	}
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := m.Refresh(ctx); err != nil {
+					fmt.Printf("[Secrets] refresh error (keeping current values): %v\n", err)
+				}
+			case <-m.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("✓ Secrets refresh started (%s, every %v)\n", m.provider.Name(), m.interval)
+}
+
+// Stop stops refreshing
+func (m *Manager) Stop() {
+	close(m.stopChan)
+	m.wg.Wait()
+}
@@ -0,0 +1,167 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const vaultTimeout = 10 * time.Second
+
+// VaultConfig configures reading a Vault KV secret
+type VaultConfig struct {
+	Addr      string // e.g. https://vault.internal:8200
+	Token     string
+	TokenFile string // Read on every fetch, so a Vault agent can renew the token
+	Namespace string // Vault Enterprise namespace (optional)
+	Mount     string // KV mount (default "secret")
+	Path      string // Secret path within the mount, e.g. "mercury/prod"
+	KVVersion int    // 1 or 2 (default 2)
+}
+
+// VaultProvider reads one KV secret whose keys are secret names
+type VaultProvider struct {
+	config     VaultConfig
+	httpClient *http.Client
+}
+
+// NewVaultProvider creates a Vault KV provider
+func NewVaultProvider(config VaultConfig) (*VaultProvider, error) {
+	if config.Addr == "" {
+		return nil, errors.New("vault address is required")
+	}
+	if config.Path == "" {
+		return nil, errors.New("vault secret path is required")
+	}
+	if config.Token == "" && config.TokenFile == "" {
+		return nil, errors.New("vault token or token file is required")
+	}
+	if config.Mount == "" {
+		config.Mount = "secret"
+	}
+	if config.KVVersion == 0 {
+		config.KVVersion = 2
+	}
+	if config.KVVersion != 1 && config.KVVersion != 2 {
+		return nil, fmt.Errorf("unsupported KV version %d", config.KVVersion)
+	}
+
+	return &VaultProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: vaultTimeout},
+	}, nil
+}
+
+// Name identifies the provider in logs
+func (v *VaultProvider) Name() string {
+	return "vault:" + v.config.Mount + "/" + v.config.Path
+}
+
+// URL returns the KV read endpoint
+func (v *VaultProvider) URL() string {
+	mount := strings.Trim(v.config.Mount, "/")
+	path := strings.Trim(v.config.Path, "/")
+	if v.config.KVVersion == 2 {
+		return strings.TrimRight(v.config.Addr, "/") + "/v1/" + mount + "/data/" + path
+	}
+	return strings.TrimRight(v.config.Addr, "/") + "/v1/" + mount + "/" + path
+}
+
+// Fetch reads the secret's key/value pairs
+func (v *VaultProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	token, err := v.token()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.URL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if v.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.config.Namespace)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, vaultErrors(body))
+	}
+
+	// KV v2 nests the secret under data.data; v1 returns it as data
+	var payload struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	data := payload.Data
+	if v.config.KVVersion == 2 {
+		var nested struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(data, &nested); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+		data = nested.Data
+	}
+
+	return decodeValues(data)
+}
+
+// token returns the configured token, preferring the token file
+func (v *VaultProvider) token() (string, error) {
+	if v.config.TokenFile == "" {
+		return v.config.Token, nil
+	}
+	data, err := os.ReadFile(v.config.TokenFile)
+	if err != nil {
+		return "", fmt.Errorf("read token file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// vaultErrors extracts Vault's error messages from a response body
+func vaultErrors(body []byte) string {
+	var payload struct {
+		Errors []string `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) == nil && len(payload.Errors) > 0 {
+		return strings.Join(payload.Errors, "; ")
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// decodeValues reads a JSON object of secrets; non-string values keep their JSON text
+func decodeValues(data json.RawMessage) (map[string]string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || raw == nil {
+		return nil, errors.New("secret is not a JSON object of key/value pairs")
+	}
+
+	values := make(map[string]string, len(raw))
+	for name, value := range raw {
+		var s string
+		if err := json.Unmarshal(value, &s); err == nil {
+			values[name] = s
+		} else {
+			values[name] = string(value)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,247 @@
+package shadow
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamName is the Redis stream comparisons are published to
+const StreamName = "shadow.comparisons"
+
+const (
+	defaultQueueSize = 64
+	streamMaxLen     = 10000
+	fetchTimeout     = 30 * time.Second
+	recordTimeout    = 10 * time.Second
+)
+
+// job is one primary fetch waiting to be repeated against the shadow adapter
+type job struct {
+	oddsOpts       *models.FetchOddsOptions      // Featured fetches
+	eventOpts      *models.FetchEventOddsOptions // Props fetches
+	primary        *models.FetchResult
+	primaryLatency time.Duration
+}
+
+// Comparator repeats primary fetches against a shadow adapter in the background and
+// reports the diffs to the shadow.comparisons stream and shadow_comparisons table.
+// Jobs are dropped (and counted) when the shadow cannot keep up, so the primary
+// polls never wait on it.
+type Comparator struct {
+	adapter     contracts.VendorAdapter
+	vendor      string
+	db          *sql.DB
+	redisClient *redis.Client
+	tolerance   float64
+	queue       chan job
+	compared    atomic.Int64
+	dropped     atomic.Int64
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+}
+
+// NewComparator creates a comparator for a shadow adapter; db and redisClient may be
+// nil to skip the table or the stream
+func NewComparator(adapter contracts.VendorAdapter, vendor string, db *sql.DB, redisClient *redis.Client) *Comparator {
+	return &Comparator{
+		adapter:     adapter,
+		vendor:      vendor,
+		db:          db,
+		redisClient: redisClient,
+		tolerance:   DefaultTolerance,
+		queue:       make(chan job, defaultQueueSize),
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// SetTolerance sets the implied probability gap above which two prices differ
+func (c *Comparator) SetTolerance(tolerance float64) {
+	if tolerance > 0 {
+		c.tolerance = tolerance
+	}
+}
+
+// CompareOdds queues a featured fetch to be repeated against the shadow adapter
+func (c *Comparator) CompareOdds(opts *models.FetchOddsOptions, primary *models.FetchResult, latency time.Duration) {
+	optsCopy := *opts
+	optsCopy.Regions = append([]string(nil), opts.Regions...)
+	optsCopy.Markets = append([]string(nil), opts.Markets...)
+	optsCopy.Bookmakers = append([]string(nil), opts.Bookmakers...)
+	optsCopy.EventIDs = append([]string(nil), opts.EventIDs...)
+	c.enqueue(job{oddsOpts: &optsCopy, primary: snapshot(primary), primaryLatency: latency})
+}
+
+// CompareEventOdds queues a props fetch to be repeated against the shadow adapter
+func (c *Comparator) CompareEventOdds(opts *models.FetchEventOddsOptions, primary *models.FetchResult, latency time.Duration) {
+	optsCopy := *opts
+	optsCopy.Regions = append([]string(nil), opts.Regions...)
+	optsCopy.Markets = append([]string(nil), opts.Markets...)
+	optsCopy.Bookmakers = append([]string(nil), opts.Bookmakers...)
+	c.enqueue(job{eventOpts: &optsCopy, primary: snapshot(primary), primaryLatency: latency})
+}
+
+// Stats returns how many fetches were compared and how many were dropped
+func (c *Comparator) Stats() (compared, dropped int64) {
+	return c.compared.Load(), c.dropped.Load()
+}
+
+// enqueue hands a job to the worker without blocking
+func (c *Comparator) enqueue(j job) {
+	select {
+	case c.queue <- j:
+	default:
+		if c.dropped.Add(1) == 1 {
+			fmt.Printf("[Shadow] %s queue full, dropping comparisons\n", c.vendor)
+		}
+	}
+}
+
+// Start begins comparing queued fetches
+func (c *Comparator) Start(ctx context.Context) {
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		for {
+			select {
+			case j := <-c.queue:
+				c.Record(ctx, c.run(ctx, j))
+			case <-c.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("✓ Shadow comparisons against %s started\n", c.vendor)
+}
+
+// Stop waits for the comparison in flight and discards the rest of the queue
+func (c *Comparator) Stop() {
+	close(c.stopChan)
+	c.wg.Wait()
+
+	compared, dropped := c.Stats()
+	fmt.Printf("✓ Shadow comparisons stopped (%d compared, %d dropped)\n", compared, dropped)
+}
+
+// run fetches a job from the shadow adapter and diffs it against the primary's result
+func (c *Comparator) run(ctx context.Context, j job) Comparison {
+	fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var result *models.FetchResult
+	var err error
+	var sportKey, eventID, kind string
+	if j.eventOpts != nil {
+		sportKey, eventID, kind = j.eventOpts.Sport, j.eventOpts.EventID, "event-odds"
+		result, err = c.adapter.FetchEventOdds(fetchCtx, j.eventOpts)
+	} else {
+		sportKey, kind = j.oddsOpts.Sport, "odds"
+		result, err = c.adapter.FetchOdds(fetchCtx, j.oddsOpts)
+	}
+	latency := time.Since(start)
+
+	var cmp Comparison
+	if err != nil {
+		cmp = Comparison{Error: err.Error()}
+	} else {
+		cmp = Compare(j.primary, result, c.tolerance)
+	}
+	cmp.Vendor = c.vendor
+	cmp.Kind = kind
+	cmp.SportKey = sportKey
+	cmp.EventID = eventID
+	cmp.PrimaryLatency = j.primaryLatency
+	cmp.ShadowLatency = latency
+	cmp.ComparedAt = timeutil.Now()
+	return cmp
+}
+
+// Record publishes a comparison to the stream and stores it in the table
+func (c *Comparator) Record(ctx context.Context, cmp Comparison) {
+	c.compared.Add(1)
+
+	ctx, cancel := context.WithTimeout(ctx, recordTimeout)
+	defer cancel()
+
+	if c.redisClient != nil {
+		if err := c.publish(ctx, cmp); err != nil {
+			fmt.Printf("[Shadow] publish error: %v\n", err)
+		}
+	}
+	if c.db != nil {
+		if err := c.insert(ctx, cmp); err != nil {
+			fmt.Printf("[Shadow] insert error: %v\n", err)
+		}
+	}
+}
+
+// publish adds a comparison to the shadow.comparisons stream
+func (c *Comparator) publish(ctx context.Context, cmp Comparison) error {
+	data, err := json.Marshal(cmp)
+	if err != nil {
+		return fmt.Errorf("marshal comparison: %w", err)
+	}
+
+	return c.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: StreamName,
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"data": data},
+	}).Err()
+}
+
+// insert stores a comparison in shadow_comparisons
+func (c *Comparator) insert(ctx context.Context, cmp Comparison) error {
+	_, err := c.db.ExecContext(ctx, `
+		INSERT INTO shadow_comparisons (
+			vendor, kind, sport_key, event_id,
+			primary_events, shadow_events, matched_events,
+			primary_odds, shadow_odds, matched_odds,
+			price_diffs, mean_prob_diff, max_prob_diff,
+			missing_books, extra_books,
+			primary_latency_ms, shadow_latency_ms, error, compared_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`, cmp.Vendor, cmp.Kind, cmp.SportKey, cmp.EventID,
+		cmp.PrimaryEvents, cmp.ShadowEvents, cmp.MatchedEvents,
+		cmp.PrimaryOdds, cmp.ShadowOdds, cmp.MatchedOdds,
+		cmp.PriceDiffs, cmp.MeanProbDiff, cmp.MaxProbDiff,
+		pq.Array(orEmpty(cmp.MissingBooks)), pq.Array(orEmpty(cmp.ExtraBooks)),
+		cmp.PrimaryLatency.Milliseconds(), cmp.ShadowLatency.Milliseconds(), cmp.Error, cmp.ComparedAt)
+	if err != nil {
+		return fmt.Errorf("insert comparison: %w", err)
+	}
+	return nil
+}
+
+// snapshot copies a result's slices so the pipeline cannot change them under the worker
+func snapshot(result *models.FetchResult) *models.FetchResult {
+	if result == nil {
+		return nil
+	}
+	return &models.FetchResult{
+		Events: append([]models.Event(nil), result.Events...),
+		Odds:   append([]models.RawOdds(nil), result.Odds...),
+	}
+}
+
+// orEmpty maps a nil book list to an empty array (the columns are NOT NULL)
+func orEmpty(books []string) []string {
+	if books == nil {
+		return []string{}
+	}
+	return books
+}
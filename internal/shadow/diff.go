@@ -0,0 +1,192 @@
+// Package shadow evaluates a candidate vendor before cutover: every primary fetch
+// is repeated against a secondary adapter and the two results are diffed (coverage,
+// prices, latency). Shadow results are only reported, never written to Alexandria
+// or the odds streams.
+package shadow
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// DefaultTolerance is the implied probability gap above which two prices differ
+const DefaultTolerance = 0.005
+
+// eventMatchWindow is how far apart two vendors' commence times may be for the same
+// teams to count as one event when the event IDs differ
+const eventMatchWindow = 2 * time.Hour
+
+// Comparison is the diff of one shadow fetch against the primary's result
+type Comparison struct {
+	Vendor         string        `json:"vendor"`
+	Kind           string        `json:"kind"` // "odds" or "event-odds"
+	SportKey       string        `json:"sport_key"`
+	EventID        string        `json:"event_id,omitempty"` // Event-odds fetches only
+	PrimaryEvents  int           `json:"primary_events"`
+	ShadowEvents   int           `json:"shadow_events"`
+	MatchedEvents  int           `json:"matched_events"`
+	PrimaryOdds    int           `json:"primary_odds"`
+	ShadowOdds     int           `json:"shadow_odds"`
+	MatchedOdds    int           `json:"matched_odds"`
+	PriceDiffs     int           `json:"price_diffs"`    // Matched outcomes priced more than the tolerance apart
+	MeanProbDiff   float64       `json:"mean_prob_diff"` // Mean implied probability gap over matched outcomes
+	MaxProbDiff    float64       `json:"max_prob_diff"`
+	MissingBooks   []string      `json:"missing_books,omitempty"` // Books the primary has and the shadow lacks
+	ExtraBooks     []string      `json:"extra_books,omitempty"`   // Books only the shadow has
+	PrimaryLatency time.Duration `json:"primary_latency"`
+	ShadowLatency  time.Duration `json:"shadow_latency"`
+	Error          string        `json:"error,omitempty"` // Shadow fetch error (the diff is empty)
+	ComparedAt     time.Time     `json:"compared_at"`
+}
+
+// Coverage returns the share of the primary's odds the shadow also quoted
+func (c Comparison) Coverage() float64 {
+	if c.PrimaryOdds == 0 {
+		return 1
+	}
+	return float64(c.MatchedOdds) / float64(c.PrimaryOdds)
+}
+
+// String summarizes a comparison for logs
+func (c Comparison) String() string {
+	if c.Error != "" {
+		return fmt.Sprintf("%s %s: shadow error: %s", c.SportKey, c.Kind, c.Error)
+	}
+	return fmt.Sprintf("%s %s: events %d/%d, odds %d/%d (%.0f%% coverage), %d price diff(s) (mean %.4f, max %.4f), latency %v vs %v",
+		c.SportKey, c.Kind, c.MatchedEvents, c.PrimaryEvents, c.MatchedOdds, c.PrimaryOdds, 100*c.Coverage(),
+		c.PriceDiffs, c.MeanProbDiff, c.MaxProbDiff,
+		c.PrimaryLatency.Round(time.Millisecond), c.ShadowLatency.Round(time.Millisecond))
+}
+
+// Compare diffs a shadow fetch result against the primary's. Events are matched by
+// ID, or by teams and commence time when the vendors use different IDs; outcomes by
+// market, book, outcome, player and point. Prices are compared as implied probability
+func Compare(primary, shadow *models.FetchResult, tolerance float64) Comparison {
+	if primary == nil {
+		primary = &models.FetchResult{}
+	}
+	if shadow == nil {
+		shadow = &models.FetchResult{}
+	}
+
+	c := Comparison{
+		PrimaryEvents: len(primary.Events),
+		ShadowEvents:  len(shadow.Events),
+		PrimaryOdds:   len(primary.Odds),
+		ShadowOdds:    len(shadow.Odds),
+	}
+
+	eventIDs := matchEvents(primary.Events, shadow.Events)
+	c.MatchedEvents = len(eventIDs)
+
+	primaryOdds := make(map[string]models.RawOdds, len(primary.Odds))
+	primaryBooks := make(map[string]bool)
+	for _, odd := range primary.Odds {
+		primaryOdds[outcomeKey(odd.EventID, odd)] = odd
+		primaryBooks[odd.BookKey] = true
+	}
+
+	shadowBooks := make(map[string]bool)
+	var total float64
+	for _, odd := range shadow.Odds {
+		shadowBooks[odd.BookKey] = true
+
+		eventID, ok := eventIDs[odd.EventID]
+		if !ok {
+			continue
+		}
+		match, ok := primaryOdds[outcomeKey(eventID, odd)]
+		if !ok {
+			continue
+		}
+
+		c.MatchedOdds++
+		diff := math.Abs(impliedProbability(match) - impliedProbability(odd))
+		total += diff
+		if diff > c.MaxProbDiff {
+			c.MaxProbDiff = diff
+		}
+		if diff > tolerance {
+			c.PriceDiffs++
+		}
+	}
+	if c.MatchedOdds > 0 {
+		c.MeanProbDiff = total / float64(c.MatchedOdds)
+	}
+
+	c.MissingBooks = missing(primaryBooks, shadowBooks)
+	c.ExtraBooks = missing(shadowBooks, primaryBooks)
+	return c
+}
+
+// matchEvents maps shadow event IDs to the primary's
+func matchEvents(primary, shadow []models.Event) map[string]string {
+	byID := make(map[string]bool, len(primary))
+	byTeams := make(map[string][]models.Event, len(primary))
+	for _, evt := range primary {
+		byID[evt.EventID] = true
+		key := teamsKey(evt)
+		byTeams[key] = append(byTeams[key], evt)
+	}
+
+	matched := make(map[string]string, len(shadow))
+	for _, evt := range shadow {
+		if byID[evt.EventID] {
+			matched[evt.EventID] = evt.EventID
+			continue
+		}
+		for _, candidate := range byTeams[teamsKey(evt)] {
+			gap := candidate.CommenceTime.Sub(evt.CommenceTime)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap <= eventMatchWindow {
+				matched[evt.EventID] = candidate.EventID
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// teamsKey identifies an event by its (normalized) teams
+func teamsKey(evt models.Event) string {
+	return strings.ToLower(evt.HomeTeam) + "|" + strings.ToLower(evt.AwayTeam)
+}
+
+// outcomeKey identifies an outcome within the primary's event ID space
+func outcomeKey(eventID string, odd models.RawOdds) string {
+	point := ""
+	if odd.Point != nil {
+		point = fmt.Sprintf("%g", *odd.Point)
+	}
+	return strings.Join([]string{
+		eventID, odd.MarketKey, odd.BookKey, strings.ToLower(odd.OutcomeName), strings.ToLower(odd.Description), point,
+	}, "|")
+}
+
+// impliedProbability converts a price to its implied probability (0 when unpriced)
+func impliedProbability(odd models.RawOdds) float64 {
+	decimal := odd.Decimal()
+	if decimal <= 0 {
+		return 0
+	}
+	return 1 / decimal
+}
+
+// missing returns the sorted keys of have that other lacks
+func missing(have, other map[string]bool) []string {
+	var keys []string
+	for key := range have {
+		if !other[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,79 @@
+package shadow
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Summary aggregates a vendor's comparisons for one sport and endpoint
+type Summary struct {
+	Vendor         string
+	SportKey       string
+	Kind           string
+	Comparisons    int
+	Errors         int
+	Coverage       float64 // Matched odds / primary odds
+	ExtraOdds      int     // Shadow odds beyond the matched ones
+	PriceDiffRate  float64 // Price diffs / matched odds
+	MeanProbDiff   float64 // Weighted by matched odds
+	MaxProbDiff    float64
+	PrimaryLatency time.Duration // Mean
+	ShadowLatency  time.Duration // Mean
+}
+
+// Report summarizes the comparisons made in [from, to), per vendor, sport and endpoint
+func Report(ctx context.Context, db *sql.DB, from, to time.Time) ([]Summary, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT vendor, sport_key, kind,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE error <> ''),
+			COALESCE(SUM(primary_odds) FILTER (WHERE error = ''), 0),
+			COALESCE(SUM(shadow_odds) FILTER (WHERE error = ''), 0),
+			COALESCE(SUM(matched_odds), 0),
+			COALESCE(SUM(price_diffs), 0),
+			COALESCE(SUM(mean_prob_diff * matched_odds), 0),
+			COALESCE(MAX(max_prob_diff), 0),
+			COALESCE(AVG(primary_latency_ms), 0),
+			COALESCE(AVG(shadow_latency_ms) FILTER (WHERE error = ''), 0)
+		FROM shadow_comparisons
+		WHERE compared_at >= $1 AND compared_at < $2
+		GROUP BY vendor, sport_key, kind
+		ORDER BY vendor, sport_key, kind
+	`, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query shadow comparisons: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []Summary
+	for rows.Next() {
+		var s Summary
+		var primaryOdds, shadowOdds, matched, diffs int
+		var weightedDiff, primaryMs, shadowMs float64
+		if err := rows.Scan(&s.Vendor, &s.SportKey, &s.Kind, &s.Comparisons, &s.Errors,
+			&primaryOdds, &shadowOdds, &matched, &diffs, &weightedDiff, &s.MaxProbDiff,
+			&primaryMs, &shadowMs); err != nil {
+			return nil, fmt.Errorf("scan shadow summary: %w", err)
+		}
+
+		s.Coverage = 1
+		if primaryOdds > 0 {
+			s.Coverage = float64(matched) / float64(primaryOdds)
+		}
+		s.ExtraOdds = shadowOdds - matched
+		if matched > 0 {
+			s.PriceDiffRate = float64(diffs) / float64(matched)
+			s.MeanProbDiff = weightedDiff / float64(matched)
+		}
+		s.PrimaryLatency = time.Duration(primaryMs * float64(time.Millisecond))
+		s.ShadowLatency = time.Duration(shadowMs * float64(time.Millisecond))
+		summaries = append(summaries, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return summaries, nil
+}
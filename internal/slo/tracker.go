@@ -0,0 +1,361 @@
+// Package slo tracks Mercury's pipeline latency objective: the delta → write → cache
+// share of every poll (vendor fetch time is excluded). It keeps rolling percentiles
+// per sport and stage, publishes them to pipeline health for `mercury top` and
+// alerting, and persists each violating poll with the stage that took longest.
+package slo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Defaults for Config
+const (
+	DefaultTarget   = 30 * time.Millisecond
+	DefaultWindow   = 500
+	DefaultInterval = 30 * time.Second
+
+	queueSize     = 256
+	insertTimeout = 5 * time.Second
+)
+
+// Stages are the pipeline stages the SLO covers, in the order the scheduler runs them
+var Stages = []string{"delta", "write", "cache"}
+
+// Config configures SLO tracking
+type Config struct {
+	Target     time.Duration // Pipeline latency objective per poll
+	Window     int           // Polls per sport the rolling percentiles cover
+	Interval   time.Duration // How often rolling percentiles are published to health
+	InstanceID string        // Recorded with each violation
+}
+
+// Violation is one poll that exceeded the target
+type Violation struct {
+	SportKey   string
+	Kind       models.PayloadKind
+	Latency    time.Duration
+	Target     time.Duration
+	Stage      string                   // Stage that took longest
+	Stages     map[string]time.Duration // stage name -> duration (SLO stages only)
+	Deltas     int
+	OccurredAt time.Time
+}
+
+// window is a ring buffer of the latest samples
+type window struct {
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+func (w *window) add(d time.Duration) {
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % len(w.samples)
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+func (w *window) values() []time.Duration {
+	n := w.next
+	if w.full {
+		n = len(w.samples)
+	}
+	values := make([]time.Duration, n)
+	copy(values, w.samples[:n])
+	return values
+}
+
+// sportWindows holds one sport's rolling latency and stage windows
+type sportWindows struct {
+	total  *window
+	stages map[string]*window
+}
+
+// Tracker measures pipeline latency against the SLO
+type Tracker struct {
+	db     *sql.DB // Optional: violations are only logged without it
+	config Config
+	health *health.Reporter
+
+	mu     sync.Mutex
+	sports map[string]*sportWindows
+
+	queue    chan Violation
+	dropped  atomic.Int64
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewTracker creates an SLO tracker persisting violations to db
+func NewTracker(db *sql.DB, config Config) *Tracker {
+	if config.Target <= 0 {
+		config.Target = DefaultTarget
+	}
+	if config.Window <= 0 {
+		config.Window = DefaultWindow
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	return &Tracker{
+		db:       db,
+		config:   config,
+		sports:   make(map[string]*sportWindows),
+		queue:    make(chan Violation, queueSize),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetHealthReporter publishes rolling percentiles to pipeline health
+func (t *Tracker) SetHealthReporter(reporter *health.Reporter) {
+	t.health = reporter
+}
+
+// Target returns the latency objective
+func (t *Tracker) Target() time.Duration {
+	return t.config.Target
+}
+
+// Observe records one poll's stage durations. It returns the violation when the
+// SLO stages took longer than the target (nil otherwise) and queues it for storage
+func (t *Tracker) Observe(sportKey string, kind models.PayloadKind, stages map[string]time.Duration, deltas int) *Violation {
+	var latency time.Duration
+	var slowest string
+	observed := make(map[string]time.Duration, len(Stages))
+	for _, stage := range Stages {
+		d, ok := stages[stage]
+		if !ok {
+			continue
+		}
+		observed[stage] = d
+		latency += d
+		if slowest == "" || d > observed[slowest] {
+			slowest = stage
+		}
+	}
+	if len(observed) == 0 {
+		return nil
+	}
+
+	violated := latency > t.config.Target
+
+	t.mu.Lock()
+	w := t.windows(sportKey)
+	w.total.add(latency)
+	for stage, d := range observed {
+		w.stages[stage].add(d)
+	}
+	t.mu.Unlock()
+
+	if !violated {
+		return nil
+	}
+
+	v := &Violation{
+		SportKey:   sportKey,
+		Kind:       kind,
+		Latency:    latency,
+		Target:     t.config.Target,
+		Stage:      slowest,
+		Stages:     observed,
+		Deltas:     deltas,
+		OccurredAt: time.Now(),
+	}
+	fmt.Printf("[SLO] %s %s poll took %v (SLO %v), slowest stage %s (%v)\n",
+		sportKey, kind, latency, t.config.Target, slowest, observed[slowest])
+
+	select {
+	case t.queue <- *v:
+	default:
+		if t.dropped.Add(1) == 1 {
+			fmt.Println("[SLO] violation queue full, dropping records")
+		}
+	}
+	return v
+}
+
+// windows returns a sport's windows, creating them (caller holds mu)
+func (t *Tracker) windows(sportKey string) *sportWindows {
+	w, ok := t.sports[sportKey]
+	if !ok {
+		w = &sportWindows{
+			total:  &window{samples: make([]time.Duration, t.config.Window)},
+			stages: make(map[string]*window, len(Stages)),
+		}
+		for _, stage := range Stages {
+			w.stages[stage] = &window{samples: make([]time.Duration, t.config.Window)}
+		}
+		t.sports[sportKey] = w
+	}
+	return w
+}
+
+// Stats returns a sport's rolling percentiles over the last Window polls
+func (t *Tracker) Stats(sportKey string) health.SLOStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w, ok := t.sports[sportKey]
+	if !ok {
+		return health.SLOStats{Target: t.config.Target}
+	}
+
+	total := sorted(w.total.values())
+	stats := health.SLOStats{
+		Target:  t.config.Target,
+		Samples: len(total),
+		P50:     Percentile(total, 0.50),
+		P95:     Percentile(total, 0.95),
+		P99:     Percentile(total, 0.99),
+	}
+	for _, latency := range total {
+		if latency > t.config.Target {
+			stats.Violations++
+		}
+	}
+	for _, stage := range Stages {
+		p95 := Percentile(sorted(w.stages[stage].values()), 0.95)
+		if stats.Stage == "" || p95 > stats.StageP95 {
+			stats.Stage, stats.StageP95 = stage, p95
+		}
+	}
+	return stats
+}
+
+// sorted sorts samples in place and returns them
+func sorted(samples []time.Duration) []time.Duration {
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	return samples
+}
+
+// Percentile returns the nearest-rank q-th percentile (0 < q ≤ 1) of sorted samples
+func Percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(q*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// Start begins storing violations and publishing rolling percentiles
+func (t *Tracker) Start(ctx context.Context) {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		ticker := time.NewTicker(t.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case v := <-t.queue:
+				t.store(ctx, v)
+			case <-ticker.C:
+				t.publish(ctx)
+			case <-t.stopChan:
+				t.drain(context.Background())
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("✓ Pipeline SLO tracking started (target %v over the last %d polls per sport)\n",
+		t.config.Target, t.config.Window)
+}
+
+// Stop stores queued violations and stops the tracker
+func (t *Tracker) Stop() {
+	close(t.stopChan)
+	t.wg.Wait()
+
+	if dropped := t.dropped.Load(); dropped > 0 {
+		fmt.Printf("[SLO] stopped (%d violations dropped)\n", dropped)
+	}
+}
+
+// drain stores violations queued before Stop
+func (t *Tracker) drain(ctx context.Context) {
+	for {
+		select {
+		case v := <-t.queue:
+			t.store(ctx, v)
+		default:
+			return
+		}
+	}
+}
+
+// publish records every sport's rolling percentiles in health
+func (t *Tracker) publish(ctx context.Context) {
+	if t.health == nil {
+		return
+	}
+
+	t.mu.Lock()
+	sportKeys := make([]string, 0, len(t.sports))
+	for sportKey := range t.sports {
+		sportKeys = append(sportKeys, sportKey)
+	}
+	t.mu.Unlock()
+
+	for _, sportKey := range sportKeys {
+		if err := t.health.RecordSLO(ctx, sportKey, t.Stats(sportKey)); err != nil {
+			fmt.Printf("[SLO] health report error: %v\n", err)
+			return
+		}
+	}
+}
+
+// store inserts one violation into slo_violations
+func (t *Tracker) store(ctx context.Context, v Violation) {
+	if t.db == nil {
+		return
+	}
+
+	insertCtx, cancel := context.WithTimeout(ctx, insertTimeout)
+	defer cancel()
+
+	_, err := t.db.ExecContext(insertCtx, `
+		INSERT INTO slo_violations (
+			instance_id, sport_key, kind, latency_ms, target_ms, stage,
+			delta_ms, write_ms, cache_ms, deltas, occurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`,
+		t.config.InstanceID,
+		v.SportKey,
+		string(v.Kind),
+		millis(v.Latency),
+		millis(v.Target),
+		v.Stage,
+		millis(v.Stages["delta"]),
+		millis(v.Stages["write"]),
+		millis(v.Stages["cache"]),
+		v.Deltas,
+		v.OccurredAt,
+	)
+	if err != nil {
+		fmt.Printf("[SLO] insert error: %v\n", err)
+	}
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
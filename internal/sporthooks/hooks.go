@@ -0,0 +1,107 @@
+// Package sporthooks calls the optional lifecycle hooks a sport module implements
+// (see the contracts.*Hook interfaces), isolating the pipeline from their failures:
+// each call gets a timeout, and errors and panics are logged, never returned.
+package sporthooks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// hookTimeout bounds one hook call
+const hookTimeout = 5 * time.Second
+
+// EventLive calls sport's OnEventLive, if it implements one
+func EventLive(ctx context.Context, sport contracts.SportModule, event models.Event) {
+	if hook, ok := sport.(contracts.EventLiveHook); ok {
+		call(ctx, sport, "OnEventLive", func(ctx context.Context) error {
+			return hook.OnEventLive(ctx, event)
+		})
+	}
+}
+
+// EventCompleted calls sport's OnEventCompleted, if it implements one
+func EventCompleted(ctx context.Context, sport contracts.SportModule, event models.Event) {
+	if hook, ok := sport.(contracts.EventCompletedHook); ok {
+		call(ctx, sport, "OnEventCompleted", func(ctx context.Context) error {
+			return hook.OnEventCompleted(ctx, event)
+		})
+	}
+}
+
+// OddsAccepted calls sport's OnOddsAccepted, if it implements one and odds is not empty
+func OddsAccepted(ctx context.Context, sport contracts.SportModule, odds []models.RawOdds) {
+	if hook, ok := sport.(contracts.OddsAcceptedHook); ok && len(odds) > 0 {
+		call(ctx, sport, "OnOddsAccepted", func(ctx context.Context) error {
+			return hook.OnOddsAccepted(ctx, odds)
+		})
+	}
+}
+
+// call runs one hook with a timeout, logging an error or recovered panic
+func call(ctx context.Context, sport contracts.SportModule, name string, fn func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("[Hooks] ✗ %s %s panicked: %v\n", sport.GetSportKey(), name, r)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		fmt.Printf("[Hooks] ⚠ %s %s: %v\n", sport.GetSportKey(), name, err)
+	}
+}
+
+// Discovery calls OnEventDiscovered once per event. Events are forgotten once their
+// game is over (commence time plus the sport's typical duration), so the set stays
+// bounded by the listed slate
+type Discovery struct {
+	mu   sync.Mutex
+	seen map[string]time.Time // event_id -> when it can be forgotten
+}
+
+// NewDiscovery creates an empty discovery tracker
+func NewDiscovery() *Discovery {
+	return &Discovery{seen: make(map[string]time.Time)}
+}
+
+// Observe calls sport's OnEventDiscovered for each event not seen before. Events
+// whose game is already over (e.g. from a replayed payload) are ignored
+func (d *Discovery) Observe(ctx context.Context, sport contracts.SportModule, events []models.Event) {
+	hook, ok := sport.(contracts.EventDiscoveredHook)
+	if !ok || len(events) == 0 {
+		return
+	}
+
+	now := time.Now()
+	var discovered []models.Event
+
+	d.mu.Lock()
+	for eventID, forgetAt := range d.seen {
+		if now.After(forgetAt) {
+			delete(d.seen, eventID)
+		}
+	}
+	for _, event := range events {
+		forgetAt := event.CommenceTime.Add(sport.GetTypicalGameDuration())
+		if _, seen := d.seen[event.EventID]; seen || now.After(forgetAt) {
+			continue
+		}
+		d.seen[event.EventID] = forgetAt
+		discovered = append(discovered, event)
+	}
+	d.mu.Unlock()
+
+	for _, event := range discovered {
+		call(ctx, sport, "OnEventDiscovered", func(ctx context.Context) error {
+			return hook.OnEventDiscovered(ctx, event)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Package sportlock shards sports across Mercury instances with per-sport Redis
+// locks, as an alternative to single-leader mode (internal/leader) for deployments
+// with more sports than one instance should poll.
+//
+// Each instance heartbeats its membership and claims at most its fair share of
+// sports (ceil(sports / live instances)) with SET NX semantics, renewing held locks every
+// heartbeat. When instances join, those over their share release the excess; when
+// one dies its locks expire and the survivors pick them up within one TTL.
+// Pollers call Owns before every vendor request, so a sport is never double-polled
+// for longer than a lock that expired mid-request.
+//
+// In-process consumers (edge, arb, steam, ...) only see deltas for the sports
+// their instance owns.
+package sportlock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	lockKeyPrefix = "mercury:sportlock:"          // String per sport holding the owner's instance ID
+	membersKey    = "mercury:sportlock:instances" // Sorted set of instance ID -> last heartbeat (unix ms)
+
+	// DefaultTTL is the lock length: the worst-case handoff time after a crash
+	DefaultTTL = 15 * time.Second
+)
+
+// claimScript takes a free lock, or re-adopts one this instance still holds (e.g.
+// after a heartbeat error dropped it locally)
+var claimScript = redis.NewScript(`
+local owner = redis.call("GET", KEYS[1])
+if owner == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if not owner then
+	redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+	return 1
+end
+return 0`)
+
+// renewScript extends a lock only if this instance still holds it
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// releaseScript deletes a lock only if this instance still holds it
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// LockKey returns the Redis key of a sport's lock
+func LockKey(sportKey string) string {
+	return lockKeyPrefix + sportKey
+}
+
+// FairShare is the most sports one of n live instances should hold
+func FairShare(sports, instances int) int {
+	if instances < 1 {
+		instances = 1
+	}
+	return (sports + instances - 1) / instances
+}
+
+// Manager claims and renews per-sport locks for one instance
+type Manager struct {
+	redis      *redis.Client
+	instanceID string
+	sports     []string
+	ttl        time.Duration
+
+	mu       sync.RWMutex
+	owned    map[string]bool
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// NewManager creates a lock manager for the given sports
+func NewManager(redisClient *redis.Client, instanceID string, sportKeys []string) *Manager {
+	sports := append([]string(nil), sportKeys...)
+	sort.Strings(sports)
+
+	return &Manager{
+		redis:      redisClient,
+		instanceID: instanceID,
+		sports:     sports,
+		ttl:        DefaultTTL,
+		owned:      make(map[string]bool),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// SetTTL sets the lock length (heartbeats run at a third of it)
+func (m *Manager) SetTTL(ttl time.Duration) {
+	if ttl > 0 {
+		m.ttl = ttl
+	}
+}
+
+// Owns reports whether this instance currently holds a sport's lock
+func (m *Manager) Owns(sportKey string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.owned[sportKey]
+}
+
+// Owned returns the sorted sport keys this instance holds
+func (m *Manager) Owned() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	owned := make([]string, 0, len(m.owned))
+	for sportKey := range m.owned {
+		owned = append(owned, sportKey)
+	}
+	sort.Strings(owned)
+	return owned
+}
+
+// Start runs heartbeats until Stop or ctx cancellation. The first heartbeat runs
+// before Start returns so pollers begin with their initial share.
+func (m *Manager) Start(ctx context.Context) {
+	m.heartbeat(ctx)
+
+	go func() {
+		ticker := time.NewTicker(m.ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.heartbeat(ctx)
+			case <-m.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops heartbeats and releases every held lock so other instances take the
+// sports over immediately
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopChan)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	for _, sportKey := range m.Owned() {
+		m.release(ctx, sportKey)
+	}
+	m.redis.ZRem(ctx, membersKey, m.instanceID)
+}
+
+// heartbeat refreshes membership, renews held locks, then claims or releases sports
+// to converge on the fair share
+func (m *Manager) heartbeat(ctx context.Context) {
+	now := time.Now()
+	instances, err := m.liveInstances(ctx, now)
+	if err != nil {
+		fmt.Printf("[SportLock] Heartbeat error: %v\n", err)
+		m.expireAll()
+		return
+	}
+	share := FairShare(len(m.sports), instances)
+
+	// Renew what we hold; anything we could not renew is no longer ours
+	for _, sportKey := range m.Owned() {
+		renewed, err := renewScript.Run(ctx, m.redis, []string{LockKey(sportKey)}, m.instanceID, m.ttl.Milliseconds()).Int()
+		if err != nil || renewed == 0 {
+			m.setOwned(sportKey, false)
+			fmt.Printf("[SportLock] %s lost %s\n", m.instanceID, sportKey)
+		}
+	}
+
+	// Shed sports above our share (in reverse order, keeping the rest stable)
+	owned := m.Owned()
+	for i := len(owned) - 1; i >= 0 && len(owned) > share; i-- {
+		m.release(ctx, owned[i])
+		owned = owned[:i]
+	}
+
+	// Claim free sports up to our share
+	for _, sportKey := range m.sports {
+		if len(owned) >= share {
+			break
+		}
+		if m.Owns(sportKey) {
+			continue
+		}
+		claimed, err := claimScript.Run(ctx, m.redis, []string{LockKey(sportKey)}, m.instanceID, m.ttl.Milliseconds()).Int()
+		if err != nil || claimed == 0 {
+			continue
+		}
+		m.setOwned(sportKey, true)
+		owned = append(owned, sportKey)
+		fmt.Printf("[SportLock] %s now polls %s (%d/%d, %d instance(s))\n", m.instanceID, sportKey, len(owned), share, instances)
+	}
+}
+
+// liveInstances records our heartbeat and counts instances seen within one TTL
+func (m *Manager) liveInstances(ctx context.Context, now time.Time) (int, error) {
+	cutoff := now.Add(-m.ttl).UnixMilli()
+
+	pipe := m.redis.TxPipeline()
+	pipe.ZAdd(ctx, membersKey, redis.Z{Score: float64(now.UnixMilli()), Member: m.instanceID})
+	pipe.ZRemRangeByScore(ctx, membersKey, "-inf", "("+strconv.FormatInt(cutoff, 10))
+	count := pipe.ZCard(ctx, membersKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return int(count.Val()), nil
+}
+
+func (m *Manager) release(ctx context.Context, sportKey string) {
+	m.setOwned(sportKey, false)
+	if err := releaseScript.Run(ctx, m.redis, []string{LockKey(sportKey)}, m.instanceID).Err(); err != nil {
+		fmt.Printf("[SportLock] Failed to release %s: %v\n", sportKey, err)
+		return
+	}
+	fmt.Printf("[SportLock] %s released %s\n", m.instanceID, sportKey)
+}
+
+// expireAll drops every lock locally when Redis is unreachable: we cannot renew, so
+// we must assume another instance will take the sports once they expire
+func (m *Manager) expireAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for sportKey := range m.owned {
+		delete(m.owned, sportKey)
+		fmt.Printf("[SportLock] %s dropped %s (Redis unavailable)\n", m.instanceID, sportKey)
+	}
+}
+
+func (m *Manager) setOwned(sportKey string, owned bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if owned {
+		m.owned[sportKey] = true
+	} else {
+		delete(m.owned, sportKey)
+	}
+}
+
+// Owners returns each sport's current lock holder ("" if unclaimed)
+func Owners(ctx context.Context, redisClient *redis.Client, sportKeys []string) (map[string]string, error) {
+	pipe := redisClient.Pipeline()
+	cmds := make(map[string]*redis.StringCmd, len(sportKeys))
+	for _, sportKey := range sportKeys {
+		cmds[sportKey] = pipe.Get(ctx, LockKey(sportKey))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	owners := make(map[string]string, len(sportKeys))
+	for sportKey, cmd := range cmds {
+		owners[sportKey], _ = cmd.Result()
+	}
+	return owners, nil
+}
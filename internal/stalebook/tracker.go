@@ -0,0 +1,282 @@
+// Package stalebook detects books that stopped moving: a book is stale when none of
+// its quotes for a sport produced an accepted delta for a while, as long as one of
+// the sport's events is about to start (when every active book should be moving).
+// Stale books are published to pipeline health for alerting, and listeners such as
+// the best-line cache are told when a book's state changes.
+package stalebook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Defaults for Config
+const (
+	DefaultSilentAfter  = 20 * time.Minute
+	DefaultTipoffWindow = 2 * time.Hour
+	DefaultInterval     = time.Minute
+
+	// startedRetention is how long after start an event is remembered
+	startedRetention = time.Hour
+)
+
+// Config configures stale book detection
+type Config struct {
+	SilentAfter  time.Duration // Stale after this long without an accepted delta (0 disables detection)
+	TipoffWindow time.Duration // Only while one of the sport's events starts within this window
+	Interval     time.Duration // How often staleness is re-evaluated
+}
+
+// ChangeFunc is told which of a sport's books became stale or fresh again
+type ChangeFunc func(ctx context.Context, sportKey string, books []string)
+
+// Tracker records the last accepted delta per sport and book from committed batches
+type Tracker struct {
+	config   Config
+	reporter *health.Reporter
+
+	mu        sync.Mutex
+	lastDelta map[string]map[string]time.Time // sport_key -> book_key -> last committed delta
+	commence  map[string]map[string]time.Time // sport_key -> event_id -> commence time
+	stale     map[string]map[string]bool      // sport_key -> book_key -> stale at the last check
+	listeners []ChangeFunc
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewTracker creates a tracker
+func NewTracker(config Config) *Tracker {
+	if config.TipoffWindow <= 0 {
+		config.TipoffWindow = DefaultTipoffWindow
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	return &Tracker{
+		config:    config,
+		lastDelta: make(map[string]map[string]time.Time),
+		commence:  make(map[string]map[string]time.Time),
+		stale:     make(map[string]map[string]bool),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// SetReporter publishes each sport's stale books to pipeline health
+func (t *Tracker) SetReporter(reporter *health.Reporter) {
+	t.reporter = reporter
+}
+
+// OnChange registers fn to be told about books whose staleness changed
+func (t *Tracker) OnChange(fn ChangeFunc) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.listeners = append(t.listeners, fn)
+}
+
+// HandleDeltaBatchCommitted records the batch's books as moving and its events'
+// commence times. A stale book with a new delta is fresh again at once
+func (t *Tracker) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	at := msg.CommittedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	revived := make(map[string][]string)
+	t.mu.Lock()
+	t.observeEvents(msg)
+	for _, odd := range msg.Odds {
+		books := t.lastDelta[odd.SportKey]
+		if books == nil {
+			books = make(map[string]time.Time)
+			t.lastDelta[odd.SportKey] = books
+		}
+		if at.After(books[odd.BookKey]) {
+			books[odd.BookKey] = at
+		}
+		if t.stale[odd.SportKey][odd.BookKey] {
+			delete(t.stale[odd.SportKey], odd.BookKey)
+			revived[odd.SportKey] = append(revived[odd.SportKey], odd.BookKey)
+			fmt.Printf("[StaleBook] %s %s moving again\n", odd.SportKey, odd.BookKey)
+		}
+	}
+	listeners := t.listeners
+	t.mu.Unlock()
+
+	for sportKey, books := range revived {
+		for _, fn := range listeners {
+			fn(ctx, sportKey, books)
+		}
+	}
+}
+
+// HandleEventDiscovered records the commence times of new events
+func (t *Tracker) HandleEventDiscovered(ctx context.Context, msg bus.EventDiscovered) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.observeEvents(bus.DeltaBatchCommitted{Events: msg.Events})
+}
+
+// HandleEventStatusChanged forgets events that can no longer be bet
+func (t *Tracker) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	switch msg.NewStatus {
+	case models.EventStatusCompleted, models.EventStatusCancelled, models.EventStatusPostponed:
+		t.mu.Lock()
+		delete(t.commence[msg.SportKey], msg.EventID)
+		t.mu.Unlock()
+	}
+}
+
+// observeEvents records commence times (caller holds mu)
+func (t *Tracker) observeEvents(msg bus.DeltaBatchCommitted) {
+	for _, evt := range msg.Events {
+		if evt.CommenceTime.IsZero() {
+			continue
+		}
+		events := t.commence[evt.SportKey]
+		if events == nil {
+			events = make(map[string]time.Time)
+			t.commence[evt.SportKey] = events
+		}
+		events[evt.EventID] = evt.CommenceTime
+	}
+}
+
+// IsStale reports whether a book was stale for a sport at the last check
+func (t *Tracker) IsStale(sportKey, bookKey string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stale[sportKey][bookKey]
+}
+
+// NextTipoff returns the earliest commence time of a sport's events at or after now
+// (zero when none is known). Events that started more than an hour ago are forgotten
+func (t *Tracker) NextTipoff(sportKey string, now time.Time) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.nextTipoff(sportKey, now)
+}
+
+func (t *Tracker) nextTipoff(sportKey string, now time.Time) time.Time {
+	var next time.Time
+	for eventID, commence := range t.commence[sportKey] {
+		if commence.Before(now.Add(-startedRetention)) {
+			delete(t.commence[sportKey], eventID)
+			continue
+		}
+		if !commence.Before(now) && (next.IsZero() || commence.Before(next)) {
+			next = commence
+		}
+	}
+	return next
+}
+
+// Stale reports whether a book whose last accepted delta was at lastDelta is stale,
+// given the sport's next tipoff
+func (c Config) Stale(lastDelta, nextTipoff, now time.Time) bool {
+	if c.SilentAfter <= 0 || lastDelta.IsZero() || nextTipoff.IsZero() {
+		return false
+	}
+	if nextTipoff.Sub(now) > c.TipoffWindow {
+		return false
+	}
+	return now.Sub(lastDelta) > c.SilentAfter
+}
+
+// Check re-evaluates every book, publishes each sport's stale books to health and
+// tells listeners about books whose state changed
+func (t *Tracker) Check(ctx context.Context, now time.Time) {
+	type sportState struct {
+		stale   []health.StaleBook
+		changed []string
+	}
+	states := make(map[string]*sportState)
+
+	t.mu.Lock()
+	for sportKey, books := range t.lastDelta {
+		state := &sportState{}
+		states[sportKey] = state
+		next := t.nextTipoff(sportKey, now)
+
+		was := t.stale[sportKey]
+		is := make(map[string]bool)
+		for bookKey, lastDelta := range books {
+			if !t.config.Stale(lastDelta, next, now) {
+				continue
+			}
+			is[bookKey] = true
+			state.stale = append(state.stale, health.StaleBook{SportKey: sportKey, BookKey: bookKey, LastDelta: lastDelta, NextTipoff: next})
+			if !was[bookKey] {
+				state.changed = append(state.changed, bookKey)
+				fmt.Printf("[StaleBook] %s %s silent for %v with tipoff in %v\n",
+					sportKey, bookKey, now.Sub(lastDelta).Round(time.Second), next.Sub(now).Round(time.Minute))
+			}
+		}
+		for bookKey := range was {
+			if !is[bookKey] {
+				state.changed = append(state.changed, bookKey)
+			}
+		}
+		t.stale[sportKey] = is
+
+		sort.Slice(state.stale, func(i, j int) bool { return state.stale[i].BookKey < state.stale[j].BookKey })
+		sort.Strings(state.changed)
+	}
+	listeners := t.listeners
+	t.mu.Unlock()
+
+	for sportKey, state := range states {
+		if t.reporter != nil {
+			if err := t.reporter.RecordStaleBooks(ctx, sportKey, state.stale, 3*t.config.Interval); err != nil {
+				fmt.Printf("[StaleBook] health report error: %v\n", err)
+			}
+		}
+		if len(state.changed) > 0 {
+			for _, fn := range listeners {
+				fn(ctx, sportKey, state.changed)
+			}
+		}
+	}
+}
+
+// Start begins periodic checks (no-op when detection is disabled)
+func (t *Tracker) Start(ctx context.Context) {
+	if t.config.SilentAfter <= 0 {
+		return
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		ticker := time.NewTicker(t.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				t.Check(ctx, time.Now())
+			case <-t.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("✓ Stale book detection started (silent > %v within %v of tipoff)\n",
+		t.config.SilentAfter, t.config.TipoffWindow)
+}
+
+// Stop stops periodic checks
+func (t *Tracker) Stop() {
+	close(t.stopChan)
+	t.wg.Wait()
+}
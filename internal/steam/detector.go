@@ -0,0 +1,341 @@
+// Package steam tracks line velocity per outcome and flags steam: rapid, correlated
+// movement of the same outcome across several books inside a short window.
+package steam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/pricebook"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamKey is the Redis stream receiving steam signals
+const StreamKey = "steam.detected"
+
+// Move is one book's change on an outcome
+type Move struct {
+	BookKey   string    `json:"book_key"`
+	OldPrice  int       `json:"old_price"`
+	NewPrice  int       `json:"new_price"`
+	OldPoint  *float64  `json:"old_point,omitempty"`
+	NewPoint  *float64  `json:"new_point,omitempty"`
+	Direction int       `json:"direction"` // +1 toward the outcome (shortening), -1 away
+	At        time.Time `json:"at"`
+
+	PriceDeltaCents int      `json:"price_delta_cents"`     // New minus old price in cents, across even money
+	PointDelta      *float64 `json:"point_delta,omitempty"` // New minus old point, when both have one
+}
+
+// Signal is a detected steam move on one outcome
+type Signal struct {
+	EventID        string    `json:"event_id"`
+	SportKey       string    `json:"sport_key"`
+	MarketKey      string    `json:"market_key"`
+	Description    string    `json:"description,omitempty"`
+	OutcomeName    string    `json:"outcome_name"`
+	Direction      string    `json:"direction"` // "toward" or "away" from the outcome
+	Books          []string  `json:"books"`
+	Moves          []Move    `json:"moves"`
+	MovesInWindow  int       `json:"moves_in_window"` // All moves on the outcome, either direction
+	VelocityPerMin float64   `json:"velocity_per_min"`
+	Window         string    `json:"window"`
+	FirstMoveAt    time.Time `json:"first_move_at"`
+	DetectedAt     time.Time `json:"detected_at"`
+}
+
+// outcomeState tracks the last quote per book and recent moves for one outcome
+type outcomeState struct {
+	last     map[string]models.RawOdds // book_key -> last quote
+	moves    []Move                    // Moves inside the window, oldest first
+	lastSent map[int]time.Time         // direction -> when steam was last signalled
+}
+
+// Detector watches committed deltas for steam
+type Detector struct {
+	redis         *redis.Client
+	sportRegistry *registry.SportRegistry
+
+	mu       sync.Mutex
+	outcomes map[string]*outcomeState // event|market|description|outcome -> state
+}
+
+// NewDetector creates a steam detector; sensitivity comes from each sport module
+func NewDetector(redisClient *redis.Client, sportRegistry *registry.SportRegistry) *Detector {
+	return &Detector{
+		redis:         redisClient,
+		sportRegistry: sportRegistry,
+		outcomes:      make(map[string]*outcomeState),
+	}
+}
+
+// HandleDeltaBatchCommitted records moves from a committed batch and publishes steam
+func (d *Detector) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	signals := d.Observe(msg.Odds)
+	if len(signals) == 0 {
+		return
+	}
+
+	if err := d.publish(ctx, signals); err != nil {
+		fmt.Printf("[Steam] publish error: %v\n", err)
+		return
+	}
+
+	for _, signal := range signals {
+		fmt.Printf("[Steam] %s %s %s %s across %d books in %s\n",
+			signal.EventID, signal.MarketKey, signal.OutcomeName, signal.Direction, len(signal.Books), signal.Window)
+	}
+}
+
+// HandleEventStatusChanged drops state for finished events
+func (d *Detector) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	switch msg.NewStatus {
+	case "completed", "cancelled", "postponed":
+		d.Evict(msg.EventID)
+	}
+}
+
+// Evict removes all state for an event
+func (d *Detector) Evict(eventID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prefix := eventID + "|"
+	for key := range d.outcomes {
+		if strings.HasPrefix(key, prefix) {
+			delete(d.outcomes, key)
+		}
+	}
+}
+
+// settings returns the steam sensitivity for a sport
+func (d *Detector) settings(sportKey string) models.SteamSettings {
+	if d.sportRegistry != nil {
+		if sport, ok := d.sportRegistry.Get(sportKey); ok {
+			if s := sport.GetSteamSettings(); s.Window > 0 && s.MinBooks > 0 {
+				return s
+			}
+		}
+	}
+	return models.DefaultSteamSettings
+}
+
+// Observe records moves from odds (timestamped by ReceivedAt) and returns new steam signals
+func (d *Detector) Observe(odds []models.RawOdds) []Signal {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	touched := make(map[string]models.RawOdds)
+	for _, odd := range odds {
+		key := outcomeID(odd)
+		state, ok := d.outcomes[key]
+		if !ok {
+			state = &outcomeState{
+				last:     make(map[string]models.RawOdds),
+				lastSent: make(map[int]time.Time),
+			}
+			d.outcomes[key] = state
+		}
+
+		settings := d.settings(odd.SportKey)
+		if prev, ok := state.last[odd.BookKey]; ok {
+			if dir := direction(prev, odd, settings.MinProbMove); dir != 0 {
+				state.moves = append(state.moves, Move{
+					BookKey:   odd.BookKey,
+					OldPrice:  prev.Price,
+					NewPrice:  odd.Price,
+					OldPoint:  prev.Point,
+					NewPoint:  odd.Point,
+					Direction: dir,
+					At:        odd.ReceivedAt,
+
+					PriceDeltaCents: oddsmath.PriceDeltaCents(prev.Price, odd.Price),
+					PointDelta:      pointDelta(prev.Point, odd.Point),
+				})
+				touched[key] = odd
+			}
+		}
+		state.last[odd.BookKey] = odd
+	}
+
+	var signals []Signal
+	for key, odd := range touched {
+		if signal, ok := d.evaluate(d.outcomes[key], odd, d.settings(odd.SportKey)); ok {
+			signals = append(signals, signal)
+		}
+	}
+
+	sort.Slice(signals, func(i, j int) bool { return signals[i].FirstMoveAt.Before(signals[j].FirstMoveAt) })
+	return signals
+}
+
+// evaluate prunes moves outside the window and checks for correlated movement
+func (d *Detector) evaluate(state *outcomeState, odd models.RawOdds, settings models.SteamSettings) (Signal, bool) {
+	now := odd.ReceivedAt
+	cutoff := now.Add(-settings.Window)
+
+	kept := state.moves[:0]
+	for _, move := range state.moves {
+		if move.At.After(cutoff) {
+			kept = append(kept, move)
+		}
+	}
+	state.moves = kept
+
+	// Each book counts once, by its latest move in the window
+	latest := make(map[string]Move)
+	for _, move := range state.moves {
+		latest[move.BookKey] = move
+	}
+
+	for _, dir := range []int{1, -1} {
+		var books []string
+		var moves []Move
+		for book, move := range latest {
+			if move.Direction == dir {
+				books = append(books, book)
+				moves = append(moves, move)
+			}
+		}
+
+		if len(books) < settings.MinBooks {
+			continue
+		}
+
+		// One signal per direction per window
+		if sent, ok := state.lastSent[dir]; ok && now.Sub(sent) < settings.Window {
+			continue
+		}
+		state.lastSent[dir] = now
+
+		sort.Strings(books)
+		sort.Slice(moves, func(i, j int) bool { return moves[i].At.Before(moves[j].At) })
+
+		signal := Signal{
+			EventID:        odd.EventID,
+			SportKey:       odd.SportKey,
+			MarketKey:      odd.MarketKey,
+			Description:    odd.Description,
+			OutcomeName:    odd.OutcomeName,
+			Direction:      "toward",
+			Books:          books,
+			Moves:          moves,
+			MovesInWindow:  len(state.moves),
+			VelocityPerMin: float64(len(state.moves)) / settings.Window.Minutes(),
+			Window:         settings.Window.String(),
+			FirstMoveAt:    moves[0].At,
+			DetectedAt:     now,
+		}
+		if dir < 0 {
+			signal.Direction = "away"
+		}
+		return signal, true
+	}
+
+	return Signal{}, false
+}
+
+// Velocity returns the moves per minute on an outcome over the sport's window
+func (d *Detector) Velocity(odd models.RawOdds, now time.Time) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, ok := d.outcomes[outcomeID(odd)]
+	if !ok {
+		return 0
+	}
+
+	settings := d.settings(odd.SportKey)
+	cutoff := now.Add(-settings.Window)
+	count := 0
+	for _, move := range state.moves {
+		if move.At.After(cutoff) {
+			count++
+		}
+	}
+	return float64(count) / settings.Window.Minutes()
+}
+
+// outcomeID identifies an outcome independent of its point, so line moves are tracked
+func outcomeID(odd models.RawOdds) string {
+	return odd.EventID + "|" + odd.MarketKey + "|" + odd.Description + "|" + odd.OutcomeName
+}
+
+// direction classifies a quote change: +1 toward the outcome, -1 away, 0 not a move
+// A point move dominates: a shorter spread or a higher Over / lower Under total means
+// money came in on the outcome. Otherwise the implied probability change must reach minProbMove.
+func direction(prev, next models.RawOdds, minProbMove float64) int {
+	if prev.Point != nil && next.Point != nil && *prev.Point != *next.Point {
+		change := *next.Point - *prev.Point
+		switch {
+		case pricebook.IsSpreadMarket(next.MarketKey):
+			change = -change // -3.5 -> -4.5: the side is laying more points
+		case next.OutcomeName == "Under":
+			change = -change
+		case next.OutcomeName != "Over":
+			return 0
+		}
+		if change > 0 {
+			return 1
+		}
+		return -1
+	}
+
+	prevDecimal, nextDecimal := prev.Decimal(), next.Decimal()
+	if prevDecimal <= 1 || nextDecimal <= 1 {
+		return 0
+	}
+
+	probChange := 1/nextDecimal - 1/prevDecimal
+	switch {
+	case probChange >= minProbMove:
+		return 1
+	case probChange <= -minProbMove:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// pointDelta returns next - prev when both quotes have a point
+func pointDelta(prev, next *float64) *float64 {
+	if prev == nil || next == nil {
+		return nil
+	}
+	delta := *next - *prev
+	return &delta
+}
+
+// publish appends steam signals to the steam stream
+func (d *Detector) publish(ctx context.Context, signals []Signal) error {
+	pipe := d.redis.Pipeline()
+
+	for _, signal := range signals {
+		msgJSON, err := json.Marshal(signal)
+		if err != nil {
+			return fmt.Errorf("marshal steam signal: %w", err)
+		}
+
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: StreamKey,
+			Values: map[string]interface{}{
+				"data": msgJSON,
+			},
+		})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("redis pipeline exec: %w", err)
+	}
+
+	return nil
+}
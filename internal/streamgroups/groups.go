@@ -0,0 +1,172 @@
+// Package streamgroups manages Redis consumer groups on the odds.raw.<sport> and
+// odds.live.<sport> streams: bootstrap on startup, lag tracking, and replaying a
+// stream into a group.
+package streamgroups
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/redis/go-redis/v9"
+)
+
+// GroupLag describes how far a consumer group is behind a stream
+type GroupLag struct {
+	Stream          string
+	Group           string
+	Consumers       int64
+	Pending         int64 // Delivered but not acked
+	Lag             int64 // Not yet delivered (-1 when Redis cannot report it, pre-7.0)
+	LastDeliveredID string
+}
+
+// Bootstrap creates each group on each sport's streams, starting at new entries
+// Existing groups keep their position
+func Bootstrap(ctx context.Context, redisClient *redis.Client, sports, groups []string) error {
+	for _, stream := range sportStreams(sports) {
+		for _, group := range groups {
+			err := redisClient.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+			if err != nil && !consumer.IsBusyGroup(err) {
+				return fmt.Errorf("create group %s on %s: %w", group, stream, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Lag returns lag for every consumer group on the given sports' streams
+func Lag(ctx context.Context, redisClient *redis.Client, sports []string) ([]GroupLag, error) {
+	var lags []GroupLag
+
+	for _, stream := range sportStreams(sports) {
+		groups, err := redisClient.XInfoGroups(ctx, stream).Result()
+		if err != nil {
+			if isNoSuchKey(err) {
+				continue // Stream not created yet
+			}
+			return nil, fmt.Errorf("xinfo groups %s: %w", stream, err)
+		}
+
+		for _, g := range groups {
+			lag := g.Lag
+			if lag < 0 {
+				lag = -1
+			}
+			lags = append(lags, GroupLag{
+				Stream:          stream,
+				Group:           g.Name,
+				Consumers:       g.Consumers,
+				Pending:         g.Pending,
+				Lag:             lag,
+				LastDeliveredID: g.LastDeliveredID,
+			})
+		}
+	}
+
+	return lags, nil
+}
+
+// Replay rewinds a group on a sport's odds streams (odds.raw and odds.live) so its
+// next reads start at fromID (inclusive)
+// The group is created at that position where it does not exist. Entries already
+// pending for the group's consumers are unaffected.
+func Replay(ctx context.Context, redisClient *redis.Client, sportKey, group, fromID string) error {
+	lastDelivered, err := precedingID(fromID)
+	if err != nil {
+		return err
+	}
+
+	for _, stream := range sportStreams([]string{sportKey}) {
+		err = redisClient.XGroupCreateMkStream(ctx, stream, group, lastDelivered).Err()
+		if err == nil {
+			continue
+		}
+		if !consumer.IsBusyGroup(err) {
+			return fmt.Errorf("create group %s on %s: %w", group, stream, err)
+		}
+
+		if err := redisClient.XGroupSetID(ctx, stream, group, lastDelivered).Err(); err != nil {
+			return fmt.Errorf("set group %s on %s to %s: %w", group, stream, lastDelivered, err)
+		}
+	}
+	return nil
+}
+
+// ParseStart converts a replay start to a stream entry ID
+// Accepts a stream ID (1700000000000-0 or 1700000000000), an RFC3339 timestamp,
+// or "beginning" for the whole stream
+func ParseStart(value string) (string, error) {
+	switch value {
+	case "", "beginning", "0", "-":
+		return "0-0", nil
+	}
+
+	if _, _, err := splitID(value); err == nil {
+		if !strings.Contains(value, "-") {
+			value += "-0"
+		}
+		return value, nil
+	}
+
+	t, err := timeutil.ParseVendorTime(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid replay start %q: want a stream ID or RFC3339 timestamp", value)
+	}
+	return consumer.IDFromTime(t), nil
+}
+
+// precedingID returns the ID just before id, so SETID to it makes id the next delivered entry
+func precedingID(id string) (string, error) {
+	ms, seq, err := splitID(id)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case seq > 0:
+		return fmt.Sprintf("%d-%d", ms, seq-1), nil
+	case ms > 0:
+		return fmt.Sprintf("%d-%d", ms-1, uint64(math.MaxUint64)), nil
+	default:
+		return "0-0", nil
+	}
+}
+
+// splitID parses "<ms>-<seq>" (or "<ms>") into its parts
+func splitID(id string) (uint64, uint64, error) {
+	msPart, seqPart, hasSeq := strings.Cut(id, "-")
+
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stream ID %q", id)
+	}
+
+	var seq uint64
+	if hasSeq {
+		seq, err = strconv.ParseUint(seqPart, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid stream ID %q", id)
+		}
+	}
+
+	return ms, seq, nil
+}
+
+// isNoSuchKey reports whether err is Redis' missing key error
+func isNoSuchKey(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such key")
+}
+
+// sportStreams lists the pre-match and in-play odds streams of each sport
+func sportStreams(sports []string) []string {
+	streams := make([]string, 0, 2*len(sports))
+	for _, sport := range sports {
+		streams = append(streams, consumer.StreamKey(sport), consumer.LiveStreamKey(sport))
+	}
+	return streams
+}
@@ -0,0 +1,96 @@
+package streamgroups
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/redis/go-redis/v9"
+)
+
+// LagMonitor periodically records consumer group lag for registered sports
+type LagMonitor struct {
+	redis         *redis.Client
+	sportRegistry *registry.SportRegistry
+	health        *health.Reporter
+	pollInterval  time.Duration
+	warnLag       int64 // Log a warning when lag + pending exceeds this (0 disables)
+	stopChan      chan struct{}
+}
+
+// NewLagMonitor creates a new consumer group lag monitor
+func NewLagMonitor(redisClient *redis.Client, sportRegistry *registry.SportRegistry, reporter *health.Reporter, pollInterval time.Duration, warnLag int64) *LagMonitor {
+	return &LagMonitor{
+		redis:         redisClient,
+		sportRegistry: sportRegistry,
+		health:        reporter,
+		pollInterval:  pollInterval,
+		warnLag:       warnLag,
+		stopChan:      make(chan struct{}),
+	}
+}
+
+// Start begins periodic lag checks
+func (m *LagMonitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+
+	fmt.Println("✓ Stream consumer lag monitor started")
+
+	m.check(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			m.check(ctx)
+		case <-m.stopChan:
+			fmt.Println("✓ Stream consumer lag monitor stopped")
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the monitor
+func (m *LagMonitor) Stop() {
+	close(m.stopChan)
+}
+
+// check reads lag for all groups, records it and warns on groups falling behind
+func (m *LagMonitor) check(ctx context.Context) {
+	sports := make([]string, 0)
+	for _, sport := range m.sportRegistry.GetAll() {
+		sports = append(sports, sport.GetSportKey())
+	}
+
+	lags, err := Lag(ctx, m.redis, sports)
+	if err != nil {
+		fmt.Printf("[StreamGroups] lag check error: %v\n", err)
+		return
+	}
+
+	streamLags := make([]health.StreamLag, len(lags))
+	for i, lag := range lags {
+		streamLags[i] = health.StreamLag{
+			Stream:    lag.Stream,
+			Group:     lag.Group,
+			Consumers: lag.Consumers,
+			Pending:   lag.Pending,
+			Lag:       lag.Lag,
+		}
+
+		if m.warnLag > 0 && lag.Lag+lag.Pending > m.warnLag {
+			fmt.Printf("[StreamGroups] WARNING: group %s on %s is behind (lag=%d pending=%d)\n",
+				lag.Group, lag.Stream, lag.Lag, lag.Pending)
+		}
+	}
+
+	if m.health != nil {
+		if err := m.health.RecordStreamLag(ctx, streamLags); err != nil {
+			fmt.Printf("[StreamGroups] health report error: %v\n", err)
+		}
+	}
+}
@@ -10,15 +10,24 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"sync"
 	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
 )
 
+// defaultBatchConcurrency bounds in-flight requests for BatchOpenGamePages
+const defaultBatchConcurrency = 5
+
 // Client handles HTTP communication with Talos Bot Manager for page warming
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
 	enabled    bool
-	books      []string // List of book keys to warm pages for
+	books      []string            // List of book keys to warm pages for
+	teamNames  *normalize.Registry // Canonical team names for requests and game keys (nil = names as given)
 }
 
 // Config holds configuration for the Talos client
@@ -71,6 +80,12 @@ func NewClient(cfg Config) *Client {
 	}
 }
 
+// SetTeamNames sets the team alias registry used for page requests and game keys,
+// so they match the names the adapter stores on events
+func (c *Client) SetTeamNames(registry *normalize.Registry) {
+	c.teamNames = registry
+}
+
 // IsEnabled returns whether page warming is enabled
 func (c *Client) IsEnabled() bool {
 	return c.enabled && c.baseURL != ""
@@ -83,12 +98,15 @@ func (c *Client) OpenGamePage(ctx context.Context, homeTeam, awayTeam, sport str
 		return nil
 	}
 
+	awayTeam = c.teamNames.TeamName(sport, awayTeam)
+	homeTeam = c.teamNames.TeamName(sport, homeTeam)
+
 	req := OpenGamePageRequest{
 		Team1:       awayTeam, // Away team first (convention)
 		Team2:       homeTeam, // Home team second
 		Sport:       mapSportKey(sport),
 		BetPeriod:   "game",
-		EventDate:   commenceTime.Format("2006-01-02"),
+		EventDate:   timeutil.LocalDate(commenceTime, sport), // Sport-local date, not UTC
 		TargetBooks: c.books,
 	}
 
@@ -116,20 +134,72 @@ func (c *Client) OpenGamePage(ctx context.Context, homeTeam, awayTeam, sport str
 		return fmt.Errorf("failed to read response: %w", err)
 	}
 
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("open game page failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
 	var pageResp PageActionResponse
 	if err := json.Unmarshal(body, &pageResp); err != nil {
 		return fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	if !pageResp.AnyOK {
-		log.Printf("[Talos] Warning: No bots warmed page for %s @ %s", awayTeam, homeTeam)
-	} else {
-		log.Printf("[Talos] Game page warmed: %s @ %s (all_ok=%v)", awayTeam, homeTeam, pageResp.AllOK)
+		// Returned as an error so the warm queue retries later (book may not list the game yet)
+		return fmt.Errorf("no bots warmed page for %s @ %s", awayTeam, homeTeam)
 	}
 
+	log.Printf("[Talos] Game page warmed: %s @ %s (all_ok=%v)", awayTeam, homeTeam, pageResp.AllOK)
 	return nil
 }
 
+// GamePage identifies a game page to open
+type GamePage struct {
+	HomeTeam     string
+	AwayTeam     string
+	Sport        string
+	CommenceTime time.Time
+}
+
+// BatchOpenGamePages warms many game pages concurrently with at most
+// concurrency requests in flight. Returns one error slot per page (nil on success).
+func (c *Client) BatchOpenGamePages(ctx context.Context, pages []GamePage, concurrency int) []error {
+	errs := make([]error, len(pages))
+	if !c.IsEnabled() || len(pages) == 0 {
+		return errs
+	}
+
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, page := range pages {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// Mark the remaining pages as not attempted
+			for j := i; j < len(pages); j++ {
+				errs[j] = ctx.Err()
+			}
+			wg.Wait()
+			return errs
+		}
+
+		wg.Add(1)
+		go func(i int, page GamePage) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = c.OpenGamePage(ctx, page.HomeTeam, page.AwayTeam, page.Sport, page.CommenceTime)
+		}(i, page)
+	}
+
+	wg.Wait()
+	return errs
+}
+
 // CloseGamePage closes a game page across all configured books
 // Called when an event is marked as completed
 func (c *Client) CloseGamePage(ctx context.Context, gameKey string) error {
@@ -182,12 +252,12 @@ func (c *Client) CloseGamePageForEvent(ctx context.Context, homeTeam, awayTeam,
 	}
 
 	// Build game key for each book and close
-	dateStr := commenceTime.Format("20060102")
+	dateStr := timeutil.LocalDateCompact(commenceTime, sport)
 	sportKey := mapSportKey(sport)
 
 	// Normalize team names for key
-	team1 := normalizeTeamName(awayTeam)
-	team2 := normalizeTeamName(homeTeam)
+	team1 := normalize.Slug(c.teamNames.TeamName(sport, awayTeam))
+	team2 := normalize.Slug(c.teamNames.TeamName(sport, homeTeam))
 
 	// Ensure consistent ordering (alphabetical)
 	if team1 > team2 {
@@ -206,6 +276,34 @@ func (c *Client) CloseGamePageForEvent(ctx context.Context, homeTeam, awayTeam,
 	return nil
 }
 
+// IsTerminalStatus reports whether an event status means its game pages should be closed
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case "completed", "cancelled", "postponed":
+		return true
+	default:
+		return false
+	}
+}
+
+// HandleEventStatusChanged closes game pages when an event completes, is cancelled,
+// or is postponed (bus subscriber)
+func (c *Client) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	if !c.IsEnabled() || !IsTerminalStatus(msg.NewStatus) {
+		return
+	}
+
+	closeCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := c.CloseGamePageForEvent(closeCtx, msg.HomeTeam, msg.AwayTeam, msg.SportKey, msg.CommenceTime); err != nil {
+		log.Printf("[Talos] Page close failed for %s @ %s: %v", msg.AwayTeam, msg.HomeTeam, err)
+		return
+	}
+
+	log.Printf("[Talos] Closed pages for %s @ %s (%s)", msg.AwayTeam, msg.HomeTeam, msg.NewStatus)
+}
+
 // mapSportKey converts API sport keys to normalized format
 func mapSportKey(sport string) string {
 	switch sport {
@@ -221,19 +319,3 @@ func mapSportKey(sport string) string {
 		return sport
 	}
 }
-
-// normalizeTeamName converts team name to slug format for game key
-func normalizeTeamName(name string) string {
-	// Simple normalization - lowercase and replace spaces with underscores
-	result := ""
-	for _, c := range name {
-		if c >= 'A' && c <= 'Z' {
-			result += string(c + 32) // lowercase
-		} else if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
-			result += string(c)
-		} else if c == ' ' {
-			result += "_"
-		}
-	}
-	return result
-}
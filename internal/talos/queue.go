@@ -0,0 +1,651 @@
+package talos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/leader"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	warmQueueKey            = "talos:warm:queue"       // Pending jobs (list)
+	warmProcessingPrefix    = "talos:warm:processing:" // Jobs being worked by one consumer (list per consumer ID)
+	warmConsumersKey        = "talos:warm:consumers"   // Consumer leases (zset: consumer ID scored by lease expiry)
+	legacyWarmProcessingKey = "talos:warm:processing"  // Shared processing list written by older versions
+	warmRetryKey            = "talos:warm:retry"       // Failed jobs awaiting retry (zset scored by due time)
+	warmDeadKey             = "talos:warm:dead"        // Jobs that exhausted retries (list)
+	openPagesKey            = "talos:pages:open"       // Successfully warmed pages (hash: event_id -> job)
+
+	defaultWarmMaxAttempts = 5
+	defaultWarmBaseBackoff = 30 * time.Second
+	defaultWarmMaxBackoff  = 30 * time.Minute
+	defaultWarmRateLimit   = 1 * time.Second
+	defaultWarmBatchSize   = 10
+	defaultWarmStatsEvery  = 60 * time.Second
+	defaultWarmCallTimeout = 30 * time.Second
+	defaultWarmLeaseTTL    = 2 * time.Minute
+	maxDeadLetterJobs      = 1000
+
+	// Most sportsbooks only list games 1-3 days ahead
+	warmWindow = 72 * time.Hour
+)
+
+// WarmJob is a persisted request to warm a game page
+type WarmJob struct {
+	EventID      string    `json:"event_id"`
+	SportKey     string    `json:"sport_key"`
+	HomeTeam     string    `json:"home_team"`
+	AwayTeam     string    `json:"away_team"`
+	CommenceTime time.Time `json:"commence_time"`
+	Attempts     int       `json:"attempts"`
+	LastError    string    `json:"last_error,omitempty"`
+	EnqueuedAt   time.Time `json:"enqueued_at"`
+}
+
+// WarmQueueConfig holds retry and pacing configuration for the warm queue
+type WarmQueueConfig struct {
+	MaxAttempts int           // Attempts before a job is moved to the dead letter list
+	BaseBackoff time.Duration // First retry delay (doubles per attempt)
+	MaxBackoff  time.Duration // Cap on retry delay
+	RateLimit   time.Duration // Minimum delay between batches
+	BatchSize   int           // Jobs pulled per batch
+	Concurrency int           // Concurrent OpenGamePage calls within a batch
+	StatsEvery  time.Duration // How often to log queue depth
+	ConsumerID  string        // Names this replica's processing list (default hostname-pid)
+	LeaseTTL    time.Duration // How long a consumer's jobs stay its own without a heartbeat
+}
+
+// QueueStats reports warm queue depth
+type QueueStats struct {
+	Pending    int64
+	Processing int64
+	Retrying   int64
+	Dead       int64
+	Open       int64 // Pages warmed and not yet closed
+}
+
+// WarmQueue is a Redis-backed queue of page warm requests with retry and backoff
+// Each consumer works jobs from its own processing list and heartbeats a lease;
+// jobs survive restarts because a consumer's list is requeued on its next Start,
+// or by any replica once its lease has expired
+type WarmQueue struct {
+	redis  *redis.Client
+	client *Client
+	config WarmQueueConfig
+	clock  clock.Clock
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewWarmQueue creates a new persistent warm queue
+func NewWarmQueue(redisClient *redis.Client, client *Client, cfg WarmQueueConfig) *WarmQueue {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWarmMaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaultWarmBaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaultWarmMaxBackoff
+	}
+	if cfg.RateLimit <= 0 {
+		cfg.RateLimit = defaultWarmRateLimit
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWarmBatchSize
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultBatchConcurrency
+	}
+	if cfg.StatsEvery <= 0 {
+		cfg.StatsEvery = defaultWarmStatsEvery
+	}
+	if cfg.ConsumerID == "" {
+		cfg.ConsumerID = leader.DefaultInstanceID()
+	}
+	if cfg.LeaseTTL <= 0 {
+		cfg.LeaseTTL = defaultWarmLeaseTTL
+	}
+
+	return &WarmQueue{
+		redis:    redisClient,
+		client:   client,
+		config:   cfg,
+		clock:    clock.Real,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetClock replaces the wall clock (tests drive batches and backoff with a clock.Fake)
+func (q *WarmQueue) SetClock(c clock.Clock) {
+	q.clock = c
+}
+
+// processingKey is this consumer's processing list
+func (q *WarmQueue) processingKey() string {
+	return processingKeyFor(q.config.ConsumerID)
+}
+
+func processingKeyFor(consumerID string) string {
+	return warmProcessingPrefix + consumerID
+}
+
+// IsEnabled returns whether the underlying Talos client is enabled
+func (q *WarmQueue) IsEnabled() bool {
+	return q.client != nil && q.client.IsEnabled()
+}
+
+// Enqueue persists warm jobs for the worker to process
+func (q *WarmQueue) Enqueue(ctx context.Context, jobs ...WarmJob) error {
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, 0, len(jobs))
+	now := q.clock.Now().UTC()
+	for _, job := range jobs {
+		if job.EnqueuedAt.IsZero() {
+			job.EnqueuedAt = now
+		}
+
+		data, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("marshal warm job: %w", err)
+		}
+		values = append(values, data)
+	}
+
+	if err := q.redis.RPush(ctx, warmQueueKey, values...).Err(); err != nil {
+		return fmt.Errorf("rpush warm jobs: %w", err)
+	}
+
+	return nil
+}
+
+// HandleEventDiscovered enqueues warm jobs for newly discovered events (bus subscriber)
+// Only warms upcoming events within 72 hours (most sportsbooks only list 1-3 days ahead)
+func (q *WarmQueue) HandleEventDiscovered(ctx context.Context, msg bus.EventDiscovered) {
+	if !q.IsEnabled() {
+		return
+	}
+
+	now := q.clock.Now()
+
+	var toWarm []models.Event
+	var skippedFuture int
+
+	for _, evt := range msg.Events {
+		// Only warm pages for upcoming events (not live or completed)
+		if evt.EventStatus != "" && evt.EventStatus != "upcoming" {
+			continue
+		}
+
+		// Skip if commence time is in the past
+		if evt.CommenceTime.Before(now) {
+			continue
+		}
+
+		// Skip if event is too far in the future (sportsbook won't have it listed)
+		if evt.CommenceTime.After(now.Add(warmWindow)) {
+			skippedFuture++
+			continue
+		}
+
+		toWarm = append(toWarm, evt)
+	}
+
+	if len(toWarm) == 0 {
+		if skippedFuture > 0 {
+			log.Printf("[Talos] Skipped %d events beyond 72h window", skippedFuture)
+		}
+		return
+	}
+
+	if skippedFuture > 0 {
+		log.Printf("[Talos] Warming %d events (skipped %d beyond 72h window)", len(toWarm), skippedFuture)
+	} else {
+		log.Printf("[Talos] Warming %d new events...", len(toWarm))
+	}
+
+	if err := q.Enqueue(ctx, WarmJobsFromEvents(toWarm)...); err != nil {
+		log.Printf("[Talos] Warning: Failed to enqueue page warm jobs: %v", err)
+	}
+}
+
+// WarmJobsFromEvents converts events into warm jobs
+func WarmJobsFromEvents(events []models.Event) []WarmJob {
+	jobs := make([]WarmJob, len(events))
+	for i, e := range events {
+		jobs[i] = WarmJob{
+			EventID:      e.EventID,
+			SportKey:     e.SportKey,
+			HomeTeam:     e.HomeTeam,
+			AwayTeam:     e.AwayTeam,
+			CommenceTime: e.CommenceTime,
+		}
+	}
+	return jobs
+}
+
+// Start takes this consumer's lease, recovers interrupted jobs and begins the
+// background worker
+func (q *WarmQueue) Start(ctx context.Context) {
+	if err := q.renewLease(ctx); err != nil {
+		log.Printf("[Talos] Warning: Failed to take warm queue lease: %v", err)
+	}
+	if n, err := q.recoverProcessing(ctx); err != nil {
+		log.Printf("[Talos] Warning: Failed to recover in-flight warm jobs: %v", err)
+	} else if n > 0 {
+		log.Printf("[Talos] Requeued %d interrupted warm job(s)", n)
+	}
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		q.run(ctx)
+	}()
+}
+
+// Stop gracefully stops the worker (pending jobs remain in Redis)
+func (q *WarmQueue) Stop() {
+	close(q.stopChan)
+	q.wg.Wait()
+}
+
+// Stats returns current queue depth (Processing counts every consumer's list)
+func (q *WarmQueue) Stats(ctx context.Context) (QueueStats, error) {
+	consumers, err := q.redis.ZRange(ctx, warmConsumersKey, 0, -1).Result()
+	if err != nil {
+		return QueueStats{}, fmt.Errorf("queue stats: %w", err)
+	}
+
+	pipe := q.redis.Pipeline()
+	pending := pipe.LLen(ctx, warmQueueKey)
+	processing := []*redis.IntCmd{pipe.LLen(ctx, legacyWarmProcessingKey)}
+	for _, consumerID := range consumers {
+		processing = append(processing, pipe.LLen(ctx, processingKeyFor(consumerID)))
+	}
+	retrying := pipe.ZCard(ctx, warmRetryKey)
+	dead := pipe.LLen(ctx, warmDeadKey)
+	open := pipe.HLen(ctx, openPagesKey)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return QueueStats{}, fmt.Errorf("queue stats: %w", err)
+	}
+
+	var inFlight int64
+	for _, cmd := range processing {
+		inFlight += cmd.Val()
+	}
+
+	return QueueStats{
+		Pending:    pending.Val(),
+		Processing: inFlight,
+		Retrying:   retrying.Val(),
+		Dead:       dead.Val(),
+		Open:       open.Val(),
+	}, nil
+}
+
+// run is the worker loop: one concurrent batch per rate-limit tick
+func (q *WarmQueue) run(ctx context.Context) {
+	ticker := q.clock.NewTicker(q.config.RateLimit)
+	defer ticker.Stop()
+
+	statsTicker := q.clock.NewTicker(q.config.StatsEvery)
+	defer statsTicker.Stop()
+
+	for {
+		select {
+		case <-ticker.C():
+			if err := q.renewLease(ctx); err != nil {
+				log.Printf("[Talos] Warning: Failed to renew warm queue lease: %v", err)
+			}
+			if err := q.promoteDueRetries(ctx); err != nil {
+				log.Printf("[Talos] Warning: Failed to promote warm retries: %v", err)
+			}
+			if err := q.processBatch(ctx); err != nil {
+				log.Printf("[Talos] Warning: Warm queue error: %v", err)
+			}
+		case <-statsTicker.C():
+			if n, err := q.recoverExpired(ctx); err != nil {
+				log.Printf("[Talos] Warning: Failed to recover abandoned warm jobs: %v", err)
+			} else if n > 0 {
+				log.Printf("[Talos] Requeued %d warm job(s) from expired consumers", n)
+			}
+			q.logStats(ctx)
+		case <-q.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// processBatch moves up to BatchSize jobs to this consumer's processing list,
+// warms them concurrently, and acknowledges them
+func (q *WarmQueue) processBatch(ctx context.Context) error {
+	processingKey := q.processingKey()
+
+	var raws []string
+	for len(raws) < q.config.BatchSize {
+		raw, err := q.redis.LMove(ctx, warmQueueKey, processingKey, "LEFT", "RIGHT").Result()
+		if err == redis.Nil {
+			break // Queue empty
+		}
+		if err != nil {
+			return fmt.Errorf("lmove warm job: %w", err)
+		}
+		raws = append(raws, raw)
+	}
+
+	if len(raws) == 0 {
+		return nil
+	}
+
+	// Acknowledge (remove from processing) regardless of outcome; failures are rescheduled
+	defer func() {
+		pipe := q.redis.Pipeline()
+		for _, raw := range raws {
+			pipe.LRem(ctx, processingKey, 1, raw)
+		}
+		pipe.Exec(ctx)
+	}()
+
+	now := q.clock.Now()
+	jobs := make([]WarmJob, 0, len(raws))
+	pages := make([]GamePage, 0, len(raws))
+
+	for _, raw := range raws {
+		var job WarmJob
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			log.Printf("[Talos] Warning: Dropping malformed warm job: %v", err)
+			continue
+		}
+
+		// No point warming a page for a game that has already started
+		if job.CommenceTime.Before(now) {
+			continue
+		}
+
+		jobs = append(jobs, job)
+		pages = append(pages, GamePage{
+			HomeTeam:     job.HomeTeam,
+			AwayTeam:     job.AwayTeam,
+			Sport:        job.SportKey,
+			CommenceTime: job.CommenceTime,
+		})
+	}
+
+	if len(pages) == 0 {
+		return nil
+	}
+
+	warmCtx, cancel := context.WithTimeout(ctx, defaultWarmCallTimeout)
+	errs := q.client.BatchOpenGamePages(warmCtx, pages, q.config.Concurrency)
+	cancel()
+
+	for i, err := range errs {
+		if err == nil {
+			if err := q.markPageOpen(ctx, jobs[i]); err != nil {
+				log.Printf("[Talos] Warning: %v", err)
+			}
+			continue
+		}
+
+		job := jobs[i]
+		job.Attempts++
+		job.LastError = err.Error()
+		if err := q.reschedule(ctx, job); err != nil {
+			log.Printf("[Talos] Warning: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// markPageOpen records a warmed page so it can be closed or reconciled later
+func (q *WarmQueue) markPageOpen(ctx context.Context, job WarmJob) error {
+	job.Attempts = 0
+	job.LastError = ""
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal open page: %w", err)
+	}
+
+	if err := q.redis.HSet(ctx, openPagesKey, job.EventID, data).Err(); err != nil {
+		return fmt.Errorf("record open page: %w", err)
+	}
+	return nil
+}
+
+// OpenPages returns every page currently recorded as warmed
+func (q *WarmQueue) OpenPages(ctx context.Context) ([]WarmJob, error) {
+	values, err := q.redis.HGetAll(ctx, openPagesKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("list open pages: %w", err)
+	}
+
+	pages := make([]WarmJob, 0, len(values))
+	for eventID, raw := range values {
+		var job WarmJob
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			log.Printf("[Talos] Warning: Malformed open page record for %s: %v", eventID, err)
+			job = WarmJob{EventID: eventID}
+		}
+		pages = append(pages, job)
+	}
+	return pages, nil
+}
+
+// ClosePage closes an open page via Talos and forgets it
+func (q *WarmQueue) ClosePage(ctx context.Context, page WarmJob) error {
+	if page.HomeTeam != "" && page.AwayTeam != "" {
+		if err := q.client.CloseGamePageForEvent(ctx, page.HomeTeam, page.AwayTeam, page.SportKey, page.CommenceTime); err != nil {
+			return fmt.Errorf("close page for %s: %w", page.EventID, err)
+		}
+	}
+
+	if err := q.redis.HDel(ctx, openPagesKey, page.EventID).Err(); err != nil {
+		return fmt.Errorf("forget open page: %w", err)
+	}
+	return nil
+}
+
+// HandleEventStatusChanged closes pages for events that completed, were cancelled,
+// or were postponed, and forgets them (bus subscriber)
+func (q *WarmQueue) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	if !q.IsEnabled() || !IsTerminalStatus(msg.NewStatus) {
+		return
+	}
+
+	q.client.HandleEventStatusChanged(ctx, msg)
+
+	if err := q.redis.HDel(ctx, openPagesKey, msg.EventID).Err(); err != nil {
+		log.Printf("[Talos] Warning: Failed to forget open page %s: %v", msg.EventID, err)
+	}
+}
+
+// reschedule puts a failed job on the retry set, or the dead letter list if retries are exhausted
+func (q *WarmQueue) reschedule(ctx context.Context, job WarmJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("marshal warm job: %w", err)
+	}
+
+	if job.Attempts >= q.config.MaxAttempts {
+		log.Printf("[Talos] Giving up warming %s @ %s after %d attempts: %s",
+			job.AwayTeam, job.HomeTeam, job.Attempts, job.LastError)
+
+		pipe := q.redis.Pipeline()
+		pipe.LPush(ctx, warmDeadKey, data)
+		pipe.LTrim(ctx, warmDeadKey, 0, maxDeadLetterJobs-1)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return fmt.Errorf("dead letter warm job: %w", err)
+		}
+		return nil
+	}
+
+	backoff := q.backoff(job.Attempts)
+	log.Printf("[Talos] Page warm failed for %s @ %s (attempt %d), retrying in %v: %s",
+		job.AwayTeam, job.HomeTeam, job.Attempts, backoff, job.LastError)
+
+	dueAt := q.clock.Now().Add(backoff)
+	if err := q.redis.ZAdd(ctx, warmRetryKey, redis.Z{
+		Score:  float64(dueAt.Unix()),
+		Member: data,
+	}).Err(); err != nil {
+		return fmt.Errorf("schedule warm retry: %w", err)
+	}
+
+	return nil
+}
+
+// backoff returns the exponential retry delay for the given attempt count
+func (q *WarmQueue) backoff(attempts int) time.Duration {
+	delay := q.config.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= q.config.MaxBackoff {
+			return q.config.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// promoteDueRetries moves retry jobs whose backoff has elapsed back onto the pending queue
+func (q *WarmQueue) promoteDueRetries(ctx context.Context) error {
+	due, err := q.redis.ZRangeByScore(ctx, warmRetryKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(q.clock.Now().Unix(), 10),
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("query due retries: %w", err)
+	}
+
+	for _, member := range due {
+		// Only the worker that removes the member requeues it
+		removed, err := q.redis.ZRem(ctx, warmRetryKey, member).Result()
+		if err != nil {
+			return fmt.Errorf("remove due retry: %w", err)
+		}
+		if removed == 0 {
+			continue
+		}
+
+		if err := q.redis.RPush(ctx, warmQueueKey, member).Err(); err != nil {
+			return fmt.Errorf("requeue due retry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renewLease extends this consumer's claim on its processing list
+func (q *WarmQueue) renewLease(ctx context.Context) error {
+	expiresAt := q.clock.Now().Add(q.config.LeaseTTL)
+	if err := q.redis.ZAdd(ctx, warmConsumersKey, redis.Z{
+		Score:  float64(expiresAt.Unix()),
+		Member: q.config.ConsumerID,
+	}).Err(); err != nil {
+		return fmt.Errorf("renew warm lease: %w", err)
+	}
+	return nil
+}
+
+// recoverProcessing requeues jobs this consumer had in flight when it last stopped,
+// jobs left in the shared list by older versions, and jobs of expired consumers.
+// Other live replicas' processing lists are left alone
+func (q *WarmQueue) recoverProcessing(ctx context.Context) (int, error) {
+	count, err := q.requeueList(ctx, q.processingKey())
+	if err != nil {
+		return count, err
+	}
+
+	n, err := q.requeueList(ctx, legacyWarmProcessingKey)
+	count += n
+	if err != nil {
+		return count, err
+	}
+
+	n, err = q.recoverExpired(ctx)
+	return count + n, err
+}
+
+// recoverExpired requeues the processing lists of consumers whose lease has lapsed
+// (crashed replicas that never came back under the same ID)
+func (q *WarmQueue) recoverExpired(ctx context.Context) (int, error) {
+	expired, err := q.redis.ZRangeByScore(ctx, warmConsumersKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(q.clock.Now().Unix(), 10),
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("query expired consumers: %w", err)
+	}
+
+	count := 0
+	for _, consumerID := range expired {
+		if consumerID == q.config.ConsumerID {
+			continue
+		}
+
+		// Only the worker that removes the lease requeues the list
+		removed, err := q.redis.ZRem(ctx, warmConsumersKey, consumerID).Result()
+		if err != nil {
+			return count, fmt.Errorf("remove expired consumer: %w", err)
+		}
+		if removed == 0 {
+			continue
+		}
+
+		n, err := q.requeueList(ctx, processingKeyFor(consumerID))
+		count += n
+		if err != nil {
+			return count, err
+		}
+	}
+
+	return count, nil
+}
+
+// requeueList moves every job in a processing list back to the front of the queue
+func (q *WarmQueue) requeueList(ctx context.Context, key string) (int, error) {
+	count := 0
+	for {
+		_, err := q.redis.LMove(ctx, key, warmQueueKey, "RIGHT", "LEFT").Result()
+		if err == redis.Nil {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("recover processing: %w", err)
+		}
+		count++
+	}
+}
+
+// logStats logs queue depth when there is anything outstanding
+func (q *WarmQueue) logStats(ctx context.Context) {
+	stats, err := q.Stats(ctx)
+	if err != nil {
+		log.Printf("[Talos] Warning: %v", err)
+		return
+	}
+
+	if stats.Pending+stats.Processing+stats.Retrying+stats.Dead == 0 {
+		return
+	}
+
+	log.Printf("[Talos] Warm queue depth: pending=%d processing=%d retrying=%d dead=%d open=%d",
+		stats.Pending, stats.Processing, stats.Retrying, stats.Dead, stats.Open)
+}
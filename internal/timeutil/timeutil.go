@@ -0,0 +1,170 @@
+// Package timeutil centralizes time handling so every stored timestamp is UTC.
+//
+// Rules enforced across Mercury:
+//   - Vendor timestamps are parsed with ParseVendorTime (never time.Parse directly)
+//   - Timestamps are converted with UTC before being stored or published
+//   - Sport-local time is only used for display and slate/date math (LocalDate, SlateBounds)
+package timeutil
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // Embed zone data so sport locations resolve in minimal containers
+)
+
+// vendorLayouts are accepted vendor timestamp formats, most specific first
+// Layouts without a zone are interpreted in the configured vendor location
+var vendorLayouts = []struct {
+	layout  string
+	hasZone bool
+}{
+	{time.RFC3339Nano, true},
+	{time.RFC3339, true},
+	{"2006-01-02T15:04:05.999999999", false},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02T15:04Z07:00", true},
+	{"2006-01-02T15:04", false},
+}
+
+// sportLocations maps sport keys to the timezone used for their slates
+var sportLocations = map[string]string{
+	"basketball_nba":         "America/New_York",
+	"basketball_wnba":        "America/New_York",
+	"basketball_ncaab":       "America/New_York",
+	"americanfootball_nfl":   "America/New_York",
+	"americanfootball_ncaaf": "America/New_York",
+	"baseball_mlb":           "America/New_York",
+	"icehockey_nhl":          "America/New_York",
+}
+
+// defaultSportLocation is used for sports without a configured slate timezone
+const defaultSportLocation = "America/New_York"
+
+var (
+	vendorLocation   = time.UTC
+	vendorLocationMu sync.RWMutex
+)
+
+// SetVendorLocation configures the timezone assumed for vendor timestamps that carry no offset
+// Defaults to UTC. Accepts IANA names (e.g. "America/New_York") or "UTC".
+func SetVendorLocation(name string) error {
+	if name == "" {
+		name = "UTC"
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("load vendor timezone %q: %w", name, err)
+	}
+
+	vendorLocationMu.Lock()
+	vendorLocation = loc
+	vendorLocationMu.Unlock()
+	return nil
+}
+
+// VendorLocation returns the timezone assumed for vendor timestamps without an offset
+func VendorLocation() *time.Location {
+	vendorLocationMu.RLock()
+	defer vendorLocationMu.RUnlock()
+	return vendorLocation
+}
+
+// Now returns the current time in UTC
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// UTC normalizes a timestamp to UTC (zero times stay zero)
+func UTC(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.UTC()
+}
+
+// FormatVendorTime formats a timestamp for vendor query parameters
+// (UTC ISO 8601 without fractional seconds, e.g. 2025-01-15T00:00:00Z)
+func FormatVendorTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// ParseVendorTime parses a vendor timestamp and returns it in UTC
+// Timestamps without an offset are interpreted in the configured vendor location
+func ParseVendorTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty timestamp")
+	}
+
+	for _, l := range vendorLayouts {
+		var t time.Time
+		var err error
+		if l.hasZone {
+			t, err = time.Parse(l.layout, value)
+		} else {
+			t, err = time.ParseInLocation(l.layout, value, VendorLocation())
+		}
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized timestamp format: %q", value)
+}
+
+// ParseVendorTimeOr parses a vendor timestamp, returning fallback (in UTC) if it is invalid
+func ParseVendorTimeOr(value string, fallback time.Time) time.Time {
+	t, err := ParseVendorTime(value)
+	if err != nil {
+		return UTC(fallback)
+	}
+	return t
+}
+
+// ParseHTTPTime parses an HTTP date header (e.g. Retry-After) and returns it in UTC
+func ParseHTTPTime(value string) (time.Time, error) {
+	t, err := http.ParseTime(strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognized HTTP date: %q", value)
+	}
+	return t.UTC(), nil
+}
+
+// SportLocation returns the timezone used for a sport's slates and display
+func SportLocation(sportKey string) *time.Location {
+	name, ok := sportLocations[sportKey]
+	if !ok {
+		name = defaultSportLocation
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LocalDate returns the sport-local calendar date (YYYY-MM-DD) for a timestamp
+// A 10pm ET tipoff is still "today" locally even though it is tomorrow in UTC
+func LocalDate(t time.Time, sportKey string) string {
+	return t.In(SportLocation(sportKey)).Format("2006-01-02")
+}
+
+// LocalDateCompact returns the sport-local date as YYYYMMDD
+func LocalDateCompact(t time.Time, sportKey string) string {
+	return t.In(SportLocation(sportKey)).Format("20060102")
+}
+
+// SlateBounds returns the UTC [start, end) of the sport-local day containing t
+func SlateBounds(t time.Time, sportKey string) (time.Time, time.Time) {
+	loc := SportLocation(sportKey)
+	local := t.In(loc)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1)
+	return start.UTC(), end.UTC()
+}
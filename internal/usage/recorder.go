@@ -0,0 +1,191 @@
+// Package usage persists the credit cost of every vendor request and rolls it up
+// per day, sport, endpoint and market so the API budget can be accounted for.
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultQueueSize = 1024
+	maxBatch         = 100
+	insertTimeout    = 10 * time.Second
+)
+
+// Ensure Recorder implements UsageSink
+var _ contracts.UsageSink = (*Recorder)(nil)
+
+// Recorder writes usage records to the vendor_usage table in the background, so
+// requests never wait on Postgres; records are dropped (and counted) when the
+// queue is full
+type Recorder struct {
+	db       *sql.DB
+	health   *health.Reporter // Optional: today's per-sport usage for `mercury top`
+	queue    chan models.VendorUsage
+	written  atomic.Int64
+	dropped  atomic.Int64
+	failed   atomic.Int64
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewRecorder creates a usage recorder writing to db
+func NewRecorder(db *sql.DB) *Recorder {
+	return &Recorder{
+		db:       db,
+		queue:    make(chan models.VendorUsage, defaultQueueSize),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetHealthReporter also adds each request's cost to the Redis health usage counters
+func (r *Recorder) SetHealthReporter(reporter *health.Reporter) {
+	r.health = reporter
+}
+
+// RecordUsage queues one request's usage without blocking
+func (r *Recorder) RecordUsage(usage models.VendorUsage) {
+	select {
+	case r.queue <- usage:
+	default:
+		if r.dropped.Add(1) == 1 {
+			fmt.Println("[Usage] queue full, dropping records")
+		}
+	}
+}
+
+// Start begins writing queued records
+func (r *Recorder) Start(ctx context.Context) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			select {
+			case usage := <-r.queue:
+				r.write(ctx, r.batch(usage))
+			case <-r.stopChan:
+				r.drain(ctx)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop writes anything still queued and stops the recorder
+func (r *Recorder) Stop() {
+	close(r.stopChan)
+	r.wg.Wait()
+
+	fmt.Printf("[Usage] stopped (%d written, %d failed, %d dropped)\n",
+		r.written.Load(), r.failed.Load(), r.dropped.Load())
+}
+
+// batch collects first plus whatever else is already queued, up to maxBatch
+func (r *Recorder) batch(first models.VendorUsage) []models.VendorUsage {
+	records := []models.VendorUsage{first}
+	for len(records) < maxBatch {
+		select {
+		case usage := <-r.queue:
+			records = append(records, usage)
+		default:
+			return records
+		}
+	}
+	return records
+}
+
+// drain writes records queued before Stop
+func (r *Recorder) drain(ctx context.Context) {
+	for {
+		select {
+		case usage := <-r.queue:
+			r.write(ctx, r.batch(usage))
+		default:
+			return
+		}
+	}
+}
+
+// write inserts one batch and updates the health counters
+func (r *Recorder) write(ctx context.Context, records []models.VendorUsage) {
+	insertCtx, cancel := context.WithTimeout(ctx, insertTimeout)
+	defer cancel()
+
+	if r.health != nil {
+		for _, usage := range records {
+			if err := r.health.RecordUsage(insertCtx, usage); err != nil {
+				fmt.Printf("health report error: %v\n", err)
+				break
+			}
+		}
+	}
+
+	if err := r.insert(insertCtx, records); err != nil {
+		r.failed.Add(int64(len(records)))
+		fmt.Printf("[Usage] insert error: %v\n", err)
+		return
+	}
+	r.written.Add(int64(len(records)))
+}
+
+func (r *Recorder) insert(ctx context.Context, records []models.VendorUsage) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO vendor_usage (
+			vendor, kind, sport_key, event_id, markets, status_code, cost, used, remaining, requested_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, usage := range records {
+		markets := usage.Markets
+		if markets == nil {
+			markets = []string{}
+		}
+		_, err := stmt.ExecContext(ctx,
+			usage.Vendor,
+			string(usage.Kind),
+			usage.SportKey,
+			usage.EventID,
+			pq.Array(markets),
+			usage.StatusCode,
+			nullCount(usage.Cost),
+			nullCount(usage.Used),
+			nullCount(usage.Remaining),
+			usage.RequestedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("insert usage: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// nullCount maps an unreported (-1) header value to NULL
+func nullCount(n int) sql.NullInt64 {
+	if n < 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(n), Valid: true}
+}
@@ -0,0 +1,194 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// DailyUsage is one row of the daily rollup
+type DailyUsage struct {
+	Day       time.Time // UTC midnight
+	Vendor    string
+	SportKey  string
+	Kind      string
+	MarketKey string  // "" for requests without markets
+	Requests  int     // Requests that included the market
+	Credits   float64 // Request cost split evenly across its markets
+}
+
+// Rollup recomputes vendor_usage_daily for the UTC days overlapping [from, to)
+// Re-running a day replaces its rows, so partial days can be rolled up repeatedly.
+func Rollup(ctx context.Context, db *sql.DB, from, to time.Time) error {
+	from, to = dayStart(from), dayStart(to.Add(24*time.Hour-time.Nanosecond))
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO vendor_usage_daily (day, vendor, sport_key, kind, market_key, requests, credits, updated_at)
+		SELECT (u.requested_at AT TIME ZONE 'UTC')::date, u.vendor, u.sport_key, u.kind, m.market_key,
+		       COUNT(*),
+		       COALESCE(SUM(COALESCE(u.cost, 0)::numeric / GREATEST(cardinality(u.markets), 1)), 0),
+		       NOW()
+		FROM vendor_usage u
+		CROSS JOIN LATERAL unnest(
+			CASE WHEN cardinality(u.markets) = 0 THEN ARRAY['']::text[] ELSE u.markets END
+		) AS m(market_key)
+		WHERE u.requested_at >= $1 AND u.requested_at < $2
+		GROUP BY 1, 2, 3, 4, 5
+		ON CONFLICT (day, vendor, sport_key, kind, market_key) DO UPDATE SET
+			requests = EXCLUDED.requests,
+			credits = EXCLUDED.credits,
+			updated_at = NOW()
+	`, from, to)
+	if err != nil {
+		return fmt.Errorf("roll up vendor usage: %w", err)
+	}
+	return nil
+}
+
+// Report returns the daily rollup for the UTC days overlapping [from, to),
+// newest day first and the most expensive rows first within a day
+func Report(ctx context.Context, db *sql.DB, from, to time.Time) ([]DailyUsage, error) {
+	from, to = dayStart(from), dayStart(to.Add(24*time.Hour-time.Nanosecond))
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT day, vendor, sport_key, kind, market_key, requests, credits
+		FROM vendor_usage_daily
+		WHERE day >= $1::date AND day < $2::date
+		ORDER BY day DESC, credits DESC, sport_key, kind, market_key
+	`, from.Format("2006-01-02"), to.Format("2006-01-02")) // Dates, so the session time zone cannot shift them
+	if err != nil {
+		return nil, fmt.Errorf("query vendor usage: %w", err)
+	}
+	defer rows.Close()
+
+	var report []DailyUsage
+	for rows.Next() {
+		var row DailyUsage
+		if err := rows.Scan(&row.Day, &row.Vendor, &row.SportKey, &row.Kind, &row.MarketKey, &row.Requests, &row.Credits); err != nil {
+			return nil, fmt.Errorf("scan vendor usage: %w", err)
+		}
+		row.Day = timeutil.UTC(row.Day)
+		report = append(report, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read vendor usage: %w", err)
+	}
+	return report, nil
+}
+
+// Prune deletes raw usage rows older than before; the daily rollup is kept
+func Prune(ctx context.Context, db *sql.DB, before time.Time) (int64, error) {
+	res, err := db.ExecContext(ctx, `DELETE FROM vendor_usage WHERE requested_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune vendor usage: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// Summarize formats per-sport credit totals, highest first
+// (e.g. "basketball_nba=120, icehockey_nhl=30")
+func Summarize(rows []DailyUsage) string {
+	credits := make(map[string]float64)
+	for _, row := range rows {
+		credits[row.SportKey] += row.Credits
+	}
+
+	sports := make([]string, 0, len(credits))
+	for sport := range credits {
+		sports = append(sports, sport)
+	}
+	sort.Slice(sports, func(i, j int) bool {
+		if credits[sports[i]] != credits[sports[j]] {
+			return credits[sports[i]] > credits[sports[j]]
+		}
+		return sports[i] < sports[j]
+	})
+
+	parts := make([]string, len(sports))
+	for i, sport := range sports {
+		parts[i] = fmt.Sprintf("%s=%.0f", sport, credits[sport])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dayStart truncates t to UTC midnight
+func dayStart(t time.Time) time.Time {
+	return timeutil.UTC(t).Truncate(24 * time.Hour)
+}
+
+// Job rolls up today and yesterday (UTC) on an interval and prunes old raw rows
+type Job struct {
+	db           *sql.DB
+	retention    time.Duration // Raw rows older than this are deleted (0 = keep)
+	pollInterval time.Duration
+	lastReported time.Time // Last completed day logged
+	stopChan     chan struct{}
+}
+
+// NewJob creates a usage rollup job
+func NewJob(db *sql.DB, retention, pollInterval time.Duration) *Job {
+	return &Job{
+		db:           db,
+		retention:    retention,
+		pollInterval: pollInterval,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start begins periodic rollups
+func (j *Job) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.pollInterval)
+	defer ticker.Stop()
+
+	j.run(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			j.run(ctx)
+		case <-j.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop gracefully stops the job
+func (j *Job) Stop() {
+	close(j.stopChan)
+}
+
+// run rolls up yesterday (late rows) and today, logs each completed day once and prunes
+func (j *Job) run(ctx context.Context) {
+	today := dayStart(timeutil.Now())
+	yesterday := today.Add(-24 * time.Hour)
+
+	if err := Rollup(ctx, j.db, yesterday, today.Add(24*time.Hour)); err != nil {
+		fmt.Printf("[Usage] %v\n", err)
+		return
+	}
+
+	if !j.lastReported.Equal(yesterday) {
+		if rows, err := Report(ctx, j.db, yesterday, today); err != nil {
+			fmt.Printf("[Usage] %v\n", err)
+		} else if len(rows) > 0 {
+			fmt.Printf("[Usage] %s: %s\n", yesterday.Format("2006-01-02"), Summarize(rows))
+		}
+		j.lastReported = yesterday
+	}
+
+	if j.retention > 0 {
+		if n, err := Prune(ctx, j.db, timeutil.Now().Add(-j.retention)); err != nil {
+			fmt.Printf("[Usage] %v\n", err)
+		} else if n > 0 {
+			fmt.Printf("[Usage] pruned %d raw row(s)\n", n)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+// Package webhooks posts significant line moves to HTTP endpoints. Per-market rules
+// decide which moves count; deliveries are signed with HMAC-SHA256 and retried with
+// exponential backoff.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// DefaultRules fire on a 1.5 point spread move, a 1 point total move or a 20 cent
+// price move in any market
+const DefaultRules = "spreads:point>=1.5,totals:point>=1,*:price>=20"
+
+// Delivery headers
+const (
+	HeaderSignature = "X-Mercury-Signature" // sha256=<hex HMAC of "<timestamp>.<body>">
+	HeaderTimestamp = "X-Mercury-Timestamp" // Unix seconds when the attempt was signed
+	HeaderDelivery  = "X-Mercury-Delivery"  // Alert ID, identical across retries
+	HeaderEvent     = "X-Mercury-Event"
+)
+
+// EventLineMove is the X-Mercury-Event value of line move alerts
+const EventLineMove = "line_move"
+
+const (
+	defaultQueueSize  = 256
+	defaultWorkers    = 4
+	defaultMaxRetries = 3
+	defaultTimeout    = 5 * time.Second
+	initialBackoff    = time.Second
+	maxBackoff        = 30 * time.Second
+)
+
+// Config configures webhook delivery
+type Config struct {
+	URLs       []string      // Every alert is posted to each URL
+	Secret     string        // HMAC key; deliveries are unsigned when empty
+	Rules      []Rule        // Moves matching any rule fire an alert
+	MaxRetries int           // Retries after the first attempt (0 = default, <0 = none)
+	Timeout    time.Duration // Per attempt
+}
+
+// Alert is the webhook payload: the delta that moved the line past a rule, measured
+// from the anchor quote (the last one that fired, or the first one seen)
+type Alert struct {
+	ID               string    `json:"id"` // Dedupe key of the new quote
+	Rules            []string  `json:"rules"`
+	EventID          string    `json:"event_id"`
+	SportKey         string    `json:"sport_key"`
+	MarketKey        string    `json:"market_key"`
+	BookKey          string    `json:"book_key"`
+	OutcomeName      string    `json:"outcome_name"`
+	Description      string    `json:"description,omitempty"`
+	OldPrice         int       `json:"old_price"`
+	NewPrice         int       `json:"new_price"`
+	OldPoint         *float64  `json:"old_point,omitempty"`
+	NewPoint         *float64  `json:"new_point,omitempty"`
+	PointMove        float64   `json:"point_move"`
+	PriceMoveCents   int       `json:"price_move_cents"`
+	ChangeType       string    `json:"change_type,omitempty"`
+	VendorLastUpdate time.Time `json:"vendor_last_update"`
+	ReceivedAt       time.Time `json:"received_at"`
+	DetectedAt       time.Time `json:"detected_at"`
+}
+
+// anchor is the quote moves are measured from, per book and outcome
+type anchor struct {
+	point      *float64 // Line when the anchor was set
+	price      int      // Price at pricePoint
+	pricePoint *float64 // Line the price was quoted at; prices only compare at the same line
+}
+
+// delivery is one alert waiting to be posted to one URL
+type delivery struct {
+	url  string
+	id   string
+	body []byte
+}
+
+// Notifier evaluates committed deltas against the rules and posts matching moves.
+// Deliveries run in the background and are dropped (and counted) when the queue is
+// full, so the write pipeline never waits on an endpoint
+type Notifier struct {
+	config     Config
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	anchors map[string]*anchor // event|market|description|outcome|book -> anchor
+
+	queue     chan delivery
+	delivered atomic.Int64
+	failed    atomic.Int64
+	dropped   atomic.Int64
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewNotifier creates a webhook notifier
+func NewNotifier(config Config) *Notifier {
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	return &Notifier{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		anchors:    make(map[string]*anchor),
+		queue:      make(chan delivery, defaultQueueSize),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// HandleDeltaBatchCommitted fires alerts for committed moves that match a rule
+func (n *Notifier) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	for _, alert := range n.Observe(msg.Odds) {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			fmt.Printf("[Webhooks] marshal alert: %v\n", err)
+			continue
+		}
+
+		fmt.Printf("[Webhooks] %s %s %s %s moved (%s)\n",
+			alert.EventID, alert.MarketKey, alert.BookKey, alert.OutcomeName, strings.Join(alert.Rules, ", "))
+		for _, url := range n.config.URLs {
+			n.enqueue(delivery{url: url, id: alert.ID, body: body})
+		}
+	}
+}
+
+// HandleEventStatusChanged drops anchors of finished events
+func (n *Notifier) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	switch msg.NewStatus {
+	case "completed", "cancelled", "postponed":
+		n.Evict(msg.EventID)
+	}
+}
+
+// Evict removes all anchors for an event
+func (n *Notifier) Evict(eventID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	prefix := eventID + "|"
+	for key := range n.anchors {
+		if strings.HasPrefix(key, prefix) {
+			delete(n.anchors, key)
+		}
+	}
+}
+
+// Observe measures each quote against its anchor and returns alerts for moves that
+// match a rule. A firing quote becomes the new anchor, so a line drifting in small
+// steps still fires once the total move reaches the threshold
+func (n *Notifier) Observe(odds []models.RawOdds) []Alert {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var alerts []Alert
+	for _, odd := range odds {
+		key := anchorKey(odd)
+		a, ok := n.anchors[key]
+		if !ok {
+			n.anchors[key] = &anchor{point: odd.Point, price: odd.Price, pricePoint: odd.Point}
+			continue
+		}
+
+		var move Move
+		if a.point != nil && odd.Point != nil {
+			move.Point = *odd.Point - *a.point
+			if move.Point < 0 {
+				move.Point = -move.Point
+			}
+			move.HasPoint = true
+		}
+		oldPrice := a.price
+		if samePoint(a.pricePoint, odd.Point) {
+			move.PriceCents = PriceCents(a.price, odd.Price)
+			move.HasPrice = true
+		}
+
+		var matched []string
+		for _, rule := range n.config.Rules {
+			if rule.Matches(odd.MarketKey, move) {
+				matched = append(matched, rule.String())
+			}
+		}
+
+		if len(matched) == 0 {
+			if !move.HasPrice {
+				// The line moved without firing: compare later prices at the new line
+				a.price, a.pricePoint = odd.Price, odd.Point
+			}
+			continue
+		}
+
+		alerts = append(alerts, Alert{
+			ID:               odd.DedupeKey(),
+			Rules:            matched,
+			EventID:          odd.EventID,
+			SportKey:         odd.SportKey,
+			MarketKey:        odd.MarketKey,
+			BookKey:          odd.BookKey,
+			OutcomeName:      odd.OutcomeName,
+			Description:      odd.Description,
+			OldPrice:         oldPrice,
+			NewPrice:         odd.Price,
+			OldPoint:         a.point,
+			NewPoint:         odd.Point,
+			PointMove:        move.Point,
+			PriceMoveCents:   move.PriceCents,
+			ChangeType:       odd.ChangeType,
+			VendorLastUpdate: odd.VendorLastUpdate,
+			ReceivedAt:       odd.ReceivedAt,
+			DetectedAt:       timeutil.Now(),
+		})
+		n.anchors[key] = &anchor{point: odd.Point, price: odd.Price, pricePoint: odd.Point}
+	}
+	return alerts
+}
+
+// Stats returns how many deliveries succeeded, failed after all retries, and were dropped
+func (n *Notifier) Stats() (delivered, failed, dropped int64) {
+	return n.delivered.Load(), n.failed.Load(), n.dropped.Load()
+}
+
+// enqueue hands a delivery to the workers without blocking
+func (n *Notifier) enqueue(d delivery) {
+	select {
+	case n.queue <- d:
+	default:
+		if n.dropped.Add(1) == 1 {
+			fmt.Println("[Webhooks] queue full, dropping deliveries")
+		}
+	}
+}
+
+// Start begins delivering queued alerts
+func (n *Notifier) Start(ctx context.Context) {
+	for i := 0; i < defaultWorkers; i++ {
+		n.wg.Add(1)
+		go func() {
+			defer n.wg.Done()
+			for {
+				select {
+				case d := <-n.queue:
+					n.deliver(ctx, d)
+				case <-n.stopChan:
+					n.drain(ctx)
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	fmt.Printf("✓ Webhooks started (%d URL(s), %d rule(s))\n", len(n.config.URLs), len(n.config.Rules))
+}
+
+// Stop delivers what is still queued (one attempt each, without retries) and waits
+// for the workers; in-flight retries end after their current attempt
+func (n *Notifier) Stop() {
+	close(n.stopChan)
+	n.wg.Wait()
+
+	fmt.Printf("[Webhooks] stopped (%d delivered, %d failed, %d dropped)\n",
+		n.delivered.Load(), n.failed.Load(), n.dropped.Load())
+}
+
+// drain delivers the queued alerts left at Stop
+func (n *Notifier) drain(ctx context.Context) {
+	for {
+		select {
+		case d := <-n.queue:
+			n.deliver(ctx, d)
+		default:
+			return
+		}
+	}
+}
+
+// deliver posts one alert, retrying with exponential backoff
+func (n *Notifier) deliver(ctx context.Context, d delivery) {
+	backoff := initialBackoff
+	attempts := 1 + n.config.MaxRetries
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var retry bool
+		if retry, err = n.post(ctx, d); err == nil {
+			n.delivered.Add(1)
+			return
+		}
+		if !retry || attempt == attempts {
+			break
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-n.stopChan:
+			n.failed.Add(1)
+			return
+		case <-ctx.Done():
+			n.failed.Add(1)
+			return
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+
+	n.failed.Add(1)
+	fmt.Printf("[Webhooks] delivery %s to %s failed: %v\n", d.id, d.url, err)
+}
+
+// post makes one delivery attempt; retry reports whether a failure is worth retrying
+// (network errors, 429 and 5xx)
+func (n *Notifier) post(ctx context.Context, d delivery) (retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(d.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Mercury-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, EventLineMove)
+	req.Header.Set(HeaderDelivery, d.id)
+	if n.config.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, timestamp)
+		req.Header.Set(HeaderSignature, Sign(n.config.Secret, timestamp, d.body))
+	}
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("status %d", resp.StatusCode)
+	}
+}
+
+// Sign returns the X-Mercury-Signature value for a body signed at timestamp.
+// Receivers recompute it over the raw body and reject stale timestamps
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is valid for body and timestamp
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// anchorKey identifies a book's quote on an outcome independent of its point
+func anchorKey(odd models.RawOdds) string {
+	return odd.EventID + "|" + odd.MarketKey + "|" + odd.Description + "|" + odd.OutcomeName + "|" + odd.BookKey
+}
+
+// samePoint reports whether two optional points are equal
+func samePoint(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
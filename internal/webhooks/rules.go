@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+)
+
+// Rule metrics
+const (
+	MetricPoint = "point" // Absolute change of the line (spread or total points)
+	MetricPrice = "price" // Absolute change of the American price in cents (-110 -> +110 is 20)
+)
+
+// AnyMarket matches every market key
+const AnyMarket = "*"
+
+// Rule fires when a market's line moves by at least Threshold on Metric
+type Rule struct {
+	Market    string
+	Metric    string
+	Threshold float64
+}
+
+// String formats the rule the way ParseRules reads it (spreads:point>=1.5)
+func (r Rule) String() string {
+	return r.Market + ":" + r.Metric + ">=" + strconv.FormatFloat(r.Threshold, 'f', -1, 64)
+}
+
+// Matches reports whether a move of the given size on market triggers the rule
+func (r Rule) Matches(market string, move Move) bool {
+	if r.Market != AnyMarket && r.Market != market {
+		return false
+	}
+	switch r.Metric {
+	case MetricPoint:
+		return move.HasPoint && move.Point >= r.Threshold
+	case MetricPrice:
+		return move.HasPrice && float64(move.PriceCents) >= r.Threshold
+	default:
+		return false
+	}
+}
+
+// ParseRules parses comma-separated rules of the form market:metric>=threshold,
+// e.g. "spreads:point>=1.5,totals:point>=1,h2h:price>=20,*:price>=50"
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		market, condition, ok := strings.Cut(part, ":")
+		if !ok || strings.TrimSpace(market) == "" {
+			return nil, fmt.Errorf("rule %q: expected market:metric>=threshold", part)
+		}
+		metric, threshold, ok := strings.Cut(condition, ">=")
+		if !ok {
+			return nil, fmt.Errorf("rule %q: expected market:metric>=threshold", part)
+		}
+
+		rule := Rule{
+			Market: strings.TrimSpace(market),
+			Metric: strings.ToLower(strings.TrimSpace(metric)),
+		}
+		if rule.Metric != MetricPoint && rule.Metric != MetricPrice {
+			return nil, fmt.Errorf("rule %q: unknown metric %q (point or price)", part, rule.Metric)
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(threshold), 64)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("rule %q: threshold must be a positive number", part)
+		}
+		rule.Threshold = value
+
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Move is the change of a quote since its anchor (the last quote that fired, or the
+// first one seen)
+type Move struct {
+	Point      float64 // Absolute point change
+	HasPoint   bool    // Both quotes have a point
+	PriceCents int     // Absolute price change in cents, at the same point
+	HasPrice   bool    // The point is unchanged since the price anchor, so prices compare
+}
+
+// PriceCents returns the distance between two American prices in cents, counting
+// across even money: -110 -> -105 is 5, -105 -> +105 is 10
+func PriceCents(from, to int) int {
+	diff := oddsmath.PriceDeltaCents(from, to)
+	if diff < 0 {
+		return -diff
+	}
+	return diff
+}
@@ -3,13 +3,24 @@ package writer
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/latency"
+	"github.com/XavierBriggs/Mercury/internal/lifecycle"
+	"github.com/XavierBriggs/Mercury/internal/ordering"
+	"github.com/XavierBriggs/Mercury/internal/outlier"
+	"github.com/XavierBriggs/Mercury/internal/participants"
 	"github.com/XavierBriggs/Mercury/internal/talos"
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
 	"github.com/XavierBriggs/Mercury/pkg/models"
 	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
@@ -18,25 +29,55 @@ import (
 const (
 	defaultBatchSize     = 100
 	defaultFlushInterval = 5 * time.Second
-	streamKeyFormat      = "odds.raw.%s" // odds.raw.basketball_nba
+	streamKeyFormat      = "odds.raw.%s"  // odds.raw.basketball_nba
+	liveStreamKeyFormat  = "odds.live.%s" // In-play odds when live streams are split out
+
+	preMatchTable = "odds_raw"
+	liveTable     = "odds_live" // In-play odds when the live table is enabled
 )
 
+// ErrNotLeader is returned by WriteWithEvents once this replica has lost leadership
+var ErrNotLeader = errors.New("not the leader")
+
 // Writer batches Alexandria DB writes and publishes to Redis Streams
 // Implements the write-through cache pattern
 type Writer struct {
-	db    *sql.DB
-	redis *redis.Client
-	talos *talos.Client // Optional Talos client for page warming
+	db        *sql.DB
+	redis     *redis.Client
+	warmQueue *talos.WarmQueue        // Optional persistent queue for Talos startup warm-up
+	eventBus  *bus.Bus                // Optional bus for discovery/commit notifications
+	books     *books.Registry         // Optional book metadata for books first seen in odds
+	teams     *participants.Directory // Optional participant IDs attached to stream messages
+	outliers  *outlier.Detector       // Optional consensus check tagging outlier quotes
+
+	// In-play odds go to odds.live.<sport> instead of odds.raw.<sport>, and to
+	// odds_live instead of odds_raw, when these are set
+	liveStreams bool
+	liveTable   bool
+
+	// Events last seen live, so buffered odds (which carry no event) route the same way
+	liveEvents   map[string]bool
+	liveEventsMu sync.RWMutex
+
+	streamEncoding models.StreamEncoding // Payload encoding on the odds streams (default JSON)
+	streamSecret   string                // HMAC key signing odds stream entries (empty = unsigned)
+	aggregate      bool                  // One entry per (event, book, poll) instead of per outcome
+
+	sinks []contracts.StreamSink // Optional transports fed alongside the Redis streams
+
+	isLeader func() bool // Optional: nothing is committed or published once it reports false
 
 	batchSize     int
 	flushInterval time.Duration
+	clock         clock.Clock // Drives the flush ticker and commit timestamps
+
+	latency *latency.Recorder // Optional publish-stage latency histogram
 
 	buffer []models.RawOdds
 	mu     sync.Mutex
 
-	flushTicker *time.Ticker
-	stopChan    chan struct{}
-	wg          sync.WaitGroup
+	// Per-outcome ordering lanes held from commit through publish
+	lanes *ordering.Lanes
 
 	// Track seen events to only warm new ones
 	seenEvents   map[string]bool
@@ -44,18 +85,38 @@ type Writer struct {
 }
 
 // StreamMessage represents a message published to Redis Stream
-type StreamMessage struct {
-	EventID          string    `json:"event_id"`
-	SportKey         string    `json:"sport_key"`
-	MarketKey        string    `json:"market_key"`
-	BookKey          string    `json:"book_key"`
-	OutcomeName      string    `json:"outcome_name"`
-	Price            int       `json:"price"`
-	Point            *float64  `json:"point,omitempty"`
-	VendorLastUpdate time.Time `json:"vendor_last_update"`
-	ReceivedAt       time.Time `json:"received_at"`
-	EventStatus      string    `json:"event_status"` // "upcoming" or "live"
-	ChangeType       string    `json:"change_type,omitempty"`
+// Defined in pkg/models so external consumers (pkg/consumer) can decode it
+type StreamMessage = models.StreamMessage
+
+// NewStreamMessage builds the published form of an odds delta
+// Shared by the Redis stream and in-process push consumers so both carry the same payload
+func NewStreamMessage(odd models.RawOdds, eventStatus string) StreamMessage {
+	return StreamMessage{
+		SchemaVersion:    models.StreamSchemaVersion,
+		EventID:          odd.EventID,
+		SportKey:         odd.SportKey,
+		MarketKey:        odd.MarketKey,
+		BookKey:          odd.BookKey,
+		OutcomeName:      odd.OutcomeName,
+		Side:             odd.Side,
+		Description:      odd.Description,
+		Price:            odd.Price,
+		PriceDecimal:     odd.Decimal(),
+		OddsFormat:       string(oddsFormat(odd)),
+		Point:            odd.Point,
+		Limit:            odd.Limit,
+		DeepLink:         odd.DeepLink,
+		VendorLastUpdate: timeutil.UTC(odd.VendorLastUpdate),
+		ReceivedAt:       timeutil.UTC(odd.ReceivedAt),
+		EventStatus:      eventStatus,
+		ChangeType:       odd.ChangeType,
+		OldPrice:         odd.OldPrice,
+		OldPoint:         odd.OldPoint,
+		PriceDeltaCents:  odd.PriceDeltaCents(),
+		PointDelta:       odd.PointDelta(),
+		DedupeKey:        odd.DedupeKey(),
+		PollID:           odd.PollID,
+	}
 }
 
 // NewWriter creates a new batching writer
@@ -66,47 +127,126 @@ func NewWriter(db *sql.DB, redisClient *redis.Client) *Writer {
 		batchSize:     defaultBatchSize,
 		flushInterval: defaultFlushInterval,
 		buffer:        make([]models.RawOdds, 0, defaultBatchSize),
-		stopChan:      make(chan struct{}),
 		seenEvents:    make(map[string]bool),
+		liveEvents:    make(map[string]bool),
+		lanes:         ordering.NewLanes(ordering.DefaultLanes),
+		clock:         clock.Real,
 	}
 }
 
-// SetTalosClient sets the Talos client for page warming
-func (w *Writer) SetTalosClient(client *talos.Client) {
-	w.talos = client
+// SetClock replaces the wall clock driving the flush ticker (for tests)
+func (w *Writer) SetClock(c clock.Clock) {
+	w.clock = c
 }
 
-// Start begins the background flush ticker
-func (w *Writer) Start(ctx context.Context) {
-	w.flushTicker = time.NewTicker(w.flushInterval)
+// SetLatencyRecorder times every publish to the streams and sinks as the "publish" stage
+func (w *Writer) SetLatencyRecorder(recorder *latency.Recorder) {
+	w.latency = recorder
+}
 
-	w.wg.Add(1)
-	go func() {
-		defer w.wg.Done()
-		for {
-			select {
-			case <-w.flushTicker.C:
-				if err := w.Flush(ctx); err != nil {
-					// Log error but continue (would use proper logging in production)
-					fmt.Printf("flush error: %v\n", err)
-				}
-			case <-w.stopChan:
-				w.flushTicker.Stop()
-				// Final flush on shutdown
-				_ = w.Flush(ctx)
-				return
-			case <-ctx.Done():
-				w.flushTicker.Stop()
-				return
-			}
-		}
-	}()
+// SetEventBus sets the event bus used to announce new events and committed deltas
+func (w *Writer) SetEventBus(eventBus *bus.Bus) {
+	w.eventBus = eventBus
+}
+
+// SetBooks sets the book metadata used to classify books first seen in odds
+// Without it new books are inserted as soft US books
+func (w *Writer) SetBooks(registry *books.Registry) {
+	w.books = registry
+}
+
+// SetParticipants sets the directory used to attach team IDs to stream messages
+func (w *Writer) SetParticipants(directory *participants.Directory) {
+	w.teams = directory
+}
+
+// SetLiveStreams publishes odds for live events to odds.live.<sport> instead of
+// odds.raw.<sport>, so in-play consumers don't share a stream with pre-match volume
+func (w *Writer) SetLiveStreams(enabled bool) {
+	w.liveStreams = enabled
+}
+
+// SetLiveTable stores odds for live events in odds_live instead of odds_raw. The
+// latest pre-match rows in odds_raw are then left as they stood at tip-off
+func (w *Writer) SetLiveTable(enabled bool) {
+	w.liveTable = enabled
+}
+
+// SetStreamEncoding sets the payload encoding of odds stream entries. Protobuf cuts
+// serialization cost for high-volume deployments; consumers must use pkg/consumer
+// (or read the entry's "encoding" field) to decode it
+func (w *Writer) SetStreamEncoding(encoding models.StreamEncoding) {
+	w.streamEncoding = encoding
+}
+
+// SetStreamSecret signs every odds stream entry with an HMAC-SHA256 of its payload
+// under the "signature" field, so consumers holding the secret can verify Mercury
+// produced it (pkg/consumer Config.Secret). Empty leaves entries unsigned
+func (w *Writer) SetStreamSecret(secret string) {
+	w.streamSecret = secret
+}
+
+// SetOutliers compares every published delta to the other books' consensus and tags
+// outliers (and the consensus price) on the stream messages
+func (w *Writer) SetOutliers(detector *outlier.Detector) {
+	w.outliers = detector
+}
+
+// SetStreamAggregate publishes one stream entry per (event, book, poll) holding every
+// changed outcome (models.StreamBatch) instead of one entry per outcome. Sinks still
+// get one message per outcome
+func (w *Writer) SetStreamAggregate(enabled bool) {
+	w.aggregate = enabled
+}
+
+// AddSink forwards every published odds delta to sink as well as the Redis streams
+// Call before Start
+func (w *Writer) AddSink(sink contracts.StreamSink) {
+	w.sinks = append(w.sinks, sink)
+}
+
+// SetWarmQueue sets the persistent Talos warm queue for startup page warming
+func (w *Writer) SetWarmQueue(queue *talos.WarmQueue) {
+	w.warmQueue = queue
+}
+
+// SetLeaderCheck fences writes behind leader election: once isLeader reports false
+// (the lease was lost and another replica may be polling) buffered odds are dropped
+// instead of flushed, and nothing more is committed or published
+func (w *Writer) SetLeaderCheck(isLeader func() bool) {
+	w.isLeader = isLeader
 }
 
-// Stop gracefully shuts down the writer
-func (w *Writer) Stop() {
-	close(w.stopChan)
-	w.wg.Wait()
+// fenced reports whether leadership was lost
+func (w *Writer) fenced() bool {
+	return w.isLeader != nil && !w.isLeader()
+}
+
+// Run flushes the buffer every flush interval until ctx is done, then flushes what
+// is left. It returns an error when flushes keep failing or the final flush fails
+func (w *Writer) Run(ctx context.Context) error {
+	ticker := w.clock.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	var failures lifecycle.Failures
+	for {
+		select {
+		case <-ticker.C():
+			err := w.Flush(ctx)
+			if err != nil && ctx.Err() == nil {
+				fmt.Printf("[Writer] flush error: %v\n", err)
+			}
+			if err := failures.Record(err); err != nil {
+				return fmt.Errorf("flush: %w", err)
+			}
+		case <-ctx.Done():
+			// Final flush on shutdown, outliving the cancelled context
+			if err := w.Flush(context.WithoutCancel(ctx)); err != nil {
+				return fmt.Errorf("final flush: %w", err)
+			}
+			return nil
+		}
+	}
 }
 
 // Write adds odds to the buffer and flushes if batch size is reached
@@ -128,13 +268,18 @@ func (w *Writer) WriteWithEvents(ctx context.Context, events []models.Event, odd
 	if len(events) == 0 && len(odds) == 0 {
 		return nil
 	}
+	if w.fenced() {
+		return ErrNotLeader
+	}
 
 	// Filter odds: Only accept Pinnacle from EU region books
-	// All US/US2 books are accepted automatically
+	// All US/US2 books are accepted automatically. Sports with a bookmakers list
+	// never download the rest; this guards sports that still poll whole regions
 	odds = filterEUBooks(odds)
 
 	// Identify new events (not seen before) for page warming
 	newEvents := w.identifyNewEvents(events)
+	w.trackStatuses(events)
 
 	// Execute write in transaction immediately (bypass buffer)
 	tx, err := w.db.BeginTx(ctx, nil)
@@ -157,34 +302,59 @@ func (w *Writer) WriteWithEvents(ctx context.Context, events []models.Event, odd
 		}
 	}
 
-	// Step 1: Update previous rows (set is_latest = false)
+	// Steps 1-3: dedupe, retire previous rows and insert, per table
 	if len(odds) > 0 {
-		if err := w.updatePreviousOdds(ctx, tx, odds); err != nil {
-			return fmt.Errorf("update previous odds: %w", err)
+		if !w.liveTable {
+			odds, err = w.writeOdds(ctx, tx, preMatchTable, odds)
+		} else {
+			preMatch, live := SplitLive(odds, w.IsLive)
+			if preMatch, err = w.writeOdds(ctx, tx, preMatchTable, preMatch); err == nil {
+				live, err = w.writeOdds(ctx, tx, liveTable, live)
+			}
+			odds = append(preMatch, live...)
 		}
-
-		// Step 2: Insert new rows (with is_latest = true)
-		if err := w.insertNewOdds(ctx, tx, odds); err != nil {
-			return fmt.Errorf("insert new odds: %w", err)
+		if err != nil {
+			return err
 		}
 	}
 
+	// Hold ordering lanes from commit through publish so per-outcome
+	// stream order always matches commit order
+	release := w.lanes.AcquireOdds(odds)
+
+	// Leadership may have been lost while the transaction ran
+	if w.fenced() {
+		release()
+		return ErrNotLeader
+	}
+
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
+		release()
 		return fmt.Errorf("commit transaction: %w", err)
 	}
 
-	// Step 3: Publish to Redis Streams (after successful DB write)
+	// Step 4: Publish to Redis Streams (after successful DB write)
 	if len(odds) > 0 {
 		if err := w.publishToStream(ctx, odds, events); err != nil {
 			// Log but don't fail - DB is source of truth
 			fmt.Printf("publish to stream error: %v\n", err)
 		}
 	}
+	release()
 
-	// Step 4: Warm game pages for new events (after successful DB write)
-	if len(newEvents) > 0 {
-		w.warmGamePages(ctx, newEvents)
+	// Step 5: Announce new events and committed deltas (after successful DB write)
+	if w.eventBus != nil {
+		if len(newEvents) > 0 {
+			w.eventBus.PublishEventDiscovered(bus.EventDiscovered{Events: newEvents})
+		}
+		if len(odds) > 0 {
+			w.eventBus.PublishDeltaBatchCommitted(bus.DeltaBatchCommitted{
+				Events:      events,
+				Odds:        odds,
+				CommittedAt: w.clock.Now(),
+			})
+		}
 	}
 
 	return nil
@@ -203,6 +373,11 @@ func (w *Writer) Flush(ctx context.Context) error {
 	w.buffer = make([]models.RawOdds, 0, w.batchSize)
 	w.mu.Unlock()
 
+	if w.fenced() {
+		w.dropFenced(len(odds))
+		return nil
+	}
+
 	// Execute write in transaction
 	tx, err := w.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -210,47 +385,168 @@ func (w *Writer) Flush(ctx context.Context) error {
 	}
 	defer tx.Rollback()
 
-	// Step 1: Update previous rows (set is_latest = false)
-	if err := w.updatePreviousOdds(ctx, tx, odds); err != nil {
-		return fmt.Errorf("update previous odds: %w", err)
+	// Steps 1-3: dedupe, retire previous rows and insert, per table. Buffered odds
+	// carry no event, so live ones are told apart by the statuses last seen
+	if !w.liveTable {
+		odds, err = w.writeOdds(ctx, tx, preMatchTable, odds)
+	} else {
+		preMatch, live := SplitLive(odds, w.IsLive)
+		if preMatch, err = w.writeOdds(ctx, tx, preMatchTable, preMatch); err == nil {
+			live, err = w.writeOdds(ctx, tx, liveTable, live)
+		}
+		odds = append(preMatch, live...)
+	}
+	if err != nil {
+		return err
 	}
+	if len(odds) == 0 {
+		return nil
+	}
+
+	// Hold ordering lanes from commit through publish (see WriteWithEvents)
+	release := w.lanes.AcquireOdds(odds)
 
-	// Step 2: Insert new rows (with is_latest = true)
-	if err := w.insertNewOdds(ctx, tx, odds); err != nil {
-		return fmt.Errorf("insert new odds: %w", err)
+	if w.fenced() {
+		release()
+		w.dropFenced(len(odds))
+		return nil
 	}
 
 	// Commit transaction
 	if err := tx.Commit(); err != nil {
+		release()
 		return fmt.Errorf("commit transaction: %w", err)
 	}
 
-	// Step 3: Publish to Redis Streams (after successful DB write)
-	// Note: events are not available in Flush context, pass nil
+	// Step 4: Publish to Redis Streams (after successful DB write)
+	// Note: events are not available in Flush context; statuses come from IsLive
 	if err := w.publishToStream(ctx, odds, nil); err != nil {
 		// Log but don't fail - DB is source of truth
 		fmt.Printf("publish to stream error: %v\n", err)
 	}
+	release()
+
+	if w.eventBus != nil && len(odds) > 0 {
+		w.eventBus.PublishDeltaBatchCommitted(bus.DeltaBatchCommitted{
+			Odds:        odds,
+			CommittedAt: w.clock.Now(),
+		})
+	}
 
 	return nil
 }
 
+// dropFenced logs buffered odds discarded after leadership was lost
+func (w *Writer) dropFenced(n int) {
+	fmt.Printf("[Writer] ✗ Leadership lost: dropped %d buffered odds\n", n)
+}
+
+// writeOdds drops stored quotes, retires the previous rows and inserts the rest into
+// table (odds_raw or odds_live), returning the odds actually inserted
+func (w *Writer) writeOdds(ctx context.Context, tx *sql.Tx, table string, odds []models.RawOdds) ([]models.RawOdds, error) {
+	if len(odds) == 0 {
+		return odds, nil
+	}
+
+	// Step 1: Drop quotes already stored (replays, retries, overlapping pollers)
+	odds, err := w.dedupe(ctx, tx, table, odds)
+	if err != nil {
+		return nil, fmt.Errorf("dedupe odds: %w", err)
+	}
+	if len(odds) == 0 {
+		return odds, nil
+	}
+
+	// Step 2: Update previous rows (set is_latest = false)
+	if err := w.updatePreviousOdds(ctx, tx, table, odds); err != nil {
+		return nil, fmt.Errorf("update previous odds: %w", err)
+	}
+
+	// Step 3: Insert new rows (with is_latest = true)
+	if odds, err = w.insertNewOdds(ctx, tx, table, odds); err != nil {
+		return nil, fmt.Errorf("insert new odds: %w", err)
+	}
+	return odds, nil
+}
+
+// SplitLive separates odds for live events from pre-match odds, keeping their order
+func SplitLive(odds []models.RawOdds, isLive func(eventID string) bool) (preMatch, live []models.RawOdds) {
+	for _, odd := range odds {
+		if isLive(odd.EventID) {
+			live = append(live, odd)
+		} else {
+			preMatch = append(preMatch, odd)
+		}
+	}
+	return preMatch, live
+}
+
+// dedupe drops odds repeated within the batch or whose dedupe key is already in
+// table, so a quote that is written twice is neither stored nor published twice
+func (w *Writer) dedupe(ctx context.Context, tx *sql.Tx, table string, odds []models.RawOdds) ([]models.RawOdds, error) {
+	keys := make([]string, 0, len(odds))
+	unique := make([]models.RawOdds, 0, len(odds))
+	seen := make(map[string]bool, len(odds))
+	for _, odd := range odds {
+		key := odd.DedupeKey()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+		unique = append(unique, odd)
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT dedupe_key FROM `+table+` WHERE dedupe_key = ANY($1)`, pq.Array(keys))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stored := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		stored[key] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	fresh := unique
+	if len(stored) > 0 {
+		fresh = make([]models.RawOdds, 0, len(unique))
+		for i, odd := range unique {
+			if !stored[keys[i]] {
+				fresh = append(fresh, odd)
+			}
+		}
+	}
+
+	if skipped := len(odds) - len(fresh); skipped > 0 {
+		fmt.Printf("[Writer] Skipped %d duplicate odds\n", skipped)
+	}
+	return fresh, nil
+}
+
 // updatePreviousOdds sets is_latest = false for existing odds
-func (w *Writer) updatePreviousOdds(ctx context.Context, tx *sql.Tx, odds []models.RawOdds) error {
+func (w *Writer) updatePreviousOdds(ctx context.Context, tx *sql.Tx, table string, odds []models.RawOdds) error {
 	if len(odds) == 0 {
 		return nil
 	}
 
 	// Build UPDATE statement for batch
 	// UPDATE odds_raw SET is_latest = false
-	// WHERE is_latest = true AND (event_id, market_key, book_key, outcome_name) IN (...)
+	// WHERE is_latest = true AND (event_id, market_key, book_key, outcome_name, description) IN (...)
 
 	query := `
-		UPDATE odds_raw 
+		UPDATE ` + table + `
 		SET is_latest = false 
 		WHERE is_latest = true 
-		  AND (event_id, market_key, book_key, outcome_name) IN (
-			SELECT UNNEST($1::text[]), UNNEST($2::text[]), UNNEST($3::text[]), UNNEST($4::text[])
+		  AND (event_id, market_key, book_key, outcome_name, description) IN (
+			SELECT UNNEST($1::text[]), UNNEST($2::text[]), UNNEST($3::text[]), UNNEST($4::text[]), UNNEST($5::text[])
 		  )
 	`
 
@@ -258,34 +554,42 @@ func (w *Writer) updatePreviousOdds(ctx context.Context, tx *sql.Tx, odds []mode
 	marketKeys := make([]string, len(odds))
 	bookKeys := make([]string, len(odds))
 	outcomeNames := make([]string, len(odds))
+	descriptions := make([]string, len(odds))
 
 	for i, odd := range odds {
 		eventIDs[i] = odd.EventID
 		marketKeys[i] = odd.MarketKey
 		bookKeys[i] = odd.BookKey
 		outcomeNames[i] = odd.OutcomeName
+		descriptions[i] = odd.Description
 	}
 
-	_, err := tx.ExecContext(ctx, query, pq.Array(eventIDs), pq.Array(marketKeys), pq.Array(bookKeys), pq.Array(outcomeNames))
+	_, err := tx.ExecContext(ctx, query, pq.Array(eventIDs), pq.Array(marketKeys), pq.Array(bookKeys), pq.Array(outcomeNames), pq.Array(descriptions))
 	return err
 }
 
-// insertNewOdds inserts new odds rows with is_latest = true
-func (w *Writer) insertNewOdds(ctx context.Context, tx *sql.Tx, odds []models.RawOdds) error {
+// insertNewOdds inserts new odds rows with is_latest = true and returns the odds
+// actually inserted: a row whose dedupe key was committed concurrently (e.g. by an
+// overlapping poller) is skipped by the unique index
+func (w *Writer) insertNewOdds(ctx context.Context, tx *sql.Tx, table string, odds []models.RawOdds) ([]models.RawOdds, error) {
 	if len(odds) == 0 {
-		return nil
+		return odds, nil
 	}
 
 	// Build INSERT statement with UNNEST for batch insert
 	query := `
-		INSERT INTO odds_raw (
-			event_id, sport_key, market_key, book_key, outcome_name,
-			price, point, vendor_last_update, received_at, is_latest
+		INSERT INTO ` + table + ` (
+			event_id, sport_key, market_key, book_key, outcome_name, description,
+			price, price_decimal, point, vendor_last_update, received_at, is_latest, deep_link, bet_limit,
+			dedupe_key, side, poll_id
 		)
 		SELECT * FROM UNNEST(
-			$1::text[], $2::text[], $3::text[], $4::text[], $5::text[],
-			$6::int[], $7::decimal[], $8::timestamptz[], $9::timestamptz[], $10::boolean[]
+			$1::text[], $2::text[], $3::text[], $4::text[], $5::text[], $6::text[],
+			$7::int[], $8::decimal[], $9::decimal[], $10::timestamptz[], $11::timestamptz[], $12::boolean[],
+			$13::text[], $14::decimal[], $15::text[], $16::text[], $17::text[]
 		)
+		ON CONFLICT (dedupe_key) WHERE dedupe_key IS NOT NULL DO NOTHING
+		RETURNING dedupe_key
 	`
 
 	eventIDs := make([]string, len(odds))
@@ -293,11 +597,18 @@ func (w *Writer) insertNewOdds(ctx context.Context, tx *sql.Tx, odds []models.Ra
 	marketKeys := make([]string, len(odds))
 	bookKeys := make([]string, len(odds))
 	outcomeNames := make([]string, len(odds))
+	descriptions := make([]string, len(odds))
 	prices := make([]int, len(odds))
+	decimalPrices := make([]float64, len(odds))
 	points := make([]*float64, len(odds))
 	vendorUpdates := make([]time.Time, len(odds))
 	receivedAts := make([]time.Time, len(odds))
 	isLatests := make([]bool, len(odds))
+	deepLinks := make([]*string, len(odds))
+	limits := make([]*float64, len(odds))
+	dedupeKeys := make([]string, len(odds))
+	sides := make([]*string, len(odds))
+	pollIDs := make([]*string, len(odds))
 
 	for i, odd := range odds {
 		eventIDs[i] = odd.EventID
@@ -305,80 +616,143 @@ func (w *Writer) insertNewOdds(ctx context.Context, tx *sql.Tx, odds []models.Ra
 		marketKeys[i] = odd.MarketKey
 		bookKeys[i] = odd.BookKey
 		outcomeNames[i] = odd.OutcomeName
+		descriptions[i] = odd.Description
 		prices[i] = odd.Price
+		decimalPrices[i] = odd.Decimal()
 		points[i] = odd.Point
-		vendorUpdates[i] = odd.VendorLastUpdate
-		receivedAts[i] = odd.ReceivedAt
+		vendorUpdates[i] = timeutil.UTC(odd.VendorLastUpdate)
+		receivedAts[i] = timeutil.UTC(odd.ReceivedAt)
 		isLatests[i] = true
+		limits[i] = odd.Limit
+		dedupeKeys[i] = odd.DedupeKey()
+		if odd.DeepLink != "" {
+			link := odd.DeepLink
+			deepLinks[i] = &link
+		}
+		if odd.Side != "" {
+			side := odd.Side
+			sides[i] = &side
+		}
+		if odd.PollID != "" {
+			pollID := odd.PollID
+			pollIDs[i] = &pollID
+		}
 	}
 
-	_, err := tx.ExecContext(ctx, query,
-		pq.Array(eventIDs), pq.Array(sportKeys), pq.Array(marketKeys), pq.Array(bookKeys), pq.Array(outcomeNames),
-		pq.Array(prices), pq.Array(points), pq.Array(vendorUpdates), pq.Array(receivedAts), pq.Array(isLatests),
+	rows, err := tx.QueryContext(ctx, query,
+		pq.Array(eventIDs), pq.Array(sportKeys), pq.Array(marketKeys), pq.Array(bookKeys), pq.Array(outcomeNames), pq.Array(descriptions),
+		pq.Array(prices), pq.Array(decimalPrices), pq.Array(points), pq.Array(vendorUpdates), pq.Array(receivedAts), pq.Array(isLatests),
+		pq.Array(deepLinks), pq.Array(limits), pq.Array(dedupeKeys), pq.Array(sides), pq.Array(pollIDs),
 	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	return err
+	inserted := make(map[string]bool, len(odds))
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		inserted[key] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(inserted) == len(odds) {
+		return odds, nil
+	}
+
+	kept := make([]models.RawOdds, 0, len(inserted))
+	for i, odd := range odds {
+		if inserted[dedupeKeys[i]] {
+			kept = append(kept, odd)
+		}
+	}
+	fmt.Printf("[Writer] Skipped %d odds committed concurrently\n", len(odds)-len(kept))
+	return kept, nil
 }
 
-// publishToStream publishes odds deltas to Redis Stream
+// observePublish records the time since a publish started
+func (w *Writer) observePublish(start time.Time) {
+	w.latency.Observe(latency.StagePublish, w.clock.Since(start))
+}
+
+// publishToStream publishes odds deltas to Redis Stream, then to any sinks
+//
+// Ordering guarantee: for any single (event, market, book, outcome), messages
+// appear in the stream (and reach each sink) in the order their rows were
+// committed. Callers must hold the batch's ordering lanes from commit until this
+// returns; within a batch, odds are published in slice order.
 func (w *Writer) publishToStream(ctx context.Context, odds []models.RawOdds, events []models.Event) error {
 	if len(odds) == 0 {
 		return nil
 	}
-
-	// Build event status lookup map
-	eventStatusMap := make(map[string]string)
-	for _, event := range events {
-		eventStatusMap[event.EventID] = event.EventStatus
+	if w.latency != nil {
+		defer w.observePublish(w.clock.Now())
 	}
 
-	// Group by sport for separate streams
-	bySport := make(map[string][]models.RawOdds)
-	for _, odd := range odds {
-		bySport[odd.SportKey] = append(bySport[odd.SportKey], odd)
+	// Build event lookup map (status and teams)
+	eventMap := make(map[string]*models.Event, len(events))
+	for i := range events {
+		eventMap[events[i].EventID] = &events[i]
 	}
 
-	// Publish to each sport's stream
-	for sportKey, sportOdds := range bySport {
-		streamKey := fmt.Sprintf(streamKeyFormat, sportKey)
+	verdicts := w.outliers.Check(odds)
 
-		pipe := w.redis.Pipeline()
+	// Build messages, grouped by stream: one per sport, or two when live odds are split out
+	messages := make([]StreamMessage, 0, len(odds))
+	byStream := make(map[string][]StreamMessage)
+	for i, odd := range odds {
+		// Get event status from map, else from the statuses last seen, default to "upcoming"
+		event := eventMap[odd.EventID]
+		eventStatus := "upcoming"
+		if event != nil && event.EventStatus != "" {
+			eventStatus = event.EventStatus
+		} else if w.IsLive(odd.EventID) {
+			eventStatus = models.EventStatusLive
+		}
 
-		for _, odd := range sportOdds {
-			// Get event status from map, default to "upcoming" if not found
-			eventStatus := eventStatusMap[odd.EventID]
-			if eventStatus == "" {
-				eventStatus = "upcoming"
-			}
+		msg := NewStreamMessage(odd, eventStatus)
+		w.teams.Enrich(&msg, event)
+		if verdicts != nil && verdicts[i].Consensus != 0 {
+			consensus := verdicts[i].Consensus
+			msg.ConsensusPrice = &consensus
+			msg.Outlier = verdicts[i].Outlier
+		}
+		messages = append(messages, msg)
 
-			msg := StreamMessage{
-				EventID:          odd.EventID,
-				SportKey:         odd.SportKey,
-				MarketKey:        odd.MarketKey,
-				BookKey:          odd.BookKey,
-				OutcomeName:      odd.OutcomeName,
-				Price:            odd.Price,
-				Point:            odd.Point,
-				VendorLastUpdate: odd.VendorLastUpdate,
-				ReceivedAt:       odd.ReceivedAt,
-				EventStatus:      eventStatus,
-			}
+		streamKey := StreamKey(odd.SportKey, eventStatus, w.liveStreams)
+		byStream[streamKey] = append(byStream[streamKey], msg)
+	}
 
-			msgJSON, err := json.Marshal(msg)
-			if err != nil {
-				return fmt.Errorf("marshal stream message: %w", err)
+	// Sinks get the batch whether or not Redis accepted it
+	defer w.publishToSinks(ctx, messages)
+
+	// Publish to each stream
+	for streamKey, streamMessages := range byStream {
+		entries, err := w.streamEntries(streamMessages)
+		if err != nil {
+			return err
+		}
+
+		pipe := w.redis.Pipeline()
+		for _, values := range entries {
+			if w.streamSecret != "" {
+				if err := consumer.Sign(values, w.streamSecret); err != nil {
+					return fmt.Errorf("sign stream message: %w", err)
+				}
 			}
 
 			pipe.XAdd(ctx, &redis.XAddArgs{
 				Stream: streamKey,
-				Values: map[string]interface{}{
-					"data": msgJSON,
-				},
+				Values: values,
 			})
 		}
 
-		_, err := pipe.Exec(ctx)
-		if err != nil {
+		if _, err := pipe.Exec(ctx); err != nil {
 			return fmt.Errorf("redis pipeline exec for stream: %w", err)
 		}
 	}
@@ -386,7 +760,69 @@ func (w *Writer) publishToStream(ctx context.Context, odds []models.RawOdds, eve
 	return nil
 }
 
+// streamEntries encodes one stream's messages as entries: one per message, or one
+// per (event, book, poll) when aggregating
+func (w *Writer) streamEntries(messages []StreamMessage) ([]map[string]interface{}, error) {
+	entries := make([]map[string]interface{}, 0, len(messages))
+	if !w.aggregate {
+		for _, msg := range messages {
+			values, err := consumer.Encode(msg, w.streamEncoding)
+			if err != nil {
+				return nil, fmt.Errorf("encode stream message: %w", err)
+			}
+			entries = append(entries, values)
+		}
+		return entries, nil
+	}
+
+	for _, batch := range aggregateMessages(messages) {
+		values, err := consumer.EncodeBatch(batch, w.streamEncoding)
+		if err != nil {
+			return nil, fmt.Errorf("encode stream batch: %w", err)
+		}
+		entries = append(entries, values)
+	}
+	return entries, nil
+}
+
+// aggregateMessages groups messages into batches per (event, book, poll), keeping
+// commit order. A message from another poll than its event and book's open batch
+// starts a new batch, so an outcome's updates stay in order across batches
+func aggregateMessages(messages []StreamMessage) []models.StreamBatch {
+	var batches []models.StreamBatch
+	open := make(map[string]int) // event|book -> index of its latest batch
+	for _, msg := range messages {
+		key := msg.EventID + "|" + msg.BookKey
+		i, ok := open[key]
+		if !ok || batches[i].PollID != msg.PollID {
+			batches = append(batches, models.StreamBatch{
+				SchemaVersion: models.StreamSchemaVersion,
+				EventID:       msg.EventID,
+				SportKey:      msg.SportKey,
+				BookKey:       msg.BookKey,
+				PollID:        msg.PollID,
+			})
+			i = len(batches) - 1
+			open[key] = i
+		}
+		batches[i].Outcomes = append(batches[i].Outcomes, msg)
+	}
+	return batches
+}
+
+// publishToSinks hands a published batch to each sink, logging (not failing) errors
+func (w *Writer) publishToSinks(ctx context.Context, messages []StreamMessage) {
+	for _, sink := range w.sinks {
+		if err := sink.Publish(ctx, messages); err != nil {
+			fmt.Printf("[Writer] %s sink error: %v\n", sink.Name(), err)
+		}
+	}
+}
+
 // upsertEventsFromList inserts or updates events in the events table
+// New events take the vendor's status; existing events keep theirs, since the status
+// updater owns transitions (and announces them). A postponed or cancelled event the
+// vendor lists again before its new start is upcoming again
 func (w *Writer) upsertEventsFromList(ctx context.Context, tx *sql.Tx, events []models.Event) error {
 	if len(events) == 0 {
 		return nil
@@ -404,7 +840,11 @@ func (w *Writer) upsertEventsFromList(ctx context.Context, tx *sql.Tx, events []
 			home_team = EXCLUDED.home_team,
 			away_team = EXCLUDED.away_team,
 			commence_time = EXCLUDED.commence_time,
-			event_status = EXCLUDED.event_status
+			event_status = CASE
+				WHEN events.event_status IN ('postponed', 'cancelled') AND EXCLUDED.event_status = 'upcoming' THEN 'upcoming'
+				ELSE events.event_status
+			END,
+			last_seen_at = NOW()
 	`
 
 	eventIDs := make([]string, len(events))
@@ -419,7 +859,7 @@ func (w *Writer) upsertEventsFromList(ctx context.Context, tx *sql.Tx, events []
 		sportKeys[i] = evt.SportKey
 		homeTeams[i] = evt.HomeTeam
 		awayTeams[i] = evt.AwayTeam
-		commenceTimes[i] = evt.CommenceTime
+		commenceTimes[i] = timeutil.UTC(evt.CommenceTime)
 		statuses[i] = evt.EventStatus
 	}
 
@@ -432,6 +872,8 @@ func (w *Writer) upsertEventsFromList(ctx context.Context, tx *sql.Tx, events []
 }
 
 // upsertBooksFromOdds extracts unique books from odds and inserts them if they don't exist
+// Classification, display name and regions come from the book registry; rows that
+// already exist are left alone (seed data and the admin API own them)
 func (w *Writer) upsertBooksFromOdds(ctx context.Context, tx *sql.Tx, odds []models.RawOdds) error {
 	if len(odds) == 0 {
 		return nil
@@ -447,43 +889,37 @@ func (w *Writer) upsertBooksFromOdds(ctx context.Context, tx *sql.Tx, odds []mod
 		return nil
 	}
 
-	// Build UPSERT statement for books
-	// We'll insert with minimal info and let manual seed data provide full details
+	// Regions travel as comma-joined text because UNNEST cannot produce a row of arrays
 	query := `
 		INSERT INTO books (book_key, display_name, book_type, active, regions, supported_sports)
-		SELECT UNNEST($1::text[]), UNNEST($2::text[]), 'soft', true, ARRAY['us'], ARRAY[UNNEST($3::text[])]
+		SELECT UNNEST($1::text[]), UNNEST($2::text[]), UNNEST($3::text[]), true,
+			string_to_array(UNNEST($4::text[]), ','), ARRAY[UNNEST($5::text[])]
 		ON CONFLICT (book_key) DO NOTHING
 	`
 
 	bookKeys := make([]string, 0, len(bookMap))
 	displayNames := make([]string, 0, len(bookMap))
+	bookTypes := make([]string, 0, len(bookMap))
+	regions := make([]string, 0, len(bookMap))
 	sportKeys := make([]string, 0, len(bookMap))
 
 	for bookKey, sportKey := range bookMap {
+		book := w.books.Lookup(bookKey) // nil-safe: unknown books are soft/us
 		bookKeys = append(bookKeys, bookKey)
-		// Capitalize first letter for display name
-		displayNames = append(displayNames, capitalizeFirst(bookKey))
+		displayNames = append(displayNames, book.DisplayName)
+		bookTypes = append(bookTypes, string(book.Class))
+		regions = append(regions, strings.Join(book.Regions, ","))
 		sportKeys = append(sportKeys, sportKey)
 	}
 
 	_, err := tx.ExecContext(ctx, query,
-		pq.Array(bookKeys), pq.Array(displayNames), pq.Array(sportKeys),
+		pq.Array(bookKeys), pq.Array(displayNames), pq.Array(bookTypes),
+		pq.Array(regions), pq.Array(sportKeys),
 	)
 
 	return err
 }
 
-// capitalizeFirst capitalizes the first letter of a string
-func capitalizeFirst(s string) string {
-	if len(s) == 0 {
-		return s
-	}
-	if s[0] >= 'a' && s[0] <= 'z' {
-		return string(s[0]-32) + s[1:]
-	}
-	return s
-}
-
 // filterEUBooks only accepts Pinnacle from EU region books
 // This prevents foreign key errors from unknown EU bookmakers
 func filterEUBooks(odds []models.RawOdds) []models.RawOdds {
@@ -542,93 +978,86 @@ func isEUOnlyBook(bookKey string) bool {
 	return euOnlyBooks[bookKey]
 }
 
-// identifyNewEvents returns events that haven't been seen before
-// This is used to trigger page warming only for genuinely new events
-func (w *Writer) identifyNewEvents(events []models.Event) []models.Event {
-	if len(events) == 0 {
-		return nil
+// StreamKey returns the odds stream an event's odds are published to: odds.live.<sport>
+// for live events when live streams are split out, otherwise odds.raw.<sport>
+func StreamKey(sportKey, eventStatus string, liveStreams bool) string {
+	if liveStreams && eventStatus == models.EventStatusLive {
+		return fmt.Sprintf(liveStreamKeyFormat, sportKey)
 	}
+	return fmt.Sprintf(streamKeyFormat, sportKey)
+}
 
-	w.seenEventsMu.Lock()
-	defer w.seenEventsMu.Unlock()
-
-	newEvents := make([]models.Event, 0)
-	for _, evt := range events {
-		if !w.seenEvents[evt.EventID] {
-			w.seenEvents[evt.EventID] = true
-			newEvents = append(newEvents, evt)
-		}
-	}
+// IsLive reports whether eventID was live when its status was last seen (in written
+// events, a status change or the startup load)
+func (w *Writer) IsLive(eventID string) bool {
+	w.liveEventsMu.RLock()
+	defer w.liveEventsMu.RUnlock()
+	return w.liveEvents[eventID]
+}
 
-	return newEvents
+// HandleEventStatusChanged tracks events going live (or ending) between polls, so
+// buffered odds are routed by their current status
+func (w *Writer) HandleEventStatusChanged(ctx context.Context, msg bus.EventStatusChanged) {
+	w.setLive(msg.EventID, msg.NewStatus)
 }
 
-// warmGamePages sends OpenGamePage requests to Talos for new events
-// Only warms events within 72 hours (most sportsbooks only list 1-3 days ahead)
-// Rate limited to 1 second between requests to avoid overwhelming Talos
-func (w *Writer) warmGamePages(ctx context.Context, events []models.Event) {
-	if w.talos == nil || !w.talos.IsEnabled() {
-		return
+// LoadLiveEvents loads the events already live, so odds buffered right after a
+// restart are routed as live. Call on startup when live odds are split out
+func (w *Writer) LoadLiveEvents(ctx context.Context) error {
+	rows, err := w.db.QueryContext(ctx, `SELECT event_id FROM events WHERE event_status = 'live'`)
+	if err != nil {
+		return fmt.Errorf("query live events: %w", err)
 	}
+	defer rows.Close()
 
-	// Filter events that should be warmed
-	now := time.Now()
-	warmWindow := 72 * time.Hour // Match sportsbook availability window
-
-	var toWarm []models.Event
-	var skippedFuture int
-
-	for _, evt := range events {
-		// Only warm pages for upcoming events (not live or completed)
-		if evt.EventStatus != "" && evt.EventStatus != "upcoming" {
-			continue
-		}
-
-		// Skip if commence time is in the past
-		if evt.CommenceTime.Before(now) {
-			continue
-		}
-
-		// Skip if event is too far in the future (sportsbook won't have it listed)
-		if evt.CommenceTime.After(now.Add(warmWindow)) {
-			skippedFuture++
-			continue
+	for rows.Next() {
+		var eventID string
+		if err := rows.Scan(&eventID); err != nil {
+			return fmt.Errorf("scan live event: %w", err)
 		}
-
-		toWarm = append(toWarm, evt)
+		w.setLive(eventID, models.EventStatusLive)
 	}
+	return rows.Err()
+}
 
-	if len(toWarm) == 0 {
-		if skippedFuture > 0 {
-			fmt.Printf("[Writer] Skipped %d events beyond 72h window\n", skippedFuture)
+// trackStatuses records the statuses of written events
+func (w *Writer) trackStatuses(events []models.Event) {
+	for _, event := range events {
+		if event.EventStatus != "" {
+			w.setLive(event.EventID, event.EventStatus)
 		}
-		return
 	}
+}
 
-	if skippedFuture > 0 {
-		fmt.Printf("[Writer] Warming %d events (skipped %d beyond 72h window)\n", len(toWarm), skippedFuture)
+func (w *Writer) setLive(eventID, status string) {
+	w.liveEventsMu.Lock()
+	defer w.liveEventsMu.Unlock()
+	if status == models.EventStatusLive {
+		w.liveEvents[eventID] = true
 	} else {
-		fmt.Printf("[Writer] Warming %d new events...\n", len(toWarm))
+		delete(w.liveEvents, eventID)
 	}
+}
 
-	// Send page warm requests with rate limiting
-	// Use a goroutine to avoid blocking the writer, but rate limit internally
-	go func() {
-		for i, e := range toWarm {
-			warmCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-
-			if err := w.talos.OpenGamePage(warmCtx, e.HomeTeam, e.AwayTeam, e.SportKey, e.CommenceTime); err != nil {
-				fmt.Printf("[Writer] Page warm failed for %s @ %s: %v\n", e.AwayTeam, e.HomeTeam, err)
-			}
+// identifyNewEvents returns events that haven't been seen before
+// This is used to trigger page warming only for genuinely new events
+func (w *Writer) identifyNewEvents(events []models.Event) []models.Event {
+	if len(events) == 0 {
+		return nil
+	}
 
-			cancel()
+	w.seenEventsMu.Lock()
+	defer w.seenEventsMu.Unlock()
 
-			// Rate limit: 1 second between requests, except after last
-			if i < len(toWarm)-1 {
-				time.Sleep(1 * time.Second)
-			}
+	newEvents := make([]models.Event, 0)
+	for _, evt := range events {
+		if !w.seenEvents[evt.EventID] {
+			w.seenEvents[evt.EventID] = true
+			newEvents = append(newEvents, evt)
 		}
-	}()
+	}
+
+	return newEvents
 }
 
 // ClearSeenEvents clears the seen events cache (useful for testing or restarts)
@@ -669,7 +1098,7 @@ func (w *Writer) LoadSeenEventsFromDB(ctx context.Context) error {
 	return nil
 }
 
-// WarmUpcomingEvents sends OpenGamePage requests for ALL upcoming events on startup
+// WarmUpcomingEvents enqueues page warm jobs for ALL upcoming events on startup
 // This ensures game pages are warmed even if Talos was down when Mercury discovered the events.
 //
 // Key behavior:
@@ -677,8 +1106,9 @@ func (w *Writer) LoadSeenEventsFromDB(ctx context.Context) error {
 // - Does NOT check seenEvents - that's only for preventing duplicates during polling
 // - Marks events as seen AFTER queuing warm request (prevents re-warming during polling)
 // - Talos has deduplication at the bot level, so duplicate requests are safe
+// - Jobs are persisted in the warm queue, which rate limits and retries failures
 func (w *Writer) WarmUpcomingEvents(ctx context.Context) error {
-	if w.talos == nil || !w.talos.IsEnabled() {
+	if w.warmQueue == nil || !w.warmQueue.IsEnabled() {
 		fmt.Println("[Writer] Talos client not enabled, skipping warm-up")
 		return nil
 	}
@@ -719,29 +1149,27 @@ func (w *Writer) WarmUpcomingEvents(ctx context.Context) error {
 		return nil
 	}
 
-	fmt.Printf("[Writer] Startup warm-up: sending %d events to Talos (Talos will deduplicate)...\n", len(eventsToWarm))
+	fmt.Printf("[Writer] Startup warm-up: queuing %d events for Talos (Talos will deduplicate)...\n", len(eventsToWarm))
 
-	// Warm pages for all events
+	if err := w.warmQueue.Enqueue(ctx, talos.WarmJobsFromEvents(eventsToWarm)...); err != nil {
+		return fmt.Errorf("enqueue warm-up jobs: %w", err)
+	}
+
+	// Mark as seen so polling doesn't re-warm these
+	w.seenEventsMu.Lock()
 	for _, evt := range eventsToWarm {
-		// Mark as seen so polling doesn't re-warm these
-		w.seenEventsMu.Lock()
 		w.seenEvents[evt.EventID] = true
-		w.seenEventsMu.Unlock()
-
-		// Send warm request (async)
-		go func(e models.Event) {
-			warmCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-
-			if err := w.talos.OpenGamePage(warmCtx, e.HomeTeam, e.AwayTeam, e.SportKey, e.CommenceTime); err != nil {
-				fmt.Printf("[Writer] Warm-up failed for %s @ %s: %v\n", e.AwayTeam, e.HomeTeam, err)
-			}
-		}(evt)
-
-		// Rate limit: 1 second between requests to avoid overwhelming Talos
-		time.Sleep(1 * time.Second)
 	}
+	w.seenEventsMu.Unlock()
 
-	fmt.Printf("[Writer] Warm-up requests sent for %d events\n", len(eventsToWarm))
+	fmt.Printf("[Writer] Warm-up jobs queued for %d events\n", len(eventsToWarm))
 	return nil
 }
+
+// oddsFormat returns the format an odd was quoted in, defaulting to American
+func oddsFormat(odd models.RawOdds) models.OddsFormat {
+	if odd.OddsFormat == "" {
+		return models.OddsFormatAmerican
+	}
+	return odd.OddsFormat
+}
@@ -0,0 +1,327 @@
+// Package wspush serves odds deltas to WebSocket clients in real time.
+// Clients subscribe with sport/event/market/book filters; deltas are sourced from
+// the writer's commit path via the in-process event bus, so pushes carry the same
+// payload as the odds.raw.<sport> Redis streams without a Redis round trip.
+package wspush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/participants"
+	"github.com/XavierBriggs/Mercury/internal/writer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const (
+	defaultSendBuffer = 1024
+	writeTimeout      = 10 * time.Second
+	pingInterval      = 30 * time.Second
+
+	closeNormal    = 1000
+	closeGoingAway = 1001
+)
+
+// Filter selects which deltas a client receives; empty lists match everything
+type Filter struct {
+	Sports  []string `json:"sports,omitempty"`
+	Events  []string `json:"events,omitempty"`
+	Markets []string `json:"markets,omitempty"`
+	Books   []string `json:"books,omitempty"`
+}
+
+// Matches reports whether a delta passes the filter
+func (f Filter) Matches(msg writer.StreamMessage) bool {
+	return matchAny(f.Sports, msg.SportKey) &&
+		matchAny(f.Events, msg.EventID) &&
+		matchAny(f.Markets, msg.MarketKey) &&
+		matchAny(f.Books, msg.BookKey)
+}
+
+// ClientMessage is a control message sent by a client
+// {"action":"subscribe","sports":["basketball_nba"],"markets":["spreads"]} replaces the filter;
+// {"action":"unsubscribe"} stops deltas until the next subscribe
+type ClientMessage struct {
+	Action string `json:"action"`
+	Filter
+}
+
+// ServerMessage is a message pushed to a client
+type ServerMessage struct {
+	Type   string                `json:"type"` // subscribed, unsubscribed, delta, error
+	Filter *Filter               `json:"filter,omitempty"`
+	Data   *writer.StreamMessage `json:"data,omitempty"`
+	Error  string                `json:"error,omitempty"`
+}
+
+// client is one connected WebSocket
+type client struct {
+	conn    *conn
+	send    chan []byte
+	done    chan struct{}
+	dropped atomic.Int64
+
+	mu         sync.RWMutex
+	filter     Filter
+	subscribed bool
+}
+
+// wants reports whether the client is subscribed to a delta
+func (c *client) wants(msg writer.StreamMessage) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.subscribed && c.filter.Matches(msg)
+}
+
+// enqueue queues a message without blocking; a full queue drops it
+func (c *client) enqueue(payload []byte) {
+	select {
+	case c.send <- payload:
+	default:
+		c.dropped.Add(1)
+	}
+}
+
+// Server accepts WebSocket connections and fans out committed deltas
+type Server struct {
+	addr       string
+	sendBuffer int
+	httpServer *http.Server
+	teams      *participants.Directory // Optional participant IDs, as on the Redis stream
+
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+
+	wg sync.WaitGroup
+}
+
+// NewServer creates a new WebSocket push server listening on addr (e.g. ":8090")
+func NewServer(addr string) *Server {
+	s := &Server{
+		addr:       addr,
+		sendBuffer: defaultSendBuffer,
+		clients:    make(map[*client]struct{}),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", s.HandleWebSocket)
+	s.httpServer = &http.Server{Addr: addr, Handler: mux}
+
+	return s
+}
+
+// SetParticipants sets the directory used to attach team IDs to deltas
+func (s *Server) SetParticipants(directory *participants.Directory) {
+	s.teams = directory
+}
+
+// SetSendBuffer sets the per-client outbound queue size (slow clients drop beyond it)
+func (s *Server) SetSendBuffer(size int) {
+	if size > 0 {
+		s.sendBuffer = size
+	}
+}
+
+// Start begins accepting connections
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", s.addr, err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("[WSPush] server error: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("✓ WebSocket push server listening on %s/ws\n", listener.Addr())
+	return nil
+}
+
+// Stop closes the listener and disconnects all clients
+func (s *Server) Stop() {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	s.httpServer.Shutdown(shutdownCtx)
+
+	// Hijacked connections are not tracked by http.Server; close them here
+	s.mu.Lock()
+	for c := range s.clients {
+		c.conn.writeClose(closeGoingAway, writeTimeout)
+		c.conn.close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// Clients returns the number of connected clients
+func (s *Server) Clients() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.clients)
+}
+
+// HandleWebSocket upgrades a request and serves the client until it disconnects
+func (s *Server) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	wsConn, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+
+	c := &client{
+		conn: wsConn,
+		send: make(chan []byte, s.sendBuffer),
+		done: make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.writeLoop(c)
+	}()
+
+	s.readLoop(c)
+
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+
+	close(c.done)
+	c.conn.close()
+
+	if dropped := c.dropped.Load(); dropped > 0 {
+		fmt.Printf("[WSPush] client %s disconnected after dropping %d deltas\n", r.RemoteAddr, dropped)
+	}
+}
+
+// readLoop applies client control messages until the connection closes
+func (s *Server) readLoop(c *client) {
+	for {
+		payload, err := c.conn.readMessage(writeTimeout)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				c.conn.writeClose(closeNormal, writeTimeout)
+			}
+			return
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			c.enqueue(encode(ServerMessage{Type: "error", Error: "invalid JSON: " + err.Error()}))
+			continue
+		}
+
+		switch msg.Action {
+		case "subscribe":
+			c.mu.Lock()
+			c.filter = msg.Filter
+			c.subscribed = true
+			c.mu.Unlock()
+			filter := msg.Filter
+			c.enqueue(encode(ServerMessage{Type: "subscribed", Filter: &filter}))
+		case "unsubscribe":
+			c.mu.Lock()
+			c.filter = Filter{}
+			c.subscribed = false
+			c.mu.Unlock()
+			c.enqueue(encode(ServerMessage{Type: "unsubscribed"}))
+		default:
+			c.enqueue(encode(ServerMessage{Type: "error", Error: fmt.Sprintf("unknown action %q", msg.Action)}))
+		}
+	}
+}
+
+// writeLoop sends queued messages and keepalive pings
+func (s *Server) writeLoop(c *client) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case payload := <-c.send:
+			if err := c.conn.writeText(payload, writeTimeout); err != nil {
+				c.conn.close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.writeFrame(opPing, nil, time.Now().Add(writeTimeout)); err != nil {
+				c.conn.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// HandleDeltaBatchCommitted fans a committed batch out to subscribed clients
+func (s *Server) HandleDeltaBatchCommitted(ctx context.Context, msg bus.DeltaBatchCommitted) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.clients) == 0 {
+		return
+	}
+
+	events := make(map[string]*models.Event, len(msg.Events))
+	for i := range msg.Events {
+		events[msg.Events[i].EventID] = &msg.Events[i]
+	}
+
+	for _, odd := range msg.Odds {
+		event := events[odd.EventID]
+		status := "upcoming"
+		if event != nil && event.EventStatus != "" {
+			status = event.EventStatus
+		}
+		delta := writer.NewStreamMessage(odd, status)
+		s.teams.Enrich(&delta, event)
+
+		var payload []byte
+		for c := range s.clients {
+			if !c.wants(delta) {
+				continue
+			}
+			if payload == nil {
+				payload = encode(ServerMessage{Type: "delta", Data: &delta})
+			}
+			c.enqueue(payload)
+		}
+	}
+}
+
+// encode marshals a server message (all fields are JSON-safe)
+func encode(msg ServerMessage) []byte {
+	payload, _ := json.Marshal(msg)
+	return payload
+}
+
+// matchAny reports whether value is in values (an empty filter matches everything)
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,534 @@
+// Package consumer is a typed reader over Mercury's odds.raw.<sport> Redis Streams
+// and odds.live.<sport>, where in-play odds go when Mercury splits them out.
+//
+// It wraps consumer-group plumbing (group creation, XREADGROUP, pending
+// re-delivery, XACK) and decodes entries into models.StreamMessage so downstream
+// services don't each reimplement it:
+//
+//	c := consumer.New(redisClient, consumer.Config{
+//		Group:    "edge-engine",
+//		Consumer: hostname,
+//		Sports:   []string{"basketball_nba"},
+//	})
+//	err := c.Run(ctx, func(ctx context.Context, msg consumer.Message) error {
+//		// handle msg.Odds; returning nil acks the entry
+//		return nil
+//	})
+package consumer
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// StreamKeyPrefix prefixes every per-sport odds stream (odds.raw.basketball_nba)
+	StreamKeyPrefix = "odds.raw."
+
+	// LiveStreamKeyPrefix prefixes the per-sport in-play odds streams (odds.live.basketball_nba)
+	LiveStreamKeyPrefix = "odds.live."
+
+	defaultCount = 100
+	defaultBlock = 5 * time.Second
+)
+
+// StreamKey returns the odds stream for a sport
+func StreamKey(sportKey string) string {
+	return StreamKeyPrefix + sportKey
+}
+
+// LiveStreamKey returns the in-play odds stream for a sport
+func LiveStreamKey(sportKey string) string {
+	return LiveStreamKeyPrefix + sportKey
+}
+
+// Message is one decoded odds update. An aggregated entry (KindBatch) decodes to one
+// Message per outcome, all sharing the entry's ID
+type Message struct {
+	Stream string // e.g. odds.raw.basketball_nba
+	ID     string // Redis stream entry ID
+	Odds   models.StreamMessage
+}
+
+// DecodeError reports a stream entry whose payload could not be decoded
+type DecodeError struct {
+	Stream string
+	ID     string
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode %s %s: %v", e.Stream, e.ID, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Config configures a consumer group reader
+type Config struct {
+	Group    string   // Consumer group name (required)
+	Consumer string   // Consumer name within the group (required, unique per process)
+	Sports   []string // Sports to read (required)
+
+	// PreMatchOnly skips the sports' odds.live streams. By default both are read, so
+	// in-play odds are seen whether or not Mercury splits them out
+	PreMatchOnly bool
+
+	// StartID is where a newly created group begins: "$" (default) for new entries
+	// only, "0" to replay the full stream, or any entry ID
+	StartID string
+
+	Count int64         // Max entries per read (default 100)
+	Block time.Duration // How long a read waits for new entries (default 5s)
+
+	// OnDecodeError is called for malformed entries, which Run acks and skips
+	OnDecodeError func(err *DecodeError)
+
+	// Secret is Mercury's STREAM_SIGNING_SECRET. When set, entries without a valid
+	// signature are treated as malformed (ErrUnsigned, ErrBadSignature)
+	Secret string
+}
+
+// Consumer reads odds streams as part of a consumer group
+type Consumer struct {
+	redis   *redis.Client
+	config  Config
+	streams []string
+}
+
+// New creates a consumer group reader
+func New(redisClient *redis.Client, config Config) *Consumer {
+	if config.StartID == "" {
+		config.StartID = "$"
+	}
+	if config.Count <= 0 {
+		config.Count = defaultCount
+	}
+	if config.Block <= 0 {
+		config.Block = defaultBlock
+	}
+
+	streams := make([]string, 0, 2*len(config.Sports))
+	for _, sport := range config.Sports {
+		streams = append(streams, StreamKey(sport))
+		if !config.PreMatchOnly {
+			streams = append(streams, LiveStreamKey(sport))
+		}
+	}
+
+	return &Consumer{
+		redis:   redisClient,
+		config:  config,
+		streams: streams,
+	}
+}
+
+// EnsureGroups creates the consumer group on every stream (and the stream itself if missing)
+// Existing groups are left untouched
+func (c *Consumer) EnsureGroups(ctx context.Context) error {
+	if c.config.Group == "" || c.config.Consumer == "" || len(c.streams) == 0 {
+		return errors.New("consumer: group, consumer and sports are required")
+	}
+
+	for _, stream := range c.streams {
+		err := c.redis.XGroupCreateMkStream(ctx, stream, c.config.Group, c.config.StartID).Err()
+		if err != nil && !IsBusyGroup(err) {
+			return fmt.Errorf("create group %s on %s: %w", c.config.Group, stream, err)
+		}
+	}
+	return nil
+}
+
+// Read returns new entries for this consumer, blocking up to Config.Block
+// Entries stay pending until acked
+func (c *Consumer) Read(ctx context.Context) ([]Message, []*DecodeError, error) {
+	return c.read(ctx, ">", c.config.Block)
+}
+
+// ReadPending returns entries delivered to this consumer but not yet acked
+// (e.g. after a crash), without blocking
+func (c *Consumer) ReadPending(ctx context.Context) ([]Message, []*DecodeError, error) {
+	return c.read(ctx, "0", -1)
+}
+
+// read issues XREADGROUP with the same ID for every stream
+func (c *Consumer) read(ctx context.Context, id string, block time.Duration) ([]Message, []*DecodeError, error) {
+	args := make([]string, 0, len(c.streams)*2)
+	args = append(args, c.streams...)
+	for range c.streams {
+		args = append(args, id)
+	}
+
+	result, err := c.redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.config.Group,
+		Consumer: c.config.Consumer,
+		Streams:  args,
+		Count:    c.config.Count,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("xreadgroup: %w", err)
+	}
+
+	messages, decodeErrs := decodeStreams(result, c.config.Secret)
+	return messages, decodeErrs, nil
+}
+
+// Ack acknowledges processed messages
+func (c *Consumer) Ack(ctx context.Context, messages ...Message) error {
+	byStream := make(map[string][]string)
+	for _, msg := range messages {
+		byStream[msg.Stream] = append(byStream[msg.Stream], msg.ID)
+	}
+	return c.ackIDs(ctx, byStream)
+}
+
+// ackIDs acknowledges entry IDs grouped by stream
+func (c *Consumer) ackIDs(ctx context.Context, byStream map[string][]string) error {
+	for stream, ids := range byStream {
+		if err := c.redis.XAck(ctx, stream, c.config.Group, ids...).Err(); err != nil {
+			return fmt.Errorf("xack %s: %w", stream, err)
+		}
+	}
+	return nil
+}
+
+// Run ensures groups exist, drains this consumer's pending entries, then reads new
+// entries until ctx is done. Entries are acked when handler returns nil; on error
+// the entry stays pending and is retried on the next Run. The outcomes of an
+// aggregated entry are handled in order and acked together
+func (c *Consumer) Run(ctx context.Context, handler func(ctx context.Context, msg Message) error) error {
+	if err := c.EnsureGroups(ctx); err != nil {
+		return err
+	}
+
+	pending := true
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var messages []Message
+		var decodeErrs []*DecodeError
+		var err error
+		if pending {
+			messages, decodeErrs, err = c.ReadPending(ctx)
+		} else {
+			messages, decodeErrs, err = c.Read(ctx)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		if err := c.skipMalformed(ctx, decodeErrs); err != nil {
+			return err
+		}
+
+		// Pending is drained once a pending read comes back empty
+		if pending && len(messages) == 0 && len(decodeErrs) == 0 {
+			pending = false
+			continue
+		}
+
+		// An aggregated entry is acked once every outcome in it is handled
+		failed := false
+		for i, msg := range messages {
+			if err := handler(ctx, msg); err != nil {
+				failed = true
+				if pending {
+					// Leave it pending and move on to new entries rather than spin
+					pending = false
+				}
+			}
+			if i+1 < len(messages) && messages[i+1].Stream == msg.Stream && messages[i+1].ID == msg.ID {
+				continue
+			}
+			if !failed {
+				if err := c.Ack(ctx, msg); err != nil {
+					return err
+				}
+			}
+			failed = false
+		}
+	}
+}
+
+// skipMalformed reports and acks entries that cannot be decoded so they are not redelivered
+func (c *Consumer) skipMalformed(ctx context.Context, decodeErrs []*DecodeError) error {
+	if len(decodeErrs) == 0 {
+		return nil
+	}
+
+	byStream := make(map[string][]string)
+	for _, decodeErr := range decodeErrs {
+		if c.config.OnDecodeError != nil {
+			c.config.OnDecodeError(decodeErr)
+		}
+		byStream[decodeErr.Stream] = append(byStream[decodeErr.Stream], decodeErr.ID)
+	}
+	return c.ackIDs(ctx, byStream)
+}
+
+// ReadRange replays a sport's odds streams (odds.raw and odds.live) from an entry ID
+// without a consumer group. Use "-" for the beginning; the returned messages are
+// oldest first across both streams. Pass the last message ID (exclusive, prefixed
+// with "(") to page forward (signatures are not checked)
+func ReadRange(ctx context.Context, redisClient *redis.Client, sportKey, fromID string, count int64) ([]Message, []*DecodeError, error) {
+	var streams []redis.XStream
+	for _, stream := range []string{StreamKey(sportKey), LiveStreamKey(sportKey)} {
+		entries, err := redisClient.XRangeN(ctx, stream, fromID, "+", count).Result()
+		if err != nil && err != redis.Nil {
+			return nil, nil, fmt.Errorf("xrange %s: %w", stream, err)
+		}
+		streams = append(streams, redis.XStream{Stream: stream, Messages: entries})
+	}
+
+	messages, decodeErrs := decodeStreams(mergeStreams(streams, count), "")
+	return messages, decodeErrs, nil
+}
+
+// mergeStreams interleaves the streams' entries by ID, as single-entry streams so each
+// keeps its stream name, and keeps the first count. Entries sharing the last kept ID
+// are all kept, so paging past that ID skips nothing
+func mergeStreams(streams []redis.XStream, count int64) []redis.XStream {
+	var merged []redis.XStream
+	for _, stream := range streams {
+		for _, entry := range stream.Messages {
+			merged = append(merged, redis.XStream{Stream: stream.Stream, Messages: []redis.XMessage{entry}})
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return CompareIDs(merged[i].Messages[0].ID, merged[j].Messages[0].ID) < 0
+	})
+
+	if count > 0 && int64(len(merged)) > count {
+		last := merged[count-1].Messages[0].ID
+		n := count
+		for n < int64(len(merged)) && CompareIDs(merged[n].Messages[0].ID, last) == 0 {
+			n++
+		}
+		merged = merged[:n]
+	}
+	return merged
+}
+
+// CompareIDs orders two stream entry IDs ("<ms>-<seq>") like cmp.Compare; IDs that
+// don't parse sort first
+func CompareIDs(a, b string) int {
+	aMs, aSeq := parseID(a)
+	bMs, bSeq := parseID(b)
+	if c := cmp.Compare(aMs, bMs); c != 0 {
+		return c
+	}
+	return cmp.Compare(aSeq, bSeq)
+}
+
+// parseID splits a stream entry ID into its millisecond and sequence parts
+func parseID(id string) (uint64, uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ := strconv.ParseUint(msPart, 10, 64)
+	seq, _ := strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
+
+// IDFromTime returns the first stream entry ID at or after t (for replay by timestamp)
+func IDFromTime(t time.Time) string {
+	return fmt.Sprintf("%d-0", t.UnixMilli())
+}
+
+// IsBusyGroup reports whether err is Redis' "consumer group already exists" error
+func IsBusyGroup(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "BUSYGROUP")
+}
+
+// FieldKind is the stream entry field marking aggregated entries (KindBatch); it is
+// absent on single-outcome entries
+const FieldKind = "kind"
+
+// KindBatch marks an entry carrying a models.StreamBatch
+const KindBatch = "batch"
+
+// Encode builds the fields of a stream entry: the message under "data", plus an
+// "encoding" field for anything but JSON so readers can tell payloads apart
+func Encode(msg models.StreamMessage, encoding models.StreamEncoding) (map[string]interface{}, error) {
+	if encoding == models.StreamEncodingProtobuf {
+		return map[string]interface{}{
+			"data":     msg.MarshalProto(),
+			"encoding": string(models.StreamEncodingProtobuf),
+		}, nil
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"data": data}, nil
+}
+
+// EncodeBatch builds the fields of an aggregated stream entry: the batch under
+// "data", "kind" = "batch", and "encoding" as Encode sets it
+func EncodeBatch(batch models.StreamBatch, encoding models.StreamEncoding) (map[string]interface{}, error) {
+	if encoding == models.StreamEncodingProtobuf {
+		return map[string]interface{}{
+			"data":     batch.MarshalProto(),
+			"encoding": string(models.StreamEncodingProtobuf),
+			FieldKind:  KindBatch,
+		}, nil
+	}
+
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"data": data, FieldKind: KindBatch}, nil
+}
+
+// DecodeEntry parses a raw stream entry into its messages: one for a single-outcome
+// entry, every outcome of an aggregated one
+func DecodeEntry(values map[string]interface{}) ([]models.StreamMessage, error) {
+	switch kind, _ := values[FieldKind].(string); kind {
+	case "":
+		msg, err := Decode(values)
+		if err != nil {
+			return nil, err
+		}
+		return []models.StreamMessage{msg}, nil
+	case KindBatch:
+		batch, err := DecodeBatch(values)
+		if err != nil {
+			return nil, err
+		}
+		return batch.Outcomes, nil
+	default:
+		return nil, fmt.Errorf("unknown entry kind %q", kind)
+	}
+}
+
+// DecodeBatch parses the "data" field of an aggregated stream entry. Outcomes without
+// a schema version are reported as schema 1
+func DecodeBatch(values map[string]interface{}) (models.StreamBatch, error) {
+	var batch models.StreamBatch
+
+	data, err := entryData(values)
+	if err != nil {
+		return batch, err
+	}
+
+	switch encoding, _ := values["encoding"].(string); models.StreamEncoding(encoding) {
+	case "", models.StreamEncodingJSON:
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return batch, err
+		}
+	case models.StreamEncodingProtobuf:
+		if err := batch.UnmarshalProto(data); err != nil {
+			return batch, err
+		}
+	default:
+		return batch, fmt.Errorf("unknown encoding %q", encoding)
+	}
+
+	if batch.SchemaVersion == 0 {
+		batch.SchemaVersion = 1
+	}
+	if len(batch.Outcomes) == 0 {
+		return batch, errors.New("batch has no outcomes")
+	}
+	for i := range batch.Outcomes {
+		if batch.Outcomes[i].SchemaVersion == 0 {
+			batch.Outcomes[i].SchemaVersion = 1
+		}
+	}
+	return batch, nil
+}
+
+// Decode parses the "data" field of a raw stream entry, JSON or protobuf as its
+// "encoding" field says. Messages without a schema version are reported as schema 1
+func Decode(values map[string]interface{}) (models.StreamMessage, error) {
+	var msg models.StreamMessage
+
+	data, err := entryData(values)
+	if err != nil {
+		return msg, err
+	}
+
+	switch encoding, _ := values["encoding"].(string); models.StreamEncoding(encoding) {
+	case "", models.StreamEncodingJSON:
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return msg, err
+		}
+	case models.StreamEncodingProtobuf:
+		if err := msg.UnmarshalProto(data); err != nil {
+			return msg, err
+		}
+	default:
+		return msg, fmt.Errorf("unknown encoding %q", encoding)
+	}
+
+	if msg.SchemaVersion == 0 {
+		msg.SchemaVersion = 1
+	}
+	return msg, nil
+}
+
+// entryData returns the payload of a raw stream entry (string once read back from
+// Redis, bytes as encoded)
+func entryData(values map[string]interface{}) ([]byte, error) {
+	raw, ok := values["data"]
+	if !ok {
+		return nil, errors.New("missing data field")
+	}
+
+	switch v := raw.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("unexpected data type %T", raw)
+	}
+}
+
+// decodeStreams converts raw stream entries, separating malformed ones. With a
+// secret, entries without a valid signature count as malformed
+func decodeStreams(streams []redis.XStream, secret string) ([]Message, []*DecodeError) {
+	var messages []Message
+	var decodeErrs []*DecodeError
+
+	for _, stream := range streams {
+		for _, entry := range stream.Messages {
+			if secret != "" {
+				if err := Verify(entry.Values, secret); err != nil {
+					decodeErrs = append(decodeErrs, &DecodeError{Stream: stream.Stream, ID: entry.ID, Err: err})
+					continue
+				}
+			}
+			outcomes, err := DecodeEntry(entry.Values)
+			if err != nil {
+				decodeErrs = append(decodeErrs, &DecodeError{Stream: stream.Stream, ID: entry.ID, Err: err})
+				continue
+			}
+			for _, odds := range outcomes {
+				messages = append(messages, Message{Stream: stream.Stream, ID: entry.ID, Odds: odds})
+			}
+		}
+	}
+
+	return messages, decodeErrs
+}
@@ -0,0 +1,57 @@
+package consumer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// FieldSignature is the stream entry field holding the payload's signature when
+// Mercury signs its streams (STREAM_SIGNING_SECRET)
+const FieldSignature = "signature"
+
+// Signature errors, reported as decode errors by consumers with a Secret
+var (
+	ErrUnsigned     = errors.New("entry is not signed")
+	ErrBadSignature = errors.New("signature does not match")
+)
+
+// SignPayload returns the signature of a payload: "sha256=" and the hex HMAC-SHA256
+// of the bytes as published, the same form as webhook signatures
+func SignPayload(secret string, data []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyPayload reports whether signature is valid for data
+func VerifyPayload(secret string, data []byte, signature string) bool {
+	return hmac.Equal([]byte(SignPayload(secret, data)), []byte(signature))
+}
+
+// Sign adds the signature of an encoded entry's "data" field to its fields
+func Sign(values map[string]interface{}, secret string) error {
+	data, err := entryData(values)
+	if err != nil {
+		return err
+	}
+	values[FieldSignature] = SignPayload(secret, data)
+	return nil
+}
+
+// Verify checks the signature of a raw stream entry
+func Verify(values map[string]interface{}, secret string) error {
+	data, err := entryData(values)
+	if err != nil {
+		return err
+	}
+	signature, _ := values[FieldSignature].(string)
+	if signature == "" {
+		return ErrUnsigned
+	}
+	if !VerifyPayload(secret, data, signature) {
+		return ErrBadSignature
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package contracts
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// PollAuditSink receives one audit row per completed poll
+// RecordPoll is called on the polling path and must not block
+type PollAuditSink interface {
+	RecordPoll(audit models.PollAudit)
+}
+
+// requestStatsKey carries a poll's request stats in a request context
+type requestStatsKey struct{}
+
+// RequestStats accumulates what the vendor requests made for one poll returned. The
+// caller attaches it with WithRequestStats; an adapter adds every response to it.
+// Safe for concurrent use, as split fetches send requests in parallel
+type RequestStats struct {
+	mu     sync.Mutex
+	totals RequestTotals
+}
+
+// RequestTotals is a snapshot of RequestStats
+type RequestTotals struct {
+	Requests   int
+	StatusCode int   // Last failed status, else the last status (0 = no response)
+	Bytes      int64 // Response body bytes as received (compressed when gzipped)
+	Credits    int   // Credits the vendor reported (-1 = it did not say)
+}
+
+// WithRequestStats returns a context whose vendor requests are added to stats
+func WithRequestStats(ctx context.Context, stats *RequestStats) context.Context {
+	return context.WithValue(ctx, requestStatsKey{}, stats)
+}
+
+// RequestStatsFrom returns the stats attached to ctx, or nil
+func RequestStatsFrom(ctx context.Context) *RequestStats {
+	stats, _ := ctx.Value(requestStatsKey{}).(*RequestStats)
+	return stats
+}
+
+// Response records one response and its credit cost (-1 when not reported).
+// A nil receiver is a no-op
+func (s *RequestStats) Response(statusCode, credits int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.totals.Requests == 0 {
+		s.totals.Credits = -1
+	}
+	s.totals.Requests++
+	if s.totals.StatusCode == 0 || s.totals.StatusCode == http.StatusOK || statusCode != http.StatusOK {
+		s.totals.StatusCode = statusCode
+	}
+	if credits >= 0 {
+		s.totals.Credits = max(s.totals.Credits, 0) + credits
+	}
+}
+
+// AddBytes records n bytes of response body. A nil receiver is a no-op
+func (s *RequestStats) AddBytes(n int64) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.totals.Bytes += n
+}
+
+// Totals returns what has been recorded so far
+func (s *RequestStats) Totals() RequestTotals {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.totals
+}
@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// FuturesAdapter is implemented by vendor adapters that can fetch futures/outright markets
+// Kept separate from VendorAdapter so adapters without futures support need no stubs
+type FuturesAdapter interface {
+	// FetchFutures retrieves odds for a futures/outright market
+	FetchFutures(ctx context.Context, opts *models.FetchFuturesOptions) ([]models.FuturesOdds, error)
+
+	// GetRateLimits returns current rate limit information
+	GetRateLimits() *models.RateLimits
+}
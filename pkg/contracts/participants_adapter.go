@@ -0,0 +1,15 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// ParticipantsAdapter is implemented by vendor adapters that can list a sport's
+// participants (teams or players) with stable IDs
+// Kept separate from VendorAdapter so adapters without a roster endpoint need no stubs
+type ParticipantsAdapter interface {
+	// FetchParticipants retrieves every participant the vendor lists for a sport
+	FetchParticipants(ctx context.Context, sport string) ([]models.Participant, error)
+}
@@ -0,0 +1,15 @@
+package contracts
+
+import "github.com/XavierBriggs/Mercury/pkg/models"
+
+// PayloadArchiver receives raw vendor responses before they are parsed
+// Archive is called on the polling path and must not block
+type PayloadArchiver interface {
+	Archive(payload models.RawPayload)
+}
+
+// PayloadParser is implemented by vendor adapters that can re-parse archived payloads
+// Parsed odds keep the payload's ReceivedAt so replays carry the original timestamps
+type PayloadParser interface {
+	ParsePayload(payload models.RawPayload) (*models.FetchResult, error)
+}
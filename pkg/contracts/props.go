@@ -0,0 +1,19 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// PropsScheduleStore persists each event's props polling schedule, so a restart
+// resumes the pollers where they were on the ramp instead of polling every event at
+// once. Save and Remove are called from props pollers after each poll
+type PropsScheduleStore interface {
+	// Load returns a sport's saved schedules
+	Load(ctx context.Context, sportKey string) ([]models.PropsPoller, error)
+	// Save inserts or replaces an event's schedule
+	Save(ctx context.Context, poller models.PropsPoller) error
+	// Remove deletes the schedule of an event whose poller finished
+	Remove(ctx context.Context, eventID string) error
+}
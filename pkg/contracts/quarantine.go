@@ -0,0 +1,9 @@
+package contracts
+
+import "github.com/XavierBriggs/Mercury/pkg/models"
+
+// QuarantineSink receives vendor records rejected by an adapter's parser
+// Quarantine is called on the polling path and must not block
+type QuarantineSink interface {
+	Quarantine(records []models.QuarantinedRecord)
+}
@@ -0,0 +1,25 @@
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// retryBudgetKey carries a caller's retry budget in a request context
+type retryBudgetKey struct{}
+
+// WithRetryBudget bounds the total time an adapter may spend waiting between retries
+// of one request, typically the caller's poll interval: past it the next poll is due
+// anyway. An adapter that cannot retry within the budget returns its last error
+func WithRetryBudget(ctx context.Context, budget time.Duration) context.Context {
+	if budget <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, retryBudgetKey{}, budget)
+}
+
+// RetryBudget returns the retry budget set on ctx, if any
+func RetryBudget(ctx context.Context) (time.Duration, bool) {
+	budget, ok := ctx.Value(retryBudgetKey{}).(time.Duration)
+	return budget, ok
+}
@@ -0,0 +1,16 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// ScoresAdapter is implemented by vendor adapters that report game state (scores and
+// a completed flag), which event status prefers over commence time heuristics
+// Kept separate from VendorAdapter so adapters without a scores endpoint need no stubs
+type ScoresAdapter interface {
+	// FetchScores retrieves live and upcoming games for a sport, plus games completed
+	// within the last daysFrom days (0 = none)
+	FetchScores(ctx context.Context, sport string, daysFrom int) ([]models.EventScore, error)
+}
@@ -0,0 +1,36 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// Optional SportModule hooks. A sport module implements any of these interfaces to
+// run sport-specific side effects at points in an event's life without changes to
+// the pipeline. Hooks are called synchronously with a short timeout, so they must
+// return quickly and hand slow work off. A returned error or panic is logged and
+// never fails the poll or status update that triggered it.
+
+// EventDiscoveredHook is called the first time this instance sees an event that has
+// not finished, from a featured poll or props discovery. With sport sharding or
+// leader election, only the instance polling the sport sees it
+type EventDiscoveredHook interface {
+	OnEventDiscovered(ctx context.Context, event models.Event) error
+}
+
+// EventLiveHook is called when the status updater moves an event to live
+type EventLiveHook interface {
+	OnEventLive(ctx context.Context, event models.Event) error
+}
+
+// EventCompletedHook is called when the status updater moves an event to completed
+type EventCompletedHook interface {
+	OnEventCompleted(ctx context.Context, event models.Event) error
+}
+
+// OddsAcceptedHook is called with the odds of a poll that passed validation and were
+// written: the changed prices only, as the delta engine drops unchanged ones
+type OddsAcceptedHook interface {
+	OnOddsAccepted(ctx context.Context, odds []models.RawOdds) error
+}
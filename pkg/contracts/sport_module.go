@@ -21,22 +21,80 @@ type SportModule interface {
 	// GetRegions returns the regions to poll (e.g., ["us", "us2"])
 	GetRegions() []string
 
+	// GetBookmakers returns the vendor bookmaker keys to request instead of whole
+	// regions (empty = poll GetRegions). Each 10 books cost the same as one region
+	GetBookmakers() []string
+
 	// GetFeaturedPollInterval returns how often to poll featured markets
 	GetFeaturedPollInterval() time.Duration
 
+	// GetFeaturedWindowHours limits featured polls to events starting within this many
+	// hours (live events are always included). Zero polls the whole listed schedule
+	GetFeaturedWindowHours() int
+
+	// GetTipoffWindow returns how long before start an event gets targeted featured
+	// refreshes on top of the slate poll (zero disables the tipoff track)
+	GetTipoffWindow() time.Duration
+
+	// GetTipoffInterval returns how often events inside the tipoff window are refreshed
+	GetTipoffInterval() time.Duration
+
 	// GetPropsPollInterval returns how often to poll player props
 	GetPropsPollInterval() time.Duration
 
+	// GetPropsMarkets returns the per-event markets (player and game props) to poll
+	GetPropsMarkets() []string
+
+	// GetPropsInterval returns the props poll interval for an event on the ramp schedule
+	GetPropsInterval(hoursUntilStart float64, isLive bool) time.Duration
+
+	// GetPropsJitterSeconds returns the maximum random jitter added to props polls
+	GetPropsJitterSeconds() int
+
 	// GetPropsDiscoveryInterval returns how often to discover new events
 	GetPropsDiscoveryInterval() time.Duration
 
 	// GetPropsDiscoveryWindow returns how many hours ahead to discover events
 	GetPropsDiscoveryWindowHours() int
 
+	// GetTypicalGameDuration returns how long after commence_time a game is
+	// considered finished (including a safety buffer for overtime/delays)
+	GetTypicalGameDuration() time.Duration
+
+	// GetQuotaPriority returns the degradation priority under quota pressure
+	// Lower values are more important and are cut last
+	GetQuotaPriority() int
+
+	// GetMinFeaturedCadence returns the slowest featured interval allowed under quota pressure
+	GetMinFeaturedCadence() time.Duration
+
+	// GetMinPropsCadence returns the slowest props interval allowed under quota pressure
+	// Zero means props polling may be paused entirely
+	GetMinPropsCadence() time.Duration
+
+	// GetFuturesKeys returns the vendor outright keys to poll on the futures track
+	// (e.g. "basketball_nba_championship_winner"); empty disables futures for the sport
+	GetFuturesKeys() []string
+
+	// GetFuturesPollInterval returns how often to poll futures/outright markets
+	GetFuturesPollInterval() time.Duration
+
+	// GetSteamSettings returns steam move detection sensitivity for this sport
+	GetSteamSettings() models.SteamSettings
+
+	// GetTeamAliases returns vendor/book team name spellings mapped to canonical names
+	// (e.g. "LA Lakers" -> "Los Angeles Lakers"), applied by internal/normalize
+	GetTeamAliases() map[string]string
+
 	// ShouldPollProps returns whether this sport supports props polling
 	ShouldPollProps() bool
 
 	// ValidateOdds performs sport-specific validation on raw odds
+	// Failures should be classified as pkg/errors.ErrValidation
 	ValidateOdds(odds models.RawOdds) error
+
+	// ValidateEvent performs sport-specific validation on a fetched event
+	// Failures should be classified as pkg/errors.ErrValidation
+	ValidateEvent(event models.Event) error
 }
 
@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// StreamSink receives every committed odds delta alongside the Redis streams, for
+// forwarding to another message transport (NATS JetStream, Kafka...)
+// Publish is called on the write path, in commit order per outcome, and must bound
+// its own latency; errors are logged and never fail the write
+type StreamSink interface {
+	Name() string
+	Publish(ctx context.Context, messages []models.StreamMessage) error
+	Close() error
+}
@@ -0,0 +1,9 @@
+package contracts
+
+import "github.com/XavierBriggs/Mercury/pkg/models"
+
+// UsageSink receives the quota cost of every vendor request an adapter makes
+// RecordUsage is called on the polling path and must not block
+type UsageSink interface {
+	RecordUsage(usage models.VendorUsage)
+}
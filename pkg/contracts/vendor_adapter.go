@@ -18,7 +18,7 @@ type VendorAdapter interface {
 	FetchEventOdds(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error)
 
 	// FetchEvents retrieves upcoming events without odds (for discovery)
-	FetchEvents(ctx context.Context, sport string) ([]models.Event, error)
+	FetchEvents(ctx context.Context, opts *models.FetchEventsOptions) ([]models.Event, error)
 
 	// SupportsMarket checks if this adapter supports a given market
 	SupportsMarket(market string) bool
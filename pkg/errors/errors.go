@@ -0,0 +1,161 @@
+// Package errors classifies vendor and pipeline failures so callers can decide whether
+// to retry, back off or give up, and label metrics by cause rather than by message.
+// A classified error wraps its cause and one kind sentinel; errors.Is matches both,
+// through fmt.Errorf wrapping and errors.Join. Messages are unchanged by classifying.
+// It has no dependencies so adapters outside this module can import it.
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Kinds. Retryable kinds may succeed if the same request is made again later;
+// terminal ones will not until something changes (a key, the quota, the payload)
+var (
+	// ErrRateLimited means the vendor throttled the request (retryable, after RetryAfter)
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrQuotaExhausted means the vendor's credit quota is used up (terminal)
+	ErrQuotaExhausted = errors.New("quota exhausted")
+
+	// ErrVendorUnavailable covers network failures, timeouts and 5xx (retryable)
+	ErrVendorUnavailable = errors.New("vendor unavailable")
+
+	// ErrVendorRejected covers other 4xx: bad key, bad parameters, unknown sport (terminal)
+	ErrVendorRejected = errors.New("vendor rejected request")
+
+	// ErrMalformedResponse means a vendor payload could not be decoded (terminal)
+	ErrMalformedResponse = errors.New("malformed vendor response")
+
+	// ErrValidation means a record failed a sport module's rules (terminal)
+	ErrValidation = errors.New("validation failed")
+
+	// ErrStorage covers Redis and Postgres failures in the pipeline (retryable)
+	ErrStorage = errors.New("storage error")
+)
+
+// labels maps each kind to its metrics label
+var labels = map[error]string{
+	ErrRateLimited:       "rate_limited",
+	ErrQuotaExhausted:    "quota_exhausted",
+	ErrVendorUnavailable: "vendor_unavailable",
+	ErrVendorRejected:    "vendor_rejected",
+	ErrMalformedResponse: "malformed_response",
+	ErrValidation:        "validation",
+	ErrStorage:           "storage",
+}
+
+// Error is a classified failure
+type Error struct {
+	Kind       error         // One of the Err* kinds
+	Op         string        // Optional operation prefixed to the message, e.g. "detect changes"
+	StatusCode int           // Vendor HTTP status, if any
+	RetryAfter time.Duration // Vendor-requested wait before retrying (rate limits), if any
+	Err        error         // Cause
+}
+
+// Error returns the cause's message, prefixed with Op when set
+func (e *Error) Error() string {
+	if e.Op == "" {
+		return e.Err.Error()
+	}
+	return e.Op + ": " + e.Err.Error()
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is and errors.As
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// Wrap classifies err as kind, prefixing op to its message (nil err returns nil)
+func Wrap(kind error, op string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Op: op, Err: err}
+}
+
+// Newf returns a classified error with a formatted message
+func Newf(kind error, format string, args ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// FromStatus classifies a non-2xx vendor response: 429 is a rate limit, 408 and 5xx
+// mean the vendor is unavailable, any other status is a rejection. Adapters whose
+// vendor signals an exhausted quota with its own status or message override Kind
+func FromStatus(statusCode int, message string, retryAfter time.Duration) *Error {
+	kind := ErrVendorRejected
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case statusCode == http.StatusRequestTimeout || statusCode >= 500:
+		kind = ErrVendorUnavailable
+	}
+
+	return &Error{
+		Kind:       kind,
+		StatusCode: statusCode,
+		RetryAfter: retryAfter,
+		Err:        fmt.Errorf("HTTP %d: %s", statusCode, message),
+	}
+}
+
+// KindOf returns the kind of the first classified error in err's tree, or nil
+func KindOf(err error) error {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.Kind
+	}
+	return nil
+}
+
+// RetryAfter returns the wait requested by the vendor in err's tree (0 if none)
+func RetryAfter(err error) time.Duration {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.RetryAfter
+	}
+	return 0
+}
+
+// Retryable reports whether the same request may succeed later. Cancellation and
+// unclassified errors are not retryable
+func Retryable(err error) bool {
+	switch KindOf(err) {
+	case ErrRateLimited, ErrVendorUnavailable, ErrStorage:
+		return true
+	}
+	return false
+}
+
+// Terminal reports whether err is classified as one that retrying will not fix
+func Terminal(err error) bool {
+	switch KindOf(err) {
+	case ErrQuotaExhausted, ErrVendorRejected, ErrMalformedResponse, ErrValidation:
+		return true
+	}
+	return false
+}
+
+// Label returns a metrics label for err: its kind's label, "canceled" or "timeout"
+// for context errors, "unknown" for anything else and "" for nil
+func Label(err error) string {
+	if err == nil {
+		return ""
+	}
+	if label, ok := labels[KindOf(err)]; ok {
+		return label
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+	return "unknown"
+}
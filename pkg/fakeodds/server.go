@@ -0,0 +1,487 @@
+// Package fakeodds is an httptest server speaking The Odds API v4. It serves a
+// configurable slate whose prices move on a script as a clock advances, reports
+// credit headers, and can be told to fail, so end-to-end behaviour (props ramping,
+// deltas, status changes, closing lines) can be tested without spending credits.
+//
+// Endpoints: /v4/sports/{sport}/odds, /events, /events/{id}/odds, /scores and
+// /participants. Regions are accepted but ignored (every book is served); a
+// bookmakers parameter filters books. Lines are served for whichever markets are
+// requested, featured or props, on either odds endpoint.
+package fakeodds
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// DefaultQuota is the credits a server starts with unless WithQuota is given
+const DefaultQuota = 1_000_000
+
+// Event is one listed game. It is listed until it completes, Duration after it
+// commences; its scores are reported from the commence time on
+type Event struct {
+	ID           string
+	SportKey     string
+	HomeTeam     string
+	AwayTeam     string
+	CommenceTime time.Time
+	Duration     time.Duration // 0 = never completes
+	HomeScore    int
+	AwayScore    int
+	Lines        []Line
+}
+
+// Line is one book's price for one outcome. It opens at Price and Point, then each
+// move applies once the clock reaches its At
+type Line struct {
+	Book        string
+	Market      string
+	Outcome     string
+	Description string // Player name on props outcomes
+	Price       float64
+	Point       *float64
+	Moves       []Move
+}
+
+// Move is a scripted line movement
+type Move struct {
+	At    time.Time
+	Price float64
+	Point *float64 // nil keeps the current point
+}
+
+// Request is one request the server answered
+type Request struct {
+	Path       string
+	Query      url.Values
+	StatusCode int
+	Cost       int
+}
+
+// Clock is a manually advanced clock for scripting line moves and game states
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewClock returns a clock stopped at start
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the clock's time
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to t
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Option customizes a Server at construction
+type Option func(*Server)
+
+// WithClock replaces the wall clock (see Clock)
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) {
+		s.now = now
+	}
+}
+
+// WithQuota sets the credits available; once spent, requests are refused with
+// 401 OUT_OF_USAGE_CREDITS
+func WithQuota(remaining int) Option {
+	return func(s *Server) {
+		s.remaining = remaining
+	}
+}
+
+// WithAPIKeys accepts only these keys, refusing others with 401 (default: any key)
+func WithAPIKeys(keys ...string) Option {
+	return func(s *Server) {
+		s.apiKeys = make(map[string]bool, len(keys))
+		for _, key := range keys {
+			s.apiKeys[key] = true
+		}
+	}
+}
+
+// Server is a fake The Odds API. Close it when done
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	now       func() time.Time
+	opened    time.Time // last_update of lines that have not moved
+	events    []*Event
+	apiKeys   map[string]bool
+	remaining int
+	used      int
+	failures  []failure
+	requests  []Request
+}
+
+// failure is one scripted error response
+type failure struct {
+	status     int
+	retryAfter time.Duration
+}
+
+// New starts a fake server with an empty slate
+func New(opts ...Option) *Server {
+	s := &Server{
+		now:       time.Now,
+		remaining: DefaultQuota,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.opened = s.now().UTC()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// BaseURL returns the URL to configure a client with (theoddsapi.WithBaseURL)
+func (s *Server) BaseURL() string {
+	return s.URL + "/"
+}
+
+// AddEvent lists an event (replacing one with the same ID)
+func (s *Server) AddEvent(event Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event.Lines = append([]Line(nil), event.Lines...)
+	for i, existing := range s.events {
+		if existing.ID == event.ID {
+			s.events[i] = &event
+			return
+		}
+	}
+	s.events = append(s.events, &event)
+}
+
+// Move scripts a movement of one line of an event
+func (s *Server) Move(eventID, book, market, outcome string, move Move) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range s.events {
+		if event.ID != eventID {
+			continue
+		}
+		for i := range event.Lines {
+			line := &event.Lines[i]
+			if line.Book == book && line.Market == market && line.Outcome == outcome {
+				line.Moves = append(line.Moves, move)
+				sort.SliceStable(line.Moves, func(a, b int) bool { return line.Moves[a].At.Before(line.Moves[b].At) })
+				return nil
+			}
+		}
+		return fmt.Errorf("event %s has no %s %s %s line", eventID, book, market, outcome)
+	}
+	return fmt.Errorf("unknown event %s", eventID)
+}
+
+// FailNext makes the next n requests fail with status, sending Retry-After when
+// retryAfter is set
+func (s *Server) FailNext(n, status int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, failure{status: status, retryAfter: retryAfter})
+	}
+}
+
+// Requests returns every request answered so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Remaining returns the credits left
+func (s *Server) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.remaining
+}
+
+// handle routes /v4/sports/{sport}/...
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	now := s.now().UTC()
+	record := func(status, cost int) {
+		s.requests = append(s.requests, Request{Path: r.URL.Path, Query: query, StatusCode: status, Cost: cost})
+	}
+
+	if len(s.failures) > 0 {
+		fail := s.failures[0]
+		s.failures = s.failures[1:]
+		if fail.retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fail.retryAfter.Round(time.Second)/time.Second)))
+		}
+		record(fail.status, 0)
+		s.writeError(w, fail.status, "SCRIPTED_FAILURE", http.StatusText(fail.status))
+		return
+	}
+
+	if s.apiKeys != nil && !s.apiKeys[query.Get("apiKey")] {
+		record(http.StatusUnauthorized, 0)
+		s.writeError(w, http.StatusUnauthorized, "INVALID_KEY", "API key is not valid")
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "v4" || parts[1] != "sports" {
+		record(http.StatusNotFound, 0)
+		s.writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown endpoint")
+		return
+	}
+	sport := parts[2]
+
+	var body any
+	cost := 0
+	switch {
+	case len(parts) == 4 && parts[3] == "odds":
+		var events []oddsEvent
+		events, cost = s.odds(sport, "", query, now)
+		body = events
+	case len(parts) == 4 && parts[3] == "events":
+		body = s.listEvents(sport, query, now)
+	case len(parts) == 6 && parts[3] == "events" && parts[5] == "odds":
+		events, eventCost := s.odds(sport, parts[4], query, now)
+		if len(events) == 0 {
+			record(http.StatusNotFound, 0)
+			s.writeError(w, http.StatusNotFound, "EVENT_NOT_FOUND", "Event not found. The event may have expired or the event id is invalid.")
+			return
+		}
+		body, cost = events[0], eventCost
+	case len(parts) == 4 && parts[3] == "scores":
+		body = s.scores(sport, query.Get("daysFrom") != "", now)
+		cost = 1
+		if query.Get("daysFrom") != "" {
+			cost = 2
+		}
+	case len(parts) == 4 && parts[3] == "participants":
+		body = s.participants(sport)
+		cost = 1
+	default:
+		record(http.StatusNotFound, 0)
+		s.writeError(w, http.StatusNotFound, "NOT_FOUND", "Unknown endpoint")
+		return
+	}
+
+	if cost > s.remaining {
+		record(http.StatusUnauthorized, 0)
+		w.Header().Set("x-requests-remaining", "0")
+		w.Header().Set("x-requests-used", strconv.Itoa(s.used))
+		s.writeError(w, http.StatusUnauthorized, "OUT_OF_USAGE_CREDITS", "Usage quota has been reached")
+		return
+	}
+	s.remaining -= cost
+	s.used += cost
+	record(http.StatusOK, cost)
+
+	w.Header().Set("x-requests-remaining", strconv.Itoa(s.remaining))
+	w.Header().Set("x-requests-used", strconv.Itoa(s.used))
+	w.Header().Set("x-requests-last", strconv.Itoa(cost))
+	writeJSON(w, r, body)
+}
+
+// listed reports whether an event is listed for sport at now within the request's
+// commence window
+func listed(event *Event, sport string, query url.Values, now time.Time) bool {
+	if event.SportKey != sport || completed(event, now) {
+		return false
+	}
+	if from, err := timeutil.ParseVendorTime(query.Get("commenceTimeFrom")); err == nil && event.CommenceTime.Before(from) {
+		return false
+	}
+	if to, err := timeutil.ParseVendorTime(query.Get("commenceTimeTo")); err == nil && event.CommenceTime.After(to) {
+		return false
+	}
+	return true
+}
+
+// completed reports whether an event's game is over at now
+func completed(event *Event, now time.Time) bool {
+	return event.Duration > 0 && !now.Before(event.CommenceTime.Add(event.Duration))
+}
+
+// odds builds the odds response for sport (or one event) and its cost: the
+// distinct markets returned times the region units requested
+func (s *Server) odds(sport, eventID string, query url.Values, now time.Time) ([]oddsEvent, int) {
+	markets := csvSet(query.Get("markets"))
+	books := csvSet(query.Get("bookmakers"))
+	eventIDs := csvSet(query.Get("eventIds"))
+
+	var events []oddsEvent
+	returned := make(map[string]bool)
+	for _, event := range s.events {
+		if !listed(event, sport, query, now) || (eventID != "" && event.ID != eventID) {
+			continue
+		}
+		if len(eventIDs) > 0 && !eventIDs[event.ID] {
+			continue
+		}
+
+		out := oddsEvent{eventJSON: toEventJSON(event)}
+		byBook := make(map[string]int)
+		for _, line := range event.Lines {
+			if (len(markets) > 0 && !markets[line.Market]) || (len(books) > 0 && !books[line.Book]) {
+				continue
+			}
+			price, point, updated := s.quote(line, now)
+
+			i, ok := byBook[line.Book]
+			if !ok {
+				i = len(out.Bookmakers)
+				byBook[line.Book] = i
+				out.Bookmakers = append(out.Bookmakers, bookJSON{Key: line.Book, Title: line.Book})
+			}
+			out.Bookmakers[i].add(line, price, point, updated)
+			returned[line.Market] = true
+		}
+		events = append(events, out)
+	}
+
+	units := len(csvSet(query.Get("regions")))
+	if len(books) > 0 {
+		units = (len(books) + 9) / 10
+	}
+	return events, len(returned) * max(units, 1)
+}
+
+// quote returns a line's price, point and last update at now
+func (s *Server) quote(line Line, now time.Time) (float64, *float64, time.Time) {
+	price, point, updated := line.Price, line.Point, s.opened
+	for _, move := range line.Moves {
+		if move.At.After(now) {
+			break
+		}
+		price, updated = move.Price, move.At.UTC()
+		if move.Point != nil {
+			point = move.Point
+		}
+	}
+	return price, point, updated
+}
+
+// listEvents builds the events response (free)
+func (s *Server) listEvents(sport string, query url.Values, now time.Time) []eventJSON {
+	events := []eventJSON{}
+	for _, event := range s.events {
+		if listed(event, sport, query, now) {
+			events = append(events, toEventJSON(event))
+		}
+	}
+	return events
+}
+
+// scores builds the scores response: every listed game, plus completed ones when
+// daysFrom was given
+func (s *Server) scores(sport string, includeCompleted bool, now time.Time) []scoreJSON {
+	scores := []scoreJSON{}
+	for _, event := range s.events {
+		if event.SportKey != sport {
+			continue
+		}
+		done := completed(event, now)
+		if done && !includeCompleted {
+			continue
+		}
+
+		score := scoreJSON{eventJSON: toEventJSON(event), Completed: done}
+		if !now.Before(event.CommenceTime) {
+			score.Scores = []teamScore{
+				{Name: event.HomeTeam, Score: strconv.Itoa(event.HomeScore)},
+				{Name: event.AwayTeam, Score: strconv.Itoa(event.AwayScore)},
+			}
+			updated := timeutil.FormatVendorTime(now)
+			score.LastUpdate = &updated
+		}
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// participants lists the teams of sport's events
+func (s *Server) participants(sport string) []participantJSON {
+	seen := make(map[string]bool)
+	participants := []participantJSON{}
+	for _, event := range s.events {
+		if event.SportKey != sport {
+			continue
+		}
+		for _, team := range []string{event.HomeTeam, event.AwayTeam} {
+			if team == "" || seen[team] {
+				continue
+			}
+			seen[team] = true
+			participants = append(participants, participantJSON{
+				ID:       "par_" + strings.ToLower(strings.ReplaceAll(team, " ", "_")),
+				FullName: team,
+			})
+		}
+	}
+	return participants
+}
+
+// writeError writes a vendor-style error body (caller holds mu)
+func (s *Server) writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"message": message, "error_code": code})
+}
+
+// writeJSON writes body, gzipped when the client accepts it
+func writeJSON(w http.ResponseWriter, r *http.Request, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		json.NewEncoder(w).Encode(body)
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	gz := gzip.NewWriter(w)
+	defer gz.Close()
+	json.NewEncoder(gz).Encode(body)
+}
+
+// csvSet splits a comma-separated parameter into a set
+func csvSet(value string) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			set[item] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,107 @@
+package fakeodds
+
+import (
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// Response bodies, shaped like The Odds API's
+
+type eventJSON struct {
+	ID           string `json:"id"`
+	SportKey     string `json:"sport_key"`
+	SportTitle   string `json:"sport_title"`
+	CommenceTime string `json:"commence_time"`
+	HomeTeam     string `json:"home_team"`
+	AwayTeam     string `json:"away_team"`
+}
+
+type oddsEvent struct {
+	eventJSON
+	Bookmakers []bookJSON `json:"bookmakers"`
+}
+
+type bookJSON struct {
+	Key        string       `json:"key"`
+	Title      string       `json:"title"`
+	LastUpdate string       `json:"last_update"`
+	Markets    []marketJSON `json:"markets"`
+
+	updated time.Time
+}
+
+type marketJSON struct {
+	Key        string        `json:"key"`
+	LastUpdate string        `json:"last_update"`
+	Outcomes   []outcomeJSON `json:"outcomes"`
+
+	updated time.Time
+}
+
+type outcomeJSON struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Price       float64  `json:"price"`
+	Point       *float64 `json:"point,omitempty"`
+}
+
+type scoreJSON struct {
+	eventJSON
+	Completed  bool        `json:"completed"`
+	Scores     []teamScore `json:"scores"`
+	LastUpdate *string     `json:"last_update"`
+}
+
+type teamScore struct {
+	Name  string `json:"name"`
+	Score string `json:"score"`
+}
+
+type participantJSON struct {
+	ID       string `json:"id"`
+	FullName string `json:"full_name"`
+}
+
+// toEventJSON converts an event's listing fields
+func toEventJSON(event *Event) eventJSON {
+	return eventJSON{
+		ID:           event.ID,
+		SportKey:     event.SportKey,
+		SportTitle:   event.SportKey,
+		CommenceTime: timeutil.FormatVendorTime(event.CommenceTime),
+		HomeTeam:     event.HomeTeam,
+		AwayTeam:     event.AwayTeam,
+	}
+}
+
+// add appends one outcome to the book's market, keeping last_update the latest
+// movement in the market and in the book
+func (b *bookJSON) add(line Line, price float64, point *float64, updated time.Time) {
+	var market *marketJSON
+	for i := range b.Markets {
+		if b.Markets[i].Key == line.Market {
+			market = &b.Markets[i]
+			break
+		}
+	}
+	if market == nil {
+		b.Markets = append(b.Markets, marketJSON{Key: line.Market})
+		market = &b.Markets[len(b.Markets)-1]
+	}
+
+	market.Outcomes = append(market.Outcomes, outcomeJSON{
+		Name:        line.Outcome,
+		Description: line.Description,
+		Price:       price,
+		Point:       point,
+	})
+	if updated.After(market.updated) {
+		market.updated = updated
+		market.LastUpdate = timeutil.FormatVendorTime(updated)
+	}
+	if updated.After(b.updated) {
+		b.updated = updated
+		b.LastUpdate = timeutil.FormatVendorTime(updated)
+	}
+}
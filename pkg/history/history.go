@@ -0,0 +1,203 @@
+// Package history reads the price and point history of outcomes from Alexandria's
+// odds_raw (and odds_live, where in-play odds go when Mercury splits them out), so
+// charting and model training don't need hand-written SQL:
+//
+//	series, err := history.Fetch(ctx, db, history.Query{
+//		EventID:     eventID,
+//		MarketKey:   "spreads",
+//		OutcomeName: "Los Angeles Lakers",
+//		Interval:    5 * time.Minute, // last quote per 5 minutes
+//	})
+//
+// Each Series is one book's quotes for one outcome, oldest first. Every stored row is
+// a change, so a series steps from one quote to the next.
+package history
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+// Bounds on the rows read per query
+const (
+	DefaultLimit = 10000
+	MaxLimit     = 100000
+)
+
+// Query selects history. EventID and MarketKey are required; other empty fields
+// match everything
+type Query struct {
+	EventID     string
+	MarketKey   string
+	BookKey     string
+	OutcomeName string
+	Description string // Player for props
+
+	From time.Time // received_at bounds; zero = unbounded
+	To   time.Time
+
+	// Down-sampling, applied per series after reading: Interval keeps the last quote
+	// in each interval, then MaxPoints thins to at most that many evenly spaced
+	// quotes (keeping the first and last). Zero keeps every change
+	Interval  time.Duration
+	MaxPoints int
+
+	Limit int // Rows read, oldest first (default DefaultLimit, capped at MaxLimit)
+}
+
+// Point is one quote in a series
+type Point struct {
+	Price            int       `json:"price"`
+	PriceDecimal     float64   `json:"price_decimal"`
+	Point            *float64  `json:"point,omitempty"`
+	VendorLastUpdate time.Time `json:"vendor_last_update"`
+	ReceivedAt       time.Time `json:"received_at"`
+}
+
+// Series is one book's history for one outcome, oldest first
+type Series struct {
+	BookKey     string  `json:"book_key"`
+	OutcomeName string  `json:"outcome_name"`
+	Description string  `json:"description,omitempty"`
+	Points      []Point `json:"points"`
+}
+
+// Fetch reads the history matching q, one series per book and outcome, ordered by
+// book, outcome and description
+func Fetch(ctx context.Context, db *sql.DB, q Query) ([]Series, error) {
+	if q.EventID == "" || q.MarketKey == "" {
+		return nil, errors.New("history: event and market are required")
+	}
+
+	conds := []string{"event_id = $1", "market_key = $2"}
+	args := []interface{}{q.EventID, q.MarketKey}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if q.BookKey != "" {
+		add("book_key = $%d", q.BookKey)
+	}
+	if q.OutcomeName != "" {
+		add("outcome_name = $%d", q.OutcomeName)
+	}
+	if q.Description != "" {
+		add("description = $%d", q.Description)
+	}
+	if !q.From.IsZero() {
+		add("received_at >= $%d", q.From)
+	}
+	if !q.To.IsZero() {
+		add("received_at <= $%d", q.To)
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	args = append(args, limit)
+
+	selectFrom := func(table string) string {
+		return `SELECT book_key, outcome_name, description, price, price_decimal, point,
+				vendor_last_update, received_at
+			FROM ` + table + ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	rows, err := db.QueryContext(ctx, selectFrom("odds_raw")+`
+		UNION ALL
+		`+selectFrom("odds_live")+`
+		ORDER BY received_at
+		LIMIT $`+fmt.Sprint(len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query history: %w", err)
+	}
+	defer rows.Close()
+
+	byKey := make(map[string]*Series)
+	for rows.Next() {
+		var s Series
+		var p Point
+		var priceDecimal, point sql.NullFloat64
+		if err := rows.Scan(&s.BookKey, &s.OutcomeName, &s.Description, &p.Price, &priceDecimal, &point,
+			&p.VendorLastUpdate, &p.ReceivedAt); err != nil {
+			return nil, fmt.Errorf("scan history: %w", err)
+		}
+		p.PriceDecimal = priceDecimal.Float64
+		if point.Valid {
+			p.Point = &point.Float64
+		}
+		p.VendorLastUpdate = timeutil.UTC(p.VendorLastUpdate)
+		p.ReceivedAt = timeutil.UTC(p.ReceivedAt)
+
+		key := s.BookKey + "|" + s.OutcomeName + "|" + s.Description
+		series := byKey[key]
+		if series == nil {
+			series = &s
+			byKey[key] = series
+		}
+		series.Points = append(series.Points, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+
+	result := make([]Series, 0, len(byKey))
+	for _, series := range byKey {
+		series.Points = Thin(Downsample(series.Points, q.Interval), q.MaxPoints)
+		result = append(result, *series)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].BookKey != result[j].BookKey {
+			return result[i].BookKey < result[j].BookKey
+		}
+		if result[i].OutcomeName != result[j].OutcomeName {
+			return result[i].OutcomeName < result[j].OutcomeName
+		}
+		return result[i].Description < result[j].Description
+	})
+	return result, nil
+}
+
+// Downsample keeps the last point received in each interval (points oldest first)
+// An interval of zero or less keeps every point
+func Downsample(points []Point, interval time.Duration) []Point {
+	if interval <= 0 || len(points) == 0 {
+		return points
+	}
+
+	sampled := make([]Point, 0, len(points))
+	for i, p := range points {
+		last := i == len(points)-1
+		if last || !points[i+1].ReceivedAt.Truncate(interval).Equal(p.ReceivedAt.Truncate(interval)) {
+			sampled = append(sampled, p)
+		}
+	}
+	return sampled
+}
+
+// Thin keeps at most max evenly spaced points, always including the first and last
+// A max of zero or less keeps every point
+func Thin(points []Point, max int) []Point {
+	if max <= 0 || len(points) <= max {
+		return points
+	}
+	if max == 1 {
+		return points[len(points)-1:]
+	}
+
+	thinned := make([]Point, max)
+	step := float64(len(points)-1) / float64(max-1)
+	for i := range thinned {
+		thinned[i] = points[int(float64(i)*step+0.5)]
+	}
+	return thinned
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// PollAudit is a compact record of one poll: what was asked of the vendor, what
+// came back and what the pipeline made of it
+type PollAudit struct {
+	PollID     string // Correlation ID carried by the poll's odds rows and stream messages
+	SportKey   string
+	Track      string   // featured, tipoff, props or props discovery
+	EventID    string   // Set for props polls
+	Regions    []string // Regions requested
+	Markets    []string // Markets requested
+	Requests   int      // Vendor requests sent, retries and split fetches included
+	StatusCode int      // Last failed HTTP status, else 200 (0 = no response)
+	Bytes      int64    // Response bytes received
+	Events     int
+	Odds       int
+	Deltas     int
+	Credits    int    // Credits the vendor charged (-1 = not reported)
+	ErrorKind  string // pkg/errors label of the poll's error ("" = succeeded)
+	Error      string
+	StartedAt  time.Time
+	Duration   time.Duration
+}
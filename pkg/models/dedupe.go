@@ -0,0 +1,39 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DedupeKey identifies one vendor quote: the same outcome at the same price, point
+// and limit with the same vendor timestamp always hashes to the same key, however
+// many times it is fetched, retried or replayed. odds_raw.dedupe_key is unique on it.
+// The limit is appended only when set, so keys of quotes without one are unchanged
+// and a limit-only change (ChangeType "limit") is not dropped as a duplicate.
+func (o RawOdds) DedupeKey() string {
+	point := ""
+	if o.Point != nil {
+		point = strconv.FormatFloat(*o.Point, 'f', -1, 64)
+	}
+
+	parts := []string{
+		o.EventID,
+		o.MarketKey,
+		o.BookKey,
+		o.OutcomeName,
+		o.Description,
+		strconv.Itoa(o.Price),
+		strconv.FormatFloat(o.Decimal(), 'f', -1, 64),
+		point,
+		o.VendorLastUpdate.UTC().Format(time.RFC3339Nano),
+	}
+	if o.Limit != nil {
+		parts = append(parts, "limit="+strconv.FormatFloat(*o.Limit, 'f', -1, 64))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:16])
+}
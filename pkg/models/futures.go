@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// FuturesOdds represents a futures/outright price (championship winner, win totals)
+// Futures have no single event commence time, so they live outside the event pipeline
+type FuturesOdds struct {
+	SportKey         string // Parent sport (e.g. basketball_nba)
+	FuturesKey       string // Vendor outright key (e.g. basketball_nba_championship_winner)
+	MarketKey        string // Usually "outrights"
+	BookKey          string
+	OutcomeName      string     // Team or player
+	Price            int        // American odds
+	DecimalPrice     float64    // Decimal odds
+	Point            *float64   // For win totals
+	ExpiresAt        *time.Time // When the market settles, if the vendor provides it
+	VendorLastUpdate time.Time
+	ReceivedAt       time.Time
+}
+
+// FetchFuturesOptions contains parameters for fetching futures/outright odds
+type FetchFuturesOptions struct {
+	Sport      string // Parent sport key
+	FuturesKey string // Vendor outright key
+	Regions    []string
+	Bookmakers []string // Vendor bookmaker keys; when set, requested instead of Regions
+}
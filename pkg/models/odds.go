@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+)
 
 // RawOdds represents raw odds data from a vendor before normalization
 type RawOdds struct {
@@ -9,12 +13,49 @@ type RawOdds struct {
 	MarketKey         string
 	BookKey           string
 	OutcomeName       string
-	Price             int       // American odds
-	Point             *float64  // For spreads/totals
+	Side              string     // home or away for team-sided outcomes (moneyline, spreads); empty otherwise
+	Description       string     // Player name for props outcomes (empty for featured markets)
+	Price             int        // American odds (converted when the vendor quotes decimal)
+	DecimalPrice      float64    // Decimal odds (native when the vendor quotes decimal)
+	OddsFormat        OddsFormat // Format the vendor quoted; the matching price field is lossless
+	Point             *float64   // For spreads/totals
+	Limit             *float64   // Max bet size quoted by the book (nil when the vendor does not expose limits)
+	DeepLink          string     // Vendor bet link (outcome, else market, else bookmaker level); empty if unavailable
+	ChangeType        string     // Set by delta detection (new, price, point, price_and_point, limit)
+	OldPrice          *int       // Previous price, set by delta detection (nil for new outcomes)
+	OldPoint          *float64   // Previous point, set by delta detection (nil for new outcomes or no point)
+	PollID            string     // Correlation ID of the poll that fetched it (poll_audit.poll_id); set by the scheduler
 	VendorLastUpdate  time.Time
 	ReceivedAt        time.Time
 }
 
+// Decimal returns the decimal price, converting from American if it was not populated
+func (o RawOdds) Decimal() float64 {
+	if o.DecimalPrice > 0 {
+		return o.DecimalPrice
+	}
+	return AmericanToDecimal(o.Price)
+}
+
+// PriceDeltaCents returns the price move since OldPrice in cents, across even money
+// (nil without a previous price)
+func (o RawOdds) PriceDeltaCents() *int {
+	if o.OldPrice == nil {
+		return nil
+	}
+	delta := oddsmath.PriceDeltaCents(*o.OldPrice, o.Price)
+	return &delta
+}
+
+// PointDelta returns the point move since OldPoint (nil unless both quotes have a point)
+func (o RawOdds) PointDelta() *float64 {
+	if o.OldPoint == nil || o.Point == nil {
+		return nil
+	}
+	delta := *o.Point - *o.OldPoint
+	return &delta
+}
+
 // Event represents a sporting event
 type Event struct {
 	EventID      string
@@ -27,29 +68,95 @@ type Event struct {
 
 // FetchOddsOptions contains parameters for fetching odds
 type FetchOddsOptions struct {
-	Sport   string
-	Regions []string
-	Markets []string
+	Sport      string
+	Regions    []string
+	Markets    []string
+	Bookmakers []string // Vendor bookmaker keys; when set, requested instead of Regions
+
+	// Only events commencing in [CommenceTimeFrom, CommenceTimeTo]; zero = unbounded
+	CommenceTimeFrom time.Time
+	CommenceTimeTo   time.Time
+
+	// Only these events (empty = the whole slate)
+	EventIDs []string
+}
+
+// FetchEventsOptions contains parameters for fetching upcoming events (discovery)
+type FetchEventsOptions struct {
+	Sport string
+
+	// Only events commencing in [CommenceTimeFrom, CommenceTimeTo]; zero = unbounded
+	CommenceTimeFrom time.Time
+	CommenceTimeTo   time.Time
+}
+
+// InCommenceWindow reports whether commence falls in [from, to] (zero bounds are open)
+func InCommenceWindow(commence, from, to time.Time) bool {
+	if !from.IsZero() && commence.Before(from) {
+		return false
+	}
+	if !to.IsZero() && commence.After(to) {
+		return false
+	}
+	return true
 }
 
 // FetchResult contains both events and odds from a fetch operation
 type FetchResult struct {
 	Events []Event
 	Odds   []RawOdds
+
+	// BookStamps holds the vendor last_update of each bookmaker parsed into Odds, by
+	// bookskip key (nil unless unchanged bookmakers are being skipped)
+	BookStamps map[string]time.Time
+
+	// Validators holds the ETag and Last-Modified sent with each response, by redacted
+	// request (nil unless requests are conditional and the vendor sent validators)
+	Validators map[string]Validators
+
+	// NotModified is set when the vendor answered 304: nothing changed since the
+	// response last processed for the same request, and no body was read
+	NotModified bool
+}
+
+// Validators are a response's cache validators, sent back on the next request for
+// the same resource as If-None-Match and If-Modified-Since
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// Empty reports whether the vendor sent neither validator
+func (v Validators) Empty() bool {
+	return v.ETag == "" && v.LastModified == ""
 }
 
 // FetchEventOddsOptions contains parameters for fetching event-specific odds (props)
 type FetchEventOddsOptions struct {
-	Sport   string
-	EventID string
-	Regions []string
-	Markets []string
+	Sport      string
+	EventID    string
+	Regions    []string
+	Markets    []string
+	Bookmakers []string // Vendor bookmaker keys; when set, requested instead of Regions
 }
 
 // RateLimits contains rate limiting information
 type RateLimits struct {
 	RequestsRemaining int
 	RequestsUsed      int
+	RequestsLast      int // Cost of the most recent request
 	ResetTime         time.Time
+	Keys              []KeyUsage // Per-key usage when several API keys are configured (nil with one)
+}
+
+// KeyUsage is one vendor API key's observed quota and request counts
+type KeyUsage struct {
+	Key           string    // Masked to its last four characters
+	Requests      int64     // Requests sent with the key since startup
+	Failures      int64     // Times the vendor refused the key (401 or used-up quota)
+	Remaining     int       // Credits left, as last reported (-1 = not yet seen)
+	Used          int       // Credits used, as last reported (-1 = not yet seen)
+	DisabledUntil time.Time // Out of rotation until then (zero = in rotation)
+	DisabledKind  string    // pkg/errors label of the refusal that disabled it
 }
 
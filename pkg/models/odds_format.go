@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+)
+
+// OddsFormat identifies how a price was quoted by the vendor
+type OddsFormat string
+
+const (
+	OddsFormatAmerican   OddsFormat = "american"   // -110, +150
+	OddsFormatDecimal    OddsFormat = "decimal"    // 1.91, 2.50
+	OddsFormatFractional OddsFormat = "fractional" // 10/11, 3/2
+)
+
+// ParseOddsFormat validates the name of a format prices can be requested in
+// (case-insensitive). Fractional is rejected: vendors quote american or decimal, and
+// consumers derive fractional odds from the decimal price
+func ParseOddsFormat(value string) (OddsFormat, error) {
+	switch OddsFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case OddsFormatAmerican:
+		return OddsFormatAmerican, nil
+	case OddsFormatDecimal:
+		return OddsFormatDecimal, nil
+	case OddsFormatFractional:
+		return "", fmt.Errorf("odds format %q cannot be requested (use american or decimal)", value)
+	default:
+		return "", fmt.Errorf("unknown odds format: %q", value)
+	}
+}
+
+// AmericanToDecimal converts American odds to decimal odds
+// Returns 0 for invalid American prices (between -100 and +100 exclusive)
+func AmericanToDecimal(american int) float64 {
+	return oddsmath.AmericanToDecimal(american)
+}
+
+// DecimalToAmerican converts decimal odds to American odds, rounded to the nearest integer
+// Returns 0 for invalid decimal prices (<= 1.0)
+func DecimalToAmerican(decimal float64) int {
+	return oddsmath.DecimalToAmerican(decimal)
+}
+
+// FractionalToDecimal converts fractional odds ("5/2", "evs") to decimal odds
+func FractionalToDecimal(fractional string) (float64, error) {
+	value := strings.ToLower(strings.TrimSpace(fractional))
+	if value == "evs" || value == "evens" {
+		return 2, nil
+	}
+
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid fractional odds: %q", fractional)
+	}
+
+	numerator, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fractional numerator %q: %w", parts[0], err)
+	}
+	denominator, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fractional denominator %q: %w", parts[1], err)
+	}
+	if numerator <= 0 || denominator <= 0 {
+		return 0, fmt.Errorf("invalid fractional odds: %q", fractional)
+	}
+
+	return 1 + numerator/denominator, nil
+}
+
+// DecimalToFractional converts decimal odds to the closest fraction with a denominator up to 100
+func DecimalToFractional(decimal float64) string {
+	if decimal <= 1 {
+		return ""
+	}
+
+	profit := decimal - 1
+	bestNum, bestDen := 0, 1
+	bestErr := math.MaxFloat64
+
+	for den := 1; den <= 100; den++ {
+		num := int(math.Round(profit * float64(den)))
+		if num <= 0 {
+			continue
+		}
+		if diff := math.Abs(profit - float64(num)/float64(den)); diff < bestErr-1e-9 {
+			bestNum, bestDen, bestErr = num, den, diff
+		}
+	}
+
+	return fmt.Sprintf("%d/%d", bestNum, bestDen)
+}
+
+// NormalizePrice converts a vendor price into both representations carried on RawOdds
+// American prices are exact when the vendor quotes American; decimal is exact otherwise
+func NormalizePrice(format OddsFormat, price float64) (american int, decimal float64, err error) {
+	switch format {
+	case OddsFormatAmerican, "":
+		american = int(math.Round(price))
+		decimal = AmericanToDecimal(american)
+	case OddsFormatDecimal:
+		decimal = price
+		american = DecimalToAmerican(price)
+	default:
+		return 0, 0, fmt.Errorf("unsupported numeric odds format: %q", format)
+	}
+
+	if decimal <= 1 || american == 0 {
+		return 0, 0, fmt.Errorf("invalid %s price: %v", format, price)
+	}
+	return american, decimal, nil
+}
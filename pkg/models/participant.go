@@ -0,0 +1,9 @@
+package models
+
+// Participant is a team (or player, in individual sports) as the vendor identifies it
+// IDs are stable across seasons and renames, so consumers can join on them
+type Participant struct {
+	ID       string // Vendor participant ID (e.g. "par_01hqmkq6fceknv7cwebesgx5ja")
+	SportKey string
+	FullName string // Canonical team name, as stored on events
+}
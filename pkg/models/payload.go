@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// PayloadKind identifies which vendor endpoint produced a raw payload
+type PayloadKind string
+
+const (
+	PayloadKindOdds         PayloadKind = "odds"         // Featured odds for a sport
+	PayloadKindEventOdds    PayloadKind = "event-odds"   // Per-event odds (props)
+	PayloadKindEvents       PayloadKind = "events"       // Event discovery (no odds)
+	PayloadKindFutures      PayloadKind = "futures"      // Futures/outright odds
+	PayloadKindParticipants PayloadKind = "participants" // Team/participant rosters
+	PayloadKindScores       PayloadKind = "scores"       // Game state (scores, completed flag)
+)
+
+// RawPayload is an unparsed vendor response body, captured before parsing so it can be
+// archived and re-processed later
+type RawPayload struct {
+	Vendor     string      // Adapter name (e.g. "theoddsapi")
+	Kind       PayloadKind // Endpoint that produced the body
+	Sport      string      // Sport key the request was made for
+	EventID    string      // Set for event-odds payloads
+	OddsFormat OddsFormat  // Price format the request asked for
+	Request    string      // Request path and query, API key redacted
+	ReceivedAt time.Time   // When the response was received (UTC)
+	Body       []byte      // Response body exactly as received
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// PropsPoller is one event's props polling schedule: the event, where its poller is on
+// the ramp and when it polls next. The scheduler keeps one per active poller and
+// persists it so a restart resumes the schedule
+type PropsPoller struct {
+	EventID      string        `json:"event_id"`
+	SportKey     string        `json:"sport_key"`
+	HomeTeam     string        `json:"home_team"`
+	AwayTeam     string        `json:"away_team"`
+	CommenceTime time.Time     `json:"commence_time"`
+	StartedAt    time.Time     `json:"started_at"` // When discovery first scheduled the event
+	Polls        int           `json:"polls"`
+	LastPollAt   time.Time     `json:"last_poll_at"`            // Zero until the first poll
+	NextPollAt   time.Time     `json:"next_poll_at"`            // Zero while a poll runs
+	RampInterval time.Duration `json:"ramp_interval,omitempty"` // Current ramp tier's interval (before jitter and quota slowdown)
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// QuarantineReason classifies why a vendor record was rejected during parsing
+type QuarantineReason string
+
+const (
+	QuarantineMissingField     QuarantineReason = "missing_field"     // Required id/key/team/name is empty
+	QuarantineBadTimestamp     QuarantineReason = "bad_timestamp"     // commence_time or last_update unparseable
+	QuarantineBadPrice         QuarantineReason = "bad_price"         // Price invalid or outside sane bounds
+	QuarantineBadPoint         QuarantineReason = "bad_point"         // Line outside sane bounds
+	QuarantineDuplicateOutcome QuarantineReason = "duplicate_outcome" // Same outcome quoted twice by one book in one payload
+	QuarantineOneSided         QuarantineReason = "one_sided_market"  // Only one side of a two-way line arrived
+	QuarantineBadVig           QuarantineReason = "bad_vig"           // A line's implied probabilities sum outside sane bounds
+	QuarantineSportRule        QuarantineReason = "sport_rule"        // Failed the sport module's ValidateOdds
+)
+
+// QuarantinedRecord is a vendor record the parser (or the scheduler's sport validation)
+// refused to turn into odds or events
+// Records are kept for inspection instead of being dropped or patched with fallbacks
+type QuarantinedRecord struct {
+	Vendor     string           // Adapter name (e.g. "theoddsapi")
+	Kind       PayloadKind      // Endpoint that produced the record
+	SportKey   string           // Sport key from the record (may be empty)
+	EventID    string           // Event ID from the record (may be empty)
+	BookKey    string           // Set for bookmaker/market/outcome records
+	MarketKey  string           // Set for market/outcome records
+	Reason     QuarantineReason // Why the record was rejected
+	Detail     string           // Offending field and value, or the record as JSON
+	ReceivedAt time.Time        // When the payload was received (UTC)
+}
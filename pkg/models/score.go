@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Event statuses stored in events.event_status
+const (
+	EventStatusUpcoming  = "upcoming"
+	EventStatusLive      = "live"
+	EventStatusCompleted = "completed"
+	EventStatusCancelled = "cancelled"
+	EventStatusPostponed = "postponed"
+)
+
+// EventScore is the vendor's game state for one event
+type EventScore struct {
+	EventID      string
+	SportKey     string
+	HomeTeam     string
+	AwayTeam     string
+	CommenceTime time.Time
+	Completed    bool   // The vendor marked the game final
+	HasScores    bool   // The vendor reported a score, so the game has started
+	HomeScore    string // As sent (empty before the game starts)
+	AwayScore    string
+	LastUpdate   time.Time     // When the vendor last updated the score (zero if never)
+	Periods      []PeriodScore // Per-period scores, in order, when the vendor reports them
+}
+
+// PeriodScore is the score of one period (quarter, half, inning...) of a game
+type PeriodScore struct {
+	Period    string // Vendor label, e.g. "1", "2", "OT"
+	HomeScore string
+	AwayScore string
+}
+
+// Status returns the event status the vendor's signals imply at now
+func (s EventScore) Status(now time.Time) string {
+	return ResolveEventStatus(s.CommenceTime, s.Completed, s.HasScores, now)
+}
+
+// ResolveEventStatus picks an event's status from the vendor's signals: a completed
+// flag wins, then a reported score; only without either does the commence time decide
+func ResolveEventStatus(commenceTime time.Time, completed, started bool, now time.Time) string {
+	switch {
+	case completed:
+		return EventStatusCompleted
+	case started, now.After(commenceTime):
+		return EventStatusLive
+	}
+	return EventStatusUpcoming
+}
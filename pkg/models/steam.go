@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// SteamSettings tunes steam move detection for a sport
+type SteamSettings struct {
+	Window      time.Duration // Sliding window in which correlated moves count
+	MinBooks    int           // Distinct books that must move the same direction
+	MinProbMove float64       // Minimum implied-probability change for a price move to count (0.01 = 1pt)
+}
+
+// DefaultSteamSettings are used for sports that don't configure steam detection
+var DefaultSteamSettings = SteamSettings{
+	Window:      2 * time.Minute,
+	MinBooks:    3,
+	MinProbMove: 0.01,
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// StreamSchemaVersion is the StreamMessage schema producers publish. Bump it when a
+// field changes meaning or is removed; adding a field does not need a bump. Messages
+// published before versioning carry no version and are schema 1
+const StreamSchemaVersion = 1
+
+// StreamMessage is the payload of each odds.raw.<sport> Redis Stream entry (stored
+// under the "data" field), JSON by default or protobuf when the entry's "encoding"
+// field says so
+type StreamMessage struct {
+	SchemaVersion    int       `json:"schema_version"`
+	EventID          string    `json:"event_id"`
+	SportKey         string    `json:"sport_key"`
+	MarketKey        string    `json:"market_key"`
+	BookKey          string    `json:"book_key"`
+	OutcomeName      string    `json:"outcome_name"`
+	Side             string    `json:"side,omitempty"`        // home or away for team-sided outcomes
+	Description      string    `json:"description,omitempty"` // Player name for props
+	Price            int       `json:"price"`                 // American odds
+	PriceDecimal     float64   `json:"price_decimal"`         // Decimal odds
+	OddsFormat       string    `json:"odds_format"`           // Format the vendor quoted
+	Point            *float64  `json:"point,omitempty"`
+	Limit            *float64  `json:"limit,omitempty"`     // Max bet when the book exposes it
+	DeepLink         string    `json:"deep_link,omitempty"` // Vendor bet link when available
+	VendorLastUpdate time.Time `json:"vendor_last_update"`
+	ReceivedAt       time.Time `json:"received_at"`
+	EventStatus      string    `json:"event_status"`           // "upcoming" or "live"
+	TeamID           string    `json:"team_id,omitempty"`      // Participant ID of the outcome's team
+	HomeTeamID       string    `json:"home_team_id,omitempty"` // Participant IDs, when the event is known
+	AwayTeamID       string    `json:"away_team_id,omitempty"`
+	ChangeType       string    `json:"change_type,omitempty"`
+	DedupeKey        string    `json:"dedupe_key,omitempty"`        // Quote identity (RawOdds.DedupeKey), for idempotent consumers
+	PollID           string    `json:"poll_id,omitempty"`           // Poll that produced the update (poll_audit.poll_id)
+	OldPrice         *int      `json:"old_price,omitempty"`         // Previous price (absent for new outcomes)
+	OldPoint         *float64  `json:"old_point,omitempty"`         // Previous point
+	PriceDeltaCents  *int      `json:"price_delta_cents,omitempty"` // Price move in cents across even money (-105 -> +105 is 10)
+	PointDelta       *float64  `json:"point_delta,omitempty"`       // Point move, when both quotes have a point
+	ConsensusPrice   *int      `json:"consensus_price,omitempty"`   // Median price of the other books, with outlier detection on
+	Outlier          bool      `json:"outlier,omitempty"`           // Price strays from the consensus (possible stale or boosted line)
+}
+
+// StreamBatch is the payload of an aggregated stream entry (STREAM_AGGREGATE=true):
+// every outcome one poll changed for one event and book, in commit order. Such
+// entries carry "kind" = "batch" next to "data"
+type StreamBatch struct {
+	SchemaVersion int             `json:"schema_version"`
+	EventID       string          `json:"event_id"`
+	SportKey      string          `json:"sport_key"`
+	BookKey       string          `json:"book_key"`
+	PollID        string          `json:"poll_id,omitempty"`
+	Outcomes      []StreamMessage `json:"outcomes"`
+}
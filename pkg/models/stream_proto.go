@@ -0,0 +1,426 @@
+package models
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// StreamEncoding identifies how StreamMessage payloads are serialized on the streams
+type StreamEncoding string
+
+const (
+	StreamEncodingJSON     StreamEncoding = "json"     // Default; readable with redis-cli
+	StreamEncodingProtobuf StreamEncoding = "protobuf" // api/proto/mercury/v1/stream.proto
+)
+
+// ParseStreamEncoding validates a stream encoding name (case-insensitive)
+func ParseStreamEncoding(value string) (StreamEncoding, error) {
+	switch StreamEncoding(strings.ToLower(strings.TrimSpace(value))) {
+	case StreamEncodingJSON:
+		return StreamEncodingJSON, nil
+	case StreamEncodingProtobuf, "proto":
+		return StreamEncodingProtobuf, nil
+	default:
+		return "", fmt.Errorf("unknown stream encoding: %q", value)
+	}
+}
+
+// Field numbers of mercury.v1.StreamMessage (api/proto/mercury/v1/stream.proto)
+const (
+	protoEventID          = 1
+	protoSportKey         = 2
+	protoMarketKey        = 3
+	protoBookKey          = 4
+	protoOutcomeName      = 5
+	protoDescription      = 6
+	protoPrice            = 7
+	protoPriceDecimal     = 8
+	protoOddsFormat       = 9
+	protoPoint            = 10
+	protoLimit            = 11
+	protoDeepLink         = 12
+	protoVendorLastUpdate = 13
+	protoReceivedAt       = 14
+	protoEventStatus      = 15
+	protoTeamID           = 16
+	protoHomeTeamID       = 17
+	protoAwayTeamID       = 18
+	protoChangeType       = 19
+	protoSchemaVersion    = 20
+	protoDedupeKey        = 21
+	protoSide             = 22
+	protoPollID           = 23
+	protoOldPrice         = 24
+	protoOldPoint         = 25
+	protoPriceDeltaCents  = 26
+	protoPointDelta       = 27
+	protoConsensusPrice   = 28
+	protoOutlier          = 29
+)
+
+// Protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errProtoTruncated = errors.New("protobuf: truncated message")
+
+// MarshalProto encodes the message in protobuf wire format. Zero values are omitted
+// as proto3 does, except point and limit, which keep their presence
+func (m StreamMessage) MarshalProto() []byte {
+	b := make([]byte, 0, 256)
+	b = appendProtoString(b, protoEventID, m.EventID)
+	b = appendProtoString(b, protoSportKey, m.SportKey)
+	b = appendProtoString(b, protoMarketKey, m.MarketKey)
+	b = appendProtoString(b, protoBookKey, m.BookKey)
+	b = appendProtoString(b, protoOutcomeName, m.OutcomeName)
+	b = appendProtoString(b, protoDescription, m.Description)
+	if m.Price != 0 {
+		// sint32: American prices are often negative
+		b = appendProtoSint32(b, protoPrice, m.Price)
+	}
+	if m.PriceDecimal != 0 {
+		b = appendProtoDouble(b, protoPriceDecimal, m.PriceDecimal)
+	}
+	b = appendProtoString(b, protoOddsFormat, m.OddsFormat)
+	if m.Point != nil {
+		b = appendProtoDouble(b, protoPoint, *m.Point)
+	}
+	if m.Limit != nil {
+		b = appendProtoDouble(b, protoLimit, *m.Limit)
+	}
+	b = appendProtoString(b, protoDeepLink, m.DeepLink)
+	b = appendProtoTimestamp(b, protoVendorLastUpdate, m.VendorLastUpdate)
+	b = appendProtoTimestamp(b, protoReceivedAt, m.ReceivedAt)
+	b = appendProtoString(b, protoEventStatus, m.EventStatus)
+	b = appendProtoString(b, protoTeamID, m.TeamID)
+	b = appendProtoString(b, protoHomeTeamID, m.HomeTeamID)
+	b = appendProtoString(b, protoAwayTeamID, m.AwayTeamID)
+	b = appendProtoString(b, protoChangeType, m.ChangeType)
+	if m.SchemaVersion != 0 {
+		b = appendProtoTag(b, protoSchemaVersion, wireVarint)
+		b = binary.AppendUvarint(b, uint64(m.SchemaVersion))
+	}
+	b = appendProtoString(b, protoDedupeKey, m.DedupeKey)
+	b = appendProtoString(b, protoSide, m.Side)
+	b = appendProtoString(b, protoPollID, m.PollID)
+	if m.OldPrice != nil {
+		b = appendProtoSint32(b, protoOldPrice, *m.OldPrice)
+	}
+	if m.OldPoint != nil {
+		b = appendProtoDouble(b, protoOldPoint, *m.OldPoint)
+	}
+	if m.PriceDeltaCents != nil {
+		b = appendProtoSint32(b, protoPriceDeltaCents, *m.PriceDeltaCents)
+	}
+	if m.PointDelta != nil {
+		b = appendProtoDouble(b, protoPointDelta, *m.PointDelta)
+	}
+	if m.ConsensusPrice != nil {
+		b = appendProtoSint32(b, protoConsensusPrice, *m.ConsensusPrice)
+	}
+	if m.Outlier {
+		b = appendProtoTag(b, protoOutlier, wireVarint)
+		b = append(b, 1)
+	}
+	return b
+}
+
+// UnmarshalProto decodes a protobuf-encoded message. Unknown fields are skipped, so
+// consumers keep working when newer producers add fields
+func (m *StreamMessage) UnmarshalProto(data []byte) error {
+	*m = StreamMessage{}
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errProtoTruncated
+		}
+		data = data[n:]
+		field, wireType := int(tag>>3), int(tag&7)
+
+		switch wireType {
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return errProtoTruncated
+			}
+			data = data[n:]
+			switch field {
+			case protoPrice:
+				m.Price = decodeSint32(v)
+			case protoOldPrice:
+				price := decodeSint32(v)
+				m.OldPrice = &price
+			case protoPriceDeltaCents:
+				delta := decodeSint32(v)
+				m.PriceDeltaCents = &delta
+			case protoConsensusPrice:
+				price := decodeSint32(v)
+				m.ConsensusPrice = &price
+			case protoOutlier:
+				m.Outlier = v != 0
+			case protoSchemaVersion:
+				m.SchemaVersion = int(v)
+			}
+
+		case wireFixed64:
+			if len(data) < 8 {
+				return errProtoTruncated
+			}
+			v := math.Float64frombits(binary.LittleEndian.Uint64(data))
+			data = data[8:]
+			switch field {
+			case protoPriceDecimal:
+				m.PriceDecimal = v
+			case protoPoint:
+				m.Point = &v
+			case protoLimit:
+				m.Limit = &v
+			case protoOldPoint:
+				m.OldPoint = &v
+			case protoPointDelta:
+				m.PointDelta = &v
+			}
+
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errProtoTruncated
+			}
+			value := data[n : n+int(length)]
+			data = data[n+int(length):]
+			if err := m.setProtoBytes(field, value); err != nil {
+				return err
+			}
+
+		case wireFixed32:
+			if len(data) < 4 {
+				return errProtoTruncated
+			}
+			data = data[4:]
+
+		default:
+			return fmt.Errorf("protobuf: unsupported wire type %d (field %d)", wireType, field)
+		}
+	}
+	return nil
+}
+
+// setProtoBytes assigns a length-delimited field
+func (m *StreamMessage) setProtoBytes(field int, value []byte) error {
+	var err error
+	switch field {
+	case protoEventID:
+		m.EventID = string(value)
+	case protoSportKey:
+		m.SportKey = string(value)
+	case protoMarketKey:
+		m.MarketKey = string(value)
+	case protoBookKey:
+		m.BookKey = string(value)
+	case protoOutcomeName:
+		m.OutcomeName = string(value)
+	case protoDescription:
+		m.Description = string(value)
+	case protoOddsFormat:
+		m.OddsFormat = string(value)
+	case protoDeepLink:
+		m.DeepLink = string(value)
+	case protoVendorLastUpdate:
+		m.VendorLastUpdate, err = parseProtoTimestamp(value)
+	case protoReceivedAt:
+		m.ReceivedAt, err = parseProtoTimestamp(value)
+	case protoEventStatus:
+		m.EventStatus = string(value)
+	case protoTeamID:
+		m.TeamID = string(value)
+	case protoHomeTeamID:
+		m.HomeTeamID = string(value)
+	case protoAwayTeamID:
+		m.AwayTeamID = string(value)
+	case protoChangeType:
+		m.ChangeType = string(value)
+	case protoDedupeKey:
+		m.DedupeKey = string(value)
+	case protoSide:
+		m.Side = string(value)
+	case protoPollID:
+		m.PollID = string(value)
+	}
+	return err
+}
+
+// Field numbers of mercury.v1.StreamBatch
+const (
+	protoBatchEventID       = 1
+	protoBatchSportKey      = 2
+	protoBatchBookKey       = 3
+	protoBatchPollID        = 4
+	protoBatchSchemaVersion = 5
+	protoBatchOutcomes      = 6
+)
+
+// MarshalProto encodes the batch in protobuf wire format, each outcome as an embedded
+// StreamMessage
+func (b StreamBatch) MarshalProto() []byte {
+	out := make([]byte, 0, 64+256*len(b.Outcomes))
+	out = appendProtoString(out, protoBatchEventID, b.EventID)
+	out = appendProtoString(out, protoBatchSportKey, b.SportKey)
+	out = appendProtoString(out, protoBatchBookKey, b.BookKey)
+	out = appendProtoString(out, protoBatchPollID, b.PollID)
+	if b.SchemaVersion != 0 {
+		out = appendProtoTag(out, protoBatchSchemaVersion, wireVarint)
+		out = binary.AppendUvarint(out, uint64(b.SchemaVersion))
+	}
+	for _, msg := range b.Outcomes {
+		data := msg.MarshalProto()
+		out = appendProtoTag(out, protoBatchOutcomes, wireBytes)
+		out = binary.AppendUvarint(out, uint64(len(data)))
+		out = append(out, data...)
+	}
+	return out
+}
+
+// UnmarshalProto decodes a protobuf-encoded batch. Unknown fields are skipped
+func (b *StreamBatch) UnmarshalProto(data []byte) error {
+	*b = StreamBatch{}
+
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errProtoTruncated
+		}
+		data = data[n:]
+		field, wireType := int(tag>>3), int(tag&7)
+
+		switch wireType {
+		case wireVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return errProtoTruncated
+			}
+			data = data[n:]
+			if field == protoBatchSchemaVersion {
+				b.SchemaVersion = int(v)
+			}
+
+		case wireFixed64:
+			if len(data) < 8 {
+				return errProtoTruncated
+			}
+			data = data[8:]
+
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return errProtoTruncated
+			}
+			value := data[n : n+int(length)]
+			data = data[n+int(length):]
+			switch field {
+			case protoBatchEventID:
+				b.EventID = string(value)
+			case protoBatchSportKey:
+				b.SportKey = string(value)
+			case protoBatchBookKey:
+				b.BookKey = string(value)
+			case protoBatchPollID:
+				b.PollID = string(value)
+			case protoBatchOutcomes:
+				var msg StreamMessage
+				if err := msg.UnmarshalProto(value); err != nil {
+					return err
+				}
+				b.Outcomes = append(b.Outcomes, msg)
+			}
+
+		case wireFixed32:
+			if len(data) < 4 {
+				return errProtoTruncated
+			}
+			data = data[4:]
+
+		default:
+			return fmt.Errorf("protobuf: unsupported wire type %d (field %d)", wireType, field)
+		}
+	}
+	return nil
+}
+
+func appendProtoTag(b []byte, field, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field<<3|wireType))
+}
+
+// appendProtoSint32 encodes a zigzag sint32 (American prices are often negative)
+func appendProtoSint32(b []byte, field, value int) []byte {
+	b = appendProtoTag(b, field, wireVarint)
+	return binary.AppendUvarint(b, uint64(uint32(int32(value)<<1)^uint32(int32(value)>>31)))
+}
+
+// decodeSint32 reverses appendProtoSint32's zigzag encoding
+func decodeSint32(v uint64) int {
+	return int(int32(uint32(v)>>1) ^ -int32(uint32(v)&1))
+}
+
+func appendProtoString(b []byte, field int, value string) []byte {
+	if value == "" {
+		return b
+	}
+	b = appendProtoTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+func appendProtoDouble(b []byte, field int, value float64) []byte {
+	b = appendProtoTag(b, field, wireFixed64)
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(value))
+}
+
+// appendProtoTimestamp encodes a google.protobuf.Timestamp (seconds = 1, nanos = 2)
+func appendProtoTimestamp(b []byte, field int, t time.Time) []byte {
+	if t.IsZero() {
+		return b
+	}
+	var ts []byte
+	if seconds := t.Unix(); seconds != 0 {
+		ts = binary.AppendUvarint(append(ts, 1<<3|wireVarint), uint64(seconds))
+	}
+	if nanos := t.Nanosecond(); nanos != 0 {
+		ts = binary.AppendUvarint(append(ts, 2<<3|wireVarint), uint64(nanos))
+	}
+	b = appendProtoTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(ts)))
+	return append(b, ts...)
+}
+
+// parseProtoTimestamp decodes a google.protobuf.Timestamp as a UTC time
+func parseProtoTimestamp(data []byte) (time.Time, error) {
+	var seconds, nanos int64
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 || tag&7 != wireVarint {
+			return time.Time{}, errProtoTruncated
+		}
+		data = data[n:]
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return time.Time{}, errProtoTruncated
+		}
+		data = data[n:]
+		switch tag >> 3 {
+		case 1:
+			seconds = int64(v)
+		case 2:
+			nanos = int64(v)
+		}
+	}
+	return time.Unix(seconds, nanos).UTC(), nil
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// VendorUsage is the quota cost of one vendor request, read from the response headers
+// (x-requests-last/used/remaining on The Odds API)
+type VendorUsage struct {
+	Vendor      string      // Adapter name (e.g. "theoddsapi")
+	Kind        PayloadKind // Endpoint that was called
+	SportKey    string      // Sport key the request was made for
+	EventID     string      // Set for event-odds requests
+	Markets     []string    // Markets requested (cost scales with markets x regions)
+	StatusCode  int         // HTTP status of the response
+	Cost        int         // Credits this request used (-1 when the vendor did not say)
+	Used        int         // Credits used in the billing period after this request (-1 = unknown)
+	Remaining   int         // Credits remaining after this request (-1 = unknown)
+	RequestedAt time.Time   // When the response arrived (UTC)
+}
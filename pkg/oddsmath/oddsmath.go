@@ -0,0 +1,173 @@
+// Package oddsmath converts between American, decimal and implied-probability odds and
+// implements the vig, expected-value, Kelly and arbitrage math used by Mercury's edge
+// and arbitrage engines. It has no dependencies so downstream consumers can import it.
+//
+// Invalid inputs (American prices between -100 and +100, decimal prices <= 1,
+// probabilities outside (0, 1)) produce zero values rather than errors.
+package oddsmath
+
+import "math"
+
+// AmericanToDecimal converts American odds to decimal odds
+func AmericanToDecimal(american int) float64 {
+	switch {
+	case american >= 100:
+		return 1 + float64(american)/100
+	case american <= -100:
+		return 1 + 100/float64(-american)
+	default:
+		return 0
+	}
+}
+
+// DecimalToAmerican converts decimal odds to American odds, rounded to the nearest integer
+// Even money (2.0) is +100
+func DecimalToAmerican(decimal float64) int {
+	switch {
+	case decimal >= 2:
+		return int(math.Round((decimal - 1) * 100))
+	case decimal > 1:
+		return int(math.Round(-100 / (decimal - 1)))
+	default:
+		return 0
+	}
+}
+
+// PriceDeltaCents returns the signed move between two American prices in cents,
+// counting across even money: -110 -> -105 is 5, +105 -> -105 is -10
+func PriceDeltaCents(from, to int) int {
+	return centsLine(to) - centsLine(from)
+}
+
+// centsLine maps American prices onto a continuous scale with even money at 0
+func centsLine(price int) int {
+	switch {
+	case price >= 100:
+		return price - 100
+	case price <= -100:
+		return price + 100
+	default:
+		return price
+	}
+}
+
+// DecimalToImplied converts decimal odds to the implied win probability (vig included)
+func DecimalToImplied(decimal float64) float64 {
+	if decimal <= 1 {
+		return 0
+	}
+	return 1 / decimal
+}
+
+// AmericanToImplied converts American odds to the implied win probability (vig included)
+func AmericanToImplied(american int) float64 {
+	return DecimalToImplied(AmericanToDecimal(american))
+}
+
+// ImpliedToDecimal converts a win probability to its fair decimal price
+func ImpliedToDecimal(prob float64) float64 {
+	if prob <= 0 || prob >= 1 {
+		return 0
+	}
+	return 1 / prob
+}
+
+// ImpliedToAmerican converts a win probability to its fair American price
+func ImpliedToAmerican(prob float64) int {
+	return DecimalToAmerican(ImpliedToDecimal(prob))
+}
+
+// Overround returns the summed implied probability of a full market (1.0476 for -110/-110)
+// Returns 0 if any price is invalid
+func Overround(decimalPrices []float64) float64 {
+	total := 0.0
+	for _, price := range decimalPrices {
+		if price <= 1 {
+			return 0
+		}
+		total += 1 / price
+	}
+	return total
+}
+
+// Hold returns the bookmaker's theoretical margin on a full market as a fraction of
+// handle (0.0455 for -110/-110), assuming stakes balanced to the implied probabilities
+func Hold(decimalPrices []float64) float64 {
+	overround := Overround(decimalPrices)
+	if overround == 0 {
+		return 0
+	}
+	return 1 - 1/overround
+}
+
+// NoVig removes the bookmaker margin from a full market (all outcomes of one line)
+// using multiplicative normalization. Returns fair probabilities in the same order,
+// or nil if any price is invalid or the market is incomplete (< 2 outcomes)
+func NoVig(decimalPrices []float64) []float64 {
+	if len(decimalPrices) < 2 {
+		return nil
+	}
+
+	overround := Overround(decimalPrices)
+	if overround == 0 {
+		return nil
+	}
+
+	fair := make([]float64, len(decimalPrices))
+	for i, price := range decimalPrices {
+		fair[i] = 1 / price / overround
+	}
+	return fair
+}
+
+// ExpectedValue returns the expected return per unit staked at decimalPrice when the
+// true win probability is fairProb (e.g. 0.03 = +3% EV)
+func ExpectedValue(fairProb, decimalPrice float64) float64 {
+	return fairProb*decimalPrice - 1
+}
+
+// Kelly returns the Kelly-optimal fraction of bankroll to stake at decimalPrice when
+// the true win probability is fairProb. Returns 0 when the bet has no edge
+func Kelly(fairProb, decimalPrice float64) float64 {
+	if decimalPrice <= 1 || fairProb <= 0 || fairProb >= 1 {
+		return 0
+	}
+
+	fraction := (fairProb*decimalPrice - 1) / (decimalPrice - 1)
+	if fraction <= 0 {
+		return 0
+	}
+	return fraction
+}
+
+// FractionalKelly scales the Kelly stake by multiplier (0.25 = quarter Kelly) to
+// reduce variance from errors in the fair probability
+func FractionalKelly(fairProb, decimalPrice, multiplier float64) float64 {
+	return Kelly(fairProb, decimalPrice) * multiplier
+}
+
+// ArbitrageProfit returns the guaranteed return on total stake when backing every
+// outcome at the given prices (0.02 = +2%). Negative means no arbitrage; returns
+// -1 if any price is invalid
+func ArbitrageProfit(decimalPrices []float64) float64 {
+	overround := Overround(decimalPrices)
+	if overround == 0 {
+		return -1
+	}
+	return 1/overround - 1
+}
+
+// StakeSplit returns the fraction of total stake to place on each price so every
+// outcome returns the same amount (fractions sum to 1). Returns nil if any price is invalid
+func StakeSplit(decimalPrices []float64) []float64 {
+	overround := Overround(decimalPrices)
+	if overround == 0 {
+		return nil
+	}
+
+	split := make([]float64, len(decimalPrices))
+	for i, price := range decimalPrices {
+		split[i] = 1 / price / overround
+	}
+	return split
+}
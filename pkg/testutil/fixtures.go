@@ -34,13 +34,15 @@ func NewTestOdd(eventID, marketKey, bookKey, outcomeName string, price int, poin
 	}
 }
 
-// GoldenFixtures returns a set of known odds for testing normalization
+// GoldenFixture is a set of known odds with expected pkg/oddsmath outputs
+// Values refer to each book's first quoted outcome; the reference line is
+// pinnacle's when quoted, otherwise the first book's
 type GoldenFixture struct {
 	Name             string
 	Odds             []models.RawOdds
 	ExpectedNoVig    map[string]float64 // bookKey -> expected no-vig probability
-	ExpectedFairOdds int                // Expected fair American odds
-	ExpectedEdge     map[string]float64 // bookKey -> expected edge %
+	ExpectedFairOdds int                // Expected fair American odds of the reference line
+	ExpectedEdge     map[string]float64 // bookKey -> expected value % against the reference line
 }
 
 // GetGoldenFixtures returns test fixtures with expected outputs
@@ -55,9 +57,9 @@ func GetGoldenFixtures() []GoldenFixture {
 			ExpectedNoVig: map[string]float64{
 				"fanduel": 0.50, // After removing vig
 			},
-			ExpectedFairOdds: -100, // True even money
+			ExpectedFairOdds: 100, // True even money
 			ExpectedEdge: map[string]float64{
-				"fanduel": -4.76, // Negative edge due to vig
+				"fanduel": -4.55, // Negative edge due to vig
 			},
 		},
 		{
@@ -67,9 +69,9 @@ func GetGoldenFixtures() []GoldenFixture {
 				NewTestOdd("game2", "spreads", "draftkings", "Celtics +7.5", -115, ptrFloat64(7.5)),
 			},
 			ExpectedNoVig: map[string]float64{
-				"draftkings": 0.523, // Approximation
+				"draftkings": 0.4892, // -105 side after removing vig
 			},
-			ExpectedFairOdds: -110,
+			ExpectedFairOdds: 104,
 			ExpectedEdge:     map[string]float64{},
 		},
 		{
@@ -81,7 +83,7 @@ func GetGoldenFixtures() []GoldenFixture {
 			ExpectedNoVig: map[string]float64{
 				"betmgm": 0.50,
 			},
-			ExpectedFairOdds: -100,
+			ExpectedFairOdds: 100,
 			ExpectedEdge:     map[string]float64{},
 		},
 		{
@@ -94,9 +96,12 @@ func GetGoldenFixtures() []GoldenFixture {
 				NewTestOdd("game4", "h2h", "fanduel", "Lakers", -115, nil),
 				NewTestOdd("game4", "h2h", "fanduel", "Celtics", -105, nil),
 			},
-			ExpectedFairOdds: -105, // Pinnacle's line is fair
+			ExpectedNoVig: map[string]float64{
+				"pinnacle": 0.50,
+			},
+			ExpectedFairOdds: 100, // Pinnacle's no-vig line is fair
 			ExpectedEdge: map[string]float64{
-				"fanduel": -4.35, // Lakers side has negative edge vs Pinnacle
+				"fanduel": -6.52, // Lakers side has negative edge vs Pinnacle
 			},
 		},
 	}
@@ -106,51 +111,3 @@ func GetGoldenFixtures() []GoldenFixture {
 func ptrFloat64(val float64) *float64 {
 	return &val
 }
-
-// MockVendorAdapter is a test adapter that returns predetermined odds
-type MockVendorAdapter struct {
-	FetchOddsFunc       func() ([]models.RawOdds, error)
-	FetchEventOddsFunc  func() ([]models.RawOdds, error)
-	FetchEventsFunc     func() ([]models.Event, error)
-	SupportsMarketFunc  func(market string) bool
-	GetRateLimitsFunc   func() *models.RateLimits
-}
-
-func (m *MockVendorAdapter) FetchOdds(ctx interface{}, opts interface{}) ([]models.RawOdds, error) {
-	if m.FetchOddsFunc != nil {
-		return m.FetchOddsFunc()
-	}
-	return []models.RawOdds{}, nil
-}
-
-func (m *MockVendorAdapter) FetchEventOdds(ctx interface{}, opts interface{}) ([]models.RawOdds, error) {
-	if m.FetchEventOddsFunc != nil {
-		return m.FetchEventOddsFunc()
-	}
-	return []models.RawOdds{}, nil
-}
-
-func (m *MockVendorAdapter) FetchEvents(ctx interface{}, sport string) ([]models.Event, error) {
-	if m.FetchEventsFunc != nil {
-		return m.FetchEventsFunc()
-	}
-	return []models.Event{}, nil
-}
-
-func (m *MockVendorAdapter) SupportsMarket(market string) bool {
-	if m.SupportsMarketFunc != nil {
-		return m.SupportsMarketFunc(market)
-	}
-	return true
-}
-
-func (m *MockVendorAdapter) GetRateLimits() *models.RateLimits {
-	if m.GetRateLimitsFunc != nil {
-		return m.GetRateLimitsFunc()
-	}
-	return &models.RateLimits{
-		RequestsRemaining: 500,
-		RequestsUsed:      0,
-	}
-}
-
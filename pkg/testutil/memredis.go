@@ -0,0 +1,289 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MemRedis answers the list, sorted set and hash commands the Talos warm queue uses
+// from memory, so its tests (and those of its callers) run without a Redis server.
+// Any other command fails
+type MemRedis struct {
+	mu     sync.Mutex
+	lists  map[string][]string
+	zsets  map[string]map[string]float64
+	hashes map[string]map[string]string
+}
+
+// NewMemRedis creates an empty in-memory Redis
+func NewMemRedis() *MemRedis {
+	return &MemRedis{
+		lists:  make(map[string][]string),
+		zsets:  make(map[string]map[string]float64),
+		hashes: make(map[string]map[string]string),
+	}
+}
+
+// Client returns a client whose commands are answered by m (the caller closes it)
+func (m *MemRedis) Client() *redis.Client {
+	c := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	c.AddHook(m)
+	return c
+}
+
+// List returns a copy of a list
+func (m *MemRedis) List(key string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.lists[key]...)
+}
+
+// SetList replaces a list
+func (m *MemRedis) SetList(key string, values ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lists[key] = append([]string(nil), values...)
+}
+
+// ZSet returns a copy of a sorted set as member -> score
+func (m *MemRedis) ZSet(key string) map[string]float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]float64, len(m.zsets[key]))
+	for member, score := range m.zsets[key] {
+		out[member] = score
+	}
+	return out
+}
+
+// SetZSet replaces a sorted set
+func (m *MemRedis) SetZSet(key string, scores map[string]float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.zsets[key] = make(map[string]float64, len(scores))
+	for member, score := range scores {
+		m.zsets[key][member] = score
+	}
+}
+
+// Hash returns a copy of a hash
+func (m *MemRedis) Hash(key string) map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]string, len(m.hashes[key]))
+	for field, value := range m.hashes[key] {
+		out[field] = value
+	}
+	return out
+}
+
+// SetHash sets one hash field
+func (m *MemRedis) SetHash(key, field, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.hashes[key] == nil {
+		m.hashes[key] = make(map[string]string)
+	}
+	m.hashes[key][field] = value
+}
+
+// DialHook implements redis.Hook: nothing is ever dialled
+func (m *MemRedis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no network in tests")
+	}
+}
+
+// ProcessHook implements redis.Hook
+func (m *MemRedis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		return m.process(cmd)
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook, running the commands in order
+func (m *MemRedis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := m.process(cmd); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (m *MemRedis) process(cmd redis.Cmder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	args := cmd.Args()
+	key := ""
+	if len(args) > 1 {
+		key = argString(args[1])
+	}
+
+	switch cmd.Name() {
+	case "rpush", "lpush":
+		for _, v := range args[2:] {
+			if cmd.Name() == "rpush" {
+				m.lists[key] = append(m.lists[key], argString(v))
+			} else {
+				m.lists[key] = append([]string{argString(v)}, m.lists[key]...)
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(int64(len(m.lists[key])))
+	case "lmove":
+		src, dst := m.lists[key], argString(args[2])
+		if len(src) == 0 {
+			cmd.SetErr(redis.Nil)
+			return redis.Nil
+		}
+		var v string
+		if argString(args[3]) == "LEFT" {
+			v, m.lists[key] = src[0], src[1:]
+		} else {
+			v, m.lists[key] = src[len(src)-1], src[:len(src)-1]
+		}
+		if argString(args[4]) == "LEFT" {
+			m.lists[dst] = append([]string{v}, m.lists[dst]...)
+		} else {
+			m.lists[dst] = append(m.lists[dst], v)
+		}
+		cmd.(*redis.StringCmd).SetVal(v)
+	case "lrem":
+		// Only the count = 1 form the warm queue sends
+		value, removed := argString(args[3]), int64(0)
+		kept := make([]string, 0, len(m.lists[key]))
+		for _, v := range m.lists[key] {
+			if v == value && removed == 0 {
+				removed++
+				continue
+			}
+			kept = append(kept, v)
+		}
+		m.lists[key] = kept
+		cmd.(*redis.IntCmd).SetVal(removed)
+	case "llen":
+		cmd.(*redis.IntCmd).SetVal(int64(len(m.lists[key])))
+	case "ltrim":
+		// Only the 0..stop form the warm queue sends
+		stop, _ := strconv.Atoi(argString(args[3]))
+		if stop+1 < len(m.lists[key]) {
+			m.lists[key] = m.lists[key][:stop+1]
+		}
+		cmd.(*redis.StatusCmd).SetVal("OK")
+	case "zadd":
+		if m.zsets[key] == nil {
+			m.zsets[key] = make(map[string]float64)
+		}
+		var added int64
+		for i := 2; i+1 < len(args); i += 2 {
+			score, _ := strconv.ParseFloat(argString(args[i]), 64)
+			member := argString(args[i+1])
+			if _, ok := m.zsets[key][member]; !ok {
+				added++
+			}
+			m.zsets[key][member] = score
+		}
+		cmd.(*redis.IntCmd).SetVal(added)
+	case "zrem":
+		var removed int64
+		for _, v := range args[2:] {
+			if _, ok := m.zsets[key][argString(v)]; ok {
+				delete(m.zsets[key], argString(v))
+				removed++
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(removed)
+	case "zcard":
+		cmd.(*redis.IntCmd).SetVal(int64(len(m.zsets[key])))
+	case "zrange", "zrangebyscore":
+		// zrange is only sent for the whole set (0 -1)
+		min, minEx, max, maxEx := -1e308, false, 1e308, false
+		if cmd.Name() == "zrangebyscore" {
+			min, minEx = parseScoreBound(argString(args[2]))
+			max, maxEx = parseScoreBound(argString(args[3]))
+		}
+		var members []string
+		for member, score := range m.zsets[key] {
+			if score < min || (minEx && score == min) || score > max || (maxEx && score == max) {
+				continue
+			}
+			members = append(members, member)
+		}
+		sort.Slice(members, func(i, j int) bool {
+			return m.zsets[key][members[i]] < m.zsets[key][members[j]]
+		})
+		cmd.(*redis.StringSliceCmd).SetVal(members)
+	case "hset":
+		if m.hashes[key] == nil {
+			m.hashes[key] = make(map[string]string)
+		}
+		var added int64
+		for i := 2; i+1 < len(args); i += 2 {
+			field := argString(args[i])
+			if _, ok := m.hashes[key][field]; !ok {
+				added++
+			}
+			m.hashes[key][field] = argString(args[i+1])
+		}
+		cmd.(*redis.IntCmd).SetVal(added)
+	case "hdel":
+		var removed int64
+		for _, v := range args[2:] {
+			if _, ok := m.hashes[key][argString(v)]; ok {
+				delete(m.hashes[key], argString(v))
+				removed++
+			}
+		}
+		cmd.(*redis.IntCmd).SetVal(removed)
+	case "hlen":
+		cmd.(*redis.IntCmd).SetVal(int64(len(m.hashes[key])))
+	case "hgetall":
+		values := make(map[string]string, len(m.hashes[key]))
+		for field, value := range m.hashes[key] {
+			values[field] = value
+		}
+		cmd.(*redis.MapStringStringCmd).SetVal(values)
+	default:
+		err := errors.New("testutil: MemRedis does not support " + cmd.Name())
+		cmd.SetErr(err)
+		return err
+	}
+	return nil
+}
+
+// argString returns a command argument as sent
+func argString(arg interface{}) string {
+	switch v := arg.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// parseScoreBound parses a ZRANGEBYSCORE bound: a score, "(score" (exclusive) or ±inf
+func parseScoreBound(s string) (float64, bool) {
+	exclusive := strings.HasPrefix(s, "(")
+	s = strings.TrimPrefix(s, "(")
+	switch s {
+	case "-inf":
+		return -1e308, exclusive
+	case "+inf", "inf":
+		return 1e308, exclusive
+	}
+	f, _ := strconv.ParseFloat(s, 64)
+	return f, exclusive
+}
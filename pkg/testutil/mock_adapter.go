@@ -0,0 +1,178 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+var _ contracts.VendorAdapter = (*MockVendorAdapter)(nil)
+
+// Mock method names, as recorded in MockCall.Method
+const (
+	MockFetchOdds      = "FetchOdds"
+	MockFetchEventOdds = "FetchEventOdds"
+	MockFetchEvents    = "FetchEvents"
+)
+
+// MockVendorAdapter is a scriptable contracts.VendorAdapter that records every fetch.
+// A fetch is answered by its Func when set, otherwise by the next queued response,
+// otherwise with an empty result. Safe for concurrent use
+type MockVendorAdapter struct {
+	FetchOddsFunc      func(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error)
+	FetchEventOddsFunc func(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error)
+	FetchEventsFunc    func(ctx context.Context, opts *models.FetchEventsOptions) ([]models.Event, error)
+	SupportsMarketFunc func(market string) bool
+	GetRateLimitsFunc  func() *models.RateLimits
+
+	mu        sync.Mutex
+	calls     []MockCall
+	odds      []MockResult
+	eventOdds []MockResult
+	events    []MockEvents
+}
+
+// MockCall is one recorded fetch; only the options of its method are set
+type MockCall struct {
+	Method    string
+	Odds      *models.FetchOddsOptions
+	EventOdds *models.FetchEventOddsOptions
+	Events    *models.FetchEventsOptions
+}
+
+// MockResult is a queued odds response
+type MockResult struct {
+	Result *models.FetchResult
+	Err    error
+}
+
+// MockEvents is a queued events response
+type MockEvents struct {
+	Events []models.Event
+	Err    error
+}
+
+// QueueOdds queues a FetchOdds response
+func (m *MockVendorAdapter) QueueOdds(result *models.FetchResult, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.odds = append(m.odds, MockResult{Result: result, Err: err})
+}
+
+// QueueEventOdds queues a FetchEventOdds response
+func (m *MockVendorAdapter) QueueEventOdds(result *models.FetchResult, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.eventOdds = append(m.eventOdds, MockResult{Result: result, Err: err})
+}
+
+// QueueEvents queues a FetchEvents response
+func (m *MockVendorAdapter) QueueEvents(events []models.Event, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, MockEvents{Events: events, Err: err})
+}
+
+// Calls returns every fetch made so far, in order
+func (m *MockVendorAdapter) Calls() []MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallCount returns how many times method was called
+func (m *MockVendorAdapter) CallCount(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for _, call := range m.calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// record appends call and pops the next queued response of the method, if any
+func (m *MockVendorAdapter) record(call MockCall) (MockResult, MockEvents, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, call)
+	switch {
+	case call.Method == MockFetchOdds && len(m.odds) > 0:
+		next := m.odds[0]
+		m.odds = m.odds[1:]
+		return next, MockEvents{}, true
+	case call.Method == MockFetchEventOdds && len(m.eventOdds) > 0:
+		next := m.eventOdds[0]
+		m.eventOdds = m.eventOdds[1:]
+		return next, MockEvents{}, true
+	case call.Method == MockFetchEvents && len(m.events) > 0:
+		next := m.events[0]
+		m.events = m.events[1:]
+		return MockResult{}, next, true
+	}
+	return MockResult{}, MockEvents{}, false
+}
+
+// FetchOdds implements contracts.VendorAdapter
+func (m *MockVendorAdapter) FetchOdds(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+	copied := *opts
+	queued, _, ok := m.record(MockCall{Method: MockFetchOdds, Odds: &copied})
+	if m.FetchOddsFunc != nil {
+		return m.FetchOddsFunc(ctx, opts)
+	}
+	if ok {
+		return queued.Result, queued.Err
+	}
+	return &models.FetchResult{}, nil
+}
+
+// FetchEventOdds implements contracts.VendorAdapter
+func (m *MockVendorAdapter) FetchEventOdds(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error) {
+	copied := *opts
+	queued, _, ok := m.record(MockCall{Method: MockFetchEventOdds, EventOdds: &copied})
+	if m.FetchEventOddsFunc != nil {
+		return m.FetchEventOddsFunc(ctx, opts)
+	}
+	if ok {
+		return queued.Result, queued.Err
+	}
+	return &models.FetchResult{}, nil
+}
+
+// FetchEvents implements contracts.VendorAdapter
+func (m *MockVendorAdapter) FetchEvents(ctx context.Context, opts *models.FetchEventsOptions) ([]models.Event, error) {
+	copied := *opts
+	_, queued, ok := m.record(MockCall{Method: MockFetchEvents, Events: &copied})
+	if m.FetchEventsFunc != nil {
+		return m.FetchEventsFunc(ctx, opts)
+	}
+	if ok {
+		return queued.Events, queued.Err
+	}
+	return []models.Event{}, nil
+}
+
+// SupportsMarket implements contracts.VendorAdapter (every market by default)
+func (m *MockVendorAdapter) SupportsMarket(market string) bool {
+	if m.SupportsMarketFunc != nil {
+		return m.SupportsMarketFunc(market)
+	}
+	return true
+}
+
+// GetRateLimits implements contracts.VendorAdapter
+func (m *MockVendorAdapter) GetRateLimits() *models.RateLimits {
+	if m.GetRateLimitsFunc != nil {
+		return m.GetRateLimitsFunc()
+	}
+	return &models.RateLimits{
+		RequestsRemaining: 500,
+		RequestsUsed:      0,
+	}
+}
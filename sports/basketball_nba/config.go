@@ -2,6 +2,8 @@ package basketball_nba
 
 import (
 	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
 )
 
 // Config contains NBA-specific polling configuration (Plan A from Phase 3)
@@ -13,11 +15,52 @@ type Config struct {
 	// Regions to poll
 	Regions []string
 
+	// Bookmakers to request instead of Regions (empty = poll every book in Regions)
+	// The vendor bills each group of 10 books like one region, so a list of the books
+	// Mercury keeps costs less than downloading whole regions and filtering
+	Bookmakers []string
+
+	// How long after tipoff a game is considered completed
+	GameDuration time.Duration
+
 	// Featured markets configuration (h2h, spreads, totals)
 	Featured FeaturedConfig
 
 	// Props markets configuration
 	Props PropsConfig
+
+	// Quota degradation configuration
+	Quota QuotaConfig
+
+	// Futures/outrights configuration
+	Futures FuturesConfig
+
+	// Steam move detection sensitivity
+	Steam models.SteamSettings
+
+	// Vendor/book spellings mapped to canonical team names
+	TeamAliases map[string]string
+}
+
+// FuturesConfig defines the low-frequency futures/outrights polling track
+type FuturesConfig struct {
+	// Vendor outright keys (empty disables futures polling)
+	Keys []string
+
+	// Polling interval (futures move slowly; each key costs one request per region)
+	PollInterval time.Duration
+}
+
+// QuotaConfig defines how NBA polling degrades when vendor quota runs low
+type QuotaConfig struct {
+	// Priority relative to other sports (lower = protected longer)
+	Priority int
+
+	// Slowest featured interval allowed under quota pressure
+	MinFeaturedCadence time.Duration
+
+	// Slowest props interval allowed under quota pressure (0 = props may be paused)
+	MinPropsCadence time.Duration
 }
 
 // FeaturedConfig defines polling for mainline markets
@@ -36,6 +79,15 @@ type FeaturedConfig struct {
 
 	// In-play polling interval
 	InPlayInterval time.Duration
+
+	// Only poll events starting within this many hours (0 = every listed event)
+	// Sports with long published schedules use it to shrink payloads
+	WindowHours int
+
+	// Events starting within TipoffWindow are also fetched by ID every TipoffInterval,
+	// so closing moves are caught without polling the whole slate that often
+	TipoffWindow   time.Duration
+	TipoffInterval time.Duration
 }
 
 // PropsConfig defines polling for player props
@@ -77,12 +129,24 @@ func DefaultConfig() *Config {
 		DisplayName: "NBA Basketball",
 		Regions:     []string{"us", "us2", "eu"}, // Added EU for Pinnacle
 
+		// 10 books = one region's cost per market, instead of three regions
+		Bookmakers: []string{
+			"pinnacle", "fanduel", "draftkings", "betmgm", "williamhill_us",
+			"espnbet", "fanatics", "betrivers", "hardrockbet", "bovada",
+		},
+
+		// NBA games typically last 2-2.5 hours, so 3 hours is a safe buffer
+		GameDuration: 3 * time.Hour,
+
 		Featured: FeaturedConfig{
 			PollInterval:       60 * time.Second, // Default pre-match interval
 			PreMatchInterval:   60 * time.Second,
 			RampWithinHours:    6.0,
 			RampTargetInterval: 40 * time.Second,
 			InPlayInterval:     40 * time.Second,
+			WindowHours:        0, // The vendor lists about two weeks of NBA games; poll them all
+			TipoffWindow:       20 * time.Minute,
+			TipoffInterval:     15 * time.Second,
 		},
 
 		Props: PropsConfig{
@@ -103,6 +167,32 @@ func DefaultConfig() *Config {
 			JitterSeconds:         5,
 			PostGameFinalSnapshot: true,
 		},
+
+		// NBA featured is the most protected market; props are paused before featured slows
+		Quota: QuotaConfig{
+			Priority:           1,
+			MinFeaturedCadence: 5 * time.Minute,
+			MinPropsCadence:    0,
+		},
+
+		Futures: FuturesConfig{
+			Keys:         []string{"basketball_nba_championship_winner"},
+			PollInterval: 6 * time.Hour,
+		},
+		Steam: models.SteamSettings{
+			Window:      90 * time.Second, // NBA lines move fast near tip-off
+			MinBooks:    3,
+			MinProbMove: 0.01,
+		},
+		TeamAliases: map[string]string{
+			"LA Lakers":   "Los Angeles Lakers",
+			"LA Clippers": "Los Angeles Clippers",
+			"NY Knicks":   "New York Knicks",
+			"GS Warriors": "Golden State Warriors",
+			"SA Spurs":    "San Antonio Spurs",
+			"OKC Thunder": "Oklahoma City Thunder",
+			"NO Pelicans": "New Orleans Pelicans",
+		},
 	}
 }
 
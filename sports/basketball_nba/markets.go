@@ -24,6 +24,28 @@ func PropsMarkets() []string {
 	}
 }
 
+// GamePropsMarkets returns the list of game prop markets for NBA
+// These are polled per event alongside player props on the props ramp schedule
+func GamePropsMarkets() []string {
+	return []string{
+		"team_totals",
+		"alternate_team_totals",
+		"alternate_spreads",
+		"alternate_totals",
+		"h2h_q1",
+		"spreads_q1",
+		"totals_q1",
+		"h2h_h1",
+		"spreads_h1",
+		"totals_h1",
+	}
+}
+
+// AllPropsMarkets returns player and game prop markets polled per event
+func AllPropsMarkets() []string {
+	return append(PropsMarkets(), GamePropsMarkets()...)
+}
+
 // MapVendorMarketKey translates vendor market keys to internal keys
 // For The Odds API, these are already 1:1, but this allows for future adapters
 func MapVendorMarketKey(vendorKey string) string {
@@ -31,6 +53,27 @@ func MapVendorMarketKey(vendorKey string) string {
 	return vendorKey
 }
 
+// IsGamePropsMarket returns true if the market is a game prop
+func IsGamePropsMarket(marketKey string) bool {
+	for _, m := range GamePropsMarkets() {
+		if m == marketKey {
+			return true
+		}
+	}
+	return false
+}
+
+// RequiresPoint returns true if odds in the market must carry a line
+func RequiresPoint(marketKey string) bool {
+	switch marketKey {
+	case "spreads", "totals",
+		"team_totals", "alternate_team_totals", "alternate_spreads", "alternate_totals",
+		"spreads_q1", "totals_q1", "spreads_h1", "totals_h1":
+		return true
+	}
+	return false
+}
+
 // IsPropsMarket returns true if the market is a player prop
 func IsPropsMarket(marketKey string) bool {
 	propsMap := make(map[string]bool)
@@ -1,9 +1,9 @@
 package basketball_nba
 
 import (
-	"fmt"
 	"time"
 
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
 	"github.com/XavierBriggs/Mercury/pkg/models"
 )
 
@@ -39,16 +39,51 @@ func (m *Module) GetRegions() []string {
 	return m.config.Regions
 }
 
+// GetBookmakers returns the bookmakers to request instead of whole regions
+func (m *Module) GetBookmakers() []string {
+	return m.config.Bookmakers
+}
+
 // GetFeaturedPollInterval returns the poll interval for featured markets
 func (m *Module) GetFeaturedPollInterval() time.Duration {
 	return m.config.Featured.PollInterval
 }
 
+// GetFeaturedWindowHours returns how far ahead featured polls reach (0 = all listed events)
+func (m *Module) GetFeaturedWindowHours() int {
+	return m.config.Featured.WindowHours
+}
+
+// GetTipoffWindow returns how long before tipoff events get targeted refreshes
+func (m *Module) GetTipoffWindow() time.Duration {
+	return m.config.Featured.TipoffWindow
+}
+
+// GetTipoffInterval returns the targeted refresh interval near tipoff
+func (m *Module) GetTipoffInterval() time.Duration {
+	return m.config.Featured.TipoffInterval
+}
+
 // GetPropsPollInterval returns the poll interval for props
 func (m *Module) GetPropsPollInterval() time.Duration {
 	return m.config.Props.PollInterval
 }
 
+// GetPropsMarkets returns the player and game prop markets polled per event
+func (m *Module) GetPropsMarkets() []string {
+	return AllPropsMarkets()
+}
+
+// GetPropsInterval returns the props poll interval for an event on the ramp schedule
+func (m *Module) GetPropsInterval(hoursUntilStart float64, isLive bool) time.Duration {
+	return m.config.GetPropsInterval(hoursUntilStart, isLive)
+}
+
+// GetPropsJitterSeconds returns the maximum random jitter added to props polls
+func (m *Module) GetPropsJitterSeconds() int {
+	return m.config.Props.JitterSeconds
+}
+
 // GetPropsDiscoveryInterval returns how often to discover new events
 func (m *Module) GetPropsDiscoveryInterval() time.Duration {
 	return m.config.Props.DiscoverySweepInterval
@@ -59,16 +94,61 @@ func (m *Module) GetPropsDiscoveryWindowHours() int {
 	return m.config.Props.DiscoveryWindowHours
 }
 
+// GetTypicalGameDuration returns how long after tipoff an NBA game is considered completed
+func (m *Module) GetTypicalGameDuration() time.Duration {
+	return m.config.GameDuration
+}
+
+// GetQuotaPriority returns the NBA degradation priority under quota pressure
+func (m *Module) GetQuotaPriority() int {
+	return m.config.Quota.Priority
+}
+
+// GetMinFeaturedCadence returns the slowest featured interval allowed under quota pressure
+func (m *Module) GetMinFeaturedCadence() time.Duration {
+	return m.config.Quota.MinFeaturedCadence
+}
+
+// GetMinPropsCadence returns the slowest props interval allowed under quota pressure
+func (m *Module) GetMinPropsCadence() time.Duration {
+	return m.config.Quota.MinPropsCadence
+}
+
+// GetFuturesKeys returns the NBA outright keys polled on the futures track
+func (m *Module) GetFuturesKeys() []string {
+	return m.config.Futures.Keys
+}
+
+// GetFuturesPollInterval returns the poll interval for futures/outrights
+func (m *Module) GetFuturesPollInterval() time.Duration {
+	return m.config.Futures.PollInterval
+}
+
+// GetSteamSettings returns steam move detection sensitivity for the NBA
+func (m *Module) GetSteamSettings() models.SteamSettings {
+	return m.config.Steam
+}
+
+// GetTeamAliases returns NBA team name aliases
+func (m *Module) GetTeamAliases() map[string]string {
+	return m.config.TeamAliases
+}
+
 // ShouldPollProps returns whether props polling is enabled
 func (m *Module) ShouldPollProps() bool {
 	return m.config.Props.Enabled
 }
 
+// ValidateEvent performs NBA-specific event validation
+func (m *Module) ValidateEvent(event models.Event) error {
+	return ValidateEvent(&event)
+}
+
 // ValidateOdds performs NBA-specific validation
 func (m *Module) ValidateOdds(odds models.RawOdds) error {
 	// Validate sport key
 	if odds.SportKey != m.config.SportKey {
-		return fmt.Errorf("invalid sport_key: expected %s, got %s", m.config.SportKey, odds.SportKey)
+		return merrors.Newf(merrors.ErrValidation, "invalid sport_key: expected %s, got %s", m.config.SportKey, odds.SportKey)
 	}
 
 	// Validate market key
@@ -76,22 +156,27 @@ func (m *Module) ValidateOdds(odds models.RawOdds) error {
 	for _, market := range FeaturedMarkets() {
 		validMarkets[market] = true
 	}
-	for _, market := range PropsMarkets() {
+	for _, market := range AllPropsMarkets() {
 		validMarkets[market] = true
 	}
 
 	if !validMarkets[odds.MarketKey] {
-		return fmt.Errorf("invalid market_key for NBA: %s", odds.MarketKey)
+		return merrors.Newf(merrors.ErrValidation, "invalid market_key for NBA: %s", odds.MarketKey)
 	}
 
 	// Validate American odds format (should be integer)
 	if odds.Price == 0 {
-		return fmt.Errorf("invalid price: cannot be 0")
+		return merrors.Newf(merrors.ErrValidation, "invalid price: cannot be 0")
+	}
+
+	// Validate spreads/totals (including team totals, alternates and periods) have point values
+	if RequiresPoint(odds.MarketKey) && odds.Point == nil {
+		return merrors.Newf(merrors.ErrValidation, "market %s requires point value", odds.MarketKey)
 	}
 
-	// Validate spreads/totals have point values
-	if (odds.MarketKey == "spreads" || odds.MarketKey == "totals") && odds.Point == nil {
-		return fmt.Errorf("market %s requires point value", odds.MarketKey)
+	// Team totals are Over/Under per team; the team is carried in the description
+	if (odds.MarketKey == "team_totals" || odds.MarketKey == "alternate_team_totals") && odds.Description == "" {
+		return merrors.Newf(merrors.ErrValidation, "market %s requires team description", odds.MarketKey)
 	}
 
 	return nil
@@ -0,0 +1,217 @@
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/streamgroups"
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// TestConsumer_GroupReadAckAndPending verifies group reads, re-delivery of unacked entries and acking
+func TestConsumer_GroupReadAckAndPending(t *testing.T) {
+	ctx := context.Background()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "localhost:6379"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       1, // Use test DB
+	})
+	defer redisClient.Close()
+
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: %v", err)
+	}
+	redisClient.FlushDB(ctx)
+
+	c := consumer.New(redisClient, consumer.Config{
+		Group:    "test-group",
+		Consumer: "test-consumer",
+		Sports:   []string{"basketball_nba"},
+		StartID:  "0",
+		Block:    100 * time.Millisecond,
+	})
+	if err := c.EnsureGroups(ctx); err != nil {
+		t.Fatalf("ensure groups: %v", err)
+	}
+	// Idempotent
+	if err := c.EnsureGroups(ctx); err != nil {
+		t.Fatalf("ensure groups twice: %v", err)
+	}
+
+	for _, price := range []int{-110, -115} {
+		data, _ := json.Marshal(models.StreamMessage{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "fanduel", OutcomeName: "Lakers", Price: price})
+		redisClient.XAdd(ctx, &redis.XAddArgs{Stream: consumer.StreamKey("basketball_nba"), Values: map[string]interface{}{"data": data}})
+	}
+	redisClient.XAdd(ctx, &redis.XAddArgs{Stream: consumer.StreamKey("basketball_nba"), Values: map[string]interface{}{"data": "garbage"}})
+
+	messages, decodeErrs, err := c.Read(ctx)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(messages) != 2 || len(decodeErrs) != 1 {
+		t.Fatalf("expected 2 messages and 1 decode error, got %d and %d", len(messages), len(decodeErrs))
+	}
+
+	// Unacked entries are re-delivered as pending
+	pending, _, err := c.ReadPending(ctx)
+	if err != nil {
+		t.Fatalf("read pending: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("expected 2 pending, got %d", len(pending))
+	}
+
+	if err := c.Ack(ctx, messages...); err != nil {
+		t.Fatalf("ack: %v", err)
+	}
+	pending, _, _ = c.ReadPending(ctx)
+	if len(pending) != 0 {
+		t.Errorf("expected no pending after ack, got %d", len(pending))
+	}
+
+	// Replay without a group
+	replayed, _, err := consumer.ReadRange(ctx, redisClient, "basketball_nba", "-", 10)
+	if err != nil {
+		t.Fatalf("read range: %v", err)
+	}
+	if len(replayed) != 2 || replayed[0].Odds.Price != -110 {
+		t.Errorf("unexpected replay: %+v", replayed)
+	}
+
+	// Run acks handled entries and stops with the context
+	runCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	defer cancel()
+	handled := 0
+	err = c.Run(runCtx, func(ctx context.Context, msg consumer.Message) error {
+		handled++
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+// TestConsumer_ReadRangeMergesLiveStream verifies replay interleaves the pre-match and
+// in-play streams by entry ID and pages without skipping entries that share an ID
+func TestConsumer_ReadRangeMergesLiveStream(t *testing.T) {
+	ctx := context.Background()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "localhost:6379"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       1, // Use test DB
+	})
+	defer redisClient.Close()
+
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: %v", err)
+	}
+	redisClient.FlushDB(ctx)
+
+	add := func(stream, id string, price int) {
+		data, _ := json.Marshal(models.StreamMessage{EventID: "e1", SportKey: "basketball_nba", Price: price})
+		if err := redisClient.XAdd(ctx, &redis.XAddArgs{Stream: stream, ID: id, Values: map[string]interface{}{"data": data}}).Err(); err != nil {
+			t.Fatalf("xadd: %v", err)
+		}
+	}
+	add(consumer.StreamKey("basketball_nba"), "1-0", 100)
+	add(consumer.LiveStreamKey("basketball_nba"), "2-0", 200)
+	add(consumer.StreamKey("basketball_nba"), "3-0", 300)
+	add(consumer.LiveStreamKey("basketball_nba"), "3-0", 301)
+
+	page, _, err := consumer.ReadRange(ctx, redisClient, "basketball_nba", "-", 2)
+	if err != nil {
+		t.Fatalf("read range: %v", err)
+	}
+	if len(page) != 2 || page[0].Odds.Price != 100 || page[1].Odds.Price != 200 ||
+		page[1].Stream != consumer.LiveStreamKey("basketball_nba") {
+		t.Fatalf("unexpected first page: %+v", page)
+	}
+
+	// Both entries with ID 3-0 come back even though the page size is 1
+	page, _, err = consumer.ReadRange(ctx, redisClient, "basketball_nba", "("+page[1].ID, 1)
+	if err != nil {
+		t.Fatalf("read range: %v", err)
+	}
+	if len(page) != 2 || page[0].Odds.Price != 300 || page[1].Odds.Price != 301 {
+		t.Errorf("unexpected second page: %+v", page)
+	}
+}
+
+// TestStreamGroups_BootstrapReplay verifies bootstrapped groups start at new entries and replay rewinds them
+func TestStreamGroups_BootstrapReplay(t *testing.T) {
+	ctx := context.Background()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "localhost:6379"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       1, // Use test DB
+	})
+	defer redisClient.Close()
+
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: %v", err)
+	}
+	redisClient.FlushDB(ctx)
+
+	stream := consumer.StreamKey("basketball_nba")
+	var ids []string
+	for _, price := range []int{-110, -115, -120} {
+		data, _ := json.Marshal(models.StreamMessage{EventID: "e1", SportKey: "basketball_nba", Price: price})
+		id, err := redisClient.XAdd(ctx, &redis.XAddArgs{Stream: stream, Values: map[string]interface{}{"data": data}}).Result()
+		if err != nil {
+			t.Fatalf("xadd: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := streamgroups.Bootstrap(ctx, redisClient, []string{"basketball_nba"}, []string{"replay-group"}); err != nil {
+		t.Fatalf("bootstrap: %v", err)
+	}
+
+	c := consumer.New(redisClient, consumer.Config{
+		Group:    "replay-group",
+		Consumer: "c1",
+		Sports:   []string{"basketball_nba"},
+		Block:    50 * time.Millisecond,
+	})
+
+	// Bootstrapped at "$": existing entries are not delivered
+	messages, _, err := c.Read(ctx)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(messages) != 0 {
+		t.Fatalf("expected no messages after bootstrap, got %d", len(messages))
+	}
+
+	// Replay from the second entry (inclusive)
+	if err := streamgroups.Replay(ctx, redisClient, "basketball_nba", "replay-group", ids[1]); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+
+	messages, _, err = c.Read(ctx)
+	if err != nil {
+		t.Fatalf("read after replay: %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != ids[1] {
+		t.Fatalf("expected replay from %s, got %+v", ids[1], messages)
+	}
+
+	lags, err := streamgroups.Lag(ctx, redisClient, []string{"basketball_nba"})
+	if err != nil {
+		t.Fatalf("lag: %v", err)
+	}
+	if len(lags) != 1 || lags[0].Pending != 2 {
+		t.Errorf("expected 2 pending for replay-group, got %+v", lags)
+	}
+}
@@ -194,6 +194,115 @@ func TestDetectChanges_PointChange(t *testing.T) {
 	}
 }
 
+func TestDetectChanges_LimitChange(t *testing.T) {
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "localhost:6379"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+	})
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	engine := delta.NewEngine(redisClient, 30*time.Second)
+
+	redisClient.FlushDB(ctx)
+
+	now := time.Now()
+	oldLimit := 5000.0
+	newLimit := 2000.0
+
+	initialOdds := []models.RawOdds{
+		{
+			EventID:          "test_event_1",
+			SportKey:         "basketball_nba",
+			MarketKey:        "h2h",
+			BookKey:          "pinnacle",
+			OutcomeName:      "Lakers",
+			Price:            -110,
+			Limit:            &oldLimit,
+			VendorLastUpdate: now,
+			ReceivedAt:       now,
+		},
+	}
+
+	engine.UpdateCache(ctx, initialOdds)
+
+	// Same price, limit cut
+	changedOdds := []models.RawOdds{initialOdds[0]}
+	changedOdds[0].Limit = &newLimit
+
+	deltas, err := engine.DetectChanges(ctx, changedOdds)
+	if err != nil {
+		t.Fatalf("DetectChanges failed: %v", err)
+	}
+
+	if len(deltas) != 1 {
+		t.Fatalf("expected 1 delta, got %d", len(deltas))
+	}
+
+	if deltas[0].ChangeType != delta.ChangeTypeLimitOnly {
+		t.Errorf("expected ChangeTypeLimitOnly, got %s", deltas[0].ChangeType)
+	}
+
+	if deltas[0].OldLimit == nil || *deltas[0].OldLimit != oldLimit {
+		t.Errorf("expected old limit %v, got %v", oldLimit, deltas[0].OldLimit)
+	}
+}
+
+func TestDetectChanges_SkipUnchangedTimestamps(t *testing.T) {
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "localhost:6379"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+	})
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	engine := delta.NewEngine(redisClient, 30*time.Second)
+	engine.SetSkipUnchangedTimestamps(true)
+
+	redisClient.FlushDB(ctx)
+
+	now := time.Now()
+
+	initialOdds := []models.RawOdds{
+		{
+			EventID:          "test_event_1",
+			SportKey:         "basketball_nba",
+			MarketKey:        "h2h",
+			BookKey:          "fanduel",
+			OutcomeName:      "Lakers",
+			Price:            -110,
+			VendorLastUpdate: now,
+			ReceivedAt:       now,
+		},
+	}
+
+	engine.UpdateCache(ctx, initialOdds)
+
+	// Price differs but vendor timestamp did not advance: short-circuited
+	sameTimestamp := []models.RawOdds{initialOdds[0]}
+	sameTimestamp[0].Price = -115
+
+	deltas, err := engine.DetectChanges(ctx, sameTimestamp)
+	if err != nil {
+		t.Fatalf("DetectChanges failed: %v", err)
+	}
+	if len(deltas) != 0 {
+		t.Errorf("expected 0 deltas when vendor timestamp unchanged, got %d", len(deltas))
+	}
+
+	// Timestamp advanced: compared normally
+	advanced := []models.RawOdds{sameTimestamp[0]}
+	advanced[0].VendorLastUpdate = now.Add(time.Minute)
+
+	deltas, err = engine.DetectChanges(ctx, advanced)
+	if err != nil {
+		t.Fatalf("DetectChanges failed: %v", err)
+	}
+	if len(deltas) != 1 || deltas[0].ChangeType != delta.ChangeTypePriceOnly {
+		t.Errorf("expected 1 price delta after timestamp advanced, got %v", deltas)
+	}
+}
+
 func TestDetectChanges_NoChange(t *testing.T) {
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     getEnv("REDIS_URL", "localhost:6379"),
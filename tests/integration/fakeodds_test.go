@@ -0,0 +1,109 @@
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/delta"
+	"github.com/XavierBriggs/Mercury/pkg/fakeodds"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// TestFakeOdds_LineMovesBecomeDeltas polls a fake vendor through the real adapter and
+// delta engine while its lines move, from the opening number to the close
+func TestFakeOdds_LineMovesBecomeDeltas(t *testing.T) {
+	ctx := context.Background()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "localhost:6379"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       1,
+	})
+	defer redisClient.Close()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: %v", err)
+	}
+	redisClient.FlushDB(ctx)
+
+	open := time.Now().UTC().Truncate(time.Minute)
+	tip := open.Add(4 * time.Hour)
+	clock := fakeodds.NewClock(open)
+	server := fakeodds.New(fakeodds.WithClock(clock.Now))
+	defer server.Close()
+
+	point := func(v float64) *float64 { return &v }
+	server.AddEvent(fakeodds.Event{
+		ID:           "fake_e1",
+		SportKey:     "basketball_nba",
+		HomeTeam:     "Los Angeles Lakers",
+		AwayTeam:     "Boston Celtics",
+		CommenceTime: tip,
+		Duration:     3 * time.Hour,
+		Lines: []fakeodds.Line{
+			{Book: "fanduel", Market: "h2h", Outcome: "Los Angeles Lakers", Price: -150,
+				Moves: []fakeodds.Move{{At: open.Add(time.Hour), Price: -160}, {At: tip.Add(-time.Minute), Price: -175}}},
+			{Book: "fanduel", Market: "h2h", Outcome: "Boston Celtics", Price: 130,
+				Moves: []fakeodds.Move{{At: open.Add(time.Hour), Price: 140}, {At: tip.Add(-time.Minute), Price: 150}}},
+			{Book: "fanduel", Market: "spreads", Outcome: "Los Angeles Lakers", Price: -110, Point: point(-3.5)},
+			{Book: "fanduel", Market: "spreads", Outcome: "Boston Celtics", Price: -110, Point: point(3.5)},
+		},
+	})
+
+	client := theoddsapi.NewClient("test_key", theoddsapi.WithBaseURL(server.BaseURL()), theoddsapi.WithHTTPClient(server.Client()))
+	engine := delta.NewEngine(redisClient, time.Hour)
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"h2h", "spreads"}}
+
+	poll := func() []delta.Delta {
+		t.Helper()
+		result, err := client.FetchOdds(ctx, opts)
+		if err != nil {
+			t.Fatalf("fetch: %v", err)
+		}
+		deltas, err := engine.DetectChanges(ctx, result.Odds)
+		if err != nil {
+			t.Fatalf("detect: %v", err)
+		}
+		if err := engine.UpdateCache(ctx, result.Odds); err != nil {
+			t.Fatalf("update cache: %v", err)
+		}
+		return deltas
+	}
+
+	if deltas := poll(); len(deltas) != 4 {
+		t.Fatalf("expected 4 new lines at the open, got %d", len(deltas))
+	}
+	if deltas := poll(); len(deltas) != 0 {
+		t.Errorf("expected no deltas before any move, got %d", len(deltas))
+	}
+
+	clock.Advance(90 * time.Minute)
+	deltas := poll()
+	if len(deltas) != 2 {
+		t.Fatalf("expected the two moneyline moves, got %d", len(deltas))
+	}
+	for _, d := range deltas {
+		if d.ChangeType != delta.ChangeTypePriceOnly || d.Odd.MarketKey != "h2h" {
+			t.Errorf("expected h2h price moves, got %s on %s", d.ChangeType, d.Odd.MarketKey)
+		}
+	}
+
+	// The closing line is the last one served before tip-off
+	clock.Set(tip.Add(-30 * time.Second))
+	closing := make(map[string]int)
+	for _, d := range poll() {
+		closing[d.Odd.OutcomeName] = d.Odd.Price
+	}
+	if closing["Los Angeles Lakers"] != -175 || closing["Boston Celtics"] != 150 {
+		t.Errorf("expected a -175/+150 close, got %v", closing)
+	}
+
+	if requests := server.Requests(); len(requests) != 4 {
+		t.Errorf("expected 4 vendor requests, got %d", len(requests))
+	}
+}
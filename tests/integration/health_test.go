@@ -0,0 +1,77 @@
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// TestHealthReporter_RoundTrip verifies poll health written by the scheduler can be read back by `mercury top`
+func TestHealthReporter_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     getEnv("REDIS_URL", "localhost:6379"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+		DB:       1, // Use test DB
+	})
+	defer redisClient.Close()
+
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: %v", err)
+	}
+	redisClient.FlushDB(ctx)
+
+	reporter := health.NewReporter(redisClient)
+
+	stats := health.PollStats{
+		Events: 3,
+		Odds:   120,
+		Deltas: 7,
+		Stages: map[string]time.Duration{"fetch": 250 * time.Millisecond, "write": 4 * time.Millisecond},
+		Total:  260 * time.Millisecond,
+	}
+	for i := 0; i < 2; i++ {
+		if err := reporter.RecordPoll(ctx, "basketball_nba", stats); err != nil {
+			t.Fatalf("record poll: %v", err)
+		}
+	}
+	if err := reporter.RecordError(ctx, "basketball_nba", errors.New("fetch odds: timeout")); err != nil {
+		t.Fatalf("record error: %v", err)
+	}
+	if err := reporter.RecordQuota(ctx, &models.RateLimits{RequestsRemaining: 420, RequestsUsed: 80}); err != nil {
+		t.Fatalf("record quota: %v", err)
+	}
+
+	snapshot, err := reporter.Snapshot(ctx)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	if len(snapshot.Sports) != 1 {
+		t.Fatalf("expected 1 sport, got %d", len(snapshot.Sports))
+	}
+
+	nba := snapshot.Sports[0]
+	if nba.Polls != 2 || nba.Deltas != 14 || nba.Errors != 1 {
+		t.Errorf("expected 2 polls / 14 deltas / 1 error, got %d / %d / %d", nba.Polls, nba.Deltas, nba.Errors)
+	}
+	if nba.Stages["fetch"] != 250*time.Millisecond {
+		t.Errorf("expected fetch stage 250ms, got %v", nba.Stages["fetch"])
+	}
+	if nba.LastError != "fetch odds: timeout" {
+		t.Errorf("unexpected last error: %q", nba.LastError)
+	}
+
+	if snapshot.Quota == nil || snapshot.Quota.Remaining != 420 {
+		t.Errorf("expected quota remaining 420, got %+v", snapshot.Quota)
+	}
+}
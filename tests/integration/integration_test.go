@@ -54,8 +54,15 @@ func TestEndToEnd_FetchDetectWrite(t *testing.T) {
 
 	deltaEngine := delta.NewEngine(redisClient, 30*time.Second)
 	w := writer.NewWriter(db, redisClient)
-	w.Start(ctx)
-	defer w.Stop()
+	writerCtx, stopWriter := context.WithCancel(ctx)
+	writerDone := make(chan error, 1)
+	go func() { writerDone <- w.Run(writerCtx) }()
+	defer func() {
+		stopWriter()
+		if err := <-writerDone; err != nil {
+			t.Errorf("writer: %v", err)
+		}
+	}()
 
 	// Step 1: Create initial odds
 	odds1 := []models.RawOdds{
@@ -203,8 +210,15 @@ func TestIntegration_LatencySLO(t *testing.T) {
 
 	deltaEngine := delta.NewEngine(redisClient, 30*time.Second)
 	w := writer.NewWriter(db, redisClient)
-	w.Start(ctx)
-	defer w.Stop()
+	writerCtx, stopWriter := context.WithCancel(ctx)
+	writerDone := make(chan error, 1)
+	go func() { writerDone <- w.Run(writerCtx) }()
+	defer func() {
+		stopWriter()
+		if err := <-writerDone; err != nil {
+			t.Errorf("writer: %v", err)
+		}
+	}()
 
 	// Create 100 odds changes (using real book keys)
 	realBooks := []string{"fanduel", "draftkings", "betmgm", "caesars", "pinnacle", "circa", "bookmaker", "pointsbet", "betrivers", "wynnbet"}
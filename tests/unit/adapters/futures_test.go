@@ -0,0 +1,97 @@
+package adapters_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const futuresFixture = `[{"id":"f1","sport_key":"basketball_nba_championship_winner",
+	"commence_time":"2025-06-20T00:00:00Z","home_team":null,"away_team":null,"bookmakers":[
+	{"key":"fanduel","last_update":"2025-01-15T11:59:00Z","markets":[{"key":"outrights",
+	"last_update":"2025-01-15T11:58:00Z","outcomes":[
+	{"name":"Boston Celtics","price":350},{"name":"Denver Nuggets","price":0},
+	{"name":"Oklahoma City Thunder","price":500,"point":58.5}]}]},
+	{"key":"draftkings","last_update":"2025-01-15T11:57:00Z","markets":[{"key":"outrights","outcomes":[
+	{"name":"Boston Celtics","price":-120}]}]}]}]`
+
+func fetchFutures(t *testing.T, body string, format models.OddsFormat) []models.FuturesOdds {
+	t.Helper()
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/sports/basketball_nba_championship_winner/odds" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("markets") != "outrights" || query.Get("oddsFormat") != string(format) {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(body))
+	})
+	if err := client.SetOddsFormat(format); err != nil {
+		t.Fatalf("set odds format: %v", err)
+	}
+
+	odds, err := client.FetchFutures(context.Background(), &models.FetchFuturesOptions{
+		Sport:      "basketball_nba",
+		FuturesKey: "basketball_nba_championship_winner",
+		Regions:    []string{"us"},
+	})
+	if err != nil {
+		t.Fatalf("fetch futures: %v", err)
+	}
+	return odds
+}
+
+func TestFetchFutures_ParsesOutrights(t *testing.T) {
+	odds := fetchFutures(t, futuresFixture, models.OddsFormatAmerican)
+
+	// The zero Denver price is skipped, not stored as a bogus line
+	if len(odds) != 3 {
+		t.Fatalf("expected 3 futures odds, got %d: %+v", len(odds), odds)
+	}
+
+	celtics := odds[0]
+	if celtics.SportKey != "basketball_nba" || celtics.FuturesKey != "basketball_nba_championship_winner" ||
+		celtics.MarketKey != "outrights" || celtics.BookKey != "fanduel" || celtics.OutcomeName != "Boston Celtics" {
+		t.Errorf("unexpected keys %+v", celtics)
+	}
+	if celtics.Price != 350 || celtics.DecimalPrice != 4.5 || celtics.Point != nil {
+		t.Errorf("expected +350 (4.5) without a point, got %+v", celtics)
+	}
+	if want := time.Date(2025, 6, 20, 0, 0, 0, 0, time.UTC); celtics.ExpiresAt == nil || !celtics.ExpiresAt.Equal(want) {
+		t.Errorf("expected the market to expire at %v, got %v", want, celtics.ExpiresAt)
+	}
+	if want := time.Date(2025, 1, 15, 11, 58, 0, 0, time.UTC); !celtics.VendorLastUpdate.Equal(want) {
+		t.Errorf("expected the market's last_update %v, got %v", want, celtics.VendorLastUpdate)
+	}
+
+	thunder := odds[1]
+	if thunder.OutcomeName != "Oklahoma City Thunder" || thunder.Point == nil || *thunder.Point != 58.5 {
+		t.Errorf("expected the win total point 58.5, got %+v", thunder)
+	}
+
+	draftkings := odds[2]
+	if draftkings.BookKey != "draftkings" || draftkings.Price != -120 {
+		t.Errorf("unexpected draftkings odd %+v", draftkings)
+	}
+	if want := time.Date(2025, 1, 15, 11, 57, 0, 0, time.UTC); !draftkings.VendorLastUpdate.Equal(want) {
+		t.Errorf("expected the bookmaker's last_update %v when the market has none, got %v", want, draftkings.VendorLastUpdate)
+	}
+}
+
+func TestFetchFutures_DecimalFormatKeepsVendorDecimal(t *testing.T) {
+	body := `[{"id":"f1","sport_key":"basketball_nba_championship_winner","commence_time":"2025-06-20T00:00:00Z",
+		"bookmakers":[{"key":"pinnacle","last_update":"2025-01-15T11:59:00Z","markets":[{"key":"outrights",
+		"outcomes":[{"name":"Boston Celtics","price":1.909}]}]}]}]`
+	odds := fetchFutures(t, body, models.OddsFormatDecimal)
+
+	if len(odds) != 1 {
+		t.Fatalf("expected 1 futures odd, got %d", len(odds))
+	}
+	if odds[0].DecimalPrice != 1.909 || odds[0].Price != -110 {
+		t.Errorf("expected 1.909 (-110), got %v (%d)", odds[0].DecimalPrice, odds[0].Price)
+	}
+}
@@ -0,0 +1,74 @@
+package adapters_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/fixtures"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// goldenRoot holds recorded vendor responses (mercury record-fixtures --out tests/fixtures)
+const goldenRoot = "../../fixtures"
+
+// TestGoldenFixtures_ParseCleanly parses every recorded response: real payload shapes
+// must produce events and odds without anything being quarantined
+func TestGoldenFixtures_ParseCleanly(t *testing.T) {
+	golden, err := fixtures.LoadAll(goldenRoot, "theoddsapi")
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	if len(golden) == 0 {
+		t.Fatal("no golden fixtures found")
+	}
+
+	for _, f := range golden {
+		t.Run(string(f.Kind)+"/"+f.Sport, func(t *testing.T) {
+			client := theoddsapi.NewClient("")
+			sink := &recordingQuarantine{}
+			client.SetQuarantineSink(sink)
+
+			result, err := client.ParsePayload(f.Payload())
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if len(sink.records) > 0 {
+				t.Errorf("expected nothing quarantined, got %+v", sink.records)
+			}
+			if len(result.Events) == 0 {
+				t.Error("expected events")
+			}
+			if f.Kind != models.PayloadKindEvents && len(result.Odds) == 0 {
+				t.Error("expected odds")
+			}
+			for _, odd := range result.Odds {
+				if !odd.ReceivedAt.Equal(f.RecordedAt) {
+					t.Fatalf("expected odds received at the recording time %v, got %v", f.RecordedAt, odd.ReceivedAt)
+				}
+			}
+		})
+	}
+}
+
+func TestGoldenFixtures_PropsCarryDescriptions(t *testing.T) {
+	golden, err := fixtures.LoadAll(goldenRoot, "theoddsapi")
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+
+	client := theoddsapi.NewClient("")
+	for _, f := range golden {
+		if f.Kind != models.PayloadKindEventOdds {
+			continue
+		}
+		result, err := client.ParsePayload(f.Payload())
+		if err != nil {
+			t.Fatalf("parse %s: %v", f.EventID, err)
+		}
+		for _, odd := range result.Odds {
+			if odd.Description == "" || odd.Point == nil {
+				t.Errorf("expected props to carry a description and line, got %+v", odd)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package adapters_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// linksEvent has one book per deep link level: fanduel links every outcome, betmgm
+// only the market, draftkings only the bookmaker, and bovada nothing. fanduel's
+// Celtics outcome has no link of its own, so it falls back to the market's
+const linksEvent = `{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z",
+	"home_team":"Lakers","away_team":"Celtics","bookmakers":[
+	{"key":"fanduel","last_update":"2025-01-15T11:59:00Z","link":"https://fd.example/game",
+	 "markets":[{"key":"h2h","link":"https://fd.example/h2h","outcomes":[
+		{"name":"Lakers","price":-120,"link":"https://fd.example/slip?lakers"},
+		{"name":"Celtics","price":100}]}]},
+	{"key":"betmgm","last_update":"2025-01-15T11:59:00Z",
+	 "markets":[{"key":"h2h","link":"https://mgm.example/h2h","outcomes":[
+		{"name":"Lakers","price":-118},{"name":"Celtics","price":-102}]}]},
+	{"key":"draftkings","last_update":"2025-01-15T11:59:00Z","link":"https://dk.example/game",
+	 "markets":[{"key":"h2h","outcomes":[
+		{"name":"Lakers","price":-125},{"name":"Celtics","price":105}]}]},
+	{"key":"bovada","last_update":"2025-01-15T11:59:00Z",
+	 "markets":[{"key":"h2h","outcomes":[
+		{"name":"Lakers","price":-120},{"name":"Celtics","price":100}]}]}]}`
+
+// wantLinks is each outcome's expected deep link, keyed by book and outcome
+var wantLinks = map[string]string{
+	"fanduel/Lakers":     "https://fd.example/slip?lakers",
+	"fanduel/Celtics":    "https://fd.example/h2h",
+	"betmgm/Lakers":      "https://mgm.example/h2h",
+	"betmgm/Celtics":     "https://mgm.example/h2h",
+	"draftkings/Lakers":  "https://dk.example/game",
+	"draftkings/Celtics": "https://dk.example/game",
+	"bovada/Lakers":      "",
+	"bovada/Celtics":     "",
+}
+
+func assertLinks(t *testing.T, odds []models.RawOdds) {
+	t.Helper()
+	if len(odds) != len(wantLinks) {
+		t.Fatalf("expected %d odds, got %d", len(wantLinks), len(odds))
+	}
+	for _, odd := range odds {
+		key := odd.BookKey + "/" + odd.OutcomeName
+		want, ok := wantLinks[key]
+		if !ok {
+			t.Errorf("unexpected outcome %s", key)
+			continue
+		}
+		if odd.DeepLink != want {
+			t.Errorf("%s: deep link = %q, want %q", key, odd.DeepLink, want)
+		}
+	}
+}
+
+func TestParseOdds_DeepLinkFallbackOrder(t *testing.T) {
+	result, quarantined := parseWithQuarantine(t, models.PayloadKindOdds, "["+linksEvent+"]")
+	if len(quarantined) != 0 {
+		t.Fatalf("expected nothing quarantined, got %+v", quarantined)
+	}
+	assertLinks(t, result.Odds)
+}
+
+func TestParseEventOdds_DeepLinkFallbackOrder(t *testing.T) {
+	result, quarantined := parseWithQuarantine(t, models.PayloadKindEventOdds, linksEvent)
+	if len(quarantined) != 0 {
+		t.Fatalf("expected nothing quarantined, got %+v", quarantined)
+	}
+	assertLinks(t, result.Odds)
+}
+
+func TestFetchEventOdds_RequestsLinksWhenEnabled(t *testing.T) {
+	for _, include := range []bool{false, true} {
+		var gotParam string
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			gotParam = r.URL.Query().Get("includeLinks")
+			w.Write([]byte(linksEvent))
+		})
+		client.SetIncludeLinks(include)
+
+		result, err := client.FetchEventOdds(context.Background(), &models.FetchEventOddsOptions{
+			Sport:   "basketball_nba",
+			EventID: "e1",
+			Regions: []string{"us"},
+			Markets: []string{"h2h"},
+		})
+		if err != nil {
+			t.Fatalf("fetch event odds: %v", err)
+		}
+
+		if want := map[bool]string{false: "", true: "true"}[include]; gotParam != want {
+			t.Errorf("includeLinks=%v: query param = %q, want %q", include, gotParam, want)
+		}
+		if include {
+			assertLinks(t, result.Odds)
+		}
+	}
+}
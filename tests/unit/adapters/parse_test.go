@@ -0,0 +1,390 @@
+package adapters_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/fixtures"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// recordingQuarantine captures records the parser rejects
+type recordingQuarantine struct {
+	records []models.QuarantinedRecord
+}
+
+func (q *recordingQuarantine) Quarantine(records []models.QuarantinedRecord) {
+	q.records = append(q.records, records...)
+}
+
+// oddsEvent builds a one-event odds payload; fields are raw JSON fragments
+type oddsEvent struct {
+	id, sport, commence, home, away string
+	book, bookUpdate, market        string
+	marketUpdate                    string // Omitted when empty
+	outcomes                        string
+}
+
+func validEvent() oddsEvent {
+	return oddsEvent{
+		id: `"e1"`, sport: `"basketball_nba"`, commence: `"2025-01-16T00:00:00Z"`,
+		home: `"Lakers"`, away: `"Celtics"`,
+		book: `"fanduel"`, bookUpdate: `"2025-01-15T11:59:00Z"`, market: `"spreads"`,
+		outcomes: `{"name":"Lakers","price":-110,"point":-3.5},{"name":"Celtics","price":-110,"point":3.5}`,
+	}
+}
+
+func (e oddsEvent) body() string {
+	market := fmt.Sprintf(`{"key":%s,"outcomes":[%s]}`, e.market, e.outcomes)
+	if e.marketUpdate != "" {
+		market = fmt.Sprintf(`{"key":%s,"last_update":%s,"outcomes":[%s]}`, e.market, e.marketUpdate, e.outcomes)
+	}
+	return fmt.Sprintf(`[{"id":%s,"sport_key":%s,"commence_time":%s,"home_team":%s,"away_team":%s,
+		"bookmakers":[{"key":%s,"last_update":%s,"markets":[%s]}]}]`,
+		e.id, e.sport, e.commence, e.home, e.away, e.book, e.bookUpdate, market)
+}
+
+// parseWithQuarantine parses with the two-sided market checks off, so record-level
+// validation is tested in isolation (a rejected outcome would leave its line one-sided)
+func parseWithQuarantine(t *testing.T, kind models.PayloadKind, body string) (*models.FetchResult, []models.QuarantinedRecord) {
+	t.Helper()
+	return parseWithChecks(t, kind, body, theoddsapi.MarketChecks{})
+}
+
+func parseWithChecks(t *testing.T, kind models.PayloadKind, body string, checks theoddsapi.MarketChecks) (*models.FetchResult, []models.QuarantinedRecord) {
+	t.Helper()
+
+	client := theoddsapi.NewClient("")
+	sink := &recordingQuarantine{}
+	client.SetQuarantineSink(sink)
+	client.SetMarketChecks(checks)
+
+	result, err := client.ParsePayload(models.RawPayload{
+		Kind:       kind,
+		Sport:      "basketball_nba",
+		ReceivedAt: time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+		Body:       []byte(body),
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return result, sink.records
+}
+
+func TestParseOdds_ValidPayloadIsNotQuarantined(t *testing.T) {
+	result, quarantined := parseWithQuarantine(t, models.PayloadKindOdds, validEvent().body())
+
+	if len(result.Events) != 1 || len(result.Odds) != 2 {
+		t.Fatalf("expected 1 event and 2 odds, got %d and %d", len(result.Events), len(result.Odds))
+	}
+	if len(quarantined) != 0 {
+		t.Errorf("expected nothing quarantined, got %+v", quarantined)
+	}
+	want := time.Date(2025, 1, 15, 11, 59, 0, 0, time.UTC)
+	if got := result.Odds[0].VendorLastUpdate; !got.Equal(want) {
+		t.Errorf("expected bookmaker last_update %v when the market has none, got %v", want, got)
+	}
+}
+
+func TestParseOdds_QuarantinesMalformedRecords(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(e *oddsEvent)
+		events int // Events still emitted
+		odds   int // Odds still emitted
+		reason models.QuarantineReason
+	}{
+		{"missing event id", func(e *oddsEvent) { e.id = `""` }, 0, 0, models.QuarantineMissingField},
+		{"missing sport key", func(e *oddsEvent) { e.sport = `""` }, 0, 0, models.QuarantineMissingField},
+		{"missing team", func(e *oddsEvent) { e.away = `""` }, 0, 0, models.QuarantineMissingField},
+		{"malformed commence time", func(e *oddsEvent) { e.commence = `"tomorrow"` }, 0, 0, models.QuarantineBadTimestamp},
+		{"empty commence time", func(e *oddsEvent) { e.commence = `""` }, 0, 0, models.QuarantineBadTimestamp},
+		{"missing book key", func(e *oddsEvent) { e.book = `""` }, 1, 0, models.QuarantineMissingField},
+		{"malformed book update", func(e *oddsEvent) { e.bookUpdate = `"2025-13-45"` }, 1, 0, models.QuarantineBadTimestamp},
+		{"empty book update", func(e *oddsEvent) { e.bookUpdate = `""` }, 1, 0, models.QuarantineBadTimestamp},
+		{"malformed market update", func(e *oddsEvent) { e.marketUpdate = `"noon"` }, 1, 0, models.QuarantineBadTimestamp},
+		{"missing market key", func(e *oddsEvent) { e.market = `""` }, 1, 0, models.QuarantineMissingField},
+		{"missing outcome name", func(e *oddsEvent) {
+			e.outcomes = `{"name":"","price":-110,"point":-3.5},{"name":"Celtics","price":-110,"point":3.5}`
+		}, 1, 1, models.QuarantineMissingField},
+		{"american price inside (-100, 100)", func(e *oddsEvent) {
+			e.outcomes = `{"name":"Lakers","price":50,"point":-3.5},{"name":"Celtics","price":-110,"point":3.5}`
+		}, 1, 1, models.QuarantineBadPrice},
+		{"absurd long price", func(e *oddsEvent) {
+			e.outcomes = `{"name":"Lakers","price":250000,"point":-3.5},{"name":"Celtics","price":-110,"point":3.5}`
+		}, 1, 1, models.QuarantineBadPrice},
+		{"absurd favourite price", func(e *oddsEvent) {
+			e.outcomes = `{"name":"Lakers","price":-1e12,"point":-3.5},{"name":"Celtics","price":-110,"point":3.5}`
+		}, 1, 1, models.QuarantineBadPrice},
+		{"absurd point", func(e *oddsEvent) {
+			e.outcomes = `{"name":"Lakers","price":-110,"point":-35000},{"name":"Celtics","price":-110,"point":3.5}`
+		}, 1, 1, models.QuarantineBadPoint},
+		{"duplicate outcome", func(e *oddsEvent) {
+			e.outcomes = `{"name":"Lakers","price":-110,"point":-3.5},{"name":"Lakers","price":-105,"point":-3.5}`
+		}, 1, 1, models.QuarantineDuplicateOutcome},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := validEvent()
+			tt.modify(&event)
+
+			result, quarantined := parseWithQuarantine(t, models.PayloadKindOdds, event.body())
+
+			if len(result.Events) != tt.events || len(result.Odds) != tt.odds {
+				t.Errorf("expected %d events and %d odds, got %d and %d",
+					tt.events, tt.odds, len(result.Events), len(result.Odds))
+			}
+			if len(quarantined) != 1 {
+				t.Fatalf("expected 1 quarantined record, got %+v", quarantined)
+			}
+			rec := quarantined[0]
+			if rec.Reason != tt.reason {
+				t.Errorf("expected reason %s, got %s (%s)", tt.reason, rec.Reason, rec.Detail)
+			}
+			if rec.Vendor != "theoddsapi" || rec.Kind != models.PayloadKindOdds || rec.ReceivedAt.IsZero() {
+				t.Errorf("expected vendor, kind and receive time on the record, got %+v", rec)
+			}
+		})
+	}
+}
+
+func TestParseOdds_DuplicateOutcomeKeepsFirstQuote(t *testing.T) {
+	event := validEvent()
+	event.outcomes = `{"name":"Lakers","price":-110,"point":-3.5},{"name":"Lakers","price":-105,"point":-3.5},` +
+		`{"name":"Lakers","price":-120,"point":-4.5}` // Different line: not a duplicate
+
+	result, quarantined := parseWithQuarantine(t, models.PayloadKindOdds, event.body())
+
+	if len(result.Odds) != 2 || result.Odds[0].Price != -110 {
+		t.Fatalf("expected the -110 quote and the -4.5 line, got %+v", result.Odds)
+	}
+	if len(quarantined) != 1 || quarantined[0].BookKey != "fanduel" || quarantined[0].MarketKey != "spreads" {
+		t.Errorf("expected one duplicate quarantined with book and market, got %+v", quarantined)
+	}
+}
+
+func TestParseOdds_ResolvesSpreadSides(t *testing.T) {
+	event := validEvent()
+	event.home, event.away = `"Los Angeles Lakers"`, `"Boston Celtics"`
+	event.outcomes = `{"name":"LA Lakers -3.5","price":-110},{"name":"Celtics","price":-110,"point":3.5}`
+
+	result, quarantined := parseWithQuarantine(t, models.PayloadKindOdds, event.body())
+	if len(quarantined) != 0 || len(result.Odds) != 2 {
+		t.Fatalf("expected 2 odds, got %+v (quarantined %+v)", result.Odds, quarantined)
+	}
+
+	home, away := result.Odds[0], result.Odds[1]
+	if home.OutcomeName != "Los Angeles Lakers" || home.Side != "home" || home.Point == nil || *home.Point != -3.5 {
+		t.Errorf("home outcome = %q side %q point %v", home.OutcomeName, home.Side, home.Point)
+	}
+	if away.OutcomeName != "Boston Celtics" || away.Side != "away" || away.Point == nil || *away.Point != 3.5 {
+		t.Errorf("away outcome = %q side %q point %v", away.OutcomeName, away.Side, away.Point)
+	}
+}
+
+func TestParseOdds_NoSideForTotals(t *testing.T) {
+	event := validEvent()
+	event.market = `"totals"`
+	event.outcomes = `{"name":"Over","price":-110,"point":220.5},{"name":"Under","price":-110,"point":220.5}`
+
+	result, _ := parseWithQuarantine(t, models.PayloadKindOdds, event.body())
+	for _, odd := range result.Odds {
+		if odd.Side != "" {
+			t.Errorf("%s has side %q, want none", odd.OutcomeName, odd.Side)
+		}
+	}
+}
+
+func TestParseOdds_CanonicalizesTotalsOutcomes(t *testing.T) {
+	event := validEvent()
+	event.market = `"totals"`
+	event.outcomes = `{"name":"over","price":-110,"point":220.5},{"name":"U 220.5","price":-110}`
+
+	result, quarantined := parseWithQuarantine(t, models.PayloadKindOdds, event.body())
+	if len(quarantined) != 0 || len(result.Odds) != 2 {
+		t.Fatalf("expected 2 odds and nothing quarantined, got %d odds, %+v", len(result.Odds), quarantined)
+	}
+	for i, want := range []string{"Over", "Under"} {
+		odd := result.Odds[i]
+		if odd.OutcomeName != want {
+			t.Errorf("outcome %d named %q, want %q", i, odd.OutcomeName, want)
+		}
+		if odd.Point == nil || *odd.Point != 220.5 {
+			t.Errorf("%s point = %v, want 220.5", odd.OutcomeName, odd.Point)
+		}
+	}
+}
+
+func TestParseOdds_MarketChecksPassSoundLines(t *testing.T) {
+	event := validEvent()
+	event.market = `"h2h"`
+	event.outcomes = `{"name":"Lakers","price":-150},{"name":"Celtics","price":130}`
+
+	result, quarantined := parseWithChecks(t, models.PayloadKindOdds, event.body(), theoddsapi.DefaultMarketChecks())
+	if len(result.Odds) != 2 || len(quarantined) != 0 {
+		t.Fatalf("expected both sides kept, got %d odds and %+v", len(result.Odds), quarantined)
+	}
+}
+
+func TestParseOdds_MarketChecksQuarantineBrokenLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		market   string
+		outcomes string
+		odds     int // Odds still emitted
+		reason   models.QuarantineReason
+	}{
+		{"missing away side", `"spreads"`,
+			`{"name":"Lakers","price":-110,"point":-3.5},{"name":"Celtics","price":-110,"point":4.5},{"name":"Lakers","price":-110,"point":-4.5}`,
+			2, models.QuarantineOneSided},
+		{"missing under", `"totals"`,
+			`{"name":"Over","price":-110,"point":220.5},{"name":"Over","price":-110,"point":221.5},{"name":"Under","price":-110,"point":221.5}`,
+			2, models.QuarantineOneSided},
+		{"spread sides do not mirror", `"spreads"`,
+			`{"name":"Lakers","price":-110,"point":-3.5},{"name":"Celtics","price":-110,"point":-3.5}`,
+			0, models.QuarantineBadPoint},
+		{"book is an arbitrage against itself", `"h2h"`,
+			`{"name":"Lakers","price":150},{"name":"Celtics","price":150}`,
+			0, models.QuarantineBadVig},
+		{"stale side", `"totals"`,
+			`{"name":"Over","price":-110,"point":220.5},{"name":"Under","price":-500,"point":220.5}`,
+			0, models.QuarantineBadVig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := validEvent()
+			event.market, event.outcomes = tt.market, tt.outcomes
+
+			result, quarantined := parseWithChecks(t, models.PayloadKindOdds, event.body(), theoddsapi.DefaultMarketChecks())
+
+			if len(result.Odds) != tt.odds {
+				t.Errorf("expected %d odds, got %+v", tt.odds, result.Odds)
+			}
+			if len(quarantined) != 1 {
+				t.Fatalf("expected 1 quarantined line, got %+v", quarantined)
+			}
+			if rec := quarantined[0]; rec.Reason != tt.reason || rec.BookKey != "fanduel" || rec.MarketKey == "" {
+				t.Errorf("expected %s with book and market, got %+v", tt.reason, rec)
+			}
+		})
+	}
+}
+
+func TestParseOdds_MarketChecksIgnoreUnpairedMarkets(t *testing.T) {
+	event := validEvent()
+	event.market = `"player_first_basket"`
+	event.outcomes = `{"name":"LeBron James","price":450},{"name":"Jayson Tatum","price":500}`
+
+	result, quarantined := parseWithChecks(t, models.PayloadKindOdds, event.body(), theoddsapi.DefaultMarketChecks())
+	if len(result.Odds) != 2 || len(quarantined) != 0 {
+		t.Fatalf("expected list markets left alone, got %d odds and %+v", len(result.Odds), quarantined)
+	}
+}
+
+func TestParseEvents_QuarantinesInsteadOfSkipping(t *testing.T) {
+	body := `[{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z","home_team":"Lakers","away_team":"Celtics"},
+		{"id":"e2","sport_key":"basketball_nba","commence_time":"TBD","home_team":"Heat","away_team":"Knicks"},
+		{"id":"","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z","home_team":"Suns","away_team":"Jazz"}]`
+
+	result, quarantined := parseWithQuarantine(t, models.PayloadKindEvents, body)
+
+	if len(result.Events) != 1 || result.Events[0].EventID != "e1" {
+		t.Fatalf("expected only e1, got %+v", result.Events)
+	}
+	if len(quarantined) != 2 {
+		t.Fatalf("expected 2 quarantined events, got %+v", quarantined)
+	}
+	if quarantined[0].EventID != "e2" || quarantined[0].Reason != models.QuarantineBadTimestamp {
+		t.Errorf("expected e2 quarantined for its commence time, got %+v", quarantined[0])
+	}
+	if quarantined[1].Reason != models.QuarantineMissingField || quarantined[1].Kind != models.PayloadKindEvents {
+		t.Errorf("expected missing-id event quarantined, got %+v", quarantined[1])
+	}
+}
+
+func TestParseOdds_ClipsQuarantineDetail(t *testing.T) {
+	event := validEvent()
+	event.commence = `"` + strings.Repeat("é", 2000) + `"`
+
+	_, quarantined := parseWithQuarantine(t, models.PayloadKindOdds, event.body())
+
+	if len(quarantined) != 1 {
+		t.Fatalf("expected 1 quarantined record, got %d", len(quarantined))
+	}
+	if detail := quarantined[0].Detail; len(detail) > 600 || !strings.HasSuffix(detail, "…") {
+		t.Errorf("expected a clipped detail, got %d bytes", len(detail))
+	}
+}
+
+// FuzzParsePayload feeds arbitrary bodies through every payload kind. Parsing may fail,
+// but must never panic, and whatever it emits must satisfy the pipeline's invariants.
+func FuzzParsePayload(f *testing.F) {
+	f.Add(validEvent().body())
+	f.Add(`{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z","home_team":"A","away_team":"B",
+		"bookmakers":[{"key":"b","last_update":"2025-01-15T11:59:00Z","markets":[{"key":"h2h","outcomes":[{"name":"A","price":1.5}]}]}]}`)
+	f.Add(`[{"id":"e1","sport_key":"x","commence_time":"2025-01-16T00:00:00Z","home_team":"A","away_team":"B"}]`)
+	f.Add(`[{"id":"e1","bookmakers":[{"markets":[{"outcomes":[{"price":-0.4,"point":1e308}]}]}]}]`)
+	f.Add(`[{"id":"e1","commence_time":"2025-01-16T00:00:00+99:00"}]`)
+	f.Add(`[null,{},[],"e1",1]`)
+	f.Add(`[`)
+	f.Add(``)
+	if golden, err := fixtures.LoadAll(goldenRoot, "theoddsapi"); err == nil {
+		for _, g := range golden {
+			f.Add(string(g.Body))
+		}
+	}
+
+	kinds := []models.PayloadKind{models.PayloadKindOdds, models.PayloadKindEventOdds, models.PayloadKindEvents}
+	formats := []models.OddsFormat{models.OddsFormatAmerican, models.OddsFormatDecimal}
+
+	f.Fuzz(func(t *testing.T, body string) {
+		for _, kind := range kinds {
+			for _, format := range formats {
+				client := theoddsapi.NewClient("")
+				sink := &recordingQuarantine{}
+				client.SetQuarantineSink(sink)
+
+				result, err := client.ParsePayload(models.RawPayload{
+					Kind:       kind,
+					Sport:      "basketball_nba",
+					OddsFormat: format,
+					ReceivedAt: time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+					Body:       []byte(body),
+				})
+				if err != nil {
+					continue
+				}
+
+				for _, evt := range result.Events {
+					if evt.EventID == "" || evt.SportKey == "" || evt.HomeTeam == "" || evt.AwayTeam == "" || evt.CommenceTime.IsZero() {
+						t.Fatalf("%s/%s: incomplete event emitted: %+v", kind, format, evt)
+					}
+				}
+				for _, odd := range result.Odds {
+					if odd.EventID == "" || odd.BookKey == "" || odd.MarketKey == "" || odd.OutcomeName == "" {
+						t.Fatalf("%s/%s: odds missing identity: %+v", kind, format, odd)
+					}
+					if odd.Price == 0 || odd.DecimalPrice <= 1 || odd.DecimalPrice > 1001 {
+						t.Fatalf("%s/%s: odds with absurd price emitted: %+v", kind, format, odd)
+					}
+					if odd.VendorLastUpdate.IsZero() {
+						t.Fatalf("%s/%s: odds without a vendor timestamp: %+v", kind, format, odd)
+					}
+					if odd.Point != nil && (*odd.Point > 1000 || *odd.Point < -1000) {
+						t.Fatalf("%s/%s: odds with absurd point emitted: %+v", kind, format, odd)
+					}
+				}
+				for _, rec := range sink.records {
+					if rec.Reason == "" || rec.Kind != kind {
+						t.Fatalf("%s/%s: quarantined record missing reason or kind: %+v", kind, format, rec)
+					}
+				}
+			}
+		}
+	})
+}
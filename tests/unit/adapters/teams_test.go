@@ -0,0 +1,65 @@
+package adapters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestParseOdds_CanonicalizesTeamNames(t *testing.T) {
+	teamNames := normalize.NewRegistry()
+	teamNames.SetAliases("basketball_nba", map[string]string{
+		"LA Lakers": "Los Angeles Lakers",
+		"Celtics":   "Boston Celtics",
+	})
+
+	client := theoddsapi.NewClient("")
+	client.SetTeamNames(teamNames)
+
+	body := `[{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z",
+		"home_team":"LA Lakers","away_team":"Celtics",
+		"bookmakers":[{"key":"fanduel","last_update":"2025-01-15T11:59:00Z","markets":[
+			{"key":"h2h","outcomes":[{"name":"LA Lakers","price":-150},{"name":"Celtics","price":130}]},
+			{"key":"team_totals","outcomes":[{"name":"Over","description":"LA Lakers","price":-110,"point":112.5}]},
+			{"key":"player_points","outcomes":[{"name":"Over","description":"LeBron James","price":-115,"point":25.5}]}
+		]}]}]`
+
+	result, err := client.ParsePayload(models.RawPayload{
+		Kind:       models.PayloadKindOdds,
+		Sport:      "basketball_nba",
+		ReceivedAt: time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+		Body:       []byte(body),
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if len(result.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(result.Events))
+	}
+	if e := result.Events[0]; e.HomeTeam != "Los Angeles Lakers" || e.AwayTeam != "Boston Celtics" {
+		t.Errorf("expected canonical event teams, got home=%q away=%q", e.HomeTeam, e.AwayTeam)
+	}
+
+	names := map[string]bool{}
+	for _, odd := range result.Odds {
+		switch odd.MarketKey {
+		case "h2h":
+			names[odd.OutcomeName] = true
+		case "team_totals":
+			if odd.OutcomeName != "Over" || odd.Description != "Los Angeles Lakers" {
+				t.Errorf("expected team_totals description to be canonical, got %q / %q", odd.OutcomeName, odd.Description)
+			}
+		case "player_points":
+			if odd.Description != "LeBron James" {
+				t.Errorf("expected player description untouched, got %q", odd.Description)
+			}
+		}
+	}
+	if !names["Los Angeles Lakers"] || !names["Boston Celtics"] {
+		t.Errorf("expected canonical h2h outcomes, got %v", names)
+	}
+}
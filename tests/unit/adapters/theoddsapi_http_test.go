@@ -0,0 +1,742 @@
+package adapters_test
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/bookskip"
+	"github.com/XavierBriggs/Mercury/internal/conditional"
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const oddsFixture = `[{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z",
+	"home_team":"Lakers","away_team":"Celtics","bookmakers":[{"key":"fanduel",
+	"last_update":"2025-01-15T11:59:00Z","markets":[{"key":"spreads","outcomes":[
+	{"name":"Lakers","price":-110,"point":-3.5},{"name":"Celtics","price":-110,"point":3.5}]}]}]}]`
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *theoddsapi.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return theoddsapi.NewClient("test_key", theoddsapi.WithBaseURL(server.URL+"/"), theoddsapi.WithHTTPClient(server.Client()))
+}
+
+func TestFetchOdds_HTTP(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/sports/basketball_nba/odds" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("apiKey") != "test_key" || query.Get("markets") != "h2h,spreads" || query.Get("regions") != "us" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("x-requests-remaining", "420")
+		w.Header().Set("x-requests-used", "80")
+		w.Write([]byte(oddsFixture))
+	})
+
+	result, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{
+		Sport:   "basketball_nba",
+		Regions: []string{"us"},
+		Markets: []string{"h2h", "spreads"},
+	})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	if len(result.Events) != 1 || len(result.Odds) != 2 {
+		t.Fatalf("expected 1 event and 2 odds, got %d and %d", len(result.Events), len(result.Odds))
+	}
+	odd := result.Odds[0]
+	if odd.Price != -110 || odd.Point == nil || *odd.Point != -3.5 {
+		t.Errorf("unexpected odd %+v", odd)
+	}
+	if want := time.Date(2025, 1, 15, 11, 59, 0, 0, time.UTC); !odd.VendorLastUpdate.Equal(want) {
+		t.Errorf("vendor last update = %v, want %v", odd.VendorLastUpdate, want)
+	}
+
+	if limits := client.GetRateLimits(); limits.RequestsRemaining != 420 || limits.RequestsUsed != 80 {
+		t.Errorf("rate limits not read from headers: %+v", limits)
+	}
+}
+
+func TestFetchOdds_BookmakersReplaceRegions(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("bookmakers") != "pinnacle,fanduel" || query.Has("regions") {
+			t.Errorf("expected bookmakers instead of regions, got %s", r.URL.RawQuery)
+		}
+		if strings.Contains(r.URL.Path, "/events/") {
+			w.Write([]byte(`{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z","home_team":"Lakers","away_team":"Celtics","bookmakers":[]}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	_, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{
+		Sport:      "basketball_nba",
+		Regions:    []string{"us", "eu"},
+		Markets:    []string{"h2h"},
+		Bookmakers: []string{"pinnacle", "fanduel"},
+	})
+	if err != nil {
+		t.Fatalf("fetch odds: %v", err)
+	}
+
+	_, err = client.FetchEventOdds(context.Background(), &models.FetchEventOddsOptions{
+		Sport:      "basketball_nba",
+		EventID:    "e1",
+		Regions:    []string{"us"},
+		Markets:    []string{"player_points"},
+		Bookmakers: []string{"pinnacle", "fanduel"},
+	})
+	if err != nil {
+		t.Fatalf("fetch event odds: %v", err)
+	}
+}
+
+func TestFetchEvents_HTTP(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/sports/basketball_nba/events" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`[{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z",
+			"home_team":"Lakers","away_team":"Celtics"}]`))
+	})
+
+	events, err := client.FetchEvents(context.Background(), &models.FetchEventsOptions{Sport: "basketball_nba"})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(events) != 1 || events[0].EventID != "e1" || events[0].HomeTeam != "Lakers" {
+		t.Errorf("unexpected events %+v", events)
+	}
+}
+
+func TestFetchParticipants_HTTP(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/sports/basketball_nba/participants" || r.URL.Query().Get("apiKey") != "test_key" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`[{"full_name":"Atlanta Hawks","id":"par_atl"},
+			{"full_name":"LA Clippers","id":"par_lac"},{"full_name":"","id":"par_blank"}]`))
+	})
+	teamNames := normalize.NewRegistry()
+	teamNames.SetAliases("basketball_nba", map[string]string{"LA Clippers": "Los Angeles Clippers"})
+	client.SetTeamNames(teamNames)
+
+	got, err := client.FetchParticipants(context.Background(), "basketball_nba")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	want := []models.Participant{
+		{ID: "par_atl", SportKey: "basketball_nba", FullName: "Atlanta Hawks"},
+		{ID: "par_lac", SportKey: "basketball_nba", FullName: "Los Angeles Clippers"},
+	}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestSetAPIKey_UsedByNextRequest(t *testing.T) {
+	var keys []string
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.URL.Query().Get("apiKey"))
+		w.Write([]byte(`[]`))
+	})
+
+	if _, err := client.FetchParticipants(context.Background(), "basketball_nba"); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	client.SetAPIKey("rotated_key")
+	if _, err := client.FetchParticipants(context.Background(), "basketball_nba"); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "test_key" || keys[1] != "rotated_key" {
+		t.Errorf("api keys = %v, want [test_key rotated_key]", keys)
+	}
+}
+
+func TestFetchOdds_SkipsUnchangedBookmakers(t *testing.T) {
+	bookUpdate := "2025-01-15T11:59:00Z"
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Replace(oddsFixture, "2025-01-15T11:59:00Z", bookUpdate, 1)))
+	})
+	cache := bookskip.NewCache(time.Hour)
+	client.SetBookmakerCache(cache)
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"spreads"}}
+
+	first, err := client.FetchOdds(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(first.Odds) != 2 || len(first.BookStamps) != 1 {
+		t.Fatalf("first fetch: %d odds, %d stamps; want 2, 1", len(first.Odds), len(first.BookStamps))
+	}
+
+	// Not committed yet (the pipeline has not processed it): parsed again
+	if again, _ := client.FetchOdds(context.Background(), opts); len(again.Odds) != 2 {
+		t.Fatalf("uncommitted refetch: %d odds, want 2", len(again.Odds))
+	}
+
+	cache.Commit(first.BookStamps, time.Now())
+	skipped, err := client.FetchOdds(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(skipped.Odds) != 0 || len(skipped.Events) != 1 {
+		t.Errorf("unchanged refetch: %d odds, %d events; want 0, 1", len(skipped.Odds), len(skipped.Events))
+	}
+
+	bookUpdate = "2025-01-15T12:00:30Z"
+	if changed, _ := client.FetchOdds(context.Background(), opts); len(changed.Odds) != 2 {
+		t.Errorf("advanced last_update: %d odds, want 2", len(changed.Odds))
+	}
+
+	// Archived payloads are always parsed in full
+	replayed, err := client.ParsePayload(models.RawPayload{Kind: models.PayloadKindOdds, Sport: "basketball_nba", Body: []byte(oddsFixture)})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(replayed.Odds) != 2 || replayed.BookStamps != nil {
+		t.Errorf("replay: %d odds, stamps %v; want 2, none", len(replayed.Odds), replayed.BookStamps)
+	}
+}
+
+func TestFetchOdds_ConditionalRequests(t *testing.T) {
+	var sent []string
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(oddsFixture))
+	})
+	cache := conditional.NewCache(time.Hour)
+	client.SetValidatorCache(cache)
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"spreads"}}
+
+	first, err := client.FetchOdds(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if first.NotModified || len(first.Odds) != 2 || len(first.Validators) != 1 {
+		t.Fatalf("first fetch: %d odds, validators %v", len(first.Odds), first.Validators)
+	}
+	for request, v := range first.Validators {
+		if strings.Contains(request, "test_key") || v.ETag != `"v1"` {
+			t.Errorf("unexpected validators %s: %+v", request, v)
+		}
+	}
+
+	// Not committed yet (the pipeline has not processed it): fetched in full again
+	if again, _ := client.FetchOdds(context.Background(), opts); again.NotModified || len(again.Odds) != 2 {
+		t.Fatalf("uncommitted refetch: not modified %t, %d odds", again.NotModified, len(again.Odds))
+	}
+
+	cache.Commit(first.Validators, time.Now())
+	unchanged, err := client.FetchOdds(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if !unchanged.NotModified || len(unchanged.Odds) != 0 || len(unchanged.Events) != 0 {
+		t.Errorf("expected an empty NotModified result, got %+v", unchanged)
+	}
+	if got := strings.Join(sent, ","); got != `,,"v1"` {
+		t.Errorf("expected only the committed refetch to be conditional, got %s", got)
+	}
+	if sent, notModified := cache.Stats(); sent != 1 || notModified != 1 {
+		t.Errorf("stats: %d sent, %d not modified; want 1, 1", sent, notModified)
+	}
+}
+
+func TestFetchOdds_UnrequestedNotModifiedFails(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	})
+	client.SetValidatorCache(conditional.NewCache(time.Hour))
+
+	result, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba", Markets: []string{"h2h"}})
+	if err == nil || result != nil {
+		t.Errorf("expected a 304 without validators sent to fail, got %+v", result)
+	}
+}
+
+func TestFetchScores_HTTP(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/sports/basketball_nba/scores" || r.URL.Query().Get("daysFrom") != "1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte(`[
+			{"id":"done","sport_key":"basketball_nba","commence_time":"2025-01-15T00:00:00Z","completed":true,
+			 "home_team":"LA Clippers","away_team":"Utah Jazz",
+			 "scores":[{"name":"LA Clippers","score":"112"},{"name":"Utah Jazz","score":"104"}],
+			 "last_update":"2025-01-15T02:31:00Z"},
+			{"id":"playing","sport_key":"basketball_nba","commence_time":"2025-01-15T01:00:00Z","completed":false,
+			 "home_team":"Boston Celtics","away_team":"Miami Heat",
+			 "scores":[{"name":"Boston Celtics","score":"50"},{"name":"Miami Heat","score":"48"}],"last_update":null},
+			{"id":"later","sport_key":"basketball_nba","commence_time":"2099-01-01T00:00:00Z","completed":false,
+			 "home_team":"Denver Nuggets","away_team":"Utah Jazz","scores":null,"last_update":null},
+			{"id":"broken","sport_key":"basketball_nba","commence_time":"soon","completed":false,
+			 "home_team":"Chicago Bulls","away_team":"Orlando Magic","scores":null,"last_update":null}
+		]`))
+	})
+	teamNames := normalize.NewRegistry()
+	teamNames.SetAliases("basketball_nba", map[string]string{"LA Clippers": "Los Angeles Clippers"})
+	client.SetTeamNames(teamNames)
+
+	got, err := client.FetchScores(context.Background(), "basketball_nba", 1)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 games (the bad commence time dropped), got %+v", got)
+	}
+
+	now := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	done := got[0]
+	if done.HomeTeam != "Los Angeles Clippers" || done.HomeScore != "112" || done.AwayScore != "104" ||
+		!done.LastUpdate.Equal(time.Date(2025, 1, 15, 2, 31, 0, 0, time.UTC)) {
+		t.Errorf("unexpected completed game %+v", done)
+	}
+	for i, want := range []string{models.EventStatusCompleted, models.EventStatusLive, models.EventStatusUpcoming} {
+		if status := got[i].Status(now); status != want {
+			t.Errorf("%s: status %s, want %s", got[i].EventID, status, want)
+		}
+	}
+	if !got[1].HasScores || got[2].HasScores {
+		t.Errorf("expected scores only on started games: %+v", got)
+	}
+}
+
+func TestFetch_CommenceWindow(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if strings.HasSuffix(r.URL.Path, "/events") {
+			if query.Get("commenceTimeFrom") != "2025-01-15T12:00:00Z" || query.Get("commenceTimeTo") != "2025-01-17T12:00:00Z" {
+				t.Errorf("unexpected events window %s", r.URL.RawQuery)
+			}
+		} else if query.Has("commenceTimeFrom") || query.Get("commenceTimeTo") != "2025-01-16T00:00:00Z" {
+			t.Errorf("expected only an upper bound on odds, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	from := time.Date(2025, 1, 15, 7, 0, 0, 0, time.FixedZone("EST", -5*3600)) // Sent as UTC
+	if _, err := client.FetchEvents(context.Background(), &models.FetchEventsOptions{
+		Sport:            "basketball_nba",
+		CommenceTimeFrom: from,
+		CommenceTimeTo:   from.Add(48 * time.Hour),
+	}); err != nil {
+		t.Fatalf("fetch events: %v", err)
+	}
+
+	if _, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{
+		Sport:          "basketball_nba",
+		Regions:        []string{"us"},
+		Markets:        []string{"h2h"},
+		CommenceTimeTo: time.Date(2025, 1, 16, 0, 0, 0, 500, time.UTC), // Sub-second precision dropped
+	}); err != nil {
+		t.Fatalf("fetch odds: %v", err)
+	}
+}
+
+func TestFetchOdds_EventIDs(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("eventIds"); got != "e1,e2" {
+			t.Errorf("expected eventIds e1,e2, got %q", got)
+		}
+		w.Write([]byte(`[]`))
+	})
+
+	if _, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{
+		Sport:    "basketball_nba",
+		Regions:  []string{"us"},
+		Markets:  []string{"h2h"},
+		EventIDs: []string{"e1", "e2"},
+	}); err != nil {
+		t.Fatalf("fetch odds: %v", err)
+	}
+}
+
+func TestFetchOdds_ClientErrorIsNotRetried(t *testing.T) {
+	calls := 0
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message":"invalid api key"}`, http.StatusUnauthorized)
+	})
+
+	_, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba"})
+	if err == nil {
+		t.Fatal("expected an error for 401")
+	}
+	if calls != 1 {
+		t.Errorf("a 4xx must not be retried, got %d calls", calls)
+	}
+}
+
+func TestFetchOdds_QuotaExhaustedIsClassified(t *testing.T) {
+	calls := 0
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("x-requests-remaining", "0")
+		http.Error(w, `{"message":"Usage quota has been reached","error_code":"OUT_OF_USAGE_CREDITS"}`, http.StatusUnauthorized)
+	})
+
+	_, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba"})
+	if !errors.Is(err, merrors.ErrQuotaExhausted) {
+		t.Fatalf("expected ErrQuotaExhausted, got %v", err)
+	}
+	if !merrors.Terminal(err) || calls != 1 {
+		t.Errorf("an exhausted quota must not be retried, got %d calls", calls)
+	}
+}
+
+func TestFetchOdds_RateLimitIsRetried(t *testing.T) {
+	calls := 0
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(oddsFixture))
+	})
+
+	result, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba"})
+	if err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if calls != 2 || len(result.Odds) != 2 {
+		t.Errorf("expected 2 calls and 2 odds, got %d calls and %d odds", calls, len(result.Odds))
+	}
+}
+
+func TestFetchOdds_RetryAfterBeyondBudgetIsSurfaced(t *testing.T) {
+	resetAt := time.Now().Add(90 * time.Second).Unix()
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{"seconds", map[string]string{"Retry-After": "120"}, 120 * time.Second},
+		{"http date", map[string]string{"Retry-After": time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)}, time.Minute},
+		{"ratelimit reset", map[string]string{"RateLimit-Reset": "45"}, 45 * time.Second},
+		{"unix reset", map[string]string{"X-RateLimit-Reset": strconv.FormatInt(resetAt, 10)}, 90 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				http.Error(w, "slow down", http.StatusTooManyRequests)
+			})
+
+			ctx := contracts.WithRetryBudget(context.Background(), 10*time.Second)
+			_, err := client.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "basketball_nba"})
+			if !errors.Is(err, merrors.ErrRateLimited) {
+				t.Fatalf("expected ErrRateLimited, got %v", err)
+			}
+			if calls != 1 {
+				t.Errorf("a wait past the retry budget must not be retried, got %d calls", calls)
+			}
+			if got := merrors.RetryAfter(err); got < tt.want-2*time.Second || got > tt.want {
+				t.Errorf("RetryAfter = %v, want about %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchOdds_MalformedResponseIsClassified(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"not":"an array"}`))
+	})
+
+	_, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba"})
+	if !errors.Is(err, merrors.ErrMalformedResponse) {
+		t.Fatalf("expected ErrMalformedResponse, got %v", err)
+	}
+}
+
+type recordingUsage struct {
+	records []models.VendorUsage
+}
+
+func (r *recordingUsage) RecordUsage(usage models.VendorUsage) {
+	r.records = append(r.records, usage)
+}
+
+func TestUsageSink_RecordsEveryResponse(t *testing.T) {
+	calls := 0
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("x-requests-last", "6")
+		w.Header().Set("x-requests-used", "106")
+		w.Header().Set("x-requests-remaining", "394")
+		if calls == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable) // Retried
+			return
+		}
+		w.Write([]byte(`[]`))
+	})
+	sink := &recordingUsage{}
+	client.SetUsageSink(sink)
+
+	if _, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{
+		Sport:   "basketball_nba",
+		Regions: []string{"us", "us2"},
+		Markets: []string{"h2h", "spreads", "totals"},
+	}); err != nil {
+		t.Fatalf("fetch odds: %v", err)
+	}
+
+	if len(sink.records) != 2 {
+		t.Fatalf("expected a record per response (including the retried one), got %d", len(sink.records))
+	}
+	first, last := sink.records[0], sink.records[1]
+	if first.StatusCode != http.StatusServiceUnavailable || last.StatusCode != http.StatusOK {
+		t.Errorf("unexpected statuses %d, %d", first.StatusCode, last.StatusCode)
+	}
+	if last.Kind != models.PayloadKindOdds || last.SportKey != "basketball_nba" || last.Vendor != "theoddsapi" {
+		t.Errorf("unexpected request identity %+v", last)
+	}
+	if last.Cost != 6 || last.Used != 106 || last.Remaining != 394 || len(last.Markets) != 3 {
+		t.Errorf("unexpected usage %+v", last)
+	}
+	if client.GetRateLimits().RequestsLast != 6 {
+		t.Errorf("expected the last request cost on rate limits, got %d", client.GetRateLimits().RequestsLast)
+	}
+}
+
+func TestUsageSink_MissingHeaders(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	})
+	sink := &recordingUsage{}
+	client.SetUsageSink(sink)
+
+	if _, err := client.FetchEvents(context.Background(), &models.FetchEventsOptions{Sport: "basketball_nba"}); err != nil {
+		t.Fatalf("fetch events: %v", err)
+	}
+	if len(sink.records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(sink.records))
+	}
+	if got := sink.records[0]; got.Cost != -1 || got.Used != -1 || got.Remaining != -1 || got.Markets != nil {
+		t.Errorf("expected unknown usage and no markets, got %+v", got)
+	}
+}
+
+type recordingArchiver struct {
+	payloads []models.RawPayload
+}
+
+func (a *recordingArchiver) Archive(payload models.RawPayload) {
+	a.payloads = append(a.payloads, payload)
+}
+
+func TestFetchOdds_GzipStreamIsArchivedWhole(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected gzip to be requested, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(oddsFixture))
+		gz.Close()
+	})
+	archiver := &recordingArchiver{}
+	client.SetPayloadArchiver(archiver)
+
+	result, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba"})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(result.Odds) != 2 {
+		t.Fatalf("expected 2 odds, got %d", len(result.Odds))
+	}
+
+	// The archive holds the decompressed body, byte for byte
+	if len(archiver.payloads) != 1 || string(archiver.payloads[0].Body) != oddsFixture {
+		t.Fatalf("archived body does not match the response")
+	}
+	if !archiver.payloads[0].ReceivedAt.Equal(result.Odds[0].ReceivedAt) {
+		t.Error("archived payload and parsed odds must share a receipt time")
+	}
+}
+
+func TestFetchOdds_MalformedBodyIsStillArchived(t *testing.T) {
+	body := `[{"id":"e1","sport_key":"basketball_nba","bookmakers":[` // truncated
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	archiver := &recordingArchiver{}
+	client.SetPayloadArchiver(archiver)
+
+	if _, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba"}); err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if len(archiver.payloads) != 1 || string(archiver.payloads[0].Body) != body {
+		t.Error("a payload that fails to parse must still be archived for post-mortems")
+	}
+
+	client = newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message":"not an array"}`))
+	})
+	if _, err := client.FetchOdds(context.Background(), &models.FetchOddsOptions{Sport: "basketball_nba"}); err == nil {
+		t.Error("expected an error for a non-array odds body")
+	}
+}
+
+func TestAPIKeys_RotateAcrossKeys(t *testing.T) {
+	var keys []string
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.URL.Query().Get("apiKey"))
+		w.Write([]byte(`[]`))
+	})
+	client.SetAPIKey("key_one, key_two,key_one")
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.FetchParticipants(context.Background(), "basketball_nba"); err != nil {
+			t.Fatalf("fetch: %v", err)
+		}
+	}
+	if strings.Join(keys, " ") != "key_one key_two key_one key_two" {
+		t.Errorf("api keys = %v, want alternating key_one and key_two", keys)
+	}
+}
+
+func TestAPIKeys_FailOverOnUnauthorized(t *testing.T) {
+	var keys []string
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("apiKey")
+		keys = append(keys, key)
+		if key == "bad_key1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"API key is not valid"}`))
+			return
+		}
+		w.Header().Set("x-requests-remaining", "300")
+		w.Header().Set("x-requests-used", "200")
+		w.Write([]byte(`[]`))
+	})
+	client.SetAPIKey("bad_key1,good_key")
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.FetchParticipants(context.Background(), "basketball_nba"); err != nil {
+			t.Fatalf("fetch %d: %v", i, err)
+		}
+	}
+
+	// The refused key is tried once, then left out of rotation
+	if strings.Join(keys, " ") != "bad_key1 good_key good_key good_key" {
+		t.Errorf("api keys = %v", keys)
+	}
+
+	usage := client.KeyUsage()
+	if len(usage) != 2 || usage[0].Key != "…key1" || usage[1].Key != "…_key" {
+		t.Fatalf("unexpected key usage %+v", usage)
+	}
+	if usage[0].Failures != 1 || usage[0].DisabledKind != "vendor_rejected" || usage[0].DisabledUntil.IsZero() {
+		t.Errorf("expected the bad key disabled, got %+v", usage[0])
+	}
+	if usage[1].Requests != 3 || usage[1].Remaining != 300 {
+		t.Errorf("expected 3 requests and 300 remaining on the good key, got %+v", usage[1])
+	}
+
+	limits := client.GetRateLimits()
+	if limits.RequestsRemaining != 300 || limits.RequestsUsed != 200 || len(limits.Keys) != 2 {
+		t.Errorf("expected combined limits from the reporting key, got %+v", limits)
+	}
+}
+
+func TestAPIKeys_EveryKeyExhausted(t *testing.T) {
+	requests := 0
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("x-requests-remaining", "0")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error_code":"OUT_OF_USAGE_CREDITS"}`))
+	})
+	client.SetAPIKey("key_one,key_two")
+
+	_, err := client.FetchParticipants(context.Background(), "basketball_nba")
+	if !errors.Is(err, merrors.ErrQuotaExhausted) {
+		t.Fatalf("expected ErrQuotaExhausted, got %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected one request per key, got %d", requests)
+	}
+}
+
+func TestAPIKeys_CombinedRateLimits(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apiKey") == "key_one" {
+			w.Header().Set("x-requests-remaining", "100")
+			w.Header().Set("x-requests-used", "400")
+		} else {
+			w.Header().Set("x-requests-remaining", "250")
+			w.Header().Set("x-requests-used", "250")
+		}
+		w.Write([]byte(`[]`))
+	})
+	client.SetAPIKey("key_one,key_two")
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.FetchParticipants(context.Background(), "basketball_nba"); err != nil {
+			t.Fatalf("fetch: %v", err)
+		}
+	}
+
+	limits := client.GetRateLimits()
+	if limits.RequestsRemaining != 350 || limits.RequestsUsed != 650 {
+		t.Errorf("expected 350 remaining and 650 used across keys, got %+v", limits)
+	}
+}
+
+func TestFetchOdds_RecordsRequestStats(t *testing.T) {
+	refused := `{"message":"API key is not valid"}`
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apiKey") == "bad_key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(refused))
+			return
+		}
+		w.Header().Set("x-requests-last", "2")
+		w.Write([]byte(oddsFixture))
+	})
+	client.SetAPIKey("bad_key,good_key")
+
+	stats := &contracts.RequestStats{}
+	ctx := contracts.WithRequestStats(context.Background(), stats)
+	if _, err := client.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "basketball_nba"}); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	totals := stats.Totals()
+	want := contracts.RequestTotals{
+		Requests:   2,
+		StatusCode: http.StatusUnauthorized, // The failed status is kept over the later 200
+		Bytes:      int64(len(refused) + len(oddsFixture)),
+		Credits:    2,
+	}
+	if totals != want {
+		t.Errorf("totals = %+v, want %+v", totals, want)
+	}
+}
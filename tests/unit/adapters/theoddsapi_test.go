@@ -2,8 +2,10 @@ package adapters_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/pkg/models"
 )
 
 func TestSupportsMarket(t *testing.T) {
@@ -53,10 +55,62 @@ func TestGetRateLimits(t *testing.T) {
 	}
 }
 
-// TODO: Add HTTP mocking tests for FetchOdds and FetchEvents
-// These require either:
-// 1. Exposing httpClient or baseURL in Client for testing
-// 2. Using dependency injection for HTTP client
-// 3. Creating a testable constructor that accepts custom base URL
-//
-// For now, these methods are tested via integration tests
+func TestSetOddsFormat(t *testing.T) {
+	client := theoddsapi.NewClient("test_key")
+
+	if err := client.SetOddsFormat(models.OddsFormatDecimal); err != nil {
+		t.Errorf("expected decimal to be supported, got %v", err)
+	}
+
+	// The Odds API has no native fractional format
+	if err := client.SetOddsFormat(models.OddsFormatFractional); err == nil {
+		t.Error("expected fractional to be rejected")
+	}
+}
+
+// Every ODDS_FORMAT value that parses must be accepted by the adapter, and the rest
+// rejected by both, so a config that parses never fails at startup
+func TestSetOddsFormat_MatchesParseOddsFormat(t *testing.T) {
+	client := theoddsapi.NewClient("test_key")
+
+	for _, value := range []string{"american", "decimal", "fractional", "moneyline"} {
+		format, parseErr := models.ParseOddsFormat(value)
+		setErr := client.SetOddsFormat(models.OddsFormat(value))
+		if (parseErr == nil) != (setErr == nil) {
+			t.Errorf("%s: ParseOddsFormat error %v but SetOddsFormat error %v", value, parseErr, setErr)
+		}
+		if parseErr == nil && format != models.OddsFormat(value) {
+			t.Errorf("%s: parsed as %q", value, format)
+		}
+	}
+}
+
+func TestParsePayload_UsesArchivedFormatAndTime(t *testing.T) {
+	client := theoddsapi.NewClient("") // Requests american; the payload was archived as decimal
+	receivedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	result, err := client.ParsePayload(models.RawPayload{
+		Kind:       models.PayloadKindOdds,
+		Sport:      "basketball_nba",
+		OddsFormat: models.OddsFormatDecimal,
+		ReceivedAt: receivedAt,
+		Body: []byte(`[{"id":"e1","sport_key":"basketball_nba","commence_time":"2025-01-16T00:00:00Z",
+			"home_team":"Lakers","away_team":"Celtics","bookmakers":[{"key":"fanduel",
+			"last_update":"2025-01-15T11:59:00Z","markets":[{"key":"h2h","outcomes":[
+			{"name":"Lakers","price":2.5},{"name":"Celtics","price":1.6}]}]}]}]`),
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if len(result.Events) != 1 || len(result.Odds) != 2 {
+		t.Fatalf("expected 1 event and 2 odds, got %d and %d", len(result.Events), len(result.Odds))
+	}
+	if odd := result.Odds[0]; odd.Price != 150 || !odd.ReceivedAt.Equal(receivedAt) {
+		t.Errorf("expected +150 received at %v, got %+v", receivedAt, odd)
+	}
+
+	if _, err := client.ParsePayload(models.RawPayload{Kind: models.PayloadKindOdds, Body: []byte(`{bad`)}); err == nil {
+		t.Error("expected an error for a malformed body")
+	}
+}
@@ -0,0 +1,345 @@
+package admin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/admin"
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestListAndGetBooks(t *testing.T) {
+	server := admin.NewServer(":0", nil, books.NewRegistry(books.Defaults))
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d", rec.Code)
+	}
+	var all []books.Book
+	if err := json.NewDecoder(rec.Body).Decode(&all); err != nil || len(all) != len(books.Defaults) {
+		t.Fatalf("expected %d books, got %d (%v)", len(books.Defaults), len(all), err)
+	}
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books/pinnacle", nil))
+	var pinnacle books.Book
+	json.NewDecoder(rec.Body).Decode(&pinnacle)
+	if rec.Code != http.StatusOK || pinnacle.Class != books.ClassSharp {
+		t.Errorf("unexpected pinnacle response %d: %+v", rec.Code, pinnacle)
+	}
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books/nope", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown book, got %d", rec.Code)
+	}
+}
+
+func TestPutBook_RejectsInvalidUpdates(t *testing.T) {
+	registry := books.NewRegistry(books.Defaults)
+	server := admin.NewServer(":0", nil, registry)
+	server.SetToken("s3cret")
+
+	for _, body := range []string{
+		`{"book_type":"vip"}`,
+		`{"default_weight":2}`,
+		`{"regions":[]}`,
+		`{"unknown_field":true}`,
+		`not json`,
+	} {
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, withToken(httptest.NewRequest(http.MethodPut, "/books/fanduel", strings.NewReader(body)), "s3cret"))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, rec.Code)
+		}
+	}
+
+	if c := registry.Class("fanduel"); c != books.ClassSoft {
+		t.Errorf("expected rejected updates to leave the registry alone, got %s", c)
+	}
+}
+
+func TestToken(t *testing.T) {
+	server := admin.NewServer(":0", nil, books.NewRegistry(books.Defaults))
+	server.SetToken("s3cret")
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without a token, got %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/books", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected 200 with the token, got %d", rec.Code)
+	}
+}
+
+func TestNoToken_AnonymousIsReadOnly(t *testing.T) {
+	registry := books.NewRegistry(books.Defaults)
+	server := admin.NewServer(":0", nil, registry)
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/books", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected reads to stay open without tokens, got %d", rec.Code)
+	}
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPut, "/books/fanduel", strings.NewReader(`{"book_type":"sharp"}`)),
+		httptest.NewRequest(http.MethodPost, "/quarantine/7/review", strings.NewReader(`{}`)),
+	} {
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusForbidden {
+			t.Errorf("%s %s: expected 403 without tokens, got %d", req.Method, req.URL.Path, rec.Code)
+		}
+	}
+	if c := registry.Class("fanduel"); c != books.ClassSoft {
+		t.Errorf("expected an anonymous change to be refused, got fanduel as %s", c)
+	}
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/whoami", nil))
+	var cred admin.Credential
+	if err := json.NewDecoder(rec.Body).Decode(&cred); err != nil || cred.Operator != "anonymous" || cred.Role != admin.RoleObserver {
+		t.Errorf("expected an anonymous observer, got %+v (%v)", cred, err)
+	}
+}
+
+// withToken sends a request with a bearer token
+func withToken(req *http.Request, token string) *http.Request {
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestBookUpdate_Apply(t *testing.T) {
+	w := 0.9
+	exchange := books.ClassExchange
+	base := books.Book{Key: "novig", DisplayName: "Novig", Class: books.ClassSoft, Weight: &w, Regions: []string{"us"}, Active: true}
+
+	got := admin.BookUpdate{Class: &exchange, ClearWeight: true}.Apply(base)
+	if got.Class != books.ClassExchange || got.Weight != nil || got.DisplayName != "Novig" || got.Regions[0] != "us" {
+		t.Errorf("unexpected result: %+v", got)
+	}
+
+	muted := true
+	if got := (admin.BookUpdate{Muted: &muted}).Apply(base); !got.Muted || got.Class != books.ClassSoft || !got.Active {
+		t.Errorf("expected only muted to change, got %+v", got)
+	}
+}
+
+func TestParseQuarantineFilter(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	f, err := admin.ParseQuarantineFilter(url.Values{
+		"sport": {"basketball_nba"}, "reason": {"sport_rule"}, "reviewed": {"false"}, "since": {"6h"}, "limit": {"50"},
+	}, now)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if f.SportKey != "basketball_nba" || f.Reason != "sport_rule" || f.Limit != 50 {
+		t.Errorf("unexpected filter %+v", f)
+	}
+	if f.Reviewed == nil || *f.Reviewed {
+		t.Errorf("expected reviewed=false, got %v", f.Reviewed)
+	}
+	if !f.Since.Equal(now.Add(-6 * time.Hour)) {
+		t.Errorf("expected since 6h before now, got %v", f.Since)
+	}
+}
+
+func TestQuarantineEndpoints_RejectBadParameters(t *testing.T) {
+	server := admin.NewServer(":0", nil, books.NewRegistry(books.Defaults))
+	server.SetToken("s3cret")
+
+	for _, target := range []string{
+		"/quarantine?reviewed=maybe",
+		"/quarantine?since=yesterday",
+		"/quarantine?limit=0",
+		"/quarantine?limit=5000",
+		"/quarantine/summary?since=-1h",
+	} {
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, withToken(httptest.NewRequest(http.MethodGet, target, nil), "s3cret"))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, rec.Code)
+		}
+	}
+
+	for _, body := range []string{`{"unknown":1}`, `not json`} {
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, withToken(httptest.NewRequest(http.MethodPost, "/quarantine/7/review", strings.NewReader(body)), "s3cret"))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("review %s: expected 400, got %d", body, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, withToken(httptest.NewRequest(http.MethodPost, "/quarantine/abc/review", nil), "s3cret"))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a non-numeric id, got %d", rec.Code)
+	}
+}
+
+func TestOperatorTokens(t *testing.T) {
+	tokens, err := admin.ParseOperatorTokens("alice:tok1, bob:tok2:observer")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(tokens) != 2 || tokens["tok1"] != (admin.Credential{Operator: "alice", Role: admin.RoleOperator}) ||
+		tokens["tok2"] != (admin.Credential{Operator: "bob", Role: admin.RoleObserver}) {
+		t.Fatalf("unexpected tokens %v", tokens)
+	}
+	for _, bad := range []string{"alice", "alice:", ":tok1", "alice:tok1,bob:tok1", "alice:tok1:root", "alice:tok1:operator:x"} {
+		if _, err := admin.ParseOperatorTokens(bad); err == nil {
+			t.Errorf("%q: expected an error", bad)
+		}
+	}
+
+	server := admin.NewServer(":0", nil, books.NewRegistry(books.Defaults))
+	server.SetOperatorTokens(tokens)
+	tests := []struct {
+		method, target, token string
+		want                  int
+	}{
+		{http.MethodGet, "/books", "", http.StatusUnauthorized},
+		{http.MethodGet, "/books", "nope", http.StatusUnauthorized},
+		{http.MethodGet, "/books", "tok2", http.StatusOK},
+		// Observers are stopped before the handler; operators reach it (and fail validation)
+		{http.MethodPut, "/books/fanduel", "tok2", http.StatusForbidden},
+		{http.MethodPost, "/quarantine/7/review", "tok2", http.StatusForbidden},
+		{http.MethodPut, "/books/fanduel", "tok1", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader("not json"))
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, req)
+		if rec.Code != tt.want {
+			t.Errorf("%s %s with %q: expected %d, got %d", tt.method, tt.target, tt.token, tt.want, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/whoami", nil)
+	req.Header.Set("Authorization", "Bearer tok2")
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, req)
+	var cred admin.Credential
+	if err := json.NewDecoder(rec.Body).Decode(&cred); err != nil || cred.Operator != "bob" || cred.Role != admin.RoleObserver {
+		t.Errorf("unexpected whoami %+v (%v)", cred, err)
+	}
+}
+
+func TestParseChangeFilter(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	f, err := admin.ParseChangeFilter(url.Values{
+		"operator": {"alice"}, "action": {admin.ActionBookUpdate}, "target": {"novig"}, "since": {"24h"}, "limit": {"10"},
+	}, now)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if f.Operator != "alice" || f.Action != admin.ActionBookUpdate || f.Target != "novig" || f.Limit != 10 {
+		t.Errorf("unexpected filter %+v", f)
+	}
+	if !f.Since.Equal(now.Add(-24 * time.Hour)) {
+		t.Errorf("expected since 24h before now, got %v", f.Since)
+	}
+
+	server := admin.NewServer(":0", nil, books.NewRegistry(books.Defaults))
+	for _, target := range []string{"/audit?since=yesterday", "/audit?limit=0", "/audit?limit=5000"} {
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, rec.Code)
+		}
+	}
+}
+
+func TestPropsPollers(t *testing.T) {
+	server := admin.NewServer(":0", nil, books.NewRegistry(books.Defaults))
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/props/pollers", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without a registry, got %d", rec.Code)
+	}
+
+	registry := scheduler.NewPropsRegistry()
+	registry.Register("basketball_nba", models.Event{EventID: "e1", CommenceTime: time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)}, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+	server.SetPropsPollers(registry)
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/props/pollers?sport=basketball_nba", nil))
+	var pollers []scheduler.PropsPoller
+	if err := json.NewDecoder(rec.Body).Decode(&pollers); err != nil || len(pollers) != 1 || pollers[0].EventID != "e1" {
+		t.Errorf("unexpected pollers %+v (%v)", pollers, err)
+	}
+
+	rec = httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/props/pollers/nope", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an event without a poller, got %d", rec.Code)
+	}
+}
+
+func TestParseHistoryQuery(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	q, err := admin.ParseHistoryQuery(url.Values{
+		"event": {"e1"}, "market": {"spreads"}, "book": {"fanduel"}, "outcome": {"Los Angeles Lakers"},
+		"since": {"24h"}, "interval": {"5m"}, "max_points": {"100"}, "limit": {"500"},
+	}, now)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if q.EventID != "e1" || q.MarketKey != "spreads" || q.BookKey != "fanduel" || q.OutcomeName != "Los Angeles Lakers" {
+		t.Errorf("unexpected query %+v", q)
+	}
+	if !q.From.Equal(now.Add(-24*time.Hour)) || !q.To.IsZero() {
+		t.Errorf("expected from 24h before now and no upper bound, got %v to %v", q.From, q.To)
+	}
+	if q.Interval != 5*time.Minute || q.MaxPoints != 100 || q.Limit != 500 {
+		t.Errorf("unexpected down-sampling %+v", q)
+	}
+
+	q, err = admin.ParseHistoryQuery(url.Values{
+		"event": {"e1"}, "market": {"h2h"}, "from": {"2025-01-14T00:00:00Z"}, "to": {"2025-01-15T00:00:00Z"}, "since": {"1h"},
+	}, now)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !q.From.Equal(time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)) || !q.To.Equal(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected from to take precedence over since, got %v to %v", q.From, q.To)
+	}
+
+	server := admin.NewServer(":0", nil, books.NewRegistry(books.Defaults))
+	for _, target := range []string{
+		"/history?market=h2h",
+		"/history?event=e1",
+		"/history?event=e1&market=h2h&from=yesterday",
+		"/history?event=e1&market=h2h&interval=0s",
+		"/history?event=e1&market=h2h&max_points=-1",
+		"/history?event=e1&market=h2h&limit=500000",
+	} {
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, rec.Code)
+		}
+	}
+}
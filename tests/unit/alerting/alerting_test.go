@@ -0,0 +1,272 @@
+package alerting_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/alerting"
+	"github.com/XavierBriggs/Mercury/internal/arb"
+	"github.com/XavierBriggs/Mercury/internal/health"
+	"github.com/XavierBriggs/Mercury/internal/steam"
+)
+
+var now = time.Date(2025, 1, 15, 20, 0, 0, 0, time.UTC)
+
+var thresholds = alerting.Thresholds{
+	QuotaRemaining: 100,
+	StaleAfter:     15 * time.Minute,
+	SLO:            30 * time.Millisecond,
+	StreamLag:      10000,
+}
+
+func keys(alerts []alerting.Alert) map[string]alerting.Alert {
+	byKey := make(map[string]alerting.Alert)
+	for _, alert := range alerts {
+		byKey[alert.Key] = alert
+	}
+	return byKey
+}
+
+func TestCheckHealthy(t *testing.T) {
+	snapshot := &health.Snapshot{
+		Sports: []health.SportHealth{{
+			SportKey:   "basketball_nba",
+			LastPollAt: now.Add(-time.Minute),
+			Stages:     map[string]time.Duration{"fetch": 400 * time.Millisecond, "delta": time.Millisecond, "write": 15 * time.Millisecond},
+		}},
+		Quota: &health.Quota{Remaining: 5000, Used: 100},
+		Lags:  []health.StreamLag{{Stream: "odds.raw.basketball_nba", Group: "edge", Lag: 10}},
+	}
+
+	if alerts := alerting.Check(snapshot, thresholds, now); len(alerts) != 0 {
+		t.Errorf("healthy snapshot should not alert, got %+v", alerts)
+	}
+}
+
+func TestCheckAlerts(t *testing.T) {
+	snapshot := &health.Snapshot{
+		Sports: []health.SportHealth{
+			{
+				SportKey:    "americanfootball_nfl",
+				LastPollAt:  now.Add(-2 * time.Minute),
+				LastErrorAt: now.Add(-time.Minute),
+				LastError:   "vendor returned 500",
+				Errors:      3,
+			},
+			{SportKey: "baseball_mlb", LastPollAt: now.Add(-time.Hour)},
+			{
+				SportKey:   "basketball_nba",
+				LastPollAt: now,
+				Stages:     map[string]time.Duration{"fetch": time.Second, "delta": 5 * time.Millisecond, "write": 40 * time.Millisecond},
+			},
+		},
+		Quota: &health.Quota{Remaining: 20, Used: 19980},
+		Lags:  []health.StreamLag{{Stream: "odds.raw.basketball_nba", Group: "edge", Lag: 25000, Pending: 3}},
+	}
+
+	alerts := keys(alerting.Check(snapshot, thresholds, now))
+	if len(alerts) != 5 {
+		t.Fatalf("expected 5 alerts, got %+v", alerts)
+	}
+
+	if quota := alerts["quota"]; quota.Severity != alerting.SeverityCritical {
+		t.Errorf("20 of 100 remaining should be critical, got %s", quota.Severity)
+	}
+	if errors := alerts["errors:americanfootball_nfl"]; !strings.Contains(errors.Text, "vendor returned 500") {
+		t.Errorf("error alert should carry the last error: %+v", errors)
+	}
+	if _, ok := alerts["stale:americanfootball_nfl"]; ok {
+		t.Error("a failing sport should alert on errors, not staleness")
+	}
+	if stale := alerts["stale:baseball_mlb"]; !strings.Contains(stale.Text, "1h0m0s") {
+		t.Errorf("stale alert should say for how long: %+v", stale)
+	}
+	if slo := alerts["slo:basketball_nba"]; !strings.Contains(slo.Text, "45ms") {
+		t.Errorf("SLO alert should exclude the fetch stage: %+v", slo)
+	}
+	if _, ok := alerts["lag:odds.raw.basketball_nba|edge"]; !ok {
+		t.Error("expected a consumer group lag alert")
+	}
+}
+
+func TestCheckErrorKinds(t *testing.T) {
+	failing := func(sport, kind string) health.SportHealth {
+		return health.SportHealth{
+			SportKey:    sport,
+			LastPollAt:  now.Add(-2 * time.Minute),
+			LastErrorAt: now.Add(-time.Minute),
+			LastError:   "HTTP 429: slow down",
+			LastErrKind: kind,
+			Errors:      1,
+		}
+	}
+	snapshot := &health.Snapshot{Sports: []health.SportHealth{
+		failing("basketball_nba", "rate_limited"),
+		failing("americanfootball_nfl", "storage"),
+	}}
+
+	alerts := keys(alerting.Check(snapshot, thresholds, now))
+	if limited := alerts["errors:basketball_nba"]; limited.Severity != alerting.SeverityWarning || !strings.Contains(limited.Text, "(rate_limited)") {
+		t.Errorf("a rate limit should only warn and name its kind: %+v", limited)
+	}
+	if storage := alerts["errors:americanfootball_nfl"]; storage.Severity != alerting.SeverityCritical || !strings.HasPrefix(storage.Title, "Pipeline errors") {
+		t.Errorf("a storage failure is a critical pipeline error: %+v", storage)
+	}
+}
+
+func TestCheckDisabledThresholds(t *testing.T) {
+	snapshot := &health.Snapshot{
+		Sports: []health.SportHealth{{SportKey: "baseball_mlb", LastPollAt: now.Add(-time.Hour)}},
+		Quota:  &health.Quota{Remaining: 1},
+	}
+
+	if alerts := alerting.Check(snapshot, alerting.Thresholds{}, now); len(alerts) != 0 {
+		t.Errorf("zero thresholds should disable checks, got %+v", alerts)
+	}
+}
+
+func TestCheckRollingSLO(t *testing.T) {
+	sport := health.SportHealth{
+		SportKey:   "basketball_nba",
+		LastPollAt: now.Add(-time.Minute),
+		Stages:     map[string]time.Duration{"delta": time.Millisecond}, // Last poll alone was fine
+		SLO: health.SLOStats{
+			Target:     30 * time.Millisecond,
+			Samples:    500,
+			P50:        12 * time.Millisecond,
+			P95:        41 * time.Millisecond,
+			P99:        70 * time.Millisecond,
+			Violations: 40,
+			Stage:      "write",
+			StageP95:   35 * time.Millisecond,
+		},
+	}
+
+	alert, ok := keys(alerting.Check(&health.Snapshot{Sports: []health.SportHealth{sport}}, thresholds, now))["slo:basketball_nba"]
+	if !ok {
+		t.Fatal("expected an SLO alert from the rolling p95")
+	}
+	if alert.Severity != alerting.SeverityCritical {
+		t.Errorf("p99 over twice the SLO should be critical, got %s", alert.Severity)
+	}
+	if !strings.Contains(alert.Text, "slowest stage write") {
+		t.Errorf("expected stage attribution, got %q", alert.Text)
+	}
+
+	sport.SLO.P95, sport.SLO.P99 = 25*time.Millisecond, 45*time.Millisecond
+	if _, ok := keys(alerting.Check(&health.Snapshot{Sports: []health.SportHealth{sport}}, thresholds, now))["slo:basketball_nba"]; ok {
+		t.Error("rolling p95 within the SLO should not alert")
+	}
+}
+
+func TestCheckStaleBooks(t *testing.T) {
+	snapshot := &health.Snapshot{
+		StaleBooks: []health.StaleBook{{
+			SportKey:   "basketball_nba",
+			BookKey:    "pointsbetus",
+			LastDelta:  now.Add(-25 * time.Minute),
+			NextTipoff: now.Add(40 * time.Minute),
+		}},
+	}
+
+	alert, ok := keys(alerting.Check(snapshot, alerting.Thresholds{}, now))["stale_book:basketball_nba:pointsbetus"]
+	if !ok {
+		t.Fatal("expected a stale book alert")
+	}
+	if alert.Severity != alerting.SeverityWarning {
+		t.Errorf("expected a warning, got %s", alert.Severity)
+	}
+	if !strings.Contains(alert.Text, "25m0s") || !strings.Contains(alert.Text, "40m0s") {
+		t.Errorf("expected silence and time to tipoff in the text, got %q", alert.Text)
+	}
+}
+
+func TestSignalAlert(t *testing.T) {
+	point := 221.5
+	opp, _ := json.Marshal(arb.Opportunity{
+		EventID:   "e1",
+		MarketKey: "totals",
+		ProfitPct: 1.25,
+		Legs: []arb.Leg{
+			{BookKey: "fanduel", OutcomeName: "Over", Point: &point, Price: 110, StakePct: 48.8},
+			{BookKey: "draftkings", OutcomeName: "Under", Point: &point, Price: 105, StakePct: 51.2},
+		},
+	})
+	alert, err := alerting.SignalAlert(arb.StreamKey, opp)
+	if err != nil {
+		t.Fatalf("arb: %v", err)
+	}
+	if alert.Severity != alerting.SeveritySignal || alert.Title != "Arb 1.25% on e1 totals" {
+		t.Errorf("unexpected arb alert: %+v", alert)
+	}
+	if !strings.Contains(alert.Text, "fanduel Over 221.5 +110 (48.8%)") {
+		t.Errorf("arb legs: %s", alert.Text)
+	}
+
+	signal, _ := json.Marshal(steam.Signal{
+		EventID:     "e1",
+		MarketKey:   "h2h",
+		OutcomeName: "Lakers",
+		Direction:   "toward",
+		Books:       []string{"draftkings", "fanduel", "pinnacle"},
+		Window:      "2m0s",
+	})
+	alert, err = alerting.SignalAlert(steam.StreamKey, signal)
+	if err != nil {
+		t.Fatalf("steam: %v", err)
+	}
+	if alert.Title != "Steam toward Lakers on e1 h2h" || !strings.Contains(alert.Text, "3 books in 2m0s") {
+		t.Errorf("unexpected steam alert: %+v", alert)
+	}
+
+	if _, err := alerting.SignalAlert("other", signal); err == nil {
+		t.Error("unknown stream should fail")
+	}
+}
+
+func TestSenders(t *testing.T) {
+	var payload map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload = nil
+		json.NewDecoder(r.Body).Decode(&payload)
+		if r.URL.Path == "/fail" {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	alert := alerting.Alert{Severity: alerting.SeverityWarning, Title: "Stale sport baseball_mlb", Text: "No successful poll for 1h0m0s"}
+
+	if err := alerting.NewSlackSender(server.URL, "mercury-1").Send(context.Background(), alert); err != nil {
+		t.Fatalf("slack: %v", err)
+	}
+	if want := "[mercury-1] ⚠ *Stale sport baseball_mlb*\nNo successful poll for 1h0m0s"; payload["text"] != want {
+		t.Errorf("slack text = %q, want %q", payload["text"], want)
+	}
+
+	if err := alerting.NewDiscordSender(server.URL, "").Send(context.Background(), alerting.Resolved("stale:baseball_mlb", alert.Title)); err != nil {
+		t.Fatalf("discord: %v", err)
+	}
+	if want := "✓ **Resolved: Stale sport baseball_mlb**"; payload["content"] != want {
+		t.Errorf("discord content = %q, want %q", payload["content"], want)
+	}
+
+	long := alert
+	long.Text = strings.Repeat("x", 3000)
+	if err := alerting.NewDiscordSender(server.URL, "").Send(context.Background(), long); err != nil {
+		t.Fatalf("discord: %v", err)
+	}
+	if n := len([]rune(payload["content"])); n != 2000 {
+		t.Errorf("discord content should be truncated to 2000 runes, got %d", n)
+	}
+
+	err := alerting.NewSlackSender(server.URL+"/fail", "").Send(context.Background(), alert)
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("expected a 403 error, got %v", err)
+	}
+}
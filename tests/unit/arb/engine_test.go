@@ -0,0 +1,97 @@
+package arb_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/arb"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func h2h(book, outcome string, price int) models.RawOdds {
+	return models.RawOdds{EventID: "e1", SportKey: "soccer_epl", MarketKey: "h2h", BookKey: book, OutcomeName: outcome, Price: price}
+}
+
+func TestEngine_TwoWayArbAcrossBooks(t *testing.T) {
+	engine := arb.NewEngine(nil, nil, 0.5)
+
+	// +110 at fanduel and +110 at draftkings on opposite sides => implied 0.952, +5% profit
+	opportunities := engine.Detect([]models.RawOdds{
+		h2h("fanduel", "Lakers", 110),
+		h2h("fanduel", "Celtics", -130),
+		h2h("draftkings", "Lakers", -130),
+		h2h("draftkings", "Celtics", 110),
+	}, time.Now())
+
+	if len(opportunities) != 1 {
+		t.Fatalf("expected 1 arb, got %d", len(opportunities))
+	}
+	got := opportunities[0]
+	if len(got.Legs) != 2 || math.Abs(got.ProfitPct-5.0) > 0.01 {
+		t.Errorf("unexpected arb: %+v", got)
+	}
+	for _, leg := range got.Legs {
+		if leg.Price != 110 || math.Abs(leg.StakePct-50) > 0.01 {
+			t.Errorf("unexpected leg: %+v", leg)
+		}
+	}
+
+	// Unchanged on re-detect
+	if again := engine.Detect([]models.RawOdds{h2h("betmgm", "Lakers", -150)}, time.Now()); len(again) != 0 {
+		t.Errorf("expected no re-emit, got %+v", again)
+	}
+}
+
+func TestEngine_ThreeWayArb(t *testing.T) {
+	engine := arb.NewEngine(nil, nil, 0.5)
+
+	opportunities := engine.Detect([]models.RawOdds{
+		h2h("bet365", "Arsenal", 210), h2h("bet365", "Draw", 220), h2h("bet365", "Chelsea", 250),
+		h2h("unibet", "Arsenal", 180), h2h("unibet", "Draw", 300), h2h("unibet", "Chelsea", 240),
+		h2h("betfair", "Arsenal", 170), h2h("betfair", "Draw", 230), h2h("betfair", "Chelsea", 280),
+	}, time.Now())
+
+	// Best: Arsenal 3.10, Draw 4.00, Chelsea 3.80 => implied 0.9404
+	if len(opportunities) != 1 || len(opportunities[0].Legs) != 3 {
+		t.Fatalf("expected one 3-way arb, got %+v", opportunities)
+	}
+	if opportunities[0].ImpliedSum >= 1 {
+		t.Errorf("expected implied sum below 1, got %v", opportunities[0].ImpliedSum)
+	}
+}
+
+func TestEngine_SpreadSidesMustBeComplementary(t *testing.T) {
+	engine := arb.NewEngine(nil, nil, 0.5)
+	minus, plus := -1.5, 1.5
+
+	spread := func(book, outcome string, price int, point *float64) models.RawOdds {
+		odd := h2h(book, outcome, price)
+		odd.MarketKey = "spreads"
+		odd.Point = point
+		return odd
+	}
+
+	// Lakers -1.5 and Celtics -1.5 are not complementary; no arb despite high prices
+	opportunities := engine.Detect([]models.RawOdds{
+		spread("fanduel", "Lakers", 120, &minus), spread("fanduel", "Celtics", -150, &plus),
+		spread("draftkings", "Lakers", -150, &plus), spread("draftkings", "Celtics", 120, &minus),
+	}, time.Now())
+
+	if len(opportunities) != 0 {
+		t.Errorf("expected no arb across non-complementary sides, got %+v", opportunities)
+	}
+}
+
+func TestEngine_IgnoresSingleBookArb(t *testing.T) {
+	engine := arb.NewEngine(nil, nil, 0.5)
+
+	opportunities := engine.Detect([]models.RawOdds{
+		h2h("fanduel", "Lakers", 110),
+		h2h("fanduel", "Celtics", 110),
+	}, time.Now())
+
+	if len(opportunities) != 0 {
+		t.Errorf("expected single-book pricing error to be ignored, got %+v", opportunities)
+	}
+}
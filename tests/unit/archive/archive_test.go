@@ -0,0 +1,182 @@
+package archive_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/archive"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func payload(kind models.PayloadKind, eventID string, at time.Time) models.RawPayload {
+	return models.RawPayload{
+		Vendor:     "theoddsapi",
+		Kind:       kind,
+		Sport:      "basketball_nba",
+		EventID:    eventID,
+		OddsFormat: models.OddsFormatDecimal,
+		ReceivedAt: at,
+		Body:       []byte(`[{"id":"e1"}]`),
+	}
+}
+
+func TestKey_PartitionedBySportAndDate(t *testing.T) {
+	at := time.Date(2025, 1, 15, 23, 59, 1, 5, time.UTC)
+
+	key := archive.Key(payload(models.PayloadKindEventOdds, "e1", at))
+	want := "theoddsapi/basketball_nba/2025-01-15/20250115T235901.000000005Z.event-odds.e1.json.gz"
+	if key != want {
+		t.Fatalf("key = %q, want %q", key, want)
+	}
+
+	got, err := archive.KeyTime(key)
+	if err != nil || !got.Equal(at) {
+		t.Errorf("KeyTime = %v, %v; want %v", got, err, at)
+	}
+}
+
+func TestEncode_RoundTripsAndStaysPlainGzip(t *testing.T) {
+	original := payload(models.PayloadKindOdds, "", time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+
+	data, err := archive.Encode(original)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	// zcat yields the vendor body unchanged
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	body, _ := io.ReadAll(zr)
+	if !bytes.Equal(body, original.Body) {
+		t.Errorf("gunzipped body = %s", body)
+	}
+
+	decoded, err := archive.Decode(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.Kind != original.Kind || decoded.Sport != original.Sport || decoded.OddsFormat != original.OddsFormat ||
+		!decoded.ReceivedAt.Equal(original.ReceivedAt) || !bytes.Equal(decoded.Body, original.Body) {
+		t.Errorf("round trip mismatch: %+v", decoded)
+	}
+}
+
+func TestArchiver_WritesAndListsRange(t *testing.T) {
+	store, err := archive.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+
+	ctx := context.Background()
+	a := archive.NewArchiver(store)
+	a.Start(ctx)
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	a.Archive(payload(models.PayloadKindOdds, "", day.Add(-time.Minute))) // Previous day
+	a.Archive(payload(models.PayloadKindOdds, "", day.Add(time.Hour)))
+	a.Archive(payload(models.PayloadKindEventOdds, "e1", day.Add(2*time.Hour)))
+	a.Archive(payload(models.PayloadKindOdds, "", day.Add(25*time.Hour))) // Next day
+	a.Stop()
+
+	keys, err := archive.ListRange(ctx, store, "theoddsapi", "basketball_nba", day, day.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 keys in range, got %v", keys)
+	}
+
+	got, err := archive.Read(ctx, store, keys[1])
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if got.Kind != models.PayloadKindEventOdds || got.EventID != "e1" {
+		t.Errorf("expected the event-odds payload second, got %+v", got)
+	}
+
+	if _, err := store.Get(ctx, "theoddsapi/missing.json.gz"); !errors.Is(err, archive.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestOpen_RejectsUnknownScheme(t *testing.T) {
+	if _, err := archive.Open("ftp://bucket/prefix", archive.S3Config{}); err == nil {
+		t.Error("expected an error for an unsupported scheme")
+	}
+	if _, err := archive.Open("s3://bucket/prefix", archive.S3Config{}); err == nil {
+		t.Error("expected an error without credentials")
+	}
+}
+
+func TestS3Store_SignsPathStyleRequests(t *testing.T) {
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || r.Header.Get("X-Amz-Content-Sha256") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = body
+		case r.URL.Query().Get("list-type") == "2":
+			prefix := r.URL.Query().Get("prefix")
+			io.WriteString(w, "<ListBucketResult>")
+			for path := range objects {
+				if key := strings.TrimPrefix(path, "/bucket/"); strings.HasPrefix(key, prefix) {
+					io.WriteString(w, "<Contents><Key>"+key+"</Key></Contents>")
+				}
+			}
+			io.WriteString(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+		default:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	store, err := archive.Open("s3://bucket/raw", archive.S3Config{
+		Endpoint:        server.URL,
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+	})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := store.Put(ctx, "theoddsapi/basketball_nba/2025-01-15/a.json.gz", []byte("data")); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if _, ok := objects["/bucket/raw/theoddsapi/basketball_nba/2025-01-15/a.json.gz"]; !ok {
+		t.Fatalf("expected the object under the URL prefix, have %v", objects)
+	}
+
+	keys, err := store.List(ctx, "theoddsapi/basketball_nba/")
+	if err != nil || len(keys) != 1 || keys[0] != "theoddsapi/basketball_nba/2025-01-15/a.json.gz" {
+		t.Fatalf("list = %v, %v", keys, err)
+	}
+
+	if data, err := store.Get(ctx, keys[0]); err != nil || string(data) != "data" {
+		t.Errorf("get = %q, %v", data, err)
+	}
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, archive.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package bench_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bench"
+	"github.com/XavierBriggs/Mercury/internal/delta"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+type countingWriter struct {
+	calls int
+	odds  int
+}
+
+func (w *countingWriter) WriteWithEvents(_ context.Context, _ []models.Event, odds []models.RawOdds) error {
+	w.calls++
+	w.odds += len(odds)
+	return nil
+}
+
+func TestGenerator_SlateSize(t *testing.T) {
+	gen := bench.NewGenerator("basketball_nba", 4, 3, 5, 0.1, 1)
+
+	if gen.Size() != 4*3*5*2 {
+		t.Errorf("expected %d outcomes, got %d", 4*3*5*2, gen.Size())
+	}
+	if len(gen.Events()) != 4 {
+		t.Errorf("expected 4 events, got %d", len(gen.Events()))
+	}
+
+	markets := map[string]bool{}
+	for _, odd := range gen.Next(time.Now()) {
+		markets[odd.MarketKey] = true
+		if odd.DecimalPrice <= 1 || odd.Price == 0 {
+			t.Fatalf("invalid synthetic price: %+v", odd)
+		}
+		if (odd.MarketKey == "spreads" || odd.MarketKey == "totals") && odd.Point == nil {
+			t.Fatalf("expected a line on %s", odd.MarketKey)
+		}
+	}
+	if len(markets) != 5 {
+		t.Errorf("expected 5 distinct markets, got %v", markets)
+	}
+}
+
+func TestGenerator_SeedIsDeterministic(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	a := bench.NewGenerator("basketball_nba", 2, 2, 3, 0.5, 42)
+	b := bench.NewGenerator("basketball_nba", 2, 2, 3, 0.5, 42)
+
+	for i := 0; i < 3; i++ {
+		sa, sb := a.Next(now), b.Next(now)
+		for j := range sa {
+			if sa[j].Price != sb[j].Price || !reflect.DeepEqual(sa[j].Point, sb[j].Point) {
+				t.Fatalf("snapshot %d outcome %d differs: %+v vs %+v", i, j, sa[j], sb[j])
+			}
+		}
+	}
+}
+
+func TestGenerator_MovesSkipTheAmericanGap(t *testing.T) {
+	gen := bench.NewGenerator("basketball_nba", 1, 1, 1, 1, 7)
+	for i := 0; i < 200; i++ {
+		for _, odd := range gen.Next(time.Now()) {
+			if odd.Price > -100 && odd.Price < 100 {
+				t.Fatalf("price %d inside (-100, 100)", odd.Price)
+			}
+		}
+	}
+}
+
+func TestRunner_ChurnDrivesDeltasAndWrites(t *testing.T) {
+	tests := []struct {
+		name   string
+		churn  float64
+		deltas func(size int) int
+	}{
+		{"no churn", 0, func(int) int { return 0 }},
+		{"full churn", 1, func(size int) int { return 3 * size }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := bench.NewGenerator("basketball_nba", 3, 4, 3, tt.churn, 1)
+			engine := delta.NewEngineWithCache(delta.NewMemoryCache(), time.Minute)
+			writer := &countingWriter{}
+
+			report := bench.NewRunner(gen, engine, writer).Run(context.Background(), bench.Config{Batches: 3})
+
+			if report.Batches != 3 || report.Odds != 3*gen.Size() {
+				t.Fatalf("expected 3 batches of %d odds, got %d batches, %d odds", gen.Size(), report.Batches, report.Odds)
+			}
+			if want := tt.deltas(gen.Size()); report.Deltas != want {
+				t.Errorf("expected %d deltas, got %d", want, report.Deltas)
+			}
+			// The seed snapshot writes every outcome once before measuring
+			if writer.odds != gen.Size()+report.Deltas {
+				t.Errorf("expected %d odds written, got %d", gen.Size()+report.Deltas, writer.odds)
+			}
+			for _, stage := range []string{"detect", "write", "cache", "total"} {
+				if _, ok := report.Stages[stage]; !ok {
+					t.Errorf("expected %s latencies", stage)
+				}
+			}
+		})
+	}
+}
+
+func TestRunner_WithoutWriterSkipsWriteStage(t *testing.T) {
+	gen := bench.NewGenerator("basketball_nba", 2, 2, 2, 0.5, 1)
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), time.Minute)
+
+	report := bench.NewRunner(gen, engine, nil).Run(context.Background(), bench.Config{Batches: 2})
+
+	if _, ok := report.Stages["write"]; ok {
+		t.Error("expected no write stage without a writer")
+	}
+	if report.Errors != 0 {
+		t.Errorf("expected no errors, got %d", report.Errors)
+	}
+}
+
+func TestRunner_RespectsRateAndDuration(t *testing.T) {
+	gen := bench.NewGenerator("basketball_nba", 1, 1, 1, 0.5, 1)
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), time.Minute)
+
+	report := bench.NewRunner(gen, engine, nil).Run(context.Background(), bench.Config{Rate: 50, Duration: 200 * time.Millisecond})
+
+	// 50/s for 200ms is ~10 snapshots; allow for scheduler jitter
+	if report.Batches < 5 || report.Batches > 12 {
+		t.Errorf("expected about 10 snapshots, got %d", report.Batches)
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	durations := make([]time.Duration, 100)
+	for i := range durations {
+		durations[len(durations)-1-i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	l := bench.Distribution(durations)
+	if l.P50 != 50*time.Millisecond || l.P90 != 90*time.Millisecond || l.P99 != 99*time.Millisecond || l.Max != 100*time.Millisecond {
+		t.Errorf("unexpected distribution: %+v", l)
+	}
+	if (bench.Distribution(nil) != bench.Latency{}) {
+		t.Error("expected zero latency for no samples")
+	}
+}
+
+func TestReport_MeetsSLO(t *testing.T) {
+	report := bench.Report{SLO: bench.SLO, Stages: map[string]bench.Latency{"total": {P99: 29 * time.Millisecond}}}
+	if !report.MeetsSLO() {
+		t.Error("expected 29ms p99 to meet the 30ms SLO")
+	}
+
+	report.Stages["total"] = bench.Latency{P99: 31 * time.Millisecond}
+	if report.MeetsSLO() {
+		t.Error("expected 31ms p99 to miss the 30ms SLO")
+	}
+}
@@ -0,0 +1,136 @@
+package bestline_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/bestline"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func quote(book, outcome string, price int) models.RawOdds {
+	return models.RawOdds{
+		EventID:     "e1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "h2h",
+		BookKey:     book,
+		OutcomeName: outcome,
+		Price:       price,
+	}
+}
+
+func TestApply_PicksBestPricePerOutcome(t *testing.T) {
+	c := bestline.NewCache(nil, nil)
+
+	best := c.Apply([]models.RawOdds{
+		quote("fanduel", "Lakers", -110),
+		quote("draftkings", "Lakers", -105),
+		quote("betmgm", "Lakers", -105),
+		quote("fanduel", "Celtics", -105),
+	})
+	if len(best) != 2 {
+		t.Fatalf("expected 2 best lines, got %d", len(best))
+	}
+
+	lakers := best[0]
+	if lakers.BookKey != "betmgm" || lakers.Price != -105 || lakers.BookCount != 3 {
+		t.Errorf("unexpected Lakers best line: %+v", lakers)
+	}
+	if !reflect.DeepEqual(lakers.Books, []string{"betmgm", "draftkings"}) {
+		t.Errorf("expected tied books betmgm, draftkings; got %v", lakers.Books)
+	}
+}
+
+func TestApply_FallsBackWhenBestBookWorsens(t *testing.T) {
+	c := bestline.NewCache(nil, nil)
+
+	c.Apply([]models.RawOdds{
+		quote("fanduel", "Lakers", 120),
+		quote("draftkings", "Lakers", 110),
+	})
+
+	best := c.Apply([]models.RawOdds{quote("fanduel", "Lakers", 100)})
+	if len(best) != 1 || best[0].BookKey != "draftkings" || best[0].Price != 110 {
+		t.Fatalf("expected draftkings +110 to become best, got %+v", best)
+	}
+}
+
+func TestApply_SpreadSidesAreSeparate(t *testing.T) {
+	c := bestline.NewCache(nil, nil)
+
+	spread := func(book, outcome string, point float64, price int) models.RawOdds {
+		odd := quote(book, outcome, price)
+		odd.MarketKey = "spreads"
+		odd.Point = &point
+		return odd
+	}
+
+	best := c.Apply([]models.RawOdds{
+		spread("fanduel", "Lakers", -3.5, -110),
+		spread("fanduel", "Celtics", 3.5, -110),
+		spread("draftkings", "Lakers", -3.5, -105),
+		spread("draftkings", "Celtics", 3.5, -115),
+	})
+
+	fields := make(map[string]string)
+	for _, bp := range best {
+		fields[bestline.Field(bp.MarketKey, bp.Description, bp.OutcomeName, bp.Point)] = bp.BookKey
+	}
+	if fields["spreads||Lakers|-3.5"] != "draftkings" || fields["spreads||Celtics|3.5"] != "fanduel" {
+		t.Errorf("unexpected spread best lines: %v", fields)
+	}
+}
+
+type staleBooks map[string]bool
+
+func (s staleBooks) IsStale(sportKey, bookKey string) bool {
+	return s[sportKey+"|"+bookKey]
+}
+
+func TestApply_SkipsStaleBooks(t *testing.T) {
+	c := bestline.NewCache(nil, nil)
+	c.SetStaleBooks(staleBooks{"basketball_nba|fanduel": true})
+
+	best := c.Apply([]models.RawOdds{
+		quote("fanduel", "Lakers", 150),
+		quote("draftkings", "Lakers", 120),
+		quote("fanduel", "Celtics", -150),
+	})
+	if len(best) != 2 {
+		t.Fatalf("expected 2 best lines, got %d", len(best))
+	}
+
+	lakers := best[0]
+	if lakers.BookKey != "draftkings" || lakers.Stale || lakers.BookCount != 2 {
+		t.Errorf("expected fresh draftkings to be best, got %+v", lakers)
+	}
+	if !reflect.DeepEqual(lakers.StaleBooks, []string{"fanduel"}) {
+		t.Errorf("expected fanduel listed as stale, got %v", lakers.StaleBooks)
+	}
+
+	celtics := best[1]
+	if celtics.BookKey != "fanduel" || !celtics.Stale {
+		t.Errorf("expected the only quote to be kept and flagged stale, got %+v", celtics)
+	}
+}
+
+func TestRecompute_RestoresFreshBook(t *testing.T) {
+	c := bestline.NewCache(nil, nil)
+	stale := staleBooks{"basketball_nba|fanduel": true}
+	c.SetStaleBooks(stale)
+
+	c.Apply([]models.RawOdds{
+		quote("fanduel", "Lakers", 150),
+		quote("draftkings", "Lakers", 120),
+	})
+
+	delete(stale, "basketball_nba|fanduel")
+	best := c.Recompute("basketball_nba", []string{"fanduel"})
+	if len(best) != 1 || best[0].BookKey != "fanduel" || len(best[0].StaleBooks) != 0 {
+		t.Fatalf("expected fanduel to be best again, got %+v", best)
+	}
+
+	if best := c.Recompute("americanfootball_nfl", []string{"fanduel"}); len(best) != 0 {
+		t.Errorf("expected no lines for another sport, got %+v", best)
+	}
+}
@@ -0,0 +1,131 @@
+package books_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+)
+
+func TestDefaults_AreValid(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, b := range books.Defaults {
+		if err := b.Validate(); err != nil {
+			t.Errorf("default %s: %v", b.Key, err)
+		}
+		if seen[b.Key] {
+			t.Errorf("duplicate default %s", b.Key)
+		}
+		seen[b.Key] = true
+	}
+}
+
+func TestRegistry_KeysOrderedByWeight(t *testing.T) {
+	registry := books.NewRegistry(books.Defaults)
+
+	want := []string{"pinnacle", "circa", "bookmaker"}
+	if got := registry.Keys(books.ClassSharp); !reflect.DeepEqual(got, want) {
+		t.Errorf("sharp keys = %v, want %v", got, want)
+	}
+
+	// Inactive books are not offered as references
+	pinnacle, _ := registry.Get("pinnacle")
+	pinnacle.Active = false
+	if err := registry.Set(pinnacle); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if got := registry.Keys(books.ClassSharp); got[0] != "circa" {
+		t.Errorf("expected inactive pinnacle to be skipped, got %v", got)
+	}
+}
+
+func TestRegistry_LookupUnknownBook(t *testing.T) {
+	registry := books.NewRegistry(nil)
+
+	b := registry.Lookup("newbook")
+	if b.Class != books.ClassSoft || b.DisplayName != "Newbook" || !reflect.DeepEqual(b.Regions, []string{"us"}) || !b.Active {
+		t.Errorf("unexpected fallback: %+v", b)
+	}
+	if w := registry.Weight("newbook"); w != 0.5 {
+		t.Errorf("expected soft default weight, got %v", w)
+	}
+
+	var nilRegistry *books.Registry
+	if c := nilRegistry.Class("pinnacle"); c != books.ClassSoft {
+		t.Errorf("expected a nil registry to classify everything soft, got %s", c)
+	}
+}
+
+func TestRegistry_ApplyOverrides(t *testing.T) {
+	registry := books.NewRegistry(books.Defaults)
+
+	if err := registry.ApplyOverrides("circa=soft, fanduel=sharp:0.7 ,sporttrade=exchange"); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if c := registry.Class("circa"); c != books.ClassSoft {
+		t.Errorf("circa class = %s", c)
+	}
+	if w := registry.Weight("circa"); w != 0.5 {
+		t.Errorf("expected reclassified circa to take the soft default weight, got %v", w)
+	}
+	if w := registry.Weight("fanduel"); w != 0.7 {
+		t.Errorf("fanduel weight = %v", w)
+	}
+	if b, ok := registry.Get("sporttrade"); !ok || b.Class != books.ClassExchange || b.ConsensusWeight() != 0.8 {
+		t.Errorf("expected new exchange book, got %+v (found %t)", b, ok)
+	}
+}
+
+func TestRegistry_ApplyOverridesRejectsInvalid(t *testing.T) {
+	for _, spec := range []string{"pinnacle", "pinnacle=vip", "pinnacle=sharp:abc", "pinnacle=sharp:1.5"} {
+		if err := books.NewRegistry(books.Defaults).ApplyOverrides(spec); err == nil {
+			t.Errorf("expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestRegistry_GetReturnsCopy(t *testing.T) {
+	registry := books.NewRegistry(books.Defaults)
+
+	b, _ := registry.Get("fanduel")
+	b.Regions[0] = "eu"
+
+	if again, _ := registry.Get("fanduel"); again.Regions[0] != "us" {
+		t.Errorf("expected registry state to be unaffected, got %v", again.Regions)
+	}
+}
+
+func TestRegistry_Muting(t *testing.T) {
+	registry := books.NewRegistry(books.Defaults)
+
+	pinnacle, _ := registry.Get("pinnacle")
+	pinnacle.Muted = true
+	if err := registry.Set(pinnacle); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	registry.MuteFromConfig([]string{" Bovada ", ""})
+
+	if !registry.Muted("pinnacle") || !registry.Muted("bovada") || registry.Muted("fanduel") {
+		t.Errorf("unexpected mutes: %v", registry.MutedKeys())
+	}
+	if got, want := registry.MutedKeys(), []string{"bovada", "pinnacle"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MutedKeys() = %v, want %v", got, want)
+	}
+	if got := registry.Keys(books.ClassSharp); got[0] != "circa" {
+		t.Errorf("expected muted pinnacle to leave the sharp list, got %v", got)
+	}
+
+	if got, want := registry.Unmuted([]string{"fanduel", "pinnacle", "draftkings"}), []string{"fanduel", "draftkings"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmuted() = %v, want %v", got, want)
+	}
+	// Never turn a bookmakers request into a whole-region one
+	if got, want := registry.Unmuted([]string{"pinnacle", "bovada"}), []string{"pinnacle", "bovada"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected an all-muted list to be kept, got %v", got)
+	}
+
+	var nilRegistry *books.Registry
+	if nilRegistry.Muted("pinnacle") || len(nilRegistry.Unmuted([]string{"pinnacle"})) != 1 {
+		t.Error("a nil registry should mute nothing")
+	}
+}
@@ -0,0 +1,79 @@
+package bookskip_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bookskip"
+)
+
+var (
+	now     = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	updated = time.Date(2025, 1, 15, 11, 59, 0, 0, time.UTC)
+)
+
+func TestKeyIgnoresMarketOrder(t *testing.T) {
+	a := bookskip.Key("e1", "fanduel", []string{"totals", "h2h", "spreads"})
+	b := bookskip.Key("e1", "fanduel", []string{"h2h", "spreads", "totals"})
+	if a != b {
+		t.Errorf("keys differ: %q vs %q", a, b)
+	}
+	if a == bookskip.Key("e1", "fanduel", []string{"h2h", "spreads"}) {
+		t.Error("different market sets share a key")
+	}
+}
+
+func TestUnchangedOnlyAfterCommit(t *testing.T) {
+	cache := bookskip.NewCache(10 * time.Minute)
+	key := bookskip.Key("e1", "fanduel", []string{"h2h"})
+
+	if cache.Unchanged(key, updated, now) {
+		t.Fatal("unseen bookmaker reported unchanged")
+	}
+	// Parsed but never committed (e.g. the write failed): still parsed next time
+	if cache.Unchanged(key, updated, now.Add(time.Minute)) {
+		t.Fatal("uncommitted bookmaker reported unchanged")
+	}
+
+	cache.Commit(map[string]time.Time{key: updated}, now)
+	if !cache.Unchanged(key, updated, now.Add(time.Minute)) {
+		t.Error("same last_update should be skipped")
+	}
+	if cache.Unchanged(key, updated.Add(time.Second), now.Add(time.Minute)) {
+		t.Error("advanced last_update should be parsed")
+	}
+	if cache.Unchanged(key, time.Time{}, now.Add(time.Minute)) {
+		t.Error("missing last_update should be parsed")
+	}
+
+	skipped, parsed := cache.Stats()
+	if skipped != 1 || parsed != 4 {
+		t.Errorf("stats = %d skipped, %d parsed; want 1, 4", skipped, parsed)
+	}
+}
+
+func TestMaxAgeForcesReparse(t *testing.T) {
+	cache := bookskip.NewCache(5 * time.Minute)
+	key := bookskip.Key("e1", "fanduel", []string{"h2h"})
+	cache.Commit(map[string]time.Time{key: updated}, now)
+
+	if !cache.Unchanged(key, updated, now.Add(4*time.Minute)) {
+		t.Error("expected a skip within the max age")
+	}
+	if cache.Unchanged(key, updated, now.Add(5*time.Minute)) {
+		t.Error("expected a re-parse at the max age")
+	}
+}
+
+func TestCommitPrunesExpiredEntries(t *testing.T) {
+	cache := bookskip.NewCache(5 * time.Minute)
+	old := bookskip.Key("e1", "fanduel", []string{"h2h"})
+	cache.Commit(map[string]time.Time{old: updated}, now)
+
+	fresh := bookskip.Key("e2", "fanduel", []string{"h2h"})
+	cache.Commit(map[string]time.Time{fresh: updated}, now.Add(6*time.Minute))
+
+	if cache.Len() != 1 {
+		t.Errorf("len = %d, want 1 (expired entry pruned)", cache.Len())
+	}
+}
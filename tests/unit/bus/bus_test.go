@@ -0,0 +1,76 @@
+package bus_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestBus_DeliversToTypedSubscribers(t *testing.T) {
+	b := bus.New()
+
+	var mu sync.Mutex
+	var discovered []string
+	var statuses []string
+
+	b.SubscribeEventDiscovered("test-discovered", func(ctx context.Context, msg bus.EventDiscovered) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, evt := range msg.Events {
+			discovered = append(discovered, evt.EventID)
+		}
+	})
+	b.SubscribeEventStatusChanged("test-status", func(ctx context.Context, msg bus.EventStatusChanged) {
+		mu.Lock()
+		defer mu.Unlock()
+		statuses = append(statuses, msg.EventID+":"+msg.NewStatus)
+	})
+
+	b.Start(context.Background())
+
+	b.PublishEventDiscovered(bus.EventDiscovered{Events: []models.Event{{EventID: "e1"}, {EventID: "e2"}}})
+	b.PublishEventStatusChanged(bus.EventStatusChanged{EventID: "e1", OldStatus: "upcoming", NewStatus: "live"})
+
+	// Stop drains queued messages
+	b.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(discovered) != 2 {
+		t.Errorf("expected 2 discovered events, got %v", discovered)
+	}
+	if len(statuses) != 1 || statuses[0] != "e1:live" {
+		t.Errorf("expected [e1:live], got %v", statuses)
+	}
+}
+
+func TestBus_PanickingSubscriberDoesNotStopDelivery(t *testing.T) {
+	b := bus.New()
+
+	var mu sync.Mutex
+	count := 0
+
+	b.SubscribeDeltaBatchCommitted("panics", func(ctx context.Context, msg bus.DeltaBatchCommitted) {
+		panic("boom")
+	})
+	b.SubscribeDeltaBatchCommitted("counts", func(ctx context.Context, msg bus.DeltaBatchCommitted) {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+	})
+
+	b.Start(context.Background())
+	b.PublishDeltaBatchCommitted(bus.DeltaBatchCommitted{})
+	b.PublishDeltaBatchCommitted(bus.DeltaBatchCommitted{})
+	b.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if count != 2 {
+		t.Errorf("expected 2 deliveries, got %d", count)
+	}
+}
@@ -0,0 +1,113 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+)
+
+var start = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+// received returns the pending tick, if any, without blocking
+func received(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFake_TickerFiresEveryPeriod(t *testing.T) {
+	fake := clock.NewFake(start)
+	ticker := fake.NewTicker(time.Minute)
+
+	fake.Advance(59 * time.Second)
+	if _, ok := received(ticker.C()); ok {
+		t.Fatal("expected no tick before the period")
+	}
+
+	fake.Advance(time.Second)
+	if tick, ok := received(ticker.C()); !ok || !tick.Equal(start.Add(time.Minute)) {
+		t.Fatalf("expected a tick at the period, got %v (%v)", tick, ok)
+	}
+
+	// Unreceived ticks are dropped, like time.Ticker
+	fake.Advance(3 * time.Minute)
+	if tick, ok := received(ticker.C()); !ok || !tick.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("expected the first missed tick only, got %v (%v)", tick, ok)
+	}
+	if _, ok := received(ticker.C()); ok {
+		t.Error("expected later ticks dropped")
+	}
+	if !fake.Now().Equal(start.Add(4 * time.Minute)) {
+		t.Errorf("expected the clock at the advance target, got %v", fake.Now())
+	}
+}
+
+func TestFake_TickerReset(t *testing.T) {
+	fake := clock.NewFake(start)
+	ticker := fake.NewTicker(time.Minute)
+
+	fake.Advance(30 * time.Second)
+	ticker.Reset(2 * time.Minute)
+	fake.Advance(time.Minute)
+	if _, ok := received(ticker.C()); ok {
+		t.Fatal("expected the reset period to restart from the reset")
+	}
+	fake.Advance(time.Minute)
+	if tick, ok := received(ticker.C()); !ok || !tick.Equal(start.Add(150*time.Second)) {
+		t.Errorf("expected a tick two minutes after the reset, got %v (%v)", tick, ok)
+	}
+
+	ticker.Stop()
+	fake.Advance(time.Hour)
+	if _, ok := received(ticker.C()); ok || fake.Waiters() != 0 {
+		t.Error("expected a stopped ticker never to fire")
+	}
+}
+
+func TestFake_TimerFiresOnce(t *testing.T) {
+	fake := clock.NewFake(start)
+	timer := fake.NewTimer(10 * time.Second)
+	stopped := fake.NewTimer(10 * time.Second)
+	if !stopped.Stop() {
+		t.Error("expected Stop to report a pending timer")
+	}
+
+	fake.Advance(time.Minute)
+	if _, ok := received(timer.C()); !ok {
+		t.Fatal("expected the timer to fire")
+	}
+	if _, ok := received(stopped.C()); ok {
+		t.Error("expected the stopped timer not to fire")
+	}
+	if timer.Stop() {
+		t.Error("expected Stop to report a fired timer as not pending")
+	}
+	if fake.Waiters() != 0 {
+		t.Errorf("expected no pending waiters, got %d", fake.Waiters())
+	}
+}
+
+func TestFake_BlockUntilWaitsForWaiters(t *testing.T) {
+	fake := clock.NewFake(start)
+	fired := make(chan time.Time, 1)
+
+	go func() {
+		timer := fake.NewTimer(time.Minute)
+		fired <- <-timer.C()
+	}()
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	select {
+	case tick := <-fired:
+		if !tick.Equal(start.Add(time.Minute)) {
+			t.Errorf("expected the tick at the deadline, got %v", tick)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timer never fired")
+	}
+}
@@ -0,0 +1,231 @@
+package closer_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/closer"
+	"github.com/XavierBriggs/Mercury/internal/talos"
+	"github.com/XavierBriggs/Mercury/pkg/testutil"
+)
+
+const openPagesKey = "talos:pages:open"
+
+// fakeStatuses is a scriptable closer.EventStatusSource
+type fakeStatuses struct {
+	mu       sync.Mutex
+	statuses map[string]string
+	err      error
+	calls    int
+}
+
+func (f *fakeStatuses) EventStatuses(ctx context.Context, eventIDs []string) (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	out := make(map[string]string)
+	for _, id := range eventIDs {
+		if status, ok := f.statuses[id]; ok {
+			out[id] = status
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStatuses) set(eventID, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses[eventID] = status
+}
+
+func (f *fakeStatuses) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// talosCloses counts the close-game-page requests a fake Talos receives
+type talosCloses struct {
+	mu       sync.Mutex
+	gameKeys []string
+}
+
+func (c *talosCloses) get() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.gameKeys...)
+}
+
+// reconcilerFixture wires a reconciler to an in-memory warm queue and a fake Talos
+type reconcilerFixture struct {
+	mem      *testutil.MemRedis
+	statuses *fakeStatuses
+	closes   *talosCloses
+	clock    *clock.Fake
+	recon    *closer.PageReconciler
+}
+
+var reconcileStart = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+func newReconcilerFixture(t *testing.T, statuses map[string]string) *reconcilerFixture {
+	t.Helper()
+
+	closes := &talosCloses{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req talos.CloseGamePageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		closes.mu.Lock()
+		closes.gameKeys = append(closes.gameKeys, req.GameKey)
+		closes.mu.Unlock()
+		w.Write([]byte(`{"all_ok": true, "any_ok": true, "results": {}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	mem := testutil.NewMemRedis()
+	redisClient := mem.Client()
+	t.Cleanup(func() { redisClient.Close() })
+
+	client := talos.NewClient(talos.Config{BaseURL: server.URL, Enabled: true, Books: []string{"fanduel"}})
+	queue := talos.NewWarmQueue(redisClient, client, talos.WarmQueueConfig{ConsumerID: "replica-a"})
+
+	fake := clock.NewFake(reconcileStart)
+	source := &fakeStatuses{statuses: statuses}
+	recon := closer.NewPageReconciler(nil, queue, time.Minute)
+	recon.SetClock(fake)
+	recon.SetStatusSource(source)
+
+	return &reconcilerFixture{mem: mem, statuses: source, closes: closes, clock: fake, recon: recon}
+}
+
+// open records a warmed page for eventID starting at commence
+func (f *reconcilerFixture) open(t *testing.T, eventID, homeTeam string, commence time.Time) {
+	t.Helper()
+	data, err := json.Marshal(talos.WarmJob{
+		EventID:      eventID,
+		SportKey:     "basketball_nba",
+		HomeTeam:     homeTeam,
+		AwayTeam:     "Boston Celtics",
+		CommenceTime: commence,
+	})
+	if err != nil {
+		t.Fatalf("encode page: %v", err)
+	}
+	f.mem.SetHash(openPagesKey, eventID, string(data))
+}
+
+// openIDs returns the event IDs still recorded as open, sorted
+func (f *reconcilerFixture) openIDs() []string {
+	var ids []string
+	for id := range f.mem.Hash(openPagesKey) {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// run starts the reconciler and stops it when the test ends
+func (f *reconcilerFixture) run(t *testing.T) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- f.recon.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("run: %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Error("reconciler did not stop")
+		}
+	})
+}
+
+// waitFor polls cond until it holds (the reconciler runs in its own goroutine)
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPageReconciler_ClosesPagesThatShouldNotBeOpen(t *testing.T) {
+	f := newReconcilerFixture(t, map[string]string{
+		"completed": "completed",
+		"cancelled": "cancelled",
+		"postponed": "postponed",
+		"stale":     "live",
+		"upcoming":  "upcoming",
+		"live":      "live",
+	})
+	tomorrow := reconcileStart.Add(24 * time.Hour)
+	f.open(t, "completed", "Los Angeles Lakers", reconcileStart.Add(-4*time.Hour))
+	f.open(t, "cancelled", "Denver Nuggets", reconcileStart.Add(-50*time.Hour))
+	f.open(t, "postponed", "Miami Heat", reconcileStart.Add(-time.Hour))
+	f.open(t, "gone", "Chicago Bulls", tomorrow)
+	f.open(t, "stale", "Phoenix Suns", reconcileStart.Add(-25*time.Hour))
+	f.open(t, "upcoming", "Golden State Warriors", tomorrow)
+	f.open(t, "live", "New York Knicks", reconcileStart.Add(-time.Hour))
+
+	f.run(t)
+	waitFor(t, "the first sweep", func() bool { return len(f.openIDs()) == 2 })
+
+	if got := f.openIDs(); len(got) != 2 || got[0] != "live" || got[1] != "upcoming" {
+		t.Errorf("expected only the live and upcoming pages left open, got %v", got)
+	}
+	if closes := f.closes.get(); len(closes) != 5 {
+		t.Errorf("expected Talos asked to close 5 pages, got %v", closes)
+	}
+}
+
+func TestPageReconciler_ClosesPageOnLaterSweep(t *testing.T) {
+	f := newReconcilerFixture(t, map[string]string{"e1": "live"})
+	f.open(t, "e1", "Los Angeles Lakers", reconcileStart.Add(-time.Hour))
+
+	f.run(t)
+	waitFor(t, "the first sweep", func() bool { return f.statuses.callCount() == 1 })
+	if got := f.openIDs(); len(got) != 1 {
+		t.Fatalf("expected the live page kept open, got %v", got)
+	}
+
+	f.statuses.set("e1", "cancelled")
+	f.clock.Advance(time.Minute)
+	waitFor(t, "the page closed on the next sweep", func() bool { return len(f.openIDs()) == 0 })
+
+	if closes := f.closes.get(); len(closes) != 1 {
+		t.Errorf("expected one close request, got %v", closes)
+	}
+}
+
+func TestPageReconciler_StatusErrorClosesNothing(t *testing.T) {
+	f := newReconcilerFixture(t, nil)
+	f.statuses.err = errors.New("connection refused")
+	f.open(t, "e1", "Los Angeles Lakers", reconcileStart.Add(-48*time.Hour))
+
+	f.run(t)
+	waitFor(t, "the failed sweep", func() bool { return f.statuses.callCount() == 1 })
+
+	if got := f.openIDs(); len(got) != 1 {
+		t.Errorf("expected the page kept when statuses are unavailable, got %v", got)
+	}
+	if closes := f.closes.get(); len(closes) != 0 {
+		t.Errorf("expected no close requests, got %v", closes)
+	}
+}
@@ -0,0 +1,54 @@
+package conditional_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/conditional"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+var now = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+const request = "/v4/sports/basketball_nba/odds?apiKey=REDACTED&markets=h2h"
+
+func TestGetOnlyAfterCommit(t *testing.T) {
+	cache := conditional.NewCache(10 * time.Minute)
+
+	if _, ok := cache.Get(request, now); ok {
+		t.Fatal("validators returned before any commit")
+	}
+
+	cache.Commit(map[string]models.Validators{request: {ETag: `"v1"`, LastModified: "Wed, 15 Jan 2025 11:59:00 GMT"}}, now)
+	v, ok := cache.Get(request, now.Add(time.Minute))
+	if !ok || v.ETag != `"v1"` || v.LastModified == "" {
+		t.Fatalf("expected committed validators, got %+v, %t", v, ok)
+	}
+	if _, ok := cache.Get(request, now.Add(10*time.Minute)); ok {
+		t.Error("validators past the max age should not be sent")
+	}
+	if sent, _ := cache.Stats(); sent != 1 {
+		t.Errorf("expected 1 conditional request counted, got %d", sent)
+	}
+}
+
+func TestCommitEmptyForgetsValidators(t *testing.T) {
+	cache := conditional.NewCache(0)
+	cache.Commit(map[string]models.Validators{request: {ETag: `"v1"`}}, now)
+
+	// The vendor stopped sending validators: the next request is plain
+	cache.Commit(map[string]models.Validators{request: {}}, now.Add(time.Minute))
+	if _, ok := cache.Get(request, now.Add(time.Minute)); ok || cache.Len() != 0 {
+		t.Errorf("expected empty validators to remove the entry, %d left", cache.Len())
+	}
+}
+
+func TestCommitPrunesExpired(t *testing.T) {
+	cache := conditional.NewCache(10 * time.Minute)
+	cache.Commit(map[string]models.Validators{"old": {ETag: `"a"`}}, now)
+	cache.Commit(map[string]models.Validators{"new": {ETag: `"b"`}}, now.Add(15*time.Minute))
+
+	if cache.Len() != 1 {
+		t.Errorf("expected the expired entry pruned, %d left", cache.Len())
+	}
+}
@@ -0,0 +1,223 @@
+package consumer_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestDecode(t *testing.T) {
+	msg, err := consumer.Decode(map[string]interface{}{
+		"data": `{"event_id":"e1","sport_key":"basketball_nba","market_key":"spreads","book_key":"pinnacle","outcome_name":"Lakers","price":-110,"point":-3.5}`,
+	})
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if msg.EventID != "e1" || msg.Price != -110 || msg.Point == nil || *msg.Point != -3.5 {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+	if msg.SchemaVersion != 1 {
+		t.Errorf("expected unversioned messages to be schema 1, got %d", msg.SchemaVersion)
+	}
+}
+
+func sampleMessage() models.StreamMessage {
+	point, limit := -3.5, 0.0
+	oldPrice, oldPoint, priceDelta, pointDelta, consensus := -105, -3.0, -10, -0.5, -120
+	return models.StreamMessage{
+		SchemaVersion:    models.StreamSchemaVersion,
+		EventID:          "e1",
+		SportKey:         "basketball_nba",
+		MarketKey:        "player_points",
+		BookKey:          "pinnacle",
+		OutcomeName:      "Over",
+		Description:      "LeBron James",
+		Price:            -115,
+		PriceDecimal:     1.8696,
+		OddsFormat:       "american",
+		Point:            &point,
+		Limit:            &limit, // Present even though zero
+		VendorLastUpdate: time.Date(2025, 1, 15, 0, 29, 58, 0, time.UTC),
+		ReceivedAt:       time.Date(2025, 1, 15, 0, 30, 1, 123456789, time.UTC),
+		EventStatus:      "live",
+		TeamID:           "t1",
+		ChangeType:       "price",
+		DedupeKey:        "0f3c2a",
+		PollID:           "9b1e4d2a7c3f5e60",
+		OldPrice:         &oldPrice,
+		OldPoint:         &oldPoint,
+		PriceDeltaCents:  &priceDelta,
+		PointDelta:       &pointDelta,
+		ConsensusPrice:   &consensus,
+		Outlier:          true,
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	want := sampleMessage()
+	for _, encoding := range []models.StreamEncoding{models.StreamEncodingJSON, models.StreamEncodingProtobuf} {
+		values, err := consumer.Encode(want, encoding)
+		if err != nil {
+			t.Fatalf("%s: encode: %v", encoding, err)
+		}
+		got, err := consumer.Decode(values)
+		if err != nil {
+			t.Fatalf("%s: decode: %v", encoding, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s round trip:\n got %+v\nwant %+v", encoding, got, want)
+		}
+	}
+}
+
+func TestEncodeDecode_Side(t *testing.T) {
+	want := sampleMessage()
+	want.MarketKey, want.OutcomeName, want.Description, want.Side = "spreads", "Los Angeles Lakers", "", "home"
+	for _, encoding := range []models.StreamEncoding{models.StreamEncodingJSON, models.StreamEncodingProtobuf} {
+		values, err := consumer.Encode(want, encoding)
+		if err != nil {
+			t.Fatalf("%s: encode: %v", encoding, err)
+		}
+		got, err := consumer.Decode(values)
+		if err != nil {
+			t.Fatalf("%s: decode: %v", encoding, err)
+		}
+		if got.Side != "home" {
+			t.Errorf("%s: side = %q, want home", encoding, got.Side)
+		}
+	}
+}
+
+func TestDecodeProtobuf_SkipsUnknownFields(t *testing.T) {
+	data := sampleMessage().MarshalProto()
+	// Field 99 (string "new") and field 98 (varint 7) from a newer producer
+	data = append(data, 0x9a, 0x06, 3, 'n', 'e', 'w', 0x90, 0x06, 7)
+
+	msg, err := consumer.Decode(map[string]interface{}{"data": string(data), "encoding": "protobuf"})
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if msg.EventID != "e1" || msg.Price != -115 {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+}
+
+func TestParseStreamEncoding(t *testing.T) {
+	if enc, err := models.ParseStreamEncoding(" Protobuf "); err != nil || enc != models.StreamEncodingProtobuf {
+		t.Errorf("ParseStreamEncoding(protobuf) = %q, %v", enc, err)
+	}
+	if _, err := models.ParseStreamEncoding("avro"); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
+
+func TestDecode_Malformed(t *testing.T) {
+	cases := []map[string]interface{}{
+		{},
+		{"data": 42},
+		{"data": "{not json"},
+		{"data": "\x0a\x05e1", "encoding": "protobuf"},
+		{"data": "{}", "encoding": "avro"},
+	}
+	for _, values := range cases {
+		if _, err := consumer.Decode(values); err == nil {
+			t.Errorf("expected error decoding %v", values)
+		}
+	}
+}
+
+func TestStreamKeyAndIDFromTime(t *testing.T) {
+	if got := consumer.StreamKey("basketball_nba"); got != "odds.raw.basketball_nba" {
+		t.Errorf("unexpected stream key %s", got)
+	}
+	if got := consumer.LiveStreamKey("basketball_nba"); got != "odds.live.basketball_nba" {
+		t.Errorf("unexpected live stream key %s", got)
+	}
+
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if got := consumer.IDFromTime(ts); got != "1735787045000-0" {
+		t.Errorf("unexpected ID %s", got)
+	}
+}
+
+func TestIsBusyGroup(t *testing.T) {
+	if !consumer.IsBusyGroup(errors.New("BUSYGROUP Consumer Group name already exists")) {
+		t.Error("expected BUSYGROUP to be detected")
+	}
+	if consumer.IsBusyGroup(errors.New("ERR no such key")) || consumer.IsBusyGroup(nil) {
+		t.Error("unexpected BUSYGROUP match")
+	}
+}
+
+func TestEncodeDecodeBatch_RoundTrip(t *testing.T) {
+	over, under := sampleMessage(), sampleMessage()
+	under.OutcomeName, under.Price, under.PriceDecimal = "Under", -105, 1.9524
+	want := models.StreamBatch{
+		SchemaVersion: models.StreamSchemaVersion,
+		EventID:       "e1",
+		SportKey:      "basketball_nba",
+		BookKey:       "pinnacle",
+		PollID:        over.PollID,
+		Outcomes:      []models.StreamMessage{over, under},
+	}
+	for _, encoding := range []models.StreamEncoding{models.StreamEncodingJSON, models.StreamEncodingProtobuf} {
+		values, err := consumer.EncodeBatch(want, encoding)
+		if err != nil {
+			t.Fatalf("%s: encode: %v", encoding, err)
+		}
+		if values[consumer.FieldKind] != consumer.KindBatch {
+			t.Errorf("%s: expected kind=batch, got %v", encoding, values[consumer.FieldKind])
+		}
+		got, err := consumer.DecodeBatch(values)
+		if err != nil {
+			t.Fatalf("%s: decode: %v", encoding, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s round trip:\n got %+v\nwant %+v", encoding, got, want)
+		}
+
+		outcomes, err := consumer.DecodeEntry(values)
+		if err != nil || len(outcomes) != 2 || outcomes[1].OutcomeName != "Under" {
+			t.Errorf("%s: DecodeEntry = %+v, %v", encoding, outcomes, err)
+		}
+	}
+}
+
+func TestDecodeEntry_SingleAndUnknownKind(t *testing.T) {
+	values, _ := consumer.Encode(sampleMessage(), models.StreamEncodingJSON)
+	outcomes, err := consumer.DecodeEntry(values)
+	if err != nil || len(outcomes) != 1 || outcomes[0].EventID != "e1" {
+		t.Errorf("DecodeEntry(single) = %+v, %v", outcomes, err)
+	}
+
+	values[consumer.FieldKind] = "digest"
+	if _, err := consumer.DecodeEntry(values); err == nil {
+		t.Error("expected an error for an unknown entry kind")
+	}
+	if _, err := consumer.DecodeEntry(map[string]interface{}{"data": `{"event_id":"e1","outcomes":[]}`, "kind": "batch"}); err == nil {
+		t.Error("expected an error for an empty batch")
+	}
+}
+
+func TestCompareIDs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1700000000000-0", "1700000000000-0", 0},
+		{"1700000000000-1", "1700000000000-0", 1},
+		{"1700000000000-9", "1700000000001-0", -1},
+		// Numeric, not lexical: 10 sorts after 9
+		{"1700000000000-10", "1700000000000-9", 1},
+		{"999-0", "1000-0", -1},
+	}
+	for _, tt := range tests {
+		if got := consumer.CompareIDs(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareIDs(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package consumer_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestSignVerify(t *testing.T) {
+	for _, encoding := range []models.StreamEncoding{models.StreamEncodingJSON, models.StreamEncodingProtobuf} {
+		values, err := consumer.Encode(sampleMessage(), encoding)
+		if err != nil {
+			t.Fatalf("%s: encode: %v", encoding, err)
+		}
+		if err := consumer.Sign(values, "s3cret"); err != nil {
+			t.Fatalf("%s: sign: %v", encoding, err)
+		}
+
+		// Read back from Redis every field is a string
+		read := make(map[string]interface{}, len(values))
+		for field, value := range values {
+			if data, ok := value.([]byte); ok {
+				value = string(data)
+			}
+			read[field] = value
+		}
+		if err := consumer.Verify(read, "s3cret"); err != nil {
+			t.Errorf("%s: expected a valid signature, got %v", encoding, err)
+		}
+		if err := consumer.Verify(read, "other"); !errors.Is(err, consumer.ErrBadSignature) {
+			t.Errorf("%s: expected a bad signature under another secret, got %v", encoding, err)
+		}
+
+		read["data"] = read["data"].(string) + " "
+		if err := consumer.Verify(read, "s3cret"); !errors.Is(err, consumer.ErrBadSignature) {
+			t.Errorf("%s: expected tampered data to fail, got %v", encoding, err)
+		}
+	}
+}
+
+func TestVerify_Unsigned(t *testing.T) {
+	values, _ := consumer.Encode(sampleMessage(), models.StreamEncodingJSON)
+	if err := consumer.Verify(values, "s3cret"); !errors.Is(err, consumer.ErrUnsigned) {
+		t.Errorf("expected ErrUnsigned, got %v", err)
+	}
+}
+
+func TestSignPayload_Format(t *testing.T) {
+	// HMAC-SHA256("key", "The quick brown fox jumps over the lazy dog")
+	want := "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8"
+	if got := consumer.SignPayload("key", []byte("The quick brown fox jumps over the lazy dog")); got != want {
+		t.Errorf("SignPayload = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,221 @@
+package delta_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/delta"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func lakersML(price int, updated time.Time) models.RawOdds {
+	return models.RawOdds{
+		EventID:          "test_event_1",
+		SportKey:         "basketball_nba",
+		MarketKey:        "h2h",
+		BookKey:          "fanduel",
+		OutcomeName:      "Lakers",
+		Price:            price,
+		DecimalPrice:     models.AmericanToDecimal(price),
+		VendorLastUpdate: updated,
+		ReceivedAt:       updated,
+	}
+}
+
+func TestMemoryCache_MGetPreservesOrderAndMisses(t *testing.T) {
+	ctx := context.Background()
+	cache := delta.NewMemoryCache()
+
+	if err := cache.SetMany(ctx, []delta.CacheEntry{{Key: "a", Value: []byte("1")}, {Key: "c", Value: []byte("3")}}, time.Minute); err != nil {
+		t.Fatalf("SetMany: %v", err)
+	}
+
+	values, err := cache.MGet(ctx, "a", "b", "c")
+	if err != nil {
+		t.Fatalf("MGet: %v", err)
+	}
+	if len(values) != 3 || values[0] != "1" || values[1] != nil || values[2] != "3" {
+		t.Errorf("expected [1 <nil> 3], got %v", values)
+	}
+}
+
+func TestMemoryCache_ExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	cache := delta.NewMemoryCache()
+	cache.SetClock(func() time.Time { return now })
+
+	cache.SetMany(ctx, []delta.CacheEntry{{Key: "short", Value: []byte("x")}}, time.Minute)
+	cache.SetMany(ctx, []delta.CacheEntry{{Key: "forever", Value: []byte("y")}}, 0)
+
+	now = now.Add(time.Minute)
+
+	values, _ := cache.MGet(ctx, "short", "forever")
+	if values[0] != nil || values[1] != "y" {
+		t.Errorf("expected short-lived entry expired and TTL 0 kept, got %v", values)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("expected 1 live entry, got %d", cache.Len())
+	}
+}
+
+func TestDetectChanges_ExpiredCacheEntryIsNew(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	cache := delta.NewMemoryCache()
+	cache.SetClock(func() time.Time { return now })
+	engine := delta.NewEngineWithCache(cache, 30*time.Second)
+
+	odd := lakersML(-110, now)
+	if err := engine.UpdateCache(ctx, []models.RawOdds{odd}); err != nil {
+		t.Fatalf("UpdateCache: %v", err)
+	}
+
+	deltas, _ := engine.DetectChanges(ctx, []models.RawOdds{odd})
+	if len(deltas) != 0 {
+		t.Fatalf("expected no delta within the TTL, got %+v", deltas)
+	}
+
+	now = now.Add(31 * time.Second)
+
+	deltas, _ = engine.DetectChanges(ctx, []models.RawOdds{odd})
+	if len(deltas) != 1 || deltas[0].ChangeType != delta.ChangeTypeNew {
+		t.Errorf("expected the odd to be new once its cache entry expired, got %+v", deltas)
+	}
+}
+
+func TestDetectChanges_LimitOnlyChange(t *testing.T) {
+	ctx := context.Background()
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
+	now := time.Now()
+
+	oldLimit, newLimit := 5000.0, 2500.0
+	odd := lakersML(-110, now)
+	odd.Limit = &oldLimit
+	engine.UpdateCache(ctx, []models.RawOdds{odd})
+
+	odd.Limit = &newLimit
+	odd.VendorLastUpdate = now.Add(time.Minute)
+
+	deltas, err := engine.DetectChanges(ctx, []models.RawOdds{odd})
+	if err != nil {
+		t.Fatalf("DetectChanges: %v", err)
+	}
+	if len(deltas) != 1 || deltas[0].ChangeType != delta.ChangeTypeLimitOnly {
+		t.Fatalf("expected a limit-only delta, got %+v", deltas)
+	}
+	if deltas[0].OldLimit == nil || *deltas[0].OldLimit != oldLimit {
+		t.Errorf("expected old limit %v, got %v", oldLimit, deltas[0].OldLimit)
+	}
+}
+
+func TestDelta_OddsCarryPreviousQuote(t *testing.T) {
+	ctx := context.Background()
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
+	now := time.Now()
+
+	oldLine, newLine := -3.5, -4.5
+	odd := lakersML(-105, now)
+	odd.MarketKey, odd.Point = "spreads", &oldLine
+	engine.UpdateCache(ctx, []models.RawOdds{odd})
+
+	odd.Price, odd.DecimalPrice, odd.Point = 105, models.AmericanToDecimal(105), &newLine
+	odd.VendorLastUpdate = now.Add(time.Minute)
+
+	deltas, _ := engine.DetectChanges(ctx, []models.RawOdds{odd})
+	if len(deltas) != 1 {
+		t.Fatalf("expected one delta, got %+v", deltas)
+	}
+	changed := deltas[0].Odds()
+	if changed.ChangeType != string(delta.ChangeTypeBoth) {
+		t.Errorf("expected price_and_point, got %s", changed.ChangeType)
+	}
+	if cents := changed.PriceDeltaCents(); cents == nil || *cents != 10 {
+		t.Errorf("expected a 10 cent move across even money, got %v", cents)
+	}
+	if move := changed.PointDelta(); move == nil || *move != -1 {
+		t.Errorf("expected a -1 point move, got %v", move)
+	}
+
+	deltas, _ = engine.DetectChanges(ctx, []models.RawOdds{lakersML(-110, now)})
+	if len(deltas) != 1 || deltas[0].Odds().OldPrice != nil || deltas[0].Odds().PriceDeltaCents() != nil {
+		t.Errorf("expected a new outcome without a previous quote, got %+v", deltas)
+	}
+}
+
+func TestDetectChanges_PropsKeyedByDescription(t *testing.T) {
+	ctx := context.Background()
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
+	now := time.Now()
+	line := 25.5
+
+	lebron := models.RawOdds{
+		EventID: "test_event_1", SportKey: "basketball_nba", MarketKey: "player_points", BookKey: "fanduel",
+		OutcomeName: "Over", Description: "LeBron James", Point: &line, Price: -115,
+		DecimalPrice: models.AmericanToDecimal(-115), VendorLastUpdate: now, ReceivedAt: now,
+	}
+	engine.UpdateCache(ctx, []models.RawOdds{lebron})
+
+	davis := lebron
+	davis.Description = "Anthony Davis"
+
+	deltas, _ := engine.DetectChanges(ctx, []models.RawOdds{lebron, davis})
+	if len(deltas) != 1 || deltas[0].Odd.Description != "Anthony Davis" || deltas[0].ChangeType != delta.ChangeTypeNew {
+		t.Errorf("expected only the second player to be new, got %+v", deltas)
+	}
+}
+
+func TestDetectChanges_SkipUnchangedTimestamps(t *testing.T) {
+	ctx := context.Background()
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
+	engine.SetSkipUnchangedTimestamps(true)
+	now := time.Now()
+
+	engine.UpdateCache(ctx, []models.RawOdds{lakersML(-110, now)})
+
+	// Same vendor timestamp: trusted as unchanged even though the price differs
+	if deltas, _ := engine.DetectChanges(ctx, []models.RawOdds{lakersML(-120, now)}); len(deltas) != 0 {
+		t.Errorf("expected no deltas without a newer vendor timestamp, got %+v", deltas)
+	}
+
+	if deltas, _ := engine.DetectChanges(ctx, []models.RawOdds{lakersML(-120, now.Add(time.Second))}); len(deltas) != 1 {
+		t.Errorf("expected a delta once the vendor timestamp advanced, got %+v", deltas)
+	}
+}
+
+func TestDetectChanges_CorruptCacheEntryIsNew(t *testing.T) {
+	ctx := context.Background()
+	cache := delta.NewMemoryCache()
+	engine := delta.NewEngineWithCache(cache, 30*time.Second)
+
+	cache.SetMany(ctx, []delta.CacheEntry{{Key: "odds:current:test_event_1:h2h:fanduel:Lakers", Value: []byte("{not json")}}, time.Minute)
+
+	deltas, err := engine.DetectChanges(ctx, []models.RawOdds{lakersML(-110, time.Now())})
+	if err != nil {
+		t.Fatalf("DetectChanges: %v", err)
+	}
+	if len(deltas) != 1 || deltas[0].ChangeType != delta.ChangeTypeNew {
+		t.Errorf("expected a corrupt entry to be treated as new, got %+v", deltas)
+	}
+}
+
+func TestUpdateCache_WritesOneEntryPerOdd(t *testing.T) {
+	ctx := context.Background()
+	cache := delta.NewMemoryCache()
+	engine := delta.NewEngineWithCache(cache, 30*time.Second)
+	now := time.Now()
+
+	celtics := lakersML(-110, now)
+	celtics.OutcomeName = "Celtics"
+
+	if err := engine.UpdateCache(ctx, []models.RawOdds{lakersML(-110, now), celtics}); err != nil {
+		t.Fatalf("UpdateCache: %v", err)
+	}
+	if err := engine.UpdateCache(ctx, nil); err != nil {
+		t.Fatalf("UpdateCache(nil): %v", err)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 cache entries, got %d", cache.Len())
+	}
+}
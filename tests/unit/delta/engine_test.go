@@ -1,5 +1,3 @@
-// +build integration
-
 package delta_test
 
 import (
@@ -9,21 +7,11 @@ import (
 
 	"github.com/XavierBriggs/Mercury/internal/delta"
 	"github.com/XavierBriggs/Mercury/pkg/models"
-	"github.com/redis/go-redis/v9"
 )
 
 func TestDetectChanges_NewOutcome(t *testing.T) {
-	// Setup test Redis (requires Redis running)
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-	})
-	defer redisClient.Close()
-
 	ctx := context.Background()
-	engine := delta.NewEngine(redisClient, 30*time.Second)
-
-	// Clear test keys
-	redisClient.FlushDB(ctx)
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
 
 	// Create new odds
 	now := time.Now()
@@ -56,15 +44,8 @@ func TestDetectChanges_NewOutcome(t *testing.T) {
 }
 
 func TestDetectChanges_PriceChange(t *testing.T) {
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-	})
-	defer redisClient.Close()
-
 	ctx := context.Background()
-	engine := delta.NewEngine(redisClient, 30*time.Second)
-
-	redisClient.FlushDB(ctx)
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
 
 	now := time.Now()
 
@@ -118,15 +99,8 @@ func TestDetectChanges_PriceChange(t *testing.T) {
 }
 
 func TestDetectChanges_PointChange(t *testing.T) {
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-	})
-	defer redisClient.Close()
-
 	ctx := context.Background()
-	engine := delta.NewEngine(redisClient, 30*time.Second)
-
-	redisClient.FlushDB(ctx)
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
 
 	now := time.Now()
 	point1 := 3.5
@@ -183,15 +157,8 @@ func TestDetectChanges_PointChange(t *testing.T) {
 }
 
 func TestDetectChanges_NoChange(t *testing.T) {
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-	})
-	defer redisClient.Close()
-
 	ctx := context.Background()
-	engine := delta.NewEngine(redisClient, 30*time.Second)
-
-	redisClient.FlushDB(ctx)
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
 
 	now := time.Now()
 
@@ -223,15 +190,8 @@ func TestDetectChanges_NoChange(t *testing.T) {
 }
 
 func BenchmarkDetectChanges(b *testing.B) {
-	redisClient := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-	})
-	defer redisClient.Close()
-
 	ctx := context.Background()
-	engine := delta.NewEngine(redisClient, 30*time.Second)
-
-	redisClient.FlushDB(ctx)
+	engine := delta.NewEngineWithCache(delta.NewMemoryCache(), 30*time.Second)
 
 	// Create 100 odds
 	now := time.Now()
@@ -0,0 +1,184 @@
+package edge_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/edge"
+	"github.com/XavierBriggs/Mercury/internal/reliability"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func odd(book, outcome string, price int, point *float64) models.RawOdds {
+	return models.RawOdds{
+		EventID:     "e1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "spreads",
+		BookKey:     book,
+		OutcomeName: outcome,
+		Price:       price,
+		Point:       point,
+		ReceivedAt:  time.Now(),
+	}
+}
+
+func TestEngine_DetectsSoftEdgeOnce(t *testing.T) {
+	engine := edge.NewEngine(nil, nil, []string{"pinnacle"}, 1.0)
+	now := time.Now()
+
+	fav, dog := -3.5, 3.5
+
+	// Sharp line -105 / -105 => fair 50/50; soft dog at +110 => +5% EV
+	edges := engine.Detect([]models.RawOdds{
+		odd("pinnacle", "Lakers", -105, &fav),
+		odd("pinnacle", "Celtics", -105, &dog),
+		odd("fanduel", "Lakers", -115, &fav),
+		odd("fanduel", "Celtics", 110, &dog),
+	}, now)
+
+	if len(edges) != 1 {
+		t.Fatalf("expected 1 edge, got %d: %+v", len(edges), edges)
+	}
+	got := edges[0]
+	if got.BookKey != "fanduel" || got.OutcomeName != "Celtics" || got.SharpBookKey != "pinnacle" {
+		t.Errorf("unexpected edge: %+v", got)
+	}
+	if math.Abs(got.EdgePct-5.0) > 0.01 {
+		t.Errorf("expected +5%% edge, got %v", got.EdgePct)
+	}
+	// Kelly: 0.05 EV / 1.1 net odds
+	if math.Abs(got.KellyPct-4.55) > 0.01 {
+		t.Errorf("expected 4.55%% Kelly stake, got %v", got.KellyPct)
+	}
+
+	// Unrelated update on the same line: the unchanged edge is not re-emitted
+	if edges := engine.Detect([]models.RawOdds{odd("fanduel", "Lakers", -120, &fav)}, now); len(edges) != 0 {
+		t.Errorf("expected no re-emit, got %+v", edges)
+	}
+
+	// Soft price improves: emitted again
+	if edges := engine.Detect([]models.RawOdds{odd("fanduel", "Celtics", 115, &dog)}, now); len(edges) != 1 {
+		t.Errorf("expected re-emit after price change, got %+v", edges)
+	}
+}
+
+func TestEngine_RequiresCompleteSharpLine(t *testing.T) {
+	engine := edge.NewEngine(nil, nil, nil, 1.0)
+	fav, dog := -3.5, 3.5
+
+	edges := engine.Detect([]models.RawOdds{
+		odd("pinnacle", "Lakers", -105, &fav),
+		odd("fanduel", "Lakers", -115, &fav),
+		odd("fanduel", "Celtics", 150, &dog),
+	}, time.Now())
+
+	if len(edges) != 0 {
+		t.Errorf("expected no edges without a two-sided sharp line, got %+v", edges)
+	}
+}
+
+func TestEngine_EvictDropsState(t *testing.T) {
+	engine := edge.NewEngine(nil, nil, nil, 1.0)
+	fav, dog := -3.5, 3.5
+
+	engine.Detect([]models.RawOdds{
+		odd("pinnacle", "Lakers", -105, &fav),
+		odd("pinnacle", "Celtics", -105, &dog),
+	}, time.Now())
+	engine.Evict("e1")
+
+	if edges := engine.Detect([]models.RawOdds{odd("fanduel", "Celtics", 110, &dog)}, time.Now()); len(edges) != 0 {
+		t.Errorf("expected no edges after eviction, got %+v", edges)
+	}
+}
+
+func TestEngine_ConsultsBookRegistry(t *testing.T) {
+	registry := books.NewRegistry([]books.Book{
+		{Key: "circa", Class: books.ClassSharp, Regions: []string{"us"}, Active: true},
+		{Key: "novig", Class: books.ClassExchange, Regions: []string{"us_ex"}, Active: true},
+		{Key: "fanduel", Class: books.ClassSoft, Regions: []string{"us"}, Active: true},
+	})
+
+	engine := edge.NewEngine(nil, nil, nil, 1.0)
+	engine.SetBooks(registry)
+	if got := engine.SharpBooks(); len(got) != 1 || got[0] != "circa" {
+		t.Fatalf("expected registry sharp books, got %v", got)
+	}
+
+	fav, dog := -3.5, 3.5
+	edges := engine.Detect([]models.RawOdds{
+		odd("circa", "Lakers", -105, &fav),
+		odd("circa", "Celtics", -105, &dog),
+		odd("novig", "Celtics", 115, &dog),   // Exchange: not an edge target
+		odd("fanduel", "Celtics", 110, &dog), // Soft: reported
+	}, time.Now())
+
+	if len(edges) != 1 || edges[0].BookKey != "fanduel" || edges[0].SharpBookKey != "circa" {
+		t.Fatalf("expected one fanduel edge against circa, got %+v", edges)
+	}
+
+	// Reclassifying through the registry applies without rebuilding the engine
+	if err := registry.Set(books.Book{Key: "novig", Class: books.ClassSoft, Regions: []string{"us_ex"}, Active: true}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	edges = engine.Detect([]models.RawOdds{odd("novig", "Celtics", 120, &dog)}, time.Now())
+	if len(edges) != 1 || edges[0].BookKey != "novig" {
+		t.Errorf("expected reclassified book to be evaluated, got %+v", edges)
+	}
+}
+
+func TestEngine_ConfiguredSharpBooksWinOverRegistry(t *testing.T) {
+	engine := edge.NewEngine(nil, nil, []string{"pinnacle"}, 1.0)
+	engine.SetBooks(books.NewRegistry(books.Defaults))
+
+	if got := engine.SharpBooks(); len(got) != 1 || got[0] != "pinnacle" {
+		t.Errorf("expected configured sharp books, got %v", got)
+	}
+}
+
+func TestEngine_WeightsBlendSharpLines(t *testing.T) {
+	fav, dog := -3.5, 3.5
+	odds := []models.RawOdds{
+		// pinnacle: fair 50/50
+		odd("pinnacle", "Lakers", -105, &fav),
+		odd("pinnacle", "Celtics", -105, &dog),
+		// circa: -150 / +130 => Celtics fair ~0.4202
+		odd("circa", "Lakers", -150, &fav),
+		odd("circa", "Celtics", 130, &dog),
+		odd("fanduel", "Lakers", -140, &fav),
+		odd("fanduel", "Celtics", 140, &dog),
+	}
+	celticsFair := func(weights map[string]float64) edge.Edge {
+		t.Helper()
+		engine := edge.NewEngine(nil, nil, []string{"pinnacle", "circa"}, 1.0)
+		if weights != nil {
+			engine.SetWeights(reliability.NewWeights(weights))
+		}
+		for _, e := range engine.Detect(odds, time.Now()) {
+			if e.OutcomeName == "Celtics" {
+				return e
+			}
+		}
+		t.Fatalf("expected a Celtics edge with weights %v", weights)
+		return edge.Edge{}
+	}
+
+	// Without weights the first complete sharp line is the fair line
+	if got := celticsFair(nil); math.Abs(got.FairProb-0.5) > 0.0001 || got.SharpBookKey != "pinnacle" {
+		t.Errorf("expected pinnacle's 50%% fair line, got %+v", got)
+	}
+
+	// circa scored low: (1*0.5 + 0.25*0.4202) / 1.25
+	lowCirca := celticsFair(map[string]float64{"pinnacle": 1, "circa": 0.25})
+	if math.Abs(lowCirca.FairProb-0.4840) > 0.0001 || lowCirca.SharpBookKey != "pinnacle" {
+		t.Errorf("expected a 48.40%% blended fair line led by pinnacle, got %+v", lowCirca)
+	}
+
+	// pinnacle scored low instead: the line moves toward circa
+	lowPinnacle := celticsFair(map[string]float64{"pinnacle": 0.25, "circa": 1})
+	if lowPinnacle.FairProb >= lowCirca.FairProb || lowPinnacle.SharpBookKey != "circa" {
+		t.Errorf("expected a low-scored pinnacle to move the fair line less, got %+v", lowPinnacle)
+	}
+}
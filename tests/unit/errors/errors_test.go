@@ -0,0 +1,99 @@
+package errors_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+)
+
+func TestFromStatus(t *testing.T) {
+	tests := []struct {
+		status    int
+		kind      error
+		retryable bool
+	}{
+		{429, merrors.ErrRateLimited, true},
+		{500, merrors.ErrVendorUnavailable, true},
+		{503, merrors.ErrVendorUnavailable, true},
+		{408, merrors.ErrVendorUnavailable, true},
+		{401, merrors.ErrVendorRejected, false},
+		{422, merrors.ErrVendorRejected, false},
+	}
+
+	for _, tt := range tests {
+		err := merrors.FromStatus(tt.status, "body", 0)
+		if !errors.Is(err, tt.kind) {
+			t.Errorf("%d: expected kind %v, got %v", tt.status, tt.kind, err.Kind)
+		}
+		if got := merrors.Retryable(err); got != tt.retryable {
+			t.Errorf("%d: Retryable = %v, want %v", tt.status, got, tt.retryable)
+		}
+		if merrors.Terminal(err) == tt.retryable {
+			t.Errorf("%d: Terminal should be the opposite of Retryable", tt.status)
+		}
+	}
+
+	if msg := merrors.FromStatus(429, "slow down", 0).Error(); msg != "HTTP 429: slow down" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestClassificationSurvivesWrapping(t *testing.T) {
+	cause := errors.New("connection reset")
+	err := merrors.Wrap(merrors.ErrStorage, "write deltas", cause)
+	if err.Error() != "write deltas: connection reset" {
+		t.Errorf("unexpected message %q", err)
+	}
+
+	wrapped := fmt.Errorf("poll: %w", errors.Join(errors.New("other part failed"), err))
+	if !errors.Is(wrapped, merrors.ErrStorage) || !errors.Is(wrapped, cause) {
+		t.Error("expected kind and cause to be found through wrapping")
+	}
+	if merrors.KindOf(wrapped) != merrors.ErrStorage || !merrors.Retryable(wrapped) {
+		t.Errorf("KindOf = %v", merrors.KindOf(wrapped))
+	}
+
+	if merrors.Wrap(merrors.ErrStorage, "op", nil) != nil {
+		t.Error("wrapping nil should return nil")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	err := fmt.Errorf("fetch odds failed: %w", merrors.FromStatus(429, "", 7*time.Second))
+	if got := merrors.RetryAfter(err); got != 7*time.Second {
+		t.Errorf("RetryAfter = %v", got)
+	}
+	if got := merrors.RetryAfter(errors.New("plain")); got != 0 {
+		t.Errorf("RetryAfter of unclassified = %v", got)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, ""},
+		{merrors.FromStatus(429, "", 0), "rate_limited"},
+		{merrors.Newf(merrors.ErrQuotaExhausted, "out of credits"), "quota_exhausted"},
+		{merrors.Newf(merrors.ErrValidation, "home team cannot be empty"), "validation"},
+		{merrors.Wrap(merrors.ErrMalformedResponse, "", errors.New("bad json")), "malformed_response"},
+		{fmt.Errorf("fetch: %w", context.Canceled), "canceled"},
+		{context.DeadlineExceeded, "timeout"},
+		{errors.New("plain"), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := merrors.Label(tt.err); got != tt.want {
+			t.Errorf("Label(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+
+	if merrors.Retryable(context.Canceled) || merrors.Terminal(errors.New("plain")) {
+		t.Error("unclassified errors are neither retryable nor terminal")
+	}
+}
@@ -0,0 +1,71 @@
+package export_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/export"
+)
+
+func TestParseColumnsAndSelect(t *testing.T) {
+	columns, err := export.ParseColumns("odds_raw.event_id, odds_raw.price,events.home_team")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(columns["odds_raw"]) != 2 || columns["events"][0] != "home_team" {
+		t.Fatalf("unexpected columns: %v", columns)
+	}
+
+	table, _ := export.LookupTable("odds_raw")
+	selected, err := table.Select(columns["odds_raw"])
+	if err != nil || len(selected) != 2 || selected[1].Type != export.ColumnInt {
+		t.Fatalf("select = %+v, %v", selected, err)
+	}
+
+	if _, err := table.Select([]string{"nope"}); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+	if _, err := export.ParseColumns("price"); err == nil {
+		t.Error("expected an error without a table prefix")
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := export.NewCSVWriter(&buf, []export.Column{
+		{Name: "book_key", Type: export.ColumnString},
+		{Name: "price", Type: export.ColumnInt},
+		{Name: "point", Type: export.ColumnFloat, Nullable: true},
+		{Name: "received_at", Type: export.ColumnTimestamp},
+	})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	at := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	w.WriteRow([]interface{}{"fanduel", int64(-110), -3.5, at})
+	w.WriteRow([]interface{}{"book, with comma", int64(150), nil, at})
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	want := "book_key,price,point,received_at\n" +
+		"fanduel,-110,-3.5,2025-01-15T12:00:00Z\n" +
+		"\"book, with comma\",150,,2025-01-15T12:00:00Z\n"
+	if buf.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	from := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	name := export.FileName("odds_raw", from, from.Add(24*time.Hour), export.FormatParquet)
+	if name != "odds_raw_20250115T000000Z_20250116T000000Z.parquet" {
+		t.Errorf("unexpected file name %q", name)
+	}
+	if !strings.HasSuffix(export.FileName("events", from, from, export.FormatCSV), ".csv") {
+		t.Error("expected a .csv suffix")
+	}
+}
@@ -0,0 +1,165 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/export"
+)
+
+// compactReader decodes the Thrift compact protocol into field-ID maps so the test
+// can check the footer against the Parquet spec independently of the writer
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *compactReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *compactReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *compactReader) varint() int64 {
+	v, n := binary.Varint(r.data[r.pos:])
+	r.pos += n
+	return v
+}
+
+func (r *compactReader) value(kind byte) interface{} {
+	switch kind {
+	case 1:
+		return true
+	case 2:
+		return false
+	case 5, 6:
+		return r.varint()
+	case 8:
+		n := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+n])
+		r.pos += n
+		return s
+	case 9:
+		header := r.byte()
+		size := int(header >> 4)
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]interface{}, size)
+		for i := range list {
+			list[i] = r.value(header & 0x0F)
+		}
+		return list
+	case 12:
+		return r.structure()
+	}
+	panic("unsupported thrift type")
+}
+
+func (r *compactReader) structure() map[int16]interface{} {
+	fields := make(map[int16]interface{})
+	var last int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.varint())
+		}
+		fields[id] = r.value(header & 0x0F)
+		last = id
+	}
+}
+
+func TestParquetWriter_FooterAndPages(t *testing.T) {
+	var buf bytes.Buffer
+	columns := []export.Column{
+		{Name: "book_key", Type: export.ColumnString},
+		{Name: "price", Type: export.ColumnInt},
+		{Name: "point", Type: export.ColumnFloat, Nullable: true},
+		{Name: "is_latest", Type: export.ColumnBool},
+		{Name: "received_at", Type: export.ColumnTimestamp},
+	}
+	w, err := export.NewParquetWriter(&buf, columns)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	at := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	rows := [][]interface{}{
+		{"fanduel", int64(-110), -3.5, true, at},
+		{"draftkings", int64(150), nil, false, at},
+		{"betmgm", int64(-105), 3.5, true, at},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if err := w.WriteRow([]interface{}{nil, int64(1), nil, true, at}); err == nil {
+		t.Error("expected an error for null in a required column")
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	data := buf.Bytes()
+	if string(data[:4]) != "PAR1" || string(data[len(data)-4:]) != "PAR1" {
+		t.Fatal("missing PAR1 magic")
+	}
+	footerLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footer := (&compactReader{data: data[len(data)-8-footerLen : len(data)-8]}).structure()
+
+	if footer[3].(int64) != 3 {
+		t.Errorf("num_rows = %v, want 3", footer[3])
+	}
+
+	schema := footer[2].([]interface{})
+	if len(schema) != len(columns)+1 || schema[0].(map[int16]interface{})[5].(int64) != int64(len(columns)) {
+		t.Fatalf("unexpected schema root: %v", schema)
+	}
+	point := schema[3].(map[int16]interface{})
+	if point[4] != "point" || point[1].(int64) != 5 || point[3].(int64) != 1 {
+		t.Errorf("point should be an OPTIONAL DOUBLE, got %v", point)
+	}
+
+	rowGroups := footer[4].([]interface{})
+	chunks := rowGroups[0].(map[int16]interface{})[1].([]interface{})
+
+	// Decode the point column's page: definition levels then PLAIN doubles
+	meta := chunks[2].(map[int16]interface{})[3].(map[int16]interface{})
+	page := &compactReader{data: data, pos: int(meta[9].(int64))}
+	header := page.structure()
+	if header[1].(int64) != 0 || header[5].(map[int16]interface{})[1].(int64) != 3 {
+		t.Fatalf("unexpected page header: %v", header)
+	}
+
+	body := data[page.pos : page.pos+int(header[3].(int64))]
+	levelsLen := int(binary.LittleEndian.Uint32(body))
+	values := body[4+levelsLen:]
+	if len(values) != 16 {
+		t.Fatalf("expected 2 non-null doubles, got %d bytes", len(values))
+	}
+	if got := math.Float64frombits(binary.LittleEndian.Uint64(values[8:])); got != 3.5 {
+		t.Errorf("second point = %v, want 3.5", got)
+	}
+
+	// Booleans are bit-packed: true, false, true => 0b101
+	meta = chunks[3].(map[int16]interface{})[3].(map[int16]interface{})
+	page = &compactReader{data: data, pos: int(meta[9].(int64))}
+	page.structure()
+	if data[page.pos] != 0b101 {
+		t.Errorf("packed booleans = %08b, want 00000101", data[page.pos])
+	}
+}
@@ -0,0 +1,202 @@
+package fakeodds_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/fakeodds"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+var start = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+func point(v float64) *float64 { return &v }
+
+// newSlate starts a server with one NBA game: a spread at two books and a points prop
+func newSlate(t *testing.T, opts ...fakeodds.Option) (*fakeodds.Server, *fakeodds.Clock, *theoddsapi.Client) {
+	clock := fakeodds.NewClock(start)
+	server := fakeodds.New(append([]fakeodds.Option{fakeodds.WithClock(clock.Now)}, opts...)...)
+	t.Cleanup(server.Close)
+
+	server.AddEvent(fakeodds.Event{
+		ID:           "e1",
+		SportKey:     "basketball_nba",
+		HomeTeam:     "Los Angeles Lakers",
+		AwayTeam:     "Boston Celtics",
+		CommenceTime: start.Add(6 * time.Hour),
+		Duration:     3 * time.Hour,
+		HomeScore:    54,
+		AwayScore:    50,
+		Lines: []fakeodds.Line{
+			{Book: "fanduel", Market: "spreads", Outcome: "Los Angeles Lakers", Price: -110, Point: point(-3.5)},
+			{Book: "fanduel", Market: "spreads", Outcome: "Boston Celtics", Price: -110, Point: point(3.5)},
+			{Book: "draftkings", Market: "spreads", Outcome: "Los Angeles Lakers", Price: -108, Point: point(-3.5)},
+			{Book: "draftkings", Market: "spreads", Outcome: "Boston Celtics", Price: -112, Point: point(3.5)},
+			{Book: "fanduel", Market: "player_points", Outcome: "Over", Description: "LeBron James", Price: -115, Point: point(25.5)},
+			{Book: "fanduel", Market: "player_points", Outcome: "Under", Description: "LeBron James", Price: -105, Point: point(25.5)},
+		},
+	})
+
+	client := theoddsapi.NewClient("test_key", theoddsapi.WithBaseURL(server.BaseURL()), theoddsapi.WithHTTPClient(server.Client()))
+	return server, clock, client
+}
+
+// fanduelLakers returns FanDuel's Lakers spread from a fetch
+func fanduelLakers(t *testing.T, result *models.FetchResult) models.RawOdds {
+	t.Helper()
+	for _, odd := range result.Odds {
+		if odd.BookKey == "fanduel" && odd.MarketKey == "spreads" && odd.OutcomeName == "Los Angeles Lakers" {
+			return odd
+		}
+	}
+	t.Fatalf("no fanduel Lakers spread in %d odds", len(result.Odds))
+	return models.RawOdds{}
+}
+
+func TestServer_ScriptedMovesApplyAsClockAdvances(t *testing.T) {
+	server, clock, client := newSlate(t)
+	moves := map[string]fakeodds.Move{
+		"Los Angeles Lakers": {At: start.Add(time.Hour), Price: -120, Point: point(-4.5)},
+		"Boston Celtics":     {At: start.Add(time.Hour), Price: 100, Point: point(4.5)},
+	}
+	for outcome, move := range moves {
+		if err := server.Move("e1", "fanduel", "spreads", outcome, move); err != nil {
+			t.Fatalf("move: %v", err)
+		}
+	}
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"spreads"}}
+
+	result, err := client.FetchOdds(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(result.Events) != 1 || len(result.Odds) != 4 {
+		t.Fatalf("expected 1 event and 4 spread odds, got %d and %d", len(result.Events), len(result.Odds))
+	}
+	if odd := fanduelLakers(t, result); odd.Price != -110 || *odd.Point != -3.5 {
+		t.Errorf("expected the opening -3.5 -110, got %v %d", *odd.Point, odd.Price)
+	}
+
+	clock.Advance(90 * time.Minute)
+	result, err = client.FetchOdds(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if odd := fanduelLakers(t, result); odd.Price != -120 || *odd.Point != -4.5 {
+		t.Errorf("expected the moved -4.5 -120, got %v %d", *odd.Point, odd.Price)
+	}
+
+	if got := server.Move("e1", "fanduel", "totals", "Over", fakeodds.Move{At: start}); got == nil {
+		t.Error("expected moving a missing line to fail")
+	}
+}
+
+func TestServer_EventOddsServesProps(t *testing.T) {
+	_, _, client := newSlate(t)
+
+	result, err := client.FetchEventOdds(context.Background(), &models.FetchEventOddsOptions{
+		Sport: "basketball_nba", EventID: "e1", Regions: []string{"us"}, Markets: []string{"player_points"},
+	})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(result.Odds) != 2 || result.Odds[0].Description != "LeBron James" || *result.Odds[0].Point != 25.5 {
+		t.Fatalf("expected LeBron's points line, got %+v", result.Odds)
+	}
+
+	_, err = client.FetchEventOdds(context.Background(), &models.FetchEventOddsOptions{
+		Sport: "basketball_nba", EventID: "missing", Regions: []string{"us"}, Markets: []string{"player_points"},
+	})
+	if merrors.KindOf(err) != merrors.ErrVendorRejected {
+		t.Errorf("expected an unknown event to be rejected, got %v", err)
+	}
+}
+
+func TestServer_ScoresFollowTheClock(t *testing.T) {
+	_, clock, client := newSlate(t)
+	ctx := context.Background()
+
+	scores, err := client.FetchScores(ctx, "basketball_nba", 0)
+	if err != nil || len(scores) != 1 {
+		t.Fatalf("expected 1 score, got %d (%v)", len(scores), err)
+	}
+	if scores[0].HasScores || scores[0].Completed {
+		t.Errorf("expected no score before tip-off, got %+v", scores[0])
+	}
+
+	clock.Set(start.Add(7 * time.Hour))
+	scores, _ = client.FetchScores(ctx, "basketball_nba", 0)
+	if len(scores) != 1 || !scores[0].HasScores || scores[0].Completed || scores[0].HomeScore != "54" {
+		t.Errorf("expected a live 54-50 game, got %+v", scores)
+	}
+
+	clock.Set(start.Add(10 * time.Hour))
+	if scores, _ = client.FetchScores(ctx, "basketball_nba", 0); len(scores) != 0 {
+		t.Errorf("expected completed games only with daysFrom, got %d", len(scores))
+	}
+	scores, _ = client.FetchScores(ctx, "basketball_nba", 1)
+	if len(scores) != 1 || !scores[0].Completed {
+		t.Errorf("expected a completed game, got %+v", scores)
+	}
+
+	events, err := client.FetchEvents(ctx, &models.FetchEventsOptions{Sport: "basketball_nba"})
+	if err != nil || len(events) != 0 {
+		t.Errorf("expected completed games to leave the listing, got %d (%v)", len(events), err)
+	}
+}
+
+func TestServer_ChargesAndRefusesOnceQuotaIsSpent(t *testing.T) {
+	server, _, client := newSlate(t, fakeodds.WithQuota(3))
+	ctx := context.Background()
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"spreads", "totals"}}
+
+	// Only spreads are on the board, so one market is billed
+	if _, err := client.FetchOdds(ctx, opts); err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if server.Remaining() != 2 {
+		t.Errorf("expected 1 credit charged, %d left", server.Remaining())
+	}
+	if limits := client.GetRateLimits(); limits.RequestsRemaining != 2 || limits.RequestsUsed != 1 {
+		t.Errorf("expected the client to see 2 remaining and 1 used, got %+v", limits)
+	}
+
+	opts.Markets = []string{"spreads"}
+	opts.Regions = []string{"us", "us2", "eu"}
+	if _, err := client.FetchOdds(ctx, opts); merrors.KindOf(err) != merrors.ErrQuotaExhausted {
+		t.Errorf("expected quota exhausted, got %v", err)
+	}
+	if server.Remaining() != 2 {
+		t.Errorf("expected a refused request to cost nothing, %d left", server.Remaining())
+	}
+}
+
+func TestServer_ScriptedFailures(t *testing.T) {
+	server, _, client := newSlate(t)
+	server.FailNext(1, http.StatusTooManyRequests, time.Second)
+
+	_, err := client.FetchEvents(context.Background(), &models.FetchEventsOptions{Sport: "basketball_nba"})
+	if err != nil {
+		t.Fatalf("expected the client to retry past one 429, got %v", err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 2 || requests[0].StatusCode != http.StatusTooManyRequests || requests[1].StatusCode != http.StatusOK {
+		t.Errorf("expected a 429 then a 200, got %+v", requests)
+	}
+}
+
+func TestServer_RefusesUnknownKeys(t *testing.T) {
+	_, _, client := newSlate(t, fakeodds.WithAPIKeys("other_key"))
+
+	_, err := client.FetchEvents(context.Background(), &models.FetchEventsOptions{Sport: "basketball_nba"})
+	var classified *merrors.Error
+	if err == nil || !errors.As(err, &classified) || classified.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected a 401, got %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package fixtures_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/adapters/theoddsapi"
+	"github.com/XavierBriggs/Mercury/internal/fixtures"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+const apiKey = "sk-live-0123456789"
+
+func TestRecorder_RedactsAPIKeyAndRoundTrips(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Some error bodies echo the request; make sure the key never reaches disk
+		w.Write([]byte(`[{"id":"e1","sport_key":"basketball_nba","sport_title":"NBA","commence_time":"2025-01-16T00:00:00Z",
+			"home_team":"Lakers","away_team":"Celtics","note":"requested with ` + r.URL.Query().Get("apiKey") + `"}]`))
+	}))
+	defer server.Close()
+
+	root := t.TempDir()
+	recorder := fixtures.NewRecorder(root, apiKey)
+	client := theoddsapi.NewClient(apiKey, theoddsapi.WithBaseURL(server.URL))
+	client.SetPayloadArchiver(recorder)
+
+	if _, err := client.FetchEvents(context.Background(), &models.FetchEventsOptions{Sport: "basketball_nba"}); err != nil {
+		t.Fatalf("fetch events: %v", err)
+	}
+
+	paths := recorder.Recorded()
+	if len(paths) != 1 || !strings.HasSuffix(paths[0], "theoddsapi/v1/basketball_nba/events.json") {
+		t.Fatalf("expected one versioned events fixture, got %v", paths)
+	}
+
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	if strings.Contains(string(data), apiKey) {
+		t.Fatalf("API key leaked into the fixture:\n%s", data)
+	}
+	if !strings.Contains(string(data), "apiKey=REDACTED") {
+		t.Errorf("expected the redacted request to be recorded:\n%s", data)
+	}
+
+	f, err := fixtures.Load(paths[0])
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if f.Kind != models.PayloadKindEvents || f.Sport != "basketball_nba" || f.RecordedAt.IsZero() {
+		t.Errorf("unexpected fixture metadata: %+v", f)
+	}
+
+	result, err := theoddsapi.NewClient("").ParsePayload(f.Payload())
+	if err != nil || len(result.Events) != 1 {
+		t.Errorf("expected the recorded body to parse to 1 event, got %v, %v", result, err)
+	}
+}
+
+func TestRecorder_SkipsNonJSONBodies(t *testing.T) {
+	recorder := fixtures.NewRecorder(t.TempDir())
+	recorder.Archive(models.RawPayload{Vendor: "theoddsapi", Kind: models.PayloadKindOdds, Sport: "basketball_nba", Body: []byte(`[{"id":`)})
+
+	if len(recorder.Recorded()) != 0 || len(recorder.Errors()) != 1 {
+		t.Errorf("expected a truncated body to be rejected, got %v recorded, %v errors", recorder.Recorded(), recorder.Errors())
+	}
+}
+
+func TestLoad_RejectsOtherFormatVersions(t *testing.T) {
+	path := t.TempDir() + "/odds.json"
+	os.WriteFile(path, []byte(`{"format_version":99,"kind":"odds","body":[]}`), 0o644)
+
+	if _, err := fixtures.Load(path); err == nil {
+		t.Error("expected an error for an unknown format version")
+	}
+}
+
+func TestAdapter_ServesGoldenFixtures(t *testing.T) {
+	golden, err := fixtures.LoadAll("../../fixtures", "theoddsapi")
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	adapter := fixtures.NewAdapter(theoddsapi.NewClient(""), golden)
+	ctx := context.Background()
+
+	events, err := adapter.FetchEvents(ctx, &models.FetchEventsOptions{Sport: "basketball_nba"})
+	if err != nil || len(events) == 0 {
+		t.Fatalf("expected recorded events, got %v, %v", events, err)
+	}
+
+	result, err := adapter.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "basketball_nba", Markets: []string{"spreads"}})
+	if err != nil {
+		t.Fatalf("fetch odds: %v", err)
+	}
+	if len(result.Odds) == 0 {
+		t.Fatal("expected recorded spreads")
+	}
+	for _, odd := range result.Odds {
+		if odd.MarketKey != "spreads" {
+			t.Fatalf("expected only spreads, got %s", odd.MarketKey)
+		}
+	}
+
+	var propsEvent string
+	for _, f := range golden {
+		if f.Kind == models.PayloadKindEventOdds {
+			propsEvent = f.EventID
+		}
+	}
+	props, err := adapter.FetchEventOdds(ctx, &models.FetchEventOddsOptions{Sport: "basketball_nba", EventID: propsEvent})
+	if err != nil || len(props.Odds) == 0 {
+		t.Fatalf("expected recorded props for %s, got %v, %v", propsEvent, props, err)
+	}
+
+	if !adapter.SupportsMarket("player_points") || adapter.SupportsMarket("player_blocks") {
+		t.Error("expected SupportsMarket to reflect recorded markets")
+	}
+	if _, err := adapter.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "icehockey_nhl"}); err == nil {
+		t.Error("expected an error for a sport without fixtures")
+	}
+}
+
+func TestAdapter_FiltersEventIDs(t *testing.T) {
+	golden, err := fixtures.LoadAll("../../fixtures", "theoddsapi")
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	adapter := fixtures.NewAdapter(theoddsapi.NewClient(""), golden)
+	ctx := context.Background()
+
+	all, err := adapter.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "basketball_nba"})
+	if err != nil || len(all.Events) < 2 {
+		t.Fatalf("expected at least 2 recorded events, got %+v (%v)", all, err)
+	}
+	target := all.Events[1].EventID
+
+	result, err := adapter.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "basketball_nba", EventIDs: []string{target}})
+	if err != nil {
+		t.Fatalf("fetch odds: %v", err)
+	}
+	if len(result.Events) != 1 || result.Events[0].EventID != target || len(result.Odds) == 0 {
+		t.Fatalf("expected odds for event %s only, got %d events and %d odds", target, len(result.Events), len(result.Odds))
+	}
+	for _, odd := range result.Odds {
+		if odd.EventID != target {
+			t.Fatalf("odd for untargeted event %s", odd.EventID)
+		}
+	}
+}
+
+func TestAdapter_AppliesCommenceWindow(t *testing.T) {
+	golden, err := fixtures.LoadAll("../../fixtures", "theoddsapi")
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	adapter := fixtures.NewAdapter(theoddsapi.NewClient(""), golden)
+	ctx := context.Background()
+
+	// Recorded games tip off at 01:10 and 03:10 UTC; keep only the first
+	to := time.Date(2025, 11, 7, 2, 0, 0, 0, time.UTC)
+
+	events, err := adapter.FetchEvents(ctx, &models.FetchEventsOptions{Sport: "basketball_nba", CommenceTimeTo: to})
+	if err != nil || len(events) != 1 {
+		t.Fatalf("expected 1 event in window, got %d (%v)", len(events), err)
+	}
+
+	result, err := adapter.FetchOdds(ctx, &models.FetchOddsOptions{Sport: "basketball_nba", CommenceTimeTo: to})
+	if err != nil {
+		t.Fatalf("fetch odds: %v", err)
+	}
+	if len(result.Events) != 1 || len(result.Odds) == 0 {
+		t.Fatalf("expected odds for one event, got %d events and %d odds", len(result.Events), len(result.Odds))
+	}
+	for _, odd := range result.Odds {
+		if odd.EventID != result.Events[0].EventID {
+			t.Fatalf("odd for event %s outside the window", odd.EventID)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package freshness_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/freshness"
+)
+
+func TestHistogram_Quantiles(t *testing.T) {
+	h := freshness.NewHistogram()
+	for i := 0; i < 90; i++ {
+		h.Observe(800 * time.Millisecond)
+	}
+	for i := 0; i < 9; i++ {
+		h.Observe(12 * time.Second)
+	}
+	h.Observe(time.Hour)
+
+	tests := []struct {
+		q     float64
+		bound time.Duration
+		over  bool
+	}{
+		{0.5, time.Second, false},
+		{0.9, time.Second, false},
+		{0.95, 15 * time.Second, false},
+		{0.99, 15 * time.Second, false},
+		{1, 30 * time.Minute, true},
+	}
+	for _, tt := range tests {
+		bound, over := h.Quantile(tt.q)
+		if bound != tt.bound || over != tt.over {
+			t.Errorf("Quantile(%v) = %v, %v; want %v, %v", tt.q, bound, over, tt.bound, tt.over)
+		}
+	}
+
+	if h.Count() != 100 {
+		t.Errorf("expected 100 observations, got %d", h.Count())
+	}
+	want := (90*800*time.Millisecond + 9*12*time.Second + time.Hour) / 100
+	if h.Mean() != want {
+		t.Errorf("expected mean %v, got %v", want, h.Mean())
+	}
+}
+
+func TestHistogram_NegativeLagCountsAsZero(t *testing.T) {
+	h := freshness.NewHistogram()
+	h.Observe(-3 * time.Second)
+
+	if bound, _ := h.Quantile(0.5); bound != time.Second || h.Mean() != 0 {
+		t.Errorf("expected a clock-skewed lag in the first bucket, got %v (mean %v)", bound, h.Mean())
+	}
+}
+
+func TestHistogram_Merge(t *testing.T) {
+	a := freshness.NewHistogram()
+	a.Observe(time.Second)
+	b := freshness.NewHistogram()
+	b.Observe(time.Minute)
+	b.Observe(time.Minute)
+
+	a.Merge(b)
+	if a.Count() != 3 {
+		t.Fatalf("expected 3 observations, got %d", a.Count())
+	}
+	if bound, _ := a.Quantile(0.5); bound != time.Minute {
+		t.Errorf("expected median 1m, got %v", bound)
+	}
+}
+
+func TestHistogram_Empty(t *testing.T) {
+	h := freshness.NewHistogram()
+	if bound, over := h.Quantile(0.9); bound != 0 || over || h.Mean() != 0 {
+		t.Errorf("expected zero quantile and mean for an empty histogram, got %v %v %v", bound, over, h.Mean())
+	}
+}
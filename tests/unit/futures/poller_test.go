@@ -0,0 +1,61 @@
+package futures_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/futures"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func baseFuture() models.FuturesOdds {
+	return models.FuturesOdds{
+		SportKey:         "basketball_nba",
+		FuturesKey:       "basketball_nba_championship_winner",
+		MarketKey:        "outrights",
+		BookKey:          "pinnacle",
+		OutcomeName:      "Boston Celtics",
+		Price:            -110,
+		DecimalPrice:     1.909,
+		VendorLastUpdate: time.Date(2025, 1, 15, 11, 59, 0, 0, time.UTC),
+		ReceivedAt:       time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestCacheValue_ChangeDetection(t *testing.T) {
+	point := 58.5
+	otherPoint := 59.5
+
+	tests := []struct {
+		name    string
+		modify  func(odd *models.FuturesOdds)
+		changed bool
+	}{
+		{"same quote", func(odd *models.FuturesOdds) {}, false},
+		{"new receive and vendor times", func(odd *models.FuturesOdds) {
+			odd.ReceivedAt = odd.ReceivedAt.Add(time.Minute)
+			odd.VendorLastUpdate = odd.VendorLastUpdate.Add(time.Minute)
+		}, false},
+		{"american price", func(odd *models.FuturesOdds) { odd.Price, odd.DecimalPrice = -105, 1.952 }, true},
+		// 1.909 and 1.91 both round to -110
+		{"decimal price only", func(odd *models.FuturesOdds) { odd.DecimalPrice = 1.91 }, true},
+		{"point added", func(odd *models.FuturesOdds) { odd.Point = &point }, true},
+	}
+
+	before := futures.CacheValue(baseFuture())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			odd := baseFuture()
+			tt.modify(&odd)
+			if changed := futures.CacheValue(odd) != before; changed != tt.changed {
+				t.Errorf("changed = %v, want %v", changed, tt.changed)
+			}
+		})
+	}
+
+	withPoint, withOtherPoint := baseFuture(), baseFuture()
+	withPoint.Point, withOtherPoint.Point = &point, &otherPoint
+	if futures.CacheValue(withPoint) == futures.CacheValue(withOtherPoint) {
+		t.Error("expected a point move to change the cache value")
+	}
+}
@@ -0,0 +1,107 @@
+package grpcapi_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/grpcapi"
+)
+
+func TestOddsDelta_RoundTrip(t *testing.T) {
+	point, limit, zero := -3.5, 500.0, 0.0
+	want := grpcapi.OddsDelta{
+		Odds: &grpcapi.Odds{
+			EventID:          "e1",
+			SportKey:         "basketball_nba",
+			MarketKey:        "spreads",
+			BookKey:          "fanduel",
+			OutcomeName:      "Los Angeles Lakers",
+			Price:            -110,
+			PriceDecimal:     1.91,
+			Point:            &point,
+			Limit:            &limit,
+			DeepLink:         "https://example.com/bet",
+			VendorLastUpdate: time.Date(2025, 1, 15, 11, 59, 58, 0, time.UTC),
+			ReceivedAt:       time.Date(2025, 1, 15, 12, 0, 0, 250, time.UTC),
+		},
+		CommittedAt: time.Date(2025, 1, 15, 12, 0, 0, 900, time.UTC),
+	}
+
+	var got grpcapi.OddsDelta
+	if err := got.UnmarshalProto(want.MarshalProto()); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got.Odds, want.Odds)
+	}
+
+	// An optional point of zero (pick'em) keeps its presence
+	want.Odds.Point = &zero
+	if err := got.UnmarshalProto(want.MarshalProto()); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Odds.Point == nil || *got.Odds.Point != 0 {
+		t.Errorf("expected a zero point, got %v", got.Odds.Point)
+	}
+}
+
+func TestOdds_NegativePriceIsInt32(t *testing.T) {
+	// proto3 int32 sign-extends negative values to a ten-byte varint
+	got := (&grpcapi.Odds{Price: -110}).MarshalProto()
+	want := []byte{7<<3 | 0, 0x92, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}
+	if !bytes.Equal(got, want) {
+		t.Errorf("expected % x, got % x", want, got)
+	}
+}
+
+func TestRequests_RoundTrip(t *testing.T) {
+	from := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	events := grpcapi.ListEventsRequest{SportKey: "basketball_nba", Statuses: []string{"upcoming", "live"}, CommenceFrom: &from, Limit: 50}
+	var gotEvents grpcapi.ListEventsRequest
+	if err := gotEvents.UnmarshalProto(events.MarshalProto()); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(gotEvents, events) {
+		t.Errorf("expected %+v, got %+v", events, gotEvents)
+	}
+
+	latest := grpcapi.GetLatestOddsRequest{EventID: "e1", MarketKeys: []string{"h2h", "spreads"}, BookKeys: []string{"fanduel"}}
+	var gotLatest grpcapi.GetLatestOddsRequest
+	if err := gotLatest.UnmarshalProto(latest.MarshalProto()); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(gotLatest, latest) {
+		t.Errorf("expected %+v, got %+v", latest, gotLatest)
+	}
+
+	lines := grpcapi.GetBestLinesResponse{Lines: []*grpcapi.BestLine{
+		{EventID: "e1", MarketKey: "h2h", OutcomeName: "Lakers", BookKey: "novig", Price: 125, PriceDecimal: 2.25,
+			Books: []string{"novig", "pinnacle"}, BookCount: 6, ReceivedAt: from},
+	}}
+	var gotLines grpcapi.GetBestLinesResponse
+	if err := gotLines.UnmarshalProto(lines.MarshalProto()); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(gotLines, lines) {
+		t.Errorf("expected %+v, got %+v", lines.Lines[0], gotLines.Lines[0])
+	}
+}
+
+func TestUnmarshal_SkipsUnknownFieldsAndRejectsTruncated(t *testing.T) {
+	data := append((&grpcapi.StreamDeltasRequest{SportKeys: []string{"basketball_nba"}}).MarshalProto(),
+		15<<3|0, 0x01, // Unknown varint field 15
+		14<<3|5, 1, 2, 3, 4) // Unknown fixed32 field 14
+	var req grpcapi.StreamDeltasRequest
+	if err := req.UnmarshalProto(data); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(req.SportKeys) != 1 || req.SportKeys[0] != "basketball_nba" {
+		t.Errorf("unexpected request %+v", req)
+	}
+
+	if err := req.UnmarshalProto([]byte{0x0a, 0x05, 'n', 'b'}); err == nil {
+		t.Error("expected an error for a truncated string")
+	}
+}
@@ -0,0 +1,255 @@
+package grpcapi_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/grpcapi"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// eventsDriver answers every query with the same event rows, so ListEvents can run
+// without Postgres
+type eventsDriver struct{}
+
+func (eventsDriver) Open(string) (driver.Conn, error) { return eventsConn{}, nil }
+
+type eventsConn struct{}
+
+func (eventsConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (eventsConn) Close() error                        { return nil }
+func (eventsConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (eventsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return &eventRows{rows: [][]driver.Value{
+		{"e1", "basketball_nba", "Los Angeles Lakers", "Boston Celtics", time.Date(2025, 1, 16, 0, 30, 0, 0, time.UTC), "upcoming"},
+		{"e2", "basketball_nba", "Denver Nuggets", "Miami Heat", time.Date(2025, 1, 16, 2, 0, 0, 0, time.UTC), "upcoming"},
+	}}, nil
+}
+
+type eventRows struct {
+	rows [][]driver.Value
+}
+
+func (r *eventRows) Columns() []string {
+	return []string{"event_id", "sport_key", "home_team", "away_team", "commence_time", "event_status"}
+}
+
+func (r *eventRows) Close() error { return nil }
+
+func (r *eventRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func init() {
+	sql.Register("grpcapi-events", eventsDriver{})
+}
+
+// startServer serves svc on a free port
+func startServer(t *testing.T, svc *grpcapi.Service) *grpcapi.Server {
+	t.Helper()
+	server := grpcapi.NewServer("127.0.0.1:0", svc)
+	if err := server.Start(context.Background()); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	return server
+}
+
+// grpcClient speaks HTTP/2 with prior knowledge, as gRPC clients do for plaintext targets
+func grpcClient() *http.Client {
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	return &http.Client{Transport: &http.Transport{Protocols: &protocols}}
+}
+
+// frame length-prefixes a message (flag 0 = uncompressed)
+func frame(flag byte, msg []byte) []byte {
+	prefix := make([]byte, 5)
+	prefix[0] = flag
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(msg)))
+	return append(prefix, msg...)
+}
+
+func readFrame(r io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+	_, err := io.ReadFull(r, msg)
+	return msg, err
+}
+
+func invoke(t *testing.T, ctx context.Context, server *grpcapi.Server, method string, body []byte, header http.Header) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+server.Addr()+grpcapi.ServicePath+method, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	resp, err := grpcClient().Do(req)
+	if err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("%s: expected HTTP/2, got %s", method, resp.Proto)
+	}
+	return resp
+}
+
+// grpcStatus returns the call's status, from the headers of a trailers-only response
+// or from the trailers once the body is read
+func grpcStatus(resp *http.Response) string {
+	if status := resp.Header.Get("Grpc-Status"); status != "" {
+		return status
+	}
+	return resp.Trailer.Get("Grpc-Status")
+}
+
+func TestServer_ListEvents(t *testing.T) {
+	db, err := sql.Open("grpcapi-events", "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	server := startServer(t, grpcapi.NewService(db))
+	defer server.Stop()
+
+	req := &grpcapi.ListEventsRequest{SportKey: "basketball_nba", Statuses: []string{"upcoming"}, Limit: 10}
+	resp := invoke(t, context.Background(), server, "ListEvents", frame(0, req.MarshalProto()), nil)
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "application/grpc" {
+		t.Errorf("expected content-type application/grpc, got %q", ct)
+	}
+	data, err := readFrame(resp.Body)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	var events grpcapi.ListEventsResponse
+	if err := events.UnmarshalProto(data); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(events.Events) != 2 || events.Events[0].EventID != "e1" || events.Events[1].HomeTeam != "Denver Nuggets" {
+		t.Fatalf("unexpected events %+v", events.Events)
+	}
+	if want := time.Date(2025, 1, 16, 0, 30, 0, 0, time.UTC); !events.Events[0].CommenceTime.Equal(want) {
+		t.Errorf("expected commence time %v, got %v", want, events.Events[0].CommenceTime)
+	}
+
+	if _, err := io.ReadAll(resp.Body); err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	if status := grpcStatus(resp); status != "0" {
+		t.Errorf("expected status 0, got %q (%s)", status, resp.Trailer.Get("Grpc-Message"))
+	}
+}
+
+func TestServer_StreamDeltas(t *testing.T) {
+	svc := grpcapi.NewService(nil)
+	server := startServer(t, svc)
+	stopped := false
+	defer func() {
+		if !stopped {
+			server.Stop()
+		}
+	}()
+
+	req := &grpcapi.StreamDeltasRequest{SportKeys: []string{"basketball_nba"}, MarketKeys: []string{"spreads"}}
+	resp := invoke(t, context.Background(), server, "StreamDeltas", frame(0, req.MarshalProto()), nil)
+	defer resp.Body.Close()
+	waitForSubscribers(t, svc, 1)
+
+	point := -3.5
+	committedAt := time.Date(2025, 1, 15, 12, 0, 0, 500, time.UTC)
+	svc.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{
+		Odds: []models.RawOdds{
+			{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "fanduel", OutcomeName: "Lakers", Price: 120},
+			{EventID: "e1", SportKey: "basketball_nba", MarketKey: "spreads", BookKey: "fanduel", OutcomeName: "Lakers", Price: -110, Point: &point},
+		},
+		CommittedAt: committedAt,
+	})
+
+	data, err := readFrame(resp.Body)
+	if err != nil {
+		t.Fatalf("read delta: %v", err)
+	}
+	var delta grpcapi.OddsDelta
+	if err := delta.UnmarshalProto(data); err != nil {
+		t.Fatalf("decode delta: %v", err)
+	}
+	if delta.Odds == nil || delta.Odds.MarketKey != "spreads" || delta.Odds.Price != -110 ||
+		delta.Odds.Point == nil || *delta.Odds.Point != point {
+		t.Fatalf("unexpected delta %+v", delta.Odds)
+	}
+	if !delta.CommittedAt.Equal(committedAt) {
+		t.Errorf("expected committed at %v, got %v", committedAt, delta.CommittedAt)
+	}
+
+	// Stopping the server ends the stream with UNAVAILABLE after the last delta
+	server.Stop()
+	stopped = true
+	rest, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("drain: %v", err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("expected the filtered h2h delta not to be sent, got %d more bytes", len(rest))
+	}
+	if status := grpcStatus(resp); status != "14" {
+		t.Errorf("expected status 14 on shutdown, got %q", status)
+	}
+}
+
+func TestServer_ErrorStatuses(t *testing.T) {
+	server := startServer(t, grpcapi.NewService(nil))
+	defer server.Stop()
+
+	tests := []struct {
+		name   string
+		method string
+		body   []byte
+		header http.Header
+		want   string
+	}{
+		{"missing event", "GetLatestOdds", frame(0, (&grpcapi.GetLatestOddsRequest{}).MarshalProto()), nil, "3"},
+		{"no best-line cache", "GetBestLines", frame(0, (&grpcapi.GetBestLinesRequest{EventID: "e1"}).MarshalProto()), nil, "14"},
+		{"unknown method", "GetEverything", frame(0, nil), nil, "12"},
+		{"no request message", "ListEvents", nil, nil, "3"},
+		{"compressed request", "ListEvents", frame(1, []byte{0x0a, 0x01, 'x'}), nil, "12"},
+		{"malformed request", "ListEvents", frame(0, []byte{0x0a, 0x05, 'x'}), nil, "3"},
+		{"bad timeout", "ListEvents", frame(0, nil), http.Header{"Grpc-Timeout": {"soon"}}, "3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := invoke(t, context.Background(), server, tt.method, tt.body, tt.header)
+			defer resp.Body.Close()
+			io.ReadAll(resp.Body)
+
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("expected HTTP 200, got %d", resp.StatusCode)
+			}
+			if status := grpcStatus(resp); status != tt.want {
+				t.Errorf("expected status %s, got %q (%s)", tt.want, status, resp.Header.Get("Grpc-Message"))
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package grpcapi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/grpcapi"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// fakeStream collects sent deltas
+type fakeStream struct {
+	ctx  context.Context
+	sent chan *grpcapi.OddsDelta
+}
+
+func (f *fakeStream) Send(d *grpcapi.OddsDelta) error {
+	f.sent <- d
+	return nil
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func waitForSubscribers(t *testing.T, svc *grpcapi.Service, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for svc.Subscribers() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d subscribers, got %d", n, svc.Subscribers())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestStreamDeltas_FiltersAndDelivers(t *testing.T) {
+	svc := grpcapi.NewService(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeStream{ctx: ctx, sent: make(chan *grpcapi.OddsDelta, 10)}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.StreamDeltas(&grpcapi.StreamDeltasRequest{MarketKeys: []string{"spreads"}}, stream)
+	}()
+	waitForSubscribers(t, svc, 1)
+
+	point := -3.5
+	svc.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{
+		Odds: []models.RawOdds{
+			{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "fanduel", OutcomeName: "Lakers", Price: 120},
+			{EventID: "e1", SportKey: "basketball_nba", MarketKey: "spreads", BookKey: "fanduel", OutcomeName: "Lakers", Price: -110, Point: &point},
+		},
+		CommittedAt: time.Now(),
+	})
+
+	select {
+	case d := <-stream.sent:
+		if d.Odds.MarketKey != "spreads" || d.Odds.Point == nil || *d.Odds.Point != point {
+			t.Errorf("unexpected delta: %+v", d.Odds)
+		}
+		if d.Odds.PriceDecimal == 0 {
+			t.Error("expected decimal price to be populated")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a spreads delta")
+	}
+
+	select {
+	case d := <-stream.sent:
+		t.Errorf("expected h2h delta to be filtered, got %+v", d.Odds)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	waitForSubscribers(t, svc, 0)
+}
+
+func TestGetLatestOdds_RequiresEventID(t *testing.T) {
+	svc := grpcapi.NewService(nil)
+
+	if _, err := svc.GetLatestOdds(context.Background(), &grpcapi.GetLatestOddsRequest{}); !errors.Is(err, grpcapi.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := svc.ListEvents(context.Background(), &grpcapi.ListEventsRequest{}); !errors.Is(err, grpcapi.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+}
+
+func TestGetBestLines_RequiresCache(t *testing.T) {
+	svc := grpcapi.NewService(nil)
+
+	if _, err := svc.GetBestLines(context.Background(), &grpcapi.GetBestLinesRequest{}); !errors.Is(err, grpcapi.ErrInvalidArgument) {
+		t.Errorf("expected ErrInvalidArgument, got %v", err)
+	}
+	if _, err := svc.GetBestLines(context.Background(), &grpcapi.GetBestLinesRequest{EventID: "e1"}); !errors.Is(err, grpcapi.ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
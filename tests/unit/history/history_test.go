@@ -0,0 +1,77 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/history"
+)
+
+var start = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+// points returns one quote per offset, priced -100 minus its index
+func points(offsets ...time.Duration) []history.Point {
+	result := make([]history.Point, len(offsets))
+	for i, offset := range offsets {
+		result[i] = history.Point{Price: -100 - i, ReceivedAt: start.Add(offset)}
+	}
+	return result
+}
+
+func prices(points []history.Point) []int {
+	result := make([]int, len(points))
+	for i, p := range points {
+		result[i] = p.Price
+	}
+	return result
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestDownsample_KeepsLastQuotePerInterval(t *testing.T) {
+	in := points(0, time.Minute, 4*time.Minute, 5*time.Minute, 9*time.Minute, 16*time.Minute)
+
+	got := prices(history.Downsample(in, 5*time.Minute))
+	if want := []int{-102, -104, -105}; !equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := history.Downsample(in, 0); len(got) != len(in) {
+		t.Errorf("expected every point without an interval, got %d", len(got))
+	}
+	if got := history.Downsample(nil, time.Minute); len(got) != 0 {
+		t.Errorf("expected no points, got %d", len(got))
+	}
+}
+
+func TestThin_KeepsFirstAndLast(t *testing.T) {
+	in := make([]time.Duration, 10)
+	for i := range in {
+		in[i] = time.Duration(i) * time.Minute
+	}
+	all := points(in...)
+
+	got := prices(history.Thin(all, 4))
+	if want := []int{-100, -103, -106, -109}; !equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got := prices(history.Thin(all, 1)); !equal(got, []int{-109}) {
+		t.Errorf("expected only the latest quote, got %v", got)
+	}
+	if got := history.Thin(all, 20); len(got) != len(all) {
+		t.Errorf("expected every point under the cap, got %d", len(got))
+	}
+	if got := history.Thin(all, 0); len(got) != len(all) {
+		t.Errorf("expected every point without a cap, got %d", len(got))
+	}
+}
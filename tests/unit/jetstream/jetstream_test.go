@@ -0,0 +1,236 @@
+package jetstream_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/jetstream"
+	"github.com/XavierBriggs/Mercury/pkg/consumer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestSubject(t *testing.T) {
+	msg := models.StreamMessage{SportKey: "basketball_nba", MarketKey: "spreads", BookKey: "draft.kings", EventStatus: "live"}
+
+	if got := jetstream.Subject(jetstream.DefaultSubjectTemplate, msg); got != "mercury.odds.basketball_nba.spreads" {
+		t.Errorf("Subject = %q", got)
+	}
+	if got := jetstream.Subject("odds.{status}.{sport}.{book}.{event}", msg); got != "odds.live.basketball_nba.draft_kings._" {
+		t.Errorf("Subject = %q", got)
+	}
+	if got := jetstream.SubjectFilter("odds.{status}.{sport}.x_{market}"); got != "odds.*.*.*" {
+		t.Errorf("SubjectFilter = %q", got)
+	}
+}
+
+func TestValidateSubjectTemplate(t *testing.T) {
+	for _, template := range []string{"mercury.odds.{sport}.{market}", "odds.{status}.{book}"} {
+		if err := jetstream.ValidateSubjectTemplate(template); err != nil {
+			t.Errorf("%q: %v", template, err)
+		}
+	}
+	for _, template := range []string{"odds..{sport}", "odds.*", "odds.{league}", "odds.{sport", "odds.>"} {
+		if err := jetstream.ValidateSubjectTemplate(template); err == nil {
+			t.Errorf("expected %q to be rejected", template)
+		}
+	}
+}
+
+// published is one HPUB the fake server received
+type published struct {
+	subject string
+	headers string
+	payload []byte
+}
+
+// fakeServer speaks enough of the NATS protocol to answer JetStream API requests
+// and acknowledge publishes
+type fakeServer struct {
+	ln       net.Listener
+	mu       sync.Mutex
+	pubs     []published
+	creates  []map[string]interface{}
+	streamOK bool
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeServer{ln: ln}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeServer) url() string {
+	return "nats://user:secret@" + s.ln.Addr().String()
+}
+
+func (s *fakeServer) serve() {
+	for {
+		nc, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(nc)
+	}
+}
+
+func (s *fakeServer) handle(nc net.Conn) {
+	defer nc.Close()
+	r := bufio.NewReader(nc)
+	fmt.Fprint(nc, "INFO {\"headers\":true,\"max_payload\":1048576}\r\n")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "PING":
+			fmt.Fprint(nc, "PONG\r\n")
+		case "HPUB":
+			hdrLen, _ := strconv.Atoi(fields[3])
+			total, _ := strconv.Atoi(fields[4])
+			buf := make([]byte, total+2)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			reply := s.respond(fields[1], string(buf[:hdrLen]), buf[hdrLen:total])
+			fmt.Fprintf(nc, "MSG %s 1 %d\r\n%s\r\n", fields[2], len(reply), reply)
+		}
+	}
+}
+
+func (s *fakeServer) respond(subject, headers string, payload []byte) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case subject == "$JS.API.STREAM.INFO.MERCURY_ODDS":
+		if !s.streamOK {
+			return `{"error":{"code":404,"description":"stream not found"}}`
+		}
+		return `{"config":{}}`
+	case strings.HasPrefix(subject, "$JS.API.STREAM.CREATE."):
+		var config map[string]interface{}
+		json.Unmarshal(payload, &config)
+		s.creates = append(s.creates, config)
+		s.streamOK = true
+		return `{"config":{}}`
+	}
+
+	s.pubs = append(s.pubs, published{subject: subject, headers: headers, payload: append([]byte(nil), payload...)})
+	return fmt.Sprintf(`{"stream":"MERCURY_ODDS","seq":%d}`, len(s.pubs))
+}
+
+func TestSink_CreatesStreamAndPublishes(t *testing.T) {
+	server := newFakeServer(t)
+	sink, err := jetstream.NewSink(jetstream.Config{URL: server.url(), MaxAge: 72 * time.Hour, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	defer sink.Close()
+
+	ctx := context.Background()
+	if err := sink.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	messages := []models.StreamMessage{
+		{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", OutcomeName: "Boston Celtics", Price: -150, DedupeKey: "k1"},
+		{EventID: "e1", SportKey: "basketball_nba", MarketKey: "spreads", OutcomeName: "Miami Heat", Price: -110, DedupeKey: "k2"},
+	}
+	if err := sink.Publish(ctx, messages); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if published, failed := sink.Stats(); published != 2 || failed != 0 {
+		t.Errorf("Stats = %d published, %d failed", published, failed)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if len(server.creates) != 1 {
+		t.Fatalf("expected the stream to be created once, got %d", len(server.creates))
+	}
+	config := server.creates[0]
+	if subjects, _ := config["subjects"].([]interface{}); len(subjects) != 1 || subjects[0] != "mercury.odds.*.*" {
+		t.Errorf("unexpected stream subjects %v", config["subjects"])
+	}
+	if config["max_age"] != float64(72*time.Hour) || config["storage"] != "file" {
+		t.Errorf("unexpected stream limits %v", config)
+	}
+
+	if len(server.pubs) != 2 {
+		t.Fatalf("expected 2 publishes, got %d", len(server.pubs))
+	}
+	if server.pubs[1].subject != "mercury.odds.basketball_nba.spreads" {
+		t.Errorf("unexpected subject %q", server.pubs[1].subject)
+	}
+	if !strings.Contains(server.pubs[0].headers, "Nats-Msg-Id: k1\r\n") {
+		t.Errorf("expected the dedupe key as Nats-Msg-Id, got %q", server.pubs[0].headers)
+	}
+	var decoded models.StreamMessage
+	if err := json.Unmarshal(server.pubs[0].payload, &decoded); err != nil || decoded.Price != -150 {
+		t.Errorf("unexpected payload %s", server.pubs[0].payload)
+	}
+}
+
+func TestSink_SignsPayloads(t *testing.T) {
+	server := newFakeServer(t)
+	sink, err := jetstream.NewSink(jetstream.Config{URL: server.url(), Timeout: 2 * time.Second, Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	defer sink.Close()
+
+	message := models.StreamMessage{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", Price: -150, DedupeKey: "k1"}
+	if err := sink.Publish(context.Background(), []models.StreamMessage{message}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.pubs) != 1 {
+		t.Fatalf("expected 1 publish, got %d", len(server.pubs))
+	}
+	pub := server.pubs[0]
+	want := jetstream.HeaderSignature + ": " + consumer.SignPayload("s3cret", pub.payload) + "\r\n"
+	if !strings.Contains(pub.headers, want) {
+		t.Errorf("expected %q in headers, got %q", want, pub.headers)
+	}
+}
+
+func TestSink_ReportsConnectFailure(t *testing.T) {
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := ln.Addr().String()
+	ln.Close()
+
+	sink, err := jetstream.NewSink(jetstream.Config{URL: "nats://" + addr, Timeout: time.Second})
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.Publish(context.Background(), []models.StreamMessage{{EventID: "e1"}}); err == nil {
+		t.Fatal("expected a connect error")
+	}
+	if _, failed := sink.Stats(); failed != 1 {
+		t.Errorf("expected 1 failed message, got %d", failed)
+	}
+}
@@ -0,0 +1,93 @@
+package latency_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/latency"
+)
+
+func TestHistogram_QuantilesAndWithin(t *testing.T) {
+	h := latency.NewHistogram()
+	for i := 0; i < 95; i++ {
+		h.Observe(4 * time.Millisecond)
+	}
+	for i := 0; i < 4; i++ {
+		h.Observe(40 * time.Millisecond)
+	}
+	h.Observe(time.Minute)
+
+	tests := []struct {
+		q     float64
+		bound time.Duration
+		over  bool
+	}{
+		{0.5, 5 * time.Millisecond, false},
+		{0.95, 5 * time.Millisecond, false},
+		{0.99, 50 * time.Millisecond, false},
+		{1, 30 * time.Second, true},
+	}
+	for _, tt := range tests {
+		bound, over := h.Quantile(tt.q)
+		if bound != tt.bound || over != tt.over {
+			t.Errorf("Quantile(%v) = %v, %v; want %v, %v", tt.q, bound, over, tt.bound, tt.over)
+		}
+	}
+
+	if got := h.Within(30 * time.Millisecond); got != 0.95 {
+		t.Errorf("expected 95%% within 30ms, got %v", got)
+	}
+	// Between bounds the share is conservative: 40ms only counts within 50ms
+	if got := h.Within(45 * time.Millisecond); got != 0.95 {
+		t.Errorf("expected 95%% within 45ms, got %v", got)
+	}
+	if got := h.FormatQuantile(1); got != ">30s" {
+		t.Errorf("expected >30s, got %q", got)
+	}
+}
+
+func TestRecorder_ObservePollAddsPipeline(t *testing.T) {
+	r := latency.NewRecorder(nil, time.Minute, 0)
+	r.ObservePoll(map[string]time.Duration{
+		"fetch": 300 * time.Millisecond,
+		"delta": 2 * time.Millisecond,
+		"write": 12 * time.Millisecond,
+		"cache": time.Millisecond,
+	})
+	r.ObservePoll(map[string]time.Duration{"fetch": 200 * time.Millisecond, "delta": 40 * time.Millisecond})
+	// A poll without odds never reached the pipeline
+	r.ObservePoll(map[string]time.Duration{"fetch": 100 * time.Millisecond})
+	r.Observe(latency.StagePublish, 3*time.Millisecond)
+
+	stages, err := r.Flush(context.Background())
+	if err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	var names []string
+	counts := make(map[string]int64)
+	for _, stage := range stages {
+		names = append(names, stage.Stage)
+		counts[stage.Stage] = stage.Histogram.Count()
+	}
+	if got := strings.Join(names, ","); got != "fetch,delta,write,publish,cache,pipeline" {
+		t.Fatalf("expected stages in pipeline order, got %s", got)
+	}
+	if counts["fetch"] != 3 || counts["delta"] != 2 || counts["write"] != 1 || counts["pipeline"] != 2 {
+		t.Errorf("unexpected counts %v", counts)
+	}
+
+	pipeline := stages[len(stages)-1].Histogram
+	if pipeline.Sum != 55*time.Millisecond {
+		t.Errorf("expected pipeline sum of delta + write + cache, got %v", pipeline.Sum)
+	}
+	if got := latency.Summary(stages, 30*time.Millisecond); !strings.Contains(got, "pipeline 50.0% ≤30ms") {
+		t.Errorf("expected the SLO share in the summary, got %q", got)
+	}
+
+	if again, _ := r.Flush(context.Background()); len(again) != 0 {
+		t.Errorf("expected a flush to start a new interval, got %d stages", len(again))
+	}
+}
@@ -0,0 +1,86 @@
+// +build integration
+
+package leader_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/leader"
+	"github.com/redis/go-redis/v9"
+)
+
+func newRedis(t *testing.T) *redis.Client {
+	// Requires Redis running
+	redisClient := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	t.Cleanup(func() { redisClient.Close() })
+	redisClient.Del(context.Background(), leader.Key)
+	return redisClient
+}
+
+func TestElector_FailoverOnRelease(t *testing.T) {
+	redisClient := newRedis(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	first := leader.NewElector(redisClient, "replica-a")
+	first.SetTTL(3 * time.Second)
+	if err := first.Acquire(ctx); err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+	go first.Start(ctx)
+
+	second := leader.NewElector(redisClient, "replica-b")
+	second.SetTTL(3 * time.Second)
+	acquired := make(chan error, 1)
+	go func() { acquired <- second.Acquire(ctx) }()
+
+	select {
+	case <-acquired:
+		t.Fatal("standby acquired while the leader held the lease")
+	case <-time.After(2 * time.Second):
+	}
+
+	first.Stop()
+	if err := <-acquired; err != nil {
+		t.Fatalf("standby acquire: %v", err)
+	}
+
+	id, _, err := leader.Current(ctx, redisClient)
+	if err != nil || id != "replica-b" {
+		t.Errorf("Current = %q, %v; want replica-b", id, err)
+	}
+	second.Stop()
+}
+
+func TestElector_LostWhenLeaseTaken(t *testing.T) {
+	redisClient := newRedis(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	elector := leader.NewElector(redisClient, "replica-a")
+	elector.SetTTL(3 * time.Second)
+	if err := elector.Acquire(ctx); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	go elector.Start(ctx)
+
+	// Simulate a partition: the lease expired and another replica took it
+	redisClient.Set(ctx, leader.Key, "replica-b", 3*time.Second)
+
+	select {
+	case <-elector.Lost():
+	case <-ctx.Done():
+		t.Fatal("expected leadership to be lost")
+	}
+	if elector.IsLeader() {
+		t.Error("IsLeader should be false after losing the lease")
+	}
+
+	// Stop must not release another replica's lease
+	elector.Stop()
+	if id, _, _ := leader.Current(ctx, redisClient); id != "replica-b" {
+		t.Errorf("lease holder = %q, want replica-b", id)
+	}
+}
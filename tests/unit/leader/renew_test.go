@@ -0,0 +1,117 @@
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/leader"
+	"github.com/redis/go-redis/v9"
+)
+
+// leaseHook answers the elector's commands without Redis: SET NX acquires, and the
+// renew script succeeds the first renewals times, then fails as if Redis were down
+type leaseHook struct {
+	renewals int64
+	attempts atomic.Int64
+}
+
+func (h *leaseHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no network in tests")
+	}
+}
+
+func (h *leaseHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		switch cmd.Name() {
+		case "set":
+			cmd.(*redis.BoolCmd).SetVal(true)
+			return nil
+		case "evalsha", "eval":
+			if h.attempts.Add(1) <= h.renewals {
+				cmd.(*redis.Cmd).SetVal(int64(1))
+				return nil
+			}
+			err := errors.New("connection refused")
+			cmd.SetErr(err)
+			return err
+		}
+		return errors.New("unexpected command " + cmd.Name())
+	}
+}
+
+func (h *leaseHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// waitFor polls cond until it holds (the elector runs in its own goroutine)
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestElector_LostBeforeLeaseExpiresWhenRenewalsFail(t *testing.T) {
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	hook := &leaseHook{renewals: 1}
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	redisClient.AddHook(hook)
+	defer redisClient.Close()
+
+	const ttl = 15 * time.Second
+	elector := leader.NewElector(redisClient, "replica-a")
+	elector.SetTTL(ttl)
+	elector.SetClock(fake)
+	if err := elector.Acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go elector.Start(ctx)
+	fake.BlockUntil(1)
+
+	lost := func() bool {
+		select {
+		case <-elector.Lost():
+			return true
+		default:
+			return false
+		}
+	}
+
+	// The first renewal (at 5s) succeeds, so the lease now runs until 20s
+	fake.Advance(ttl / 3)
+	waitFor(t, "first renewal", func() bool { return hook.attempts.Load() == 1 })
+	lastRenewed := fake.Now()
+
+	// Every later renewal fails: the next tick (10s) and then retries every 2.5s until
+	// the lease is about to expire
+	for step := ttl / 3; !lost(); step = ttl / 6 {
+		if fake.Now().Sub(lastRenewed) > ttl {
+			t.Fatalf("still leader at %v, after the lease renewed at %v expired", fake.Now().Sub(start), lastRenewed.Sub(start))
+		}
+		attempts := hook.attempts.Load()
+		fake.Advance(step)
+		waitFor(t, "renewal attempt", func() bool { return hook.attempts.Load() > attempts })
+		waitFor(t, "retry or loss", func() bool { return lost() || fake.Waiters() == 2 })
+	}
+
+	if remaining := lastRenewed.Add(ttl).Sub(fake.Now()); remaining <= 0 {
+		t.Errorf("expected leadership lost before the lease expired, %v late", -remaining)
+	}
+	if elector.IsLeader() {
+		t.Error("expected IsLeader false after losing the lease")
+	}
+}
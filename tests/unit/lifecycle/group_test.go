@@ -0,0 +1,108 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/lifecycle"
+)
+
+func TestGroup_FailureCancelsSiblings(t *testing.T) {
+	group, ctx := lifecycle.WithContext(context.Background())
+	boom := errors.New("boom")
+
+	group.Go("sibling", func() error {
+		<-ctx.Done()
+		return nil
+	})
+	group.Go("writer", func() error {
+		return boom
+	})
+
+	err := group.Wait()
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "writer: boom") {
+		t.Errorf("expected the component name in %q", err)
+	}
+	if cause := context.Cause(ctx); !errors.Is(cause, boom) {
+		t.Errorf("expected cause boom, got %v", cause)
+	}
+}
+
+func TestGroup_JoinsEveryFailure(t *testing.T) {
+	group, ctx := lifecycle.WithContext(context.Background())
+
+	group.Go("a", func() error { return errors.New("first") })
+	group.Go("b", func() error {
+		<-ctx.Done()
+		return errors.New("second")
+	})
+
+	err := group.Wait()
+	if err == nil || !strings.Contains(err.Error(), "a: first") || !strings.Contains(err.Error(), "b: second") {
+		t.Fatalf("expected both failures, got %v", err)
+	}
+}
+
+func TestGroup_RecoversPanic(t *testing.T) {
+	group, _ := lifecycle.WithContext(context.Background())
+
+	group.Go("scheduler", func() error {
+		panic("nil map")
+	})
+
+	err := group.Wait()
+	if err == nil || !strings.Contains(err.Error(), "scheduler: panic: nil map") {
+		t.Fatalf("expected recovered panic, got %v", err)
+	}
+}
+
+func TestGroup_CleanShutdown(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	group, ctx := lifecycle.WithContext(parent)
+
+	group.Go("loop", func() error {
+		<-ctx.Done()
+		return nil
+	})
+
+	// Goroutines may be added from inside the group while Wait is blocked
+	group.Go("spawner", func() error {
+		group.Go("child", func() error {
+			<-ctx.Done()
+			return nil
+		})
+		return nil
+	})
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if err := group.Wait(); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+}
+
+func TestFailures_Budget(t *testing.T) {
+	failures := lifecycle.Failures{Max: 3}
+	fail := errors.New("db down")
+
+	if failures.Record(fail) != nil || failures.Record(fail) != nil {
+		t.Fatal("budget exhausted early")
+	}
+
+	// A success resets the streak
+	if failures.Record(nil) != nil {
+		t.Fatal("success returned an error")
+	}
+	failures.Record(fail)
+	failures.Record(fail)
+
+	err := failures.Record(fail)
+	if !errors.Is(err, fail) {
+		t.Fatalf("expected budget error wrapping the last failure, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package migrate_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/XavierBriggs/Mercury/infra/alexandria"
+	"github.com/XavierBriggs/Mercury/internal/migrate"
+)
+
+func TestLoad_SortsByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/010_add_thing.sql":     {Data: []byte("ALTER TABLE t ADD COLUMN x INT;")},
+		"m/002_create_events.sql": {Data: []byte("CREATE TABLE events ();")},
+		"m/001_create_sports.sql": {Data: []byte("CREATE TABLE sports ();")},
+	}
+
+	migrations, err := migrate.Load(fsys, "m")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("expected 3 migrations, got %d", len(migrations))
+	}
+	if migrations[0].Version != 1 || migrations[2].Version != 10 || migrations[2].Name != "add_thing" {
+		t.Errorf("unexpected order: %+v", migrations)
+	}
+	if migrate.Latest(migrations) != 10 {
+		t.Errorf("Latest = %d, want 10", migrate.Latest(migrations))
+	}
+}
+
+func TestLoad_RejectsBadNames(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"no version": {"m/create_events.sql": {Data: []byte("")}},
+		"duplicate": {
+			"m/002_create_events.sql": {Data: []byte("")},
+			"m/002_create_books.sql":  {Data: []byte("")},
+		},
+	}
+	for name, fsys := range cases {
+		if _, err := migrate.Load(fsys, "m"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestEmbeddedMigrations guards the shipped set: contiguous versions from 001 and
+// nothing that manages its own transaction (each migration runs in one)
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := migrate.Load(alexandria.Migrations, "migrations")
+	if err != nil {
+		t.Fatalf("load embedded: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations")
+	}
+
+	for i, mig := range migrations {
+		if mig.Version != i+1 {
+			t.Errorf("migration %03d_%s: expected version %d (versions must be contiguous)", mig.Version, mig.Name, i+1)
+		}
+		upper := strings.ToUpper(mig.SQL)
+		if strings.Contains(upper, "CONCURRENTLY") || strings.Contains(upper, "\nCOMMIT;") {
+			t.Errorf("migration %03d_%s cannot run inside a transaction", mig.Version, mig.Name)
+		}
+	}
+}
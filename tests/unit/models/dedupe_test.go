@@ -0,0 +1,82 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestDedupeKey(t *testing.T) {
+	point := -3.5
+	vendorTS := time.Date(2025, 1, 15, 19, 0, 0, 0, time.UTC)
+	base := models.RawOdds{
+		EventID:          "evt1",
+		MarketKey:        "spreads",
+		BookKey:          "fanduel",
+		OutcomeName:      "Lakers",
+		Price:            -110,
+		Point:            &point,
+		VendorLastUpdate: vendorTS,
+		ReceivedAt:       vendorTS.Add(time.Second),
+	}
+
+	key := base.DedupeKey()
+	if len(key) != 32 {
+		t.Fatalf("expected a 32-char key, got %q", key)
+	}
+	// Keys already stored in odds_raw.dedupe_key must not change
+	if want := "da0dcff5a51fb9a90bcc1a84d9a947d7"; key != want {
+		t.Errorf("expected the stored key %s for a quote without a limit, got %s", want, key)
+	}
+
+	// Receipt time and links are not part of the quote
+	same := base
+	same.ReceivedAt = vendorTS.Add(time.Minute)
+	same.DeepLink = "https://example.com/bet"
+	same.VendorLastUpdate = vendorTS.In(time.FixedZone("EST", -5*3600))
+	if same.DedupeKey() != key {
+		t.Error("the same quote received twice must share a key")
+	}
+
+	otherPoint := -4.5
+	limit := 500.0
+	changes := map[string]func(o *models.RawOdds){
+		"price":       func(o *models.RawOdds) { o.Price = -115 },
+		"point":       func(o *models.RawOdds) { o.Point = &otherPoint },
+		"no point":    func(o *models.RawOdds) { o.Point = nil },
+		"vendor ts":   func(o *models.RawOdds) { o.VendorLastUpdate = vendorTS.Add(time.Second) },
+		"description": func(o *models.RawOdds) { o.Description = "LeBron James" },
+		"book":        func(o *models.RawOdds) { o.BookKey = "draftkings" },
+		"limit":       func(o *models.RawOdds) { o.Limit = &limit },
+	}
+	for name, change := range changes {
+		changed := base
+		change(&changed)
+		if changed.DedupeKey() == key {
+			t.Errorf("changing %s must change the key", name)
+		}
+	}
+}
+
+func TestDedupeKey_LimitOnlyChange(t *testing.T) {
+	vendorTS := time.Date(2025, 1, 15, 19, 0, 0, 0, time.UTC)
+	limit, raised := 500.0, 1000.0
+	quote := models.RawOdds{EventID: "evt1", MarketKey: "h2h", BookKey: "novig", OutcomeName: "Lakers",
+		Price: 120, Limit: &limit, VendorLastUpdate: vendorTS}
+
+	// Books often raise a limit without touching the price or the vendor timestamp
+	moved := quote
+	moved.Limit = &raised
+	moved.ChangeType = "limit"
+	if moved.DedupeKey() == quote.DedupeKey() {
+		t.Error("a limit-only change must not share the previous quote's key")
+	}
+
+	same := raised
+	again := moved
+	again.Limit = &same
+	if again.DedupeKey() != moved.DedupeKey() {
+		t.Error("the same limit fetched twice must share a key")
+	}
+}
@@ -0,0 +1,120 @@
+package models_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestAmericanToDecimal(t *testing.T) {
+	tests := []struct {
+		american int
+		expected float64
+	}{
+		{-110, 1.9091},
+		{150, 2.5},
+		{100, 2.0},
+		{-100, 2.0},
+		{-250, 1.4},
+		{50, 0}, // invalid
+	}
+
+	for _, tt := range tests {
+		got := models.AmericanToDecimal(tt.american)
+		if math.Abs(got-tt.expected) > 0.0001 {
+			t.Errorf("AmericanToDecimal(%d) = %v, want %v", tt.american, got, tt.expected)
+		}
+	}
+}
+
+func TestDecimalToAmerican(t *testing.T) {
+	tests := []struct {
+		decimal  float64
+		expected int
+	}{
+		{1.91, -110},
+		{2.5, 150},
+		{2.0, 100},
+		{1.4, -250},
+		{1.0, 0}, // invalid
+	}
+
+	for _, tt := range tests {
+		if got := models.DecimalToAmerican(tt.decimal); got != tt.expected {
+			t.Errorf("DecimalToAmerican(%v) = %d, want %d", tt.decimal, got, tt.expected)
+		}
+	}
+}
+
+func TestFractionalConversions(t *testing.T) {
+	decimal, err := models.FractionalToDecimal("5/2")
+	if err != nil || decimal != 3.5 {
+		t.Errorf("FractionalToDecimal(5/2) = %v, %v; want 3.5", decimal, err)
+	}
+
+	if decimal, err := models.FractionalToDecimal("evs"); err != nil || decimal != 2 {
+		t.Errorf("FractionalToDecimal(evs) = %v, %v; want 2", decimal, err)
+	}
+
+	if _, err := models.FractionalToDecimal("5-2"); err == nil {
+		t.Error("expected error for malformed fraction")
+	}
+
+	if got := models.DecimalToFractional(1.9091); got != "10/11" {
+		t.Errorf("DecimalToFractional(1.9091) = %s, want 10/11", got)
+	}
+	if got := models.DecimalToFractional(3.5); got != "5/2" {
+		t.Errorf("DecimalToFractional(3.5) = %s, want 5/2", got)
+	}
+}
+
+func TestNormalizePrice(t *testing.T) {
+	american, decimal, err := models.NormalizePrice(models.OddsFormatDecimal, 1.87)
+	if err != nil {
+		t.Fatalf("normalize decimal: %v", err)
+	}
+	if decimal != 1.87 {
+		t.Errorf("expected native decimal to be kept exactly, got %v", decimal)
+	}
+	if american != -115 {
+		t.Errorf("expected -115, got %d", american)
+	}
+
+	american, decimal, err = models.NormalizePrice(models.OddsFormatAmerican, -110)
+	if err != nil {
+		t.Fatalf("normalize american: %v", err)
+	}
+	if american != -110 || math.Abs(decimal-1.9091) > 0.0001 {
+		t.Errorf("expected -110 / 1.9091, got %d / %v", american, decimal)
+	}
+
+	if _, _, err := models.NormalizePrice(models.OddsFormatDecimal, 0.5); err == nil {
+		t.Error("expected error for decimal price below 1")
+	}
+}
+
+func TestRawOddsDecimalFallback(t *testing.T) {
+	odd := models.RawOdds{Price: 150}
+	if odd.Decimal() != 2.5 {
+		t.Errorf("expected decimal converted from American, got %v", odd.Decimal())
+	}
+
+	odd.DecimalPrice = 2.55
+	if odd.Decimal() != 2.55 {
+		t.Errorf("expected native decimal, got %v", odd.Decimal())
+	}
+}
+
+func TestParseOddsFormat(t *testing.T) {
+	if f, err := models.ParseOddsFormat("Decimal"); err != nil || f != models.OddsFormatDecimal {
+		t.Errorf("ParseOddsFormat(Decimal) = %v, %v", f, err)
+	}
+	if _, err := models.ParseOddsFormat("moneyline"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	// Prices are never quoted fractional, so it cannot be configured
+	if _, err := models.ParseOddsFormat("fractional"); err == nil {
+		t.Error("expected error for fractional")
+	}
+}
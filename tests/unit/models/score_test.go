@@ -0,0 +1,32 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestResolveEventStatus_VendorSignalsWin(t *testing.T) {
+	now := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		commence  time.Time
+		completed bool
+		started   bool
+		want      string
+	}{
+		{"not started", future, false, false, models.EventStatusUpcoming},
+		{"past commence", past, false, false, models.EventStatusLive},
+		{"scores before listed start", future, false, true, models.EventStatusLive},
+		{"completed", past, true, true, models.EventStatusCompleted},
+		{"completed beats commence", future, true, false, models.EventStatusCompleted},
+	}
+	for _, tt := range tests {
+		if got := models.ResolveEventStatus(tt.commence, tt.completed, tt.started, now); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
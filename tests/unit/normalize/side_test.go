@@ -0,0 +1,69 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+)
+
+func TestSplitLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		team     string
+		point    float64
+		hasPoint bool
+	}{
+		{"Los Angeles Lakers", "Los Angeles Lakers", 0, false},
+		{"Lakers -3.5", "Lakers", -3.5, true},
+		{"Lakers (+3.5)", "Lakers", 3.5, true},
+		{"Boston Celtics  +10", "Boston Celtics", 10, true},
+		{"Lakers 3.5", "Lakers", 3.5, true},
+		{"Lakers PK", "Lakers", 0, true},
+		{"Philadelphia 76ers", "Philadelphia 76ers", 0, false},
+		{"Philadelphia 76ers -2", "Philadelphia 76ers", -2, true},
+	}
+	for _, tt := range tests {
+		team, point := normalize.SplitLine(tt.name)
+		if team != tt.team {
+			t.Errorf("SplitLine(%q) team = %q, want %q", tt.name, team, tt.team)
+		}
+		if (point != nil) != tt.hasPoint || (point != nil && *point != tt.point) {
+			t.Errorf("SplitLine(%q) point = %v, want %v (present %v)", tt.name, point, tt.point, tt.hasPoint)
+		}
+	}
+}
+
+func TestMatchSide(t *testing.T) {
+	home, away := "Los Angeles Lakers", "Portland Trail Blazers"
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Los Angeles Lakers", normalize.SideHome},
+		{"los angeles lakers", normalize.SideHome},
+		{"Lakers", normalize.SideHome},
+		{"LA Lakers", normalize.SideHome},
+		{"L.A. Lakers", normalize.SideHome},
+		{"Trail Blazers", normalize.SideAway},
+		{"Portland Trail-Blazers", normalize.SideAway},
+		{"Over", ""},
+		{"Draw", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := normalize.MatchSide(tt.name, home, away); got != tt.want {
+			t.Errorf("MatchSide(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMatchSide_AmbiguousNames(t *testing.T) {
+	// Both teams share the city; only the nickname decides
+	home, away := "Los Angeles Lakers", "Los Angeles Clippers"
+	if got := normalize.MatchSide("Los Angeles", home, away); got != "" {
+		t.Errorf("city only = %q, want no side", got)
+	}
+	if got := normalize.MatchSide("LA Clippers", home, away); got != normalize.SideAway {
+		t.Errorf("LA Clippers = %q, want away", got)
+	}
+}
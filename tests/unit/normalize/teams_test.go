@@ -0,0 +1,91 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+func TestTeamName_AliasesArePerSport(t *testing.T) {
+	reg := normalize.NewRegistry()
+	reg.SetAliases("basketball_nba", map[string]string{"LA Lakers": "Los Angeles Lakers"})
+	reg.SetAliases("americanfootball_nfl", map[string]string{"LA Rams": "Los Angeles Rams"})
+
+	tests := []struct {
+		sport, name, want string
+	}{
+		{"basketball_nba", "LA Lakers", "Los Angeles Lakers"},
+		{"basketball_nba", "  la   LAKERS ", "Los Angeles Lakers"}, // Case and whitespace insensitive
+		{"basketball_nba", "LA Rams", "LA Rams"},                   // Other sport's alias
+		{"americanfootball_nfl", "LA Rams", "Los Angeles Rams"},
+		{"basketball_nba", " Boston  Celtics ", "Boston Celtics"}, // No alias: trimmed only
+		{"icehockey_nhl", "LA Kings", "LA Kings"},
+	}
+
+	for _, tt := range tests {
+		if got := reg.TeamName(tt.sport, tt.name); got != tt.want {
+			t.Errorf("TeamName(%s, %q) = %q, want %q", tt.sport, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAddAliases_KeepsExistingEntries(t *testing.T) {
+	reg := normalize.NewRegistry()
+	reg.SetAliases("basketball_nba", map[string]string{"Clippers": "LA Clippers"})
+
+	added := reg.AddAliases("basketball_nba", map[string]string{"clippers": "Los Angeles Clippers", "Lakers": "Los Angeles Lakers"})
+	if added != 1 {
+		t.Errorf("expected 1 alias added, got %d", added)
+	}
+	if got := reg.TeamName("basketball_nba", "Clippers"); got != "LA Clippers" {
+		t.Errorf("expected the existing alias to stay, got %q", got)
+	}
+	if got := reg.TeamName("basketball_nba", "lakers"); got != "Los Angeles Lakers" {
+		t.Errorf("expected the new alias, got %q", got)
+	}
+}
+
+func TestSetAliases_LaterEntriesOverride(t *testing.T) {
+	reg := normalize.NewRegistry()
+	reg.SetAliases("basketball_nba", map[string]string{"LA Clippers": "Los Angeles Clippers", "NY Knicks": "New York Knicks"})
+	reg.SetAliases("basketball_nba", map[string]string{"la clippers": "LA Clippers"}) // e.g. a team_aliases row
+
+	if got := reg.TeamName("basketball_nba", "LA Clippers"); got != "LA Clippers" {
+		t.Errorf("expected the later alias to win, got %q", got)
+	}
+	if got := reg.TeamName("basketball_nba", "NY Knicks"); got != "New York Knicks" {
+		t.Errorf("expected earlier aliases to be kept, got %q", got)
+	}
+}
+
+func TestNilRegistryOnlyTrims(t *testing.T) {
+	var reg *normalize.Registry
+	if got := reg.TeamName("basketball_nba", " LA Lakers "); got != "LA Lakers" {
+		t.Errorf("expected a nil registry to trim only, got %q", got)
+	}
+}
+
+func TestLoadSports_UsesModuleAliases(t *testing.T) {
+	reg := normalize.NewRegistry()
+	reg.LoadSports([]contracts.SportModule{basketball_nba.NewModule()})
+
+	if got := reg.TeamName("basketball_nba", "OKC Thunder"); got != "Oklahoma City Thunder" {
+		t.Errorf("expected the NBA module alias, got %q", got)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Los Angeles Lakers":     "los_angeles_lakers",
+		"Philadelphia 76ers":     "philadelphia_76ers",
+		"Portland Trail-Blazers": "portland_trailblazers",
+		"St. Louis Blues":        "st_louis_blues",
+	}
+	for name, want := range tests {
+		if got := normalize.Slug(name); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+)
+
+func TestTotalsOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		canonical string
+		point     float64
+		hasPoint  bool
+	}{
+		{"Over", "Over", 0, false},
+		{"UNDER", "Under", 0, false},
+		{" o ", "Over", 0, false},
+		{"U", "Under", 0, false},
+		{"O 220.5", "Over", 220.5, true},
+		{"Under (221)", "Under", 221, true},
+		{"Über", "Over", 0, false},
+		{"Unter 220.5", "Under", 220.5, true},
+		{"Plus de", "Over", 0, false},
+		{"Moins de 2.5", "Under", 2.5, true},
+		{"Más", "Over", 0, false},
+		{"Menos", "Under", 0, false},
+		{"Più", "Over", 0, false},
+		{"Över", "Over", 0, false},
+		{"Onder", "Under", 0, false},
+	}
+	for _, tt := range tests {
+		canonical, point, ok := normalize.TotalsOutcome(tt.name)
+		if !ok || canonical != tt.canonical {
+			t.Errorf("TotalsOutcome(%q) = %q, %v, want %q", tt.name, canonical, ok, tt.canonical)
+		}
+		if (point != nil) != tt.hasPoint || (point != nil && *point != tt.point) {
+			t.Errorf("TotalsOutcome(%q) point = %v, want %v (present %v)", tt.name, point, tt.point, tt.hasPoint)
+		}
+	}
+}
+
+func TestTotalsOutcome_IgnoresOtherNames(t *testing.T) {
+	for _, name := range []string{"Los Angeles Lakers", "Draw", "Yes", "No", "LeBron James", "220.5", ""} {
+		if canonical, _, ok := normalize.TotalsOutcome(name); ok {
+			t.Errorf("TotalsOutcome(%q) = %q, want no match", name, canonical)
+		}
+	}
+}
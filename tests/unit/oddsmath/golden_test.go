@@ -0,0 +1,49 @@
+package oddsmath_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+	"github.com/XavierBriggs/Mercury/pkg/testutil"
+)
+
+func TestGoldenFixtures(t *testing.T) {
+	for _, fixture := range testutil.GetGoldenFixtures() {
+		t.Run(fixture.Name, func(t *testing.T) {
+			var books []string
+			prices := make(map[string][]float64)
+			for _, odd := range fixture.Odds {
+				if _, ok := prices[odd.BookKey]; !ok {
+					books = append(books, odd.BookKey)
+				}
+				prices[odd.BookKey] = append(prices[odd.BookKey], models.AmericanToDecimal(odd.Price))
+			}
+
+			reference := books[0]
+			if _, ok := prices["pinnacle"]; ok {
+				reference = "pinnacle"
+			}
+			fair := oddsmath.NoVig(prices[reference])
+			if fair == nil {
+				t.Fatalf("no fair line for %s", reference)
+			}
+
+			if american := oddsmath.ImpliedToAmerican(fair[0]); american != fixture.ExpectedFairOdds {
+				t.Errorf("fair odds = %d, want %d", american, fixture.ExpectedFairOdds)
+			}
+
+			for book, want := range fixture.ExpectedNoVig {
+				if got := oddsmath.NoVig(prices[book]); got == nil || !near(got[0], want, 0.0005) {
+					t.Errorf("%s no-vig = %v, want %v", book, got, want)
+				}
+			}
+
+			for book, want := range fixture.ExpectedEdge {
+				if got := oddsmath.ExpectedValue(fair[0], prices[book][0]) * 100; !near(got, want, 0.01) {
+					t.Errorf("%s edge = %.4f%%, want %.2f%%", book, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package oddsmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/pkg/oddsmath"
+)
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestImpliedConversions(t *testing.T) {
+	if p := oddsmath.AmericanToImplied(-110); !near(p, 0.52381, 1e-5) {
+		t.Errorf("AmericanToImplied(-110) = %v", p)
+	}
+	if p := oddsmath.AmericanToImplied(150); !near(p, 0.4, 1e-9) {
+		t.Errorf("AmericanToImplied(+150) = %v", p)
+	}
+	if american := oddsmath.ImpliedToAmerican(0.5); american != 100 {
+		t.Errorf("ImpliedToAmerican(0.5) = %d, want +100", american)
+	}
+	if american := oddsmath.ImpliedToAmerican(0.6); american != -150 {
+		t.Errorf("ImpliedToAmerican(0.6) = %d, want -150", american)
+	}
+	if oddsmath.AmericanToImplied(50) != 0 || oddsmath.ImpliedToDecimal(1) != 0 {
+		t.Error("expected zero for invalid inputs")
+	}
+}
+
+func TestPriceDeltaCents(t *testing.T) {
+	tests := []struct {
+		from, to, want int
+	}{
+		{-110, -105, 5},
+		{-105, 105, 10},
+		{105, -105, -10},
+		{120, 150, 30},
+		{-110, -110, 0},
+	}
+	for _, tt := range tests {
+		if got := oddsmath.PriceDeltaCents(tt.from, tt.to); got != tt.want {
+			t.Errorf("PriceDeltaCents(%d, %d) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestVig(t *testing.T) {
+	prices := []float64{oddsmath.AmericanToDecimal(-110), oddsmath.AmericanToDecimal(-110)}
+
+	if o := oddsmath.Overround(prices); !near(o, 1.04762, 1e-5) {
+		t.Errorf("Overround = %v", o)
+	}
+	if h := oddsmath.Hold(prices); !near(h, 0.04545, 1e-5) {
+		t.Errorf("Hold = %v", h)
+	}
+}
+
+func TestNoVig(t *testing.T) {
+	// -110 / -110 => fair 50/50
+	fair := oddsmath.NoVig([]float64{1.9091, 1.9091})
+	if len(fair) != 2 || !near(fair[0], 0.5, 1e-9) {
+		t.Fatalf("expected 50/50, got %v", fair)
+	}
+
+	if oddsmath.NoVig([]float64{1.9}) != nil {
+		t.Error("expected nil for a one-sided market")
+	}
+	if oddsmath.NoVig([]float64{1.9, 1.0}) != nil {
+		t.Error("expected nil for an invalid price")
+	}
+}
+
+func TestExpectedValueAndKelly(t *testing.T) {
+	if ev := oddsmath.ExpectedValue(0.5, 2.1); !near(ev, 0.05, 1e-9) {
+		t.Errorf("expected +5%% EV, got %v", ev)
+	}
+
+	// f* = (p*d - 1) / (d - 1)
+	if k := oddsmath.Kelly(0.5, 2.1); !near(k, 0.05/1.1, 1e-9) {
+		t.Errorf("Kelly = %v", k)
+	}
+	if k := oddsmath.FractionalKelly(0.5, 2.1, 0.25); !near(k, 0.05/1.1/4, 1e-9) {
+		t.Errorf("FractionalKelly = %v", k)
+	}
+	if k := oddsmath.Kelly(0.5, 1.9); k != 0 {
+		t.Errorf("expected no stake without an edge, got %v", k)
+	}
+}
+
+func TestArbitrage(t *testing.T) {
+	if profit := oddsmath.ArbitrageProfit([]float64{2.1, 2.1}); !near(profit, 0.05, 1e-9) {
+		t.Errorf("ArbitrageProfit = %v", profit)
+	}
+	if profit := oddsmath.ArbitrageProfit([]float64{1.9, 1.9}); profit >= 0 {
+		t.Errorf("expected no arbitrage, got %v", profit)
+	}
+
+	split := oddsmath.StakeSplit([]float64{2.1, 2.1})
+	if !near(split[0], 0.5, 1e-9) || !near(split[1], 0.5, 1e-9) {
+		t.Errorf("expected an even split, got %v", split)
+	}
+
+	// Equal returns: stake_i * price_i is constant
+	split = oddsmath.StakeSplit([]float64{2.5, 1.8})
+	if !near(split[0]*2.5, split[1]*1.8, 1e-9) || !near(split[0]+split[1], 1, 1e-9) {
+		t.Errorf("expected equal returns, got %v", split)
+	}
+}
@@ -0,0 +1,60 @@
+package ohlc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/ohlc"
+)
+
+func TestBucket(t *testing.T) {
+	at := time.Date(2025, 1, 15, 12, 37, 42, 0, time.UTC)
+
+	tests := []struct {
+		interval time.Duration
+		want     time.Time
+	}{
+		{time.Minute, time.Date(2025, 1, 15, 12, 37, 0, 0, time.UTC)},
+		{5 * time.Minute, time.Date(2025, 1, 15, 12, 35, 0, 0, time.UTC)},
+		{time.Hour, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := ohlc.Bucket(at, tt.interval); !got.Equal(tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.interval, tt.want, got)
+		}
+	}
+
+	// Candles are UTC whatever the input's zone
+	eastern := at.In(time.FixedZone("EST", -5*60*60))
+	if got := ohlc.Bucket(eastern, time.Hour); got.Location() != time.UTC || !got.Equal(tests[2].want) {
+		t.Errorf("expected the UTC hour, got %v", got)
+	}
+}
+
+func TestIntervalsDivideAnHour(t *testing.T) {
+	for _, interval := range ohlc.Intervals {
+		if time.Hour%interval != 0 {
+			t.Errorf("%v candles would cross an hour", interval)
+		}
+	}
+}
+
+func TestWindows(t *testing.T) {
+	from := time.Date(2025, 1, 15, 10, 20, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 15, 12, 5, 0, 0, time.UTC)
+
+	windows := ohlc.Windows(from, to)
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 hour windows, got %d", len(windows))
+	}
+	for i, window := range windows {
+		start := time.Date(2025, 1, 15, 10+i, 0, 0, 0, time.UTC)
+		if !window[0].Equal(start) || !window[1].Equal(start.Add(time.Hour)) {
+			t.Errorf("window %d: expected %v to %v, got %v to %v", i, start, start.Add(time.Hour), window[0], window[1])
+		}
+	}
+
+	if got := ohlc.Windows(to, from); len(got) != 0 {
+		t.Errorf("expected no windows for an empty range, got %d", len(got))
+	}
+}
@@ -0,0 +1,113 @@
+package ordering_test
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/ordering"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// TestLanes_PreserveCommitOrderPerKey simulates concurrent writers that "commit"
+// (take a per-key sequence number) and then "publish" after a random delay.
+// With lanes held from commit through publish, each key's published sequence
+// must be strictly increasing.
+func TestLanes_PreserveCommitOrderPerKey(t *testing.T) {
+	lanes := ordering.NewLanes(8)
+
+	const (
+		writers   = 16
+		batches   = 50
+		keysTotal = 20
+	)
+
+	var mu sync.Mutex
+	committed := make(map[string]int)   // key -> last committed sequence
+	published := make(map[string][]int) // key -> published sequences in order
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+
+			for b := 0; b < batches; b++ {
+				// Random batch of outcomes
+				odds := make([]models.RawOdds, 1+rng.Intn(4))
+				for i := range odds {
+					odds[i] = models.RawOdds{
+						EventID:     "evt",
+						MarketKey:   "h2h",
+						BookKey:     "fanduel",
+						OutcomeName: fmt.Sprintf("outcome_%d", rng.Intn(keysTotal)),
+					}
+				}
+
+				release := lanes.AcquireOdds(odds)
+
+				// "Commit"
+				mu.Lock()
+				seqs := make([]int, len(odds))
+				for i, odd := range odds {
+					key := ordering.OutcomeKey(odd)
+					committed[key]++
+					seqs[i] = committed[key]
+				}
+				mu.Unlock()
+
+				// Publish happens later; without lanes this is where reordering occurs
+				time.Sleep(time.Duration(rng.Intn(200)) * time.Microsecond)
+
+				mu.Lock()
+				for i, odd := range odds {
+					key := ordering.OutcomeKey(odd)
+					published[key] = append(published[key], seqs[i])
+				}
+				mu.Unlock()
+
+				release()
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+
+	for key, seqs := range published {
+		for i := 1; i < len(seqs); i++ {
+			if seqs[i] <= seqs[i-1] {
+				t.Fatalf("key %s published out of order: %v", key, seqs)
+			}
+		}
+	}
+}
+
+func TestLanes_SameKeySameLane(t *testing.T) {
+	lanes := ordering.NewLanes(16)
+
+	odd := models.RawOdds{EventID: "e1", MarketKey: "spreads", BookKey: "pinnacle", OutcomeName: "Lakers"}
+	key := ordering.OutcomeKey(odd)
+
+	if lanes.Lane(key) != lanes.Lane(key) {
+		t.Error("expected lane assignment to be deterministic")
+	}
+
+	// Duplicate keys in one batch must not self-deadlock
+	release := lanes.Acquire([]string{key, key, key})
+	release()
+}
+
+func TestOutcomeKey_DistinguishesPropsPlayers(t *testing.T) {
+	over := models.RawOdds{EventID: "evt1", MarketKey: "player_points", BookKey: "fanduel", OutcomeName: "Over"}
+
+	lebron := over
+	lebron.Description = "LeBron James"
+	davis := over
+	davis.Description = "Anthony Davis"
+
+	if ordering.OutcomeKey(lebron) == ordering.OutcomeKey(davis) {
+		t.Error("expected different players in the same props market to have different outcome keys")
+	}
+}
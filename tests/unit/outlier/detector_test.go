@@ -0,0 +1,109 @@
+package outlier_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/outlier"
+	"github.com/XavierBriggs/Mercury/internal/reliability"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func quote(book string, price int) models.RawOdds {
+	return models.RawOdds{
+		EventID:     "e1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "h2h",
+		BookKey:     book,
+		OutcomeName: "Lakers",
+		Price:       price,
+		ReceivedAt:  time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestDetector_FlagsQuoteFarFromConsensus(t *testing.T) {
+	d := outlier.NewDetector(outlier.Config{Cents: 40}, nil)
+
+	baseline := d.Check([]models.RawOdds{
+		quote("pinnacle", -110),
+		quote("draftkings", -115),
+		quote("betmgm", -105),
+	})
+	for _, verdict := range baseline {
+		if verdict.Consensus != 0 {
+			t.Fatalf("expected no consensus with two other books, got %+v", verdict)
+		}
+	}
+
+	verdicts := d.Check([]models.RawOdds{quote("fanduel", 140), quote("caesars", -110)})
+	if got := verdicts[0]; !got.Outlier || got.Consensus != -110 || got.Cents != 50 {
+		t.Errorf("expected +140 against -110 to be a 50 cent outlier, got %+v", got)
+	}
+	// caesars' consensus includes fanduel's +140: median of -115, -110, -105, +140
+	if got := verdicts[1]; got.Outlier || got.Consensus == 0 {
+		t.Errorf("expected caesars in line with the consensus, got %+v", got)
+	}
+}
+
+func TestDetector_LowScoredBooksMoveConsensusLess(t *testing.T) {
+	// Two reliable books at -110 and two poorly scored ones at +150
+	others := []models.RawOdds{
+		quote("pinnacle", -110), quote("circa", -110),
+		quote("sketchy1", 150), quote("sketchy2", 150),
+	}
+
+	unweighted := outlier.NewDetector(outlier.Config{Cents: 40}, nil)
+	unweighted.Check(others)
+	// Equal weights: the median falls between -110 and +150
+	if got := unweighted.Check([]models.RawOdds{quote("fanduel", -110)})[0]; got.Consensus == -110 {
+		t.Fatalf("expected the unweighted consensus to be pulled off -110, got %+v", got)
+	}
+
+	weighted := outlier.NewDetector(outlier.Config{Cents: 40}, nil)
+	weighted.SetWeights(reliability.NewWeights(map[string]float64{
+		"pinnacle": 0.9, "circa": 0.8, "sketchy1": 0.1, "sketchy2": 0.1, "fanduel": 0.6,
+	}))
+	weighted.Check(others)
+	got := weighted.Check([]models.RawOdds{quote("fanduel", -110)})[0]
+	if got.Consensus != -110 || got.Outlier {
+		t.Errorf("expected the reliable books to hold the consensus at -110, got %+v", got)
+	}
+
+	// A book weighted 0 is left out, and no longer counts toward the minimum
+	zeroed := outlier.NewDetector(outlier.Config{Cents: 40}, nil)
+	zeroed.SetWeights(reliability.NewWeights(map[string]float64{"pinnacle": 1, "circa": 1, "sketchy1": 0}))
+	zeroed.Check([]models.RawOdds{quote("pinnacle", -110), quote("circa", -110), quote("sketchy1", 150)})
+	if got := zeroed.Check([]models.RawOdds{quote("fanduel", -110)})[0]; got.Consensus != 0 {
+		t.Errorf("expected no consensus from two weighted books, got %+v", got)
+	}
+}
+
+func TestDetector_ZScore(t *testing.T) {
+	d := outlier.NewDetector(outlier.Config{ZScore: 3, MinBooks: 3}, nil)
+	d.Check([]models.RawOdds{quote("pinnacle", -110), quote("draftkings", -110), quote("betmgm", -110)})
+
+	// Identical books: the floor on their spread keeps small moves from being outliers
+	if got := d.Check([]models.RawOdds{quote("fanduel", -108)})[0]; got.Outlier {
+		t.Errorf("expected -108 against -110 not to be an outlier, got %+v", got)
+	}
+	if got := d.Check([]models.RawOdds{quote("fanduel", -140)})[0]; !got.Outlier || got.ZScore <= 3 {
+		t.Errorf("expected -140 against -110 to be an outlier, got %+v", got)
+	}
+}
+
+func TestDetector_EvictAndNilDetector(t *testing.T) {
+	d := outlier.NewDetector(outlier.Config{Cents: 20}, nil)
+	d.Check([]models.RawOdds{quote("pinnacle", -110), quote("draftkings", -110), quote("betmgm", -110)})
+	d.Evict("e1")
+	if got := d.Check([]models.RawOdds{quote("fanduel", 150)})[0]; got.Consensus != 0 {
+		t.Errorf("expected no consensus after eviction, got %+v", got)
+	}
+
+	var none *outlier.Detector
+	if verdicts := none.Check([]models.RawOdds{quote("fanduel", 150)}); verdicts != nil {
+		t.Errorf("expected a nil detector to return nil, got %+v", verdicts)
+	}
+	if (outlier.Config{MinBooks: 3}).Enabled() {
+		t.Error("expected detection off without thresholds")
+	}
+}
@@ -0,0 +1,139 @@
+package participants_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/internal/participants"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+const nba = "basketball_nba"
+
+var roster = []models.Participant{
+	{ID: "par_lal", SportKey: nba, FullName: "Los Angeles Lakers"},
+	{ID: "par_lac", SportKey: nba, FullName: "Los Angeles Clippers"},
+	{ID: "par_bos", SportKey: nba, FullName: "Boston Celtics"},
+	{ID: "par_por", SportKey: nba, FullName: "Portland Trail Blazers"},
+	{ID: "par_x1", SportKey: nba, FullName: "Team Stars"},
+	{ID: "par_x2", SportKey: nba, FullName: "World Stars"},
+}
+
+func TestDirectory_ID(t *testing.T) {
+	dir := participants.NewDirectory()
+	dir.Set(nba, roster)
+
+	tests := []struct {
+		sport, name, want string
+	}{
+		{nba, "Los Angeles Lakers", "par_lal"},
+		{nba, "  boston   CELTICS ", "par_bos"}, // Case and whitespace insensitive
+		{nba, "Over", ""},
+		{"icehockey_nhl", "Boston Celtics", ""}, // Rosters are per sport
+	}
+	for _, tt := range tests {
+		if got := dir.ID(tt.sport, tt.name); got != tt.want {
+			t.Errorf("ID(%s, %q) = %q, want %q", tt.sport, tt.name, got, tt.want)
+		}
+	}
+	if dir.Count() != len(roster) {
+		t.Errorf("expected %d participants, got %d", len(roster), dir.Count())
+	}
+
+	var nilDir *participants.Directory
+	if nilDir.ID(nba, "Boston Celtics") != "" || nilDir.Count() != 0 {
+		t.Error("expected a nil directory to resolve nothing")
+	}
+}
+
+func TestNicknames_SkipsAmbiguousWords(t *testing.T) {
+	nicknames := participants.Nicknames(roster)
+
+	if nicknames["lakers"] != "Los Angeles Lakers" || nicknames["blazers"] != "Portland Trail Blazers" {
+		t.Errorf("unexpected nicknames %v", nicknames)
+	}
+	if _, ok := nicknames["stars"]; ok {
+		t.Error("a word shared by two participants must not become a nickname")
+	}
+}
+
+func TestDirectory_AddsNicknamesWithoutOverridingAliases(t *testing.T) {
+	teamNames := normalize.NewRegistry()
+	teamNames.SetAliases(nba, map[string]string{"Clippers": "LA Clippers"}) // Configured alias wins
+
+	dir := participants.NewDirectory()
+	dir.SetTeamNames(teamNames)
+	dir.Set(nba, roster)
+
+	if got := teamNames.TeamName(nba, "Celtics"); got != "Boston Celtics" {
+		t.Errorf("expected the nickname to normalize, got %q", got)
+	}
+	if got := teamNames.TeamName(nba, "Clippers"); got != "LA Clippers" {
+		t.Errorf("expected the configured alias to win, got %q", got)
+	}
+	if got := dir.ID(nba, "lakers"); got != "par_lal" {
+		t.Errorf("expected lookups to go through normalization, got %q", got)
+	}
+}
+
+func TestDirectory_Enrich(t *testing.T) {
+	dir := participants.NewDirectory()
+	dir.Set(nba, roster)
+
+	msg := models.StreamMessage{SportKey: nba, MarketKey: "h2h", OutcomeName: "Boston Celtics"}
+	dir.Enrich(&msg, &models.Event{HomeTeam: "Los Angeles Lakers", AwayTeam: "Boston Celtics"})
+	if msg.TeamID != "par_bos" || msg.HomeTeamID != "par_lal" || msg.AwayTeamID != "par_bos" {
+		t.Errorf("unexpected IDs %+v", msg)
+	}
+
+	totals := models.StreamMessage{SportKey: nba, MarketKey: "totals", OutcomeName: "Over"}
+	dir.Enrich(&totals, nil)
+	if totals.TeamID != "" || totals.HomeTeamID != "" {
+		t.Errorf("expected no IDs for a totals outcome without an event, got %+v", totals)
+	}
+}
+
+type fakeAdapter struct {
+	participants []models.Participant
+	err          error
+	calls        int
+}
+
+func (f *fakeAdapter) FetchParticipants(ctx context.Context, sport string) ([]models.Participant, error) {
+	f.calls++
+	return f.participants, f.err
+}
+
+func newSports(t *testing.T) *registry.SportRegistry {
+	t.Helper()
+	sports := registry.NewSportRegistry()
+	if err := sports.Register(basketball_nba.NewModule()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return sports
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	adapter := &fakeAdapter{participants: roster}
+	dir := participants.NewDirectory()
+
+	syncer := participants.NewSyncer(adapter, nil, dir, newSports(t), 0)
+	if err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if adapter.calls != 1 || dir.ID(nba, "Boston Celtics") != "par_bos" {
+		t.Errorf("expected the roster in the directory after one fetch, got %d calls", adapter.calls)
+	}
+
+	failing := &fakeAdapter{err: errors.New("boom")}
+	if err := participants.NewSyncer(failing, nil, dir, newSports(t), 0).Sync(context.Background()); err == nil {
+		t.Error("expected the fetch error to be returned")
+	}
+	if dir.ID(nba, "Boston Celtics") != "par_bos" {
+		t.Error("a failed refresh must keep the known roster")
+	}
+}
@@ -0,0 +1,56 @@
+package pgnotify_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/pgnotify"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestNewPayload(t *testing.T) {
+	payload, err := pgnotify.NewPayload(models.StreamMessage{
+		EventID:     "e1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "spreads",
+		BookKey:     "fanduel",
+		OutcomeName: "Boston Celtics",
+		Price:       -110,
+		ChangeType:  "price",
+	})
+	if err != nil {
+		t.Fatalf("NewPayload: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(payload), &got); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	want := map[string]interface{}{
+		"event_id":    "e1",
+		"sport_key":   "basketball_nba",
+		"market":      "spreads",
+		"book":        "fanduel",
+		"outcome":     "Boston Celtics",
+		"change_type": "price",
+	}
+	if len(got) != len(want) {
+		t.Errorf("payload = %s, want only %v", payload, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestNewNotifier_ValidatesChannel(t *testing.T) {
+	if _, err := pgnotify.NewNotifier(nil, pgnotify.DefaultChannel); err != nil {
+		t.Errorf("default channel rejected: %v", err)
+	}
+	for _, channel := range []string{"", "Odds", "odds-changes", "odds; DROP TABLE events", "1odds"} {
+		if _, err := pgnotify.NewNotifier(nil, channel); err == nil {
+			t.Errorf("expected channel %q to be rejected", channel)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package plan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/plan"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+func TestCoverage_MergesOverlapsAndWrapsMidnight(t *testing.T) {
+	tipoffs := []time.Duration{
+		23*time.Hour + 30*time.Minute,
+		23*time.Hour + 40*time.Minute, // Overlaps the first game
+		10 * time.Hour,
+	}
+
+	// 23:00-24:00 and 00:00-00:10 wrapped, plus 09:30-10:00
+	got := plan.Coverage(tipoffs, 30*time.Minute, 30*time.Minute)
+	want := time.Hour + 10*time.Minute + 30*time.Minute + 30*time.Minute
+	if got != want {
+		t.Errorf("Coverage = %v, want %v", got, want)
+	}
+
+	if got := plan.Coverage(tipoffs, 20*time.Hour, 5*time.Hour); got != 24*time.Hour {
+		t.Errorf("expected windows longer than a day to cover it, got %v", got)
+	}
+	if got := plan.Coverage(nil, time.Hour, time.Hour); got != 0 {
+		t.Errorf("expected an empty slate to cover nothing, got %v", got)
+	}
+}
+
+func TestRequestUnits_BillsBookmakerGroupsAsRegions(t *testing.T) {
+	nba := basketball_nba.NewModule()
+	if got := plan.RequestUnits(nba); got != 1 {
+		t.Errorf("expected 10 bookmakers to cost one region, got %v", got)
+	}
+}
+
+func TestPropsTiers_FollowNBARamp(t *testing.T) {
+	tiers := plan.PropsTiers(basketball_nba.NewModule())
+
+	want := []struct {
+		label    string
+		interval time.Duration
+	}{
+		{"T-45h→T-6h @30m", 30 * time.Minute},
+		{"T-6h→T-1h30m @10m", 10 * time.Minute},
+		{"T-1h30m→T-20m @2m", 2 * time.Minute},
+		{"T-20m→T+3h @1m", time.Minute},
+	}
+	if len(tiers) != len(want) {
+		t.Fatalf("expected %d tiers, got %+v", len(want), tiers)
+	}
+	for i, w := range want {
+		if tiers[i].Label != w.label || tiers[i].Interval != w.interval {
+			t.Errorf("tier %d = %s (%v), want %s (%v)", i, tiers[i].Label, tiers[i].Interval, w.label, w.interval)
+		}
+		if tiers[i].Polls <= 0 {
+			t.Errorf("tier %d has no polls", i)
+		}
+	}
+
+	// 3h of in-play plus 20m pre-tip at one poll per ~62.5s
+	if polls := tiers[3].Polls; polls < 180 || polls > 200 {
+		t.Errorf("expected ~192 polls in the last tier, got %v", polls)
+	}
+}
+
+func TestEstimate_NBASlate(t *testing.T) {
+	nba := basketball_nba.NewModule()
+	slate := plan.Slate{Tipoffs: []time.Duration{0, 2 * time.Hour}}
+	p := plan.Estimate([]contracts.SportModule{nba}, slate)
+
+	byTrack := make(map[plan.Track]float64)
+	for _, l := range p.Lines {
+		byTrack[l.Track] += l.Credits
+	}
+
+	// Featured runs all day: 3 markets × 1440 polls
+	if got := byTrack[plan.TrackFeatured]; got != 3*1440 {
+		t.Errorf("featured credits = %v, want %v", got, 3*1440)
+	}
+	// Tipoff: two separate 20m windows at 15s = 160 polls × 3 markets
+	if got := byTrack[plan.TrackTipoff]; got != 3*160 {
+		t.Errorf("tipoff credits = %v, want %v", got, 3*160)
+	}
+	if got := byTrack[plan.TrackDiscovery]; got != 0 {
+		t.Errorf("expected discovery to be free, got %v", got)
+	}
+	if got := byTrack[plan.TrackFutures]; got != 4 {
+		t.Errorf("futures credits = %v, want 4", got)
+	}
+
+	// Props scale with the slate
+	var propsPerGame float64
+	for _, tier := range plan.PropsTiers(nba) {
+		propsPerGame += tier.Polls
+	}
+	wantProps := propsPerGame * 2 * float64(len(nba.GetPropsMarkets()))
+	if got := byTrack[plan.TrackProps]; got != wantProps {
+		t.Errorf("props credits = %v, want %v", got, wantProps)
+	}
+
+	if got := p.BySport()["basketball_nba"]; got != p.Total() {
+		t.Errorf("BySport = %v, want the total %v", got, p.Total())
+	}
+	if got := p.ByMarket()["basketball_nba/h2h"]; got != 1440+160 {
+		t.Errorf("h2h credits = %v, want %v", got, 1440+160)
+	}
+}
@@ -0,0 +1,186 @@
+package pollaudit_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/pollaudit"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// auditStore collects the committed poll_audit inserts of one test, one batch per
+// transaction, keyed by the DSN the test opened
+type auditStore struct {
+	mu      sync.Mutex
+	batches [][]string // Event IDs inserted per committed transaction
+}
+
+func (s *auditStore) get() [][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]string(nil), s.batches...)
+}
+
+var (
+	storesMu sync.Mutex
+	stores   = make(map[string]*auditStore)
+)
+
+type auditDriver struct{}
+
+func (auditDriver) Open(name string) (driver.Conn, error) {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+	store, ok := stores[name]
+	if !ok {
+		return nil, fmt.Errorf("no store %q", name)
+	}
+	return &auditConn{store: store}, nil
+}
+
+// auditConn buffers a transaction's rows and hands them to the store on commit
+type auditConn struct {
+	store   *auditStore
+	pending []string
+}
+
+func (c *auditConn) Prepare(string) (driver.Stmt, error) { return &auditStmt{conn: c}, nil }
+func (c *auditConn) Close() error                        { return nil }
+func (c *auditConn) Begin() (driver.Tx, error) {
+	c.pending = nil
+	return c, nil
+}
+
+func (c *auditConn) Commit() error {
+	c.store.mu.Lock()
+	defer c.store.mu.Unlock()
+	c.store.batches = append(c.store.batches, c.pending)
+	c.pending = nil
+	return nil
+}
+
+func (c *auditConn) Rollback() error {
+	c.pending = nil
+	return nil
+}
+
+type auditStmt struct{ conn *auditConn }
+
+func (s *auditStmt) Close() error  { return nil }
+func (s *auditStmt) NumInput() int { return -1 }
+func (s *auditStmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+// Exec records the event_id argument (the third column)
+func (s *auditStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.pending = append(s.conn.pending, fmt.Sprint(args[2]))
+	return driver.RowsAffected(1), nil
+}
+
+func init() {
+	sql.Register("pollaudit-recorder", auditDriver{})
+}
+
+// openStore returns a database whose inserts land in a fresh store
+func openStore(t *testing.T) (*sql.DB, *auditStore) {
+	t.Helper()
+	store := &auditStore{}
+	storesMu.Lock()
+	stores[t.Name()] = store
+	storesMu.Unlock()
+
+	db, err := sql.Open("pollaudit-recorder", t.Name())
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db, store
+}
+
+func pollRow(i int) models.PollAudit {
+	return models.PollAudit{
+		SportKey:  "basketball_nba",
+		Track:     "props",
+		EventID:   fmt.Sprintf("evt-%d", i),
+		Requests:  1,
+		Credits:   -1,
+		StartedAt: time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func flatten(batches [][]string) []string {
+	var ids []string
+	for _, batch := range batches {
+		ids = append(ids, batch...)
+	}
+	return ids
+}
+
+func TestRecorder_BatchesQueuedRows(t *testing.T) {
+	db, store := openStore(t)
+	recorder := pollaudit.NewRecorder(db)
+
+	for i := 0; i < 150; i++ {
+		recorder.RecordPoll(pollRow(i))
+	}
+	recorder.Start(context.Background())
+	recorder.Stop()
+
+	batches := store.get()
+	if len(batches) != 2 || len(batches[0]) != 100 || len(batches[1]) != 50 {
+		sizes := make([]int, len(batches))
+		for i, batch := range batches {
+			sizes[i] = len(batch)
+		}
+		t.Fatalf("expected batches of 100 and 50, got %v", sizes)
+	}
+
+	ids := flatten(batches)
+	for i, id := range ids {
+		if want := fmt.Sprintf("evt-%d", i); id != want {
+			t.Fatalf("row %d: expected %s, got %s", i, want, id)
+		}
+	}
+}
+
+func TestRecorder_StopWritesQueuedRows(t *testing.T) {
+	db, store := openStore(t)
+	recorder := pollaudit.NewRecorder(db)
+	recorder.Start(context.Background())
+
+	for i := 0; i < 3; i++ {
+		recorder.RecordPoll(pollRow(i))
+	}
+	recorder.Stop()
+
+	if ids := flatten(store.get()); len(ids) != 3 {
+		t.Errorf("expected 3 rows written by Stop, got %v", ids)
+	}
+}
+
+func TestRecorder_CancelledContextDrainsQueuedRows(t *testing.T) {
+	db, store := openStore(t)
+	recorder := pollaudit.NewRecorder(db)
+
+	for i := 0; i < 3; i++ {
+		recorder.RecordPoll(pollRow(i))
+	}
+
+	// Shutdown cancels the context before Stop: queued rows must still be written
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	recorder.Start(ctx)
+	recorder.Stop()
+
+	if ids := flatten(store.get()); len(ids) != 3 {
+		t.Errorf("expected 3 rows written after cancellation, got %v", ids)
+	}
+}
@@ -0,0 +1,104 @@
+package quota_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/quota"
+)
+
+func testPolicies() []quota.Policy {
+	return []quota.Policy{
+		{
+			SportKey:           "basketball_nba",
+			Priority:           1,
+			FeaturedInterval:   60 * time.Second,
+			PropsInterval:      30 * time.Minute,
+			PollsProps:         true,
+			MinFeaturedCadence: 5 * time.Minute,
+		},
+		{
+			SportKey:           "basketball_ncaab",
+			Priority:           3,
+			FeaturedInterval:   60 * time.Second,
+			PropsInterval:      30 * time.Minute,
+			PollsProps:         true,
+			MinFeaturedCadence: 10 * time.Minute,
+			MinPropsCadence:    2 * time.Hour,
+		},
+	}
+}
+
+func TestCalculatePressure(t *testing.T) {
+	tests := []struct {
+		remaining int
+		expected  float64
+	}{
+		{500, 0},
+		{200, 0},
+		{125, 0.5},
+		{50, 1},
+		{0, 1},
+	}
+
+	for _, tt := range tests {
+		got := quota.CalculatePressure(tt.remaining, 200, 50)
+		if got != tt.expected {
+			t.Errorf("CalculatePressure(%d) = %v, want %v", tt.remaining, got, tt.expected)
+		}
+	}
+}
+
+func TestDegrade_NoPressure(t *testing.T) {
+	plan := quota.Degrade(testPolicies(), 0)
+
+	nba := plan["basketball_nba"]
+	if nba.FeaturedInterval != 60*time.Second || nba.PropsPaused || nba.FeaturedDegraded {
+		t.Errorf("expected NBA untouched, got %+v", nba)
+	}
+}
+
+func TestDegrade_CutsLowPriorityPropsFirst(t *testing.T) {
+	// 4 cuts available; low pressure makes exactly one
+	plan := quota.Degrade(testPolicies(), 0.1)
+
+	ncaab := plan["basketball_ncaab"]
+	if !ncaab.PropsDegraded || ncaab.PropsInterval != 2*time.Hour {
+		t.Errorf("expected NCAAB props slowed to 2h, got %+v", ncaab)
+	}
+	if ncaab.PropsPaused {
+		t.Error("NCAAB props should be slowed, not paused, when a minimum cadence is set")
+	}
+
+	nba := plan["basketball_nba"]
+	if nba.PropsDegraded || nba.FeaturedDegraded {
+		t.Errorf("expected NBA untouched, got %+v", nba)
+	}
+}
+
+func TestDegrade_ProtectsHighPriorityFeaturedLongest(t *testing.T) {
+	// 3 of 4 cuts: both props, then NCAAB featured
+	plan := quota.Degrade(testPolicies(), 0.75)
+
+	nba := plan["basketball_nba"]
+	if !nba.PropsPaused {
+		t.Error("expected NBA props paused (no minimum props cadence)")
+	}
+	if nba.FeaturedDegraded {
+		t.Error("expected NBA featured protected")
+	}
+
+	ncaab := plan["basketball_ncaab"]
+	if !ncaab.FeaturedDegraded || ncaab.FeaturedInterval != 10*time.Minute {
+		t.Errorf("expected NCAAB featured slowed to 10m, got %+v", ncaab)
+	}
+}
+
+func TestDegrade_FullPressure(t *testing.T) {
+	plan := quota.Degrade(testPolicies(), 1)
+
+	nba := plan["basketball_nba"]
+	if !nba.FeaturedDegraded || nba.FeaturedInterval != 5*time.Minute {
+		t.Errorf("expected NBA featured at minimum cadence, got %+v", nba)
+	}
+}
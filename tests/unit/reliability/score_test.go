@@ -0,0 +1,104 @@
+package reliability_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/books"
+	"github.com/XavierBriggs/Mercury/internal/reliability"
+)
+
+func TestScore_PerfectBook(t *testing.T) {
+	score := reliability.Score(reliability.Signals{
+		BookKey:          "pinnacle",
+		Rows:             1000,
+		StalenessSeconds: 0,
+		Coverage:         1,
+		AnomalyCount:     0,
+		ClosingDeviation: 0,
+		ClosingSamples:   200,
+	})
+
+	if score < 0.999 {
+		t.Errorf("expected perfect book to score ~1, got %v", score)
+	}
+}
+
+func TestScore_PenalizesPoorSignals(t *testing.T) {
+	good := reliability.Signals{Rows: 1000, StalenessSeconds: 10, Coverage: 0.95, ClosingSamples: 100, ClosingDeviation: 0.005}
+
+	tests := []struct {
+		name   string
+		mutate func(*reliability.Signals)
+	}{
+		{"stale", func(s *reliability.Signals) { s.StalenessSeconds = 600 }},
+		{"low coverage", func(s *reliability.Signals) { s.Coverage = 0.2 }},
+		{"anomalies", func(s *reliability.Signals) { s.AnomalyCount = 40 }},
+		{"disagrees with close", func(s *reliability.Signals) { s.ClosingDeviation = 0.04 }},
+	}
+
+	base := reliability.Score(good)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig := good
+			tt.mutate(&sig)
+			if got := reliability.Score(sig); got >= base {
+				t.Errorf("expected score below %v, got %v", base, got)
+			}
+		})
+	}
+}
+
+func TestScore_NoData(t *testing.T) {
+	if got := reliability.Score(reliability.Signals{}); got != 0 {
+		t.Errorf("expected 0 for book with no rows, got %v", got)
+	}
+
+	// Without closing lines the agreement component is excluded, not counted as zero
+	score := reliability.Score(reliability.Signals{Rows: 100, Coverage: 1})
+	if score < 0.999 {
+		t.Errorf("expected missing closing data to be neutral, got %v", score)
+	}
+}
+
+func TestWeight(t *testing.T) {
+	if w := reliability.Weight("sharp", nil); w != 1.0 {
+		t.Errorf("expected static sharp weight 1.0, got %v", w)
+	}
+	if w := reliability.Weight("soft", nil); w != 0.5 {
+		t.Errorf("expected static soft weight 0.5, got %v", w)
+	}
+	if w := reliability.Weight("exchange", nil); w != 0.8 {
+		t.Errorf("expected static exchange weight 0.8, got %v", w)
+	}
+	if w := reliability.Weight("unknown", nil); w != 0.5 {
+		t.Errorf("expected unknown types to get the soft weight, got %v", w)
+	}
+
+	score := 0.82
+	if w := reliability.Weight("soft", &score); w != 0.82 {
+		t.Errorf("expected scored weight 0.82, got %v", w)
+	}
+}
+
+func TestWeights_Of(t *testing.T) {
+	var none *reliability.Weights
+	if got := none.Of("pinnacle"); got != 1 {
+		t.Errorf("expected nil weights to weigh every book 1, got %v", got)
+	}
+
+	weights := reliability.NewWeights(map[string]float64{"pinnacle": 0.9, "broken": 1.7})
+	if got := weights.Of("pinnacle"); got != 0.9 {
+		t.Errorf("expected 0.9, got %v", got)
+	}
+	if got := weights.Of("broken"); got != 1 {
+		t.Errorf("expected weights clamped to 1, got %v", got)
+	}
+	if got := weights.Of("newbook"); got != books.DefaultWeight(books.ClassSoft) {
+		t.Errorf("expected an unloaded book to get the soft default, got %v", got)
+	}
+
+	weights.Set(map[string]float64{"pinnacle": 0.4})
+	if got := weights.Of("pinnacle"); got != 0.4 {
+		t.Errorf("expected the reloaded 0.4, got %v", got)
+	}
+}
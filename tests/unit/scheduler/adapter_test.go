@@ -0,0 +1,122 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/testutil"
+)
+
+func newMockScheduler(adapter *testutil.MockVendorAdapter) *scheduler.Scheduler {
+	return scheduler.NewScheduler(nil, nil, adapter, time.Minute, registry.NewSportRegistry())
+}
+
+func oddsResult(eventID, market string) *models.FetchResult {
+	return &models.FetchResult{
+		Events: []models.Event{testutil.NewTestEvent(eventID, "Los Angeles Lakers", "Boston Celtics", 2)},
+		Odds:   []models.RawOdds{testutil.NewTestOdd(eventID, market, "fanduel", "Los Angeles Lakers", -110, nil)},
+	}
+}
+
+func TestFetchOdds_PassesOptionsThrough(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{}
+	adapter.QueueOdds(oddsResult("e1", "h2h"), nil)
+	s := newMockScheduler(adapter)
+
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"h2h", "spreads"}}
+	result, err := s.FetchOdds(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if len(result.Odds) != 1 {
+		t.Errorf("expected the queued result, got %d odds", len(result.Odds))
+	}
+
+	calls := adapter.Calls()
+	if len(calls) != 1 || calls[0].Method != testutil.MockFetchOdds {
+		t.Fatalf("expected one FetchOdds call, got %+v", calls)
+	}
+	if got := strings.Join(calls[0].Odds.Markets, ","); got != "h2h,spreads" || calls[0].Odds.Sport != "basketball_nba" {
+		t.Errorf("expected the options unchanged, got %+v", calls[0].Odds)
+	}
+}
+
+func TestFetchOdds_SplitsAndKeepsPartialResults(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{
+		FetchOddsFunc: func(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+			if opts.Markets[0] == "totals" {
+				return nil, merrors.Newf(merrors.ErrVendorUnavailable, "HTTP 502")
+			}
+			return oddsResult("e1", opts.Markets[0]), nil
+		},
+	}
+	s := newMockScheduler(adapter)
+	s.SetFetchSplit(scheduler.FetchSplit{Parallelism: 3, MarketsPerRequest: 1})
+
+	result, err := s.FetchOdds(context.Background(), &models.FetchOddsOptions{
+		Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"h2h", "spreads", "totals"},
+	})
+	if adapter.CallCount(testutil.MockFetchOdds) != 3 {
+		t.Fatalf("expected one request per market, got %d", adapter.CallCount(testutil.MockFetchOdds))
+	}
+	if merrors.KindOf(err) != merrors.ErrVendorUnavailable || !strings.Contains(err.Error(), "markets=[totals]") {
+		t.Errorf("expected the failed part's error, got %v", err)
+	}
+	if result == nil || len(result.Odds) != 2 || len(result.Events) != 1 {
+		t.Fatalf("expected the two successful parts merged, got %+v", result)
+	}
+}
+
+func TestFetchOdds_RateLimitPausesVendorRequests(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{}
+	adapter.QueueOdds(nil, &merrors.Error{Kind: merrors.ErrRateLimited, StatusCode: 429, RetryAfter: time.Minute, Err: errors.New("HTTP 429")})
+	s := newMockScheduler(adapter)
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Regions: []string{"us"}, Markets: []string{"h2h"}}
+
+	if _, err := s.FetchOdds(context.Background(), opts); merrors.KindOf(err) != merrors.ErrRateLimited {
+		t.Fatalf("expected the rate limit, got %v", err)
+	}
+	if _, err := s.FetchOdds(context.Background(), opts); merrors.KindOf(err) != merrors.ErrRateLimited {
+		t.Errorf("expected requests paused as rate limited, got %v", err)
+	}
+	if _, err := s.FetchEventOdds(context.Background(), &models.FetchEventOddsOptions{Sport: "basketball_nba", EventID: "e1", Markets: []string{"player_points"}}); err == nil {
+		t.Error("expected props requests paused too")
+	}
+	if calls := len(adapter.Calls()); calls != 1 {
+		t.Errorf("expected no requests while paused, got %d", calls)
+	}
+}
+
+func TestFetchEventOdds_BatchesMarkets(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{}
+	adapter.QueueEventOdds(oddsResult("e1", "player_points"), nil)
+	adapter.QueueEventOdds(oddsResult("e1", "player_assists"), nil)
+	s := newMockScheduler(adapter)
+	s.SetMarketBatching(scheduler.MarketBatching{MaxMarketsPerRequest: 2})
+
+	result, err := s.FetchEventOdds(context.Background(), &models.FetchEventOddsOptions{
+		Sport: "basketball_nba", EventID: "e1", Regions: []string{"us"},
+		Markets: []string{"player_points", "player_rebounds", "player_assists"},
+	})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+
+	calls := adapter.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("expected 2 requests for 3 markets at 2 per request, got %d", len(calls))
+	}
+	if len(calls[0].EventOdds.Markets) != 2 || len(calls[1].EventOdds.Markets) != 1 || calls[1].EventOdds.EventID != "e1" {
+		t.Errorf("unexpected batches %+v and %+v", calls[0].EventOdds, calls[1].EventOdds)
+	}
+	if len(result.Odds) != 2 {
+		t.Errorf("expected both batches merged, got %d odds", len(result.Odds))
+	}
+}
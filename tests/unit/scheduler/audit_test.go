@@ -0,0 +1,156 @@
+package scheduler_test
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/contracts"
+	merrors "github.com/XavierBriggs/Mercury/pkg/errors"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/testutil"
+)
+
+// auditRows collects the rows a scheduler hands to its audit sink
+type auditRows struct {
+	mu   sync.Mutex
+	rows []models.PollAudit
+}
+
+func (a *auditRows) RecordPoll(row models.PollAudit) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.rows = append(a.rows, row)
+}
+
+func (a *auditRows) get() []models.PollAudit {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]models.PollAudit(nil), a.rows...)
+}
+
+func TestScheduler_EachPollGetsItsOwnID(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{
+		FetchOddsFunc: func(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+			return nil, merrors.Newf(merrors.ErrVendorUnavailable, "HTTP 502")
+		},
+	}
+	sink := &auditRows{}
+	fake := runNBA(t, adapter, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), func(s *scheduler.Scheduler) {
+		s.SetPollAuditSink(sink)
+	})
+
+	featured := func(n int) func() bool {
+		return func() bool {
+			count := 0
+			for _, row := range sink.get() {
+				if row.Track == "featured" {
+					count++
+				}
+			}
+			return count >= n
+		}
+	}
+	waitFor(t, "the first featured audit row", featured(1))
+	fake.BlockUntil(4)
+	fake.Advance(time.Minute)
+	waitFor(t, "the second featured audit row", featured(2))
+
+	seen := make(map[string]bool)
+	for _, row := range sink.get() {
+		if _, err := hex.DecodeString(row.PollID); err != nil || len(row.PollID) != 16 {
+			t.Errorf("expected a 16 hex character poll ID, got %q", row.PollID)
+		}
+		if seen[row.PollID] {
+			t.Errorf("poll ID %q reused", row.PollID)
+		}
+		seen[row.PollID] = true
+	}
+}
+
+// featuredRows returns the featured track's rows recorded so far
+func featuredRows(sink *auditRows) []models.PollAudit {
+	var rows []models.PollAudit
+	for _, row := range sink.get() {
+		if row.Track == "featured" {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func TestScheduler_AuditRowCarriesResponseStats(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{
+		FetchOddsFunc: func(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+			stats := contracts.RequestStatsFrom(ctx)
+			stats.Response(200, 3)
+			stats.AddBytes(2048)
+			return &models.FetchResult{}, nil
+		},
+	}
+	sink := &auditRows{}
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	runNBA(t, adapter, start, func(s *scheduler.Scheduler) {
+		s.SetPollAuditSink(sink)
+	})
+
+	waitFor(t, "the featured audit row", func() bool { return len(featuredRows(sink)) >= 1 })
+	row := featuredRows(sink)[0]
+
+	if row.SportKey != "basketball_nba" || !row.StartedAt.Equal(start) {
+		t.Errorf("expected an NBA row started at %v, got %s at %v", start, row.SportKey, row.StartedAt)
+	}
+	if row.Requests != 1 || row.StatusCode != 200 || row.Bytes != 2048 || row.Credits != 3 {
+		t.Errorf("expected 1 request, status 200, 2048 bytes and 3 credits, got %+v", row)
+	}
+	if len(row.Regions) == 0 || len(row.Markets) == 0 {
+		t.Errorf("expected the requested regions and markets, got %v and %v", row.Regions, row.Markets)
+	}
+	if row.ErrorKind != "" || row.Error != "" {
+		t.Errorf("expected no error, got %s: %s", row.ErrorKind, row.Error)
+	}
+}
+
+func TestScheduler_AuditRowLabelsFailures(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{
+		FetchOddsFunc: func(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+			contracts.RequestStatsFrom(ctx).Response(502, -1)
+			return nil, merrors.Newf(merrors.ErrVendorUnavailable, "HTTP 502")
+		},
+	}
+	sink := &auditRows{}
+	runNBA(t, adapter, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), func(s *scheduler.Scheduler) {
+		s.SetPollAuditSink(sink)
+	})
+
+	waitFor(t, "the featured audit row", func() bool { return len(featuredRows(sink)) >= 1 })
+	row := featuredRows(sink)[0]
+
+	if row.Requests != 1 || row.StatusCode != 502 || row.Credits != -1 {
+		t.Errorf("expected 1 request with status 502 and unreported credits, got %+v", row)
+	}
+	if row.ErrorKind != "vendor_unavailable" || row.Error == "" {
+		t.Errorf("expected a vendor_unavailable error, got %q: %q", row.ErrorKind, row.Error)
+	}
+}
+
+func TestScheduler_NoAuditRowWithoutRequestOrError(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{}
+	sink := &auditRows{}
+	fake := runNBA(t, adapter, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), func(s *scheduler.Scheduler) {
+		s.SetPollAuditSink(sink)
+	})
+
+	// The second featured poll only starts once the first has finished its audit
+	waitFor(t, "the initial featured poll", func() bool { return adapter.CallCount(testutil.MockFetchOdds) >= 1 })
+	fake.BlockUntil(4)
+	fake.Advance(time.Minute)
+	waitFor(t, "the second featured poll", func() bool { return adapter.CallCount(testutil.MockFetchOdds) >= 2 })
+
+	if rows := featuredRows(sink); len(rows) != 0 {
+		t.Errorf("expected no row for polls that sent no request, got %+v", rows)
+	}
+}
@@ -0,0 +1,145 @@
+package scheduler_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+)
+
+var featured = []string{"h2h", "spreads", "totals", "alternate_spreads"}
+
+func TestParseMarketCadence(t *testing.T) {
+	cadence, err := scheduler.ParseMarketCadence(" totals=2m, h2h=5m ,")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := map[string]time.Duration{"totals": 2 * time.Minute, "h2h": 5 * time.Minute}
+	if !reflect.DeepEqual(cadence, want) {
+		t.Errorf("cadence = %v, want %v", cadence, want)
+	}
+
+	for _, bad := range []string{"totals", "totals=soon", "=1m", "h2h=-1m"} {
+		if _, err := scheduler.ParseMarketCadence(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestRequestCount(t *testing.T) {
+	tests := []struct{ markets, limit, want int }{
+		{0, 3, 0},
+		{4, 0, 1},
+		{3, 3, 1},
+		{4, 3, 2},
+		{7, 3, 3},
+	}
+	for _, tt := range tests {
+		if got := scheduler.RequestCount(tt.markets, tt.limit); got != tt.want {
+			t.Errorf("RequestCount(%d, %d) = %d, want %d", tt.markets, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMarketPlanner_FollowsCadence(t *testing.T) {
+	planner := scheduler.NewMarketPlanner(scheduler.MarketBatching{
+		Cadence: map[string]time.Duration{"totals": 3 * time.Minute},
+	})
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	interval := time.Minute
+
+	// Everything is due before its first fetch
+	got := planner.Plan("basketball_nba", featured, start, interval, 1, true)
+	if !reflect.DeepEqual(got, featured) {
+		t.Fatalf("first plan = %v, want every market", got)
+	}
+	planner.Fetched("basketball_nba", got, start)
+
+	got = planner.Plan("basketball_nba", featured, start.Add(interval), interval, 1, true)
+	if want := []string{"h2h", "spreads", "alternate_spreads"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("plan after 1m = %v, want %v", got, want)
+	}
+
+	// Due within half a poll counts as due, so ticker drift does not skip a poll
+	got = planner.Plan("basketball_nba", featured, start.Add(3*interval-time.Second), interval, 1, true)
+	if !reflect.DeepEqual(got, featured) {
+		t.Errorf("plan after 3m = %v, want every market", got)
+	}
+
+	// Other sports are tracked separately
+	if got := planner.Plan("americanfootball_nfl", featured, start.Add(interval), interval, 1, true); len(got) != len(featured) {
+		t.Errorf("other sport plan = %v, want every market", got)
+	}
+}
+
+func TestMarketPlanner_SkipsPollWithNothingDue(t *testing.T) {
+	planner := scheduler.NewMarketPlanner(scheduler.MarketBatching{
+		Cadence: map[string]time.Duration{"totals": 5 * time.Minute},
+	})
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	planner.Fetched("basketball_nba", []string{"totals"}, start)
+
+	if got := planner.Plan("basketball_nba", []string{"totals"}, start.Add(time.Minute), time.Minute, 1, true); got != nil {
+		t.Errorf("plan = %v, want nil", got)
+	}
+}
+
+func TestMarketPlanner_PullsForwardIntoSpareSlots(t *testing.T) {
+	planner := scheduler.NewMarketPlanner(scheduler.MarketBatching{
+		MaxMarketsPerRequest: 2,
+		Cadence: map[string]time.Duration{
+			"spreads":           2 * time.Minute,
+			"totals":            2 * time.Minute,
+			"alternate_spreads": 2 * time.Minute,
+		},
+	})
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	interval := time.Minute
+	planner.Fetched("basketball_nba", featured, start)
+	planner.Fetched("basketball_nba", []string{"totals"}, start.Add(-20*time.Second))
+
+	// Only h2h is due; the spare slot in its request takes the market due soonest
+	got := planner.Plan("basketball_nba", featured, start.Add(interval), interval, 1, true)
+	if want := []string{"h2h", "totals"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("plan = %v, want %v", got, want)
+	}
+
+	// Under quota pressure nothing is pulled forward
+	got = planner.Plan("basketball_nba", featured, start.Add(interval), interval, 1, false)
+	if want := []string{"h2h"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("degraded plan = %v, want %v", got, want)
+	}
+}
+
+func TestMarketPlanner_NoPullForwardWithoutLimit(t *testing.T) {
+	planner := scheduler.NewMarketPlanner(scheduler.MarketBatching{
+		Cadence: map[string]time.Duration{"totals": 2 * time.Minute},
+	})
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	planner.Fetched("basketball_nba", featured, start)
+
+	// Without a limit everything fits one request anyway, so early fetches save nothing
+	got := planner.Plan("basketball_nba", featured, start.Add(time.Minute), time.Minute, 1, true)
+	if want := []string{"h2h", "spreads", "alternate_spreads"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("plan = %v, want %v", got, want)
+	}
+}
+
+func TestMarketPlanner_StretchesCadenceUnderQuotaPressure(t *testing.T) {
+	planner := scheduler.NewMarketPlanner(scheduler.MarketBatching{
+		Cadence: map[string]time.Duration{"totals": 2 * time.Minute},
+	})
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	planner.Fetched("basketball_nba", featured, start)
+
+	// Featured interval degraded 1m → 2m: totals now waits 4m
+	got := planner.Plan("basketball_nba", featured, start.Add(2*time.Minute), 2*time.Minute, 2, false)
+	if want := []string{"h2h", "spreads", "alternate_spreads"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("plan after 2m = %v, want %v", got, want)
+	}
+	got = planner.Plan("basketball_nba", featured, start.Add(4*time.Minute), 2*time.Minute, 2, false)
+	if !reflect.DeepEqual(got, featured) {
+		t.Errorf("plan after 4m = %v, want every market", got)
+	}
+}
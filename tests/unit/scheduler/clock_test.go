@@ -0,0 +1,127 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/testutil"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+// waitFor polls cond until it holds, failing the test after a few seconds. The
+// scheduler's loops run on their own goroutines, so they react to a fake clock
+// advance shortly after it
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// runNBA runs a scheduler polling the NBA module against adapter on a fake clock.
+// Waiters pending once it is idle: featured, tipoff, props discovery and writer
+// tickers, plus one timer per props poller. configure runs before the scheduler starts
+func runNBA(t *testing.T, adapter *testutil.MockVendorAdapter, start time.Time, configure ...func(*scheduler.Scheduler)) *clock.Fake {
+	t.Helper()
+
+	sports := registry.NewSportRegistry()
+	if err := sports.Register(basketball_nba.NewModule()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	fake := clock.NewFake(start)
+	s := scheduler.NewScheduler(nil, nil, adapter, time.Minute, sports)
+	s.SetClock(fake)
+	s.Writer.SetClock(fake)
+	for _, fn := range configure {
+		fn(s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("run: %v", err)
+			}
+		case <-time.After(3 * time.Second):
+			t.Error("scheduler did not stop")
+		}
+	})
+	return fake
+}
+
+func TestScheduler_FeaturedPollsEveryInterval(t *testing.T) {
+	adapter := &testutil.MockVendorAdapter{}
+	fake := runNBA(t, adapter, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+
+	featured := func(n int) func() bool {
+		return func() bool { return adapter.CallCount(testutil.MockFetchOdds) >= n }
+	}
+	waitFor(t, "the initial featured poll", featured(1))
+	fake.BlockUntil(4)
+
+	fake.Advance(59 * time.Second)
+	if got := adapter.CallCount(testutil.MockFetchOdds); got != 1 {
+		t.Fatalf("expected no poll before the 60s interval, got %d", got)
+	}
+	fake.Advance(time.Second)
+	waitFor(t, "the second featured poll", featured(2))
+	fake.Advance(time.Minute)
+	waitFor(t, "the third featured poll", featured(3))
+}
+
+func TestScheduler_PropsRampTowardsTipoff(t *testing.T) {
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	event := testutil.NewTestEvent("e1", "Los Angeles Lakers", "Boston Celtics", 0)
+	event.CommenceTime = start.Add(100 * time.Minute)
+
+	adapter := &testutil.MockVendorAdapter{}
+	adapter.QueueEvents([]models.Event{event}, nil)
+	fake := runNBA(t, adapter, start)
+
+	props := func(n int) func() bool {
+		return func() bool { return adapter.CallCount(testutil.MockFetchEventOdds) >= n }
+	}
+	waitFor(t, "the initial props poll", props(1))
+	fake.BlockUntil(5)
+
+	// 1h40m out the NBA ramp polls props every 10 minutes, plus up to 5s of jitter
+	for i := 0; i < 9; i++ {
+		fake.Advance(time.Minute)
+	}
+	if got := adapter.CallCount(testutil.MockFetchEventOdds); got != 1 {
+		t.Fatalf("expected no props poll within 10 minutes, got %d", got)
+	}
+	fake.Advance(time.Minute)
+	fake.Advance(5 * time.Second)
+	waitFor(t, "the second props poll", props(2))
+	fake.BlockUntil(5)
+
+	// Now inside 1.5h of tip-off the ramp tightens to 2 minutes
+	fake.Advance(time.Minute)
+	if got := adapter.CallCount(testutil.MockFetchEventOdds); got != 2 {
+		t.Fatalf("expected no props poll within 2 minutes, got %d", got)
+	}
+	fake.Advance(time.Minute + 5*time.Second)
+	waitFor(t, "the third props poll", props(3))
+
+	calls := adapter.Calls()
+	for _, call := range calls {
+		if call.Method == testutil.MockFetchEventOdds && call.EventOdds.EventID != "e1" {
+			t.Errorf("expected props polls for e1, got %q", call.EventOdds.EventID)
+		}
+	}
+}
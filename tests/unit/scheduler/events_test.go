@@ -0,0 +1,50 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestEventsCache(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	cache := scheduler.NewEventsCache(time.Minute)
+	window := func(at time.Time, hours int) *models.FetchEventsOptions {
+		return &models.FetchEventsOptions{Sport: "basketball_nba", CommenceTimeFrom: at, CommenceTimeTo: at.Add(time.Duration(hours) * time.Hour)}
+	}
+
+	if _, ok := cache.Get(window(now, 48), now); ok {
+		t.Fatal("hit before any fetch")
+	}
+	cache.Put(window(now, 48), []models.Event{
+		{EventID: "tonight", CommenceTime: now.Add(8 * time.Hour)},
+		{EventID: "friday", CommenceTime: now.Add(40 * time.Hour)},
+	}, now)
+
+	// Half a minute later the window has moved on but is still covered
+	later := now.Add(30 * time.Second)
+	if events, ok := cache.Get(window(later, 48), later); !ok || len(events) != 2 {
+		t.Errorf("expected both events from cache, got %d (hit %t)", len(events), ok)
+	}
+	if events, ok := cache.Get(window(later, 24), later); !ok || len(events) != 1 || events[0].EventID != "tonight" {
+		t.Errorf("expected a narrower window filtered from cache, got %v (hit %t)", events, ok)
+	}
+
+	tests := []struct {
+		name string
+		opts *models.FetchEventsOptions
+		at   time.Time
+	}{
+		{"expired", window(now.Add(time.Minute), 48), now.Add(time.Minute)},
+		{"wider window", window(later, 72), later},
+		{"unbounded", &models.FetchEventsOptions{Sport: "basketball_nba"}, later},
+		{"other sport", &models.FetchEventsOptions{Sport: "americanfootball_nfl"}, later},
+	}
+	for _, tt := range tests {
+		if _, ok := cache.Get(tt.opts, tt.at); ok {
+			t.Errorf("%s: expected a miss", tt.name)
+		}
+	}
+}
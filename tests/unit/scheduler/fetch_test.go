@@ -0,0 +1,159 @@
+package scheduler_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestSplitFetch(t *testing.T) {
+	opts := &models.FetchOddsOptions{
+		Sport:   "basketball_nba",
+		Regions: []string{"us", "us2"},
+		Markets: []string{"h2h", "spreads", "totals"},
+	}
+
+	parts := scheduler.SplitFetch(opts, 2)
+	if len(parts) != 4 {
+		t.Fatalf("expected 2 regions x 2 market groups, got %d parts", len(parts))
+	}
+	if !reflect.DeepEqual(parts[0].Regions, []string{"us"}) || !reflect.DeepEqual(parts[0].Markets, []string{"h2h", "spreads"}) {
+		t.Errorf("unexpected first part %+v", parts[0])
+	}
+	if !reflect.DeepEqual(parts[3].Regions, []string{"us2"}) || !reflect.DeepEqual(parts[3].Markets, []string{"totals"}) {
+		t.Errorf("unexpected last part %+v", parts[3])
+	}
+
+	// Without a market group size, split by region only
+	if parts := scheduler.SplitFetch(opts, 0); len(parts) != 2 || len(parts[1].Markets) != 3 {
+		t.Errorf("expected one request per region with every market, got %+v", parts)
+	}
+}
+
+func TestSplitFetch_Bookmakers(t *testing.T) {
+	books := []string{"b1", "b2", "b3", "b4", "b5", "b6", "b7", "b8", "b9", "b10", "b11", "b12"}
+	opts := &models.FetchOddsOptions{
+		Sport:      "basketball_nba",
+		Regions:    []string{"us", "us2", "eu"},
+		Markets:    []string{"h2h", "spreads", "totals"},
+		Bookmakers: books,
+	}
+
+	// One request per billing group of 10 books; regions are ignored
+	parts := scheduler.SplitFetch(opts, 0)
+	if len(parts) != 2 {
+		t.Fatalf("expected 2 bookmaker groups, got %d parts", len(parts))
+	}
+	if !reflect.DeepEqual(parts[0].Bookmakers, books[:10]) || !reflect.DeepEqual(parts[1].Bookmakers, books[10:]) {
+		t.Errorf("unexpected bookmaker groups %v / %v", parts[0].Bookmakers, parts[1].Bookmakers)
+	}
+	for _, part := range parts {
+		if len(part.Regions) != 0 || len(part.Markets) != 3 {
+			t.Errorf("unexpected part %+v", part)
+		}
+	}
+
+	if parts := scheduler.SplitFetch(opts, 2); len(parts) != 4 {
+		t.Errorf("expected 2 bookmaker groups x 2 market groups, got %d parts", len(parts))
+	}
+}
+
+func TestSplitFetch_KeepsCommenceWindow(t *testing.T) {
+	to := time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)
+	for _, opts := range []*models.FetchOddsOptions{
+		{Sport: "basketball_nba", Regions: []string{"us", "us2"}, Markets: []string{"h2h"}, CommenceTimeTo: to},
+		{Sport: "basketball_nba", Bookmakers: []string{"fanduel"}, Markets: []string{"h2h", "totals"}, CommenceTimeTo: to},
+	} {
+		for _, part := range scheduler.SplitFetch(opts, 1) {
+			if !part.CommenceTimeTo.Equal(to) || !part.CommenceTimeFrom.IsZero() {
+				t.Errorf("expected every part to keep the window, got %+v", part)
+			}
+		}
+	}
+}
+
+func TestSplitFetch_KeepsEventIDs(t *testing.T) {
+	opts := &models.FetchOddsOptions{
+		Sport:    "basketball_nba",
+		Regions:  []string{"us", "us2"},
+		Markets:  []string{"h2h", "spreads"},
+		EventIDs: []string{"e1", "e2"},
+	}
+	parts := scheduler.SplitFetch(opts, 1)
+	if len(parts) != 4 {
+		t.Fatalf("expected 4 parts, got %d", len(parts))
+	}
+	for _, part := range parts {
+		if !reflect.DeepEqual(part.EventIDs, opts.EventIDs) || len(part.Regions) != 1 {
+			t.Errorf("expected every part to target the same events, got %+v", part)
+		}
+	}
+}
+
+func TestTipoffTracker_Due(t *testing.T) {
+	now := time.Date(2025, 1, 15, 23, 50, 0, 0, time.UTC)
+	tracker := scheduler.NewTipoffTracker()
+	tracker.Observe("basketball_nba", []models.Event{
+		{EventID: "soon", CommenceTime: now.Add(10 * time.Minute)},
+		{EventID: "edge", CommenceTime: now.Add(20 * time.Minute)},
+		{EventID: "later", CommenceTime: now.Add(2 * time.Hour)},
+		{EventID: "live", CommenceTime: now.Add(-5 * time.Minute)},
+		{EventID: "undated"},
+	})
+
+	if due := tracker.Due("basketball_nba", now, 20*time.Minute); !reflect.DeepEqual(due, []string{"edge", "soon"}) {
+		t.Errorf("expected [edge soon], got %v", due)
+	}
+	if due := tracker.Due("icehockey_nhl", now, 20*time.Minute); len(due) != 0 {
+		t.Errorf("expected nothing for an unseen sport, got %v", due)
+	}
+
+	// Commence times move when the vendor reschedules
+	tracker.Observe("basketball_nba", []models.Event{{EventID: "later", CommenceTime: now.Add(15 * time.Minute)}})
+	if due := tracker.Due("basketball_nba", now, 20*time.Minute); len(due) != 3 {
+		t.Errorf("expected the rescheduled event to be due, got %v", due)
+	}
+}
+
+func TestMergeResults(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	odd := func(book, market string) models.RawOdds {
+		return models.RawOdds{EventID: "e1", MarketKey: market, BookKey: book, OutcomeName: "Lakers", Price: -110, VendorLastUpdate: now}
+	}
+	event := models.Event{EventID: "e1"}
+
+	merged := scheduler.MergeResults([]*models.FetchResult{
+		{Events: []models.Event{event}, Odds: []models.RawOdds{odd("fanduel", "h2h")}},
+		nil, // a failed part
+		{Events: []models.Event{event, {EventID: "e2"}}, Odds: []models.RawOdds{odd("fanduel", "h2h"), odd("betmgm", "spreads")}},
+	})
+
+	if len(merged.Events) != 2 {
+		t.Errorf("expected events deduplicated to 2, got %d", len(merged.Events))
+	}
+	if len(merged.Odds) != 2 {
+		t.Errorf("expected the repeated outcome kept once (2 odds), got %d", len(merged.Odds))
+	}
+}
+
+func TestMergeResults_NotModified(t *testing.T) {
+	unchanged := &models.FetchResult{NotModified: true}
+	changed := &models.FetchResult{
+		Events:     []models.Event{{EventID: "e1"}},
+		Validators: map[string]models.Validators{"/v4/sports/basketball_nba/odds?markets=h2h": {ETag: `"v2"`}},
+	}
+
+	if merged := scheduler.MergeResults([]*models.FetchResult{unchanged, nil, unchanged}); !merged.NotModified {
+		t.Error("expected every part answering 304 to merge as not modified")
+	}
+	merged := scheduler.MergeResults([]*models.FetchResult{unchanged, changed})
+	if merged.NotModified || len(merged.Validators) != 1 {
+		t.Errorf("expected a changed part to win with its validators, got %+v", merged)
+	}
+	if merged := scheduler.MergeResults([]*models.FetchResult{nil}); merged.NotModified {
+		t.Error("expected failed parts not to count as not modified")
+	}
+}
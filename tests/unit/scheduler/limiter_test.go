@@ -0,0 +1,88 @@
+package scheduler_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+)
+
+func TestRequestLimiter_BoundsConcurrency(t *testing.T) {
+	limiter := scheduler.NewRequestLimiter(2, 0)
+	stop := make(chan struct{})
+
+	var inFlight, peak int64
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := limiter.Acquire(context.Background(), stop)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			n := atomic.AddInt64(&inFlight, 1)
+			for {
+				p := atomic.LoadInt64(&peak)
+				if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt64(&inFlight, -1)
+			release()
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("peak concurrency %d exceeds 2", peak)
+	}
+}
+
+func TestRequestLimiter_PacesStarts(t *testing.T) {
+	limiter := scheduler.NewRequestLimiter(10, 20*time.Millisecond)
+	stop := make(chan struct{})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		release, err := limiter.Acquire(context.Background(), stop)
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+
+	// Starts at 0, 20, 40 and 60ms
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("4 paced starts took %v, want at least 60ms", elapsed)
+	}
+}
+
+func TestRequestLimiter_StopUnblocksWaiters(t *testing.T) {
+	limiter := scheduler.NewRequestLimiter(1, 0)
+	stop := make(chan struct{})
+
+	release, _ := limiter.Acquire(context.Background(), stop)
+	defer release()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := limiter.Acquire(context.Background(), stop)
+		done <- err
+	}()
+
+	close(stop)
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected an error once the scheduler stops")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not released by stop")
+	}
+}
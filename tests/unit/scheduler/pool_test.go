@@ -0,0 +1,111 @@
+package scheduler_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+)
+
+func TestWorkerPool_SkipsBusyKey(t *testing.T) {
+	pool := scheduler.NewWorkerPool(2)
+	defer pool.Stop()
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	if err := pool.Submit("basketball_nba", func() {
+		close(started)
+		<-release
+	}); err != nil {
+		t.Fatalf("first submit: %v", err)
+	}
+	<-started
+
+	if err := pool.Submit("basketball_nba", func() {}); !errors.Is(err, scheduler.ErrPollBusy) {
+		t.Fatalf("expected ErrPollBusy, got %v", err)
+	}
+	if !pool.Busy("basketball_nba") {
+		t.Error("expected key to be busy while running")
+	}
+
+	// Another sport is unaffected
+	done := make(chan struct{})
+	if err := pool.Submit("americanfootball_nfl", func() { close(done) }); err != nil {
+		t.Fatalf("other key: %v", err)
+	}
+	<-done
+
+	close(release)
+	waitIdle(t, pool, "basketball_nba")
+
+	ran := make(chan struct{})
+	if err := pool.Submit("basketball_nba", func() { close(ran) }); err != nil {
+		t.Fatalf("resubmit after finish: %v", err)
+	}
+	<-ran
+
+	if got := pool.Skipped(); got != 1 {
+		t.Errorf("expected 1 skipped, got %d", got)
+	}
+}
+
+func TestWorkerPool_RejectsWhenFull(t *testing.T) {
+	pool := scheduler.NewWorkerPool(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	if err := pool.Submit("a", func() {
+		close(started)
+		<-release
+	}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	// One worker busy, one queue slot
+	if err := pool.Submit("b", func() {}); err != nil {
+		t.Fatalf("queued submit: %v", err)
+	}
+	if err := pool.Submit("c", func() {}); !errors.Is(err, scheduler.ErrPoolFull) {
+		t.Fatalf("expected ErrPoolFull, got %v", err)
+	}
+
+	close(release)
+	pool.Stop()
+
+	if err := pool.Submit("d", func() {}); !errors.Is(err, scheduler.ErrPoolStopped) {
+		t.Fatalf("expected ErrPoolStopped, got %v", err)
+	}
+}
+
+func TestWorkerPool_StopWaitsForRunning(t *testing.T) {
+	pool := scheduler.NewWorkerPool(1)
+	started := make(chan struct{})
+	finished := false
+
+	if err := pool.Submit("a", func() {
+		close(started)
+		time.Sleep(20 * time.Millisecond)
+		finished = true
+	}); err != nil {
+		t.Fatal(err)
+	}
+	<-started
+
+	pool.Stop()
+	if !finished {
+		t.Error("Stop returned before the running job finished")
+	}
+}
+
+func waitIdle(t *testing.T, pool *scheduler.WorkerPool, key string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for pool.Busy(key) {
+		if time.Now().After(deadline) {
+			t.Fatalf("key %s still busy", key)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
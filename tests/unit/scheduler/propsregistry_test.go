@@ -0,0 +1,132 @@
+package scheduler_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/pkg/testutil"
+)
+
+func TestPropsRegistry(t *testing.T) {
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	registry := scheduler.NewPropsRegistry()
+	late := models.Event{EventID: "late", HomeTeam: "Lakers", AwayTeam: "Celtics", CommenceTime: now.Add(10 * time.Hour)}
+	early := models.Event{EventID: "early", CommenceTime: now.Add(2 * time.Hour)}
+
+	if !registry.Register("basketball_nba", late, now) || !registry.Register("basketball_nba", early, now) {
+		t.Fatal("expected new events to register")
+	}
+	// An overlapping discovery sweep finds the same event again
+	if registry.Register("basketball_nba", late, now.Add(time.Minute)) {
+		t.Error("expected a second registration of the same event to be refused")
+	}
+	registry.Register("americanfootball_nfl", models.Event{EventID: "nfl"}, now)
+
+	registry.Polled("late", now.Add(time.Second))
+	registry.Scheduled("late", now.Add(30*time.Minute), 30*time.Minute)
+	p, ok := registry.Get("late")
+	if !ok || p.Polls != 1 || p.HomeTeam != "Lakers" || !p.NextPollAt.Equal(now.Add(30*time.Minute)) || !p.StartedAt.Equal(now) {
+		t.Errorf("unexpected poller %+v", p)
+	}
+
+	nba := registry.List("basketball_nba")
+	if len(nba) != 2 || nba[0].EventID != "early" || nba[1].EventID != "late" {
+		t.Errorf("expected NBA pollers by commence time, got %+v", nba)
+	}
+	if len(registry.List("")) != 3 {
+		t.Errorf("expected every sport's pollers without a filter, got %d", registry.Len())
+	}
+
+	// A finished poller frees its event for a later discovery
+	registry.Remove("late")
+	if _, ok := registry.Get("late"); ok {
+		t.Error("expected the removed poller gone")
+	}
+	if !registry.Register("basketball_nba", late, now.Add(time.Hour)) {
+		t.Error("expected a removed event to register again")
+	}
+}
+
+// memoryPropsStore keeps props schedules in memory
+type memoryPropsStore struct {
+	mu      sync.Mutex
+	pollers map[string]models.PropsPoller
+}
+
+func (m *memoryPropsStore) Load(ctx context.Context, sportKey string) ([]models.PropsPoller, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var pollers []models.PropsPoller
+	for _, p := range m.pollers {
+		if p.SportKey == sportKey {
+			pollers = append(pollers, p)
+		}
+	}
+	return pollers, nil
+}
+
+func (m *memoryPropsStore) Save(ctx context.Context, p models.PropsPoller) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pollers[p.EventID] = p
+	return nil
+}
+
+func (m *memoryPropsStore) Remove(ctx context.Context, eventID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pollers, eventID)
+	return nil
+}
+
+func (m *memoryPropsStore) get(eventID string) (models.PropsPoller, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.pollers[eventID]
+	return p, ok
+}
+
+func TestScheduler_ResumesSavedPropsSchedules(t *testing.T) {
+	start := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	store := &memoryPropsStore{pollers: map[string]models.PropsPoller{
+		// Saved 4 minutes into a 10 minute ramp tier before the restart
+		"e1": {
+			EventID: "e1", SportKey: "basketball_nba", CommenceTime: start.Add(100 * time.Minute),
+			StartedAt: start.Add(-time.Hour), Polls: 3, NextPollAt: start.Add(6 * time.Minute), RampInterval: 10 * time.Minute,
+		},
+		// Over before the restart
+		"old": {EventID: "old", SportKey: "basketball_nba", CommenceTime: start.Add(-6 * time.Hour), StartedAt: start.Add(-2 * 24 * time.Hour)},
+	}}
+	adapter := &testutil.MockVendorAdapter{}
+	var sched *scheduler.Scheduler
+	fake := runNBA(t, adapter, start, func(s *scheduler.Scheduler) {
+		s.SetPropsScheduleStore(store)
+		sched = s
+	})
+
+	waitFor(t, "the resumed poller", func() bool { _, ok := sched.PropsPollers().Get("e1"); return ok })
+	if _, ok := store.get("old"); ok {
+		t.Error("expected the finished event's schedule deleted")
+	}
+	fake.BlockUntil(5)
+
+	// The resumed poller waits for its saved next poll rather than polling at once
+	fake.Advance(5 * time.Minute)
+	if got := adapter.CallCount(testutil.MockFetchEventOdds); got != 0 {
+		t.Fatalf("expected no props poll before the saved next poll, got %d", got)
+	}
+	fake.Advance(time.Minute)
+	waitFor(t, "the resumed props poll", func() bool { return adapter.CallCount(testutil.MockFetchEventOdds) >= 1 })
+
+	waitFor(t, "the saved schedule after the poll", func() bool {
+		p, _ := store.get("e1")
+		return p.Polls == 4 && p.NextPollAt.After(start.Add(6*time.Minute))
+	})
+	if p, _ := store.get("e1"); p.RampInterval != 10*time.Minute {
+		t.Errorf("expected the 10m ramp tier saved, got %v", p.RampInterval)
+	}
+}
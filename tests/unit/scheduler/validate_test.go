@@ -0,0 +1,97 @@
+package scheduler_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scheduler"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+func TestValidateOdds_QuarantinesRejectedOdds(t *testing.T) {
+	point := 220.5
+	odds := []models.RawOdds{
+		{EventID: "e1", SportKey: "basketball_nba", MarketKey: "totals", BookKey: "fanduel", OutcomeName: "Over", Price: -110, Point: &point},
+		{EventID: "e1", SportKey: "basketball_nba", MarketKey: "totals", BookKey: "draftkings", OutcomeName: "Under", Price: -110},
+		{EventID: "e1", SportKey: "basketball_nba", MarketKey: "corners", BookKey: "fanduel", OutcomeName: "Over", Price: 120},
+	}
+	receivedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	valid, rejected := scheduler.ValidateOdds(basketball_nba.NewModule(), "theoddsapi", models.PayloadKindOdds, odds, receivedAt)
+
+	if len(valid) != 1 || valid[0].BookKey != "fanduel" || valid[0].MarketKey != "totals" {
+		t.Fatalf("expected only the fanduel total kept, got %+v", valid)
+	}
+	if len(rejected) != 2 {
+		t.Fatalf("expected 2 rejected, got %+v", rejected)
+	}
+
+	rec := rejected[0]
+	if rec.Reason != models.QuarantineSportRule || rec.Vendor != "theoddsapi" || rec.Kind != models.PayloadKindOdds ||
+		rec.EventID != "e1" || rec.BookKey != "draftkings" || !rec.ReceivedAt.Equal(receivedAt) {
+		t.Errorf("unexpected record %+v", rec)
+	}
+	if !strings.Contains(rec.Detail, "requires point") {
+		t.Errorf("expected the validation error in the detail, got %q", rec.Detail)
+	}
+	if rejected[1].MarketKey != "corners" {
+		t.Errorf("expected the unknown market rejected, got %+v", rejected[1])
+	}
+}
+
+func TestValidateOdds_AllValidReturnsInput(t *testing.T) {
+	odds := []models.RawOdds{
+		{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "fanduel", OutcomeName: "Boston Celtics", Price: 120},
+	}
+
+	valid, rejected := scheduler.ValidateOdds(basketball_nba.NewModule(), "theoddsapi", models.PayloadKindOdds, odds, time.Now())
+	if len(valid) != 1 || rejected != nil {
+		t.Errorf("expected odds unchanged and nothing rejected, got %+v and %+v", valid, rejected)
+	}
+}
+
+func TestValidateEvents_DropsOddsOfRejectedEvents(t *testing.T) {
+	now := time.Now()
+	events := []models.Event{
+		{EventID: "e1", SportKey: "basketball_nba", HomeTeam: "Los Angeles Lakers", AwayTeam: "Boston Celtics", CommenceTime: now.Add(time.Hour)},
+		{EventID: "e2", SportKey: "basketball_nba", HomeTeam: "Miami Heat", AwayTeam: "Miami Heat", CommenceTime: now.Add(time.Hour)},
+	}
+	odds := []models.RawOdds{
+		{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "fanduel", OutcomeName: "Boston Celtics", Price: 120},
+		{EventID: "e2", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "fanduel", OutcomeName: "Miami Heat", Price: 120},
+		{EventID: "e2", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "draftkings", OutcomeName: "Miami Heat", Price: 110},
+	}
+
+	kept, keptOdds, rejected := scheduler.ValidateEvents(basketball_nba.NewModule(), "theoddsapi", models.PayloadKindOdds, events, odds, now)
+
+	if len(kept) != 1 || kept[0].EventID != "e1" {
+		t.Fatalf("expected only e1 kept, got %+v", kept)
+	}
+	if len(keptOdds) != 1 || keptOdds[0].EventID != "e1" {
+		t.Fatalf("expected e2's odds dropped with it, got %+v", keptOdds)
+	}
+	if len(rejected) != 1 || rejected[0].EventID != "e2" || rejected[0].Reason != models.QuarantineSportRule {
+		t.Fatalf("expected e2 quarantined, got %+v", rejected)
+	}
+	if !strings.Contains(rejected[0].Detail, "2 odds dropped") {
+		t.Errorf("expected the dropped odds counted in the detail, got %q", rejected[0].Detail)
+	}
+}
+
+func TestParseValidationMode(t *testing.T) {
+	for value, want := range map[string]scheduler.ValidationMode{
+		"":           scheduler.ValidationQuarantine,
+		"quarantine": scheduler.ValidationQuarantine,
+		"drop":       scheduler.ValidationDrop,
+		"log":        scheduler.ValidationLog,
+	} {
+		if got, err := scheduler.ParseValidationMode(value); err != nil || got != want {
+			t.Errorf("ParseValidationMode(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	if _, err := scheduler.ParseValidationMode("strict"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
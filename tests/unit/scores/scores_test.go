@@ -0,0 +1,97 @@
+package scores_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/scores"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestUpdates_PeriodsThenGameTotal(t *testing.T) {
+	received := time.Date(2025, 1, 15, 2, 0, 0, 0, time.UTC)
+	score := models.EventScore{
+		EventID:   "e1",
+		SportKey:  "basketball_nba",
+		HomeTeam:  "Boston Celtics",
+		AwayTeam:  "Miami Heat",
+		HasScores: true,
+		HomeScore: "58",
+		AwayScore: "51",
+		Periods: []models.PeriodScore{
+			{Period: "1", HomeScore: "30", AwayScore: "24"},
+			{Period: "2", HomeScore: "28", AwayScore: "27"},
+		},
+	}
+
+	updates := scores.Updates(score, received)
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 updates, got %d", len(updates))
+	}
+	if updates[0].Period != "1" || updates[0].HomeScore != "30" || updates[1].AwayScore != "27" {
+		t.Errorf("unexpected period updates: %+v", updates[:2])
+	}
+	game := updates[2]
+	if game.Period != scores.PeriodGame || game.HomeScore != "58" || game.AwayScore != "51" {
+		t.Errorf("unexpected game total: %+v", game)
+	}
+	if game.EventID != "e1" || game.SportKey != "basketball_nba" || !game.ReceivedAt.Equal(received) {
+		t.Errorf("update missing event fields: %+v", game)
+	}
+}
+
+func TestUpdates_NoneBeforeTipoff(t *testing.T) {
+	if updates := scores.Updates(models.EventScore{EventID: "e1"}, time.Now()); len(updates) != 0 {
+		t.Errorf("expected no updates without a score, got %+v", updates)
+	}
+}
+
+// fakeScores counts vendor calls
+type fakeScores struct {
+	calls int
+	err   error
+}
+
+func (f *fakeScores) FetchScores(ctx context.Context, sport string, daysFrom int) ([]models.EventScore, error) {
+	f.calls++
+	return []models.EventScore{{EventID: "e1", SportKey: sport}}, f.err
+}
+
+func TestPoller_SharesVendorCallsWithinInterval(t *testing.T) {
+	vendor := &fakeScores{}
+	p := scores.NewPoller(nil, nil, vendor, time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		got, err := p.FetchScores(ctx, "basketball_nba", 1)
+		if err != nil || len(got) != 1 {
+			t.Fatalf("FetchScores = %v, %v", got, err)
+		}
+	}
+	if vendor.calls != 1 {
+		t.Errorf("expected 1 vendor call, got %d", vendor.calls)
+	}
+
+	// A wider window or another sport needs a fresh call
+	p.FetchScores(ctx, "basketball_nba", 3)
+	p.FetchScores(ctx, "americanfootball_nfl", 1)
+	if vendor.calls != 3 {
+		t.Errorf("expected 3 vendor calls, got %d", vendor.calls)
+	}
+}
+
+func TestPoller_DoesNotCacheErrors(t *testing.T) {
+	vendor := &fakeScores{err: errors.New("boom")}
+	p := scores.NewPoller(nil, nil, vendor, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.FetchScores(context.Background(), "basketball_nba", 1); err == nil {
+			t.Fatal("expected the vendor error")
+		}
+	}
+	if vendor.calls != 2 {
+		t.Errorf("expected a retry after an error, got %d calls", vendor.calls)
+	}
+}
@@ -0,0 +1,297 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/secrets"
+)
+
+func TestVaultKVv2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/data/mercury/prod" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.Header.Get("X-Vault-Token"); got != "s.token" {
+			t.Errorf("token = %q", got)
+		}
+		if got := r.Header.Get("X-Vault-Namespace"); got != "fortuna" {
+			t.Errorf("namespace = %q", got)
+		}
+		w.Write([]byte(`{"data":{"data":{"ODDS_API_KEY":"abc","REDIS_DB":3},"metadata":{"version":4}}}`))
+	}))
+	defer server.Close()
+
+	provider, err := secrets.NewVaultProvider(secrets.VaultConfig{
+		Addr:      server.URL,
+		Token:     "s.token",
+		Namespace: "fortuna",
+		Mount:     "kv",
+		Path:      "/mercury/prod/",
+	})
+	if err != nil {
+		t.Fatalf("NewVaultProvider: %v", err)
+	}
+
+	values, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if values["ODDS_API_KEY"] != "abc" || values["REDIS_DB"] != "3" {
+		t.Errorf("values = %v", values)
+	}
+}
+
+func TestVaultKVv1TokenFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("s.from-file\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/mercury" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.Header.Get("X-Vault-Token"); got != "s.from-file" {
+			t.Errorf("token = %q", got)
+		}
+		w.Write([]byte(`{"data":{"ALEXANDRIA_PASSWORD":"pw"}}`))
+	}))
+	defer server.Close()
+
+	provider, err := secrets.NewVaultProvider(secrets.VaultConfig{
+		Addr:      server.URL,
+		TokenFile: tokenFile,
+		Path:      "mercury",
+		KVVersion: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewVaultProvider: %v", err)
+	}
+
+	values, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if values["ALEXANDRIA_PASSWORD"] != "pw" {
+		t.Errorf("values = %v", values)
+	}
+}
+
+func TestVaultError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":["permission denied"]}`))
+	}))
+	defer server.Close()
+
+	provider, err := secrets.NewVaultProvider(secrets.VaultConfig{Addr: server.URL, Token: "t", Path: "mercury"})
+	if err != nil {
+		t.Fatalf("NewVaultProvider: %v", err)
+	}
+
+	_, err = provider.Fetch(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("err = %v, want permission denied", err)
+	}
+}
+
+func TestVaultConfigValidation(t *testing.T) {
+	if _, err := secrets.NewVaultProvider(secrets.VaultConfig{Addr: "http://vault", Path: "mercury"}); err == nil {
+		t.Error("expected an error without a token")
+	}
+	if _, err := secrets.NewVaultProvider(secrets.VaultConfig{Addr: "http://vault", Token: "t", Path: "p", KVVersion: 3}); err == nil {
+		t.Error("expected an error for KV version 3")
+	}
+}
+
+func TestAWSGetSecretValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("X-Amz-Target"); got != "secretsmanager.GetSecretValue" {
+			t.Errorf("target = %q", got)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/x-amz-json-1.1" {
+			t.Errorf("content type = %q", got)
+		}
+		if got := r.Header.Get("X-Amz-Security-Token"); got != "session" {
+			t.Errorf("session token = %q", got)
+		}
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") ||
+			!strings.Contains(auth, "/us-east-1/secretsmanager/aws4_request") ||
+			!strings.Contains(auth, "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token;x-amz-target") ||
+			!strings.Contains(auth, "Signature=") {
+			t.Errorf("authorization = %s", auth)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var request map[string]string
+		json.Unmarshal(body, &request)
+		if request["SecretId"] != "mercury/prod" || request["VersionStage"] != "AWSPREVIOUS" {
+			t.Errorf("request = %v", request)
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{
+			"Name":         "mercury/prod",
+			"SecretString": `{"ODDS_API_KEY":"xyz","REDIS_PASSWORD":"r"}`,
+		})
+	}))
+	defer server.Close()
+
+	provider, err := secrets.NewAWSProvider(secrets.AWSConfig{
+		Region:          "us-east-1",
+		SecretID:        "mercury/prod",
+		VersionStage:    "AWSPREVIOUS",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		SessionToken:    "session",
+		Endpoint:        server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewAWSProvider: %v", err)
+	}
+
+	values, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if values["ODDS_API_KEY"] != "xyz" || values["REDIS_PASSWORD"] != "r" {
+		t.Errorf("values = %v", values)
+	}
+}
+
+func TestAWSError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+	}))
+	defer server.Close()
+
+	provider, err := secrets.NewAWSProvider(secrets.AWSConfig{
+		Region: "us-east-1", SecretID: "missing", AccessKeyID: "a", SecretAccessKey: "b", Endpoint: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewAWSProvider: %v", err)
+	}
+
+	_, err = provider.Fetch(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException") {
+		t.Errorf("err = %v, want ResourceNotFoundException", err)
+	}
+}
+
+// fakeProvider returns the values it holds
+type fakeProvider struct {
+	mu     sync.Mutex
+	values map[string]string
+	err    error
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	values := make(map[string]string, len(f.values))
+	for k, v := range f.values {
+		values[k] = v
+	}
+	return values, nil
+}
+
+func (f *fakeProvider) set(name, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[name] = value
+}
+
+func TestManagerLoadAndExport(t *testing.T) {
+	provider := &fakeProvider{values: map[string]string{"MERCURY_TEST_SECRET": "one", "ODDS_API_KEY": "key"}}
+	manager := secrets.NewManager(provider, 0)
+
+	if err := manager.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	t.Setenv("MERCURY_TEST_SECRET", "env")
+	t.Setenv("ODDS_API_KEY", "")
+	if err := manager.Export(); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if got := os.Getenv("MERCURY_TEST_SECRET"); got != "one" {
+		t.Errorf("MERCURY_TEST_SECRET = %q, want secret value", got)
+	}
+	if names := manager.Names(); len(names) != 2 || names[0] != "MERCURY_TEST_SECRET" || names[1] != "ODDS_API_KEY" {
+		t.Errorf("names = %v", names)
+	}
+}
+
+func TestManagerLoadEmpty(t *testing.T) {
+	manager := secrets.NewManager(&fakeProvider{values: map[string]string{}}, 0)
+	if err := manager.Load(context.Background()); err == nil {
+		t.Error("expected an error for an empty secret")
+	}
+}
+
+func TestManagerRefreshNotifiesRotations(t *testing.T) {
+	provider := &fakeProvider{values: map[string]string{"ODDS_API_KEY": "old", "ALEXANDRIA_PASSWORD": "pw1"}}
+	manager := secrets.NewManager(provider, 0)
+	if err := manager.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	var rotated []string
+	manager.OnRotate("ODDS_API_KEY", func(value string) { rotated = append(rotated, value) })
+	password := manager.Getter("ALEXANDRIA_PASSWORD")
+
+	// Unchanged values do not notify
+	if err := manager.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if len(rotated) != 0 {
+		t.Errorf("rotated = %v, want none", rotated)
+	}
+
+	provider.set("ODDS_API_KEY", "new")
+	provider.set("ALEXANDRIA_PASSWORD", "pw2")
+	if err := manager.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if len(rotated) != 1 || rotated[0] != "new" {
+		t.Errorf("rotated = %v, want [new]", rotated)
+	}
+	if got := password(); got != "pw2" {
+		t.Errorf("password = %q, want pw2", got)
+	}
+}
+
+func TestManagerRefreshErrorKeepsValues(t *testing.T) {
+	provider := &fakeProvider{values: map[string]string{"ODDS_API_KEY": "key"}}
+	manager := secrets.NewManager(provider, 0)
+	if err := manager.Load(context.Background()); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	provider.err = errors.New("unavailable")
+	if err := manager.Refresh(context.Background()); err == nil {
+		t.Error("expected the fetch error")
+	}
+	if value, ok := manager.Get("ODDS_API_KEY"); !ok || value != "key" {
+		t.Errorf("ODDS_API_KEY = %q, %v; want the last value", value, ok)
+	}
+}
@@ -0,0 +1,180 @@
+package shadow_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/shadow"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func odd(eventID, market, book, outcome string, price int) models.RawOdds {
+	return models.RawOdds{EventID: eventID, MarketKey: market, BookKey: book, OutcomeName: outcome, Price: price}
+}
+
+func spread(eventID, book, outcome string, point float64, price int) models.RawOdds {
+	o := odd(eventID, "spreads", book, outcome, price)
+	o.Point = &point
+	return o
+}
+
+func TestCompare_MatchesEventsByIDOrTeams(t *testing.T) {
+	tip := time.Date(2025, 1, 15, 0, 30, 0, 0, time.UTC)
+	primary := &models.FetchResult{
+		Events: []models.Event{
+			{EventID: "a1", HomeTeam: "Boston Celtics", AwayTeam: "Miami Heat", CommenceTime: tip},
+			{EventID: "a2", HomeTeam: "Denver Nuggets", AwayTeam: "Utah Jazz", CommenceTime: tip.Add(2 * time.Hour)},
+			{EventID: "a3", HomeTeam: "Chicago Bulls", AwayTeam: "Orlando Magic", CommenceTime: tip},
+		},
+		Odds: []models.RawOdds{
+			odd("a1", "h2h", "fanduel", "Boston Celtics", -150),
+			odd("a1", "h2h", "fanduel", "Miami Heat", 130),
+			spread("a2", "draftkings", "Denver Nuggets", -7.5, -110),
+			odd("a3", "h2h", "pinnacle", "Chicago Bulls", 105),
+		},
+	}
+	shadowResult := &models.FetchResult{
+		Events: []models.Event{
+			{EventID: "a1", HomeTeam: "Boston Celtics", AwayTeam: "Miami Heat", CommenceTime: tip},
+			// Different vendor ID, start time a few minutes off
+			{EventID: "x2", HomeTeam: "Denver Nuggets", AwayTeam: "Utah Jazz", CommenceTime: tip.Add(2*time.Hour + 5*time.Minute)},
+			// Same teams a day later: a different game
+			{EventID: "x3", HomeTeam: "Chicago Bulls", AwayTeam: "Orlando Magic", CommenceTime: tip.Add(24 * time.Hour)},
+		},
+		Odds: []models.RawOdds{
+			odd("a1", "h2h", "fanduel", "Boston Celtics", -150),
+			odd("a1", "h2h", "fanduel", "Miami Heat", 150), // 43.5% vs 40%
+			spread("x2", "draftkings", "Denver Nuggets", -7.5, -110),
+			spread("x2", "draftkings", "Utah Jazz", 7.5, -110), // Primary lacks it
+			odd("x3", "h2h", "pinnacle", "Chicago Bulls", 105),
+			odd("a1", "h2h", "betmgm", "Boston Celtics", -155),
+		},
+	}
+
+	c := shadow.Compare(primary, shadowResult, shadow.DefaultTolerance)
+
+	if c.PrimaryEvents != 3 || c.ShadowEvents != 3 || c.MatchedEvents != 2 {
+		t.Errorf("events = %d/%d matched %d, want 3/3 matched 2", c.PrimaryEvents, c.ShadowEvents, c.MatchedEvents)
+	}
+	if c.PrimaryOdds != 4 || c.ShadowOdds != 6 || c.MatchedOdds != 3 {
+		t.Errorf("odds = %d/%d matched %d, want 4/6 matched 3", c.PrimaryOdds, c.ShadowOdds, c.MatchedOdds)
+	}
+	if c.PriceDiffs != 1 {
+		t.Errorf("expected 1 price diff, got %d", c.PriceDiffs)
+	}
+	if c.MaxProbDiff < 0.03 || c.MaxProbDiff > 0.04 {
+		t.Errorf("expected a ~0.035 max gap, got %.4f", c.MaxProbDiff)
+	}
+	if c.Coverage() != 0.75 {
+		t.Errorf("Coverage = %v, want 0.75", c.Coverage())
+	}
+	if len(c.MissingBooks) != 0 {
+		t.Errorf("expected no missing books, got %v", c.MissingBooks)
+	}
+	if !reflect.DeepEqual(c.ExtraBooks, []string{"betmgm"}) {
+		t.Errorf("ExtraBooks = %v, want [betmgm]", c.ExtraBooks)
+	}
+}
+
+func TestCompare_PointsMustMatch(t *testing.T) {
+	primary := &models.FetchResult{
+		Events: []models.Event{{EventID: "e1"}},
+		Odds:   []models.RawOdds{spread("e1", "fanduel", "Boston Celtics", -5.5, -110)},
+	}
+	shadowResult := &models.FetchResult{
+		Events: []models.Event{{EventID: "e1"}},
+		Odds:   []models.RawOdds{spread("e1", "fanduel", "Boston Celtics", -6, -110)},
+	}
+
+	c := shadow.Compare(primary, shadowResult, shadow.DefaultTolerance)
+	if c.MatchedOdds != 0 {
+		t.Errorf("expected different points not to match, got %d matched", c.MatchedOdds)
+	}
+	if c.Coverage() != 0 {
+		t.Errorf("Coverage = %v, want 0", c.Coverage())
+	}
+}
+
+// fakeAdapter serves a fixed result and records the requests it got
+type fakeAdapter struct {
+	mu     sync.Mutex
+	result *models.FetchResult
+	err    error
+	odds   []models.FetchOddsOptions
+	events []models.FetchEventOddsOptions
+}
+
+func (f *fakeAdapter) FetchOdds(ctx context.Context, opts *models.FetchOddsOptions) (*models.FetchResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.odds = append(f.odds, *opts)
+	return f.result, f.err
+}
+
+func (f *fakeAdapter) FetchEventOdds(ctx context.Context, opts *models.FetchEventOddsOptions) (*models.FetchResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, *opts)
+	return f.result, f.err
+}
+
+func (f *fakeAdapter) FetchEvents(ctx context.Context, opts *models.FetchEventsOptions) ([]models.Event, error) {
+	return nil, nil
+}
+
+func (f *fakeAdapter) SupportsMarket(market string) bool { return true }
+
+func (f *fakeAdapter) GetRateLimits() *models.RateLimits { return &models.RateLimits{} }
+
+func waitCompared(t *testing.T, c *shadow.Comparator, want int64) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if compared, _ := c.Stats(); compared >= want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	compared, _ := c.Stats()
+	t.Fatalf("expected %d comparisons, got %d", want, compared)
+}
+
+func TestComparator_RepeatsFetchesAgainstShadow(t *testing.T) {
+	adapter := &fakeAdapter{result: &models.FetchResult{}}
+	c := shadow.NewComparator(adapter, "candidate", nil, nil)
+	c.Start(context.Background())
+	defer c.Stop()
+
+	opts := &models.FetchOddsOptions{Sport: "basketball_nba", Markets: []string{"h2h"}, EventIDs: []string{"e1"}}
+	c.CompareOdds(opts, &models.FetchResult{}, 40*time.Millisecond)
+	opts.Markets[0] = "spreads" // The caller's options may be reused
+	opts.Sport = "changed"
+	c.CompareEventOdds(&models.FetchEventOddsOptions{Sport: "basketball_nba", EventID: "e1"}, nil, time.Millisecond)
+	waitCompared(t, c, 2)
+
+	adapter.mu.Lock()
+	defer adapter.mu.Unlock()
+	if len(adapter.odds) != 1 || adapter.odds[0].Sport != "basketball_nba" || adapter.odds[0].Markets[0] != "h2h" || adapter.odds[0].EventIDs[0] != "e1" {
+		t.Errorf("unexpected featured requests: %+v", adapter.odds)
+	}
+	if len(adapter.events) != 1 || adapter.events[0].EventID != "e1" {
+		t.Errorf("unexpected props requests: %+v", adapter.events)
+	}
+}
+
+func TestComparator_DropsWhenQueueIsFull(t *testing.T) {
+	adapter := &fakeAdapter{err: errors.New("boom")}
+	c := shadow.NewComparator(adapter, "candidate", nil, nil)
+
+	// Not started: nothing drains the queue
+	for i := 0; i < 100; i++ {
+		c.CompareOdds(&models.FetchOddsOptions{Sport: "basketball_nba"}, nil, 0)
+	}
+	if _, dropped := c.Stats(); dropped == 0 {
+		t.Error("expected comparisons to be dropped once the queue is full")
+	}
+}
@@ -0,0 +1,98 @@
+package slo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/slo"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func stages(deltaMs, writeMs, cacheMs int) map[string]time.Duration {
+	return map[string]time.Duration{
+		"fetch": 400 * time.Millisecond, // Never counted against the SLO
+		"delta": time.Duration(deltaMs) * time.Millisecond,
+		"write": time.Duration(writeMs) * time.Millisecond,
+		"cache": time.Duration(cacheMs) * time.Millisecond,
+	}
+}
+
+func TestObserve_AttributesViolationToSlowestStage(t *testing.T) {
+	tracker := slo.NewTracker(nil, slo.Config{Target: 30 * time.Millisecond})
+
+	if v := tracker.Observe("basketball_nba", models.PayloadKindOdds, stages(1, 20, 2), 12); v != nil {
+		t.Fatalf("23ms poll should meet a 30ms SLO, got %+v", v)
+	}
+
+	v := tracker.Observe("basketball_nba", models.PayloadKindOdds, stages(2, 40, 3), 12)
+	if v == nil {
+		t.Fatal("expected a violation for a 45ms poll")
+	}
+	if v.Latency != 45*time.Millisecond || v.Stage != "write" || v.Deltas != 12 {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+	if _, ok := v.Stages["fetch"]; ok {
+		t.Error("fetch should not be part of the violation's stages")
+	}
+}
+
+func TestObserve_IgnoresFetchOnlyPolls(t *testing.T) {
+	tracker := slo.NewTracker(nil, slo.Config{})
+
+	fetchOnly := map[string]time.Duration{"fetch": time.Second}
+	if v := tracker.Observe("basketball_nba", models.PayloadKindOdds, fetchOnly, 0); v != nil {
+		t.Errorf("fetch-only poll should not be measured, got %+v", v)
+	}
+	if stats := tracker.Stats("basketball_nba"); stats.Samples != 0 {
+		t.Errorf("expected no samples, got %d", stats.Samples)
+	}
+}
+
+func TestStats_RollingWindow(t *testing.T) {
+	tracker := slo.NewTracker(nil, slo.Config{Target: 30 * time.Millisecond, Window: 10})
+
+	// 20 slow polls then 10 fast ones: the window only remembers the fast ones
+	for i := 0; i < 20; i++ {
+		tracker.Observe("basketball_nba", models.PayloadKindOdds, stages(1, 50, 1), 1)
+	}
+	for i := 1; i <= 10; i++ {
+		tracker.Observe("basketball_nba", models.PayloadKindOdds, stages(1, i, 1), 1)
+	}
+
+	stats := tracker.Stats("basketball_nba")
+	if stats.Samples != 10 || stats.Violations != 0 {
+		t.Fatalf("expected 10 samples and no violations, got %+v", stats)
+	}
+	if stats.P50 != 7*time.Millisecond || stats.P99 != 12*time.Millisecond {
+		t.Errorf("unexpected percentiles p50=%v p99=%v", stats.P50, stats.P99)
+	}
+	if stats.Stage != "write" || stats.StageP95 != 10*time.Millisecond {
+		t.Errorf("expected write to be the slowest stage at 10ms, got %s %v", stats.Stage, stats.StageP95)
+	}
+	if stats.Target != 30*time.Millisecond {
+		t.Errorf("expected the target in stats, got %v", stats.Target)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	tests := []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0.5, 5},
+		{0.95, 10},
+		{0.99, 10},
+		{0.01, 1},
+	}
+	for _, tt := range tests {
+		if got := slo.Percentile(sorted, tt.q); got != tt.want {
+			t.Errorf("Percentile(%v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+
+	if got := slo.Percentile(nil, 0.5); got != 0 {
+		t.Errorf("expected 0 for no samples, got %v", got)
+	}
+}
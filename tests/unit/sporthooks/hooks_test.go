@@ -0,0 +1,90 @@
+package sporthooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/sporthooks"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+// hookedNBA is the NBA module with every lifecycle hook recorded
+type hookedNBA struct {
+	*basketball_nba.Module
+	discovered []string
+	live       []string
+	odds       int
+	fail       error
+	panic      bool
+}
+
+func (m *hookedNBA) OnEventDiscovered(ctx context.Context, event models.Event) error {
+	m.discovered = append(m.discovered, event.EventID)
+	return m.fail
+}
+
+func (m *hookedNBA) OnEventLive(ctx context.Context, event models.Event) error {
+	if m.panic {
+		panic("boom")
+	}
+	m.live = append(m.live, event.EventID)
+	return nil
+}
+
+func (m *hookedNBA) OnOddsAccepted(ctx context.Context, odds []models.RawOdds) error {
+	m.odds += len(odds)
+	return nil
+}
+
+func TestDiscovery_CallsOncePerEvent(t *testing.T) {
+	sport := &hookedNBA{Module: basketball_nba.NewModule(), fail: errors.New("ignored")}
+	discovery := sporthooks.NewDiscovery()
+	now := time.Now()
+
+	events := []models.Event{
+		{EventID: "e1", CommenceTime: now.Add(time.Hour)},
+		{EventID: "e2", CommenceTime: now.Add(-time.Hour)},       // In play
+		{EventID: "old", CommenceTime: now.Add(-48 * time.Hour)}, // Finished (e.g. replayed)
+	}
+	discovery.Observe(context.Background(), sport, events)
+	discovery.Observe(context.Background(), sport, append(events, models.Event{EventID: "e3", CommenceTime: now.Add(2 * time.Hour)}))
+
+	want := []string{"e1", "e2", "e3"}
+	if len(sport.discovered) != len(want) {
+		t.Fatalf("discovered %v, want %v", sport.discovered, want)
+	}
+	for i := range want {
+		if sport.discovered[i] != want[i] {
+			t.Errorf("discovered %v, want %v", sport.discovered, want)
+		}
+	}
+}
+
+func TestHooks_CallImplementedHooks(t *testing.T) {
+	sport := &hookedNBA{Module: basketball_nba.NewModule()}
+	ctx := context.Background()
+
+	sporthooks.EventLive(ctx, sport, models.Event{EventID: "e1"})
+	sporthooks.EventCompleted(ctx, sport, models.Event{EventID: "e1"}) // Not implemented: no-op
+	sporthooks.OddsAccepted(ctx, sport, make([]models.RawOdds, 3))
+	sporthooks.OddsAccepted(ctx, sport, nil)
+
+	if len(sport.live) != 1 || sport.odds != 3 {
+		t.Errorf("expected one live event and 3 odds, got %v and %d", sport.live, sport.odds)
+	}
+
+	// Modules without hooks are skipped
+	plain := basketball_nba.NewModule()
+	sporthooks.EventLive(ctx, plain, models.Event{EventID: "e1"})
+	sporthooks.NewDiscovery().Observe(ctx, plain, []models.Event{{EventID: "e1", CommenceTime: time.Now()}})
+}
+
+func TestHooks_RecoverPanics(t *testing.T) {
+	sport := &hookedNBA{Module: basketball_nba.NewModule(), panic: true}
+
+	// Must not propagate to the status updater
+	sporthooks.EventLive(context.Background(), sport, models.Event{EventID: "e1"})
+}
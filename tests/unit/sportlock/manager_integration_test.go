@@ -0,0 +1,64 @@
+// +build integration
+
+package sportlock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/sportlock"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestManager_ShardsAndHandsOff(t *testing.T) {
+	// Requires Redis running
+	redisClient := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	defer redisClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	sports := []string{"basketball_nba", "americanfootball_nfl", "baseball_mlb", "icehockey_nhl"}
+	keys := []string{"mercury:sportlock:instances"}
+	for _, sportKey := range sports {
+		keys = append(keys, sportlock.LockKey(sportKey))
+	}
+	redisClient.Del(ctx, keys...)
+
+	first := sportlock.NewManager(redisClient, "replica-a", sports)
+	first.SetTTL(3 * time.Second)
+	first.Start(ctx)
+	if len(first.Owned()) != len(sports) {
+		t.Fatalf("a lone instance should poll every sport, got %v", first.Owned())
+	}
+
+	second := sportlock.NewManager(redisClient, "replica-b", sports)
+	second.SetTTL(3 * time.Second)
+	second.Start(ctx)
+
+	// Within a couple of heartbeats the first sheds half and the second claims it
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && len(second.Owned()) < 2 {
+		time.Sleep(200 * time.Millisecond)
+	}
+	if len(first.Owned()) != 2 || len(second.Owned()) != 2 {
+		t.Fatalf("expected a 2/2 split, got %v and %v", first.Owned(), second.Owned())
+	}
+	for _, sportKey := range sports {
+		if first.Owns(sportKey) == second.Owns(sportKey) {
+			t.Errorf("%s must be owned by exactly one instance", sportKey)
+		}
+	}
+
+	// A stopped instance releases its sports to the survivor
+	first.Stop()
+	deadline = time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && len(second.Owned()) < len(sports) {
+		time.Sleep(200 * time.Millisecond)
+	}
+	if len(second.Owned()) != len(sports) {
+		t.Errorf("survivor should take every sport, got %v", second.Owned())
+	}
+	second.Stop()
+}
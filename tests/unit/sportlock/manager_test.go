@@ -0,0 +1,24 @@
+package sportlock_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/sportlock"
+)
+
+func TestFairShare(t *testing.T) {
+	cases := []struct {
+		sports, instances, want int
+	}{
+		{5, 1, 5},
+		{5, 2, 3},
+		{6, 3, 2},
+		{1, 4, 1},
+		{3, 0, 3}, // no heartbeat recorded yet counts as one instance
+	}
+	for _, tc := range cases {
+		if got := sportlock.FairShare(tc.sports, tc.instances); got != tc.want {
+			t.Errorf("FairShare(%d, %d) = %d, want %d", tc.sports, tc.instances, got, tc.want)
+		}
+	}
+}
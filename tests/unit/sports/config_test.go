@@ -31,6 +31,25 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestDefaultConfig_BookmakersFitOneBillingGroup(t *testing.T) {
+	config := basketball_nba.DefaultConfig()
+
+	if len(config.Bookmakers) == 0 || len(config.Bookmakers) > 10 {
+		t.Errorf("expected 1-10 bookmakers (one region's cost), got %d", len(config.Bookmakers))
+	}
+
+	seen := make(map[string]bool)
+	for _, book := range config.Bookmakers {
+		if seen[book] {
+			t.Errorf("duplicate bookmaker %s", book)
+		}
+		seen[book] = true
+	}
+	if !seen["pinnacle"] {
+		t.Error("expected pinnacle (edge reference) to be requested")
+	}
+}
+
 func TestGetFeaturedInterval_PreMatch(t *testing.T) {
 	config := basketball_nba.DefaultConfig()
 
@@ -170,3 +189,24 @@ func BenchmarkGetPropsInterval(b *testing.B) {
 	}
 }
 
+
+func TestTypicalGameDuration(t *testing.T) {
+	module := basketball_nba.NewModule()
+
+	if module.GetTypicalGameDuration() != 3*time.Hour {
+		t.Errorf("expected 3h game duration, got %v", module.GetTypicalGameDuration())
+	}
+}
+
+func TestFuturesConfig(t *testing.T) {
+	module := basketball_nba.NewModule()
+
+	keys := module.GetFuturesKeys()
+	if len(keys) != 1 || keys[0] != "basketball_nba_championship_winner" {
+		t.Errorf("expected championship winner futures key, got %v", keys)
+	}
+
+	if module.GetFuturesPollInterval() != 6*time.Hour {
+		t.Errorf("expected 6h futures interval, got %v", module.GetFuturesPollInterval())
+	}
+}
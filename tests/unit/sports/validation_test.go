@@ -0,0 +1,72 @@
+package sports_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+func TestValidateOdds_GameProps(t *testing.T) {
+	module := basketball_nba.NewModule()
+	point := 112.5
+
+	tests := []struct {
+		name    string
+		odds    models.RawOdds
+		wantErr bool
+	}{
+		{
+			name: "team total with point and team",
+			odds: models.RawOdds{SportKey: "basketball_nba", MarketKey: "team_totals", OutcomeName: "Over", Description: "Boston Celtics", Price: -110, Point: &point},
+		},
+		{
+			name:    "team total missing point",
+			odds:    models.RawOdds{SportKey: "basketball_nba", MarketKey: "team_totals", OutcomeName: "Over", Description: "Boston Celtics", Price: -110},
+			wantErr: true,
+		},
+		{
+			name:    "team total missing team",
+			odds:    models.RawOdds{SportKey: "basketball_nba", MarketKey: "team_totals", OutcomeName: "Over", Price: -110, Point: &point},
+			wantErr: true,
+		},
+		{
+			name:    "first half spread missing point",
+			odds:    models.RawOdds{SportKey: "basketball_nba", MarketKey: "spreads_h1", OutcomeName: "Boston Celtics", Price: -110},
+			wantErr: true,
+		},
+		{
+			name: "first quarter moneyline",
+			odds: models.RawOdds{SportKey: "basketball_nba", MarketKey: "h2h_q1", OutcomeName: "Boston Celtics", Price: 120},
+		},
+		{
+			name:    "unknown market",
+			odds:    models.RawOdds{SportKey: "basketball_nba", MarketKey: "corners", OutcomeName: "Over", Price: 120},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := module.ValidateOdds(tt.odds)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOdds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetPropsMarkets_IncludesGameProps(t *testing.T) {
+	markets := basketball_nba.NewModule().GetPropsMarkets()
+
+	found := make(map[string]bool)
+	for _, m := range markets {
+		found[m] = true
+	}
+
+	for _, want := range []string{"player_points", "team_totals", "alternate_spreads", "h2h_q1"} {
+		if !found[want] {
+			t.Errorf("expected props markets to include %s", want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package stalebook_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/stalebook"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+var now = time.Date(2025, 1, 15, 23, 0, 0, 0, time.UTC)
+
+var config = stalebook.Config{
+	SilentAfter:  20 * time.Minute,
+	TipoffWindow: 2 * time.Hour,
+	Interval:     time.Minute,
+}
+
+func batch(at time.Time, commence time.Time, books ...string) bus.DeltaBatchCommitted {
+	msg := bus.DeltaBatchCommitted{
+		Events:      []models.Event{{EventID: "e1", SportKey: "basketball_nba", CommenceTime: commence}},
+		CommittedAt: at,
+	}
+	for _, book := range books {
+		msg.Odds = append(msg.Odds, models.RawOdds{EventID: "e1", SportKey: "basketball_nba", BookKey: book})
+	}
+	return msg
+}
+
+func TestConfigStale(t *testing.T) {
+	tipoff := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		config    stalebook.Config
+		lastDelta time.Time
+		tipoff    time.Time
+		want      bool
+	}{
+		{"silent near tipoff", config, now.Add(-30 * time.Minute), tipoff, true},
+		{"recent delta", config, now.Add(-5 * time.Minute), tipoff, false},
+		{"tipoff far away", config, now.Add(-30 * time.Minute), now.Add(5 * time.Hour), false},
+		{"no upcoming event", config, now.Add(-30 * time.Minute), time.Time{}, false},
+		{"never seen", config, time.Time{}, tipoff, false},
+		{"disabled", stalebook.Config{TipoffWindow: 2 * time.Hour}, now.Add(-30 * time.Minute), tipoff, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.Stale(tt.lastDelta, tt.tipoff, now); got != tt.want {
+				t.Errorf("Stale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck_FlagsSilentBooksAndRevivesOnDelta(t *testing.T) {
+	ctx := context.Background()
+	tracker := stalebook.NewTracker(config)
+
+	var changes [][]string
+	tracker.OnChange(func(ctx context.Context, sportKey string, books []string) {
+		changes = append(changes, books)
+	})
+
+	tracker.HandleDeltaBatchCommitted(ctx, batch(now.Add(-30*time.Minute), now.Add(time.Hour), "fanduel", "draftkings"))
+	tracker.HandleDeltaBatchCommitted(ctx, batch(now.Add(-2*time.Minute), now.Add(time.Hour), "draftkings"))
+
+	tracker.Check(ctx, now)
+	if !tracker.IsStale("basketball_nba", "fanduel") || tracker.IsStale("basketball_nba", "draftkings") {
+		t.Fatal("expected only fanduel to be stale")
+	}
+	if !reflect.DeepEqual(changes, [][]string{{"fanduel"}}) {
+		t.Fatalf("expected one change for fanduel, got %v", changes)
+	}
+
+	// No transition, no notification
+	tracker.Check(ctx, now.Add(time.Minute))
+	if len(changes) != 1 {
+		t.Fatalf("expected no new change, got %v", changes)
+	}
+
+	tracker.HandleDeltaBatchCommitted(ctx, batch(now.Add(2*time.Minute), now.Add(time.Hour), "fanduel"))
+	if tracker.IsStale("basketball_nba", "fanduel") {
+		t.Error("expected a delta to make fanduel fresh again")
+	}
+	if len(changes) != 2 || !reflect.DeepEqual(changes[1], []string{"fanduel"}) {
+		t.Errorf("expected fanduel's recovery to be announced, got %v", changes)
+	}
+}
+
+func TestCheck_IgnoresSilenceAwayFromTipoff(t *testing.T) {
+	ctx := context.Background()
+	tracker := stalebook.NewTracker(config)
+
+	tracker.HandleDeltaBatchCommitted(ctx, batch(now.Add(-3*time.Hour), now.Add(6*time.Hour), "fanduel"))
+	tracker.Check(ctx, now)
+	if tracker.IsStale("basketball_nba", "fanduel") {
+		t.Error("expected no staleness with tipoff 6h away")
+	}
+}
+
+func TestNextTipoff_ForgetsFinishedEvents(t *testing.T) {
+	ctx := context.Background()
+	tracker := stalebook.NewTracker(config)
+
+	tracker.HandleEventDiscovered(ctx, bus.EventDiscovered{Events: []models.Event{
+		{EventID: "e1", SportKey: "basketball_nba", CommenceTime: now.Add(30 * time.Minute)},
+		{EventID: "e2", SportKey: "basketball_nba", CommenceTime: now.Add(90 * time.Minute)},
+	}})
+	if got := tracker.NextTipoff("basketball_nba", now); !got.Equal(now.Add(30 * time.Minute)) {
+		t.Fatalf("expected e1's tipoff, got %v", got)
+	}
+
+	tracker.HandleEventStatusChanged(ctx, bus.EventStatusChanged{EventID: "e1", SportKey: "basketball_nba", NewStatus: models.EventStatusCancelled})
+	if got := tracker.NextTipoff("basketball_nba", now); !got.Equal(now.Add(90 * time.Minute)) {
+		t.Errorf("expected e2's tipoff after e1 was cancelled, got %v", got)
+	}
+}
@@ -0,0 +1,111 @@
+package steam_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/registry"
+	"github.com/XavierBriggs/Mercury/internal/steam"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/XavierBriggs/Mercury/sports/basketball_nba"
+)
+
+func quote(book string, price int, at time.Time) models.RawOdds {
+	return models.RawOdds{
+		EventID:     "e1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "h2h",
+		BookKey:     book,
+		OutcomeName: "Lakers",
+		Price:       price,
+		ReceivedAt:  at,
+	}
+}
+
+func newDetector(t *testing.T) *steam.Detector {
+	t.Helper()
+	sportRegistry := registry.NewSportRegistry()
+	if err := sportRegistry.Register(basketball_nba.NewModule()); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	return steam.NewDetector(nil, sportRegistry)
+}
+
+func TestDetector_FlagsCorrelatedMoves(t *testing.T) {
+	d := newDetector(t)
+	start := time.Now()
+
+	// Baseline quotes
+	d.Observe([]models.RawOdds{
+		quote("fanduel", 120, start),
+		quote("draftkings", 120, start),
+		quote("betmgm", 120, start),
+	})
+
+	// Two books shorten: not yet steam (NBA needs 3)
+	if signals := d.Observe([]models.RawOdds{
+		quote("fanduel", 105, start.Add(10*time.Second)),
+		quote("draftkings", 100, start.Add(20*time.Second)),
+	}); len(signals) != 0 {
+		t.Fatalf("expected no steam with 2 books, got %+v", signals)
+	}
+
+	signals := d.Observe([]models.RawOdds{quote("betmgm", -105, start.Add(30*time.Second))})
+	if len(signals) != 1 {
+		t.Fatalf("expected steam, got %d signals", len(signals))
+	}
+	if signals[0].Direction != "toward" || len(signals[0].Books) != 3 {
+		t.Errorf("unexpected signal: %+v", signals[0])
+	}
+	for _, move := range signals[0].Moves {
+		if move.BookKey == "betmgm" && move.PriceDeltaCents != -25 {
+			t.Errorf("expected +120 -> -105 to be -25 cents, got %d", move.PriceDeltaCents)
+		}
+	}
+
+	// Same direction again inside the window: not re-signalled
+	if signals := d.Observe([]models.RawOdds{quote("fanduel", -110, start.Add(40*time.Second))}); len(signals) != 0 {
+		t.Errorf("expected no duplicate signal, got %+v", signals)
+	}
+}
+
+func TestDetector_IgnoresMovesOutsideWindow(t *testing.T) {
+	d := newDetector(t)
+	start := time.Now()
+
+	d.Observe([]models.RawOdds{
+		quote("fanduel", 120, start),
+		quote("draftkings", 120, start),
+		quote("betmgm", 120, start),
+	})
+	d.Observe([]models.RawOdds{quote("fanduel", 105, start.Add(time.Second))})
+	d.Observe([]models.RawOdds{quote("draftkings", 105, start.Add(2*time.Second))})
+
+	// NBA window is 90s; the first two moves have aged out
+	if signals := d.Observe([]models.RawOdds{quote("betmgm", 105, start.Add(5*time.Minute))}); len(signals) != 0 {
+		t.Errorf("expected no steam across the window, got %+v", signals)
+	}
+}
+
+func TestDetector_SpreadPointMoves(t *testing.T) {
+	d := newDetector(t)
+	start := time.Now()
+
+	spread := func(book string, point float64, at time.Time) models.RawOdds {
+		odd := quote(book, -110, at)
+		odd.MarketKey = "spreads"
+		odd.Point = &point
+		return odd
+	}
+
+	d.Observe([]models.RawOdds{spread("fanduel", -3.5, start), spread("draftkings", -3.5, start), spread("betmgm", -3.5, start)})
+	signals := d.Observe([]models.RawOdds{
+		spread("fanduel", -4.5, start.Add(5*time.Second)),
+		spread("draftkings", -4.5, start.Add(6*time.Second)),
+		spread("betmgm", -4, start.Add(7*time.Second)),
+	})
+
+	if len(signals) != 1 || signals[0].Direction != "toward" {
+		t.Fatalf("expected steam toward the favorite, got %+v", signals)
+	}
+}
@@ -0,0 +1,33 @@
+package streamgroups_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/streamgroups"
+)
+
+func TestParseStart(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "beginning", want: "0-0"},
+		{input: "0", want: "0-0"},
+		{input: "1700000000000-5", want: "1700000000000-5"},
+		{input: "1700000000000", want: "1700000000000-0"},
+		{input: "2025-01-02T03:04:05Z", want: "1735787045000-0"},
+		{input: "yesterday", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := streamgroups.ParseStart(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseStart(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseStart(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
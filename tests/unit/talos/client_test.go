@@ -0,0 +1,125 @@
+package talos_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/normalize"
+	"github.com/XavierBriggs/Mercury/internal/talos"
+)
+
+func TestBatchOpenGamePages_BoundedConcurrency(t *testing.T) {
+	var inFlight, maxInFlight, total int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		atomic.AddInt32(&total, 1)
+
+		w.Write([]byte(`{"all_ok": true, "any_ok": true, "results": {}}`))
+	}))
+	defer server.Close()
+
+	client := talos.NewClient(talos.Config{BaseURL: server.URL, Enabled: true, Books: []string{"fanduel"}})
+
+	pages := make([]talos.GamePage, 12)
+	for i := range pages {
+		pages[i] = talos.GamePage{
+			HomeTeam:     "Los Angeles Lakers",
+			AwayTeam:     "Boston Celtics",
+			Sport:        "basketball_nba",
+			CommenceTime: time.Now().Add(24 * time.Hour),
+		}
+	}
+
+	errs := client.BatchOpenGamePages(context.Background(), pages, 3)
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("page %d: unexpected error: %v", i, err)
+		}
+	}
+	if total != 12 {
+		t.Errorf("expected 12 requests, got %d", total)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("expected at most 3 concurrent requests, got %d", maxInFlight)
+	}
+}
+
+func TestBatchOpenGamePages_ReportsPerPageErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"all_ok": false, "any_ok": false, "results": {}}`))
+	}))
+	defer server.Close()
+
+	client := talos.NewClient(talos.Config{BaseURL: server.URL, Enabled: true})
+
+	errs := client.BatchOpenGamePages(context.Background(), []talos.GamePage{
+		{HomeTeam: "A", AwayTeam: "B", Sport: "basketball_nba", CommenceTime: time.Now()},
+	}, 2)
+
+	if len(errs) != 1 || errs[0] == nil {
+		t.Errorf("expected an error when no bots warmed the page, got %v", errs)
+	}
+}
+
+func TestOpenGamePage_SendsCanonicalTeamNames(t *testing.T) {
+	var got talos.OpenGamePageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"all_ok": true, "any_ok": true, "results": {}}`))
+	}))
+	defer server.Close()
+
+	teamNames := normalize.NewRegistry()
+	teamNames.SetAliases("basketball_nba", map[string]string{"LA Lakers": "Los Angeles Lakers"})
+
+	client := talos.NewClient(talos.Config{BaseURL: server.URL, Enabled: true, Books: []string{"fanduel"}})
+	client.SetTeamNames(teamNames)
+
+	err := client.OpenGamePage(context.Background(), "LA Lakers", "Boston Celtics", "basketball_nba", time.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("open game page: %v", err)
+	}
+	if got.Team2 != "Los Angeles Lakers" || got.Team1 != "Boston Celtics" {
+		t.Errorf("expected canonical names, got team1=%q team2=%q", got.Team1, got.Team2)
+	}
+}
+
+func TestCloseGamePageForEvent_UsesCanonicalGameKey(t *testing.T) {
+	var got talos.CloseGamePageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"all_ok": true, "any_ok": true, "results": {}}`))
+	}))
+	defer server.Close()
+
+	teamNames := normalize.NewRegistry()
+	teamNames.SetAliases("basketball_nba", map[string]string{"LA Lakers": "Los Angeles Lakers"})
+
+	client := talos.NewClient(talos.Config{BaseURL: server.URL, Enabled: true, Books: []string{"fanduel"}})
+	client.SetTeamNames(teamNames)
+
+	commence := time.Date(2025, 1, 16, 3, 0, 0, 0, time.UTC) // Jan 15 evening US time
+	if err := client.CloseGamePageForEvent(context.Background(), "LA Lakers", "Boston Celtics", "basketball_nba", commence); err != nil {
+		t.Fatalf("close game page: %v", err)
+	}
+
+	if !strings.Contains(got.GameKey, ":boston_celtics:los_angeles_lakers:game") {
+		t.Errorf("expected the game key to use canonical slugs, got %q", got.GameKey)
+	}
+}
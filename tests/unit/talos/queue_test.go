@@ -0,0 +1,265 @@
+package talos_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/talos"
+	"github.com/XavierBriggs/Mercury/pkg/testutil"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisClient returns a client answered by mem
+func redisClient(t *testing.T, mem *testutil.MemRedis) *redis.Client {
+	t.Helper()
+	c := mem.Client()
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func decodeJobs(t *testing.T, raws []string) []talos.WarmJob {
+	t.Helper()
+	jobs := make([]talos.WarmJob, len(raws))
+	for i, raw := range raws {
+		if err := json.Unmarshal([]byte(raw), &jobs[i]); err != nil {
+			t.Fatalf("decode job %q: %v", raw, err)
+		}
+	}
+	return jobs
+}
+
+func encodeJob(t *testing.T, job talos.WarmJob) string {
+	t.Helper()
+	data, err := json.Marshal(job)
+	if err != nil {
+		t.Fatalf("encode job: %v", err)
+	}
+	return string(data)
+}
+
+// waitFor polls cond until it holds (the queue worker runs in its own goroutine)
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// talosServer answers open-game-page with the given any_ok
+func talosServer(t *testing.T, anyOK bool) *talos.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"all_ok": %v, "any_ok": %v, "results": {}}`, anyOK, anyOK)
+	}))
+	t.Cleanup(server.Close)
+	return talos.NewClient(talos.Config{BaseURL: server.URL, Enabled: true, Books: []string{"fanduel"}})
+}
+
+var queueStart = time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+func testJob(eventID string) talos.WarmJob {
+	return talos.WarmJob{
+		EventID:      eventID,
+		SportKey:     "basketball_nba",
+		HomeTeam:     "Los Angeles Lakers",
+		AwayTeam:     "Boston Celtics",
+		CommenceTime: queueStart.Add(24 * time.Hour),
+	}
+}
+
+func TestWarmQueue_EnqueueStampsAndPersistsJobs(t *testing.T) {
+	mem := testutil.NewMemRedis()
+	queue := talos.NewWarmQueue(redisClient(t, mem), talosServer(t, true), talos.WarmQueueConfig{ConsumerID: "replica-a"})
+	queue.SetClock(clock.NewFake(queueStart))
+
+	stamped := testJob("evt-2")
+	stamped.EnqueuedAt = queueStart.Add(-time.Hour)
+	if err := queue.Enqueue(context.Background(), testJob("evt-1"), stamped); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	jobs := decodeJobs(t, mem.List("talos:warm:queue"))
+	if len(jobs) != 2 || jobs[0].EventID != "evt-1" || jobs[1].EventID != "evt-2" {
+		t.Fatalf("expected evt-1 then evt-2 queued, got %+v", jobs)
+	}
+	if !jobs[0].EnqueuedAt.Equal(queueStart) {
+		t.Errorf("expected EnqueuedAt stamped %v, got %v", queueStart, jobs[0].EnqueuedAt)
+	}
+	if !jobs[1].EnqueuedAt.Equal(stamped.EnqueuedAt) {
+		t.Errorf("expected existing EnqueuedAt kept, got %v", jobs[1].EnqueuedAt)
+	}
+
+	stats, err := queue.Stats(context.Background())
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Pending != 2 {
+		t.Errorf("expected 2 pending, got %d", stats.Pending)
+	}
+}
+
+func TestWarmQueue_WarmedJobIsRecordedOpen(t *testing.T) {
+	mem := testutil.NewMemRedis()
+	fake := clock.NewFake(queueStart)
+	queue := talos.NewWarmQueue(redisClient(t, mem), talosServer(t, true), talos.WarmQueueConfig{
+		ConsumerID: "replica-a",
+		RateLimit:  time.Second,
+		StatsEvery: time.Hour,
+	})
+	queue.SetClock(fake)
+
+	ctx := context.Background()
+	if err := queue.Enqueue(ctx, testJob("evt-1")); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	queue.Start(ctx)
+	defer queue.Stop()
+	fake.BlockUntil(2)
+
+	fake.Advance(time.Second)
+	waitFor(t, "page recorded open", func() bool {
+		pages, _ := queue.OpenPages(ctx)
+		return len(pages) == 1
+	})
+	waitFor(t, "job acknowledged", func() bool { return len(mem.List("talos:warm:processing:replica-a")) == 0 })
+
+	if n := len(mem.List("talos:warm:queue")); n != 0 {
+		t.Errorf("expected an empty queue, got %d job(s)", n)
+	}
+	if n := len(mem.ZSet("talos:warm:retry")); n != 0 {
+		t.Errorf("expected no retries, got %d", n)
+	}
+}
+
+func TestWarmQueue_RetriesWithBackoffThenDeadLetters(t *testing.T) {
+	mem := testutil.NewMemRedis()
+	fake := clock.NewFake(queueStart)
+	queue := talos.NewWarmQueue(redisClient(t, mem), talosServer(t, false), talos.WarmQueueConfig{
+		ConsumerID:  "replica-a",
+		MaxAttempts: 3,
+		BaseBackoff: 30 * time.Second,
+		MaxBackoff:  45 * time.Second,
+		RateLimit:   time.Second,
+		StatsEvery:  time.Hour,
+	})
+	queue.SetClock(fake)
+
+	ctx := context.Background()
+	if err := queue.Enqueue(ctx, testJob("evt-1")); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	queue.Start(ctx)
+	defer queue.Stop()
+	fake.BlockUntil(2)
+
+	// retried reports the single retry entry once it has the given attempt count
+	retried := func(attempts int) (talos.WarmJob, float64, bool) {
+		for raw, score := range mem.ZSet("talos:warm:retry") {
+			job := decodeJobs(t, []string{raw})[0]
+			if job.Attempts == attempts {
+				return job, score, true
+			}
+		}
+		return talos.WarmJob{}, 0, false
+	}
+
+	// First failure at 1s: retry after the base backoff
+	fake.Advance(time.Second)
+	waitFor(t, "first retry", func() bool { _, _, ok := retried(1); return ok })
+	job, score, _ := retried(1)
+	if want := queueStart.Add(31 * time.Second).Unix(); int64(score) != want {
+		t.Errorf("first retry due at %d, want %d", int64(score), want)
+	}
+	if job.LastError == "" {
+		t.Error("expected the failure recorded on the job")
+	}
+
+	// Second failure at 31s: doubled backoff, capped at MaxBackoff
+	fake.Advance(30 * time.Second)
+	waitFor(t, "second retry", func() bool { _, _, ok := retried(2); return ok })
+	_, score, _ = retried(2)
+	if want := queueStart.Add(76 * time.Second).Unix(); int64(score) != want {
+		t.Errorf("second retry due at %d, want %d", int64(score), want)
+	}
+
+	// Third failure at 76s exhausts MaxAttempts
+	fake.Advance(45 * time.Second)
+	waitFor(t, "dead letter", func() bool { return len(mem.List("talos:warm:dead")) == 1 })
+
+	dead := decodeJobs(t, mem.List("talos:warm:dead"))
+	if dead[0].EventID != "evt-1" || dead[0].Attempts != 3 {
+		t.Errorf("expected evt-1 dead after 3 attempts, got %+v", dead[0])
+	}
+	if n := len(mem.ZSet("talos:warm:retry")); n != 0 {
+		t.Errorf("expected no retries left, got %d", n)
+	}
+	if n := len(mem.List("talos:warm:queue")); n != 0 {
+		t.Errorf("expected an empty queue, got %d job(s)", n)
+	}
+}
+
+func TestWarmQueue_StartRecoversOnlyOwnAndExpiredJobs(t *testing.T) {
+	mem := testutil.NewMemRedis()
+	client := redisClient(t, mem)
+	ctx := context.Background()
+
+	// replica-b is alive; replica-c's lease lapsed a minute ago
+	mem.SetList("talos:warm:processing:replica-a", encodeJob(t, testJob("own")))
+	mem.SetList("talos:warm:processing:replica-b", encodeJob(t, testJob("live-replica")))
+	mem.SetList("talos:warm:processing:replica-c", encodeJob(t, testJob("crashed-replica")))
+	mem.SetList("talos:warm:processing", encodeJob(t, testJob("legacy")))
+	mem.SetZSet("talos:warm:consumers", map[string]float64{
+		"replica-b": float64(queueStart.Add(time.Minute).Unix()),
+		"replica-c": float64(queueStart.Add(-time.Minute).Unix()),
+	})
+
+	fake := clock.NewFake(queueStart)
+	queue := talos.NewWarmQueue(client, talosServer(t, true), talos.WarmQueueConfig{
+		ConsumerID: "replica-a",
+		RateLimit:  time.Second,
+		StatsEvery: time.Hour,
+	})
+	queue.SetClock(fake)
+	queue.Start(ctx)
+	defer queue.Stop()
+
+	var requeued []string
+	for _, job := range decodeJobs(t, mem.List("talos:warm:queue")) {
+		requeued = append(requeued, job.EventID)
+	}
+	sort.Strings(requeued)
+	if want := []string{"crashed-replica", "legacy", "own"}; fmt.Sprint(requeued) != fmt.Sprint(want) {
+		t.Errorf("expected %v requeued, got %v", want, requeued)
+	}
+
+	if live := mem.List("talos:warm:processing:replica-b"); len(live) != 1 {
+		t.Errorf("expected the live replica's job left alone, got %v", live)
+	}
+
+	consumers := mem.ZSet("talos:warm:consumers")
+	if _, ok := consumers["replica-c"]; ok {
+		t.Error("expected the expired consumer's lease removed")
+	}
+	if expiry, ok := consumers["replica-a"]; !ok || int64(expiry) != queueStart.Add(2*time.Minute).Unix() {
+		t.Errorf("expected replica-a to hold a lease until %v, got %v (present %v)", queueStart.Add(2*time.Minute).Unix(), int64(expiry), ok)
+	}
+
+	stats, err := queue.Stats(ctx)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Pending != 3 || stats.Processing != 1 {
+		t.Errorf("expected 3 pending and 1 processing, got %+v", stats)
+	}
+}
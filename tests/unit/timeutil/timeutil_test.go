@@ -0,0 +1,193 @@
+package timeutil_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/timeutil"
+)
+
+func TestParseVendorTime(t *testing.T) {
+	want := time.Date(2025, 1, 15, 3, 30, 0, 0, time.UTC)
+
+	cases := []string{
+		"2025-01-15T03:30:00Z",
+		"2025-01-15T03:30:00.000Z",
+		"2025-01-14T22:30:00-05:00",
+	}
+
+	for _, value := range cases {
+		got, err := timeutil.ParseVendorTime(value)
+		if err != nil {
+			t.Fatalf("parse %q: %v", value, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("parse %q: expected %v, got %v", value, want, got)
+		}
+		if got.Location() != time.UTC {
+			t.Errorf("parse %q: expected UTC location, got %v", value, got.Location())
+		}
+	}
+}
+
+func TestParseVendorTimeWithoutZone(t *testing.T) {
+	defer timeutil.SetVendorLocation("UTC")
+
+	got, err := timeutil.ParseVendorTime("2025-01-15T03:30:00")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !got.Equal(time.Date(2025, 1, 15, 3, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected zoneless time to default to UTC, got %v", got)
+	}
+
+	if err := timeutil.SetVendorLocation("America/New_York"); err != nil {
+		t.Fatalf("set vendor location: %v", err)
+	}
+
+	got, err = timeutil.ParseVendorTime("2025-01-14T22:30:00")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !got.Equal(time.Date(2025, 1, 15, 3, 30, 0, 0, time.UTC)) {
+		t.Errorf("expected zoneless time in vendor zone, got %v", got)
+	}
+	if got.Location() != time.UTC {
+		t.Errorf("expected UTC location, got %v", got.Location())
+	}
+}
+
+func TestFormatVendorTime(t *testing.T) {
+	eastern := time.FixedZone("EST", -5*3600)
+	got := timeutil.FormatVendorTime(time.Date(2025, 1, 15, 19, 30, 0, 999, eastern))
+	if got != "2025-01-16T00:30:00Z" {
+		t.Errorf("FormatVendorTime = %s, want 2025-01-16T00:30:00Z", got)
+	}
+}
+
+func TestParseVendorTimeInvalid(t *testing.T) {
+	if _, err := timeutil.ParseVendorTime(""); err == nil {
+		t.Error("expected error for empty timestamp")
+	}
+	if _, err := timeutil.ParseVendorTime("tomorrow"); err == nil {
+		t.Error("expected error for garbage timestamp")
+	}
+
+	fallback := time.Date(2025, 1, 15, 12, 0, 0, 0, time.FixedZone("X", 3600))
+	got := timeutil.ParseVendorTimeOr("nope", fallback)
+	if !got.Equal(fallback) || got.Location() != time.UTC {
+		t.Errorf("expected fallback in UTC, got %v", got)
+	}
+}
+
+func TestSetVendorLocationInvalid(t *testing.T) {
+	if err := timeutil.SetVendorLocation("Not/AZone"); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
+
+func TestLocalDate(t *testing.T) {
+	// 10:30pm ET tipoff on Jan 14 is Jan 15 in UTC
+	tipoff := time.Date(2025, 1, 15, 3, 30, 0, 0, time.UTC)
+
+	if got := timeutil.LocalDate(tipoff, "basketball_nba"); got != "2025-01-14" {
+		t.Errorf("expected 2025-01-14, got %s", got)
+	}
+	if got := timeutil.LocalDateCompact(tipoff, "basketball_nba"); got != "20250114" {
+		t.Errorf("expected 20250114, got %s", got)
+	}
+}
+
+func TestSlateBounds(t *testing.T) {
+	tipoff := time.Date(2025, 1, 15, 3, 30, 0, 0, time.UTC)
+
+	start, end := timeutil.SlateBounds(tipoff, "basketball_nba")
+
+	wantStart := time.Date(2025, 1, 14, 5, 0, 0, 0, time.UTC)
+	wantEnd := time.Date(2025, 1, 15, 5, 0, 0, 0, time.UTC)
+
+	if !start.Equal(wantStart) || !end.Equal(wantEnd) {
+		t.Errorf("expected [%v, %v), got [%v, %v)", wantStart, wantEnd, start, end)
+	}
+	if start.Location() != time.UTC || end.Location() != time.UTC {
+		t.Error("expected slate bounds in UTC")
+	}
+}
+
+// TestNoLocalTimeHandling scans production code for time handling that bypasses timeutil
+// Local times must never be stored, and vendor timestamps must be parsed via ParseVendorTime
+func TestNoLocalTimeHandling(t *testing.T) {
+	root := filepath.Join("..", "..", "..")
+	dirs := []string{"internal", "adapters", "pkg", "cmd", "sports"}
+	allowed := filepath.Join("internal", "timeutil")
+
+	fset := token.NewFileSet()
+
+	for _, dir := range dirs {
+		err := filepath.Walk(filepath.Join(root, dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+
+			rel, _ := filepath.Rel(root, path)
+			if strings.HasPrefix(rel, allowed) {
+				return nil
+			}
+
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+
+			ast.Inspect(file, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+
+				pos := fset.Position(sel.Pos())
+
+				if sel.Sel.Name == "Local" {
+					if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "time" {
+						t.Errorf("%s:%d: time.Local used; store UTC via timeutil", rel, pos.Line)
+					}
+				}
+
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "time" {
+					if sel.Sel.Name == "Parse" || sel.Sel.Name == "ParseInLocation" {
+						t.Errorf("%s:%d: time.%s used; parse with timeutil.ParseVendorTime", rel, pos.Line, sel.Sel.Name)
+					}
+				}
+
+				return true
+			})
+
+			// Method calls like t.Local() convert to the host zone
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if ok && sel.Sel.Name == "Local" && len(call.Args) == 0 {
+					t.Errorf("%s:%d: .Local() used; store UTC via timeutil", rel, fset.Position(sel.Pos()).Line)
+				}
+				return true
+			})
+
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("walk %s: %v", dir, err)
+		}
+	}
+}
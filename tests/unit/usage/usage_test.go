@@ -0,0 +1,25 @@
+package usage_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/usage"
+)
+
+func TestSummarize_TotalsPerSportHighestFirst(t *testing.T) {
+	rows := []usage.DailyUsage{
+		{SportKey: "icehockey_nhl", Kind: "odds", MarketKey: "h2h", Credits: 30},
+		{SportKey: "basketball_nba", Kind: "odds", MarketKey: "h2h", Credits: 40},
+		{SportKey: "basketball_nba", Kind: "event-odds", MarketKey: "player_points", Credits: 80},
+		{SportKey: "basketball_nba", Kind: "events", Credits: 0},
+		{SportKey: "americanfootball_nfl", Kind: "odds", MarketKey: "h2h", Credits: 30},
+	}
+
+	want := "basketball_nba=120, americanfootball_nfl=30, icehockey_nhl=30"
+	if got := usage.Summarize(rows); got != want {
+		t.Errorf("Summarize = %q, want %q", got, want)
+	}
+	if got := usage.Summarize(nil); got != "" {
+		t.Errorf("expected an empty summary, got %q", got)
+	}
+}
@@ -0,0 +1,327 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/webhooks"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func spread(book string, point float64, price int) models.RawOdds {
+	return models.RawOdds{
+		EventID:     "e1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "spreads",
+		BookKey:     book,
+		OutcomeName: "Lakers",
+		Price:       price,
+		Point:       &point,
+		ReceivedAt:  time.Date(2025, 1, 15, 19, 0, 0, 0, time.UTC),
+	}
+}
+
+func moneyline(book string, price int) models.RawOdds {
+	return models.RawOdds{
+		EventID:     "e1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "h2h",
+		BookKey:     book,
+		OutcomeName: "Lakers",
+		Price:       price,
+	}
+}
+
+func mustRules(t *testing.T, spec string) []webhooks.Rule {
+	t.Helper()
+	rules, err := webhooks.ParseRules(spec)
+	if err != nil {
+		t.Fatalf("ParseRules(%q): %v", spec, err)
+	}
+	return rules
+}
+
+func TestParseRules(t *testing.T) {
+	rules := mustRules(t, "spreads:point>=1.5, h2h:PRICE>=20,,*:price>=50")
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(rules))
+	}
+	want := []string{"spreads:point>=1.5", "h2h:price>=20", "*:price>=50"}
+	for i, rule := range rules {
+		if rule.String() != want[i] {
+			t.Errorf("rule %d = %s, want %s", i, rule, want[i])
+		}
+	}
+
+	for _, spec := range []string{"spreads", "spreads:point", "spreads:edge>=1", "spreads:point>=0", "spreads:point>=x", ":point>=1"} {
+		if _, err := webhooks.ParseRules(spec); err == nil {
+			t.Errorf("ParseRules(%q) should fail", spec)
+		}
+	}
+
+	if _, err := webhooks.ParseRules(webhooks.DefaultRules); err != nil {
+		t.Errorf("default rules: %v", err)
+	}
+}
+
+func TestPriceCents(t *testing.T) {
+	tests := []struct {
+		from, to int
+		want     int
+	}{
+		{-110, -105, 5},
+		{-110, -130, 20},
+		{-105, 105, 10},
+		{-110, 110, 20},
+		{150, 120, 30},
+		{120, -120, 40},
+	}
+	for _, tt := range tests {
+		if got := webhooks.PriceCents(tt.from, tt.to); got != tt.want {
+			t.Errorf("PriceCents(%d, %d) = %d, want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestObserveSpreadDrift(t *testing.T) {
+	n := webhooks.NewNotifier(webhooks.Config{Rules: mustRules(t, "spreads:point>=1.5")})
+
+	// The first quote anchors; two one-point steps fire once the total reaches 1.5
+	if alerts := n.Observe([]models.RawOdds{spread("fanduel", -3.5, -110)}); len(alerts) != 0 {
+		t.Fatalf("first quote should only anchor, got %d alerts", len(alerts))
+	}
+	if alerts := n.Observe([]models.RawOdds{spread("fanduel", -4.5, -110)}); len(alerts) != 0 {
+		t.Fatalf("1 point move should not fire, got %d alerts", len(alerts))
+	}
+
+	alerts := n.Observe([]models.RawOdds{spread("fanduel", -5, -110), spread("draftkings", -5, -110)})
+	if len(alerts) != 1 {
+		t.Fatalf("expected 1 alert (draftkings only anchors), got %d", len(alerts))
+	}
+	alert := alerts[0]
+	if alert.BookKey != "fanduel" || *alert.OldPoint != -3.5 || *alert.NewPoint != -5 || alert.PointMove != 1.5 {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+	if len(alert.Rules) != 1 || alert.Rules[0] != "spreads:point>=1.5" {
+		t.Errorf("rules = %v", alert.Rules)
+	}
+
+	// The alert re-anchors at -5
+	if alerts := n.Observe([]models.RawOdds{spread("fanduel", -6, -110)}); len(alerts) != 0 {
+		t.Errorf("1 point move from the new anchor should not fire, got %d alerts", len(alerts))
+	}
+}
+
+func TestObservePriceRules(t *testing.T) {
+	n := webhooks.NewNotifier(webhooks.Config{Rules: mustRules(t, "h2h:price>=20,spreads:price>=20")})
+
+	n.Observe([]models.RawOdds{moneyline("fanduel", -110), spread("fanduel", -3.5, -110)})
+
+	alerts := n.Observe([]models.RawOdds{moneyline("fanduel", 110)})
+	if len(alerts) != 1 || alerts[0].OldPrice != -110 || alerts[0].NewPrice != 110 || alerts[0].PriceMoveCents != 20 {
+		t.Fatalf("expected a 20 cent alert, got %+v", alerts)
+	}
+
+	// Prices at a different line don't compare: -110 at -3.5 to -130 at -4 is no price move
+	if alerts := n.Observe([]models.RawOdds{spread("fanduel", -4, -130)}); len(alerts) != 0 {
+		t.Fatalf("price at a new line should not fire, got %+v", alerts)
+	}
+	// ... but it anchors prices at the new line
+	alerts = n.Observe([]models.RawOdds{spread("fanduel", -4, -150)})
+	if len(alerts) != 1 || alerts[0].OldPrice != -130 || alerts[0].PriceMoveCents != 20 {
+		t.Fatalf("expected a 20 cent alert at -4, got %+v", alerts)
+	}
+}
+
+func TestEvict(t *testing.T) {
+	n := webhooks.NewNotifier(webhooks.Config{Rules: mustRules(t, "h2h:price>=20")})
+	n.Observe([]models.RawOdds{moneyline("fanduel", -110)})
+
+	n.HandleEventStatusChanged(context.Background(), bus.EventStatusChanged{EventID: "e1", NewStatus: "completed"})
+
+	if alerts := n.Observe([]models.RawOdds{moneyline("fanduel", 150)}); len(alerts) != 0 {
+		t.Errorf("evicted outcome should re-anchor, got %d alerts", len(alerts))
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	body := []byte(`{"id":"abc"}`)
+	signature := webhooks.Sign("secret", "1736950000", body)
+
+	if len(signature) != len("sha256=")+64 || signature[:7] != "sha256=" {
+		t.Fatalf("unexpected signature format: %s", signature)
+	}
+	if !webhooks.Verify("secret", "1736950000", body, signature) {
+		t.Error("signature should verify")
+	}
+	if webhooks.Verify("other", "1736950000", body, signature) {
+		t.Error("wrong secret should not verify")
+	}
+	if webhooks.Verify("secret", "1736950001", body, signature) {
+		t.Error("wrong timestamp should not verify")
+	}
+	if webhooks.Verify("secret", "1736950000", []byte(`{"id":"abd"}`), signature) {
+		t.Error("modified body should not verify")
+	}
+}
+
+type received struct {
+	headers http.Header
+	body    []byte
+}
+
+func TestDeliverySignedWithRetry(t *testing.T) {
+	var mu sync.Mutex
+	var requests []received
+	done := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, received{headers: r.Header.Clone(), body: body})
+		attempt := len(requests)
+		mu.Unlock()
+
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		close(done)
+	}))
+	defer server.Close()
+
+	n := webhooks.NewNotifier(webhooks.Config{
+		URLs:       []string{server.URL},
+		Secret:     "secret",
+		Rules:      mustRules(t, "spreads:point>=1.5"),
+		MaxRetries: 2,
+	})
+	n.Start(context.Background())
+
+	n.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{Odds: []models.RawOdds{spread("fanduel", -3.5, -110)}})
+	n.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{Odds: []models.RawOdds{spread("fanduel", -1.5, -110)}})
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery was not retried")
+	}
+	n.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(requests))
+	}
+	if delivered, failed, dropped := n.Stats(); delivered != 1 || failed != 0 || dropped != 0 {
+		t.Errorf("stats = %d delivered, %d failed, %d dropped", delivered, failed, dropped)
+	}
+
+	for _, req := range requests {
+		timestamp := req.headers.Get(webhooks.HeaderTimestamp)
+		if !webhooks.Verify("secret", timestamp, req.body, req.headers.Get(webhooks.HeaderSignature)) {
+			t.Errorf("invalid signature %q", req.headers.Get(webhooks.HeaderSignature))
+		}
+		if req.headers.Get(webhooks.HeaderEvent) != webhooks.EventLineMove {
+			t.Errorf("event header = %q", req.headers.Get(webhooks.HeaderEvent))
+		}
+	}
+	if requests[0].headers.Get(webhooks.HeaderDelivery) != requests[1].headers.Get(webhooks.HeaderDelivery) {
+		t.Error("retries should keep the delivery ID")
+	}
+
+	var alert webhooks.Alert
+	if err := json.Unmarshal(requests[1].body, &alert); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if alert.ID != requests[1].headers.Get(webhooks.HeaderDelivery) || alert.PointMove != 2 || alert.MarketKey != "spreads" {
+		t.Errorf("unexpected payload: %+v", alert)
+	}
+}
+
+func TestDeliveryClientErrorNotRetried(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	n := webhooks.NewNotifier(webhooks.Config{
+		URLs:  []string{server.URL},
+		Rules: mustRules(t, "h2h:price>=20"),
+	})
+	n.Start(context.Background())
+
+	n.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{Odds: []models.RawOdds{moneyline("fanduel", -110)}})
+	n.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{Odds: []models.RawOdds{moneyline("fanduel", 120)}})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, failed, _ := n.Stats(); failed == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("delivery did not fail")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	n.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 1 {
+		t.Errorf("4xx should not be retried, got %d attempts", attempts)
+	}
+}
+
+func TestStopDrainsQueuedDeliveries(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := webhooks.NewNotifier(webhooks.Config{
+		URLs:  []string{server.URL},
+		Rules: mustRules(t, "h2h:price>=20"),
+	})
+	n.Start(context.Background())
+
+	// More alerts than workers, so some are still queued when Stop is called
+	books := []string{"fanduel", "draftkings", "betmgm", "caesars", "pinnacle", "circa", "bovada", "betrivers"}
+	for _, book := range books {
+		n.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{Odds: []models.RawOdds{moneyline(book, -110)}})
+		n.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{Odds: []models.RawOdds{moneyline(book, 120)}})
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		n.Stop()
+		close(stopped)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Stop did not return")
+	}
+
+	if delivered, failed, dropped := n.Stats(); delivered != int64(len(books)) || failed != 0 || dropped != 0 {
+		t.Errorf("stats = %d delivered, %d failed, %d dropped", delivered, failed, dropped)
+	}
+}
@@ -0,0 +1,147 @@
+package writer_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/clock"
+	"github.com/XavierBriggs/Mercury/internal/leader"
+	"github.com/XavierBriggs/Mercury/internal/writer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// dbOpens counts connections opened through the "writer-fenced" driver: a writer
+// that commits anything has to open one
+var dbOpens atomic.Int64
+
+type fencedDriver struct{}
+
+func (fencedDriver) Open(string) (driver.Conn, error) {
+	dbOpens.Add(1)
+	return nil, errors.New("no database in tests")
+}
+
+func init() {
+	sql.Register("writer-fenced", fencedDriver{})
+}
+
+// failingLease acquires the lease, then fails every renewal as if Redis were down
+type failingLease struct {
+	renewals atomic.Int64
+}
+
+func (h *failingLease) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("no network in tests")
+	}
+}
+
+func (h *failingLease) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		switch cmd.Name() {
+		case "set":
+			cmd.(*redis.BoolCmd).SetVal(true)
+			return nil
+		case "evalsha", "eval":
+			h.renewals.Add(1)
+		}
+		err := errors.New("connection refused")
+		cmd.SetErr(err)
+		return err
+	}
+}
+
+func (h *failingLease) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+// waitFor polls cond until it holds (the elector runs in its own goroutine)
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWriter_DropsBufferOnceLeadershipIsLost(t *testing.T) {
+	db, err := sql.Open("writer-fenced", "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	fake := clock.NewFake(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC))
+	hook := &failingLease{}
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	redisClient.AddHook(hook)
+	defer redisClient.Close()
+
+	const ttl = 15 * time.Second
+	elector := leader.NewElector(redisClient, "replica-a")
+	elector.SetTTL(ttl)
+	elector.SetClock(fake)
+	if err := elector.Acquire(context.Background()); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	w := writer.NewWriter(db, redisClient)
+	w.SetClock(fake)
+	w.SetLeaderCheck(elector.IsLeader)
+
+	// A batch sits in the buffer while this replica still leads
+	odds := []models.RawOdds{{EventID: "e1", SportKey: "basketball_nba", BookKey: "fanduel", OutcomeName: "Lakers", Price: -110}}
+	if err := w.Write(context.Background(), odds); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go elector.Start(ctx)
+	fake.BlockUntil(1)
+
+	// Every renewal fails: the first at ttl/3, then retries every ttl/6 until Lost fires
+	lost := func() bool {
+		select {
+		case <-elector.Lost():
+			return true
+		default:
+			return false
+		}
+	}
+	for step := ttl / 3; !lost(); step = ttl / 6 {
+		renewals := hook.renewals.Load()
+		fake.Advance(step)
+		waitFor(t, "renewal attempt", func() bool { return hook.renewals.Load() > renewals })
+		waitFor(t, "retry or loss", func() bool { return lost() || fake.Waiters() == 2 })
+	}
+
+	// Shutdown cancels the writer, whose final flush must not commit the batch
+	runCtx, stop := context.WithCancel(context.Background())
+	stop()
+	if err := w.Run(runCtx); err != nil {
+		t.Errorf("run: %v", err)
+	}
+	if err := w.Flush(context.Background()); err != nil {
+		t.Errorf("flush: %v", err)
+	}
+
+	// Running polls are refused too
+	if err := w.WriteWithEvents(context.Background(), []models.Event{{EventID: "e2"}}, odds); !errors.Is(err, writer.ErrNotLeader) {
+		t.Errorf("expected ErrNotLeader, got %v", err)
+	}
+
+	if n := dbOpens.Load(); n != 0 {
+		t.Errorf("expected nothing written after leadership was lost, the database was used %d time(s)", n)
+	}
+}
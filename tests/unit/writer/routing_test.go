@@ -0,0 +1,72 @@
+package writer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/writer"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+func TestSplitLive(t *testing.T) {
+	odds := []models.RawOdds{
+		{EventID: "pre", OutcomeName: "A"},
+		{EventID: "live", OutcomeName: "B"},
+		{EventID: "pre", OutcomeName: "C"},
+		{EventID: "live", OutcomeName: "D"},
+	}
+	preMatch, live := writer.SplitLive(odds, func(eventID string) bool { return eventID == "live" })
+
+	if len(preMatch) != 2 || preMatch[0].OutcomeName != "A" || preMatch[1].OutcomeName != "C" {
+		t.Errorf("unexpected pre-match odds %+v", preMatch)
+	}
+	if len(live) != 2 || live[0].OutcomeName != "B" || live[1].OutcomeName != "D" {
+		t.Errorf("unexpected live odds %+v", live)
+	}
+}
+
+func TestStreamKey(t *testing.T) {
+	tests := []struct {
+		status      string
+		liveStreams bool
+		want        string
+	}{
+		{models.EventStatusUpcoming, true, "odds.raw.basketball_nba"},
+		{models.EventStatusLive, true, "odds.live.basketball_nba"},
+		// Off by default: live odds stay on the one stream
+		{models.EventStatusLive, false, "odds.raw.basketball_nba"},
+		{models.EventStatusCompleted, true, "odds.raw.basketball_nba"},
+	}
+	for _, tt := range tests {
+		if got := writer.StreamKey("basketball_nba", tt.status, tt.liveStreams); got != tt.want {
+			t.Errorf("StreamKey(%s, %v) = %s, want %s", tt.status, tt.liveStreams, got, tt.want)
+		}
+	}
+}
+
+func TestWriter_TracksLiveEvents(t *testing.T) {
+	w := writer.NewWriter(nil, nil)
+	if w.IsLive("e1") {
+		t.Fatal("expected an unseen event not to be live")
+	}
+
+	// Buffered odds are routed by the last status seen, so Flush follows tip-off
+	w.HandleEventStatusChanged(context.Background(), bus.EventStatusChanged{
+		EventID: "e1", OldStatus: models.EventStatusUpcoming, NewStatus: models.EventStatusLive,
+	})
+	if !w.IsLive("e1") {
+		t.Error("expected e1 to be live after going live")
+	}
+	preMatch, live := writer.SplitLive([]models.RawOdds{{EventID: "e1"}, {EventID: "e2"}}, w.IsLive)
+	if len(live) != 1 || live[0].EventID != "e1" || len(preMatch) != 1 {
+		t.Errorf("expected e1 routed live and e2 pre-match, got %+v / %+v", live, preMatch)
+	}
+
+	w.HandleEventStatusChanged(context.Background(), bus.EventStatusChanged{
+		EventID: "e1", OldStatus: models.EventStatusLive, NewStatus: models.EventStatusCompleted,
+	})
+	if w.IsLive("e1") {
+		t.Error("expected e1 not to be live once completed")
+	}
+}
@@ -0,0 +1,147 @@
+package wspush_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/Mercury/internal/bus"
+	"github.com/XavierBriggs/Mercury/internal/writer"
+	"github.com/XavierBriggs/Mercury/internal/wspush"
+	"github.com/XavierBriggs/Mercury/pkg/models"
+)
+
+// testClient is a minimal WebSocket client speaking masked frames
+type testClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dial(t *testing.T, serverURL string) *testClient {
+	t.Helper()
+
+	addr := strings.TrimPrefix(serverURL, "http://")
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+
+	fmt.Fprintf(conn, "GET /ws HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\nSec-WebSocket-Version: 13\r\n\r\n", addr)
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("read handshake: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected 101, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("unexpected Sec-WebSocket-Accept %q", got)
+	}
+
+	return &testClient{conn: conn, reader: reader}
+}
+
+func (c *testClient) send(t *testing.T, v interface{}) {
+	t.Helper()
+
+	payload, _ := json.Marshal(v)
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x81, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatalf("write frame: %v", err)
+	}
+}
+
+func (c *testClient) read(t *testing.T) wspush.ServerMessage {
+	t.Helper()
+
+	c.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var head [2]byte
+	if _, err := io.ReadFull(c.reader, head[:]); err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	length := int(head[1] & 0x7F)
+	if length == 126 {
+		var ext [2]byte
+		io.ReadFull(c.reader, ext[:])
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		t.Fatalf("read payload: %v", err)
+	}
+
+	var msg wspush.ServerMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		t.Fatalf("decode %s: %v", payload, err)
+	}
+	return msg
+}
+
+func TestServer_SubscribeAndFanOut(t *testing.T) {
+	server := wspush.NewServer(":0")
+	httpServer := httptest.NewServer(http.HandlerFunc(server.HandleWebSocket))
+	defer httpServer.Close()
+
+	client := dial(t, httpServer.URL)
+	defer client.conn.Close()
+
+	client.send(t, map[string]interface{}{"action": "subscribe", "sports": []string{"basketball_nba"}, "books": []string{"pinnacle"}})
+	if msg := client.read(t); msg.Type != "subscribed" || msg.Filter == nil || msg.Filter.Books[0] != "pinnacle" {
+		t.Fatalf("unexpected ack: %+v", msg)
+	}
+
+	server.HandleDeltaBatchCommitted(context.Background(), bus.DeltaBatchCommitted{
+		Events: []models.Event{{EventID: "e1", EventStatus: "live"}},
+		Odds: []models.RawOdds{
+			{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "fanduel", OutcomeName: "Lakers", Price: 120},
+			{EventID: "e1", SportKey: "basketball_nba", MarketKey: "h2h", BookKey: "pinnacle", OutcomeName: "Lakers", Price: 125},
+		},
+		CommittedAt: time.Now(),
+	})
+
+	msg := client.read(t)
+	if msg.Type != "delta" || msg.Data == nil {
+		t.Fatalf("expected delta, got %+v", msg)
+	}
+	if msg.Data.BookKey != "pinnacle" || msg.Data.Price != 125 || msg.Data.EventStatus != "live" {
+		t.Errorf("unexpected delta payload: %+v", msg.Data)
+	}
+
+	client.send(t, map[string]string{"action": "bogus"})
+	if msg := client.read(t); msg.Type != "error" {
+		t.Errorf("expected error for unknown action, got %+v", msg)
+	}
+}
+
+func TestFilter_EmptyMatchesEverything(t *testing.T) {
+	var f wspush.Filter
+	if !f.Matches(wspushMessage("basketball_nba", "spreads")) {
+		t.Error("empty filter should match")
+	}
+
+	f.Markets = []string{"totals"}
+	if f.Matches(wspushMessage("basketball_nba", "spreads")) {
+		t.Error("market filter should exclude spreads")
+	}
+}
+
+func wspushMessage(sportKey, marketKey string) writer.StreamMessage {
+	return writer.StreamMessage{SportKey: sportKey, MarketKey: marketKey}
+}